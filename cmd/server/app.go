@@ -0,0 +1,337 @@
+package main
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/db"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/db/postgres"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/db/sqlite"
+
+	repositories2 "github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/repositories"
+	services2 "github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/services"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/adapters/repositories"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/adapters/repositories/apis"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/adapters/services"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/config"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/cache"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/workpool"
+)
+
+// app зависимости, общие для команд: кэш, клиенты API, репозитории и основные сервисы
+type app struct {
+	cfg       *config.Config
+	startedAt time.Time
+
+	cacheClient  cache.Cache
+	meteredCache *cache.MeteredCache
+	redisEnabled bool
+	fetchPool    *workpool.Pool
+
+	moexAPI        *apis.MOEXAPIClient
+	newsAPI        *apis.NewsAPIClient
+	cbrAPI         *apis.CBRClient
+	exchangeRouter *apis.ExchangeRouter
+
+	// Проверки зависимостей для /healthz, /readyz и инструмента health_check
+	healthProbes []services.HealthProbe
+
+	stockRepo     repositories2.StockRepository
+	newsRepo      repositories2.NewsRepository
+	portfolioRepo repositories2.PortfolioRepository
+	watchlistRepo repositories2.WatchlistRepository
+	moodRepo      repositories2.MoodRepository
+	profileRepo   repositories2.CompanyProfileRepository
+	listingRepo   repositories2.ListingRepository
+	eventRepo     repositories2.CorporateEventRepository
+	targetRepo    repositories2.PriceTargetRepository
+	macroRepo     repositories2.MacroRepository
+	indexRepo     repositories2.IndexRepository
+	securityRepo  repositories2.SecurityRepository
+
+	stockService    services2.StockService
+	newsService     services2.NewsService
+	analysisService services2.AnalysisService
+	securityService services2.SecurityService
+
+	// closers закрывают подключения к базе данных в обратном порядке
+	closers []func()
+}
+
+// newApp создает кэш, клиенты API, репозитории и основные сервисы. Ошибки подключения к Redis и базе данных
+// завершают процесс: без них ни одна команда не может работать
+func newApp(ctx context.Context, cfg *config.Config, startedAt time.Time) *app {
+	a := &app{cfg: cfg, startedAt: startedAt}
+
+	// Создаем кэш
+	var cacheClient cache.Cache
+	if cfg.Cache.RedisURI != "" || len(cfg.Cache.RedisAddrs) > 0 {
+		// Если указан адрес Redis, используем Redis для кэширования, а популярные ключи держим и в памяти процесса
+		codec, err := cache.NewCodec(cfg.Cache.Codec, cfg.Cache.Compress)
+		if err != nil {
+			log.Fatalf("Ошибка настройки кэша: %v", err)
+		}
+		redisAddrs := cfg.Cache.RedisAddrs
+		if len(redisAddrs) == 0 {
+			redisAddrs = []string{cfg.Cache.RedisURI}
+		}
+		// NewRedisCache проверяет доступность всех узлов, поэтому сервер не стартует с недоступным кэшем
+		redisCache, err := cache.NewRedisCache(cache.RedisOptions{
+			Mode:             cfg.Cache.RedisMode,
+			Addrs:            redisAddrs,
+			MasterName:       cfg.Cache.RedisMasterName,
+			DB:               cfg.Cache.RedisDB,
+			Username:         cfg.Cache.RedisUsername,
+			Password:         cfg.Cache.RedisPassword,
+			SentinelPassword: cfg.Cache.RedisSentinelPassword,
+			TLS:              cfg.Cache.RedisTLS,
+			TLSSkipVerify:    cfg.Cache.RedisTLSSkipVerify,
+			PoolSize:         cfg.Cache.RedisPoolSize,
+			MinIdleConns:     cfg.Cache.RedisMinIdleConns,
+			DialTimeout:      cfg.Cache.RedisDialTimeout,
+			ReadTimeout:      cfg.Cache.RedisReadTimeout,
+			WriteTimeout:     cfg.Cache.RedisWriteTimeout,
+		}, codec)
+		if err != nil {
+			log.Fatalf("Ошибка инициализации Redis: %v", err)
+		}
+		if cfg.Cache.RedisTLSSkipVerify {
+			log.Printf("Внимание: сертификат Redis не проверяется")
+		}
+		cacheClient = cache.NewTieredCache(redisCache, cfg.Cache.L1Size, cfg.Cache.L1TTL)
+		a.redisEnabled = true
+		a.healthProbes = append(a.healthProbes, services.HealthProbe{Name: "redis", Critical: true, Check: redisCache.HealthCheck})
+		log.Printf("Инициализирован Redis-кэш (%s, TLS: %v): %s (кэш в памяти: %d ключей на %v)",
+			redisCache.Mode(), cfg.Cache.RedisTLS, strings.Join(redisAddrs, ", "), cfg.Cache.L1Size, cfg.Cache.L1TTL)
+	} else {
+		// В противном случае используем in-memory кэш
+		cacheClient = cache.NewInMemoryCache(cfg.Cache.DefaultTTL)
+		log.Printf("Инициализирован in-memory кэш с TTL %v", cfg.Cache.DefaultTTL)
+	}
+
+	// Все ключи получают префикс окружения; персональные данные — дополнительно префикс пользователя
+	cacheClient = cache.NewNamespacedCache(cacheClient, cfg.Cache.Namespace)
+	log.Printf("Пространство имен кэша: %s", cfg.Cache.Namespace)
+
+	// Считаем попадания и промахи по префиксам ключей для /metrics и инструмента get_server_stats
+	a.meteredCache = cache.NewMeteredCache(cacheClient)
+	cacheClient = a.meteredCache
+
+	if cfg.Server.Debug {
+		// В режиме отладки учитываем время обращений к кэшу
+		cacheClient = cache.NewTimedCache(cacheClient)
+	}
+	a.cacheClient = cacheClient
+
+	// Общий лимит одновременных запросов котировок и новостей для всех репозиториев и клиентов API
+	a.fetchPool = workpool.New(cfg.Server.FetchConcurrency)
+
+	// Создаем API-клиенты
+	a.moexAPI = apis.NewMOEXAPIClient(ctx, cfg, cacheClient, a.fetchPool)
+	a.newsAPI = apis.NewNewsAPIClient(cfg, cacheClient)
+	a.cbrAPI = apis.NewCBRClient(cfg, cacheClient)
+
+	// Котировки запрашиваются у биржи, указанной в тикере (MOEX:SBER, NASDAQ:AAPL); тикеры без префикса
+	// относятся к MOEX, котировки бирж США и Европы поставляет Yahoo Finance
+	yahooAPI := apis.NewYahooFinanceClient(cfg, cacheClient, a.fetchPool)
+	// Внешние API необязательны: без них сервер отдает сохраненные данные
+	a.healthProbes = append(a.healthProbes,
+		services.HealthProbe{Name: "moex", Check: a.moexAPI.Ping},
+		services.HealthProbe{Name: "newsapi", Check: a.newsAPI.Ping},
+	)
+	exchangeClients := append([]repositories2.ExchangeClient{a.moexAPI}, yahooAPI.ExchangeClients()...)
+	exchangeRouter, err := apis.NewExchangeRouter(cfg.Exchanges.Enabled, exchangeClients...)
+	if err != nil {
+		log.Fatalf("Ошибка настройки бирж: %v", err)
+	}
+	a.exchangeRouter = exchangeRouter
+	log.Printf("Включенные биржи: %s", strings.Join(exchangeRouter.Exchanges(), ", "))
+
+	// Словарь названий компаний для поиска упоминаний бумаг в новостях: сразу — встроенные названия
+	// и названия из конфигурации, после загрузки списка бумаг MOEX — полный
+	apis.SetTickerDictionary(apis.NewTickerDictionary(nil, cfg.TickerAliases))
+	go func() {
+		dictionary, err := a.moexAPI.LoadTickerDictionary(ctx, cfg.TickerAliases)
+		if err != nil {
+			log.Printf("Не удалось загрузить список бумаг MOEX для поиска упоминаний в новостях: %v", err)
+			return
+		}
+		apis.SetTickerDictionary(dictionary)
+	}()
+
+	// Резюме длинных статей составляются при загрузке и сохраняются вместе с новостью
+	if cfg.Summarizer.Method == config.SummarizerTextRank {
+		apis.SetSummaryOptions(&apis.SummaryOptions{Sentences: cfg.Summarizer.Sentences, MinLength: cfg.Summarizer.MinLength})
+	}
+
+	a.openRepositories(ctx)
+
+	// Справочник бумаг хранится в MongoDB, а с другими базами — в файле
+	if a.securityRepo == nil {
+		a.securityRepo = repositories.NewSecurityRepositoryFile(cfg.Securities.Path)
+	}
+
+	// Создаем сервисы
+	a.securityService = services.NewSecurityService(a.moexAPI, a.securityRepo, cfg.Securities.RefreshInterval)
+	a.stockService = services.NewStockService(a.stockRepo, a.profileRepo, a.indexRepo, a.securityService, cfg.Universes, cfg.Indexes.Codes, a.fetchPool)
+	a.newsService = services.NewNewsService(a.newsRepo, a.moexAPI, apis.NewArticleFetcher(cfg, cacheClient), a.fetchPool)
+	a.analysisService = services.NewAnalysisService(a.stockRepo, a.newsRepo)
+
+	return a
+}
+
+// openRepositories подключается к базе данных, применяет миграции и создает репозитории
+func (a *app) openRepositories(ctx context.Context) {
+	cfg := a.cfg
+
+	switch {
+	case cfg.Database.Driver == config.DriverSQLite:
+		// Открываем встроенную базу данных SQLite
+		sqliteDB, err := sqlite.NewSQLite(cfg.Database.Path)
+		if err != nil {
+			log.Fatalf("Ошибка открытия базы данных SQLite: %v", err)
+		}
+		a.closers = append(a.closers, func() {
+			if err := sqliteDB.Close(); err != nil {
+				log.Printf("Ошибка при закрытии базы данных SQLite: %v", err)
+			}
+		})
+
+		if err := sqliteDB.Migrate(ctx); err != nil {
+			log.Fatalf("Ошибка применения миграций SQLite: %v", err)
+		}
+		a.healthProbes = append(a.healthProbes, services.HealthProbe{Name: "sqlite", Critical: true, Check: sqliteDB.GetDB().PingContext})
+		log.Printf("Открыта база данных SQLite: %s", cfg.Database.Path)
+
+		a.stockRepo = repositories.NewSQLStockRepository(
+			sqliteDB.GetDB(),
+			a.cacheClient,
+			a.exchangeRouter,
+			a.fetchPool,
+			cfg.Cache.StocksTTL,
+			cfg.Cache.StaleTTL,
+			true,
+		)
+
+		a.newsRepo = repositories.NewSQLNewsRepository(
+			sqliteDB.GetDB(),
+			repositories.SQLiteDialect,
+			a.cacheClient,
+			a.newsAPI,
+			cfg.Cache.NewsTTL,
+			true,
+		)
+
+	case cfg.Database.URI == "":
+		log.Printf("ПРЕДУПРЕЖДЕНИЕ: URI базы данных не указан, будет использоваться только кэш")
+		// Здесь должна быть реализация mock-репозиториев
+		log.Fatalf("В текущей версии требуется база данных (MongoDB, PostgreSQL или SQLite) для работы сервера")
+
+	case cfg.Database.Driver == config.DriverPostgres:
+		// Создаем подключение к PostgreSQL
+		pg, err := postgres.NewPostgres(cfg.Database.URI, cfg.Database.Timeout)
+		if err != nil {
+			log.Fatalf("Ошибка подключения к PostgreSQL: %v", err)
+		}
+		a.closers = append(a.closers, func() {
+			if err := pg.Close(); err != nil {
+				log.Printf("Ошибка при закрытии подключения к PostgreSQL: %v", err)
+			}
+		})
+
+		if err := pg.Migrate(ctx); err != nil {
+			log.Fatalf("Ошибка применения миграций PostgreSQL: %v", err)
+		}
+		log.Printf("Подключение к PostgreSQL установлено, миграции применены")
+		a.healthProbes = append(a.healthProbes, services.HealthProbe{Name: "postgres", Critical: true, Check: pg.GetDB().PingContext})
+
+		a.stockRepo = repositories.NewSQLStockRepository(
+			pg.GetDB(),
+			a.cacheClient,
+			a.exchangeRouter,
+			a.fetchPool,
+			cfg.Cache.StocksTTL,
+			cfg.Cache.StaleTTL,
+			true,
+		)
+
+		a.newsRepo = repositories.NewSQLNewsRepository(
+			pg.GetDB(),
+			repositories.PostgresDialect,
+			a.cacheClient,
+			a.newsAPI,
+			cfg.Cache.NewsTTL,
+			true,
+		)
+
+	case cfg.Database.Driver == config.DriverMongo:
+		// Создаем подключение к MongoDB
+		mongoDB, err := db.NewMongoDB(
+			cfg.Database.URI,
+			cfg.Database.Database,
+			cfg.Database.Collection,
+			cfg.Database.Timeout,
+		)
+		if err != nil {
+			log.Fatalf("Ошибка подключения к MongoDB: %v", err)
+		}
+		a.closers = append(a.closers, func() {
+			if err := mongoDB.Close(context.Background()); err != nil {
+				log.Printf("Ошибка при закрытии подключения к MongoDB: %v", err)
+			}
+		})
+		log.Printf("Подключение к MongoDB: %s/%s", cfg.Database.URI, cfg.Database.Database)
+		a.healthProbes = append(a.healthProbes, services.HealthProbe{Name: "mongodb", Critical: true, Check: mongoDB.Ping})
+
+		// Создаем индексы, необходимые запросам репозиториев
+		err = repositories.EnsureMongoIndexes(ctx, mongoDB.GetDatabase(), repositories.MongoIndexOptions{
+			QuotesRetention: cfg.Database.QuotesRetention,
+			NewsRetention:   cfg.Database.NewsRetention,
+		})
+		if err != nil {
+			log.Fatalf("Ошибка создания индексов MongoDB: %v", err)
+		}
+
+		a.stockRepo = repositories.NewStockRepository(
+			mongoDB.GetDatabase(),
+			a.cacheClient,
+			a.exchangeRouter,
+			a.fetchPool,
+			cfg.Cache.StocksTTL,
+			cfg.Cache.StaleTTL,
+			true,
+		)
+
+		a.newsRepo = repositories.NewNewsRepository(
+			mongoDB.GetDatabase(),
+			a.cacheClient,
+			a.newsAPI,
+			cfg.Cache.NewsTTL,
+			true,
+		)
+
+		a.portfolioRepo = repositories.NewPortfolioRepository(mongoDB.GetDatabase())
+		a.watchlistRepo = repositories.NewWatchlistRepository(mongoDB.GetDatabase())
+		a.moodRepo = repositories.NewMoodRepository(mongoDB.GetDatabase())
+		a.profileRepo = repositories.NewCompanyProfileRepository(mongoDB.GetDatabase(), a.moexAPI, a.fetchPool, cfg.Cache.ProfileTTL)
+		a.listingRepo = repositories.NewListingRepository(mongoDB.GetDatabase())
+		a.eventRepo = repositories.NewCorporateEventRepository(mongoDB.GetDatabase())
+		a.targetRepo = repositories.NewPriceTargetRepository(mongoDB.GetDatabase())
+		a.macroRepo = repositories.NewMacroRepository(mongoDB.GetDatabase())
+		a.indexRepo = repositories.NewIndexRepository(mongoDB.GetDatabase())
+		a.securityRepo = repositories.NewSecurityRepository(mongoDB.GetDatabase())
+
+	default:
+		log.Fatalf("Неизвестный драйвер базы данных: %s", cfg.Database.Driver)
+	}
+}
+
+// close закрывает подключения к базе данных
+func (a *app) close() {
+	for i := len(a.closers) - 1; i >= 0; i-- {
+		a.closers[i]()
+	}
+}
@@ -0,0 +1,352 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/adapters/repositories"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/config"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/db"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/db/postgres"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/db/sqlite"
+
+	"github.com/spf13/cobra"
+)
+
+// newServeCommand создает команду запуска MCP сервера
+func newServeCommand(configPath *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "serve [config.yaml]",
+		Short: "Запустить MCP сервер с фоновыми задачами",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runServeCommand(cmd.Context(), configFromArgs(*configPath, args))
+		},
+	}
+}
+
+// runServeCommand запускает сервер и ждет сигнала завершения
+func runServeCommand(ctx context.Context, configPath string) error {
+	startedAt := time.Now()
+	cfg, err := loadConfig(configPath)
+	if err != nil {
+		return err
+	}
+
+	a := newApp(ctx, cfg, startedAt)
+	defer a.close()
+
+	runServe(ctx, a)
+	return nil
+}
+
+// newBackfillCommand создает команды загрузки архивов новостей и истории котировок
+func newBackfillCommand(configPath *string) *cobra.Command {
+	backfill := &cobra.Command{
+		Use:   "backfill",
+		Short: "Загрузить архив новостей или историю котировок и завершить работу",
+	}
+
+	var from, to string
+	news := &cobra.Command{
+		Use:   "news",
+		Short: "Загрузить архив новостей за период",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig(*configPath)
+			if err != nil {
+				return err
+			}
+			a := newApp(cmd.Context(), cfg, time.Now())
+			defer a.close()
+			return runNewsBackfill(cmd.Context(), a, from, to)
+		},
+	}
+	news.Flags().StringVar(&from, "from", "", "первый день архива YYYY-MM-DD")
+	news.Flags().StringVar(&to, "to", "", "последний день архива YYYY-MM-DD (по умолчанию сегодня)")
+	_ = news.MarkFlagRequired("from")
+
+	var years int
+	var tickers []string
+	history := &cobra.Command{
+		Use:   "history",
+		Short: "Загрузить историю дневных свечей",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig(*configPath)
+			if err != nil {
+				return err
+			}
+			a := newApp(cmd.Context(), cfg, time.Now())
+			defer a.close()
+			return runHistoryBackfill(cmd.Context(), a, tickers, years)
+		},
+	}
+	history.Flags().IntVar(&years, "years", models.DefaultHistoryBackfillYears, "глубина истории, лет")
+	history.Flags().StringSliceVar(&tickers, "tickers", nil, "тикеры через запятую (по умолчанию все тикеры универсумов)")
+
+	backfill.AddCommand(news, history)
+	return backfill
+}
+
+// runNewsBackfill загружает архив новостей за период и выводит итог в лог
+func runNewsBackfill(ctx context.Context, a *app, fromArg, toArg string) error {
+	from, err := time.Parse("2006-01-02", fromArg)
+	if err != nil {
+		return fmt.Errorf("некорректная дата --from: %w", err)
+	}
+
+	to := time.Now().UTC()
+	if toArg != "" {
+		if to, err = time.Parse("2006-01-02", toArg); err != nil {
+			return fmt.Errorf("некорректная дата --to: %w", err)
+		}
+	}
+
+	log.Printf("Загрузка архива новостей за %s – %s...", from.Format("2006-01-02"), to.Format("2006-01-02"))
+	result, err := a.newsService.BackfillNews(ctx, from, to)
+	if err != nil {
+		return fmt.Errorf("ошибка загрузки архива новостей: %w", err)
+	}
+
+	log.Printf("Архив новостей загружен: дней %d, запросов %d, получено %d, сохранено %d, повторов %d",
+		result.Days, result.Requests, result.Fetched, result.Saved, result.Duplicates)
+	if len(result.Truncated) > 0 {
+		log.Printf("NewsAPI отдал не все страницы за дни: %v", result.Truncated)
+	}
+	for day, reason := range result.Failed {
+		log.Printf("Не удалось загрузить новости за %s: %s", day, reason)
+	}
+	return nil
+}
+
+// runHistoryBackfill загружает историю дневных свечей и выводит итог в лог
+func runHistoryBackfill(ctx context.Context, a *app, tickers []string, years int) error {
+	log.Printf("Загрузка истории дневных свечей за %d лет...", years)
+	result, err := a.stockService.BackfillHistory(ctx, tickers, years)
+	if err != nil {
+		return fmt.Errorf("ошибка загрузки истории котировок: %w", err)
+	}
+
+	for _, item := range result.Tickers {
+		if item.Error != "" {
+			log.Printf("%s: ошибка после %d свечей: %s", item.Ticker, item.Saved, item.Error)
+			continue
+		}
+		log.Printf("%s: сохранено свечей %d, запросов %d", item.Ticker, item.Saved, item.Requests)
+	}
+	log.Printf("История загружена за %s – %s: тикеров %d, сохранено свечей %d, с ошибкой %d",
+		result.From.Format("2006-01-02"), result.To.Format("2006-01-02"), len(result.Tickers), result.Saved, result.Failed)
+	return nil
+}
+
+// newWarmCacheCommand создает команду прогрева кэша котировками, новостями и профилями компаний
+func newWarmCacheCommand(configPath *string) *cobra.Command {
+	var tickers []string
+	var withNews, withProfiles bool
+
+	cmd := &cobra.Command{
+		Use:   "warm-cache",
+		Short: "Заполнить кэш котировками универсумов, новостями и профилями компаний",
+		Long: "Заполняет кэш котировками всех универсумов (или указанных тикеров), новостями и профилями компаний, " +
+			"чтобы первые запросы после развертывания не ждали внешних API. Полезно только с Redis: " +
+			"кэш в памяти исчезает вместе с процессом.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig(*configPath)
+			if err != nil {
+				return err
+			}
+			a := newApp(cmd.Context(), cfg, time.Now())
+			defer a.close()
+			if !a.redisEnabled {
+				log.Printf("ПРЕДУПРЕЖДЕНИЕ: Redis не настроен, кэш в памяти будет потерян при завершении команды")
+			}
+			return runWarmCache(cmd.Context(), a, tickers, withNews, withProfiles)
+		},
+	}
+	cmd.Flags().StringSliceVar(&tickers, "tickers", nil, "тикеры через запятую (по умолчанию все универсумы)")
+	cmd.Flags().BoolVar(&withNews, "news", true, "загрузить новости тикеров")
+	cmd.Flags().BoolVar(&withProfiles, "profiles", false, "загрузить профили компаний (только MongoDB)")
+	return cmd
+}
+
+// runWarmCache запрашивает данные через сервисы, которые сами сохраняют их в кэш
+func runWarmCache(ctx context.Context, a *app, tickers []string, withNews, withProfiles bool) error {
+	started := time.Now()
+
+	if len(tickers) == 0 {
+		for name := range a.cfg.Universes {
+			breadth, err := a.stockService.GetMarketBreadth(ctx, name)
+			if err != nil {
+				log.Printf("Не удалось загрузить котировки универсума %s: %v", name, err)
+				continue
+			}
+			log.Printf("Котировки универсума %s: %d бумаг", name, breadth.Total)
+		}
+		tickers = universeTickers(a.cfg.Universes)
+	} else {
+		stocks, err := a.stockService.GetMultipleStocks(ctx, tickers)
+		if err != nil {
+			return fmt.Errorf("ошибка загрузки котировок: %w", err)
+		}
+		log.Printf("Котировки загружены: %d из %d бумаг", len(stocks), len(tickers))
+	}
+
+	if withNews {
+		news, err := a.newsService.GetNewsForMultipleTickers(ctx, tickers)
+		if err != nil {
+			return fmt.Errorf("ошибка загрузки новостей: %w", err)
+		}
+		log.Printf("Новости загружены: %d", len(news))
+	}
+
+	if withProfiles {
+		if a.profileRepo == nil {
+			log.Printf("Профили компаний пропущены: они хранятся только в MongoDB")
+		} else {
+			profiles, err := a.profileRepo.GetCompanyProfiles(ctx, tickers)
+			if err != nil {
+				return fmt.Errorf("ошибка загрузки профилей компаний: %w", err)
+			}
+			log.Printf("Профили компаний загружены: %d из %d", len(profiles), len(tickers))
+		}
+	}
+
+	log.Printf("Кэш прогрет за %v", time.Since(started).Round(time.Millisecond))
+	return nil
+}
+
+// newCheckConfigCommand создает команду проверки конфигурации без подключения к внешним сервисам
+func newCheckConfigCommand(configPath *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "check-config [config.yaml]",
+		Short: "Проверить конфигурацию и вывести действующие значения",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.LoadConfig(configFromArgs(*configPath, args))
+			if err != nil {
+				return fmt.Errorf("не удалось загрузить конфигурацию: %w", err)
+			}
+			if err := cfg.Validate(); err != nil {
+				return fmt.Errorf("ошибки в конфигурации:\n%w", err)
+			}
+
+			out := cmd.OutOrStdout()
+			for _, warning := range cfg.Warnings() {
+				fmt.Fprintf(out, "ПРЕДУПРЕЖДЕНИЕ: %s\n", warning)
+			}
+			fmt.Fprintf(out, "Действующая конфигурация:\n%s\n", cfg.Summary())
+			fmt.Fprintln(out, "Конфигурация корректна")
+			return nil
+		},
+	}
+}
+
+// newMigrateDBCommand создает команду применения миграций базы данных без запуска сервера
+func newMigrateDBCommand(configPath *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "migrate-db",
+		Short: "Применить миграции SQLite и PostgreSQL или создать индексы MongoDB",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig(*configPath)
+			if err != nil {
+				return err
+			}
+			return migrateDatabase(cmd.Context(), cfg)
+		},
+	}
+}
+
+// migrateDatabase подключается только к базе данных: кэш и внешние API для миграций не нужны
+func migrateDatabase(ctx context.Context, cfg *config.Config) error {
+	switch {
+	case cfg.Database.Driver == config.DriverSQLite:
+		sqliteDB, err := sqlite.NewSQLite(cfg.Database.Path)
+		if err != nil {
+			return fmt.Errorf("ошибка открытия базы данных SQLite: %w", err)
+		}
+		defer sqliteDB.Close()
+
+		if err := sqliteDB.Migrate(ctx); err != nil {
+			return fmt.Errorf("ошибка применения миграций SQLite: %w", err)
+		}
+		log.Printf("Миграции SQLite применены: %s", cfg.Database.Path)
+
+	case cfg.Database.URI == "":
+		return fmt.Errorf("URI базы данных не указан")
+
+	case cfg.Database.Driver == config.DriverPostgres:
+		pg, err := postgres.NewPostgres(cfg.Database.URI, cfg.Database.Timeout)
+		if err != nil {
+			return fmt.Errorf("ошибка подключения к PostgreSQL: %w", err)
+		}
+		defer pg.Close()
+
+		if err := pg.Migrate(ctx); err != nil {
+			return fmt.Errorf("ошибка применения миграций PostgreSQL: %w", err)
+		}
+		log.Printf("Миграции PostgreSQL применены")
+
+	case cfg.Database.Driver == config.DriverMongo:
+		mongoDB, err := db.NewMongoDB(cfg.Database.URI, cfg.Database.Database, cfg.Database.Collection, cfg.Database.Timeout)
+		if err != nil {
+			return fmt.Errorf("ошибка подключения к MongoDB: %w", err)
+		}
+		defer mongoDB.Close(context.Background())
+
+		err = repositories.EnsureMongoIndexes(ctx, mongoDB.GetDatabase(), repositories.MongoIndexOptions{
+			QuotesRetention: cfg.Database.QuotesRetention,
+			NewsRetention:   cfg.Database.NewsRetention,
+		})
+		if err != nil {
+			return fmt.Errorf("ошибка создания индексов MongoDB: %w", err)
+		}
+		// Документы старых версий схемы обновляются при чтении, отдельная миграция для них не нужна
+		log.Printf("Индексы MongoDB созданы: %s", cfg.Database.Database)
+
+	default:
+		return fmt.Errorf("неизвестный драйвер базы данных: %s", cfg.Database.Driver)
+	}
+	return nil
+}
+
+// newListToolsCommand создает команду вывода инструментов, которые сервер зарегистрирует с текущей конфигурацией
+func newListToolsCommand(configPath *string) *cobra.Command {
+	var asJSON bool
+
+	cmd := &cobra.Command{
+		Use:   "list-tools",
+		Short: "Вывести инструменты, доступные с текущей конфигурацией",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig(*configPath)
+			if err != nil {
+				return err
+			}
+			a := newApp(cmd.Context(), cfg, time.Now())
+			defer a.close()
+
+			tools := buildServer(cmd.Context(), a, nil).server.Tools()
+			out := cmd.OutOrStdout()
+			if asJSON {
+				encoder := json.NewEncoder(out)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(tools)
+			}
+			for _, tool := range tools {
+				fmt.Fprintf(out, "%s\n    %s\n", tool.Name, strings.ReplaceAll(tool.Description, "\n", "\n    "))
+			}
+			fmt.Fprintf(out, "\nВсего инструментов: %d\n", len(tools))
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&asJSON, "json", false, "вывести описания и схемы аргументов в JSON")
+	return cmd
+}
@@ -2,137 +2,98 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log"
-	"os"
 	"os/signal"
+	"sort"
 	"syscall"
-	"time"
 
-	"github.com/JkLondon/mcp-stocks-info-server/pkg/db"
-
-	repositories2 "github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/repositories"
-
-	"github.com/JkLondon/mcp-stocks-info-server/internal/adapters/mcp"
-	"github.com/JkLondon/mcp-stocks-info-server/internal/adapters/repositories"
-	"github.com/JkLondon/mcp-stocks-info-server/internal/adapters/repositories/apis"
-	"github.com/JkLondon/mcp-stocks-info-server/internal/adapters/services"
 	"github.com/JkLondon/mcp-stocks-info-server/internal/config"
-	"github.com/JkLondon/mcp-stocks-info-server/pkg/cache"
+
+	"github.com/spf13/cobra"
 )
 
 func main() {
-	// Определяем путь к конфигурационному файлу
-	configPath := "config.yaml"
-	if len(os.Args) > 1 {
-		configPath = os.Args[1]
-	}
-
-	// Загружаем конфигурацию
-	cfg, err := config.LoadConfig(configPath)
-	if err != nil {
-		// Если не удалось загрузить конфигурацию, используем значения по умолчанию
-		log.Printf("Не удалось загрузить конфигурацию: %v. Используем значения по умолчанию.", err)
-		cfg = &config.Config{}
-		cfg.Cache.DefaultTTL = 5 * time.Minute
-		cfg.Server.Port = 8080
-		cfg.MOEX.BaseURL = "https://iss.moex.com/iss"
-		cfg.NewsAPI.BaseURL = "https://newsapi.org/v2"
-	}
-
-	ctx, cancel := context.WithCancel(context.Background())
+	// Сигнал завершения отменяет контекст команды: сервер останавливается, загрузки прерываются
+	// и при следующем запуске продолжаются с места остановки
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer cancel()
 
-	// Создаем кэш
-	var cacheClient cache.Cache
-	if cfg.Cache.RedisURI != "" {
-		// Если указан URI Redis, используем Redis для кэширования
-		cacheClient, err = cache.NewRedisCache(cfg.Cache.RedisURI, cfg.Cache.RedisDB)
-		if err != nil {
-			log.Fatalf("Ошибка инициализации Redis: %v", err)
-		}
-		log.Printf("Инициализирован Redis-кэш: %s", cfg.Cache.RedisURI)
-	} else {
-		// В противном случае используем in-memory кэш
-		cacheClient = cache.NewInMemoryCache(cfg.Cache.DefaultTTL)
-		log.Printf("Инициализирован in-memory кэш с TTL %v", cfg.Cache.DefaultTTL)
+	// Ошибки команд выводятся в лог, как и остальные сообщения сервера
+	if err := newRootCommand().ExecuteContext(ctx); err != nil {
+		cancel()
+		log.Fatalf("Ошибка: %v", err)
 	}
+}
 
-	// Создаем подключение к MongoDB
-	var mongoDB *db.MongoDB
-	if cfg.Database.URI != "" {
-		mongoDB, err = db.NewMongoDB(
-			cfg.Database.URI,
-			cfg.Database.Database,
-			cfg.Database.Collection,
-			cfg.Database.Timeout,
-		)
-		if err != nil {
-			log.Fatalf("Ошибка подключения к MongoDB: %v", err)
-		}
-		defer func() {
-			if err := mongoDB.Close(ctx); err != nil {
-				log.Printf("Ошибка при закрытии подключения к MongoDB: %v", err)
-			}
-		}()
-		log.Printf("Подключение к MongoDB: %s/%s", cfg.Database.URI, cfg.Database.Database)
-	} else {
-		log.Printf("ПРЕДУПРЕЖДЕНИЕ: URI базы данных не указан, будет использоваться только кэш")
-		// Можно создать заглушку для базы данных
+// newRootCommand создает корневую команду. Без подкоманды она запускает сервер, как serve,
+// поэтому прежний запуск ./mcp-stocks-server config.yaml продолжает работать
+func newRootCommand() *cobra.Command {
+	var configPath string
+
+	root := &cobra.Command{
+		Use:   "mcp-stocks-server [config.yaml]",
+		Short: "MCP сервер котировок и новостей российского фондового рынка",
+		Long: "MCP сервер котировок и новостей российского фондового рынка.\n\n" +
+			"Без подкоманды запускает сервер (как serve). Служебные подкоманды выполняют задачи оператора " +
+			"с той же конфигурацией и завершают работу.",
+		Args:          cobra.MaximumNArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runServeCommand(cmd.Context(), configFromArgs(configPath, args))
+		},
 	}
+	root.PersistentFlags().StringVarP(&configPath, "config", "c", "config.yaml", "путь к файлу конфигурации")
+
+	root.AddCommand(
+		newServeCommand(&configPath),
+		newBackfillCommand(&configPath),
+		newWarmCacheCommand(&configPath),
+		newCheckConfigCommand(&configPath),
+		newMigrateDBCommand(&configPath),
+		newListToolsCommand(&configPath),
+	)
+
+	return root
+}
 
-	// Создаем API-клиенты
-	moexAPI := apis.NewMOEXAPIClient(cfg, cacheClient)
-	newsAPI := apis.NewNewsAPIClient(cfg, cacheClient)
-
-	// Создаем репозитории
-	var stockRepo repositories2.StockRepository
-	var newsRepo repositories2.NewsRepository
-
-	if mongoDB != nil {
-		// Если есть подключение к MongoDB, используем его
-		stockRepo = repositories.NewStockRepository(
-			mongoDB.GetDatabase(),
-			cacheClient,
-			moexAPI,
-			cfg.Cache.StocksTTL,
-			true,
-		)
-
-		newsRepo = repositories.NewNewsRepository(
-			mongoDB.GetDatabase(),
-			cacheClient,
-			newsAPI,
-			cfg.Cache.NewsTTL,
-			true,
-		)
-	} else {
-		// Иначе создаем заглушки для репозиториев
-		// Здесь должна быть реализация mock-репозиториев
-		log.Fatalf("В текущей версии требуется MongoDB для работы сервера")
+// configFromArgs возвращает путь к конфигурации: позиционный аргумент важнее флага --config
+func configFromArgs(configPath string, args []string) string {
+	if len(args) > 0 {
+		return args[0]
 	}
+	return configPath
+}
 
-	// Создаем сервисы
-	stockService := services.NewStockService(stockRepo)
-	newsService := services.NewNewsService(newsRepo)
-
-	// Создаем MCP сервер
-	mcpServer := mcp.NewMCPServer(cfg, stockService, newsService)
-
-	// Обработка сигналов для корректного завершения
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+// loadConfig загружает и проверяет конфигурацию, выводит предупреждения и действующие значения в лог
+func loadConfig(path string) (*config.Config, error) {
+	cfg, err := config.LoadConfig(path)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось загрузить конфигурацию: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("ошибки в конфигурации:\n%w", err)
+	}
+	for _, warning := range cfg.Warnings() {
+		log.Printf("ПРЕДУПРЕЖДЕНИЕ: %s", warning)
+	}
+	log.Printf("Действующая конфигурация:\n%s", cfg.Summary())
+	return cfg, nil
+}
 
-	// Запускаем MCP сервер
-	go func() {
-		log.Println("Запуск MCP сервера...")
-		if err := mcpServer.Start(); err != nil {
-			log.Fatalf("Ошибка запуска MCP сервера: %v", err)
+// universeTickers возвращает тикеры всех универсумов без повторов в алфавитном порядке
+func universeTickers(universes map[string]config.UniverseConfig) []string {
+	seen := make(map[string]bool)
+	var tickers []string
+	for _, universe := range universes {
+		for _, ticker := range universe.Tickers {
+			if !seen[ticker] {
+				seen[ticker] = true
+				tickers = append(tickers, ticker)
+			}
 		}
-	}()
-
-	// Ожидаем сигнала для завершения
-	<-sigChan
-	log.Println("Получен сигнал завершения. Останавливаем сервер...")
-	cancel() // Отменяем контекст для корректного завершения всех операций
-	log.Println("Сервер остановлен")
+	}
+	sort.Strings(tickers)
+	return tickers
 }
@@ -0,0 +1,359 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	repositories2 "github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/repositories"
+	services2 "github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/services"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/adapters/mcp"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/adapters/monitoring"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/adapters/prompts"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/adapters/render"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/adapters/repositories"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/adapters/repositories/apis"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/adapters/services"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/config"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/rawarchive"
+)
+
+// builtServer MCP сервер с необязательными сервисами, которым нужны фоновые задачи
+type builtServer struct {
+	server           *mcp.Server
+	healthService    services2.HealthService
+	watchlistService services2.WatchlistService
+	moodService      services2.MoodService
+	listingService   services2.ListingService
+	eventService     services2.CorporateEventService
+	targetService    services2.PriceTargetService
+	marginService    services2.MarginService
+	gapService       services2.GapService
+	macroService     services2.MacroService
+	indexService     services2.IndexService
+	rawArchive       *rawarchive.Archive
+}
+
+// buildServer создает MCP сервер со всеми сервисами, доступными при текущей конфигурации.
+// scheduler передается командой serve, чтобы проверка готовности замечала аварийную остановку фоновых задач;
+// ctx — контекст приложения, с которым останавливаются фоновые проверки клиентов API
+func buildServer(ctx context.Context, a *app, scheduler *services.JobScheduler) *builtServer {
+	cfg := a.cfg
+	built := &builtServer{}
+
+	// Шаблоны результатов разбираются при запуске, чтобы синтаксическая ошибка в них не проявлялась только при вызове инструмента
+	renderer, err := render.New(cfg.Templates.Dir)
+	if err != nil {
+		log.Fatalf("Ошибка загрузки шаблонов результатов: %v", err)
+	}
+
+	// Шаблоны MCP оператора тоже разбираются при запуске
+	customPrompts, err := prompts.Load(cfg.Templates.PromptsDir)
+	if err != nil {
+		log.Fatalf("Ошибка загрузки шаблонов MCP: %v", err)
+	}
+	if len(customPrompts) > 0 {
+		log.Printf("Загружено шаблонов MCP из %s: %d", cfg.Templates.PromptsDir, len(customPrompts))
+	}
+
+	healthProbes := a.healthProbes
+	if scheduler != nil {
+		healthProbes = append(healthProbes, services.HealthProbe{Name: "scheduler", Critical: true, Check: scheduler.Check})
+	}
+
+	serverOpts := []mcp.Option{
+		mcp.WithRenderer(renderer),
+		mcp.WithCustomPrompts(customPrompts),
+		mcp.WithMOEXStatus(a.moexAPI),
+		mcp.WithMarketData(services.NewMarketDataService(a.moexAPI)),
+		mcp.WithCommodities(services.NewCommodityService(a.moexAPI, cfg.Commodities.UralsDiscountUSD)),
+		mcp.WithCBR(services.NewCBRService(a.cbrAPI)),
+		mcp.WithAnalysis(a.analysisService),
+		mcp.WithSelfTest(services.NewSelfTestService(ctx, cfg, a.cacheClient)),
+		mcp.WithSecurities(a.securityService),
+		mcp.WithFunds(services.NewFundService(a.moexAPI, cfg.Funds.Metadata, apis.BuiltinFundMetadata())),
+		mcp.WithSymbols(services.NewSymbolService(a.moexAPI, a.securityService, apis.NewOpenFIGIClient(cfg, a.cacheClient), cfg.TickerAliases, apis.BuiltinTickerAliases())),
+	}
+
+	// Котировки криптовалют — необязательный модуль, включается в конфигурации
+	if cfg.Crypto.Enabled {
+		cryptoService := services.NewCryptoService(apis.NewCoinGeckoClient(cfg, a.cacheClient), cfg.Crypto.Coins)
+		serverOpts = append(serverOpts, mcp.WithCrypto(cryptoService))
+		log.Printf("Включены котировки криптовалют: %v", cryptoService.GetSymbols())
+	}
+
+	// Архив необработанных ответов: клиенты API пишут в него сами, здесь — повторный разбор и очистка
+	if cfg.RawArchive.Enabled {
+		built.rawArchive = rawarchive.New(cfg.RawArchive.Dir, cfg.RawArchive.Retention)
+		rawArchiveService := services.NewRawArchiveService(built.rawArchive, a.newsRepo, a.stockRepo, a.cacheClient)
+		serverOpts = append(serverOpts, mcp.WithRawArchive(rawArchiveService))
+	}
+
+	// Крупные выгрузки сохраняются в бакет S3, если он задан, иначе в каталог; без хранилища — только в ответе
+	var exportStorage repositories2.ExportStorage
+	switch {
+	case cfg.Export.S3.Bucket != "":
+		exportStorage = repositories.NewExportStorageS3(cfg.Export.S3)
+	case cfg.Export.Dir != "":
+		exportStorage = repositories.NewExportStorageFile(cfg.Export.Dir, cfg.Export.BaseURL)
+	}
+	serverOpts = append(serverOpts, mcp.WithExport(services.NewExportService(a.stockService, a.newsService, exportStorage, cfg.Export.InlineMaxBytes)))
+
+	if cfg.Server.AdminTools {
+		serverOpts = append(serverOpts, mcp.WithCacheAdmin(services.NewCacheService(a.cacheClient)))
+	}
+	serverOpts = append(serverOpts, mcp.WithServerStats(services.NewServerStatsService(a.meteredCache, a.startedAt)))
+
+	// Проверки кэшируются на 10 секунд, чтобы частые запросы оркестратора не нагружали базу и внешние API
+	built.healthService = services.NewHealthService(a.startedAt, 10*time.Second, healthProbes...)
+	serverOpts = append(serverOpts, mcp.WithHealth(built.healthService))
+
+	// Портфели пока хранятся только в MongoDB
+	if a.portfolioRepo != nil {
+		portfolioService := services.NewPortfolioService(a.portfolioRepo, a.stockRepo, a.profileRepo, a.securityService, cfg.Broker, a.fetchPool)
+		serverOpts = append(serverOpts, mcp.WithPortfolio(portfolioService), mcp.WithTaxes(services.NewTaxService(a.portfolioRepo, a.eventRepo)))
+	} else {
+		log.Printf("Инструменты портфеля недоступны: драйвер %s не поддерживает хранение портфелей", cfg.Database.Driver)
+	}
+
+	// Списки наблюдения, как и портфели, хранятся только в MongoDB
+	if a.watchlistRepo != nil {
+		built.watchlistService = services.NewWatchlistService(a.watchlistRepo, a.stockRepo, cfg.Watchlist, a.fetchPool)
+		serverOpts = append(serverOpts, mcp.WithWatchlist(built.watchlistService))
+	} else {
+		log.Printf("Инструменты списков наблюдения недоступны: драйвер %s не поддерживает их хранение", cfg.Database.Driver)
+	}
+
+	// Профили компаний долго хранятся в MongoDB, чтобы не запрашивать описание эмитента у MOEX при каждом вызове
+	if a.profileRepo != nil {
+		serverOpts = append(serverOpts, mcp.WithCompanyProfiles(services.NewCompanyProfileService(a.profileRepo)))
+	} else {
+		log.Printf("Профили компаний недоступны: драйвер %s не поддерживает их хранение", cfg.Database.Driver)
+	}
+
+	// История индекса настроения рынка хранится только в MongoDB
+	if a.moodRepo != nil {
+		built.moodService = services.NewMoodService(a.moodRepo, a.stockRepo, a.newsRepo)
+		serverOpts = append(serverOpts, mcp.WithMood(built.moodService))
+	} else {
+		log.Printf("Индекс настроения рынка недоступен: драйвер %s не поддерживает хранение его истории", cfg.Database.Driver)
+	}
+
+	// Календарь размещений сравнивает список бумаг MOEX с сохраненным, поэтому тоже требует MongoDB
+	if a.listingRepo != nil {
+		var feed repositories2.ListingFeed
+		if cfg.Listings.FeedPath != "" {
+			feed = apis.NewListingFeedFile(cfg.Listings.FeedPath)
+		}
+		built.listingService = services.NewListingService(a.listingRepo, a.moexAPI, feed)
+		serverOpts = append(serverOpts, mcp.WithListings(built.listingService))
+	} else {
+		log.Printf("Календарь размещений недоступен: драйвер %s не поддерживает его хранение", cfg.Database.Driver)
+	}
+
+	// Календарь корпоративных событий: дивиденды бумаг универсумов из MOEX и необязательный файл оператора
+	if a.eventRepo != nil {
+		sources := []repositories2.CorporateEventSource{apis.NewMOEXDividendSource(a.moexAPI)}
+		if cfg.Events.FeedPath != "" {
+			sources = append(sources, apis.NewCorporateEventFeedFile(cfg.Events.FeedPath))
+		}
+		built.eventService = services.NewCorporateEventService(a.eventRepo, a.stockRepo, sources, universeTickers(cfg.Universes))
+		serverOpts = append(serverOpts, mcp.WithCorporateEvents(built.eventService))
+	} else {
+		log.Printf("Календарь корпоративных событий недоступен: драйвер %s не поддерживает его хранение", cfg.Database.Driver)
+	}
+
+	// Целевые цены задает пользователь; прогнозы брокеров дополнительно загружаются из необязательного файла оператора
+	if a.targetRepo != nil {
+		var sources []repositories2.PriceTargetSource
+		if cfg.Targets.FeedPath != "" {
+			sources = append(sources, apis.NewPriceTargetFeedFile(cfg.Targets.FeedPath))
+		}
+		built.targetService = services.NewPriceTargetService(a.targetRepo, a.stockRepo, sources, a.fetchPool)
+		serverOpts = append(serverOpts, mcp.WithPriceTargets(built.targetService))
+	} else {
+		log.Printf("Целевые цены недоступны: драйвер %s не поддерживает их хранение", cfg.Database.Driver)
+	}
+
+	// Гэпы открытия хранятся в кэше до конца дня
+	gapsAt, _ := time.Parse("15:04", cfg.Gaps.ScanAt)
+	built.gapService = services.NewGapService(a.stockService, a.cacheClient, cfg.Gaps.Universe, gapsAt)
+	serverOpts = append(serverOpts, mcp.WithGaps(built.gapService))
+
+	// Маржинальные списки брокеров хранятся в памяти, поэтому доступны с любой базой данных
+	if cfg.Margin.FeedPath != "" {
+		built.marginService = services.NewMarginService([]repositories2.MarginSource{apis.NewMarginFeedFile(cfg.Margin.FeedPath)})
+		serverOpts = append(serverOpts, mcp.WithMargin(built.marginService))
+	}
+
+	// Макроэкономические показатели из источников, включенных в конфигурации
+	if a.macroRepo != nil {
+		var sources []repositories2.MacroSource
+		for _, name := range cfg.Macro.Sources {
+			switch strings.ToLower(name) {
+			case models.MacroSourceCBR:
+				sources = append(sources, apis.NewCBRMacroSource(a.cbrAPI))
+			case models.MacroSourceMOEX:
+				sources = append(sources, apis.NewMOEXMacroSource(a.moexAPI))
+			case models.MacroSourceRosstat:
+				if cfg.Macro.RosstatCSVPath == "" {
+					log.Fatalf("Для источника макропоказателей rosstat не указан macro.rosstatCSVPath")
+				}
+				sources = append(sources, apis.NewRosstatCSVFile(cfg.Macro.RosstatCSVPath))
+			default:
+				log.Fatalf("Неизвестный источник макропоказателей: %s", name)
+			}
+		}
+		built.macroService = services.NewMacroService(a.macroRepo, sources)
+		serverOpts = append(serverOpts, mcp.WithMacro(built.macroService))
+	} else {
+		log.Printf("Макропоказатели недоступны: драйвер %s не поддерживает их хранение", cfg.Database.Driver)
+	}
+
+	// Состав и веса индексов MOEX
+	if a.indexRepo != nil {
+		built.indexService = services.NewIndexService(a.indexRepo, a.stockRepo, a.moexAPI, cfg.Indexes.Codes, a.fetchPool)
+		serverOpts = append(serverOpts, mcp.WithIndexes(built.indexService))
+	} else {
+		log.Printf("Состав индексов недоступен: драйвер %s не поддерживает его хранение", cfg.Database.Driver)
+	}
+
+	// Обогащение новостей через модель клиента (MCP sampling) подключаем, только если включен хотя бы один шаг.
+	// Sampling работает только поверх stdio: запросы к модели клиента идут в тот же поток
+	if cfg.Server.Transport == config.TransportStdio && (cfg.Enrichment.Summarize || cfg.Enrichment.Classify || cfg.Enrichment.TranslateTo != "") {
+		sampler := mcp.NewStdioSampler(os.Stdin, os.Stdout)
+		enrichmentService := services.NewEnrichmentService(cfg.Enrichment, sampler, a.cacheClient)
+		serverOpts = append(serverOpts, mcp.WithSampler(sampler), mcp.WithEnrichment(enrichmentService))
+		log.Printf("Включено обогащение новостей через MCP sampling")
+	}
+
+	// Создаем MCP сервер
+	built.server = mcp.NewMCPServer(cfg, a.stockService, a.newsService, serverOpts...)
+	return built
+}
+
+// runServe запускает MCP сервер с фоновыми задачами и работает до отмены контекста
+func runServe(ctx context.Context, a *app) {
+	cfg := a.cfg
+
+	// Фоновые задачи запускаются через планировщик, чтобы проверка готовности замечала их аварийную остановку
+	scheduler := services.NewJobScheduler()
+	built := buildServer(ctx, a, scheduler)
+	mcpServer := built.server
+
+	if cfg.Server.MonitoringAddr != "" {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", monitoring.MetricsHandler(a.meteredCache))
+		mux.Handle("/healthz", monitoring.HealthzHandler(built.healthService))
+		mux.Handle("/readyz", monitoring.ReadyzHandler(built.healthService))
+		go func() {
+			log.Printf("Мониторинг доступен на %s: /metrics, /healthz, /readyz", cfg.Server.MonitoringAddr)
+			if err := http.ListenAndServe(cfg.Server.MonitoringAddr, mux); err != nil {
+				log.Printf("Ошибка сервера мониторинга: %v", err)
+			}
+		}()
+	}
+
+	// Очистка архива необработанных ответов от записей старше rawArchive.retention
+	if built.rawArchive != nil {
+		scheduler.Go(ctx, "raw_archive_pruner", time.Hour, services.NewRawArchivePruner(built.rawArchive, time.Hour).Run)
+		log.Printf("Ответы внешних API сохраняются в архив %s на %v", cfg.RawArchive.Dir, cfg.RawArchive.Retention)
+	}
+
+	// Фоновая загрузка новостей из Telegram-каналов в общий репозиторий новостей
+	if cfg.Telegram.BotToken != "" && len(cfg.Telegram.Channels) > 0 {
+		telegramClient := apis.NewTelegramClient(cfg)
+		ingestor := services.NewTelegramIngestor(telegramClient, a.newsRepo, a.cacheClient, cfg.Telegram.PollInterval)
+		scheduler.Go(ctx, "telegram_ingestor", cfg.Telegram.PollInterval, ingestor.Run)
+		log.Printf("Загрузка новостей из Telegram-каналов: %v", cfg.Telegram.Channels)
+	}
+
+	// Фоновая проверка порогов уведомлений списков наблюдения
+	if built.watchlistService != nil && cfg.Watchlist.RefreshInterval > 0 {
+		refresher := services.NewWatchlistRefresher(built.watchlistService, cfg.Watchlist.RefreshInterval, mcpServer.NotifyWatchlistAlert)
+		scheduler.Go(ctx, "watchlist_refresher", cfg.Watchlist.RefreshInterval, refresher.Run)
+		log.Printf("Проверка порогов списков наблюдения каждые %v", cfg.Watchlist.RefreshInterval)
+	}
+
+	// Опрос котировок бумаг, на которые подписаны клиенты; без подписок биржа не опрашивается
+	if cfg.Features.Enabled(config.FeatureStocks) {
+		poller := services.NewQuotePoller(a.stockService, cfg.Subscriptions.PollInterval, mcpServer.SubscribedTickers, mcpServer.PublishQuotes)
+		scheduler.Go(ctx, "quote_poller", cfg.Subscriptions.PollInterval, poller.Run)
+	}
+
+	// Ежедневная загрузка справочника бумаг MOEX; время проверено при загрузке конфигурации
+	syncAt, _ := time.Parse("15:04", cfg.Securities.SyncAt)
+	scheduler.Go(ctx, "security_syncer", 24*time.Hour, services.NewSecuritySyncer(a.securityService, syncAt).Run)
+	log.Printf("Загрузка справочника бумаг MOEX ежедневно в %s по Москве", cfg.Securities.SyncAt)
+
+	// Ежечасный пересчет индекса настроения, чтобы история пополнялась без обращений к инструменту
+	if built.moodService != nil {
+		scheduler.Go(ctx, "mood_recorder", time.Hour, services.NewMoodRecorder(built.moodService, time.Hour).Run)
+	}
+
+	// Периодическая сверка списка бумаг MOEX для обнаружения новых листингов
+	if built.listingService != nil {
+		scheduler.Go(ctx, "listing_tracker", cfg.Listings.RefreshInterval, services.NewListingTracker(built.listingService, cfg.Listings.RefreshInterval).Run)
+		log.Printf("Сверка календаря размещений каждые %v", cfg.Listings.RefreshInterval)
+	}
+
+	// Периодическая загрузка корпоративных событий
+	if built.eventService != nil {
+		scheduler.Go(ctx, "corporate_event_ingestor", cfg.Events.RefreshInterval, services.NewCorporateEventIngestor(built.eventService, cfg.Events.RefreshInterval).Run)
+		log.Printf("Загрузка корпоративных событий каждые %v", cfg.Events.RefreshInterval)
+	}
+
+	// Периодическая загрузка целевых цен аналитиков, если задан файл оператора
+	if built.targetService != nil && cfg.Targets.FeedPath != "" {
+		scheduler.Go(ctx, "price_target_ingestor", cfg.Targets.RefreshInterval, services.NewPriceTargetIngestor(built.targetService, cfg.Targets.RefreshInterval).Run)
+		log.Printf("Загрузка целевых цен каждые %v", cfg.Targets.RefreshInterval)
+	}
+
+	// Периодическое обновление маржинальных списков
+	if built.marginService != nil {
+		scheduler.Go(ctx, "margin_refresher", cfg.Margin.RefreshInterval, services.NewMarginRefresher(built.marginService, cfg.Margin.RefreshInterval).Run)
+		log.Printf("Обновление маржинальных списков каждые %v", cfg.Margin.RefreshInterval)
+	}
+
+	// Периодический поиск аномальных объемов торгов
+	if cfg.Volume.ScanInterval > 0 {
+		scheduler.Go(ctx, "volume_scanner", cfg.Volume.ScanInterval, services.NewVolumeScanner(a.stockService, cfg.Volume.Universe, cfg.Volume.MinRatio, cfg.Volume.ScanInterval).Run)
+		log.Printf("Поиск аномальных объемов в универсуме %s каждые %v", cfg.Volume.Universe, cfg.Volume.ScanInterval)
+	}
+
+	// Ежедневный расчет гэпов открытия для get_gappers; время проверено при загрузке конфигурации
+	if cfg.Features.Enabled(config.FeatureStocks) {
+		gapsAt, _ := time.Parse("15:04", cfg.Gaps.ScanAt)
+		scheduler.Go(ctx, "gap_scanner", 24*time.Hour, services.NewGapScanner(built.gapService, gapsAt).Run)
+		log.Printf("Расчет гэпов открытия универсума %s ежедневно в %s по Москве", cfg.Gaps.Universe, cfg.Gaps.ScanAt)
+	}
+
+	// Периодическая загрузка макропоказателей
+	if built.macroService != nil {
+		scheduler.Go(ctx, "macro_ingestor", cfg.Macro.RefreshInterval, services.NewMacroIngestor(built.macroService, cfg.Macro.RefreshInterval).Run)
+		log.Printf("Загрузка макропоказателей каждые %v", cfg.Macro.RefreshInterval)
+	}
+
+	// Периодическая загрузка состава индексов
+	if built.indexService != nil {
+		scheduler.Go(ctx, "index_ingestor", cfg.Indexes.RefreshInterval, services.NewIndexIngestor(built.indexService, cfg.Indexes.RefreshInterval).Run)
+		log.Printf("Загрузка состава индексов каждые %v", cfg.Indexes.RefreshInterval)
+	}
+
+	// Запускаем MCP сервер
+	go func() {
+		log.Println("Запуск MCP сервера...")
+		if err := mcpServer.Start(); err != nil {
+			log.Fatalf("Ошибка запуска MCP сервера: %v", err)
+		}
+	}()
+
+	// Ожидаем сигнала для завершения: он отменяет контекст команды
+	<-ctx.Done()
+	log.Println("Получен сигнал завершения. Останавливаем сервер...")
+}
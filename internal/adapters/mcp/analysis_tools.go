@@ -0,0 +1,319 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/adapters/render"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/i18n"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// registerAnalysisTools регистрирует аналитические инструменты по котировкам и новостям
+func (s *Server) registerAnalysisTools() {
+	if s.analysisService == nil {
+		return
+	}
+
+	// Инструмент для объяснения движения акции за день
+	explainPriceMoveTool := mcp.NewTool("explain_price_move",
+		mcp.WithDescription(s.printer.T("Собрать контекст движения акции за день, чтобы объяснить, что произошло: дневная свеча, часовой профиль цены и объема, новости по компании до и после самого сильного движения, движение сектора и индекса")),
+		mcp.WithString("ticker",
+			mcp.Required(),
+			mcp.Description(s.printer.T("Тикер акции (например, SBER, GAZP, LKOH)")),
+		),
+		mcp.WithString("date",
+			mcp.Description(s.printer.T("Дата торгов в формате YYYY-MM-DD (по умолчанию сегодня)")),
+		),
+	)
+
+	s.addTool(explainPriceMoveTool, s.handleExplainMove, sourceMOEX, sourceNews)
+
+	// Прежнее имя инструмента оставлено для уже настроенных клиентов
+	explainMoveTool := explainPriceMoveTool
+	explainMoveTool.Name = "explain_move"
+
+	s.addTool(explainMoveTool, s.handleExplainMove, sourceMOEX, sourceNews)
+
+	// Инструмент для расчета корреляций и беты
+	getCorrelationTool := mcp.NewTool("get_correlation",
+		mcp.WithDescription(s.printer.T("Рассчитать попарные корреляции дневных доходностей акций и их беты относительно индекса IMOEX по истории котировок; помогает оценить диверсификацию портфеля")),
+		mcp.WithArray("tickers",
+			mcp.Required(),
+			mcp.Description(s.printer.Sprintf("Тикеры акций, не более %d (например, [\"SBER\", \"GAZP\", \"LKOH\"])", models.MaxCorrelationTickers)),
+			mcp.Items(map[string]interface{}{"type": "string"}),
+			mcp.MinItems(1),
+			mcp.MaxItems(models.MaxCorrelationTickers),
+		),
+		mcp.WithNumber("window_days",
+			mcp.Description(s.printer.Sprintf("Окно расчета в календарных днях (по умолчанию %d, не более %d)", models.DefaultCorrelationWindowDays, models.MaxCorrelationWindowDays)),
+		),
+	)
+
+	s.addTool(getCorrelationTool, s.handleGetCorrelation, sourceMOEX)
+}
+
+// handleExplainMove обрабатывает запрос на объяснение движения акции
+func (s *Server) handleExplainMove(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		Ticker string `arg:"ticker,required"`
+		Date   string `arg:"date"`
+	}
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	p := i18n.PrinterFrom(ctx)
+	date := time.Now()
+	if args.Date != "" {
+		parsed, err := parseDateArg(ctx, "date", args.Date, time.UTC)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		date = parsed
+	}
+
+	explanation, err := s.analysisService.ExplainMove(ctx, args.Ticker, date)
+	if err != nil {
+		return mcp.NewToolResultError(p.Sprintf("не удалось проанализировать движение акции: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(formatMoveExplanation(p, explanation)), nil
+}
+
+// handleGetCorrelation обрабатывает запрос на расчет корреляций и беты
+func (s *Server) handleGetCorrelation(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// Границы совпадают с models.MaxCorrelationTickers и models.MaxCorrelationWindowDays
+	args := struct {
+		Tickers    []string `arg:"tickers,required" min:"1" max:"10"`
+		WindowDays int      `arg:"window_days" min:"1" max:"730"`
+	}{WindowDays: models.DefaultCorrelationWindowDays}
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	p := i18n.PrinterFrom(ctx)
+	report, err := s.analysisService.GetCorrelation(ctx, args.Tickers, args.WindowDays)
+	if err != nil {
+		return mcp.NewToolResultError(p.Sprintf("не удалось рассчитать корреляции: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(formatCorrelationReport(p, report)), nil
+}
+
+// formatCorrelationReport форматирует беты и матрицу корреляций на языке переводчика p
+func formatCorrelationReport(p i18n.Printer, r *models.CorrelationReport) string {
+	result := p.Sprintf("Корреляции и беты за %d дней (%s – %s):\n\n",
+		r.WindowDays, r.From.Format("02.01.2006"), r.To.Format("02.01.2006"))
+
+	result += p.Sprintf("Бета относительно %s:\n", r.IndexTicker)
+	for _, beta := range r.Betas {
+		if !r.HasIndex || beta.Observations == 0 {
+			result += p.Sprintf("- %s: бета нет данных, дневная волатильность %.2f%%\n", beta.Ticker, beta.VolatilityPerc)
+			continue
+		}
+		result += p.Sprintf("- %s: бета %.2f, корреляция с индексом %.2f, дневная волатильность %.2f%% (%d сессий)\n",
+			beta.Ticker, beta.Beta, beta.IndexCorrelation, beta.VolatilityPerc, beta.Observations)
+	}
+	if !r.HasIndex {
+		result += p.Sprintf("История индекса %s недоступна, беты не рассчитаны\n", r.IndexTicker)
+	}
+
+	if len(r.Tickers) > 1 {
+		correlations := make(map[string]float64, len(r.Pairs)*2)
+		for _, pair := range r.Pairs {
+			correlations[pair.TickerA+"/"+pair.TickerB] = pair.Correlation
+			correlations[pair.TickerB+"/"+pair.TickerA] = pair.Correlation
+		}
+
+		result += p.T("\nМатрица корреляций дневных доходностей:\n\n|  |")
+		separator := "|---|"
+		for _, ticker := range r.Tickers {
+			result += fmt.Sprintf(" %s |", ticker)
+			separator += "---|"
+		}
+		result += "\n" + separator + "\n"
+		for _, rowTicker := range r.Tickers {
+			result += fmt.Sprintf("| %s |", rowTicker)
+			for _, columnTicker := range r.Tickers {
+				value, ok := correlations[rowTicker+"/"+columnTicker]
+				switch {
+				case rowTicker == columnTicker:
+					result += " 1.00 |"
+				case ok:
+					result += fmt.Sprintf(" %.2f |", value)
+				default:
+					result += p.T(" нет данных |")
+				}
+			}
+			result += "\n"
+		}
+		if len(r.Pairs) > 0 {
+			result += p.Sprintf("\nСредняя попарная корреляция: %.2f\n", r.AvgPairCorrelation)
+		}
+	}
+
+	if len(r.Skipped) > 0 {
+		result += p.Sprintf("\nНедостаточно истории котировок, не вошли в расчет: %s\n", strings.Join(r.Skipped, ", "))
+	}
+
+	return result
+}
+
+// formatMoveExplanation форматирует факторы движения акции для модели на языке переводчика p
+func formatMoveExplanation(p i18n.Printer, e *models.MoveExplanation) string {
+	q := e.Quote
+
+	result := p.Sprintf("Движение %s за %s: %+.2f%%\n\n", e.Ticker, e.Date.Format("02.01.2006"), e.ChangePerc)
+
+	result += p.T("Вероятные драйверы:\n")
+	for i, driver := range e.Drivers {
+		result += fmt.Sprintf("%d. %s\n", i+1, driver)
+	}
+
+	result += p.T("\nСвеча:\n")
+	result += fmt.Sprintf("   O: %.2f  H: %.2f  L: %.2f  C: %.2f ₽\n", q.Open, q.High, q.Low, q.Close)
+	if e.PrevClose > 0 {
+		result += p.Sprintf("   Закрытие предыдущей сессии: %.2f ₽, гэп на открытии: %+.2f%%\n", e.PrevClose, e.GapPerc)
+	}
+	result += p.Sprintf("   Форма: %s\n", p.T(e.Candle.Pattern))
+	result += p.Sprintf("   Диапазон: %.2f%%, тело: %.0f%%, верхняя тень: %.0f%%, нижняя тень: %.0f%%\n",
+		e.Candle.RangePerc, e.Candle.BodyPerc, e.Candle.UpperShadowPerc, e.Candle.LowerShadowPerc)
+
+	result += p.T("\nОбъем:\n")
+	result += p.Sprintf("   За день: %d\n", q.Volume)
+	if e.VolumeSessions > 0 {
+		result += p.Sprintf("   Средний за %d сессий: %.0f (x%.2f)\n", e.VolumeSessions, e.AvgVolume, e.VolumeRatio)
+	}
+
+	if len(e.Intraday) > 0 {
+		result += p.T("\nПо часам (изменение к предыдущему часу, объем и его доля в сессии):\n")
+		for _, bar := range e.Intraday {
+			result += p.Sprintf("   %s  %.2f ₽  %+.2f%%  объем %d (%.0f%%)", bar.Time.Format("15:04"), bar.Close, bar.ChangePerc, bar.Volume, bar.VolumeShare)
+			if e.MoveBar != nil && bar.Time.Equal(e.MoveBar.Time) {
+				result += p.T("  ← самое сильное движение")
+			}
+			result += "\n"
+		}
+	}
+
+	result += p.T("\nРынок и сектор:\n")
+	if e.HasIndex {
+		result += p.Sprintf("   Индекс %s: %+.2f%%\n", e.IndexTicker, e.IndexChangePerc)
+	} else {
+		result += p.T("   Данные по индексу недоступны\n")
+	}
+	if len(e.SectorPeers) > 0 {
+		result += p.Sprintf("   Сектор «%s»: в среднем %+.2f%%\n", e.Sector, e.SectorChangePerc)
+		for _, peer := range e.SectorPeers {
+			result += fmt.Sprintf("   - %s: %+.2f%%\n", peer.Ticker, peer.ChangePerc)
+		}
+	} else if e.Sector != "" {
+		result += p.Sprintf("   Аналоги из сектора «%s» не найдены\n", e.Sector)
+	}
+
+	result += p.T("\nНовости по компании:\n")
+	if len(e.TickerNews) == 0 {
+		result += p.T("   Не найдено\n")
+	}
+	for i, item := range e.TickerNews {
+		timing := ""
+		if e.MoveBar != nil {
+			timing = p.T(" (после движения)")
+			if item.PublishedAt.Before(e.MoveBar.Time.Add(time.Hour)) {
+				timing = p.T(" (до движения)")
+			}
+		}
+		result += fmt.Sprintf("%d. [%s]%s %s\n", i+1, item.PublishedAt.In(models.MoscowLocation).Format("02.01 15:04"), timing, item.Title)
+		result += p.Sprintf("   Источник: %s, URL: %s\n", item.Source, item.URL)
+	}
+
+	if len(e.MarketNews) > 0 {
+		result += p.T("\nОбщие новости дня:\n")
+		for i, item := range e.MarketNews {
+			result += fmt.Sprintf("%d. [%s] %s\n", i+1, item.PublishedAt.Format("15:04"), item.Title)
+		}
+	}
+
+	return result
+}
+
+// handleTradeIdeasFromNewsPrompt обрабатывает запрос на шаблон торговых идей по новостям дня
+func (s *Server) handleTradeIdeasFromNewsPrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	maxTickers := 0
+	if maxArg := request.Params.Arguments["max_tickers"]; maxArg != "" {
+		parsed, err := strconv.Atoi(strings.TrimSpace(maxArg))
+		if err != nil {
+			return nil, fmt.Errorf("параметр max_tickers должен быть числом")
+		}
+		maxTickers = parsed
+	}
+
+	clusters, err := s.analysisService.GetNewsTickerClusters(ctx, maxTickers)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось сгруппировать новости по акциям: %w", err)
+	}
+
+	systemMessage := `Ты - трейдер и аналитик российского рынка акций, ищущий торговые возможности в новостном потоке.
+По новостям дня, сгруппированным по акциям, и текущим котировкам составь торговые идеи.
+Для каждой идеи укажи:
+1. Направление: long или short
+2. Обоснование: какая новость является катализатором и почему рынок еще не полностью ее учел (сопоставь тональность новостей с изменением цены за день)
+3. Горизонт идеи и что будет сигналом к выходу
+4. Уверенность: высокая, средняя или низкая
+
+Отсортируй идеи от самой сильной к самой слабой. Не предлагай идею, если новость уже отыграна ценой или ее влияние неочевидно; прямо скажи, если сильных идей нет.`
+
+	content := formatNewsTickerClusters(clusters)
+	if s.marginService != nil {
+		tickers := make([]string, len(clusters))
+		for i, cluster := range clusters {
+			tickers[i] = cluster.Ticker
+		}
+		if margin := s.marginContext(ctx, tickers); margin != "" {
+			content += margin
+			systemMessage += `
+Предлагай short только по бумагам, доступным для шорта по маржинальным спискам брокеров; для long с плечом учитывай ставку риска.`
+		}
+	}
+
+	return mcp.NewGetPromptResult(
+		"Торговые идеи по новостям",
+		[]mcp.PromptMessage{
+			mcp.NewPromptMessage(
+				mcp.RoleAssistant,
+				mcp.NewTextContent(systemMessage),
+			),
+			mcp.NewPromptMessage(
+				mcp.RoleUser,
+				mcp.NewTextContent(content),
+			),
+		},
+	), nil
+}
+
+// formatNewsTickerClusters форматирует новости дня, сгруппированные по акциям, с котировками
+func formatNewsTickerClusters(clusters []models.NewsTickerCluster) string {
+	result := "Новости за сегодня по акциям:\n\n"
+	for i, cluster := range clusters {
+		result += fmt.Sprintf("%d. %s", i+1, cluster.Ticker)
+		if cluster.Stock != nil {
+			result += fmt.Sprintf(" (%s): %.2f %s (%+.2f%%), объем %d",
+				cluster.Stock.Name, cluster.Stock.Price, render.CurrencySign(cluster.Ticker), cluster.Stock.ChangePerc, cluster.Stock.Volume)
+		} else {
+			result += ": котировка недоступна"
+		}
+		result += fmt.Sprintf("\n   Новостей: %d, средняя тональность: %+.2f\n", cluster.TotalNews, cluster.Sentiment)
+		for _, item := range cluster.News {
+			result += fmt.Sprintf("   - %s %s (%s)\n", item.PublishedAt.In(models.MoscowLocation).Format("15:04"), item.Title, item.Source)
+		}
+		result += "\n"
+	}
+
+	return result
+}
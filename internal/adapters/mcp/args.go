@@ -0,0 +1,222 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/i18n"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// bindArguments переносит аргументы вызова инструмента в поля структуры, на которую указывает dst, и проверяет их.
+// Поле описывается тегами:
+//   - arg:"name" или arg:"name,required" — имя аргумента и его обязательность;
+//   - enum:"a|b|c" — допустимые значения строки или элементов списка строк;
+//   - min:"1", max:"100" — границы числа или количества элементов списка.
+//
+// Поддерживаются поля string, bool, int, int64, float64 и []string; встроенные структуры разбираются рекурсивно.
+// Аргумент, для которого нет поля, считается ошибкой, чтобы опечатка в имени не подменялась значением по умолчанию.
+// Сообщения об ошибках возвращаются на языке ответа из контекста
+func bindArguments(ctx context.Context, request mcp.CallToolRequest, dst interface{}) error {
+	p := i18n.PrinterFrom(ctx)
+	var fields []argField
+	collectArgFields(reflect.ValueOf(dst).Elem(), &fields)
+
+	known := make(map[string]bool, len(fields))
+	names := make([]string, 0, len(fields))
+	for _, field := range fields {
+		known[field.name] = true
+		names = append(names, field.name)
+	}
+
+	unknown := make([]string, 0)
+	for name := range request.Params.Arguments {
+		if !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		if len(names) == 0 {
+			return p.Errorf("неизвестный параметр %s: инструмент не принимает параметров", unknown[0])
+		}
+		return p.Errorf("неизвестный параметр %s, допустимые параметры: %s", unknown[0], strings.Join(names, ", "))
+	}
+
+	for _, field := range fields {
+		raw := request.Params.Arguments[field.name]
+		if raw == nil {
+			if field.required {
+				return p.Errorf("параметр %s обязателен", field.name)
+			}
+			continue
+		}
+		if err := field.bind(p, raw); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// argField поле структуры аргументов, связанное с аргументом инструмента
+type argField struct {
+	name     string
+	required bool
+	tag      reflect.StructTag
+	value    reflect.Value
+}
+
+// collectArgFields собирает поля с тегом arg, включая поля встроенных структур
+func collectArgFields(v reflect.Value, fields *[]argField) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			collectArgFields(v.Field(i), fields)
+			continue
+		}
+
+		tag, ok := field.Tag.Lookup("arg")
+		if !ok {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		*fields = append(*fields, argField{
+			name:     name,
+			required: options == "required",
+			tag:      field.Tag,
+			value:    v.Field(i),
+		})
+	}
+}
+
+// bind проверяет тип и ограничения значения аргумента и записывает его в поле
+func (f argField) bind(p i18n.Printer, raw interface{}) error {
+	switch f.value.Kind() {
+	case reflect.String:
+		value, ok := raw.(string)
+		if !ok {
+			return p.Errorf("параметр %s должен быть строкой", f.name)
+		}
+		if value == "" {
+			if f.required {
+				return p.Errorf("параметр %s обязателен", f.name)
+			}
+			return nil
+		}
+		if err := f.checkEnum(p, value); err != nil {
+			return err
+		}
+		f.value.SetString(value)
+
+	case reflect.Bool:
+		value, ok := raw.(bool)
+		if !ok {
+			return p.Errorf("параметр %s должен быть логическим значением (true или false)", f.name)
+		}
+		f.value.SetBool(value)
+
+	case reflect.Int, reflect.Int64:
+		value, ok := raw.(float64)
+		if !ok || value != math.Trunc(value) {
+			return p.Errorf("параметр %s должен быть целым числом", f.name)
+		}
+		if err := f.checkRange(p, value, "параметр %s должен быть %s %s"); err != nil {
+			return err
+		}
+		f.value.SetInt(int64(value))
+
+	case reflect.Float64:
+		value, ok := raw.(float64)
+		if !ok {
+			return p.Errorf("параметр %s должен быть числом", f.name)
+		}
+		if err := f.checkRange(p, value, "параметр %s должен быть %s %s"); err != nil {
+			return err
+		}
+		f.value.SetFloat(value)
+
+	case reflect.Slice:
+		items, ok := raw.([]interface{})
+		if !ok {
+			return p.Errorf("параметр %s должен быть списком строк", f.name)
+		}
+		values := make([]string, 0, len(items))
+		for _, item := range items {
+			value, ok := item.(string)
+			if !ok {
+				return p.Errorf("параметр %s должен быть списком строк", f.name)
+			}
+			if err := f.checkEnum(p, value); err != nil {
+				return err
+			}
+			values = append(values, value)
+		}
+		if err := f.checkRange(p, float64(len(values)), "параметр %s должен содержать %s %s элементов"); err != nil {
+			return err
+		}
+		f.value.Set(reflect.ValueOf(values))
+
+	default:
+		panic(fmt.Sprintf("аргумент %s: неподдерживаемый тип поля %s", f.name, f.value.Type()))
+	}
+
+	return nil
+}
+
+// checkEnum проверяет, что значение входит в список допустимых из тега enum
+func (f argField) checkEnum(p i18n.Printer, value string) error {
+	enum, ok := f.tag.Lookup("enum")
+	if !ok {
+		return nil
+	}
+
+	allowed := strings.Split(enum, "|")
+	for _, option := range allowed {
+		if value == option {
+			return nil
+		}
+	}
+	return p.Errorf("параметр %s должен быть одним из: %s", f.name, strings.Join(allowed, ", "))
+}
+
+// checkRange проверяет значение по границам из тегов min и max.
+// Сообщение об ошибке строится по format из имени аргумента, «не меньше» или «не больше» и границы
+func (f argField) checkRange(p i18n.Printer, value float64, format string) error {
+	if bound, ok := f.tag.Lookup("min"); ok && value < parseArgBound(f.name, bound) {
+		return p.Errorf(format, f.name, p.T("не меньше"), bound)
+	}
+	if bound, ok := f.tag.Lookup("max"); ok && value > parseArgBound(f.name, bound) {
+		return p.Errorf(format, f.name, p.T("не больше"), bound)
+	}
+	return nil
+}
+
+// parseArgBound разбирает границу из тега; ошибка в теге — ошибка программиста
+func parseArgBound(name, bound string) float64 {
+	value, err := strconv.ParseFloat(bound, 64)
+	if err != nil {
+		panic(fmt.Sprintf("аргумент %s: неверная граница %q", name, bound))
+	}
+	return value
+}
+
+// noArgs аргументы инструментов без параметров
+type noArgs struct{}
+
+// parseDateArg разбирает значение аргумента name в формате YYYY-MM-DD в часовом поясе loc
+func parseDateArg(ctx context.Context, name, value string, loc *time.Location) (time.Time, error) {
+	parsed, err := time.ParseInLocation("2006-01-02", value, loc)
+	if err != nil {
+		return time.Time{}, i18n.PrinterFrom(ctx).Errorf("параметр %s должен быть в формате YYYY-MM-DD", name)
+	}
+	return parsed, nil
+}
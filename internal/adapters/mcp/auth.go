@@ -0,0 +1,143 @@
+package mcp
+
+import (
+	"context"
+	"crypto/subtle"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/config"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/i18n"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// authClient клиент SSE-транспорта, прошедший аутентификацию
+type authClient struct {
+	name  string
+	key   []byte
+	tools map[string]bool // Разрешенные инструменты; nil — все
+	// Собственные ограничения клиента; 0 — общие из rateLimit
+	callsPerMinute float64
+	dailyQuota     int
+}
+
+// allowed сообщает, разрешен ли клиенту инструмент
+func (c *authClient) allowed(tool string) bool {
+	return c.tools == nil || c.tools[tool]
+}
+
+type authClientKey struct{}
+
+// clientFromContext возвращает клиента запроса или nil для транспорта stdio, где аутентификации нет
+func clientFromContext(ctx context.Context) *authClient {
+	client, _ := ctx.Value(authClientKey{}).(*authClient)
+	return client
+}
+
+// newAuthClients готовит клиентов из auth.keys
+func newAuthClients(keys []config.ClientKeyConfig) []*authClient {
+	clients := make([]*authClient, 0, len(keys))
+	for _, key := range keys {
+		client := &authClient{name: key.Name, key: []byte(key.Key), callsPerMinute: key.CallsPerMinute, dailyQuota: key.DailyQuota}
+		if len(key.Tools) > 0 {
+			client.tools = make(map[string]bool, len(key.Tools))
+			for _, tool := range key.Tools {
+				client.tools[strings.TrimSpace(tool)] = true
+			}
+		}
+		clients = append(clients, client)
+	}
+	return clients
+}
+
+// authenticate находит клиента по ключу из заголовка Authorization: Bearer или X-API-Key.
+// Ключи сравниваются за постоянное время, чтобы их нельзя было подобрать по времени ответа
+func (s *Server) authenticate(r *http.Request) *authClient {
+	token := r.Header.Get("X-API-Key")
+	if auth := r.Header.Get("Authorization"); token == "" && len(auth) > len("Bearer ") && strings.EqualFold(auth[:len("Bearer ")], "Bearer ") {
+		token = strings.TrimSpace(auth[len("Bearer "):])
+	}
+	if token == "" {
+		return nil
+	}
+
+	var found *authClient
+	for _, client := range s.authClients {
+		if subtle.ConstantTimeCompare([]byte(token), client.key) == 1 {
+			found = client
+		}
+	}
+	return found
+}
+
+// requireAuth пропускает к SSE-серверу только запросы с известным ключом: без него нельзя ни открыть
+// сессию (/sse), ни отправить сообщение (/message). Клиент сохраняется в контексте запроса,
+// откуда его получают middleware инструментов
+func (s *Server) requireAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		client := s.authenticate(r)
+		if client == nil {
+			log.Printf("Отклонен запрос %s %s от %s: нет действующего ключа доступа", r.Method, r.URL.Path, r.RemoteAddr)
+			w.Header().Set("WWW-Authenticate", `Bearer realm="mcp"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if strings.HasSuffix(r.URL.Path, "/sse") {
+			log.Printf("Клиент %s открыл сессию MCP с %s", client.name, r.RemoteAddr)
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), authClientKey{}, client)))
+	})
+}
+
+// authMiddleware проверяет, разрешен ли инструмент клиенту, и записывает в журнал, кто его вызвал
+func (s *Server) authMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		client := clientFromContext(ctx)
+		if client == nil {
+			return next(ctx, request)
+		}
+
+		name := request.Params.Name
+		if !client.allowed(name) {
+			log.Printf("Клиенту %s отказано в вызове %s", client.name, name)
+			p := i18n.PrinterFrom(ctx)
+			return mcp.NewToolResultError(p.Sprintf("инструмент %s недоступен клиенту %s", name, client.name)), nil
+		}
+		log.Printf("Клиент %s вызывает %s", client.name, name)
+		return next(ctx, request)
+	}
+}
+
+// filterListedTools оставляет в ответе tools/list только инструменты, разрешенные клиенту
+func (s *Server) filterListedTools(ctx context.Context, _ any, _ *mcp.ListToolsRequest, result *mcp.ListToolsResult) {
+	client := clientFromContext(ctx)
+	if client == nil || client.tools == nil || result == nil {
+		return
+	}
+
+	tools := result.Tools[:0]
+	for _, tool := range result.Tools {
+		if client.allowed(tool.Name) {
+			tools = append(tools, tool)
+		}
+	}
+	result.Tools = tools
+}
+
+// serveSSE запускает HTTP-сервер транспорта SSE на server.host:server.port
+func (s *Server) serveSSE() error {
+	addr := net.JoinHostPort(s.config.Server.Host, strconv.Itoa(s.config.Server.Port))
+	baseURL := s.config.Server.BaseURL
+	if baseURL == "" {
+		baseURL = "http://" + addr
+	}
+
+	sseServer := server.NewSSEServer(s.server, server.WithBaseURL(baseURL), server.WithKeepAlive(true))
+	log.Printf("MCP сервер доступен по SSE на %s/sse, клиентов: %d", baseURL, len(s.authClients))
+	return http.ListenAndServe(addr, s.requireAuth(sseServer))
+}
@@ -0,0 +1,111 @@
+package mcp
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/adapters/render"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/i18n"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// resultBudget возвращает ограничение длины результата инструмента в символах: из server.toolMaxChars
+// или server.maxResultChars; 0 — без ограничения
+func (s *Server) resultBudget(tool string) int {
+	if limit, ok := s.config.Server.ToolMaxChars[tool]; ok {
+		return limit
+	}
+	return s.config.Server.MaxResultChars
+}
+
+// renderNewsList оформляет список новостей шаблоном name, укладываясь в ограничение длины результата.
+// Сначала из новостей убирается полный текст, затем описание, и только потом с конца списка отбрасываются
+// целые новости — заголовки, источники и ссылки остаются, а число опущенных новостей выводится в конце
+func (s *Server) renderNewsList(p i18n.Printer, name string, list render.NewsList) (*mcp.CallToolResult, error) {
+	budget := s.resultBudget(name)
+	if budget <= 0 {
+		return s.renderResult(p, name, list)
+	}
+
+	fits := func(list render.NewsList) bool {
+		text, err := s.renderer.Render(p, name, list)
+		return err == nil && utf8.RuneCountInString(text) <= budget
+	}
+	if fits(list) {
+		return s.renderResult(p, name, list)
+	}
+
+	news := slices.Clone(list.News)
+	list.News = news
+	for _, strip := range []func(*models.News){
+		func(n *models.News) { n.Content = "" },
+		func(n *models.News) { n.Description = "" },
+	} {
+		for i := range news {
+			strip(&news[i])
+		}
+		if fits(list) {
+			return s.renderResult(p, name, list)
+		}
+	}
+
+	// Наибольшее число первых новостей, которое помещается в ограничение
+	shown := func(n int) render.NewsList {
+		trimmed := list
+		trimmed.News = news[:n]
+		trimmed.Page.Shown = n
+		trimmed.Omitted = len(news) - n
+		return trimmed
+	}
+	lo, hi := 0, len(news)-1
+	for lo < hi {
+		mid := (lo + hi + 1) / 2
+		if fits(shown(mid)) {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+	// Если не помещается даже одна новость, результат обрежет budgetMiddleware
+	return s.renderResult(p, name, shown(max(lo, 1)))
+}
+
+// budgetMiddleware обрезает текст результата, превышающий ограничение длины, по границе строки
+// и сообщает, сколько символов опущено. Инструменты со списками сокращают результат аккуратнее сами,
+// здесь обрабатываются остальные
+func (s *Server) budgetMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		result, err := next(ctx, request)
+		budget := s.resultBudget(request.Params.Name)
+		if err != nil || result == nil || budget <= 0 {
+			return result, err
+		}
+
+		p := i18n.PrinterFrom(ctx)
+		for i, content := range result.Content {
+			text, ok := content.(mcp.TextContent)
+			if !ok || utf8.RuneCountInString(text.Text) <= budget {
+				continue
+			}
+			text.Text = truncateText(p, text.Text, budget)
+			result.Content[i] = text
+		}
+		return result, nil
+	}
+}
+
+// truncateText сокращает текст до budget символов по последнему переводу строки и добавляет пояснение
+func truncateText(p i18n.Printer, text string, budget int) string {
+	runes := []rune(text)
+	cut := string(runes[:budget])
+	if i := strings.LastIndex(cut, "\n"); i > 0 {
+		cut = cut[:i]
+	}
+	omitted := len(runes) - utf8.RuneCountInString(cut)
+	return cut + p.Sprintf("\n\n… результат сокращен до %d символов, опущено символов: %d. Уточните запрос или запросите меньше данных", budget, omitted)
+}
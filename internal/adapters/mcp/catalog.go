@@ -0,0 +1,154 @@
+package mcp
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// catalogURI адрес ресурса с каталогом инструментов и шаблонов
+const catalogURI = "catalog://tools"
+
+// catalogFiles примеры вызовов (catalog/examples/<имя>.json) и эталонные результаты к ним (catalog/golden/*.golden)
+//
+//go:embed catalog
+var catalogFiles embed.FS
+
+// catalogExample пример вызова инструмента или шаблона
+type catalogExample struct {
+	Description string                 `json:"description"`
+	Arguments   map[string]interface{} `json:"arguments"`
+	Golden      string                 `json:"golden,omitempty"` // Файл эталонного результата в catalog/golden
+	Output      string                 `json:"output,omitempty"` // Эталонный результат, заполняется из Golden
+}
+
+// catalogEntry описание инструмента или шаблона в каталоге
+type catalogEntry struct {
+	Name        string               `json:"name"`
+	Description string               `json:"description"`
+	InputSchema *mcp.ToolInputSchema `json:"input_schema,omitempty"`
+	Arguments   []mcp.PromptArgument `json:"arguments,omitempty"`
+	Sources     []string             `json:"sources,omitempty"`
+	Examples    []catalogExample     `json:"examples"`
+}
+
+// toolCatalog каталог инструментов и шаблонов сервера
+type toolCatalog struct {
+	Server  string         `json:"server"`
+	Version string         `json:"version"`
+	Tools   []catalogEntry `json:"tools"`
+	Prompts []catalogEntry `json:"prompts"`
+}
+
+// registerCatalog регистрирует ресурс с каталогом всех инструментов и шаблонов и примерами их вызова,
+// по которым клиентская модель может понять, как пользоваться сервером
+func (s *Server) registerCatalog() {
+	catalogResource := mcp.NewResource(catalogURI, "Каталог инструментов и шаблонов",
+		mcp.WithResourceDescription("Все инструменты и шаблоны сервера со схемами аргументов, примерами вызова и эталонными результатами"),
+		mcp.WithMIMEType("application/json"),
+	)
+
+	s.server.AddResource(catalogResource, s.handleReadCatalog)
+}
+
+// handleReadCatalog обрабатывает запрос на чтение каталога
+func (s *Server) handleReadCatalog(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	catalog, err := s.buildCatalog()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.MarshalIndent(catalog, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("ошибка сериализации каталога: %w", err)
+	}
+
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{
+			URI:      catalogURI,
+			MIMEType: "application/json",
+			Text:     string(data),
+		},
+	}, nil
+}
+
+// buildCatalog собирает каталог из зарегистрированных инструментов и шаблонов.
+// В каталог попадают только включенные в текущей конфигурации инструменты
+func (s *Server) buildCatalog() (*toolCatalog, error) {
+	catalog := &toolCatalog{
+		Server:  s.config.Server.Name,
+		Version: s.config.Server.Version,
+		Tools:   make([]catalogEntry, 0, len(s.tools)),
+		Prompts: make([]catalogEntry, 0, len(s.prompts)),
+	}
+
+	for _, tool := range s.tools {
+		examples, err := loadCatalogExamples(tool.Name)
+		if err != nil {
+			return nil, err
+		}
+		schema := tool.InputSchema
+		entry := catalogEntry{
+			Name:        tool.Name,
+			Description: tool.Description,
+			InputSchema: &schema,
+			Examples:    examples,
+		}
+		for _, source := range s.formatter.toolSources[tool.Name] {
+			entry.Sources = append(entry.Sources, sourceNames[source])
+		}
+		catalog.Tools = append(catalog.Tools, entry)
+	}
+
+	for _, prompt := range s.prompts {
+		examples, err := loadCatalogExamples(prompt.Name)
+		if err != nil {
+			return nil, err
+		}
+		catalog.Prompts = append(catalog.Prompts, catalogEntry{
+			Name:        prompt.Name,
+			Description: prompt.Description,
+			Arguments:   prompt.Arguments,
+			Examples:    examples,
+		})
+	}
+
+	return catalog, nil
+}
+
+// loadCatalogExamples загружает примеры вызова инструмента или шаблона вместе с эталонными результатами.
+// Для инструментов без примеров возвращается пустой список
+func loadCatalogExamples(name string) ([]catalogExample, error) {
+	examples := []catalogExample{}
+
+	data, err := catalogFiles.ReadFile(path.Join("catalog", "examples", name+".json"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return examples, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ошибка чтения примеров %s: %w", name, err)
+	}
+	if err := json.Unmarshal(data, &examples); err != nil {
+		return nil, fmt.Errorf("ошибка разбора примеров %s: %w", name, err)
+	}
+
+	for i := range examples {
+		if examples[i].Golden == "" {
+			continue
+		}
+		output, err := catalogFiles.ReadFile(path.Join("catalog", "golden", examples[i].Golden))
+		if err != nil {
+			return nil, fmt.Errorf("ошибка чтения эталонного результата %s: %w", examples[i].Golden, err)
+		}
+		examples[i].Output = string(output)
+		examples[i].Golden = ""
+	}
+
+	return examples, nil
+}
@@ -0,0 +1,297 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/config"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/services"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// updateGolden перезаписывает эталонные результаты каталога: go test ./internal/adapters/mcp -run TestCatalogGolden -update
+var updateGolden = flag.Bool("update", false, "перезаписать эталонные результаты catalog/golden по текущему оформлению")
+
+// Данные, на которых построены эталонные результаты каталога
+var (
+	msk         = time.FixedZone("MSK", 3*60*60)
+	catalogNow  = time.Date(2026, 10, 16, 14, 35, 0, 0, msk)
+	catalogDate = time.Date(2026, 10, 16, 0, 0, 0, 0, msk)
+)
+
+// catalogStockService котировки для примеров каталога. Остальные методы не нужны примерам и паникуют
+type catalogStockService struct {
+	services.StockService
+}
+
+func (catalogStockService) GetUniverses() []models.Universe {
+	return []models.Universe{{Name: "full", Description: "все акции"}, {Name: "blue_chips", Description: "голубые фишки"}}
+}
+
+func (catalogStockService) GetStockInfo(ctx context.Context, ticker string, board models.TradingBoard) (*models.Stock, error) {
+	return &models.Stock{Ticker: "SBER", Name: "Сбербанк", Price: 312.45, Change: 3.87, ChangePerc: 1.25, Volume: 48213500, UpdatedAt: catalogNow}, nil
+}
+
+func (catalogStockService) GetStockHistoricalData(ctx context.Context, ticker, interval string, startDate, endDate time.Time) ([]models.StockQuote, error) {
+	closes := []float64{
+		298.60, 296.25, 293.60, 294.05, 295.80, 298.90, 301.20, 299.45, 301.75, 303.40, 304.10,
+		302.30, 303.85, 306.20, 305.90, 308.45, 306.65, 308.80, 311.10, 310.40, 308.60,
+	}
+	history := make([]models.StockQuote, len(closes))
+	for i, close := range closes {
+		history[i] = models.StockQuote{Ticker: ticker, Close: close, Date: catalogDate.AddDate(0, 0, i-len(closes))}
+	}
+	return history, nil
+}
+
+func (catalogStockService) GetMOEXTopGainers(ctx context.Context, universe string, limit int) ([]models.Stock, error) {
+	stocks := []models.Stock{
+		{Ticker: "MTLR", Name: "Мечел", Price: 112.30, ChangePerc: 6.84, UpdatedAt: catalogNow},
+		{Ticker: "SMLT", Name: "Самолет", Price: 1432.50, ChangePerc: 4.12, UpdatedAt: catalogNow},
+		{Ticker: "VKCO", Name: "VK", Price: 301.60, ChangePerc: 3.55, UpdatedAt: catalogNow},
+	}
+	return stocks[:min(limit, len(stocks))], nil
+}
+
+func (catalogStockService) GetMarketBreadth(ctx context.Context, universe string) (*models.MarketBreadth, error) {
+	return &models.MarketBreadth{
+		Universe: universe, Total: 15, Advancers: 10, Decliners: 4, Unchanged: 1,
+		AvgChangePerc: 0.64, TotalVolume: 412450330, UpdatedAt: catalogNow,
+	}, nil
+}
+
+func (catalogStockService) CompareStocks(ctx context.Context, tickers []string) (*models.StockComparison, error) {
+	return &models.StockComparison{
+		Stocks: []models.ComparedStock{
+			{
+				Stock: models.Stock{Ticker: "SBER", Name: "Сбербанк", Price: 312.45, ChangePerc: 1.25, Volume: 48213500},
+				PE:    4.1, DividendYield: 10.6, Return1MPerc: 3.42, HasReturn1M: true, Return3MPerc: 8.15, HasReturn3M: true,
+			},
+			{
+				Stock: models.Stock{Ticker: "VTBR", Name: "ВТБ", Price: 78.40, ChangePerc: -0.62, Volume: 35120400},
+				PE:    2.7, Return1MPerc: -1.87, HasReturn1M: true,
+			},
+		},
+		UpdatedAt: catalogNow,
+	}, nil
+}
+
+// catalogNewsService сводка новостей для примеров каталога
+type catalogNewsService struct {
+	services.NewsService
+}
+
+func (catalogNewsService) GetNewsSummary(ctx context.Context, headlines int) (*models.NewsSummary, error) {
+	news := func(title, source string, hour int) models.News {
+		return models.News{Title: title, Source: source, PublishedAt: catalogDate.Add(time.Duration(hour)*time.Hour + 35*time.Minute)}
+	}
+	return &models.NewsSummary{
+		Date:  catalogDate,
+		Total: 24,
+		Groups: []models.NewsTopicGroup{
+			{Topic: "нефть и газ", Count: 7, Headlines: []models.News{
+				news("Brent подорожала до $84 на фоне решения ОПЕК+", "Интерфакс", 13),
+				news("Лукойл направит на дивиденды 100% свободного денежного потока", "РБК", 11),
+			}},
+			{Topic: "банки", Count: 5, Headlines: []models.News{
+				news("Сбербанк нарастил чистую прибыль по РСБУ на 9%", "Коммерсантъ", 12),
+			}},
+			{Topic: "макроэкономика", Count: 4, Headlines: []models.News{
+				news("Банк России сохранил ключевую ставку", "ТАСС", 10),
+			}},
+			{Topic: "прочее", Count: 8, Headlines: []models.News{
+				news("Московская биржа продлит торги в выходные дни", "Ведомости", 9),
+			}},
+		},
+	}, nil
+}
+
+// catalogPortfolioService портфель для примеров каталога: 100 акций SBER по 280 ₽
+type catalogPortfolioService struct {
+	services.PortfolioService
+}
+
+func (catalogPortfolioService) StressScenarios() []models.StressScenario {
+	return nil
+}
+
+func (catalogPortfolioService) AddPosition(ctx context.Context, portfolio, ticker string, quantity int64, price float64, dryRun bool) (*models.PositionChange, error) {
+	if portfolio == "" {
+		portfolio = "default"
+	}
+	before := &models.Position{Portfolio: portfolio, Ticker: ticker, Quantity: 100, AvgPrice: 280}
+	after := *before
+	after.Quantity += quantity
+	after.AvgPrice = (before.AvgPrice*float64(before.Quantity) + price*float64(quantity)) / float64(after.Quantity)
+	return &models.PositionChange{Before: before, After: &after, DryRun: dryRun, LotSize: 10}, nil
+}
+
+// catalogWatchlistService список наблюдения для примеров каталога
+type catalogWatchlistService struct {
+	services.WatchlistService
+}
+
+func (catalogWatchlistService) AddToWatchlist(ctx context.Context, watchlist, ticker string, thresholdPerc *float64, dryRun bool) (*models.WatchlistChange, error) {
+	if watchlist == "" {
+		watchlist = "default"
+	}
+	entry := &models.WatchlistEntry{Watchlist: watchlist, Ticker: ticker, AddedAt: catalogNow, AddedPrice: 128.40}
+	if thresholdPerc != nil {
+		entry.ThresholdPerc = *thresholdPerc
+	}
+	return &models.WatchlistChange{After: entry, DryRun: dryRun}, nil
+}
+
+// catalogMoodService индекс настроения для примеров каталога
+type catalogMoodService struct {
+	services.MoodService
+}
+
+func (catalogMoodService) GetMarketMood(ctx context.Context, historyDays int) (*models.MarketMoodReport, error) {
+	today := models.MarketMood{
+		Date: catalogDate, Score: 62, Label: "оптимизм",
+		Advancers: 27, Decliners: 14, BreadthScore: 0.32,
+		DispersionPerc: 1.21, VolatilityScore: 0.19,
+		NewsCount: 24, NewsScore: 0.08, HasNews: true,
+		FXTicker: "USD000UTSTOM", FXChangePerc: -0.35, FXScore: 0.35, HasFX: true,
+		UpdatedAt: catalogNow,
+	}
+	history := []models.MarketMood{
+		{Date: catalogDate.AddDate(0, 0, -2), Score: 44, Label: "нейтрально"},
+		{Date: catalogDate.AddDate(0, 0, -1), Score: 53, Label: "нейтрально"},
+		today,
+	}
+	return &models.MarketMoodReport{Today: today, History: history[max(len(history)-historyDays, 0):]}, nil
+}
+
+// catalogProfileService профили компаний для примеров каталога
+type catalogProfileService struct {
+	services.CompanyProfileService
+}
+
+func (catalogProfileService) GetCompanyProfile(ctx context.Context, ticker string) (*models.CompanyProfile, error) {
+	return &models.CompanyProfile{
+		Ticker: "LKOH", Name: "ЛУКОЙЛ", FullName: `Нефтяная компания "ЛУКОЙЛ" (ПАО) ао`, ISIN: "RU0009024277",
+		Sector: "Нефть и газ", Industry: "Добыча и переработка нефти",
+		MarketCap: 4.71e12, SharesOutstanding: 692865762, FreeFloatPerc: 49, ListingLevel: 1,
+		UpdatedAt: catalogNow,
+	}, nil
+}
+
+// newCatalogServer создает сервер с данными примеров каталога. Строки об источниках данных отключены:
+// в эталонных результатах только оформление инструмента
+func newCatalogServer(t *testing.T) *Server {
+	t.Helper()
+	cfg := &config.Config{}
+	cfg.Server.Name = "stocks-info-test"
+	cfg.Server.Language = "ru"
+	cfg.Attribution.Disabled = true
+
+	s := NewMCPServer(cfg, catalogStockService{}, catalogNewsService{},
+		WithPortfolio(catalogPortfolioService{}),
+		WithWatchlist(catalogWatchlistService{}),
+		WithMood(catalogMoodService{}),
+		WithCompanyProfiles(catalogProfileService{}),
+	)
+	s.register()
+	return s
+}
+
+// callCatalogTool вызывает инструмент через MCP сервер со всеми middleware и возвращает текст результата
+func callCatalogTool(t *testing.T, s *Server, name string, arguments map[string]interface{}) string {
+	t.Helper()
+	message, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": mcp.JSONRPC_VERSION,
+		"id":      1,
+		"method":  string(mcp.MethodToolsCall),
+		"params":  map[string]interface{}{"name": name, "arguments": arguments},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response, ok := s.server.HandleMessage(context.Background(), message).(mcp.JSONRPCResponse)
+	if !ok {
+		t.Fatalf("%s: ответ не является результатом вызова", name)
+	}
+	result, ok := response.Result.(mcp.CallToolResult)
+	if !ok {
+		t.Fatalf("%s: неожиданный результат %T", name, response.Result)
+	}
+
+	var texts []string
+	for _, content := range result.Content {
+		if text, ok := content.(mcp.TextContent); ok {
+			texts = append(texts, text.Text)
+		}
+	}
+	if result.IsError {
+		t.Fatalf("%s: инструмент вернул ошибку: %s", name, strings.Join(texts, "\n"))
+	}
+	return strings.Join(texts, "\n")
+}
+
+// TestCatalogGolden вызывает инструменты с аргументами примеров каталога и сравнивает результат с эталоном.
+// После намеренного изменения оформления эталоны обновляются флагом -update
+func TestCatalogGolden(t *testing.T) {
+	s := newCatalogServer(t)
+
+	files, err := fs.Glob(catalogFiles, "catalog/examples/*.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checked := 0
+	for _, file := range files {
+		name := strings.TrimSuffix(path.Base(file), ".json")
+		data, err := catalogFiles.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var examples []catalogExample
+		if err := json.Unmarshal(data, &examples); err != nil {
+			t.Fatalf("%s: %v", file, err)
+		}
+
+		for _, example := range examples {
+			if example.Golden == "" {
+				continue
+			}
+			checked++
+			t.Run(strings.TrimSuffix(example.Golden, ".golden"), func(t *testing.T) {
+				got := callCatalogTool(t, s, name, example.Arguments)
+
+				goldenPath := filepath.Join("catalog", "golden", example.Golden)
+				if *updateGolden {
+					if err := os.WriteFile(goldenPath, []byte(got), 0o644); err != nil {
+						t.Fatal(err)
+					}
+					return
+				}
+
+				want, err := os.ReadFile(goldenPath)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got != string(want) {
+					t.Errorf("результат %s отличается от эталона %s (обновить: -update)\n--- получено ---\n%s\n--- эталон ---\n%s",
+						name, goldenPath, got, want)
+				}
+			})
+		}
+	}
+
+	if checked == 0 {
+		t.Fatal("в каталоге нет примеров с эталонными результатами")
+	}
+}
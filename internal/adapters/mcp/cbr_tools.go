@@ -0,0 +1,114 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/i18n"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// registerCBRTools регистрирует инструменты ставок и официальных курсов Банка России
+func (s *Server) registerCBRTools() {
+	if s.cbrService == nil {
+		return
+	}
+
+	getKeyRateTool := mcp.NewTool("get_cbr_key_rate",
+		mcp.WithDescription(s.printer.T("Получить текущую ключевую ставку Банка России, дату и размер ее последнего изменения, а также ставку RUONIA")),
+	)
+
+	s.addTool(getKeyRateTool, s.handleGetCBRKeyRate, sourceCBR)
+
+	getFXRateTool := mcp.NewTool("get_official_fx_rate",
+		mcp.WithDescription(s.printer.T("Получить официальный курс валюты, установленный Банком России")),
+		mcp.WithString("currency",
+			mcp.Description(s.printer.Sprintf("Буквенный код валюты (например, USD); если не указан, возвращаются курсы %s", strings.Join(models.DefaultOfficialFXCurrencies, ", "))),
+		),
+		mcp.WithString("date",
+			mcp.Description(s.printer.T("Дата в формате YYYY-MM-DD (по умолчанию сегодня)")),
+		),
+	)
+
+	s.addTool(getFXRateTool, s.handleGetOfficialFXRate, sourceCBR)
+}
+
+// handleGetCBRKeyRate обрабатывает запрос на получение ключевой ставки
+func (s *Server) handleGetCBRKeyRate(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args noArgs
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	p := i18n.PrinterFrom(ctx)
+	summary, err := s.cbrService.GetKeyRate(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(p.Sprintf("не удалось получить ключевую ставку: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(formatKeyRate(p, summary)), nil
+}
+
+// handleGetOfficialFXRate обрабатывает запрос на получение официального курса валюты
+func (s *Server) handleGetOfficialFXRate(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		Currency string `arg:"currency"`
+		Date     string `arg:"date"`
+	}
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	p := i18n.PrinterFrom(ctx)
+	var currencies []string
+	if args.Currency != "" {
+		currencies = []string{args.Currency}
+	}
+
+	var date time.Time
+	if args.Date != "" {
+		parsed, err := parseDateArg(ctx, "date", args.Date, models.MoscowLocation)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		date = parsed
+	}
+
+	rates, err := s.cbrService.GetOfficialFXRates(ctx, currencies, date)
+	if err != nil {
+		return mcp.NewToolResultError(p.Sprintf("не удалось получить официальный курс: %v", err)), nil
+	}
+
+	result := p.Sprintf("Официальные курсы Банка России на %s:\n", rates[0].Date.Format("02.01.2006"))
+	for _, rate := range rates {
+		if rate.Nominal > 1 {
+			result += p.Sprintf("- %s (%s): %.4f ₽ за %d (%.4f ₽ за единицу)\n", rate.Code, rate.Name, rate.Rate, rate.Nominal, rate.UnitRate)
+		} else {
+			result += fmt.Sprintf("- %s (%s): %.4f ₽\n", rate.Code, rate.Name, rate.Rate)
+		}
+	}
+
+	return mcp.NewToolResultText(result), nil
+}
+
+// formatKeyRate форматирует ключевую ставку и ставку RUONIA на языке переводчика p
+func formatKeyRate(p i18n.Printer, summary *models.KeyRateSummary) string {
+	result := p.Sprintf("Ключевая ставка Банка России: %.2f%%", summary.Current.Rate)
+	if summary.Previous != nil {
+		result += p.Sprintf(" с %s (было %.2f%%, изменение %+.2f п.п.)\n",
+			summary.ChangedAt.Format("02.01.2006"), summary.Previous.Rate, summary.Current.Rate-summary.Previous.Rate)
+	} else {
+		result += p.Sprintf(", без изменений как минимум с %s\n", summary.ChangedAt.Format("02.01.2006"))
+	}
+
+	if summary.RUONIA != nil {
+		result += p.Sprintf("RUONIA: %.2f%% на %s, объем сделок %.1f млрд ₽\n",
+			summary.RUONIA.Rate, summary.RUONIA.Date.Format("02.01.2006"), summary.RUONIA.VolumeBln)
+	}
+
+	return result
+}
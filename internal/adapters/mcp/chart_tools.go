@@ -0,0 +1,173 @@
+package mcp
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/chart"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/i18n"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// chartURITemplate шаблон адреса ресурса с графиком тикера за период по умолчанию
+const chartURITemplate = "chart://{ticker}/{interval}"
+
+// registerChartTools регистрирует инструмент и ресурс графиков котировок
+func (s *Server) registerChartTools() {
+	getStockChartTool := mcp.NewTool("get_stock_chart",
+		mcp.WithDescription(s.printer.T("Построить график котировок акции за период в PNG: свечи или линию цены закрытия с объемами торгов. Клиенты, которые умеют показывать изображения, выводят его пользователю")),
+		mcp.WithString("ticker",
+			mcp.Required(),
+			mcp.Description(s.printer.T("Тикер акции (например, SBER, GAZP, LKOH); для бумаг других бирж — с префиксом биржи, например NASDAQ:AAPL или XETRA:SAP")),
+		),
+		mcp.WithString("interval",
+			mcp.Description(s.printer.T("Интервал свечей: 1m, 10m, 1h или 1d (по умолчанию 1d). Период внутридневных свечей ограничен: 1m — сутки, 10m — неделя, 1h — месяц")),
+			mcp.Enum(models.IntervalMinute, models.IntervalTenMinute, models.IntervalHour, models.IntervalDay),
+		),
+		mcp.WithString("from",
+			mcp.Description(s.printer.T("Начало периода в формате YYYY-MM-DD или YYYY-MM-DD HH:MM по московскому времени (по умолчанию месяц назад для дневных свечей и сутки назад для внутридневных)")),
+		),
+		mcp.WithString("to",
+			mcp.Description(s.printer.T("Конец периода в формате YYYY-MM-DD (включительно) или YYYY-MM-DD HH:MM по московскому времени (по умолчанию сейчас)")),
+		),
+		mcp.WithString("style",
+			mcp.Description(s.printer.T("Вид графика: candles — свечи (по умолчанию), line — линия цены закрытия")),
+			mcp.Enum(string(chart.StyleCandles), string(chart.StyleLine)),
+		),
+		mcp.WithNumber("width",
+			mcp.Description(s.printer.Sprintf("Ширина изображения в пикселях (по умолчанию %d)", chart.DefaultWidth)),
+		),
+		mcp.WithNumber("height",
+			mcp.Description(s.printer.Sprintf("Высота изображения в пикселях (по умолчанию %d)", chart.DefaultHeight)),
+		),
+	)
+
+	s.addTool(getStockChartTool, s.handleGetStockChart, sourceMOEX, sourceYahoo)
+
+	// Тот же график доступен ресурсом: клиент может прикрепить его к диалогу без вызова инструмента
+	chartTemplate := mcp.NewResourceTemplate(chartURITemplate, s.printer.T("График котировок"),
+		mcp.WithTemplateDescription(s.printer.T("Свечной график акции в PNG за период по умолчанию: месяц для дневных свечей (interval 1d), сутки для внутридневных (1m, 10m, 1h)")),
+		mcp.WithTemplateMIMEType("image/png"),
+	)
+
+	s.server.AddResourceTemplate(chartTemplate, s.handleReadChart)
+}
+
+// handleGetStockChart обрабатывает запрос на построение графика котировок
+func (s *Server) handleGetStockChart(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	p := i18n.PrinterFrom(ctx)
+	args := struct {
+		Ticker   string `arg:"ticker,required"`
+		Interval string `arg:"interval" enum:"1m|10m|1h|1d"`
+		From     string `arg:"from"`
+		To       string `arg:"to"`
+		Style    string `arg:"style" enum:"candles|line"`
+		Width    int    `arg:"width" min:"320" max:"2000"`
+		Height   int    `arg:"height" min:"240" max:"1200"`
+	}{Interval: models.IntervalDay, Style: string(chart.StyleCandles), Width: chart.DefaultWidth, Height: chart.DefaultHeight}
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	from, to, err := historyPeriod(p, args.From, args.To)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	image, summary, err := s.stockChart(ctx, args.Ticker, args.Interval, from, to, chart.Options{
+		Style:  chart.Style(args.Style),
+		Width:  args.Width,
+		Height: args.Height,
+	})
+	if err != nil {
+		return mcp.NewToolResultError(p.Sprintf("не удалось построить график: %v", err)), nil
+	}
+
+	return mcp.NewToolResultImage(summary, base64.StdEncoding.EncodeToString(image), "image/png"), nil
+}
+
+// handleReadChart обрабатывает запрос на чтение ресурса графика chart://{ticker}/{interval}
+func (s *Server) handleReadChart(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	ticker, interval := resourceArgument(request, "ticker"), resourceArgument(request, "interval")
+	if _, ok := models.FindQuoteInterval(interval); !ok {
+		return nil, fmt.Errorf("неподдерживаемый интервал %s", interval)
+	}
+
+	image, _, err := s.stockChart(ctx, ticker, interval, time.Time{}, time.Time{}, chart.Options{})
+	if err != nil {
+		return nil, err
+	}
+
+	return []mcp.ResourceContents{
+		mcp.BlobResourceContents{
+			URI:      request.Params.URI,
+			MIMEType: "image/png",
+			Blob:     base64.StdEncoding.EncodeToString(image),
+		},
+	}, nil
+}
+
+// stockChart загружает свечи тикера за период и рисует по ним график.
+// Возвращает PNG и текстовую сводку графика для клиентов, которые не показывают изображения
+func (s *Server) stockChart(ctx context.Context, ticker, interval string, from, to time.Time, opts chart.Options) ([]byte, string, error) {
+	p := i18n.PrinterFrom(ctx)
+	ticker = models.NormalizeTicker(ticker)
+	history, err := s.stockService.GetStockHistoricalData(ctx, ticker, interval, from, to)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(history) == 0 {
+		return nil, "", p.Errorf("нет свечей %s с интервалом %s за указанный период", ticker, interval)
+	}
+
+	intraday := models.IsIntraday(interval)
+	bars := make([]chart.Bar, len(history))
+	for i, quote := range history {
+		// Дневные свечи хранятся под датой торгов в UTC, внутридневные подписываются московским временем
+		at := quote.Date
+		if intraday {
+			at = at.In(models.MoscowLocation)
+		}
+		bars[i] = chart.Bar{Time: at, Open: quote.Open, High: quote.High, Low: quote.Low, Close: quote.Close, Volume: quote.Volume}
+	}
+
+	first, last := bars[0], bars[len(bars)-1]
+	dateFormat := "02.01.06"
+	opts.TimeFormat = dateFormat
+	if intraday {
+		dateFormat = "02.01.06 15:04"
+		opts.TimeFormat = "02.01 15:04"
+	}
+	changePerc := 0.0
+	if first.Open > 0 {
+		changePerc = (last.Close - first.Open) / first.Open * 100
+	}
+	opts.Title = p.Sprintf("%s %s  %s – %s  закрытие %.2f (%+.2f%%)", ticker, interval,
+		first.Time.Format(dateFormat), last.Time.Format(dateFormat), last.Close, changePerc)
+
+	image, err := chart.Render(bars, opts)
+	if err != nil {
+		return nil, "", err
+	}
+
+	summary := p.Sprintf("График %s (%s) за %s – %s: свечей %d, закрытие %.2f, изменение за период %+.2f%%",
+		ticker, interval, first.Time.Format(dateFormat), last.Time.Format(dateFormat), len(bars), last.Close, changePerc)
+	return image, summary, nil
+}
+
+// resourceArgument возвращает значение переменной шаблона адреса ресурса
+func resourceArgument(request mcp.ReadResourceRequest, name string) string {
+	switch value := request.Params.Arguments[name].(type) {
+	case string:
+		return value
+	case []string:
+		if len(value) > 0 {
+			return value[0]
+		}
+	}
+	return ""
+}
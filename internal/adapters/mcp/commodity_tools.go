@@ -0,0 +1,88 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/i18n"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// registerCommodityTools регистрирует инструмент цен сырьевых товаров
+func (s *Server) registerCommodityTools() {
+	if s.commodityService == nil {
+		return
+	}
+
+	codes := make([]string, 0, len(models.Commodities))
+	for _, commodity := range models.Commodities {
+		codes = append(codes, commodity.Code)
+	}
+
+	getCommodityPriceTool := mcp.NewTool("get_commodity_price",
+		mcp.WithDescription(s.printer.T("Получить цену сырьевого товара (нефть Brent и Urals, золото, серебро, природный газ) в долларах и рублях по ближайшему ликвидному фьючерсу срочного рынка MOEX")),
+		mcp.WithString("commodity",
+			mcp.Description(s.printer.T("Код товара; если не указан, возвращаются цены всех товаров")),
+			mcp.Enum(codes...),
+		),
+	)
+
+	s.addTool(getCommodityPriceTool, s.handleGetCommodityPrice, sourceMOEX)
+}
+
+// handleGetCommodityPrice обрабатывает запрос на получение цены сырьевого товара
+func (s *Server) handleGetCommodityPrice(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		Commodity string `arg:"commodity"`
+	}
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	p := i18n.PrinterFrom(ctx)
+	if args.Commodity == "" {
+		quotes, err := s.commodityService.GetCommodityPrices(ctx)
+		if err != nil {
+			return mcp.NewToolResultError(p.Sprintf("не удалось получить цены сырьевых товаров: %v", err)), nil
+		}
+		return mcp.NewToolResultText(formatCommodityQuotes(p, quotes)), nil
+	}
+
+	quote, err := s.commodityService.GetCommodityPrice(ctx, args.Commodity)
+	if err != nil {
+		return mcp.NewToolResultError(p.Sprintf("не удалось получить цену сырьевого товара: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(formatCommodityQuotes(p, []models.CommodityQuote{*quote})), nil
+}
+
+// formatCommodityQuotes форматирует цены сырьевых товаров на языке переводчика p
+func formatCommodityQuotes(p i18n.Printer, quotes []models.CommodityQuote) string {
+	result := p.T("Цены сырьевых товаров (фьючерсы MOEX):\n")
+	for _, quote := range quotes {
+		result += p.Sprintf("- %s: $%.2f за %s (%+.2f%%)", p.T(quote.Name), quote.PriceUSD, p.T(quote.Unit), quote.ChangePerc)
+		if quote.PriceRUB > 0 {
+			result += fmt.Sprintf(", %.2f ₽", quote.PriceRUB)
+		}
+		result += "\n"
+
+		contract := p.Sprintf("  Контракт %s", quote.Contract)
+		if !quote.Expiration.IsZero() {
+			contract += p.Sprintf(", исполнение %s", quote.Expiration.Format("02.01.2006"))
+		}
+		if quote.Estimated {
+			contract += "; " + quote.Note
+		}
+		result += contract + "\n"
+	}
+
+	if len(quotes) > 0 && quotes[0].USDRUB > 0 {
+		result += p.Sprintf("Курс пересчета: %.4f ₽ за доллар\n", quotes[0].USDRUB)
+	} else {
+		result += p.T("Курс доллара недоступен, рублевые цены не рассчитаны\n")
+	}
+
+	return result
+}
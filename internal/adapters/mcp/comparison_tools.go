@@ -0,0 +1,226 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/i18n"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// handleCompareStocks обрабатывает запрос на сравнение акций
+func (s *Server) handleCompareStocks(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// Границы совпадают с models.MinComparedStocks и models.MaxComparedStocks
+	var args struct {
+		Tickers []string `arg:"tickers,required" min:"2" max:"5"`
+	}
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	p := i18n.PrinterFrom(ctx)
+
+	comparison, err := s.stockService.CompareStocks(ctx, args.Tickers)
+	if err != nil {
+		return mcp.NewToolResultError(p.Sprintf("не удалось сравнить акции: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(formatStockComparison(p, comparison)), nil
+}
+
+// handleStockComparisonPrompt обрабатывает запрос на шаблон сравнительного анализа акций
+func (s *Server) handleStockComparisonPrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	tickersArg, ok := request.Params.Arguments["tickers"]
+	if !ok || tickersArg == "" {
+		return nil, fmt.Errorf("требуется параметр tickers")
+	}
+
+	comparison, err := s.stockService.CompareStocks(ctx, strings.Split(tickersArg, ","))
+	if err != nil {
+		return nil, fmt.Errorf("не удалось сравнить акции: %w", err)
+	}
+
+	tickers := make([]string, 0, len(comparison.Stocks))
+	for _, stock := range comparison.Stocks {
+		tickers = append(tickers, stock.Ticker)
+	}
+
+	systemMessage := `Ты - финансовый аналитик, специализирующийся на российском рынке акций.
+Сравни акции из предоставленной таблицы.
+
+Предоставь сравнительный анализ, включая:
+1. Динамику цены за день, месяц и 3 месяца
+2. Оценку по мультипликатору P/E и дивидендной доходности
+3. Ликвидность по объему торгов
+4. Итог: какие бумаги выглядят сильнее и при каких условиях выводы могут измениться
+
+Опирайся только на данные таблицы; если показателя нет, прямо укажи это и не додумывай значение.`
+
+	return mcp.NewGetPromptResult(
+		fmt.Sprintf("Сравнение акций %s", strings.Join(tickers, ", ")),
+		[]mcp.PromptMessage{
+			mcp.NewPromptMessage(
+				mcp.RoleAssistant,
+				mcp.NewTextContent(systemMessage),
+			),
+			mcp.NewPromptMessage(
+				mcp.RoleUser,
+				mcp.NewTextContent(formatStockComparison(i18n.PrinterFrom(ctx), comparison)),
+			),
+		},
+	), nil
+}
+
+// handleCompareAnalysisPrompt обрабатывает запрос на шаблон относительной оценки 2–3 акций
+// с нормированной историей цен, чтобы модели не требовались дополнительные вызовы инструментов
+func (s *Server) handleCompareAnalysisPrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	tickersArg := request.Params.Arguments["tickers"]
+	if tickersArg == "" {
+		return nil, fmt.Errorf("требуется параметр tickers")
+	}
+
+	var tickers []string
+	seen := make(map[string]bool)
+	for _, ticker := range strings.Split(tickersArg, ",") {
+		ticker = strings.ToUpper(strings.TrimSpace(ticker))
+		if ticker != "" && !seen[ticker] {
+			seen[ticker] = true
+			tickers = append(tickers, ticker)
+		}
+	}
+	if len(tickers) < models.MinComparedStocks || len(tickers) > models.MaxCompareAnalysisStocks {
+		return nil, fmt.Errorf("для анализа нужно от %d до %d разных тикеров, передано %d",
+			models.MinComparedStocks, models.MaxCompareAnalysisStocks, len(tickers))
+	}
+
+	comparison, err := s.stockService.CompareStocks(ctx, tickers)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось сравнить акции: %w", err)
+	}
+
+	content := formatStockComparison(i18n.PrinterFrom(ctx), comparison) + "\n\n"
+	if returns, err := s.stockService.GetNormalizedReturns(ctx, tickers, models.CompareAnalysisMonths); err != nil {
+		log.Printf("ПРЕДУПРЕЖДЕНИЕ: не удалось построить нормированную историю цен: %v", err)
+		content += fmt.Sprintf("Нормированная история цен за %d месяцев недоступна.\n", models.CompareAnalysisMonths)
+	} else {
+		content += formatNormalizedReturns(returns)
+	}
+	if targets := s.priceTargetsContext(ctx, tickers); targets != "" {
+		content += "\n" + targets
+	}
+
+	systemMessage := `Ты - финансовый аналитик, специализирующийся на относительной оценке акций российского рынка.
+Проведи сравнительный анализ акций по таблице показателей и нормированной истории цен (100 — начало периода).
+Включи в анализ:
+1. Относительную динамику за период: кто опережал, кто отставал и на каких отрезках менялось лидерство
+2. Риск: максимальные просадки и устойчивость к падениям рынка
+3. Оценку по мультипликаторам, дивидендной доходности и потенциалу до целевых цен аналитиков, если они приведены: какая бумага дешевле относительно других
+4. Вывод об относительной привлекательности: какую бумагу предпочесть и при каких условиях вывод изменится
+
+Все нужные данные приведены ниже; опирайся только на них и не додумывай отсутствующие значения.`
+
+	return mcp.NewGetPromptResult(
+		fmt.Sprintf("Сравнительный анализ %s", strings.Join(tickers, ", ")),
+		[]mcp.PromptMessage{
+			mcp.NewPromptMessage(
+				mcp.RoleAssistant,
+				mcp.NewTextContent(systemMessage),
+			),
+			mcp.NewPromptMessage(
+				mcp.RoleUser,
+				mcp.NewTextContent(content),
+			),
+		},
+	), nil
+}
+
+// formatNormalizedReturns форматирует нормированную историю цен компактной таблицей: строки — даты, столбцы — акции
+func formatNormalizedReturns(r *models.NormalizedReturns) string {
+	result := fmt.Sprintf("Нормированные цены закрытия (100 = %s), шаг %d сессий:\n\n",
+		r.Points[0].Date.Format("02.01.2006"), models.NormalizedSeriesStep)
+
+	result += "| Дата |"
+	separator := "|---|"
+	for _, ticker := range r.Tickers {
+		result += fmt.Sprintf(" %s |", ticker)
+		separator += "---|"
+	}
+	result += "\n" + separator + "\n"
+	for _, point := range r.Points {
+		result += fmt.Sprintf("| %s |", point.Date.Format("02.01.2006"))
+		for _, value := range point.Values {
+			result += fmt.Sprintf(" %.1f |", value)
+		}
+		result += "\n"
+	}
+
+	result += "\nИтоги периода:\n"
+	for _, stats := range r.Stats {
+		result += fmt.Sprintf("- %s: доходность %+.2f%%, максимальная просадка %.2f%%\n", stats.Ticker, stats.ReturnPerc, stats.MaxDrawdownPerc)
+	}
+	if len(r.Skipped) > 0 {
+		result += fmt.Sprintf("Нет истории котировок с начала периода, не вошли в сравнение: %s\n", strings.Join(r.Skipped, ", "))
+	}
+
+	return result
+}
+
+// formatStockComparison форматирует сравнение акций таблицей: строки — показатели, столбцы — акции на языке переводчика p
+func formatStockComparison(p i18n.Printer, c *models.StockComparison) string {
+	header := p.T("| Показатель |")
+	separator := "|---|"
+	for _, stock := range c.Stocks {
+		header += fmt.Sprintf(" %s |", stock.Ticker)
+		separator += "---|"
+	}
+
+	rows := []struct {
+		name  string
+		value func(stock models.ComparedStock) string
+	}{
+		{"Название", func(stock models.ComparedStock) string { return stock.Name }},
+		{"Цена, ₽", func(stock models.ComparedStock) string { return fmt.Sprintf("%.2f", stock.Price) }},
+		{"Изменение за день", func(stock models.ComparedStock) string { return fmt.Sprintf("%+.2f%%", stock.ChangePerc) }},
+		{"Объем торгов", func(stock models.ComparedStock) string { return fmt.Sprintf("%d", stock.Volume) }},
+		{"P/E", func(stock models.ComparedStock) string {
+			if stock.PE == 0 {
+				return p.T("нет данных")
+			}
+			return fmt.Sprintf("%.2f", stock.PE)
+		}},
+		{"Дивидендная доходность", func(stock models.ComparedStock) string {
+			if stock.DividendYield == 0 {
+				return p.T("нет данных")
+			}
+			return fmt.Sprintf("%.2f%%", stock.DividendYield)
+		}},
+		{"Доходность за 1 месяц", func(stock models.ComparedStock) string {
+			if !stock.HasReturn1M {
+				return p.T("нет данных")
+			}
+			return fmt.Sprintf("%+.2f%%", stock.Return1MPerc)
+		}},
+		{"Доходность за 3 месяца", func(stock models.ComparedStock) string {
+			if !stock.HasReturn3M {
+				return p.T("нет данных")
+			}
+			return fmt.Sprintf("%+.2f%%", stock.Return3MPerc)
+		}},
+	}
+
+	result := p.T("Сравнение акций:\n\n") + header + "\n" + separator + "\n"
+	for _, row := range rows {
+		result += fmt.Sprintf("| %s |", p.T(row.name))
+		for _, stock := range c.Stocks {
+			result += fmt.Sprintf(" %s |", row.value(stock))
+		}
+		result += "\n"
+	}
+	result += p.Sprintf("\nДата обновления: %s", c.UpdatedAt.Format("2006-01-02 15:04:05"))
+
+	return result
+}
@@ -0,0 +1,64 @@
+package mcp
+
+import (
+	"context"
+	"strings"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/i18n"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// registerCryptoTools регистрирует инструмент котировок криптовалют
+func (s *Server) registerCryptoTools() {
+	if s.cryptoService == nil {
+		return
+	}
+
+	getCryptoPriceTool := mcp.NewTool("get_crypto_price",
+		mcp.WithDescription(s.printer.T("Получить котировку криптовалюты в долларах и рублях: изменение за 24 часа, капитализацию и объем торгов")),
+		mcp.WithString("symbol",
+			mcp.Description(s.printer.Sprintf("Тикер монеты (%s); если не указан, возвращаются котировки всех монет", strings.Join(s.cryptoService.GetSymbols(), ", "))),
+		),
+	)
+
+	s.addTool(getCryptoPriceTool, s.handleGetCryptoPrice, sourceCrypto)
+}
+
+// handleGetCryptoPrice обрабатывает запрос на получение котировки криптовалюты
+func (s *Server) handleGetCryptoPrice(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		Symbol string `arg:"symbol"`
+	}
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	p := i18n.PrinterFrom(ctx)
+
+	if args.Symbol == "" {
+		quotes, err := s.cryptoService.GetCryptoPrices(ctx)
+		if err != nil {
+			return mcp.NewToolResultError(p.Sprintf("не удалось получить котировки криптовалют: %v", err)), nil
+		}
+		return mcp.NewToolResultText(formatCryptoQuotes(p, quotes)), nil
+	}
+
+	quote, err := s.cryptoService.GetCryptoPrice(ctx, args.Symbol)
+	if err != nil {
+		return mcp.NewToolResultError(p.Sprintf("не удалось получить котировку криптовалюты: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(formatCryptoQuotes(p, []models.CryptoQuote{*quote})), nil
+}
+
+// formatCryptoQuotes форматирует котировки криптовалют на языке переводчика p
+func formatCryptoQuotes(p i18n.Printer, quotes []models.CryptoQuote) string {
+	result := p.T("Криптовалюты:\n")
+	for _, quote := range quotes {
+		result += p.Sprintf("- %s: $%.2f (%.0f ₽), за 24 часа %+.2f%%\n", quote.Symbol, quote.PriceUSD, quote.PriceRUB, quote.ChangePerc)
+		result += p.Sprintf("  Капитализация: $%.1f млрд, объем за 24 часа: $%.1f млрд\n", quote.MarketCapUSD/1e9, quote.VolumeUSD/1e9)
+	}
+	return result
+}
@@ -0,0 +1,189 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/adapters/prompts"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/adapters/render"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/i18n"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// maxPromptListLimit наибольшее количество акций или новостей, которое шаблон оператора может запросить
+const maxPromptListLimit = 50
+
+// registerCustomPrompts регистрирует шаблоны из каталога оператора. Шаблон с именем встроенного заменяет его
+func (s *Server) registerCustomPrompts() {
+	for _, t := range s.customPrompts {
+		options := []mcp.PromptOption{mcp.WithPromptDescription(t.Description)}
+		for _, arg := range t.Arguments {
+			argOptions := []mcp.ArgumentOption{mcp.ArgumentDescription(arg.Description)}
+			if arg.Required {
+				argOptions = append(argOptions, mcp.RequiredArgument())
+			}
+			options = append(options, mcp.WithArgument(arg.Name, argOptions...))
+		}
+
+		s.addPrompt(mcp.NewPrompt(t.Name, options...), s.customPromptHandler(t), t.Features...)
+	}
+}
+
+// customPromptHandler возвращает обработчик шаблона оператора
+func (s *Server) customPromptHandler(t *prompts.Template) server.PromptHandlerFunc {
+	return func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		messages, err := t.Execute(ctx, promptResolver{s: s}, request.Params.Arguments)
+		if err != nil {
+			return nil, err
+		}
+
+		result := make([]mcp.PromptMessage, 0, len(messages))
+		for _, message := range messages {
+			role := mcp.RoleUser
+			if message.Role == prompts.RoleAssistant {
+				role = mcp.RoleAssistant
+			}
+			result = append(result, mcp.NewPromptMessage(role, mcp.NewTextContent(message.Content)))
+		}
+
+		return mcp.NewGetPromptResult(t.Description, result), nil
+	}
+}
+
+// promptResolver подставляет в шаблоны оператора данные сервисов сервера.
+// Функции отключенных модулей возвращают ошибку, чтобы оператор сразу увидел, что шаблон на них опирается
+type promptResolver struct {
+	s *Server
+}
+
+// Stock возвращает карточку акции с текущей котировкой
+func (r promptResolver) Stock(ctx context.Context, ticker string) (string, error) {
+	stock, err := r.s.stockService.GetStockInfo(ctx, ticker, models.TradingBoard{})
+	if err != nil {
+		return "", fmt.Errorf("не удалось получить информацию об акции %s: %w", ticker, err)
+	}
+	if stock == nil {
+		return "", fmt.Errorf("акция с тикером %s не найдена", ticker)
+	}
+	return r.s.renderer.Render(i18n.PrinterFrom(ctx), render.StockInfo, render.StockCard{Stock: *stock})
+}
+
+// News возвращает не более limit новостей по тикеру
+func (r promptResolver) News(ctx context.Context, ticker string, limit int) (string, error) {
+	news, err := r.s.newsService.GetNewsForTicker(ctx, ticker)
+	if err != nil {
+		return "", fmt.Errorf("не удалось получить новости для акции %s: %w", ticker, err)
+	}
+	if len(news) > promptLimit(limit) {
+		news = news[:promptLimit(limit)]
+	}
+	return formatPromptNews(fmt.Sprintf("Новости по акции %s", strings.ToUpper(ticker)), news), nil
+}
+
+// TodayNews возвращает не более limit новостей за сегодня
+func (r promptResolver) TodayNews(ctx context.Context, limit int) (string, error) {
+	news, _, err := r.s.newsService.GetTodayNews(ctx, models.Pagination{Limit: promptLimit(limit)})
+	if err != nil {
+		return "", fmt.Errorf("не удалось получить новости: %w", err)
+	}
+	return formatPromptNews("Новости за сегодня", news), nil
+}
+
+// TopGainers возвращает limit лидеров роста
+func (r promptResolver) TopGainers(ctx context.Context, limit int) (string, error) {
+	stocks, err := r.s.stockService.GetMOEXTopGainers(ctx, models.UniverseFull, promptLimit(limit))
+	if err != nil {
+		return "", fmt.Errorf("не удалось получить список растущих акций: %w", err)
+	}
+	return r.s.renderer.Render(i18n.PrinterFrom(ctx), render.TopGainers, render.StockList{Stocks: stocks, Page: render.FullPage(len(stocks))})
+}
+
+// TopLosers возвращает limit лидеров падения
+func (r promptResolver) TopLosers(ctx context.Context, limit int) (string, error) {
+	stocks, err := r.s.stockService.GetMOEXTopLosers(ctx, models.UniverseFull, promptLimit(limit))
+	if err != nil {
+		return "", fmt.Errorf("не удалось получить список падающих акций: %w", err)
+	}
+	return r.s.renderer.Render(i18n.PrinterFrom(ctx), render.TopLosers, render.StockList{Stocks: stocks, Page: render.FullPage(len(stocks))})
+}
+
+// Events возвращает предстоящие корпоративные события эмитента
+func (r promptResolver) Events(ctx context.Context, ticker string) (string, error) {
+	if r.s.eventService == nil {
+		return "", fmt.Errorf("календарь корпоративных событий недоступен")
+	}
+
+	from := time.Now()
+	events, err := r.s.eventService.GetEventsByTicker(ctx, ticker, from, from.AddDate(0, 0, models.DefaultTickerEventsHorizonDays))
+	if err != nil {
+		return "", fmt.Errorf("не удалось получить корпоративные события для акции %s: %w", ticker, err)
+	}
+	title := fmt.Sprintf("Предстоящие корпоративные события %s", strings.ToUpper(ticker))
+	if len(events) == 0 {
+		return title + ": нет событий\n", nil
+	}
+	return formatCorporateEvents(i18n.PrinterFrom(ctx), title, events, false), nil
+}
+
+// PriceTargets возвращает целевые цены бумаг в сравнении с текущими котировками
+func (r promptResolver) PriceTargets(ctx context.Context, tickers []string) (string, error) {
+	if r.s.targetService == nil {
+		return "", fmt.Errorf("целевые цены недоступны")
+	}
+
+	comparisons, err := r.s.targetService.GetPriceTargetVsMarket(ctx, tickers)
+	if err != nil {
+		return "", fmt.Errorf("не удалось получить целевые цены: %w", err)
+	}
+	return formatPriceTargetComparisons(i18n.PrinterFrom(ctx), comparisons, time.Now()), nil
+}
+
+// KeyRate возвращает ключевую ставку Банка России
+func (r promptResolver) KeyRate(ctx context.Context) (string, error) {
+	if r.s.cbrService == nil {
+		return "", fmt.Errorf("данные Банка России недоступны")
+	}
+
+	summary, err := r.s.cbrService.GetKeyRate(ctx)
+	if err != nil {
+		return "", fmt.Errorf("не удалось получить ключевую ставку: %w", err)
+	}
+	return formatKeyRate(i18n.PrinterFrom(ctx), summary), nil
+}
+
+// Commodities возвращает цены сырьевых товаров
+func (r promptResolver) Commodities(ctx context.Context) (string, error) {
+	if r.s.commodityService == nil {
+		return "", fmt.Errorf("цены сырьевых товаров недоступны")
+	}
+
+	quotes, err := r.s.commodityService.GetCommodityPrices(ctx)
+	if err != nil {
+		return "", fmt.Errorf("не удалось получить цены сырьевых товаров: %w", err)
+	}
+	return formatCommodityQuotes(i18n.PrinterFrom(ctx), quotes), nil
+}
+
+// promptLimit ограничивает размер списка, запрошенного шаблоном
+func promptLimit(limit int) int {
+	return max(1, min(limit, maxPromptListLimit))
+}
+
+// formatPromptNews форматирует новости для шаблона так же, как встроенные шаблоны анализа
+func formatPromptNews(title string, news []models.News) string {
+	result := title + ":\n\n"
+	if len(news) == 0 {
+		return result + "Новости не найдены.\n"
+	}
+	for i, item := range news {
+		result += fmt.Sprintf("%d. %s\n", i+1, item.Title)
+		result += fmt.Sprintf("   %s\n", item.Description)
+		result += fmt.Sprintf("   Источник: %s, Дата: %s\n\n", item.Source, item.PublishedAt.Format("02.01.2006"))
+	}
+	return result
+}
@@ -0,0 +1,336 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/i18n"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// registerDiagnosticsTools регистрирует инструменты диагностики сервера
+func (s *Server) registerDiagnosticsTools() {
+	if s.selfTestService != nil {
+		// Инструмент для самопроверки источников данных
+		runSelfTestTool := mcp.NewTool("run_selftest",
+			mcp.WithDescription(s.printer.T("Проверить внешние источники данных: выполнить контрольный запрос к каждому (котировка SBER, поиск новостей) в обход кэша и убедиться, что разбор ответа дал непустые поля")),
+		)
+
+		s.addTool(runSelfTestTool, s.handleRunSelfTest)
+	}
+
+	if s.rawArchiveService != nil {
+		// Инструмент для повторного разбора архива ответов после исправления парсеров
+		reparseRawTool := mcp.NewTool("reparse_raw",
+			mcp.WithDescription(s.printer.T("Повторно разобрать сохраненные в архиве ответы MOEX и NewsAPI за период и обновить новости и котировки, не обращаясь к API. Котировки обновляются последним снимком периода")),
+			mcp.WithString("from",
+				mcp.Description(s.printer.T("Первый день периода в формате YYYY-MM-DD (UTC)")),
+				mcp.Required(),
+			),
+			mcp.WithString("to",
+				mcp.Description(s.printer.T("Последний день периода в формате YYYY-MM-DD (UTC), включительно")),
+				mcp.Required(),
+			),
+		)
+
+		s.addTool(reparseRawTool, s.handleReparseRaw)
+	}
+
+	if s.cacheService != nil {
+		// Инструмент администратора для сброса кэша после исправления данных
+		invalidateCacheTool := mcp.NewTool("invalidate_cache",
+			mcp.WithDescription(s.printer.T("Удалить ключи кэша по шаблону, чтобы следующие запросы получили данные из базы и внешних API. Затрагивается только пространство имен кэша этого сервера")),
+			mcp.WithString("pattern",
+				mcp.Description(s.printer.T("Glob-шаблон ключей: * — любая последовательность символов, ? — один символ. Например, stock:* — котировки акций, news:* — новости")),
+				mcp.Required(),
+			),
+		)
+
+		s.addTool(invalidateCacheTool, s.handleInvalidateCache)
+	}
+
+	if s.statsService != nil {
+		// Инструмент для оценки работы кэша и нагрузки на сервер
+		getServerStatsTool := mcp.NewTool("get_server_stats",
+			mcp.WithDescription(s.printer.T("Получить статистику сервера с момента запуска: время работы, память и обращения к кэшу по префиксам ключей (попадания, промахи, записи, ошибки, доля попаданий, среднее время)")),
+		)
+
+		s.addTool(getServerStatsTool, s.handleGetServerStats)
+	}
+
+	// Инструмент для просмотра расхода квоты вызовов
+	getUsageTool := mcp.NewTool(usageToolName,
+		mcp.WithDescription(s.printer.T("Получить число вызовов инструментов клиентом с момента запуска сервера: всего, за сегодня и отклоненных, ограничения частоты и суточную квоту, самые частые инструменты. Сам инструмент в квоту не входит")),
+	)
+
+	s.addTool(getUsageTool, s.handleGetUsage)
+
+	if s.healthService != nil {
+		// Инструмент для проверки состояния сервера и его зависимостей
+		healthCheckTool := mcp.NewTool("health_check",
+			mcp.WithDescription(s.printer.T("Проверить состояние сервера: соединение с базой данных и Redis, доступность MOEX и NewsAPI, работу фоновых задач. Помогает понять, почему данные устарели или запросы завершаются ошибкой")),
+		)
+
+		s.addTool(healthCheckTool, s.handleHealthCheck)
+	}
+}
+
+// handleRunSelfTest обрабатывает запрос на самопроверку источников данных
+func (s *Server) handleRunSelfTest(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args noArgs
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	p := i18n.PrinterFrom(ctx)
+
+	report := s.selfTestService.RunSelfTest(ctx)
+	return mcp.NewToolResultText(formatSelfTestReport(p, report)), nil
+}
+
+// handleReparseRaw обрабатывает запрос на повторный разбор архива ответов
+func (s *Server) handleReparseRaw(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		From string `arg:"from,required"`
+		To   string `arg:"to,required"`
+	}
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	p := i18n.PrinterFrom(ctx)
+
+	from, err := parseDateArg(ctx, "from", args.From, time.UTC)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	to, err := parseDateArg(ctx, "to", args.To, time.UTC)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Последний день включается в период целиком
+	reparse, err := s.rawArchiveService.ReparseRaw(ctx, from, to.Add(24*time.Hour-time.Nanosecond))
+	if err != nil {
+		return mcp.NewToolResultError(p.Sprintf("не удалось разобрать архив ответов: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(formatRawReparse(p, reparse)), nil
+}
+
+// handleInvalidateCache обрабатывает запрос на удаление ключей кэша
+func (s *Server) handleInvalidateCache(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		Pattern string `arg:"pattern,required"`
+	}
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	p := i18n.PrinterFrom(ctx)
+
+	if err := s.cacheService.InvalidateCache(ctx, args.Pattern); err != nil {
+		return mcp.NewToolResultError(p.Sprintf("не удалось сбросить кэш: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(p.Sprintf("Ключи кэша по шаблону %s удалены", strings.TrimSpace(args.Pattern))), nil
+}
+
+// handleGetServerStats обрабатывает запрос статистики сервера
+func (s *Server) handleGetServerStats(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args noArgs
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	p := i18n.PrinterFrom(ctx)
+
+	return mcp.NewToolResultText(formatServerStats(p, s.statsService.GetServerStats(ctx))), nil
+}
+
+// handleGetUsage обрабатывает запрос расхода квоты. Клиент SSE-транспорта видит только свои счетчики,
+// в stdio-сессии выводятся все клиенты
+func (s *Server) handleGetUsage(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args noArgs
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	p := i18n.PrinterFrom(ctx)
+
+	id := ""
+	if client := clientFromContext(ctx); client != nil {
+		id = client.name
+	}
+	return mcp.NewToolResultText(formatUsage(p, s.usage.snapshot(id, time.Now()))), nil
+}
+
+// handleHealthCheck обрабатывает запрос проверки состояния сервера
+func (s *Server) handleHealthCheck(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args noArgs
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	p := i18n.PrinterFrom(ctx)
+
+	return mcp.NewToolResultText(formatHealthReport(p, s.healthService.CheckHealth(ctx))), nil
+}
+
+// formatRawReparse форматирует итог повторного разбора архива на языке переводчика p
+func formatRawReparse(p i18n.Printer, reparse *models.RawReparse) string {
+	result := p.Sprintf("Повторный разбор архива ответов за %s – %s\n\n",
+		reparse.From.Format("02.01.2006"), reparse.To.Format("02.01.2006"))
+	result += p.Sprintf("Ответов в архиве: %d\n", reparse.Payloads)
+	result += p.Sprintf("Разобрано: %d\n", reparse.Parsed)
+	if reparse.Unsupported > 0 {
+		result += p.Sprintf("Пропущено ответов без повторного разбора: %d\n", reparse.Unsupported)
+	}
+	result += p.Sprintf("Сохранено новостей: %d\n", reparse.News)
+	result += p.Sprintf("Обновлено котировок: %d\n", reparse.Stocks)
+
+	if failed := reparse.Payloads - reparse.Parsed - reparse.Unsupported; failed > 0 {
+		result += p.Sprintf("\nНе удалось разобрать ответов: %d\n", failed)
+		for _, msg := range reparse.Errors {
+			result += fmt.Sprintf("- %s\n", msg)
+		}
+	}
+
+	return result
+}
+
+// formatSelfTestReport форматирует результаты самопроверки по источникам на языке переводчика p
+func formatSelfTestReport(p i18n.Printer, report *models.SelfTestReport) string {
+	status := p.T("все источники работают")
+	if !report.Passed() {
+		status = p.T("есть проблемы")
+	}
+	result := p.Sprintf("Самопроверка источников данных (%s): %s\n\n",
+		report.StartedAt.Format("02.01.2006 15:04:05"), status)
+
+	for _, check := range report.Checks {
+		mark := "PASS"
+		if !check.Passed {
+			mark = "FAIL"
+		}
+		result += p.Sprintf("[%s] %s — %s (%d мс)\n", mark, check.Source, check.Request, check.Duration.Milliseconds())
+
+		if check.Error != "" {
+			result += p.Sprintf("   Ошибка: %s\n", check.Error)
+		}
+		if len(check.Problems) > 0 {
+			result += p.Sprintf("   Проблемы разбора: %s\n", strings.Join(check.Problems, "; "))
+		}
+		if check.Summary != "" {
+			result += p.Sprintf("   Данные: %s\n", check.Summary)
+		}
+		result += "\n"
+	}
+
+	return result
+}
+
+// formatServerStats форматирует статистику сервера и таблицу обращений к кэшу на языке переводчика p
+func formatServerStats(p i18n.Printer, stats *models.ServerStats) string {
+	result := p.Sprintf("Статистика сервера (запущен %s)\n\n", stats.StartedAt.Format("02.01.2006 15:04:05"))
+	result += p.Sprintf("Время работы: %s\n", stats.Uptime.Round(time.Second))
+	result += p.Sprintf("Горутин: %d\n", stats.Goroutines)
+	result += p.Sprintf("Занято памяти: %.1f МБ\n\n", float64(stats.HeapAlloc)/(1<<20))
+
+	if len(stats.Cache) == 0 {
+		return result + p.T("Обращений к кэшу еще не было\n")
+	}
+
+	result += p.T("Кэш по префиксам ключей:\n")
+	result += p.T("| Префикс | Попадания | Промахи | Доля попаданий | Записи | Удаления | Ошибки | Среднее время |\n")
+	result += "|---|---|---|---|---|---|---|---|\n"
+	var total models.CacheStats
+	for _, c := range stats.Cache {
+		result += fmt.Sprintf("| %s | %d | %d | %.1f%% | %d | %d | %d | %s |\n",
+			c.Prefix, c.Hits, c.Misses, c.HitRate*100, c.Sets, c.Deletes, c.Errors, c.AvgLatency.Round(time.Microsecond))
+		total.Hits += c.Hits
+		total.Misses += c.Misses
+		total.Errors += c.Errors
+	}
+	if reads := total.Hits + total.Misses; reads > 0 {
+		result += p.Sprintf("\nВсего чтений: %d, доля попаданий: %.1f%%, ошибок: %d\n",
+			reads, float64(total.Hits)/float64(reads)*100, total.Errors)
+	}
+
+	return result
+}
+
+// formatHealthReport форматирует результаты проверки состояния по зависимостям на языке переводчика p
+func formatHealthReport(p i18n.Printer, report *models.HealthReport) string {
+	status := p.T("сервер готов к работе")
+	if !report.Ready() {
+		status = p.T("недоступны обязательные зависимости")
+	}
+	result := p.Sprintf("Состояние сервера (%s, работает %s): %s\n\n",
+		report.CheckedAt.Format("02.01.2006 15:04:05"), report.Uptime.Round(time.Second), status)
+
+	for _, check := range report.Checks {
+		mark := "OK"
+		if check.Status != models.HealthOK {
+			mark = "FAIL"
+		}
+		kind := p.T("необязательная")
+		if check.Critical {
+			kind = p.T("обязательная")
+		}
+		result += p.Sprintf("[%s] %s (%s) — %d мс\n", mark, check.Name, kind, check.Latency.Milliseconds())
+		if check.Error != "" {
+			result += p.Sprintf("   Ошибка: %s\n", check.Error)
+		}
+	}
+
+	return result
+}
+
+// formatUsage форматирует счетчики вызовов клиентов на языке переводчика p
+func formatUsage(p i18n.Printer, usage []clientUsage) string {
+	if len(usage) == 0 {
+		return p.T("Вызовов инструментов еще не было\n")
+	}
+
+	var b strings.Builder
+	for _, u := range usage {
+		fmt.Fprintf(&b, p.T("Клиент %s\n"), u.Client)
+		fmt.Fprintf(&b, p.T("Вызовов с момента запуска: %d, отклонено: %d\n"), u.Calls, u.Rejected)
+		if u.Limits.dailyQuota > 0 {
+			fmt.Fprintf(&b, p.T("Сегодня (%s): %d из %d, осталось %d\n"), u.Day, u.Today, u.Limits.dailyQuota, max(0, u.Limits.dailyQuota-u.Today))
+		} else {
+			fmt.Fprintf(&b, p.T("Сегодня (%s): %d, суточная квота не ограничена\n"), u.Day, u.Today)
+		}
+		if u.Limits.limit.PerMinute > 0 {
+			fmt.Fprintf(&b, p.T("Ограничение частоты: %g вызовов в минуту\n"), u.Limits.limit.PerMinute)
+		}
+		if !u.LastCall.IsZero() {
+			fmt.Fprintf(&b, p.T("Последний вызов: %s\n"), u.LastCall.In(models.MoscowLocation).Format("02.01.2006 15:04:05"))
+		}
+
+		tools := make([]string, 0, len(u.Tools))
+		for tool := range u.Tools {
+			tools = append(tools, tool)
+		}
+		sort.Slice(tools, func(i, j int) bool {
+			if u.Tools[tools[i]] != u.Tools[tools[j]] {
+				return u.Tools[tools[i]] > u.Tools[tools[j]]
+			}
+			return tools[i] < tools[j]
+		})
+		if len(tools) > 10 {
+			tools = tools[:10]
+		}
+		for _, tool := range tools {
+			fmt.Fprintf(&b, "- %s: %d\n", tool, u.Tools[tool])
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
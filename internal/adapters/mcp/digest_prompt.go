@@ -0,0 +1,204 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/i18n"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// handleDailyDigestPrompt обрабатывает запрос на шаблон ежедневного дайджеста рынка.
+// Разделы, для которых модуль не подключен или данные недоступны, пропускаются с предупреждением в журнале
+func (s *Server) handleDailyDigestPrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	sections, err := parseDigestSections(request.Params.Arguments["sections"])
+	if err != nil {
+		return nil, err
+	}
+
+	newsLimit := models.DefaultDigestNewsLimit
+	if limitArg := request.Params.Arguments["news_limit"]; limitArg != "" {
+		newsLimit, err = strconv.Atoi(strings.TrimSpace(limitArg))
+		if err != nil || newsLimit < 1 || newsLimit > models.MaxDigestNewsLimit {
+			return nil, fmt.Errorf("параметр news_limit должен быть числом от 1 до %d", models.MaxDigestNewsLimit)
+		}
+	}
+
+	systemMessage := `Ты - финансовый аналитик, готовящий ежедневный дайджест российского рынка акций.
+Составь структурированный дайджест по предоставленным данным, сохраняя порядок разделов.
+Для каждого раздела дай 2-3 предложения с главными выводами, затем подведи общий итог дня:
+настроение рынка, ключевые драйверы и на что обратить внимание на следующей торговой сессии.
+Опирайся только на предоставленные данные; если раздела нет, не додумывай его содержание.`
+
+	content := fmt.Sprintf("Дайджест рынка на %s\n\n", time.Now().In(models.MoscowLocation).Format("02.01.2006"))
+	for _, section := range models.DigestSections {
+		if !sections[section] {
+			continue
+		}
+		if text := s.digestSection(ctx, section, newsLimit); text != "" {
+			content += text + "\n"
+		}
+	}
+
+	return mcp.NewGetPromptResult(
+		"Ежедневный дайджест рынка",
+		[]mcp.PromptMessage{
+			mcp.NewPromptMessage(
+				mcp.RoleAssistant,
+				mcp.NewTextContent(systemMessage),
+			),
+			mcp.NewPromptMessage(
+				mcp.RoleUser,
+				mcp.NewTextContent(content),
+			),
+		},
+	), nil
+}
+
+// parseDigestSections разбирает список разделов дайджеста через запятую; пустой список включает все разделы
+func parseDigestSections(arg string) (map[string]bool, error) {
+	sections := make(map[string]bool, len(models.DigestSections))
+	if strings.TrimSpace(arg) == "" {
+		for _, section := range models.DigestSections {
+			sections[section] = true
+		}
+		return sections, nil
+	}
+
+	known := make(map[string]bool, len(models.DigestSections))
+	for _, section := range models.DigestSections {
+		known[section] = true
+	}
+	for _, section := range strings.Split(arg, ",") {
+		section = strings.ToLower(strings.TrimSpace(section))
+		if section == "" {
+			continue
+		}
+		if !known[section] {
+			return nil, fmt.Errorf("неизвестный раздел дайджеста %s, доступны: %s", section, strings.Join(models.DigestSections, ", "))
+		}
+		sections[section] = true
+	}
+
+	return sections, nil
+}
+
+// digestSection формирует текст раздела дайджеста; пустая строка означает, что раздел пропущен
+func (s *Server) digestSection(ctx context.Context, section string, newsLimit int) string {
+	switch section {
+	case models.DigestSectionIndexes:
+		if s.marketDataService == nil {
+			return ""
+		}
+		indexes, err := s.marketDataService.GetIndexes(ctx, nil)
+		if err != nil {
+			log.Printf("ПРЕДУПРЕЖДЕНИЕ: не удалось получить значения индексов: %v", err)
+			return ""
+		}
+		return formatMarketIndexes(indexes)
+
+	case models.DigestSectionMovers:
+		gainers, err := s.stockService.GetMOEXTopGainers(ctx, models.UniverseFull, models.DigestMoversLimit)
+		if err != nil {
+			log.Printf("ПРЕДУПРЕЖДЕНИЕ: не удалось получить список растущих акций: %v", err)
+			return ""
+		}
+		losers, err := s.stockService.GetMOEXTopLosers(ctx, models.UniverseFull, models.DigestMoversLimit)
+		if err != nil {
+			log.Printf("ПРЕДУПРЕЖДЕНИЕ: не удалось получить список падающих акций: %v", err)
+			return ""
+		}
+		result := "Лидеры роста:\n"
+		for i, stock := range gainers {
+			result += fmt.Sprintf("%d. %s (%s): %.2f ₽ (%+.2f%%)\n", i+1, stock.Ticker, stock.Name, stock.Price, stock.ChangePerc)
+		}
+		result += "Лидеры падения:\n"
+		for i, stock := range losers {
+			result += fmt.Sprintf("%d. %s (%s): %.2f ₽ (%+.2f%%)\n", i+1, stock.Ticker, stock.Name, stock.Price, stock.ChangePerc)
+		}
+		return result
+
+	case models.DigestSectionVolume:
+		report, err := s.stockService.GetUnusualVolume(ctx, s.config.Volume.Universe, s.config.Volume.MinRatio, models.DigestVolumeLimit)
+		if err != nil {
+			log.Printf("ПРЕДУПРЕЖДЕНИЕ: не удалось найти аномальные объемы: %v", err)
+			return ""
+		}
+		if report.Scanned == 0 {
+			return ""
+		}
+		return formatVolumeAnomalies(i18n.PrinterFrom(ctx), report)
+
+	case models.DigestSectionFX:
+		if s.cbrService == nil {
+			return ""
+		}
+		rates, err := s.cbrService.GetOfficialFXRates(ctx, nil, time.Time{})
+		if err != nil {
+			log.Printf("ПРЕДУПРЕЖДЕНИЕ: не удалось получить официальные курсы валют: %v", err)
+			return ""
+		}
+		result := fmt.Sprintf("Официальные курсы Банка России на %s:\n", rates[0].Date.Format("02.01.2006"))
+		for _, rate := range rates {
+			result += fmt.Sprintf("- %s: %.4f ₽\n", rate.Code, rate.UnitRate)
+		}
+		return result
+
+	case models.DigestSectionCommodities:
+		if s.commodityService == nil {
+			return ""
+		}
+		quotes, err := s.commodityService.GetCommodityPrices(ctx)
+		if err != nil {
+			log.Printf("ПРЕДУПРЕЖДЕНИЕ: не удалось получить цены сырьевых товаров: %v", err)
+			return ""
+		}
+		return formatCommodityQuotes(i18n.PrinterFrom(ctx), quotes)
+
+	case models.DigestSectionSectors:
+		report, err := s.stockService.GetSectorPerformance(ctx, models.UniverseFull)
+		if err != nil {
+			log.Printf("ПРЕДУПРЕЖДЕНИЕ: не удалось получить динамику секторов: %v", err)
+			return ""
+		}
+		result := "Динамика секторов:\n"
+		for _, sector := range report.Sectors {
+			result += fmt.Sprintf("- %s: %+.2f%% (растут: %d, падают: %d, лидер: %s %+.2f%%)\n",
+				sector.Sector, sector.CapWeightedChangePerc, sector.Advancers, sector.Decliners, sector.Leader, sector.LeaderChangePerc)
+		}
+		return result
+
+	case models.DigestSectionNews:
+		news, _, err := s.newsService.GetTodayNews(ctx, models.Pagination{Limit: newsLimit})
+		if err != nil {
+			log.Printf("ПРЕДУПРЕЖДЕНИЕ: не удалось получить новости: %v", err)
+			return ""
+		}
+		if len(news) == 0 {
+			return "Главные новости: нет новостей за сегодня.\n"
+		}
+		result := "Главные новости:\n"
+		for i, item := range news {
+			result += fmt.Sprintf("%d. %s (%s)\n", i+1, item.Title, item.Source)
+		}
+		return result
+	}
+
+	return ""
+}
+
+// formatMarketIndexes форматирует значения биржевых индексов
+func formatMarketIndexes(indexes []models.MarketIndex) string {
+	result := "Индексы Московской биржи:\n"
+	for _, index := range indexes {
+		result += fmt.Sprintf("- %s (%s): %.2f (%+.2f, %+.2f%%)\n", index.Code, index.Name, index.Value, index.Change, index.ChangePerc)
+	}
+
+	return result
+}
@@ -0,0 +1,271 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/i18n"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// eventTypeNames названия типов корпоративных событий
+var eventTypeNames = map[string]string{
+	models.EventTypeEarnings: "Отчетность",
+	models.EventTypeAGM:      "Собрание акционеров",
+	models.EventTypeBuyback:  "Обратный выкуп",
+	models.EventTypeDividend: "Дивиденды",
+}
+
+// registerEventTools регистрирует инструменты календаря корпоративных событий
+func (s *Server) registerEventTools() {
+	if s.eventService == nil {
+		return
+	}
+
+	getEventsByTickerTool := mcp.NewTool("get_events_by_ticker",
+		mcp.WithDescription(s.printer.T("Получить корпоративные события эмитента за период: даты отчетности, собрания акционеров, программы обратного выкупа и закрытия реестра под дивиденды")),
+		mcp.WithString("ticker",
+			mcp.Required(),
+			mcp.Description(s.printer.T("Тикер акции (например, SBER, GAZP, LKOH)")),
+		),
+		mcp.WithString("from",
+			mcp.Description(s.printer.T("Первый день периода в формате YYYY-MM-DD (по умолчанию сегодня)")),
+		),
+		mcp.WithString("to",
+			mcp.Description(s.printer.Sprintf("Последний день периода в формате YYYY-MM-DD, включительно (по умолчанию через %d дней)", models.DefaultTickerEventsHorizonDays)),
+		),
+	)
+
+	s.addTool(getEventsByTickerTool, s.handleGetEventsByTicker, sourceMOEX)
+
+	getEventsCalendarTool := mcp.NewTool("get_events_calendar",
+		mcp.WithDescription(s.printer.T("Получить календарь корпоративных событий всех эмитентов за период, сгруппированный по дням")),
+		mcp.WithString("from",
+			mcp.Description(s.printer.T("Первый день периода в формате YYYY-MM-DD (по умолчанию сегодня)")),
+		),
+		mcp.WithString("to",
+			mcp.Description(s.printer.Sprintf("Последний день периода в формате YYYY-MM-DD, включительно (по умолчанию через %d дней)", models.DefaultEventsCalendarDays)),
+		),
+		mcp.WithArray("types",
+			mcp.Description(s.printer.T("Типы событий; по умолчанию все")),
+			mcp.Items(map[string]interface{}{
+				"type": "string",
+				"enum": models.CorporateEventTypes,
+			}),
+		),
+	)
+
+	s.addTool(getEventsCalendarTool, s.handleGetEventsCalendar, sourceMOEX)
+}
+
+// handleGetEventsByTicker обрабатывает запрос на получение событий эмитента
+func (s *Server) handleGetEventsByTicker(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		Ticker string `arg:"ticker,required"`
+		eventsRangeArgs
+	}
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	ticker := args.Ticker
+
+	from, to, err := args.eventsRange(ctx, models.DefaultTickerEventsHorizonDays)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	p := i18n.PrinterFrom(ctx)
+	events, err := s.eventService.GetEventsByTicker(ctx, ticker, from, to)
+	if err != nil {
+		return mcp.NewToolResultError(p.Sprintf("не удалось получить события: %v", err)), nil
+	}
+
+	title := p.Sprintf("События %s за %s – %s", ticker, from.Format("02.01.2006"), to.Format("02.01.2006"))
+	return mcp.NewToolResultText(formatCorporateEvents(p, title, events, false)), nil
+}
+
+// handleGetEventsCalendar обрабатывает запрос на получение календаря событий
+func (s *Server) handleGetEventsCalendar(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// Допустимые типы совпадают с models.CorporateEventTypes
+	var args struct {
+		Types []string `arg:"types" enum:"earnings|agm|buyback|dividend"`
+		eventsRangeArgs
+	}
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	from, to, err := args.eventsRange(ctx, models.DefaultEventsCalendarDays)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	p := i18n.PrinterFrom(ctx)
+	events, err := s.eventService.GetEventsCalendar(ctx, from, to, args.Types)
+	if err != nil {
+		return mcp.NewToolResultError(p.Sprintf("не удалось получить календарь событий: %v", err)), nil
+	}
+
+	title := p.Sprintf("Календарь событий за %s – %s", from.Format("02.01.2006"), to.Format("02.01.2006"))
+	return mcp.NewToolResultText(formatCorporateEvents(p, title, events, true)), nil
+}
+
+// eventsRangeArgs аргументы периода календаря корпоративных событий
+type eventsRangeArgs struct {
+	From string `arg:"from"`
+	To   string `arg:"to"`
+}
+
+// eventsRange разбирает аргументы from и to по московскому времени;
+// по умолчанию период начинается сегодня и длится defaultDays дней. Последний день включается целиком
+func (a eventsRangeArgs) eventsRange(ctx context.Context, defaultDays int) (time.Time, time.Time, error) {
+	now := time.Now().In(models.MoscowLocation)
+	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, models.MoscowLocation)
+	if a.From != "" {
+		parsed, err := parseDateArg(ctx, "from", a.From, models.MoscowLocation)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		from = parsed
+	}
+
+	to := from.AddDate(0, 0, defaultDays)
+	if a.To != "" {
+		parsed, err := parseDateArg(ctx, "to", a.To, models.MoscowLocation)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		to = parsed
+	}
+
+	return from, to.Add(24*time.Hour - time.Nanosecond), nil
+}
+
+// formatCorporateEvents форматирует события по дням на языке переводчика p; withTicker добавляет тикер к каждому событию
+func formatCorporateEvents(p i18n.Printer, title string, events []models.CorporateEvent, withTicker bool) string {
+	if len(events) == 0 {
+		return title + p.T(": событий не найдено")
+	}
+
+	result := fmt.Sprintf("%s (%d):\n", title, len(events))
+	day := ""
+	for _, event := range events {
+		if eventDay := event.Date.In(models.MoscowLocation).Format("02.01.2006"); eventDay != day {
+			day = eventDay
+			result += fmt.Sprintf("\n%s\n", day)
+		}
+
+		result += "- "
+		if withTicker {
+			result += event.Ticker + ": "
+		}
+		result += fmt.Sprintf("%s — %s", p.T(eventTypeNames[event.Type]), event.Title)
+		if !event.EndDate.IsZero() {
+			result += p.Sprintf(" (до %s)", event.EndDate.In(models.MoscowLocation).Format("02.01.2006"))
+		}
+		result += "\n"
+		if event.Details != "" {
+			result += fmt.Sprintf("  %s\n", event.Details)
+		}
+	}
+
+	return result
+}
+
+// monthNames названия месяцев для графика дивидендных выплат
+var monthNames = [...]string{"", "январь", "февраль", "март", "апрель", "май", "июнь",
+	"июль", "август", "сентябрь", "октябрь", "ноябрь", "декабрь"}
+
+// handleDividendIncomePlanPrompt обрабатывает запрос на шаблон плана дивидендного дохода
+func (s *Server) handleDividendIncomePlanPrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	incomeArg := strings.TrimSpace(request.Params.Arguments["target_monthly_income"])
+	if incomeArg == "" {
+		return nil, fmt.Errorf("требуется параметр target_monthly_income")
+	}
+	targetIncome, err := strconv.ParseFloat(incomeArg, 64)
+	if err != nil || targetIncome <= 0 {
+		return nil, fmt.Errorf("параметр target_monthly_income должен быть положительным числом")
+	}
+
+	var tickers []string
+	if tickersArg := request.Params.Arguments["tickers"]; tickersArg != "" {
+		tickers = strings.Split(tickersArg, ",")
+	}
+
+	profiles, err := s.eventService.GetDividendProfiles(ctx, tickers)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось получить дивидендную историю: %w", err)
+	}
+
+	systemMessage := `Ты - инвестиционный консультант, специализирующийся на дивидендных стратегиях на российском рынке акций.
+Составь дивидендный портфель, который обеспечит целевой ежемесячный доход, используя предоставленные данные.
+Включи в план:
+1. Состав портфеля: бумаги, количество акций, сумму вложений и ожидаемый годовой дивиденд по каждой
+2. Требуемый капитал для достижения цели с учетом НДФЛ 13%
+3. График выплат по месяцам: учти, что деньги поступают примерно через месяц после закрытия реестра, и подбери бумаги так, чтобы сгладить пустые месяцы
+4. Риски: зависимость от разовых выплат, отраслевую концентрацию и возможную отмену дивидендов
+
+Опирайся на дивиденды за последний год и объявленные выплаты; прошлые дивиденды не гарантируют будущих, отмечай это там, где выплата была разовой.`
+
+	content := fmt.Sprintf("Целевой доход: %.2f ₽ в месяц (%.2f ₽ в год)\n\n", targetIncome, targetIncome*12)
+	content += formatDividendProfiles(profiles, targetIncome)
+
+	return mcp.NewGetPromptResult(
+		"План дивидендного дохода",
+		[]mcp.PromptMessage{
+			mcp.NewPromptMessage(
+				mcp.RoleAssistant,
+				mcp.NewTextContent(systemMessage),
+			),
+			mcp.NewPromptMessage(
+				mcp.RoleUser,
+				mcp.NewTextContent(content),
+			),
+		},
+	), nil
+}
+
+// formatDividendProfiles форматирует дивидендную доходность и график выплат бумаг.
+// Для каждой бумаги указывается капитал, при котором она одна обеспечила бы целевой доход
+func formatDividendProfiles(profiles []models.DividendProfile, targetIncome float64) string {
+	result := fmt.Sprintf("Дивиденды за последние %d дней и объявленные выплаты:\n", models.DividendHistoryDays)
+	for i, profile := range profiles {
+		result += fmt.Sprintf("%d. %s", i+1, profile.Ticker)
+		if profile.Name != "" {
+			result += fmt.Sprintf(" (%s)", profile.Name)
+		}
+		if profile.Price > 0 {
+			result += fmt.Sprintf(": цена %.2f ₽", profile.Price)
+		} else {
+			result += ": цена неизвестна"
+		}
+		result += fmt.Sprintf(", дивиденды за год %.2f ₽ на акцию", profile.TrailingDividend)
+		if profile.TrailingYieldPerc > 0 {
+			result += fmt.Sprintf(", доходность %.2f%%, капитал для цели %.0f ₽",
+				profile.TrailingYieldPerc, targetIncome*12/(profile.TrailingYieldPerc/100))
+		}
+		result += "\n"
+
+		if len(profile.PayoutMonths) > 0 {
+			months := make([]string, 0, len(profile.PayoutMonths))
+			for _, month := range profile.PayoutMonths {
+				months = append(months, monthNames[month])
+			}
+			result += fmt.Sprintf("   Месяцы закрытия реестра: %s\n", strings.Join(months, ", "))
+		}
+		for _, payment := range profile.Payments {
+			status := "выплачен"
+			if payment.Upcoming {
+				status = "объявлен"
+			}
+			result += fmt.Sprintf("   - %s: %.2f %s (%s)\n", payment.RecordDate.Format("02.01.2006"), payment.Value, payment.Currency, status)
+		}
+	}
+
+	return result
+}
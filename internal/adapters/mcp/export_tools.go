@@ -0,0 +1,104 @@
+package mcp
+
+import (
+	"context"
+	"strings"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/i18n"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// registerExportTools регистрирует инструмент выгрузки данных
+func (s *Server) registerExportTools() {
+	if s.exportService == nil {
+		return
+	}
+
+	exportDataTool := mcp.NewTool("export_data",
+		mcp.WithDescription(s.printer.T("Выгрузить историю котировок акции или новости в CSV или JSON для анализа в таблицах и pandas. Небольшая выгрузка возвращается в ответе, крупная сохраняется в хранилище сервера, и в ответе указывается путь или ссылка на файл")),
+		mcp.WithString("dataset",
+			mcp.Required(),
+			mcp.Description(s.printer.T("Набор данных: history — свечи котировок акции, news — новости тикера или поиска")),
+			mcp.Enum(models.ExportHistory, models.ExportNews),
+		),
+		mcp.WithString("format",
+			mcp.Description(s.printer.T("Формат файла: csv (по умолчанию) или json")),
+			mcp.Enum(models.ExportCSV, models.ExportJSON),
+		),
+		mcp.WithString("ticker",
+			mcp.Description(s.printer.T("Тикер акции; обязателен для history, для news — новости тикера")),
+		),
+		mcp.WithString("interval",
+			mcp.Description(s.printer.T("Интервал свечей для history: 1m, 10m, 1h или 1d (по умолчанию 1d)")),
+			mcp.Enum(models.IntervalMinute, models.IntervalTenMinute, models.IntervalHour, models.IntervalDay),
+		),
+		mcp.WithString("query",
+			mcp.Description(s.printer.T("Ключевое слово поиска новостей для news")),
+		),
+		mcp.WithString("from",
+			mcp.Description(s.printer.T("Начало периода в формате YYYY-MM-DD или YYYY-MM-DD HH:MM по московскому времени")),
+		),
+		mcp.WithString("to",
+			mcp.Description(s.printer.T("Конец периода в формате YYYY-MM-DD (включительно) или YYYY-MM-DD HH:MM по московскому времени (по умолчанию сейчас)")),
+		),
+		mcp.WithString("destination",
+			mcp.Description(s.printer.T("Куда поместить выгрузку: auto (по умолчанию) — в ответ, если она небольшая, иначе в хранилище; inline — только в ответ; storage — в хранилище")),
+			mcp.Enum(models.ExportAuto, models.ExportInline, models.ExportStorage),
+		),
+	)
+
+	s.addTool(exportDataTool, s.handleExportData, sourceMOEX, sourceYahoo, sourceNews)
+}
+
+// handleExportData обрабатывает запрос на выгрузку данных
+func (s *Server) handleExportData(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	p := i18n.PrinterFrom(ctx)
+	args := struct {
+		Dataset     string `arg:"dataset,required" enum:"history|news"`
+		Format      string `arg:"format" enum:"csv|json"`
+		Ticker      string `arg:"ticker"`
+		Interval    string `arg:"interval" enum:"1m|10m|1h|1d"`
+		Query       string `arg:"query"`
+		From        string `arg:"from"`
+		To          string `arg:"to"`
+		Destination string `arg:"destination" enum:"auto|inline|storage"`
+	}{Format: models.ExportCSV, Interval: models.IntervalDay, Destination: models.ExportAuto}
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if args.Dataset == models.ExportHistory && args.Ticker == "" {
+		return mcp.NewToolResultError(p.T("для выгрузки истории котировок укажите ticker")), nil
+	}
+
+	from, to, err := historyPeriod(p, args.From, args.To)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	result, err := s.exportService.Export(ctx, models.ExportRequest{
+		Dataset:     args.Dataset,
+		Format:      args.Format,
+		Destination: args.Destination,
+		Ticker:      args.Ticker,
+		Interval:    args.Interval,
+		Query:       args.Query,
+		From:        from,
+		To:          to,
+	})
+	if err != nil {
+		return mcp.NewToolResultError(p.Sprintf("не удалось выгрузить данные: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(formatExport(p, result)), nil
+}
+
+// formatExport форматирует итог выгрузки: содержимое файла в блоке кода или адрес сохраненного файла
+func formatExport(p i18n.Printer, e *models.ExportResult) string {
+	result := p.Sprintf("Выгрузка %s: строк %d, %d байт\n", e.Name, e.Rows, e.Bytes)
+	if e.Location != "" {
+		return result + p.Sprintf("Файл сохранен: %s\n", e.Location)
+	}
+	return result + "\n```" + e.Format + "\n" + strings.TrimRight(e.Content, "\n") + "\n```\n"
+}
@@ -0,0 +1,149 @@
+package mcp
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/config"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/i18n"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// dataSource источник данных, на который ссылается результат инструмента
+type dataSource string
+
+const (
+	sourceMOEX   dataSource = "moex"
+	sourceNews   dataSource = "news"
+	sourceCrypto dataSource = "crypto"
+	sourceYahoo  dataSource = "yahoo"
+	sourceCBR    dataSource = "cbr"
+)
+
+// UpstreamStatus сообщает о техническом обслуживании внешнего источника данных
+type UpstreamStatus interface {
+	// Maintenance возвращает время начала обслуживания и признак того, что оно продолжается
+	Maintenance() (time.Time, bool)
+}
+
+// formatter отвечает за оформление результатов инструментов,
+// общее для всех обработчиков (например, строки об источниках данных)
+type formatter struct {
+	attributions map[dataSource]string
+	toolSources  map[string][]dataSource
+	statuses     map[dataSource]UpstreamStatus
+}
+
+// newFormatter создает форматтер на основе конфигурации
+func newFormatter(cfg *config.Config) *formatter {
+	f := &formatter{
+		attributions: make(map[dataSource]string),
+		toolSources:  make(map[string][]dataSource),
+		statuses:     make(map[dataSource]UpstreamStatus),
+	}
+
+	if !cfg.Attribution.Disabled {
+		f.attributions[sourceMOEX] = cfg.Attribution.MOEX
+		f.attributions[sourceNews] = cfg.Attribution.News
+		f.attributions[sourceCrypto] = cfg.Attribution.Crypto
+		f.attributions[sourceCBR] = cfg.Attribution.CBR
+		// Yahoo Finance упоминается, только если включена хотя бы одна зарубежная биржа
+		for _, exchange := range cfg.Exchanges.Enabled {
+			if !strings.EqualFold(exchange, models.ExchangeMOEX) {
+				f.attributions[sourceYahoo] = cfg.Attribution.Yahoo
+				break
+			}
+		}
+	}
+
+	return f
+}
+
+// registerTool запоминает, на какие источники данных опирается инструмент
+func (f *formatter) registerTool(name string, sources ...dataSource) {
+	f.toolSources[name] = sources
+}
+
+// footer возвращает строки об источниках данных для инструмента на языке переводчика p
+func (f *formatter) footer(p i18n.Printer, toolName string) string {
+	var lines []string
+	for _, source := range f.toolSources[toolName] {
+		if attribution := f.attributions[source]; attribution != "" {
+			lines = append(lines, p.T(attribution))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// maintenanceNotice возвращает предупреждение о техническом обслуживании источников данных инструмента
+func (f *formatter) maintenanceNotice(p i18n.Printer, toolName string) string {
+	var lines []string
+	for _, source := range f.toolSources[toolName] {
+		status := f.statuses[source]
+		if status == nil {
+			continue
+		}
+		if since, active := status.Maintenance(); active {
+			lines = append(lines, p.Sprintf("Внимание: %s на техническом обслуживании с %s. "+
+				"Показаны последние сохраненные данные, они могут быть устаревшими.", p.T(sourceNames[source]), since.Format("15:04")))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// sourceNames названия источников данных для сообщений пользователю
+var sourceNames = map[dataSource]string{
+	sourceMOEX:   "MOEX ISS",
+	sourceNews:   "NewsAPI",
+	sourceCrypto: "CoinGecko",
+	sourceYahoo:  "Yahoo Finance",
+	sourceCBR:    "Банк России",
+}
+
+// middleware добавляет к результатам инструментов предупреждение об обслуживании источников,
+// а к успешным результатам — строки об источниках данных
+func (f *formatter) middleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		result, err := next(ctx, request)
+		if err != nil || result == nil {
+			return result, err
+		}
+		p := i18n.PrinterFrom(ctx)
+
+		// Во время обслуживания источника результат помечается, чтобы агент не принял сохраненные данные за свежие
+		if notice := f.maintenanceNotice(p, request.Params.Name); notice != "" {
+			for i, content := range result.Content {
+				if text, ok := content.(mcp.TextContent); ok {
+					text.Text = notice + "\n\n" + text.Text
+					result.Content[i] = text
+					break
+				}
+			}
+		}
+		if result.IsError {
+			return result, nil
+		}
+
+		footer := f.footer(p, request.Params.Name)
+		if footer == "" {
+			return result, nil
+		}
+
+		// Дописываем строку к последнему текстовому блоку, чтобы она не терялась у клиентов,
+		// которые показывают только первый блок
+		for i := len(result.Content) - 1; i >= 0; i-- {
+			if text, ok := result.Content[i].(mcp.TextContent); ok {
+				text.Text = strings.TrimRight(text.Text, "\n") + "\n\n---\n" + footer
+				result.Content[i] = text
+				return result, nil
+			}
+		}
+
+		result.Content = append(result.Content, mcp.NewTextContent(footer))
+		return result, nil
+	}
+}
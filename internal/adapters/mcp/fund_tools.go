@@ -0,0 +1,117 @@
+package mcp
+
+import (
+	"context"
+	"strings"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/adapters/render"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/i18n"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// registerFundTools регистрирует инструменты биржевых и паевых фондов
+func (s *Server) registerFundTools() {
+	if s.fundService == nil {
+		return
+	}
+
+	getETFInfoTool := mcp.NewTool("get_etf_info",
+		mcp.WithDescription(s.printer.T("Получить информацию о биржевом (ETF, БПИФ) или паевом фонде на MOEX: цена пая, индикативная стоимость пая (iNAV) и отклонение от нее, комиссия управляющего, базовый индекс и управляющая компания")),
+		mcp.WithString("ticker",
+			mcp.Required(),
+			mcp.Description(s.printer.T("Тикер пая фонда (например, TMOS, SBMX, LQDT)")),
+		),
+	)
+
+	s.addTool(getETFInfoTool, s.handleGetETFInfo, sourceMOEX)
+
+	searchETFsTool := mcp.NewTool("search_etfs",
+		mcp.WithDescription(s.printer.T("Найти биржевые и паевые фонды на MOEX по названию, управляющей компании, базовому индексу, типу и комиссии")),
+		mcp.WithString("query",
+			mcp.Description(s.printer.T("Подстрока тикера, названия, управляющей компании или индекса (по умолчанию все фонды)")),
+		),
+		mcp.WithString("type",
+			mcp.Description(s.printer.T("Тип фонда: etf — биржевые фонды (ETF, БПИФ), mutual — паевые фонды (ПИФ); по умолчанию все")),
+			mcp.Enum(models.FundTypes...),
+		),
+		mcp.WithString("index",
+			mcp.Description(s.printer.T("Подстрока базового индекса, например IMOEX или RGBITR")),
+		),
+		mcp.WithNumber("max_fee",
+			mcp.Description(s.printer.T("Наибольшая комиссия управляющего, % годовых; фонды с неизвестной комиссией исключаются")),
+		),
+		mcp.WithString("currency",
+			mcp.Description(s.printer.T("Валюта торгов пая, например RUB или USD")),
+		),
+		mcp.WithString("sort",
+			mcp.Description(s.printer.T("Сортировка: value — по обороту за день (по умолчанию), fee — по комиссии, change — по изменению цены")),
+			mcp.Enum(models.FundSortValue, models.FundSortFee, models.FundSortChange),
+		),
+		s.limitArg("фондов"),
+		s.offsetArg(),
+	)
+
+	s.addTool(searchETFsTool, s.handleSearchETFs, sourceMOEX)
+}
+
+// handleGetETFInfo обрабатывает запрос информации о фонде
+func (s *Server) handleGetETFInfo(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	p := i18n.PrinterFrom(ctx)
+	var args struct {
+		Ticker string `arg:"ticker,required"`
+	}
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	fund, err := s.fundService.GetFund(ctx, args.Ticker)
+	if err != nil {
+		return mcp.NewToolResultError(p.Sprintf("не удалось получить информацию о фонде: %v", err)), nil
+	}
+	if fund == nil {
+		return mcp.NewToolResultError(p.Sprintf("фонд %s не найден в режимах торгов паями фондов MOEX: для акций используйте get_stock_info, для поиска фондов — search_etfs", strings.ToUpper(args.Ticker))), nil
+	}
+
+	return s.renderResult(p, render.FundInfo, fund)
+}
+
+// handleSearchETFs обрабатывает запрос поиска фондов
+func (s *Server) handleSearchETFs(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	p := i18n.PrinterFrom(ctx)
+	var args struct {
+		Query    string  `arg:"query"`
+		Type     string  `arg:"type" enum:"etf|mutual"`
+		Index    string  `arg:"index"`
+		MaxFee   float64 `arg:"max_fee" min:"0"`
+		Currency string  `arg:"currency"`
+		Sort     string  `arg:"sort" enum:"value|fee|change"`
+		pageArgs
+	}
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	page := args.page()
+
+	funds, total, err := s.fundService.SearchFunds(ctx, models.FundFilter{
+		Query:    strings.TrimSpace(args.Query),
+		Type:     args.Type,
+		Index:    strings.TrimSpace(args.Index),
+		MaxFee:   args.MaxFee,
+		Currency: strings.TrimSpace(args.Currency),
+		Sort:     args.Sort,
+	}, page)
+	if err != nil {
+		return mcp.NewToolResultError(p.Sprintf("не удалось выполнить поиск фондов: %v", err)), nil
+	}
+	if total == 0 {
+		return mcp.NewToolResultText(p.T("По запросу не найдено фондов")), nil
+	}
+
+	return s.renderResult(p, render.SearchFunds, render.FundList{
+		Query: args.Query,
+		Funds: funds,
+		Page:  render.NewPage(page, len(funds), total),
+	})
+}
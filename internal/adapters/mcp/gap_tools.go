@@ -0,0 +1,88 @@
+package mcp
+
+import (
+	"context"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/i18n"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// registerGapTools регистрирует инструмент сканера гэпов открытия
+func (s *Server) registerGapTools() {
+	if s.gapService == nil {
+		return
+	}
+
+	getGappersTool := mcp.NewTool("get_gappers",
+		mcp.WithDescription(s.printer.Sprintf("Найти акции универсума %s, открывшие сессию с гэпом к закрытию предыдущей: величина разрыва, цена сейчас и закрыт ли гэп. Гэпы рассчитываются раз в день после открытия торгов", s.config.Gaps.Universe)),
+		mcp.WithNumber("min_gap",
+			mcp.Description(s.printer.Sprintf("Наименьший гэп по модулю, %% (по умолчанию %g)", s.config.Gaps.MinGapPerc)),
+		),
+		mcp.WithString("direction",
+			mcp.Description(s.printer.T("Направление: up — открытие выше закрытия, down — ниже; по умолчанию оба")),
+			mcp.Enum(models.GapDirections...),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description(s.printer.Sprintf("Количество акций с наибольшим гэпом (по умолчанию %d, максимум %d)", models.DefaultGappersLimit, models.MaxGappersLimit)),
+		),
+	)
+
+	s.addTool(getGappersTool, s.handleGetGappers, sourceMOEX)
+}
+
+// handleGetGappers обрабатывает запрос на получение акций с гэпом открытия
+func (s *Server) handleGetGappers(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// Граница limit совпадает с models.MaxGappersLimit
+	args := struct {
+		MinGap    float64 `arg:"min_gap" min:"0"`
+		Direction string  `arg:"direction" enum:"up|down"`
+		Limit     int     `arg:"limit" min:"1" max:"50"`
+	}{MinGap: s.config.Gaps.MinGapPerc, Limit: models.DefaultGappersLimit}
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	p := i18n.PrinterFrom(ctx)
+	report, err := s.gapService.GetGappers(ctx, args.MinGap, args.Direction, args.Limit)
+	if err != nil {
+		return mcp.NewToolResultError(p.Sprintf("не удалось рассчитать гэпы открытия: %v", err)), nil
+	}
+
+	if report.Scanned == 0 {
+		return mcp.NewToolResultText(p.Sprintf("Гэпы открытия за %s не рассчитаны: торги еще не начались или сегодня нет сессии",
+			report.Session.Format("02.01.2006"))), nil
+	}
+
+	return mcp.NewToolResultText(formatGapReport(p, report, args.MinGap)), nil
+}
+
+// formatGapReport форматирует гэпы открытия, отобранные по порогу minGap, на языке переводчика p
+func formatGapReport(p i18n.Printer, report *models.GapReport, minGap float64) string {
+	result := p.Sprintf("Гэпы открытия %s в универсуме %s (не меньше %g%%), расчет на %s:\n",
+		report.Session.Format("02.01.2006"), report.Universe, minGap, report.ComputedAt.In(models.MoscowLocation).Format("15:04"))
+	if len(report.Gaps) == 0 {
+		result += p.T("Акций с гэпом не найдено\n")
+	}
+
+	for i, gap := range report.Gaps {
+		result += p.Sprintf("%d. %s (%s): гэп %+.2f%% — закрытие %.2f → открытие %.2f; цена %.2f ₽ (%+.2f%% от открытия)",
+			i+1, gap.Ticker, gap.Name, gap.GapPerc, gap.PrevClose, gap.Open, gap.Price, gap.FromOpenPerc)
+		if gap.Filled {
+			result += p.T(", гэп закрыт")
+		}
+		result += "\n"
+	}
+	if report.Matched > len(report.Gaps) {
+		result += p.Sprintf("Показано %d из %d\n", len(report.Gaps), report.Matched)
+	}
+
+	result += p.Sprintf("\nПроверено бумаг: %d", report.Scanned)
+	if report.Skipped > 0 {
+		result += p.Sprintf(", пропущено без свечей сессии или предыдущего закрытия: %d", report.Skipped)
+	}
+	result += "\n"
+
+	return result
+}
@@ -0,0 +1,200 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/adapters/render"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/chart"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/i18n"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// defaultHistoryCandles количество последних свечей в ответе по умолчанию
+const defaultHistoryCandles = 100
+
+// handleGetStockHistory обрабатывает запрос на получение истории котировок
+func (s *Server) handleGetStockHistory(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	p := i18n.PrinterFrom(ctx)
+	args := struct {
+		Ticker   string `arg:"ticker,required"`
+		Interval string `arg:"interval" enum:"1m|10m|1h|1d"`
+		From     string `arg:"from"`
+		To       string `arg:"to"`
+		Limit    int    `arg:"limit" min:"1"`
+		sparklineArgs
+	}{Interval: models.IntervalDay, Limit: defaultHistoryCandles, sparklineArgs: sparklineArgs{Sparkline: true}}
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	ticker, interval := args.Ticker, args.Interval
+
+	from, to, err := historyPeriod(p, args.From, args.To)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	history, err := s.stockService.GetStockHistoricalData(ctx, ticker, interval, from, to)
+	if err != nil {
+		return mcp.NewToolResultError(p.Sprintf("не удалось получить историю котировок: %v", err)), nil
+	}
+
+	if len(history) == 0 {
+		return mcp.NewToolResultText(p.Sprintf("Нет свечей %s с интервалом %s за указанный период", ticker, interval)), nil
+	}
+
+	return mcp.NewToolResultText(formatStockHistory(p, ticker, interval, history, args.Limit, args.Sparkline)), nil
+}
+
+// handleBackfillHistory обрабатывает запрос на загрузку истории дневных свечей
+func (s *Server) handleBackfillHistory(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	p := i18n.PrinterFrom(ctx)
+	// Граница years совпадает с models.MaxHistoryBackfillYears
+	args := struct {
+		Tickers []string `arg:"tickers"`
+		Years   int      `arg:"years" min:"1" max:"20"`
+	}{Years: models.DefaultHistoryBackfillYears}
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	result, err := s.stockService.BackfillHistory(ctx, args.Tickers, args.Years)
+	if err != nil {
+		return mcp.NewToolResultError(p.Sprintf("не удалось загрузить историю котировок: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(formatHistoryBackfill(p, result)), nil
+}
+
+// formatHistoryBackfill форматирует итог загрузки истории по тикерам
+func formatHistoryBackfill(p i18n.Printer, b *models.HistoryBackfill) string {
+	result := p.Sprintf("Загрузка истории дневных свечей за %s – %s: тикеров %d, сохранено свечей %d\n\n",
+		b.From.Format("02.01.2006"), b.To.Format("02.01.2006"), len(b.Tickers), b.Saved)
+	for _, item := range b.Tickers {
+		switch {
+		case item.Error != "":
+			result += p.Sprintf("- %s: ошибка после %d свечей: %s\n", item.Ticker, item.Saved, item.Error)
+		case item.Requests == 0:
+			result += p.Sprintf("- %s: история уже загружена\n", item.Ticker)
+		case item.Resumed:
+			result += p.Sprintf("- %s: догружено свечей %d\n", item.Ticker, item.Saved)
+		default:
+			result += p.Sprintf("- %s: загружено свечей %d\n", item.Ticker, item.Saved)
+		}
+	}
+	if b.Failed > 0 {
+		result += p.Sprintf("\nНе загружено тикеров: %d. Повторный запуск продолжит загрузку с места остановки\n", b.Failed)
+	}
+	return result
+}
+
+// historyPeriod разбирает границы периода истории; пустая граница остается нулевой
+func historyPeriod(p i18n.Printer, fromArg, toArg string) (time.Time, time.Time, error) {
+	var from, to time.Time
+	if fromArg != "" {
+		parsed, _, err := parseHistoryTime(fromArg)
+		if err != nil {
+			return from, to, p.Errorf("параметр from должен быть в формате YYYY-MM-DD или YYYY-MM-DD HH:MM")
+		}
+		from = parsed
+	}
+	if toArg != "" {
+		parsed, dateOnly, err := parseHistoryTime(toArg)
+		if err != nil {
+			return from, to, p.Errorf("параметр to должен быть в формате YYYY-MM-DD или YYYY-MM-DD HH:MM")
+		}
+		// Дата без времени включает весь день, но не позже текущего момента
+		if dateOnly {
+			parsed = parsed.Add(24*time.Hour - time.Second)
+			if now := time.Now(); parsed.After(now) {
+				parsed = now
+			}
+		}
+		to = parsed
+	}
+	return from, to, nil
+}
+
+// parseHistoryTime разбирает дату или дату со временем по московскому времени
+// и сообщает, была ли указана только дата
+func parseHistoryTime(value string) (time.Time, bool, error) {
+	if parsed, err := time.ParseInLocation("2006-01-02 15:04", value, models.MoscowLocation); err == nil {
+		return parsed, false, nil
+	}
+	parsed, err := time.ParseInLocation("2006-01-02", value, models.MoscowLocation)
+	return parsed, true, err
+}
+
+// addStockTrend дополняет карточку акции спарклайном цен закрытия за последний месяц.
+// Если истории нет или она недоступна, карточка выводится без спарклайна
+func (s *Server) addStockTrend(ctx context.Context, card *render.StockCard) {
+	history, err := s.stockService.GetStockHistoricalData(ctx, card.Ticker, models.IntervalDay, time.Time{}, time.Time{})
+	if err != nil || len(history) < 2 {
+		return
+	}
+	card.Sparkline = chart.Sparkline(closePrices(history), chart.DefaultSparklineWidth)
+	card.TrendDays = len(history)
+	card.TrendFrom = history[0].Close
+}
+
+// closePrices возвращает цены закрытия свечей
+func closePrices(history []models.StockQuote) []float64 {
+	closes := make([]float64, len(history))
+	for i, quote := range history {
+		closes[i] = quote.Close
+	}
+	return closes
+}
+
+// formatStockHistory форматирует сводку за период, спарклайн цен закрытия (если sparkline) и последние limit свечей.
+// Время внутридневных свечей выводится по московскому времени
+func formatStockHistory(p i18n.Printer, ticker, interval string, history []models.StockQuote, limit int, sparkline bool) string {
+	layout := "02.01.2006"
+	if models.IsIntraday(interval) {
+		layout = "02.01.2006 15:04"
+	}
+
+	first, last := history[0], history[len(history)-1]
+	high, low := first.High, first.Low
+	var volume int64
+	for _, quote := range history {
+		if quote.High > high {
+			high = quote.High
+		}
+		if quote.Low < low {
+			low = quote.Low
+		}
+		volume += quote.Volume
+	}
+
+	result := p.Sprintf("История %s, свечи %s, %s – %s (%d шт.):\n",
+		ticker, interval,
+		first.Date.In(models.MoscowLocation).Format(layout), last.Date.In(models.MoscowLocation).Format(layout),
+		len(history))
+	sign := render.CurrencySign(ticker)
+	result += p.Sprintf("Открытие: %.2f %s, закрытие: %.2f %s", first.Open, sign, last.Close, sign)
+	if first.Open > 0 {
+		result += fmt.Sprintf(" (%+.2f%%)", (last.Close-first.Open)/first.Open*100)
+	}
+	result += p.Sprintf("\nМаксимум: %.2f %s, минимум: %.2f %s\n", high, sign, low, sign)
+	result += p.Sprintf("Суммарный объем: %d\n", volume)
+	if sparkline && len(history) > 1 {
+		result += p.Sprintf("Цена закрытия: %s\n", chart.Sparkline(closePrices(history), chart.DefaultSparklineWidth))
+	}
+	result += "\n"
+
+	shown := history
+	if len(shown) > limit {
+		shown = shown[len(shown)-limit:]
+		result += p.Sprintf("Последние %d свечей:\n", limit)
+	}
+	for _, quote := range shown {
+		result += fmt.Sprintf("%s  O: %.2f  H: %.2f  L: %.2f  C: %.2f  V: %d\n",
+			quote.Date.In(models.MoscowLocation).Format(layout), quote.Open, quote.High, quote.Low, quote.Close, quote.Volume)
+	}
+
+	return result
+}
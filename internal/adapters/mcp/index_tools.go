@@ -0,0 +1,88 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/i18n"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// registerIndexTools регистрирует инструмент состава биржевых индексов
+func (s *Server) registerIndexTools() {
+	if s.indexService == nil {
+		return
+	}
+
+	indexes := s.indexService.GetIndexes()
+	getIndexConstituentsTool := mcp.NewTool("get_index_constituents",
+		mcp.WithDescription(s.printer.T("Получить состав индекса Московской биржи с весами бумаг, текущими котировками и вкладом каждой бумаги в изменение индекса за день")),
+		mcp.WithString("index",
+			mcp.Required(),
+			mcp.Description(s.printer.T("Код индекса")),
+			mcp.Enum(indexes...),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description(s.printer.T("Количество бумаг с наибольшим весом (по умолчанию все)")),
+		),
+	)
+
+	s.addTool(getIndexConstituentsTool, s.handleGetIndexConstituents, sourceMOEX)
+}
+
+// handleGetIndexConstituents обрабатывает запрос на получение состава индекса
+func (s *Server) handleGetIndexConstituents(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		Index string `arg:"index,required"`
+		Limit int    `arg:"limit" min:"1"`
+	}
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	p := i18n.PrinterFrom(ctx)
+
+	composition, err := s.indexService.GetIndexConstituents(ctx, args.Index)
+	if err != nil {
+		return mcp.NewToolResultError(p.Sprintf("не удалось получить состав индекса: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(formatIndexComposition(p, composition, args.Limit)), nil
+}
+
+// formatIndexComposition форматирует состав индекса на языке переводчика p; limit ограничивает число бумаг, 0 — все бумаги
+func formatIndexComposition(p i18n.Printer, c *models.IndexComposition, limit int) string {
+	result := p.Sprintf("Состав индекса %s", c.Index)
+	if !c.TradeDate.IsZero() {
+		result += p.Sprintf(" (веса на %s)", c.TradeDate.In(models.MoscowLocation).Format("02.01.2006"))
+	}
+	result += p.Sprintf(": %d бумаг\n", len(c.Constituents))
+	if c.TotalWeight > 0 {
+		result += p.Sprintf("Изменение по весам бумаг: %+.2f%% (бумаги с котировками — %.2f%% индекса)\n", c.WeightedChangePerc, c.TotalWeight)
+	}
+	result += "\n"
+
+	shown := c.Constituents
+	if limit > 0 && limit < len(shown) {
+		shown = shown[:limit]
+	}
+	for i, quote := range shown {
+		result += fmt.Sprintf("%d. %s", i+1, quote.Ticker)
+		if quote.Name != "" {
+			result += fmt.Sprintf(" (%s)", quote.Name)
+		}
+		result += p.Sprintf(": вес %.2f%%", quote.Weight)
+		if quote.NoQuote {
+			result += p.T(", котировка недоступна\n")
+			continue
+		}
+		result += p.Sprintf(", %.2f ₽ (%+.2f%%), вклад %+.3f п.п.\n", quote.Price, quote.ChangePerc, quote.Contribution)
+	}
+	if len(shown) < len(c.Constituents) {
+		result += p.Sprintf("\nПоказаны %d бумаг с наибольшим весом из %d\n", len(shown), len(c.Constituents))
+	}
+
+	return result
+}
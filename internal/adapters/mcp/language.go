@@ -0,0 +1,62 @@
+package mcp
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/i18n"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// langArgName аргумент языка ответа, который принимают все инструменты.
+// Имя language уже занято языком новостей в search_news
+const langArgName = "lang"
+
+// defaultLanguage возвращает язык по умолчанию из конфигурации; неизвестный язык заменяется русским
+func defaultLanguage(code string) i18n.Language {
+	lang, ok := i18n.Parse(code)
+	if !ok {
+		log.Printf("Неизвестный язык %q в server.language, используется %s", code, i18n.Default)
+		return i18n.Default
+	}
+	return lang
+}
+
+// withLangArg добавляет к инструменту аргумент lang
+func (s *Server) withLangArg(tool *mcp.Tool) {
+	mcp.WithString(langArgName,
+		mcp.Description(s.printer.Sprintf("Язык ответа (по умолчанию %s)", s.printer.Language())),
+		mcp.Enum(i18n.Codes()...),
+	)(tool)
+}
+
+// languageMiddleware определяет язык ответа по аргументу lang или языку по умолчанию из конфигурации
+// и передает его обработчику и остальным middleware через контекст.
+// Аргумент убирается из запроса, чтобы проверка аргументов обработчика не считала его неизвестным
+func (s *Server) languageMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		lang := s.printer.Language()
+		if raw, ok := request.Params.Arguments[langArgName]; ok {
+			code, _ := raw.(string)
+			parsed, ok := i18n.Parse(code)
+			if !ok {
+				return mcp.NewToolResultError(s.printer.Sprintf("параметр %s должен быть одним из: %s",
+					langArgName, strings.Join(i18n.Codes(), ", "))), nil
+			}
+			lang = parsed
+
+			arguments := make(map[string]interface{}, len(request.Params.Arguments))
+			for name, value := range request.Params.Arguments {
+				if name != langArgName {
+					arguments[name] = value
+				}
+			}
+			request.Params.Arguments = arguments
+		}
+
+		return next(i18n.WithLanguage(ctx, lang), request)
+	}
+}
@@ -0,0 +1,141 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/i18n"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// listingKindNames названия видов размещения
+var listingKindNames = map[string]string{
+	models.ListingKindIPO:    "IPO",
+	models.ListingKindSPO:    "SPO",
+	models.ListingKindDirect: "листинг",
+}
+
+// registerListingTools регистрирует инструменты календаря размещений
+func (s *Server) registerListingTools() {
+	if s.listingService == nil {
+		return
+	}
+
+	getUpcomingIPOsTool := mcp.NewTool("get_upcoming_ipos",
+		mcp.WithDescription(s.printer.T("Получить объявленные IPO и SPO на Московской Бирже, торги по которым еще не начались: ожидаемая дата, ценовой диапазон и комментарии")),
+	)
+
+	s.addTool(getUpcomingIPOsTool, s.handleGetUpcomingIPOs)
+
+	getRecentListingsTool := mcp.NewTool("get_recent_listings",
+		mcp.WithDescription(s.printer.T("Получить акции, начавшие торговаться на Московской Бирже за последние дни: IPO, SPO и прямые листинги с уровнем листинга")),
+		mcp.WithNumber("days",
+			mcp.Description(s.printer.Sprintf("Глубина поиска в днях (по умолчанию %d, не более %d)", models.DefaultRecentListingsDays, models.MaxRecentListingsDays)),
+		),
+	)
+
+	s.addTool(getRecentListingsTool, s.handleGetRecentListings, sourceMOEX)
+}
+
+// handleGetUpcomingIPOs обрабатывает запрос на получение объявленных размещений
+func (s *Server) handleGetUpcomingIPOs(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args noArgs
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	p := i18n.PrinterFrom(ctx)
+	listings, err := s.listingService.GetUpcomingIPOs(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(p.Sprintf("не удалось получить календарь размещений: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(formatUpcomingIPOs(p, listings)), nil
+}
+
+// handleGetRecentListings обрабатывает запрос на получение новых листингов
+func (s *Server) handleGetRecentListings(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// Граница совпадает с models.MaxRecentListingsDays
+	args := struct {
+		Days int `arg:"days" min:"1" max:"365"`
+	}{Days: models.DefaultRecentListingsDays}
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	days := args.Days
+
+	p := i18n.PrinterFrom(ctx)
+	listings, err := s.listingService.GetRecentListings(ctx, days)
+	if err != nil {
+		return mcp.NewToolResultError(p.Sprintf("не удалось получить новые листинги: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(formatRecentListings(p, listings, days)), nil
+}
+
+// formatUpcomingIPOs форматирует объявленные размещения по ожидаемой дате на языке переводчика p
+func formatUpcomingIPOs(p i18n.Printer, listings []models.Listing) string {
+	if len(listings) == 0 {
+		return p.T("Объявленных размещений нет. Календарь пополняется из файла listings.feedPath, заданного в конфигурации сервера")
+	}
+
+	now := time.Now().In(models.MoscowLocation)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, models.MoscowLocation)
+	result := p.Sprintf("Объявленные размещения (%d):\n", len(listings))
+	for i, listing := range listings {
+		result += fmt.Sprintf("%d. %s %s", i+1, p.T(listingKindNames[listing.Kind]), listing.Ticker)
+		if listing.Name != "" {
+			result += fmt.Sprintf(" (%s)", listing.Name)
+		}
+		result += "\n"
+
+		switch {
+		case listing.ExpectedDate.IsZero():
+			result += p.T("   Дата начала торгов: не объявлена\n")
+		case listing.ExpectedDate.Before(today):
+			result += p.Sprintf("   Дата начала торгов: %s — прошла, но бумага еще не появилась в списке торгуемых\n", listing.ExpectedDate.In(models.MoscowLocation).Format("02.01.2006"))
+		default:
+			result += p.Sprintf("   Дата начала торгов: %s\n", listing.ExpectedDate.In(models.MoscowLocation).Format("02.01.2006"))
+		}
+		if listing.PriceRange != "" {
+			result += p.Sprintf("   Ценовой диапазон: %s\n", listing.PriceRange)
+		}
+		if listing.Notes != "" {
+			result += fmt.Sprintf("   %s\n", listing.Notes)
+		}
+	}
+
+	return result
+}
+
+// formatRecentListings форматирует новые листинги от новых к старым на языке переводчика p
+func formatRecentListings(p i18n.Printer, listings []models.Listing, days int) string {
+	if len(listings) == 0 {
+		return p.Sprintf("За последние %d дней новых акций в основном режиме торгов MOEX не появилось", days)
+	}
+
+	result := p.Sprintf("Новые листинги за последние %d дней (%d):\n", days, len(listings))
+	for i, listing := range listings {
+		result += fmt.Sprintf("%d. %s — %s", i+1, listing.ListedAt.In(models.MoscowLocation).Format("02.01.2006"), listing.Ticker)
+		if listing.Name != "" {
+			result += fmt.Sprintf(" (%s)", listing.Name)
+		}
+		result += fmt.Sprintf(", %s", p.T(listingKindNames[listing.Kind]))
+		if listing.ListingLevel > 0 {
+			result += p.Sprintf(", уровень листинга %d", listing.ListingLevel)
+		}
+		result += "\n"
+
+		if listing.PriceRange != "" {
+			result += p.Sprintf("   Ценовой диапазон размещения: %s\n", listing.PriceRange)
+		}
+		if listing.Notes != "" {
+			result += fmt.Sprintf("   %s\n", listing.Notes)
+		}
+	}
+
+	return result
+}
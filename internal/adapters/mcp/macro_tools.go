@@ -0,0 +1,179 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/i18n"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// maxMacroValuesShown максимальное количество значений в ответе get_macro_indicator
+const maxMacroValuesShown = 60
+
+// registerMacroTools регистрирует инструмент макроэкономических показателей
+func (s *Server) registerMacroTools() {
+	if s.macroService == nil {
+		return
+	}
+
+	codes := make([]string, 0, len(models.MacroIndicators))
+	for _, indicator := range models.MacroIndicators {
+		codes = append(codes, indicator.Code)
+	}
+
+	getMacroIndicatorTool := mcp.NewTool("get_macro_indicator",
+		mcp.WithDescription(s.printer.T("Получить историю макроэкономического показателя: инфляции, ВВП, безработицы, ключевой ставки, курса доллара или цены нефти Brent")),
+		mcp.WithString("indicator",
+			mcp.Required(),
+			mcp.Description(s.printer.T("Код показателя")),
+			mcp.Enum(codes...),
+		),
+		mcp.WithString("from",
+			mcp.Description(s.printer.Sprintf("Начало периода в формате YYYY-MM-DD (по умолчанию %d месяца назад)", models.DefaultMacroHistoryMonths)),
+		),
+		mcp.WithString("to",
+			mcp.Description(s.printer.T("Окончание периода в формате YYYY-MM-DD, включительно (по умолчанию сегодня)")),
+		),
+	)
+
+	s.addTool(getMacroIndicatorTool, s.handleGetMacroIndicator, sourceCBR, sourceMOEX)
+}
+
+// handleGetMacroIndicator обрабатывает запрос на получение истории макропоказателя
+func (s *Server) handleGetMacroIndicator(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		Indicator string `arg:"indicator,required"`
+		From      string `arg:"from"`
+		To        string `arg:"to"`
+	}
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	now := time.Now().In(models.MoscowLocation)
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, models.MoscowLocation)
+	if args.To != "" {
+		parsed, err := parseDateArg(ctx, "to", args.To, models.MoscowLocation)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		to = parsed
+	}
+	from := to.AddDate(0, -models.DefaultMacroHistoryMonths, 0)
+	if args.From != "" {
+		parsed, err := parseDateArg(ctx, "from", args.From, models.MoscowLocation)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		from = parsed
+	}
+	// Окончание периода включается целиком
+	to = to.AddDate(0, 0, 1).Add(-time.Nanosecond)
+
+	p := i18n.PrinterFrom(ctx)
+	series, err := s.macroService.GetIndicator(ctx, args.Indicator, from, to)
+	if err != nil {
+		return mcp.NewToolResultError(p.Sprintf("не удалось получить показатель: %v", err)), nil
+	}
+
+	if len(series.Values) == 0 {
+		return mcp.NewToolResultText(p.Sprintf("Нет данных по показателю «%s» за %s – %s",
+			p.T(series.Spec.Name), from.Format("02.01.2006"), to.Format("02.01.2006"))), nil
+	}
+
+	return mcp.NewToolResultText(formatMacroSeries(p, series)), nil
+}
+
+// formatMacroSeries форматирует историю макропоказателя на языке переводчика p; показываются последние maxMacroValuesShown значений
+func formatMacroSeries(p i18n.Printer, series *models.MacroSeries) string {
+	values := series.Values
+	result := p.Sprintf("%s, %s (%d значений):\n", p.T(series.Spec.Name), p.T(series.Spec.Unit), len(values))
+	if len(values) > maxMacroValuesShown {
+		values = values[len(values)-maxMacroValuesShown:]
+		result += p.Sprintf("Последние %d значений:\n", maxMacroValuesShown)
+	}
+	for _, value := range values {
+		result += fmt.Sprintf("- %s: %.2f\n", formatMacroPeriod(p, series.Spec, value.Period), value.Value)
+	}
+
+	return result
+}
+
+// formatMacroSnapshots форматирует последние значения макропоказателей с изменением к предыдущему значению на языке переводчика p
+func formatMacroSnapshots(p i18n.Printer, snapshots []models.MacroSnapshot) string {
+	result := p.T("Макроэкономические показатели:\n")
+	for _, snapshot := range snapshots {
+		result += p.Sprintf("- %s: %.2f %s за %s", p.T(snapshot.Spec.Name), snapshot.Latest.Value, p.T(snapshot.Spec.Unit),
+			formatMacroPeriod(p, snapshot.Spec, snapshot.Latest.Period))
+		if snapshot.Previous != nil {
+			result += p.Sprintf(" (предыдущее значение %.2f за %s)", snapshot.Previous.Value,
+				formatMacroPeriod(p, snapshot.Spec, snapshot.Previous.Period))
+		}
+		result += "\n"
+	}
+
+	return result
+}
+
+// formatMacroPeriod форматирует период значения в соответствии с периодичностью показателя на языке переводчика p
+func formatMacroPeriod(p i18n.Printer, spec models.MacroIndicatorSpec, period time.Time) string {
+	period = period.In(models.MoscowLocation)
+	switch spec.Frequency {
+	case models.MacroFrequencyQuarterly:
+		return p.Sprintf("%d кв. %d", (int(period.Month())-1)/3+1, period.Year())
+	case models.MacroFrequencyMonthly:
+		return period.Format("01.2006")
+	default:
+		return period.Format("02.01.2006")
+	}
+}
+
+// handleMacroOverviewPrompt обрабатывает запрос на получение шаблона макроэкономического обзора
+func (s *Server) handleMacroOverviewPrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	snapshots, err := s.macroService.GetSnapshots(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось получить макропоказатели: %w", err)
+	}
+	if len(snapshots) == 0 {
+		return nil, fmt.Errorf("макропоказатели еще не загружены")
+	}
+
+	systemMessage := `Ты - макроэкономист, анализирующий влияние экономики России на рынок акций.
+Подготовь макроэкономический обзор, используя предоставленные данные.
+Включи в обзор:
+1. Оценку фазы экономического цикла по динамике ВВП и безработицы
+2. Анализ инфляции относительно цели Банка России 4% и вероятное решение по ключевой ставке
+3. Влияние курса рубля и цен на нефть на бюджет и экспортеров
+4. Выводы для инвесторов: какие секторы выигрывают и проигрывают в текущих условиях`
+
+	p := i18n.PrinterFrom(ctx)
+	content := formatMacroSnapshots(p, snapshots)
+
+	// Добавляем сводку по ключевой ставке с RUONIA
+	if s.cbrService != nil {
+		if summary, err := s.cbrService.GetKeyRate(ctx); err != nil {
+			log.Printf("ПРЕДУПРЕЖДЕНИЕ: не удалось получить ключевую ставку: %v", err)
+		} else {
+			content += "\n" + formatKeyRate(p, summary)
+		}
+	}
+
+	return mcp.NewGetPromptResult(
+		"Макроэкономический обзор",
+		[]mcp.PromptMessage{
+			mcp.NewPromptMessage(
+				mcp.RoleAssistant,
+				mcp.NewTextContent(systemMessage),
+			),
+			mcp.NewPromptMessage(
+				mcp.RoleUser,
+				mcp.NewTextContent(content),
+			),
+		},
+	), nil
+}
@@ -0,0 +1,152 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/adapters/render"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/i18n"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// registerMarginTools регистрирует инструменты маржинальных списков брокеров
+func (s *Server) registerMarginTools() {
+	if s.marginService == nil {
+		return
+	}
+
+	// Инструмент для получения маржинальных бумаг
+	getMarginableStocksTool := mcp.NewTool("get_marginable_stocks",
+		mcp.WithDescription(s.printer.T("Получить бумаги из маржинальных списков брокеров: доступные для покупки с плечом или для шорта, со ставками риска и платой за перенос короткой позиции. Первыми идут бумаги с наибольшим плечом")),
+		mcp.WithString("side",
+			mcp.Description(s.printer.T("Сторона: long — покупка с плечом, short — продажа без покрытия; по умолчанию бумаги, доступные на любой стороне")),
+			mcp.Enum(models.MarginSides...),
+		),
+		mcp.WithString("broker",
+			mcp.Description(s.printer.T("Брокер; по умолчанию списки всех брокеров")),
+		),
+		s.limitArg("акций"),
+		s.offsetArg(),
+	)
+
+	s.addTool(getMarginableStocksTool, s.handleGetMarginableStocks, sourceMOEX)
+}
+
+// handleGetMarginableStocks обрабатывает запрос на получение маржинальных бумаг
+func (s *Server) handleGetMarginableStocks(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		Side   string `arg:"side" enum:"long|short"`
+		Broker string `arg:"broker"`
+		pageArgs
+	}
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	p := i18n.PrinterFrom(ctx)
+	page := args.page()
+	statuses, total, err := s.marginService.GetMarginableStocks(ctx, args.Side, args.Broker, page)
+	if err != nil {
+		return mcp.NewToolResultError(p.Sprintf("не удалось получить маржинальные списки: %v", err)), nil
+	}
+
+	if total == 0 {
+		return mcp.NewToolResultText(p.T("Бумаг в маржинальных списках не найдено")), nil
+	}
+
+	result := p.T("Маржинальные бумаги")
+	switch args.Side {
+	case models.MarginSideLong:
+		result += p.T(", доступные для покупки с плечом")
+	case models.MarginSideShort:
+		result += p.T(", доступные для шорта")
+	}
+	if args.Broker != "" {
+		result += p.Sprintf(" у брокера %s", args.Broker)
+	}
+	result += ":\n\n"
+	for i, status := range statuses {
+		result += fmt.Sprintf("%d. %s (%s): %s\n", page.Offset+i+1, status.Ticker, status.Broker, formatMarginTerms(p, status))
+	}
+
+	footer, err := s.renderer.Render(p, "page_footer", render.NewPage(page, len(statuses), total))
+	if err != nil {
+		return mcp.NewToolResultError(p.Sprintf("ошибка оформления результата: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(result + footer), nil
+}
+
+// addMarginStatus дополняет карточку акции условиями маржинальной торговли у брокеров.
+// Если маржинальные списки не подключены или недоступны, карточка выводится без них
+func (s *Server) addMarginStatus(ctx context.Context, card *render.StockCard) {
+	if s.marginService == nil {
+		return
+	}
+
+	statuses, err := s.marginService.GetMarginStatus(ctx, card.Ticker)
+	if err != nil {
+		log.Printf("ПРЕДУПРЕЖДЕНИЕ: не удалось получить маржинальные списки для %s: %v", card.Ticker, err)
+		return
+	}
+	card.MarginChecked = true
+	card.Margin = statuses
+}
+
+// marginContext возвращает доступность бумаг для шорта и покупки с плечом для шаблонов анализа
+// или пустую строку, если маржинальные списки не подключены
+func (s *Server) marginContext(ctx context.Context, tickers []string) string {
+	if s.marginService == nil || len(tickers) == 0 {
+		return ""
+	}
+
+	p := i18n.PrinterFrom(ctx)
+	result := p.T("Маржинальные списки брокеров:\n")
+	for _, ticker := range tickers {
+		statuses, err := s.marginService.GetMarginStatus(ctx, ticker)
+		if err != nil {
+			log.Printf("ПРЕДУПРЕЖДЕНИЕ: не удалось получить маржинальные списки для %s: %v", ticker, err)
+			return ""
+		}
+		if len(statuses) == 0 {
+			result += p.Sprintf("- %s: нет в маржинальных списках, шорт недоступен\n", ticker)
+			continue
+		}
+		for _, status := range statuses {
+			result += fmt.Sprintf("- %s (%s): %s\n", ticker, status.Broker, formatMarginTerms(p, status))
+		}
+	}
+	return result
+}
+
+// formatMarginTerms описывает условия маржинальной торговли бумагой у брокера на языке переводчика p
+func formatMarginTerms(p i18n.Printer, status models.MarginStatus) string {
+	var parts []string
+	if status.Long {
+		parts = append(parts, p.T("лонг")+formatRiskRate(p, status.RiskRateLong))
+	} else {
+		parts = append(parts, p.T("лонг с плечом недоступен"))
+	}
+	if status.Short {
+		short := p.T("шорт") + formatRiskRate(p, status.RiskRateShort)
+		if status.ShortFeePerc > 0 {
+			short += p.Sprintf(", перенос %.2f%% годовых", status.ShortFeePerc)
+		}
+		parts = append(parts, short)
+	} else {
+		parts = append(parts, p.T("шорт недоступен"))
+	}
+	return strings.Join(parts, "; ") + p.Sprintf(" (список от %s)", status.UpdatedAt.Format("02.01.2006"))
+}
+
+// formatRiskRate описывает ставку риска и соответствующее ей плечо; пустая строка, если ставка не указана
+func formatRiskRate(p i18n.Printer, rate float64) string {
+	if rate <= 0 {
+		return ""
+	}
+	return p.Sprintf(": ставка риска %.1f%%, плечо до %.1fx", rate, models.Leverage(rate))
+}
@@ -0,0 +1,176 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/i18n"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// orderBookImbalanceThreshold дисбаланс стакана, начиная с которого перевес одной из сторон считается заметным
+const orderBookImbalanceThreshold = 0.2
+
+// registerMarketDataTools регистрирует инструменты биржевых данных реального времени
+func (s *Server) registerMarketDataTools() {
+	if s.marketDataService == nil {
+		return
+	}
+
+	getOrderBookTool := mcp.NewTool("get_orderbook",
+		mcp.WithDescription(s.printer.T("Получить стакан заявок по акции на MOEX: лучшие цены покупки и продажи с объемами, спред и дисбаланс спроса и предложения")),
+		mcp.WithString("ticker",
+			mcp.Required(),
+			mcp.Description(s.printer.T("Тикер акции (например, SBER, GAZP, LKOH)")),
+		),
+		mcp.WithNumber("depth",
+			mcp.Description(s.printer.Sprintf("Количество уровней с каждой стороны (по умолчанию %d, не более %d)", models.DefaultOrderBookDepth, models.MaxOrderBookDepth)),
+		),
+		s.boardArg(),
+		s.marketArg(),
+	)
+
+	s.addTool(getOrderBookTool, s.handleGetOrderBook, sourceMOEX)
+
+	getRecentTradesTool := mcp.NewTool("get_recent_trades",
+		mcp.WithDescription(s.printer.Sprintf("Получить ленту последних сделок по акции на MOEX: время, цена, объем и направление. Если сделок больше %d, они дополнительно сворачиваются по минутам", models.TradesAggregationThreshold)),
+		mcp.WithString("ticker",
+			mcp.Required(),
+			mcp.Description(s.printer.T("Тикер акции (например, SBER, GAZP, LKOH)")),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description(s.printer.Sprintf("Количество последних сделок (по умолчанию %d, не более %d)", models.DefaultRecentTrades, models.MaxRecentTrades)),
+		),
+		s.boardArg(),
+		s.marketArg(),
+	)
+
+	s.addTool(getRecentTradesTool, s.handleGetRecentTrades, sourceMOEX)
+}
+
+// handleGetOrderBook обрабатывает запрос на получение стакана заявок
+func (s *Server) handleGetOrderBook(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	p := i18n.PrinterFrom(ctx)
+	// Граница совпадает с models.MaxOrderBookDepth
+	args := struct {
+		Ticker string `arg:"ticker,required"`
+		Depth  int    `arg:"depth" min:"1" max:"20"`
+		boardArgs
+	}{Depth: models.DefaultOrderBookDepth}
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	board, err := args.tradingBoard(p)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	book, err := s.marketDataService.GetOrderBook(ctx, args.Ticker, board, args.Depth)
+	if err != nil {
+		return mcp.NewToolResultError(p.Sprintf("не удалось получить стакан: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(formatOrderBook(p, book)), nil
+}
+
+// handleGetRecentTrades обрабатывает запрос на получение ленты сделок
+func (s *Server) handleGetRecentTrades(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	p := i18n.PrinterFrom(ctx)
+	// Граница совпадает с models.MaxRecentTrades
+	args := struct {
+		Ticker string `arg:"ticker,required"`
+		Limit  int    `arg:"limit" min:"1" max:"5000"`
+		boardArgs
+	}{Limit: models.DefaultRecentTrades}
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	board, err := args.tradingBoard(p)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	trades, err := s.marketDataService.GetRecentTrades(ctx, args.Ticker, board, args.Limit)
+	if err != nil {
+		return mcp.NewToolResultError(p.Sprintf("не удалось получить ленту сделок: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(formatRecentTrades(p, trades)), nil
+}
+
+// formatOrderBook форматирует стакан на языке переводчика p: сначала спред и дисбаланс, затем уровни продажи
+// над уровнями покупки
+func formatOrderBook(p i18n.Printer, b *models.OrderBook) string {
+	title := b.Ticker
+	if b.Board != "" {
+		title += " (" + b.Board + ")"
+	}
+	result := p.Sprintf("Стакан %s на %s:\n", title, b.UpdatedAt.In(models.MoscowLocation).Format("15:04:05"))
+
+	if b.BestBid > 0 && b.BestAsk > 0 {
+		result += p.Sprintf("Лучшая покупка: %.2f ₽, лучшая продажа: %.2f ₽\n", b.BestBid, b.BestAsk)
+		result += p.Sprintf("Спред: %.2f ₽ (%.3f%% от средней цены %.2f ₽)\n", b.Spread, b.SpreadPerc, b.MidPrice)
+	} else {
+		result += p.T("Спред: нет данных, заявки есть только с одной стороны\n")
+	}
+
+	result += p.Sprintf("Объем заявок: покупка %d лотов, продажа %d лотов\n", b.BidVolume, b.AskVolume)
+	switch {
+	case b.Imbalance >= orderBookImbalanceThreshold:
+		result += p.Sprintf("Дисбаланс: %+.2f — перевес покупателей\n", b.Imbalance)
+	case b.Imbalance <= -orderBookImbalanceThreshold:
+		result += p.Sprintf("Дисбаланс: %+.2f — перевес продавцов\n", b.Imbalance)
+	default:
+		result += p.Sprintf("Дисбаланс: %+.2f — спрос и предложение сбалансированы\n", b.Imbalance)
+	}
+
+	result += p.T("\nПродажа:\n")
+	for i := len(b.Asks) - 1; i >= 0; i-- {
+		result += fmt.Sprintf("   %.2f ₽ × %d\n", b.Asks[i].Price, b.Asks[i].Quantity)
+	}
+	result += p.T("Покупка:\n")
+	for _, level := range b.Bids {
+		result += fmt.Sprintf("   %.2f ₽ × %d\n", level.Price, level.Quantity)
+	}
+
+	return result
+}
+
+// formatRecentTrades форматирует ленту сделок на языке переводчика p: сводку по направлениям, затем минутные
+// интервалы или отдельные сделки от последней
+func formatRecentTrades(p i18n.Printer, t *models.RecentTrades) string {
+	from := t.From.In(models.MoscowLocation)
+	to := t.To.In(models.MoscowLocation)
+	result := p.Sprintf("Последние %d сделок %s с %s по %s (МСК):\n", len(t.Trades), t.Ticker, from.Format("02.01.2006 15:04:05"), to.Format("15:04:05"))
+	result += p.Sprintf("Средневзвешенная цена: %.2f ₽, оборот: %.0f ₽\n", t.VWAP, t.TotalValue)
+	result += p.Sprintf("Объем покупок: %d лотов, объем продаж: %d лотов\n", t.BuyQuantity, t.SellQuantity)
+
+	if t.Aggregated {
+		result += p.T("\nПо минутам (от последней):\n")
+		result += p.T("| Минута | Открытие | Макс. | Мин. | Закрытие | Сделок | Покупки, лотов | Продажи, лотов | VWAP |\n")
+		result += "|---|---|---|---|---|---|---|---|---|\n"
+		for i := len(t.Minutes) - 1; i >= 0; i-- {
+			m := t.Minutes[i]
+			result += fmt.Sprintf("| %s | %.2f | %.2f | %.2f | %.2f | %d | %d | %d | %.2f |\n",
+				m.Minute.In(models.MoscowLocation).Format("15:04"), m.Open, m.High, m.Low, m.Close, m.Trades, m.BuyQuantity, m.SellQuantity, m.VWAP)
+		}
+		return result
+	}
+
+	result += p.T("\nСделки (от последней):\n")
+	for i := len(t.Trades) - 1; i >= 0; i-- {
+		trade := t.Trades[i]
+		side := ""
+		switch trade.Side {
+		case models.TradeSideBuy:
+			side = p.T(" покупка")
+		case models.TradeSideSell:
+			side = p.T(" продажа")
+		}
+		result += fmt.Sprintf("   %s  %.2f ₽ × %d%s\n", trade.Time.In(models.MoscowLocation).Format("15:04:05"), trade.Price, trade.Quantity, side)
+	}
+
+	return result
+}
@@ -0,0 +1,80 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/i18n"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// registerMoodTools регистрирует инструмент индекса настроения рынка
+func (s *Server) registerMoodTools() {
+	if s.moodService == nil {
+		return
+	}
+
+	getMarketMoodTool := mcp.NewTool("get_market_mood",
+		mcp.WithDescription(s.printer.T("Получить составной индекс настроения рынка (0 — сильный страх, 100 — эйфория) по ширине рынка, волатильности, тональности новостей и курсу рубля, а также его историю по дням")),
+		mcp.WithNumber("history_days",
+			mcp.Description(s.printer.Sprintf("За сколько последних дней показать историю индекса (по умолчанию %d, не более %d)", models.DefaultMoodHistoryDays, models.MaxMoodHistoryDays)),
+		),
+	)
+
+	s.addTool(getMarketMoodTool, s.handleGetMarketMood, sourceMOEX, sourceNews)
+}
+
+// handleGetMarketMood обрабатывает запрос на получение индекса настроения рынка
+func (s *Server) handleGetMarketMood(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// Граница совпадает с models.MaxMoodHistoryDays
+	args := struct {
+		HistoryDays int `arg:"history_days" min:"1" max:"365"`
+	}{HistoryDays: models.DefaultMoodHistoryDays}
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	p := i18n.PrinterFrom(ctx)
+
+	report, err := s.moodService.GetMarketMood(ctx, args.HistoryDays)
+	if err != nil {
+		return mcp.NewToolResultError(p.Sprintf("не удалось рассчитать индекс настроения рынка: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(formatMarketMood(p, report)), nil
+}
+
+// formatMarketMood форматирует индекс настроения рынка с составляющими и историей на языке переводчика p
+func formatMarketMood(p i18n.Printer, report *models.MarketMoodReport) string {
+	today := report.Today
+
+	var b strings.Builder
+	fmt.Fprintf(&b, p.T("Индекс настроения рынка на %s: %.0f из 100 (%s)\n\n"), today.Date.Format("02.01.2006"), today.Score, p.T(today.Label))
+
+	b.WriteString(p.T("Составляющие (от -1 — страх до +1 — жадность):\n"))
+	fmt.Fprintf(&b, p.T("- Ширина рынка: %+.2f (растут %d, падают %d)\n"), today.BreadthScore, today.Advancers, today.Decliners)
+	fmt.Fprintf(&b, p.T("- Волатильность: %+.2f (разброс дневных изменений %.2f%%)\n"), today.VolatilityScore, today.DispersionPerc)
+	if today.HasNews {
+		fmt.Fprintf(&b, p.T("- Тональность новостей: %+.2f (новостей за день: %d)\n"), today.NewsScore, today.NewsCount)
+	} else {
+		b.WriteString(p.T("- Тональность новостей: нет данных, не учитывается\n"))
+	}
+	if today.HasFX {
+		fmt.Fprintf(&b, p.T("- Курс рубля: %+.2f (%s %+.2f%% за день)\n"), today.FXScore, today.FXTicker, today.FXChangePerc)
+	} else {
+		b.WriteString(p.T("- Курс рубля: нет данных, не учитывается\n"))
+	}
+
+	if len(report.History) > 1 {
+		b.WriteString(p.T("\nИстория:\n"))
+		b.WriteString(p.T("Дата       | Индекс | Оценка\n"))
+		for _, mood := range report.History {
+			fmt.Fprintf(&b, "%s | %6.0f | %s\n", mood.Date.Format("02.01.2006"), mood.Score, p.T(mood.Label))
+		}
+	}
+
+	return b.String()
+}
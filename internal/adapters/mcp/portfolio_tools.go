@@ -0,0 +1,477 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/i18n"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// registerPortfolioTools регистрирует инструменты для работы с портфелем
+func (s *Server) registerPortfolioTools() {
+	if s.portfolioService == nil {
+		return
+	}
+
+	portfolioArg := mcp.WithString("portfolio",
+		mcp.Description(s.printer.T("Имя портфеля (по умолчанию default)")),
+	)
+
+	// Инструмент для просмотра портфеля
+	getPortfolioTool := mcp.NewTool("get_portfolio",
+		mcp.WithDescription(s.printer.T("Получить позиции портфеля и их оценку по текущим ценам")),
+		portfolioArg,
+	)
+
+	s.addTool(getPortfolioTool, s.handleGetPortfolio, sourceMOEX)
+
+	// Инструмент для добавления бумаг в портфель
+	addPositionTool := mcp.NewTool("add_position",
+		mcp.WithDescription(s.printer.T("Добавить акции в портфель; средняя цена позиции пересчитывается")),
+		mcp.WithString("ticker",
+			mcp.Required(),
+			mcp.Description(s.printer.T("Тикер акции (например, SBER, GAZP, LKOH)")),
+		),
+		mcp.WithNumber("quantity",
+			mcp.Required(),
+			mcp.Description(s.printer.T("Количество акций")),
+		),
+		mcp.WithNumber("price",
+			mcp.Description(s.printer.T("Цена покупки (по умолчанию текущая цена)")),
+		),
+		portfolioArg,
+		s.dryRunArg(),
+	)
+
+	s.addTool(addPositionTool, s.handleAddPosition, sourceMOEX)
+
+	// Инструмент для удаления бумаг из портфеля
+	removePositionTool := mcp.NewTool("remove_position",
+		mcp.WithDescription(s.printer.T("Уменьшить или закрыть позицию в портфеле")),
+		mcp.WithString("ticker",
+			mcp.Required(),
+			mcp.Description(s.printer.T("Тикер акции (например, SBER, GAZP, LKOH)")),
+		),
+		mcp.WithNumber("quantity",
+			mcp.Description(s.printer.T("Количество акций (по умолчанию вся позиция)")),
+		),
+		mcp.WithNumber("price",
+			mcp.Description(s.printer.T("Цена продажи для истории сделок и расчета налогов (по умолчанию текущая цена)")),
+		),
+		portfolioArg,
+		s.dryRunArg(),
+	)
+
+	s.addTool(removePositionTool, s.handleRemovePosition, sourceMOEX)
+
+	// Инструмент для оценки стоимости заявки
+	estimateOrderCostTool := mcp.NewTool("estimate_order_cost",
+		mcp.WithDescription(s.printer.T("Оценить стоимость заявки с учетом размера лота, шага цены и комиссии брокера")),
+		mcp.WithString("ticker",
+			mcp.Required(),
+			mcp.Description(s.printer.T("Тикер акции (например, SBER, GAZP, LKOH)")),
+		),
+		mcp.WithNumber("quantity",
+			mcp.Required(),
+			mcp.Description(s.printer.T("Количество акций; заявка округляется вверх до целых лотов")),
+		),
+		mcp.WithString("side",
+			mcp.Required(),
+			mcp.Description(s.printer.T("Направление сделки: buy — покупка, sell — продажа")),
+			mcp.Enum(models.OrderSides...),
+		),
+		mcp.WithNumber("price",
+			mcp.Description(s.printer.T("Цена лимитной заявки, округляется до шага цены (по умолчанию текущая цена)")),
+		),
+	)
+
+	s.addTool(estimateOrderCostTool, s.handleEstimateOrderCost, sourceMOEX)
+
+	// Инструмент для стресс-теста портфеля на исторических кризисах
+	scenarioIDs := make([]string, 0, len(s.portfolioService.StressScenarios()))
+	scenarioHelp := ""
+	for _, scenario := range s.portfolioService.StressScenarios() {
+		scenarioIDs = append(scenarioIDs, scenario.ID)
+		scenarioHelp += fmt.Sprintf("; %s — %s", scenario.ID, scenario.Name)
+	}
+
+	stressTestTool := mcp.NewTool("stress_test_portfolio",
+		mcp.WithDescription(s.printer.T("Применить исторический кризис к текущим позициям портфеля и оценить гипотетические просадки")),
+		mcp.WithString("scenario",
+			mcp.Required(),
+			mcp.Description(s.printer.T("Сценарий")+scenarioHelp),
+			mcp.Enum(scenarioIDs...),
+		),
+		portfolioArg,
+	)
+
+	s.addTool(stressTestTool, s.handleStressTestPortfolio, sourceMOEX)
+}
+
+// handleGetPortfolio обрабатывает запрос на получение портфеля
+func (s *Server) handleGetPortfolio(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	p := i18n.PrinterFrom(ctx)
+	var args struct {
+		Portfolio string `arg:"portfolio"`
+	}
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	summary, err := s.portfolioService.GetPortfolio(ctx, args.Portfolio)
+	if err != nil {
+		return mcp.NewToolResultError(p.Sprintf("не удалось получить портфель: %v", err)), nil
+	}
+
+	if len(summary.Positions) == 0 {
+		return mcp.NewToolResultText(p.Sprintf("Портфель %s пуст", summary.Name)), nil
+	}
+
+	// Формируем результат
+	result := p.Sprintf("Портфель %s:\n\n", summary.Name)
+	for i, position := range summary.Positions {
+		result += p.Sprintf("%d. %s: %d шт. по %.2f ₽ (средняя %.2f ₽)\n",
+			i+1, position.Ticker, position.Quantity, position.Price, position.AvgPrice)
+		if position.Known() {
+			result += p.Sprintf("   Лотов: %d по %d шт.", position.Lots, position.LotSize)
+			if position.OddLot > 0 {
+				result += p.Sprintf(", неполный лот: %d шт.", position.OddLot)
+			}
+			result += "\n"
+		}
+		result += p.Sprintf("   Стоимость: %.2f ₽, результат: %+.2f ₽ (%+.2f%%)\n",
+			position.Value, position.PnL, position.PnLPerc)
+	}
+	result += p.Sprintf("\nИтого: %.2f ₽, вложено: %.2f ₽, результат: %+.2f ₽\n",
+		summary.TotalValue, summary.TotalCost, summary.TotalPnL)
+	if summary.TotalCommission > 0 {
+		result += p.Sprintf("Комиссия брокера при продаже всех позиций: %.2f ₽, стоимость за ее вычетом: %.2f ₽\n",
+			summary.TotalCommission, summary.NetValue)
+	}
+
+	return mcp.NewToolResultText(result), nil
+}
+
+// handleAddPosition обрабатывает запрос на добавление бумаг в портфель
+func (s *Server) handleAddPosition(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	p := i18n.PrinterFrom(ctx)
+	var args struct {
+		Ticker    string  `arg:"ticker,required"`
+		Quantity  int64   `arg:"quantity,required" min:"1"`
+		Price     float64 `arg:"price" min:"0"`
+		Portfolio string  `arg:"portfolio"`
+		dryRunArgs
+	}
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	change, err := s.portfolioService.AddPosition(ctx, args.Portfolio, args.Ticker, args.Quantity, args.Price, args.DryRun)
+	if err != nil {
+		return mcp.NewToolResultError(p.Sprintf("не удалось добавить позицию: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(formatPositionChange(p, change)), nil
+}
+
+// handleRemovePosition обрабатывает запрос на уменьшение или закрытие позиции
+func (s *Server) handleRemovePosition(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	p := i18n.PrinterFrom(ctx)
+	var args struct {
+		Ticker    string  `arg:"ticker,required"`
+		Quantity  int64   `arg:"quantity" min:"1"`
+		Price     float64 `arg:"price" min:"0"`
+		Portfolio string  `arg:"portfolio"`
+		dryRunArgs
+	}
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	change, err := s.portfolioService.RemovePosition(ctx, args.Portfolio, args.Ticker, args.Quantity, args.Price, args.DryRun)
+	if err != nil {
+		return mcp.NewToolResultError(p.Sprintf("не удалось изменить позицию: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(formatPositionChange(p, change)), nil
+}
+
+// formatPositionChange форматирует изменение позиции в виде «было → стало» на языке переводчика p
+func formatPositionChange(p i18n.Printer, change *models.PositionChange) string {
+	result := ""
+	if change.DryRun {
+		result += p.T(dryRunNotice)
+	}
+
+	position := change.After
+	if position == nil {
+		position = change.Before
+	}
+	result += p.Sprintf("Позиция %s в портфеле %s", position.Ticker, position.Portfolio)
+
+	switch {
+	case change.Before == nil:
+		if change.DryRun {
+			result += p.T(" будет открыта:\n")
+		} else {
+			result += p.T(" открыта:\n")
+		}
+		result += p.Sprintf("   Количество: %d шт.\n", change.After.Quantity)
+		result += p.Sprintf("   Средняя цена: %.2f ₽\n", change.After.AvgPrice)
+		result += formatLots(p, change.LotSize, 0, change.After.Quantity)
+	case change.After == nil:
+		if change.DryRun {
+			result += p.T(" будет закрыта:\n")
+		} else {
+			result += p.T(" закрыта:\n")
+		}
+		result += p.Sprintf("   Количество: %d → 0 шт.\n", change.Before.Quantity)
+	default:
+		result += ":\n"
+		result += p.Sprintf("   Количество: %d → %d шт.\n", change.Before.Quantity, change.After.Quantity)
+		result += p.Sprintf("   Средняя цена: %.2f → %.2f ₽\n", change.Before.AvgPrice, change.After.AvgPrice)
+		result += formatLots(p, change.LotSize, change.Before.Quantity, change.After.Quantity)
+	}
+
+	return result
+}
+
+// formatLots форматирует число лотов позиции до и после изменения; before равен 0 для новой позиции.
+// Если размер лота неизвестен, возвращает пустую строку
+func formatLots(p i18n.Printer, lotSize int, before, after int64) string {
+	if lotSize <= 0 {
+		return ""
+	}
+
+	lot := int64(lotSize)
+	result := p.Sprintf("   Лотов: %d по %d шт.\n", after/lot, lotSize)
+	if before > 0 {
+		result = p.Sprintf("   Лотов: %d → %d по %d шт.\n", before/lot, after/lot, lotSize)
+	}
+	if odd := after % lot; odd > 0 {
+		result += p.Sprintf("   Неполный лот: %d шт. можно продать только в режиме торгов неполными лотами\n", odd)
+	}
+
+	return result
+}
+
+// handleEstimateOrderCost обрабатывает запрос на оценку стоимости заявки
+func (s *Server) handleEstimateOrderCost(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	p := i18n.PrinterFrom(ctx)
+	var args struct {
+		Ticker   string  `arg:"ticker,required"`
+		Quantity int64   `arg:"quantity,required" min:"1"`
+		Side     string  `arg:"side,required" enum:"buy|sell"`
+		Price    float64 `arg:"price" min:"0"`
+	}
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	estimate, err := s.portfolioService.EstimateOrderCost(ctx, args.Ticker, args.Quantity, args.Side, args.Price)
+	if err != nil {
+		return mcp.NewToolResultError(p.Sprintf("не удалось оценить заявку: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(formatOrderCost(p, estimate)), nil
+}
+
+// formatOrderCost форматирует оценку стоимости заявки на языке переводчика p
+func formatOrderCost(p i18n.Printer, e *models.OrderCostEstimate) string {
+	action := p.T("Покупка")
+	if e.Side == models.OrderSideSell {
+		action = p.T("Продажа")
+	}
+
+	priceKind := p.T("текущая цена")
+	if e.LimitPrice {
+		priceKind = p.T("цена заявки")
+	}
+
+	result := p.Sprintf("%s %d шт. %s:\n", action, e.Quantity, e.Ticker)
+	if e.Known() {
+		result += p.Sprintf("   Лотов: %d по %d шт. (%d шт.)\n", e.Lots, e.LotSize, e.LotQuantity)
+		if e.LotQuantity != e.Quantity {
+			result += p.Sprintf("   Заявка исполняется только целыми лотами: %d шт. вместо %d\n", e.LotQuantity, e.Quantity)
+		}
+	} else {
+		result += p.T("   Размер лота неизвестен: бумаги нет в справочнике, заявка рассчитана поштучно\n")
+	}
+	result += p.Sprintf("   Цена: %.2f ₽ (%s)", e.Price, priceKind)
+	if e.MinStep > 0 {
+		result += p.Sprintf(", шаг цены %g ₽", e.MinStep)
+	}
+	result += "\n"
+	result += p.Sprintf("   Сумма сделки: %.2f ₽\n", e.Amount)
+
+	if e.Tariff.IsZero() {
+		result += p.T("   Комиссия брокера не учитывается: тариф не задан в конфигурации (broker)\n")
+	} else {
+		result += p.Sprintf("   Комиссия брокера: %.2f ₽ (%g%%", e.Commission, e.Tariff.Perc)
+		if e.Tariff.Min > 0 {
+			result += p.Sprintf(", не меньше %.2f ₽", e.Tariff.Min)
+		}
+		result += ")\n"
+	}
+
+	if e.Side == models.OrderSideSell {
+		result += p.Sprintf("Итого к зачислению: %.2f ₽\n", e.Total)
+	} else {
+		result += p.Sprintf("Итого к списанию: %.2f ₽\n", e.Total)
+	}
+
+	return result
+}
+
+// handleStressTestPortfolio обрабатывает запрос на стресс-тест портфеля
+func (s *Server) handleStressTestPortfolio(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	p := i18n.PrinterFrom(ctx)
+	var args struct {
+		Scenario  string `arg:"scenario,required"`
+		Portfolio string `arg:"portfolio"`
+	}
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	stress, err := s.portfolioService.StressTest(ctx, args.Portfolio, args.Scenario)
+	if err != nil {
+		return mcp.NewToolResultError(p.Sprintf("не удалось выполнить стресс-тест: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(formatStressTest(p, stress)), nil
+}
+
+// formatStressTest форматирует результат стресс-теста портфеля на языке переводчика p
+func formatStressTest(p i18n.Printer, r *models.StressTestResult) string {
+	result := p.Sprintf("Стресс-тест портфеля %s: %s (%s — %s)\n",
+		r.Portfolio, r.Scenario.Name, r.Scenario.Start.Format("02.01.2006"), r.Scenario.End.Format("02.01.2006"))
+	result += fmt.Sprintf("%s\n\n", r.Scenario.Description)
+
+	result += p.T("Гипотетический результат по позициям:\n")
+	noData := 0
+	for i, position := range r.Positions {
+		if position.NoData {
+			noData++
+			result += p.Sprintf("%d. %s: нет исторических данных за период\n", i+1, position.Ticker)
+			continue
+		}
+		result += p.Sprintf("%d. %s: %+.2f%% за период, макс. просадка %.2f%%, %+.2f ₽ от %.2f ₽\n",
+			i+1, position.Ticker, position.ReturnPerc, position.MaxDrawdownPerc, position.PnL, position.Value)
+		if position.Proxy != "" {
+			result += p.Sprintf("   Истории бумаги нет, использована динамика %s\n", position.Proxy)
+		}
+	}
+
+	result += p.Sprintf("\nПортфель: %.2f ₽ → %.2f ₽ (%+.2f%%)\n", r.TotalValue, r.TotalValue+r.PnL, r.ReturnPerc)
+	result += p.Sprintf("Средневзвешенная максимальная просадка: %.2f%%\n", r.MaxDrawdownPerc)
+	if noData > 0 {
+		result += p.Sprintf("\nДля %d позиций нет истории за период; загрузите исторические котировки, чтобы учесть их.\n", noData)
+	}
+
+	return result
+}
+
+// handlePortfolioRiskReviewPrompt обрабатывает запрос на шаблон обзора рисков портфеля
+func (s *Server) handlePortfolioRiskReviewPrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	p := i18n.PrinterFrom(ctx)
+	windowDays := 0
+	if windowArg := request.Params.Arguments["window_days"]; windowArg != "" {
+		parsed, err := strconv.Atoi(strings.TrimSpace(windowArg))
+		if err != nil {
+			return nil, p.Errorf("параметр window_days должен быть числом")
+		}
+		windowDays = parsed
+	}
+
+	review, err := s.portfolioService.GetRiskReview(ctx, request.Params.Arguments["portfolio"], windowDays)
+	if err != nil {
+		return nil, p.Errorf("не удалось рассчитать риски портфеля: %w", err)
+	}
+
+	systemMessage := `Ты - риск-менеджер, анализирующий портфель российских акций.
+Проведи обзор рисков портфеля по предоставленным данным.
+Включи в обзор:
+1. Концентрацию: крупнейшие позиции и секторы, индекс HHI и насколько портфель диверсифицирован
+2. Рыночный риск: бету портфеля и позиций, волатильность и чувствительность к падению индекса
+3. Просадки: максимальную просадку портфеля и позиций за период и их вклад
+4. Рекомендации по ребалансировке: какие доли сократить или увеличить и до каких уровней, чтобы снизить концентрацию и бету
+
+Опирайся только на предоставленные данные; позиции без истории котировок оценивай только по доле в портфеле.`
+
+	return mcp.NewGetPromptResult(
+		"Обзор рисков портфеля",
+		[]mcp.PromptMessage{
+			mcp.NewPromptMessage(
+				mcp.RoleAssistant,
+				mcp.NewTextContent(systemMessage),
+			),
+			mcp.NewPromptMessage(
+				mcp.RoleUser,
+				mcp.NewTextContent(formatPortfolioRiskReview(p, review)),
+			),
+		},
+	), nil
+}
+
+// formatPortfolioRiskReview форматирует концентрацию, бету и просадки портфеля на языке переводчика p
+func formatPortfolioRiskReview(p i18n.Printer, r *models.PortfolioRiskReview) string {
+	result := p.Sprintf("Портфель %s: %.2f ₽, окно расчета %d дней (%s — %s)\n\n",
+		r.Portfolio, r.TotalValue, r.WindowDays, r.From.Format("02.01.2006"), r.To.Format("02.01.2006"))
+
+	result += p.T("Позиции:\n")
+	var noHistory []string
+	for i, position := range r.Positions {
+		result += fmt.Sprintf("%d. %s", i+1, position.Ticker)
+		if position.Sector != "" {
+			result += fmt.Sprintf(" (%s)", position.Sector)
+		}
+		result += p.Sprintf(": %.2f ₽, доля %.2f%%", position.Value, position.WeightPerc)
+		if position.NoHistory {
+			noHistory = append(noHistory, position.Ticker)
+			result += p.T(", нет истории котировок за период\n")
+			continue
+		}
+		result += p.Sprintf(", доходность %+.2f%%, макс. просадка %.2f%%, волатильность %.2f%% в день",
+			position.ReturnPerc, position.MaxDrawdownPerc, position.VolatilityPerc)
+		switch {
+		case position.HasBeta:
+			result += p.Sprintf(", бета %.2f", position.Beta)
+		case r.HasIndex:
+			result += p.T(", бета нет данных")
+		}
+		result += "\n"
+	}
+
+	if len(r.Sectors) > 0 {
+		result += p.T("\nКонцентрация по секторам:\n")
+		for _, sector := range r.Sectors {
+			result += fmt.Sprintf("- %s: %.2f%% (%s)\n", sector.Sector, sector.WeightPerc, strings.Join(sector.Tickers, ", "))
+		}
+	}
+
+	result += p.Sprintf("\nИндекс концентрации HHI: %.0f\n", r.HHI)
+	switch {
+	case r.HasBeta:
+		result += p.Sprintf("Бета портфеля к IMOEX: %.2f\n", r.Beta)
+	case r.HasIndex:
+		result += p.T("Бета портфеля к IMOEX: нет данных\n")
+	default:
+		result += p.T("Бета не рассчитана: нет истории индекса IMOEX за период\n")
+	}
+	if len(noHistory) < len(r.Positions) {
+		result += p.Sprintf("Доходность текущего состава за период: %+.2f%%, максимальная просадка: %.2f%%\n", r.ReturnPerc, r.MaxDrawdownPerc)
+	} else {
+		result += p.T("Доходность и просадка текущего состава за период: нет данных\n")
+	}
+	if len(noHistory) > 0 {
+		result += p.Sprintf("\nНет истории за период у позиций %s; загрузите ее инструментом backfill_history, чтобы учесть их.\n", strings.Join(noHistory, ", "))
+	}
+
+	return result
+}
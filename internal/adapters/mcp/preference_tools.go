@@ -0,0 +1,236 @@
+package mcp
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/i18n"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Настройки сессии. Имя настройки совпадает с аргументом инструментов, который она подставляет
+const (
+	preferenceLang      = langArgName
+	preferenceBoard     = "board"
+	preferenceLimit     = "limit"
+	preferencePortfolio = "portfolio"
+	preferenceWatchlist = "watchlist"
+)
+
+// preferenceNames настройки сессии в порядке вывода
+var preferenceNames = []string{preferenceLang, preferenceBoard, preferenceLimit, preferencePortfolio, preferenceWatchlist}
+
+// Ограничения значений настроек
+const (
+	maxPreferenceLimit = 100 // Наименьший из верхних пределов аргументов limit инструментов
+	maxPreferenceName  = 64  // Длина имени портфеля или списка наблюдения
+)
+
+// sessionPreferences настройки сессий клиентов. Хранятся в памяти и удаляются вместе с сессией
+type sessionPreferences struct {
+	mu       sync.Mutex
+	sessions map[string]map[string]interface{}
+}
+
+func newSessionPreferences() *sessionPreferences {
+	return &sessionPreferences{sessions: make(map[string]map[string]interface{})}
+}
+
+// get возвращает копию настроек сессии
+func (sp *sessionPreferences) get(sessionID string) map[string]interface{} {
+	sp.mu.Lock()
+	defer sp.mu.Unlock()
+
+	preferences := make(map[string]interface{}, len(sp.sessions[sessionID]))
+	for name, value := range sp.sessions[sessionID] {
+		preferences[name] = value
+	}
+	return preferences
+}
+
+// set задает настройку сессии; nil сбрасывает ее
+func (sp *sessionPreferences) set(sessionID, name string, value interface{}) {
+	sp.mu.Lock()
+	defer sp.mu.Unlock()
+
+	preferences, ok := sp.sessions[sessionID]
+	if !ok {
+		preferences = make(map[string]interface{})
+		sp.sessions[sessionID] = preferences
+	}
+	if value == nil {
+		delete(preferences, name)
+	} else {
+		preferences[name] = value
+	}
+	if len(preferences) == 0 {
+		delete(sp.sessions, sessionID)
+	}
+}
+
+// dropSession удаляет настройки закрытой сессии
+func (sp *sessionPreferences) dropSession(_ context.Context, session server.ClientSession) {
+	sp.mu.Lock()
+	defer sp.mu.Unlock()
+	delete(sp.sessions, session.SessionID())
+}
+
+// preferencesMiddleware подставляет настройки сессии в аргументы, которые инструмент принимает,
+// а клиент в вызове не указал. Выполняется до определения языка ответа, чтобы язык сессии тоже учитывался
+func (s *Server) preferencesMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		session := server.ClientSessionFromContext(ctx)
+		if session == nil {
+			return next(ctx, request)
+		}
+		preferences := s.preferences.get(session.SessionID())
+		if len(preferences) == 0 {
+			return next(ctx, request)
+		}
+
+		var properties map[string]interface{}
+		for _, tool := range s.tools {
+			if tool.Name == request.Params.Name {
+				properties = tool.InputSchema.Properties
+				break
+			}
+		}
+
+		arguments := make(map[string]interface{}, len(request.Params.Arguments)+len(preferences))
+		for name, value := range request.Params.Arguments {
+			arguments[name] = value
+		}
+		for name, value := range preferences {
+			if _, accepted := properties[name]; !accepted {
+				continue
+			}
+			if _, given := arguments[name]; !given {
+				arguments[name] = value
+			}
+		}
+		request.Params.Arguments = arguments
+
+		return next(ctx, request)
+	}
+}
+
+// registerPreferenceTools регистрирует инструменты настроек сессии
+func (s *Server) registerPreferenceTools() {
+	setPreferenceTool := mcp.NewTool("set_preference",
+		mcp.WithDescription(s.printer.T("Задать настройку сессии, которая подставляется в аргументы инструментов, если они не указаны в вызове: язык ответа (lang), режим торгов (board), размер списков (limit), портфель (portfolio) или список наблюдения (watchlist). Настройки действуют до закрытия сессии")),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description(s.printer.T("Название настройки")),
+			mcp.Enum(preferenceNames...),
+		),
+		mcp.WithString("value",
+			mcp.Description(s.printer.Sprintf("Значение: код языка (%s), код режима торгов MOEX (например, TQBR), число от 1 до %d или имя портфеля либо списка наблюдения. Пустое значение сбрасывает настройку",
+				strings.Join(i18n.Codes(), ", "), maxPreferenceLimit)),
+		),
+	)
+
+	s.addTool(setPreferenceTool, s.handleSetPreference)
+
+	getPreferencesTool := mcp.NewTool("get_preferences",
+		mcp.WithDescription(s.printer.T("Получить настройки текущей сессии")),
+	)
+
+	s.addTool(getPreferencesTool, s.handleGetPreferences)
+}
+
+// handleSetPreference обрабатывает запрос на изменение настройки сессии
+func (s *Server) handleSetPreference(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	p := i18n.PrinterFrom(ctx)
+	var args struct {
+		Name  string `arg:"name,required" enum:"lang|board|limit|portfolio|watchlist"`
+		Value string `arg:"value"`
+	}
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	session := server.ClientSessionFromContext(ctx)
+	if session == nil {
+		return mcp.NewToolResultError(p.T("настройки доступны только в сессии MCP")), nil
+	}
+
+	raw := strings.TrimSpace(args.Value)
+	if raw == "" {
+		s.preferences.set(session.SessionID(), args.Name, nil)
+		return mcp.NewToolResultText(p.Sprintf("Настройка %s сброшена\n\n", args.Name) +
+			formatPreferences(p, s.preferences.get(session.SessionID()))), nil
+	}
+
+	var value interface{}
+	switch args.Name {
+	case preferenceLang:
+		lang, ok := i18n.Parse(raw)
+		if !ok {
+			return mcp.NewToolResultError(p.Sprintf("неизвестный язык %s, доступны: %s", raw, strings.Join(i18n.Codes(), ", "))), nil
+		}
+		value = string(lang)
+	case preferenceBoard:
+		board := strings.ToUpper(raw)
+		if !models.IsBoardCode(board) {
+			return mcp.NewToolResultError(p.Sprintf("некорректный код режима торгов %s: ожидаются латинские буквы и цифры, например TQBR", raw)), nil
+		}
+		value = board
+	case preferenceLimit:
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 1 || limit > maxPreferenceLimit {
+			return mcp.NewToolResultError(p.Sprintf("размер списков должен быть числом от 1 до %d", maxPreferenceLimit)), nil
+		}
+		// Числа в аргументах инструментов приходят из JSON как float64
+		value = float64(limit)
+	case preferencePortfolio, preferenceWatchlist:
+		if len([]rune(raw)) > maxPreferenceName {
+			return mcp.NewToolResultError(p.Sprintf("имя не может быть длиннее %d символов", maxPreferenceName)), nil
+		}
+		value = raw
+	}
+
+	s.preferences.set(session.SessionID(), args.Name, value)
+	return mcp.NewToolResultText(p.Sprintf("Настройка %s = %v\n\n", args.Name, value) +
+		formatPreferences(p, s.preferences.get(session.SessionID()))), nil
+}
+
+// handleGetPreferences обрабатывает запрос на просмотр настроек сессии
+func (s *Server) handleGetPreferences(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	p := i18n.PrinterFrom(ctx)
+	if err := bindArguments(ctx, request, &struct{}{}); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	session := server.ClientSessionFromContext(ctx)
+	if session == nil {
+		return mcp.NewToolResultError(p.T("настройки доступны только в сессии MCP")), nil
+	}
+
+	return mcp.NewToolResultText(formatPreferences(p, s.preferences.get(session.SessionID()))), nil
+}
+
+// formatPreferences форматирует настройки сессии; для незаданных указывается поведение по умолчанию
+func formatPreferences(p i18n.Printer, preferences map[string]interface{}) string {
+	defaults := map[string]string{
+		preferenceLang:      p.T("язык из конфигурации сервера"),
+		preferenceBoard:     p.T("основной режим торгов бумаги"),
+		preferenceLimit:     p.T("свой у каждого инструмента"),
+		preferencePortfolio: models.DefaultPortfolio,
+		preferenceWatchlist: models.DefaultWatchlist,
+	}
+
+	result := p.T("Настройки сессии:\n")
+	for _, name := range preferenceNames {
+		if value, ok := preferences[name]; ok {
+			result += p.Sprintf("- %s: %v\n", name, value)
+		} else {
+			result += p.Sprintf("- %s: не задана (%s)\n", name, defaults[name])
+		}
+	}
+	return result
+}
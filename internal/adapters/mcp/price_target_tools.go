@@ -0,0 +1,272 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/i18n"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// registerPriceTargetTools регистрирует инструменты целевых цен аналитиков
+func (s *Server) registerPriceTargetTools() {
+	if s.targetService == nil {
+		return
+	}
+
+	// Инструмент для установки целевой цены
+	setPriceTargetTool := mcp.NewTool("set_price_target",
+		mcp.WithDescription(s.printer.T("Задать целевую цену бумаги: собственную или из обзора брокера; нулевая цель удаляет прежнюю цель того же автора")),
+		mcp.WithString("ticker",
+			mcp.Required(),
+			mcp.Description(s.printer.T("Тикер акции (например, SBER, GAZP, LKOH)")),
+		),
+		mcp.WithNumber("target",
+			mcp.Required(),
+			mcp.Description(s.printer.T("Целевая цена, ₽; 0 — удалить цель")),
+		),
+		mcp.WithString("analyst",
+			mcp.Description(s.printer.T("Автор прогноза, например брокер; по умолчанию собственная цель пользователя")),
+		),
+		mcp.WithString("rating",
+			mcp.Description(s.printer.T("Рекомендация: buy — покупать, hold — держать, sell — продавать")),
+			mcp.Enum(models.PriceTargetRatings...),
+		),
+		mcp.WithString("note",
+			mcp.Description(s.printer.T("Комментарий к цели, например горизонт или обоснование")),
+		),
+		s.dryRunArg(),
+	)
+
+	s.addTool(setPriceTargetTool, s.handleSetPriceTarget, sourceMOEX)
+
+	// Инструмент для сравнения целевых цен с рынком
+	getPriceTargetVsMarketTool := mcp.NewTool("get_price_target_vs_market",
+		mcp.WithDescription(s.printer.Sprintf("Сравнить целевые цены и консенсус аналитиков с текущими котировками: потенциал роста или снижения по каждой цели и по консенсусу. Цели старше %d дней в консенсус не входят", models.PriceTargetMaxAgeDays)),
+		mcp.WithArray("tickers",
+			mcp.Description(s.printer.Sprintf("Тикеры акций, не более %d; по умолчанию все бумаги с заданными целями", models.MaxPriceTargetTickers)),
+			mcp.Items(map[string]interface{}{"type": "string"}),
+		),
+	)
+
+	s.addTool(getPriceTargetVsMarketTool, s.handleGetPriceTargetVsMarket, sourceMOEX)
+}
+
+// handleSetPriceTarget обрабатывает запрос на установку целевой цены
+func (s *Server) handleSetPriceTarget(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	p := i18n.PrinterFrom(ctx)
+	var args struct {
+		Ticker  string  `arg:"ticker,required"`
+		Target  float64 `arg:"target,required" min:"0"`
+		Analyst string  `arg:"analyst"`
+		Rating  string  `arg:"rating" enum:"buy|hold|sell"`
+		Note    string  `arg:"note"`
+		dryRunArgs
+	}
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	change, err := s.targetService.SetPriceTarget(ctx, models.PriceTarget{
+		Ticker:  args.Ticker,
+		Analyst: args.Analyst,
+		Target:  args.Target,
+		Rating:  args.Rating,
+		Note:    args.Note,
+	}, args.DryRun)
+	if err != nil {
+		return mcp.NewToolResultError(p.Sprintf("не удалось задать целевую цену: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(formatPriceTargetChange(p, change)), nil
+}
+
+// handleGetPriceTargetVsMarket обрабатывает запрос на сравнение целевых цен с рынком
+func (s *Server) handleGetPriceTargetVsMarket(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	p := i18n.PrinterFrom(ctx)
+	// Граница совпадает с models.MaxPriceTargetTickers
+	var args struct {
+		Tickers []string `arg:"tickers" max:"20"`
+	}
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	comparisons, err := s.targetService.GetPriceTargetVsMarket(ctx, args.Tickers)
+	if err != nil {
+		return mcp.NewToolResultError(p.Sprintf("не удалось сравнить целевые цены с рынком: %v", err)), nil
+	}
+
+	if len(comparisons) == 0 {
+		return mcp.NewToolResultText(p.T("Целевые цены не заданы. Задайте их инструментом set_price_target")), nil
+	}
+
+	return mcp.NewToolResultText(formatPriceTargetComparisons(p, comparisons, time.Now())), nil
+}
+
+// priceTargetsContext возвращает целевые цены бумаг для шаблонов анализа или пустую строку,
+// если модуль целевых цен не подключен или целей нет
+func (s *Server) priceTargetsContext(ctx context.Context, tickers []string) string {
+	if s.targetService == nil {
+		return ""
+	}
+
+	comparisons, err := s.targetService.GetPriceTargetVsMarket(ctx, tickers)
+	if err != nil {
+		log.Printf("ПРЕДУПРЕЖДЕНИЕ: не удалось получить целевые цены для %s: %v", strings.Join(tickers, ", "), err)
+		return ""
+	}
+
+	var withTargets []models.PriceTargetComparison
+	for _, comparison := range comparisons {
+		if len(comparison.Targets) > 0 {
+			withTargets = append(withTargets, comparison)
+		}
+	}
+	if len(withTargets) == 0 {
+		return ""
+	}
+
+	return formatPriceTargetComparisons(i18n.PrinterFrom(ctx), withTargets, time.Now())
+}
+
+// formatPriceTargetChange форматирует изменение целевой цены на языке переводчика p
+func formatPriceTargetChange(p i18n.Printer, change *models.PriceTargetChange) string {
+	result := ""
+	if change.DryRun {
+		result += p.T(dryRunNotice)
+	}
+
+	target := change.After
+	if target == nil {
+		target = change.Before
+	}
+	result += p.Sprintf("Целевая цена %s %s", target.Ticker, priceTargetAuthor(p, *target))
+
+	switch {
+	case change.After == nil:
+		if change.DryRun {
+			result += p.T(" будет удалена")
+		} else {
+			result += p.T(" удалена")
+		}
+		result += p.Sprintf(" (была %.2f ₽)\n", change.Before.Target)
+		return result
+	case change.Before == nil:
+		if change.DryRun {
+			result += p.T(" будет задана")
+		} else {
+			result += p.T(" задана")
+		}
+		result += fmt.Sprintf(": %.2f ₽\n", change.After.Target)
+	default:
+		if change.DryRun {
+			result += p.T(" будет изменена")
+		} else {
+			result += p.T(" изменена")
+		}
+		result += fmt.Sprintf(": %.2f → %.2f ₽\n", change.Before.Target, change.After.Target)
+	}
+
+	if price := change.After.PriceAtSet; price > 0 {
+		result += p.Sprintf("   Текущая цена: %.2f ₽, потенциал %+.2f%%\n", price, (change.After.Target-price)/price*100)
+	}
+	if change.After.Rating != "" {
+		result += p.Sprintf("   Рекомендация: %s\n", priceTargetRatingName(p, change.After.Rating))
+	}
+	if change.After.Note != "" {
+		result += p.Sprintf("   Комментарий: %s\n", change.After.Note)
+	}
+
+	return result
+}
+
+// formatPriceTargetComparisons форматирует сравнение целевых цен с котировками на момент now на языке переводчика p
+func formatPriceTargetComparisons(p i18n.Printer, comparisons []models.PriceTargetComparison, now time.Time) string {
+	result := p.T("Целевые цены и текущие котировки:\n")
+
+	for i, c := range comparisons {
+		result += fmt.Sprintf("\n%d. %s", i+1, c.Ticker)
+		if c.Name != "" {
+			result += fmt.Sprintf(" (%s)", c.Name)
+		}
+		if c.NoQuote {
+			result += p.T(": котировка недоступна\n")
+		} else {
+			result += fmt.Sprintf(": %.2f ₽\n", c.Price)
+		}
+
+		if len(c.Targets) == 0 {
+			result += p.T("   Целевые цены не заданы\n")
+			continue
+		}
+
+		if c.Fresh > 0 {
+			result += p.Sprintf("   Консенсус: %.2f ₽", c.Consensus)
+			if !c.NoQuote {
+				result += fmt.Sprintf(" (%+.2f%%)", c.UpsidePerc)
+			}
+			result += p.Sprintf(", медиана %.2f ₽, диапазон %.2f–%.2f ₽, целей: %d\n", c.Median, c.Low, c.High, c.Fresh)
+			if c.Buy+c.Hold+c.Sell > 0 {
+				result += p.Sprintf("   Рекомендации: покупать %d, держать %d, продавать %d\n", c.Buy, c.Hold, c.Sell)
+			}
+		} else {
+			result += p.Sprintf("   Актуальных целей нет: все старше %d дней\n", models.PriceTargetMaxAgeDays)
+		}
+
+		for _, target := range c.Targets {
+			result += fmt.Sprintf("   - %s: %.2f ₽", priceTargetAuthorName(p, target), target.Target)
+			if !c.NoQuote {
+				result += fmt.Sprintf(" (%+.2f%%)", c.UpsideTo(target.Target))
+			}
+			if target.Rating != "" {
+				result += ", " + priceTargetRatingName(p, target.Rating)
+			}
+			result += p.Sprintf(", от %s", target.SetAt.In(models.MoscowLocation).Format("02.01.2006"))
+			if target.Stale(now) {
+				result += p.T(", устарела")
+			}
+			if target.Note != "" {
+				result += fmt.Sprintf(" — %s", target.Note)
+			}
+			result += "\n"
+		}
+	}
+
+	return result
+}
+
+// priceTargetAuthor описывает автора цели в родительном падеже
+func priceTargetAuthor(p i18n.Printer, target models.PriceTarget) string {
+	if target.Analyst == "" {
+		return p.T("пользователя")
+	}
+	return p.T("от ") + target.Analyst
+}
+
+// priceTargetAuthorName возвращает имя автора цели
+func priceTargetAuthorName(p i18n.Printer, target models.PriceTarget) string {
+	if target.Analyst == "" {
+		return p.T("Собственная цель")
+	}
+	return target.Analyst
+}
+
+// priceTargetRatingName возвращает название рекомендации
+func priceTargetRatingName(p i18n.Printer, rating string) string {
+	switch rating {
+	case models.PriceTargetRatingBuy:
+		return p.T("покупать")
+	case models.PriceTargetRatingHold:
+		return p.T("держать")
+	case models.PriceTargetRatingSell:
+		return p.T("продавать")
+	default:
+		return rating
+	}
+}
@@ -0,0 +1,203 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/i18n"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// registerProfileTools регистрирует инструменты профилей компаний и секторной аналитики
+func (s *Server) registerProfileTools() {
+	if s.profileService == nil {
+		return
+	}
+
+	getCompanyProfileTool := mcp.NewTool("get_company_profile",
+		mcp.WithDescription(s.printer.T("Получить профиль эмитента: сектор, отрасль, капитализацию, число акций в обращении, free float и уровень листинга на Московской Бирже")),
+		mcp.WithString("ticker",
+			mcp.Required(),
+			mcp.Description(s.printer.T("Тикер акции (например, SBER, GAZP, LKOH)")),
+		),
+	)
+
+	s.addTool(getCompanyProfileTool, s.handleGetCompanyProfile, sourceMOEX)
+
+	// Секторы берутся из профилей компаний, поэтому инструменты доступны только вместе с ними
+	getSectorPerformanceTool := mcp.NewTool("get_sector_performance",
+		mcp.WithDescription(s.printer.T("Получить динамику секторов за день: среднее и взвешенное по капитализации изменение цены, объем и оборот, лидеров и аутсайдеров каждого сектора")),
+		s.universeArg(),
+	)
+
+	s.addTool(getSectorPerformanceTool, s.handleGetSectorPerformance, sourceMOEX)
+
+	getStocksBySectorTool := mcp.NewTool("get_stocks_by_sector",
+		mcp.WithDescription(s.printer.T("Получить акции сектора (например, «Нефть и газ», «Финансы», «Металлы и добыча») с изменением цены, объемом и капитализацией")),
+		mcp.WithString("sector",
+			mcp.Required(),
+			mcp.Description(s.printer.T("Название сектора или его часть, без учета регистра")),
+		),
+		s.universeArg(),
+	)
+
+	s.addTool(getStocksBySectorTool, s.handleGetStocksBySector, sourceMOEX)
+}
+
+// handleGetCompanyProfile обрабатывает запрос на получение профиля компании
+func (s *Server) handleGetCompanyProfile(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		Ticker string `arg:"ticker,required"`
+	}
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	p := i18n.PrinterFrom(ctx)
+	profile, err := s.profileService.GetCompanyProfile(ctx, args.Ticker)
+	if err != nil {
+		return mcp.NewToolResultError(p.Sprintf("не удалось получить профиль компании: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(formatCompanyProfile(p, profile)), nil
+}
+
+// handleGetSectorPerformance обрабатывает запрос на получение динамики секторов
+func (s *Server) handleGetSectorPerformance(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		Universe string `arg:"universe"`
+	}
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	p := i18n.PrinterFrom(ctx)
+	report, err := s.stockService.GetSectorPerformance(ctx, args.Universe)
+	if err != nil {
+		return mcp.NewToolResultError(p.Sprintf("не удалось получить динамику секторов: %v", err)), nil
+	}
+
+	if len(report.Sectors) == 0 {
+		return mcp.NewToolResultText(p.Sprintf("Нет данных по акциям универсума %s", report.Universe)), nil
+	}
+
+	return mcp.NewToolResultText(formatSectorReport(p, report)), nil
+}
+
+// handleGetStocksBySector обрабатывает запрос на получение акций сектора
+func (s *Server) handleGetStocksBySector(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		Sector   string `arg:"sector,required"`
+		Universe string `arg:"universe"`
+	}
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	p := i18n.PrinterFrom(ctx)
+	sectorStocks, err := s.stockService.GetStocksBySector(ctx, args.Sector, args.Universe)
+	if err != nil {
+		return mcp.NewToolResultError(p.Sprintf("не удалось получить акции сектора: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(formatSectorStocks(p, sectorStocks)), nil
+}
+
+// formatSectorReport форматирует динамику секторов на языке переводчика p
+func formatSectorReport(p i18n.Printer, report *models.SectorReport) string {
+	result := p.Sprintf("Динамика секторов (универсум %s):\n", report.Universe)
+	if report.Index != "" {
+		result += p.Sprintf("Доли секторов и взвешенное изменение рассчитаны по весам бумаг в индексе %s\n", report.Index)
+	}
+	result += "\n"
+	for i, sector := range report.Sectors {
+		result += fmt.Sprintf("%d. %s\n%s\n", i+1, sector.Sector, formatSectorPerformance(p, sector))
+	}
+	result += p.Sprintf("Дата обновления: %s", report.UpdatedAt.Format("2006-01-02 15:04:05"))
+
+	return result
+}
+
+// formatSectorStocks форматирует акции сектора вместе с его сводной динамикой на языке переводчика p
+func formatSectorStocks(p i18n.Printer, sectorStocks *models.SectorStocks) string {
+	result := p.Sprintf("Сектор %s (универсум %s):\n%s\n", sectorStocks.Performance.Sector, sectorStocks.Universe, formatSectorPerformance(p, sectorStocks.Performance))
+
+	for i, stock := range sectorStocks.Stocks {
+		result += p.Sprintf("%d. %s (%s): %.2f ₽ (%.2f%%), объем: %d", i+1, stock.Ticker, stock.Name, stock.Price, stock.ChangePerc, stock.Volume)
+		if stock.MarketCapRub > 0 {
+			result += p.Sprintf(", капитализация: %.2f млрд ₽", stock.MarketCapRub/1e9)
+		}
+		if stock.IndexWeight > 0 {
+			result += p.Sprintf(", вес в индексе %s: %.2f%%", sectorStocks.Index, stock.IndexWeight)
+		}
+		if stock.Industry != "" {
+			result += p.Sprintf(", отрасль: %s", stock.Industry)
+		}
+		result += "\n"
+	}
+
+	return result
+}
+
+// formatSectorPerformance форматирует сводную динамику сектора на языке переводчика p
+func formatSectorPerformance(p i18n.Printer, sector models.SectorPerformance) string {
+	result := p.Sprintf("   Акций: %d (растут: %d, падают: %d)\n", sector.Stocks, sector.Advancers, sector.Decliners)
+	result += p.Sprintf("   Изменение: %.2f%% взвешенное по капитализации, %.2f%% среднее\n", sector.CapWeightedChangePerc, sector.AvgChangePerc)
+	result += p.Sprintf("   Объем: %d, оборот: %.2f млн ₽\n", sector.TotalVolume, sector.TurnoverRub/1e6)
+	if sector.MarketCapRub > 0 {
+		result += p.Sprintf("   Капитализация: %.2f млрд ₽\n", sector.MarketCapRub/1e9)
+	}
+	if sector.IndexWeightPerc > 0 {
+		result += p.Sprintf("   Доля в индексе: %.2f%%, изменение с учетом весов индекса: %.2f%%\n", sector.IndexWeightPerc, sector.IndexWeightedChangePerc)
+	}
+	result += p.Sprintf("   Лидер: %s (%.2f%%), аутсайдер: %s (%.2f%%)\n", sector.Leader, sector.LeaderChangePerc, sector.Laggard, sector.LaggardChangePerc)
+
+	return result
+}
+
+// formatCompanyProfile форматирует профиль компании; неизвестные поля выводятся как «нет данных»; текст на языке переводчика p
+func formatCompanyProfile(p i18n.Printer, profile *models.CompanyProfile) string {
+	result := p.Sprintf("Профиль %s (%s):\n", profile.Ticker, profile.Name)
+	if profile.FullName != "" {
+		result += p.Sprintf("Эмитент: %s\n", profile.FullName)
+	}
+	if profile.ISIN != "" {
+		result += fmt.Sprintf("ISIN: %s\n", profile.ISIN)
+	}
+	result += p.Sprintf("Сектор: %s\n", valueOrUnknown(p, profile.Sector))
+	result += p.Sprintf("Отрасль: %s\n", valueOrUnknown(p, profile.Industry))
+
+	if profile.MarketCap > 0 {
+		result += p.Sprintf("Капитализация: %.2f млрд ₽\n", profile.MarketCap/1e9)
+	} else {
+		result += p.T("Капитализация: нет данных\n")
+	}
+	if profile.SharesOutstanding > 0 {
+		result += p.Sprintf("Акций в обращении: %d\n", profile.SharesOutstanding)
+	} else {
+		result += p.T("Акций в обращении: нет данных\n")
+	}
+	if profile.FreeFloatPerc > 0 {
+		result += fmt.Sprintf("Free float: %.1f%%\n", profile.FreeFloatPerc)
+	} else {
+		result += p.T("Free float: нет данных\n")
+	}
+	if profile.ListingLevel > 0 {
+		result += p.Sprintf("Уровень листинга: %d\n", profile.ListingLevel)
+	} else {
+		result += p.T("Уровень листинга: нет данных\n")
+	}
+	result += p.Sprintf("Данные обновлены: %s\n", profile.UpdatedAt.Format("02.01.2006"))
+
+	return result
+}
+
+// valueOrUnknown возвращает значение или «нет данных» для пустой строки
+func valueOrUnknown(p i18n.Printer, value string) string {
+	if value == "" {
+		return p.T("нет данных")
+	}
+	return value
+}
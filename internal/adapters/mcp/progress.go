@@ -0,0 +1,57 @@
+package mcp
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/i18n"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/progress"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// progressInterval наименьший промежуток между уведомлениями о ходе одного вызова; о завершении сообщается всегда
+const progressInterval = 250 * time.Millisecond
+
+// progressMiddleware передает клиенту ход долгих инструментов (загрузка истории, обход универсума, выгрузка)
+// уведомлениями notifications/progress, если клиент указал progressToken в _meta вызова
+func (s *Server) progressMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if request.Params.Meta == nil || request.Params.Meta.ProgressToken == nil || server.ClientSessionFromContext(ctx) == nil {
+			return next(ctx, request)
+		}
+
+		token := request.Params.Meta.ProgressToken
+		p := i18n.PrinterFrom(ctx)
+		var (
+			mu       sync.Mutex
+			lastDone = -1
+			sentAt   time.Time
+		)
+		notifyCtx := ctx
+		ctx = progress.WithReporter(ctx, func(done, total int, format string, args ...any) {
+			mu.Lock()
+			defer mu.Unlock()
+			// Ход в уведомлениях должен расти: запоздавшие сообщения параллельных шагов пропускаются
+			if done <= lastDone || (done < total && time.Since(sentAt) < progressInterval) {
+				return
+			}
+			lastDone, sentAt = done, time.Now()
+
+			params := map[string]any{
+				"progressToken": token,
+				"progress":      done,
+				"message":       p.Sprintf(format, args...),
+			}
+			if total > 0 {
+				params["total"] = total
+			}
+			// Уведомление не доставляется, только если канал сессии переполнен; следующее сообщит актуальный ход
+			_ = s.server.SendNotificationToClient(notifyCtx, "notifications/progress", params)
+		})
+
+		return next(ctx, request)
+	}
+}
@@ -0,0 +1,165 @@
+package mcp
+
+import (
+	"context"
+	"log"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/config"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/i18n"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/ratelimit"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// usageToolName инструмент просмотра расхода квоты; он не ограничивается, чтобы клиент всегда мог узнать остаток
+const usageToolName = "get_usage"
+
+// clientLimits ограничения вызовов одного клиента
+type clientLimits struct {
+	limit      ratelimit.Limit
+	dailyQuota int
+}
+
+// clientUsage счетчики вызовов инструментов клиентом с момента запуска сервера
+type clientUsage struct {
+	Client   string
+	Calls    int64
+	Rejected int64
+	Day      string // Дата по Москве, к которой относится Today
+	Today    int    // Вызовы за сутки, входящие в квоту
+	LastCall time.Time
+	Tools    map[string]int64
+	Limits   clientLimits
+}
+
+// usageTracker ограничивает частоту и суточное число вызовов инструментов каждым клиентом и ведет их учет
+type usageTracker struct {
+	defaults clientLimits
+	limiter  *ratelimit.Limiter
+
+	mu      sync.Mutex
+	clients map[string]*clientUsage
+}
+
+// newUsageTracker создает учет вызовов с общими ограничениями из конфигурации
+func newUsageTracker(cfg config.RateLimitConfig) *usageTracker {
+	return &usageTracker{
+		defaults: clientLimits{
+			limit:      ratelimit.Limit{PerMinute: cfg.CallsPerMinute, Burst: cfg.Burst},
+			dailyQuota: cfg.DailyQuota,
+		},
+		limiter: ratelimit.New(),
+		clients: make(map[string]*clientUsage),
+	}
+}
+
+// callerID возвращает имя клиента SSE-транспорта или идентификатор сессии stdio
+func callerID(ctx context.Context) string {
+	if client := clientFromContext(ctx); client != nil {
+		return client.name
+	}
+	if session := server.ClientSessionFromContext(ctx); session != nil {
+		return session.SessionID()
+	}
+	return "local"
+}
+
+// limitsFor возвращает ограничения клиента: собственные из auth.keys или общие
+func (u *usageTracker) limitsFor(ctx context.Context) clientLimits {
+	limits := u.defaults
+	if client := clientFromContext(ctx); client != nil {
+		if client.callsPerMinute > 0 {
+			limits.limit = ratelimit.Limit{PerMinute: client.callsPerMinute}
+		}
+		if client.dailyQuota > 0 {
+			limits.dailyQuota = client.dailyQuota
+		}
+	}
+	return limits
+}
+
+// usageLocked возвращает счетчики клиента, обнуляя суточный счетчик с началом новых суток
+func (u *usageTracker) usageLocked(id string, limits clientLimits, now time.Time) *clientUsage {
+	usage, ok := u.clients[id]
+	if !ok {
+		usage = &clientUsage{Client: id, Tools: make(map[string]int64)}
+		u.clients[id] = usage
+	}
+	usage.Limits = limits
+	if day := now.In(models.MoscowLocation).Format("2006-01-02"); usage.Day != day {
+		usage.Day = day
+		usage.Today = 0
+	}
+	return usage
+}
+
+// acquire учитывает вызов инструмента клиентом. Если ограничение превышено, вызов не учитывается,
+// а возвращается сообщение для клиента
+func (u *usageTracker) acquire(ctx context.Context, tool string, now time.Time) (bool, string) {
+	id := callerID(ctx)
+	limits := u.limitsFor(ctx)
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	usage := u.usageLocked(id, limits, now)
+	p := i18n.PrinterFrom(ctx)
+	if tool != usageToolName {
+		if limits.dailyQuota > 0 && usage.Today >= limits.dailyQuota {
+			usage.Rejected++
+			return false, p.Sprintf("quota_exceeded: исчерпана суточная квота клиента %s (%d вызовов), она обновится в 00:00 МСК",
+				id, limits.dailyQuota)
+		}
+		if ok, wait := u.limiter.Allow(id, limits.limit, now); !ok {
+			usage.Rejected++
+			return false, p.Sprintf("quota_exceeded: превышена частота вызовов клиента %s (%g в минуту), повторите через %d с",
+				id, limits.limit.PerMinute, int(math.Ceil(wait.Seconds())))
+		}
+		usage.Today++
+	}
+
+	usage.Calls++
+	usage.LastCall = now
+	usage.Tools[tool]++
+	return true, ""
+}
+
+// snapshot возвращает копию счетчиков клиента id или всех клиентов, если id пуст
+func (u *usageTracker) snapshot(id string, now time.Time) []clientUsage {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	var result []clientUsage
+	for clientID, usage := range u.clients {
+		if id != "" && clientID != id {
+			continue
+		}
+		copied := *u.usageLocked(clientID, usage.Limits, now)
+		copied.Tools = make(map[string]int64, len(usage.Tools))
+		for tool, calls := range usage.Tools {
+			copied.Tools[tool] = calls
+		}
+		result = append(result, copied)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Client < result[j].Client
+	})
+	return result
+}
+
+// rateLimitMiddleware отклоняет вызовы сверх ограничений клиента ошибкой quota_exceeded
+func (s *Server) rateLimitMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if ok, message := s.usage.acquire(ctx, request.Params.Name, time.Now()); !ok {
+			log.Printf("Вызов %s отклонен: %s", request.Params.Name, message)
+			return mcp.NewToolResultError(message), nil
+		}
+		return next(ctx, request)
+	}
+}
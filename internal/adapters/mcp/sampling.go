@@ -0,0 +1,215 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/services"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// samplingIDPrefix префикс идентификаторов запросов sampling, отправляемых сервером
+const samplingIDPrefix = "sampling-"
+
+// samplingResponse ответ клиента на запрос sampling
+type samplingResponse struct {
+	result *mcp.CreateMessageResult
+	err    error
+}
+
+// StdioSampler реализует MCP sampling поверх stdio-транспорта.
+// Stdio-сервер mcp-go обрабатывает входящие сообщения последовательно и не умеет
+// отправлять запросы клиенту, поэтому StdioSampler встает между stdin/stdout и сервером:
+// ответы на собственные запросы он забирает из входного потока, остальное передает серверу.
+type StdioSampler struct {
+	in        io.Reader
+	out       *lockedWriter
+	pending   sync.Map // id запроса -> chan samplingResponse
+	nextID    atomic.Int64
+	supported atomic.Bool
+}
+
+var _ services.Sampler = (*StdioSampler)(nil)
+
+// NewStdioSampler создает sampler для stdio-транспорта
+func NewStdioSampler(in io.Reader, out io.Writer) *StdioSampler {
+	return &StdioSampler{
+		in:  in,
+		out: &lockedWriter{w: out},
+	}
+}
+
+// Available сообщает, заявил ли клиент поддержку sampling при инициализации
+func (s *StdioSampler) Available(ctx context.Context) bool {
+	return s.supported.Load()
+}
+
+// CreateMessage отправляет клиенту запрос sampling/createMessage и ждет ответа
+func (s *StdioSampler) CreateMessage(ctx context.Context, systemPrompt, userText string, maxTokens int) (string, error) {
+	if !s.Available(ctx) {
+		return "", services.ErrSamplingUnavailable
+	}
+
+	id := fmt.Sprintf("%s%d", samplingIDPrefix, s.nextID.Add(1))
+	responseChan := make(chan samplingResponse, 1)
+	s.pending.Store(id, responseChan)
+	defer s.pending.Delete(id)
+
+	request := mcp.CreateMessageRequest{}
+	request.Method = "sampling/createMessage"
+	request.Params.SystemPrompt = systemPrompt
+	request.Params.MaxTokens = maxTokens
+	request.Params.Messages = []mcp.SamplingMessage{
+		{Role: mcp.RoleUser, Content: mcp.NewTextContent(userText)},
+	}
+
+	message := struct {
+		JSONRPC string `json:"jsonrpc"`
+		ID      string `json:"id"`
+		mcp.CreateMessageRequest
+	}{
+		JSONRPC:              mcp.JSONRPC_VERSION,
+		ID:                   id,
+		CreateMessageRequest: request,
+	}
+
+	data, err := json.Marshal(message)
+	if err != nil {
+		return "", err
+	}
+	if _, err := s.out.Write(append(data, '\n')); err != nil {
+		return "", fmt.Errorf("ошибка отправки запроса sampling: %w", err)
+	}
+
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case response := <-responseChan:
+		if response.err != nil {
+			return "", response.err
+		}
+		if text, ok := response.result.Content.(map[string]interface{}); ok {
+			if value, ok := text["text"].(string); ok {
+				return value, nil
+			}
+		}
+		return "", fmt.Errorf("клиент вернул ответ sampling без текста")
+	}
+}
+
+// onInitialize запоминает, поддерживает ли клиент sampling
+func (s *StdioSampler) onInitialize(ctx context.Context, id any, message *mcp.InitializeRequest, result *mcp.InitializeResult) {
+	s.supported.Store(message.Params.Capabilities.Sampling != nil)
+}
+
+// Serve запускает stdio-сервер, перехватывая ответы клиента на запросы sampling
+func (s *StdioSampler) Serve(mcpServer *server.MCPServer) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGTERM, syscall.SIGINT)
+	go func() {
+		<-sigChan
+		cancel()
+	}()
+
+	serverIn, clientOut := io.Pipe()
+	go s.route(clientOut)
+
+	stdioServer := server.NewStdioServer(mcpServer)
+	stdioServer.SetErrorLogger(log.New(os.Stderr, "", log.LstdFlags))
+	return stdioServer.Listen(ctx, serverIn, s.out)
+}
+
+// route читает входной поток клиента построчно и разделяет ответы на запросы sampling
+// и остальные сообщения, которые передаются MCP серверу. Пока обработчик инструмента
+// ждет ответа sampling, сервер не читает вход, поэтому остальные сообщения буферизуются.
+func (s *StdioSampler) route(toServer *io.PipeWriter) {
+	forward := make(chan []byte, 256)
+	go func() {
+		for line := range forward {
+			if _, err := toServer.Write(line); err != nil {
+				return
+			}
+		}
+		toServer.Close()
+	}()
+	defer close(forward)
+
+	reader := bufio.NewReader(s.in)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 && !s.deliver(line) {
+			forward <- line
+		}
+		if err != nil {
+			if err != io.EOF {
+				log.Printf("Ошибка чтения входного потока MCP: %v", err)
+			}
+			return
+		}
+	}
+}
+
+// deliver передает ответ ожидающему запросу sampling; возвращает false, если сообщение не является таким ответом
+func (s *StdioSampler) deliver(line []byte) bool {
+	var message struct {
+		ID     json.RawMessage          `json:"id"`
+		Method string                   `json:"method"`
+		Result *mcp.CreateMessageResult `json:"result"`
+		Error  *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(line, &message); err != nil || message.Method != "" {
+		return false
+	}
+
+	var id string
+	if err := json.Unmarshal(message.ID, &id); err != nil || !strings.HasPrefix(id, samplingIDPrefix) {
+		return false
+	}
+
+	value, ok := s.pending.Load(id)
+	if !ok {
+		// Ответ на запрос, который уже отменен по таймауту
+		return true
+	}
+
+	response := samplingResponse{result: message.Result}
+	if message.Error != nil {
+		response.err = fmt.Errorf("клиент отклонил запрос sampling: %s (код %d)", message.Error.Message, message.Error.Code)
+	} else if message.Result == nil {
+		response.err = fmt.Errorf("клиент вернул пустой ответ sampling")
+	}
+
+	value.(chan samplingResponse) <- response
+	return true
+}
+
+// lockedWriter сериализует запись ответов сервера и запросов sampling в общий поток
+type lockedWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// Write записывает данные под блокировкой
+func (w *lockedWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.w.Write(p)
+}
@@ -0,0 +1,93 @@
+package mcp
+
+import (
+	"context"
+	"strings"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/i18n"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// registerSecurityTools регистрирует инструмент справочника бумаг
+func (s *Server) registerSecurityTools() {
+	if s.securityService == nil {
+		return
+	}
+
+	lookupSecurityTool := mcp.NewTool("lookup_security",
+		mcp.WithDescription(s.printer.T("Получить справочные данные бумаги MOEX по тикеру, ISIN или FIGI: название, ISIN, основной и остальные режимы торгов, размер лота и уровень листинга")),
+		mcp.WithString("id",
+			mcp.Required(),
+			mcp.Description(s.printer.T("Тикер (например, SBER), ISIN (например, RU0009029540) или FIGI (например, BBG004730N88)")),
+		),
+	)
+
+	s.addTool(lookupSecurityTool, s.handleLookupSecurity, sourceMOEX)
+}
+
+// handleLookupSecurity обрабатывает запрос справочных данных бумаги
+func (s *Server) handleLookupSecurity(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	p := i18n.PrinterFrom(ctx)
+	var args struct {
+		ID string `arg:"id,required"`
+	}
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// FIGI справочник MOEX не содержит: он сопоставляется тикеру через сервис распознавания тикеров
+	id := args.ID
+	if models.IsFIGI(id) && s.symbolService != nil {
+		resolution, err := s.symbolService.ResolveTicker(ctx, id)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if !resolution.Resolved() {
+			return mcp.NewToolResultError(p.Sprintf("FIGI %s не соответствует бумаге, торгующейся на рынке акций MOEX", strings.ToUpper(id))), nil
+		}
+		id = resolution.Ticker
+	}
+
+	security, err := s.securityService.LookupSecurity(ctx, id)
+	if err != nil {
+		return mcp.NewToolResultError(p.Sprintf("не удалось получить справочник бумаг: %v", err)), nil
+	}
+	if security == nil {
+		if models.IsISIN(args.ID) && !models.IsFIGI(args.ID) {
+			return mcp.NewToolResultError(p.Sprintf("бумага с ISIN %s не торгуется на рынке акций MOEX", strings.ToUpper(args.ID))), nil
+		}
+		return mcp.NewToolResultError(p.Sprintf("бумага %s не найдена в справочнике MOEX: для поиска по названию используйте search_stocks", strings.ToUpper(args.ID))), nil
+	}
+
+	return mcp.NewToolResultText(formatSecurity(p, security)), nil
+}
+
+// formatSecurity форматирует справочные данные бумаги
+func formatSecurity(p i18n.Printer, security *models.Security) string {
+	result := p.Sprintf("%s — %s\n", security.Ticker, security.ShortName)
+	if security.Name != "" {
+		result += p.Sprintf("Полное название: %s\n", security.Name)
+	}
+	if security.LatName != "" {
+		result += p.Sprintf("Название латиницей: %s\n", security.LatName)
+	}
+	if security.ISIN != "" {
+		result += p.Sprintf("ISIN: %s\n", security.ISIN)
+	}
+	result += p.Sprintf("Основной режим торгов: %s\n", security.Board)
+	if len(security.Boards) > 1 {
+		result += p.Sprintf("Режимы торгов: %s\n", strings.Join(security.Boards, ", "))
+	}
+	if security.LotSize > 0 {
+		result += p.Sprintf("Размер лота: %d шт.\n", security.LotSize)
+	}
+	if security.MinStep > 0 {
+		result += p.Sprintf("Шаг цены: %g\n", security.MinStep)
+	}
+	if security.ListLevel > 0 {
+		result += p.Sprintf("Уровень листинга: %d\n", security.ListLevel)
+	}
+	return result
+}
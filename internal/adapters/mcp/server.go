@@ -4,11 +4,17 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/JkLondon/mcp-stocks-info-server/internal/adapters/prompts"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/adapters/render"
 	"github.com/JkLondon/mcp-stocks-info-server/internal/config"
 	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/repositories"
 	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/services"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/i18n"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -20,10 +26,296 @@ type Server struct {
 	stockService services.StockService
 	newsService  services.NewsService
 	config       *config.Config
+	formatter    *formatter
+	// renderer оформляет результаты инструментов акций и новостей по шаблонам
+	renderer *render.Renderer
+	// printer переводит описания инструментов на язык по умолчанию из конфигурации
+	printer i18n.Printer
+
+	// Необязательные зависимости, задаются через Option
+	enrichmentService services.EnrichmentService
+	analysisService   services.AnalysisService
+	portfolioService  services.PortfolioService
+	taxService        services.TaxService
+	selfTestService   services.SelfTestService
+	rawArchiveService services.RawArchiveService
+	exportService     services.ExportService
+	cacheService      services.CacheService
+	statsService      services.ServerStatsService
+	healthService     services.HealthService
+	watchlistService  services.WatchlistService
+	moodService       services.MoodService
+	profileService    services.CompanyProfileService
+	marketDataService services.MarketDataService
+	listingService    services.ListingService
+	eventService      services.CorporateEventService
+	targetService     services.PriceTargetService
+	marginService     services.MarginService
+	gapService        services.GapService
+	commodityService  services.CommodityService
+	cryptoService     services.CryptoService
+	cbrService        services.CBRService
+	macroService      services.MacroService
+	indexService      services.IndexService
+	symbolService     services.SymbolService
+	securityService   services.SecurityService
+	fundService       services.FundService
+	sampler           *StdioSampler
+	// customPrompts шаблоны из каталога оператора
+	customPrompts []*prompts.Template
+	// authClients клиенты SSE-транспорта с ключами доступа
+	authClients []*authClient
+	// usage учет и ограничение вызовов инструментов по клиентам
+	usage *usageTracker
+	// quotes подписки сессий на котировки
+	quotes *quoteSubscriptions
+	// preferences настройки сессий, подставляемые в аргументы инструментов
+	preferences *sessionPreferences
+
+	// Зарегистрированные инструменты и шаблоны в порядке регистрации, для каталога
+	tools        []mcp.Tool
+	prompts      []mcp.Prompt
+	registerOnce sync.Once
+}
+
+// Option настраивает необязательные зависимости MCP сервера
+type Option func(*Server)
+
+// WithEnrichment включает обогащение новостей в результатах инструментов
+func WithEnrichment(enrichmentService services.EnrichmentService) Option {
+	return func(s *Server) {
+		s.enrichmentService = enrichmentService
+	}
+}
+
+// WithAnalysis включает аналитические инструменты
+func WithAnalysis(analysisService services.AnalysisService) Option {
+	return func(s *Server) {
+		s.analysisService = analysisService
+	}
+}
+
+// WithPortfolio включает инструменты для работы с портфелем
+func WithPortfolio(portfolioService services.PortfolioService) Option {
+	return func(s *Server) {
+		s.portfolioService = portfolioService
+	}
+}
+
+// WithTaxes включает инструмент оценки налогов по истории сделок портфеля
+func WithTaxes(taxService services.TaxService) Option {
+	return func(s *Server) {
+		s.taxService = taxService
+	}
+}
+
+// WithWatchlist включает инструменты для работы со списками наблюдения
+func WithWatchlist(watchlistService services.WatchlistService) Option {
+	return func(s *Server) {
+		s.watchlistService = watchlistService
+	}
+}
+
+// WithMood включает инструмент индекса настроения рынка
+func WithMood(moodService services.MoodService) Option {
+	return func(s *Server) {
+		s.moodService = moodService
+	}
+}
+
+// WithCompanyProfiles включает инструмент профилей компаний
+func WithCompanyProfiles(profileService services.CompanyProfileService) Option {
+	return func(s *Server) {
+		s.profileService = profileService
+	}
+}
+
+// WithMarketData включает инструменты биржевых данных реального времени (стакан заявок, лента сделок)
+func WithMarketData(marketDataService services.MarketDataService) Option {
+	return func(s *Server) {
+		s.marketDataService = marketDataService
+	}
+}
+
+// WithListings включает инструменты календаря размещений
+func WithListings(listingService services.ListingService) Option {
+	return func(s *Server) {
+		s.listingService = listingService
+	}
+}
+
+// WithCustomPrompts добавляет шаблоны из каталога оператора; шаблон с именем встроенного заменяет его
+func WithCustomPrompts(templates []*prompts.Template) Option {
+	return func(s *Server) {
+		s.customPrompts = templates
+	}
+}
+
+// WithPriceTargets включает инструменты целевых цен и добавляет консенсус аналитиков в шаблоны анализа
+func WithPriceTargets(targetService services.PriceTargetService) Option {
+	return func(s *Server) {
+		s.targetService = targetService
+	}
+}
+
+// WithCorporateEvents включает инструменты календаря корпоративных событий
+func WithCorporateEvents(eventService services.CorporateEventService) Option {
+	return func(s *Server) {
+		s.eventService = eventService
+	}
+}
+
+// WithCommodities включает инструмент цен сырьевых товаров
+func WithCommodities(commodityService services.CommodityService) Option {
+	return func(s *Server) {
+		s.commodityService = commodityService
+	}
+}
+
+// WithCrypto включает инструмент котировок криптовалют и их блок в обзоре рынка
+func WithCrypto(cryptoService services.CryptoService) Option {
+	return func(s *Server) {
+		s.cryptoService = cryptoService
+	}
+}
+
+// WithCBR включает инструменты ключевой ставки и официальных курсов Банка России и ставку в обзоре рынка
+func WithCBR(cbrService services.CBRService) Option {
+	return func(s *Server) {
+		s.cbrService = cbrService
+	}
+}
+
+// WithMacro включает инструмент макроэкономических показателей и шаблон macro_overview
+func WithMacro(macroService services.MacroService) Option {
+	return func(s *Server) {
+		s.macroService = macroService
+	}
+}
+
+// WithGaps включает инструмент сканера гэпов открытия get_gappers
+func WithGaps(gapService services.GapService) Option {
+	return func(s *Server) {
+		s.gapService = gapService
+	}
+}
+
+// WithMargin включает инструмент маржинальных списков брокеров и их вывод в get_stock_info
+func WithMargin(marginService services.MarginService) Option {
+	return func(s *Server) {
+		s.marginService = marginService
+	}
+}
+
+// WithIndexes включает инструмент состава и весов биржевых индексов
+func WithIndexes(indexService services.IndexService) Option {
+	return func(s *Server) {
+		s.indexService = indexService
+	}
+}
+
+// WithSelfTest включает инструмент самопроверки источников данных
+func WithSelfTest(selfTestService services.SelfTestService) Option {
+	return func(s *Server) {
+		s.selfTestService = selfTestService
+	}
+}
+
+// WithRawArchive включает инструмент повторного разбора архива ответов внешних API
+func WithRawArchive(rawArchiveService services.RawArchiveService) Option {
+	return func(s *Server) {
+		s.rawArchiveService = rawArchiveService
+	}
+}
+
+// WithExport включает инструмент выгрузки данных export_data
+func WithExport(exportService services.ExportService) Option {
+	return func(s *Server) {
+		s.exportService = exportService
+	}
+}
+
+// WithCacheAdmin включает инструмент invalidate_cache
+func WithCacheAdmin(cacheService services.CacheService) Option {
+	return func(s *Server) {
+		s.cacheService = cacheService
+	}
+}
+
+// WithServerStats включает инструмент get_server_stats
+func WithServerStats(statsService services.ServerStatsService) Option {
+	return func(s *Server) {
+		s.statsService = statsService
+	}
+}
+
+// WithHealth включает инструмент health_check
+func WithHealth(healthService services.HealthService) Option {
+	return func(s *Server) {
+		s.healthService = healthService
+	}
+}
+
+// WithMOEXStatus включает пометку результатов инструментов во время технического обслуживания MOEX ISS
+func WithMOEXStatus(status UpstreamStatus) Option {
+	return func(s *Server) {
+		s.formatter.statuses[sourceMOEX] = status
+	}
+}
+
+// WithSymbols включает распознавание тикеров и названий компаний в аргументах ticker и tickers
+func WithSymbols(symbolService services.SymbolService) Option {
+	return func(s *Server) {
+		s.symbolService = symbolService
+	}
+}
+
+// WithSecurities включает инструмент lookup_security
+func WithSecurities(securityService services.SecurityService) Option {
+	return func(s *Server) {
+		s.securityService = securityService
+	}
+}
+
+// WithFunds включает инструменты биржевых и паевых фондов get_etf_info и search_etfs
+func WithFunds(fundService services.FundService) Option {
+	return func(s *Server) {
+		s.fundService = fundService
+	}
+}
+
+// WithRenderer задает шаблоны результатов инструментов акций и новостей вместо встроенных
+func WithRenderer(renderer *render.Renderer) Option {
+	return func(s *Server) {
+		s.renderer = renderer
+	}
+}
+
+// WithSampler включает поддержку MCP sampling для stdio-транспорта
+func WithSampler(sampler *StdioSampler) Option {
+	return func(s *Server) {
+		s.sampler = sampler
+	}
 }
 
 // NewMCPServer создает новый экземпляр MCP сервера
-func NewMCPServer(cfg *config.Config, stockService services.StockService, newsService services.NewsService) *Server {
+func NewMCPServer(cfg *config.Config, stockService services.StockService, newsService services.NewsService, opts ...Option) *Server {
+	s := &Server{
+		stockService: stockService,
+		newsService:  newsService,
+		config:       cfg,
+		formatter:    newFormatter(cfg),
+		renderer:     render.Default(),
+		printer:      i18n.NewPrinter(defaultLanguage(cfg.Server.Language)),
+		authClients:  newAuthClients(cfg.Auth.Keys),
+		usage:        newUsageTracker(cfg.RateLimit),
+		quotes:       newQuoteSubscriptions(),
+		preferences:  newSessionPreferences(),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
 	// Создаем MCP сервер
 
 	// Логирование запросов
@@ -50,121 +342,441 @@ func NewMCPServer(cfg *config.Config, stockService services.StockService, newsSe
 	hooks.AddBeforeCallTool(func(ctx context.Context, id any, message *mcp.CallToolRequest) {
 		fmt.Printf("beforeCallTool: %v, %v\n", id, message)
 	})
+	// Клиенты SSE-транспорта видят только разрешенные им инструменты
+	hooks.AddAfterListTools(s.filterListedTools)
+	// Подписки на котировки закрытой сессии больше некому доставлять
+	hooks.AddOnUnregisterSession(s.quotes.dropSession)
+	hooks.AddOnUnregisterSession(s.preferences.dropSession)
+	if s.sampler != nil {
+		// Запоминаем, поддерживает ли клиент sampling
+		hooks.AddAfterInitialize(s.sampler.onInitialize)
+	}
 
-	mcpServer := server.NewMCPServer(
-		"Stocks & News API",
-		"1.0.0",
+	var serverOpts []server.ServerOption
+	if cfg.Server.Debug {
+		// Разбивка времени по этапам добавляется в метаданные результатов
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(timingMiddleware))
+	}
+	serverOpts = append(serverOpts,
 		// Добавляем hooks
 		server.WithHooks(hooks),
+		// Описание сервера для клиентской модели
+		server.WithInstructions(buildInstructions(cfg, s.printer)),
+		// Настройки сессии подставляются в аргументы до определения языка: язык тоже может быть настройкой
+		server.WithToolHandlerMiddleware(s.preferencesMiddleware),
+		// Язык ответа определяется до остальных middleware, чтобы они оформляли результат на нем
+		server.WithToolHandlerMiddleware(s.languageMiddleware),
+		// Доступ клиента к инструменту проверяется до выполнения
+		server.WithToolHandlerMiddleware(s.authMiddleware),
+		// Частота и суточное число вызовов ограничиваются для каждого клиента
+		server.WithToolHandlerMiddleware(s.rateLimitMiddleware),
+		// Время выполнения ограничивается для всего вызова, включая распознавание тикеров и оформление результата
+		server.WithToolHandlerMiddleware(s.timeoutMiddleware),
+		// Ход долгих инструментов передается клиенту, запросившему уведомления о нем
+		server.WithToolHandlerMiddleware(s.progressMiddleware),
+		// Строки об источниках данных добавляются ко всем результатам централизованно
+		server.WithToolHandlerMiddleware(s.formatter.middleware),
 	)
-
-	return &Server{
-		server:       mcpServer,
-		stockService: stockService,
-		newsService:  newsService,
-		config:       cfg,
+	if s.symbolService != nil {
+		// Тикеры и названия компаний в аргументах приводятся к тикерам бумаг до обращения к данным
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(s.symbolMiddleware))
 	}
+	// Длина результата ограничивается до добавления строк об источниках, чтобы они не пропали при сокращении
+	serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(s.budgetMiddleware))
+
+	s.server = server.NewMCPServer(cfg.Server.Name, cfg.Server.Version, serverOpts...)
+
+	return s
 }
 
 // Start запускает MCP сервер
 func (s *Server) Start() error {
-	// Регистрируем инструменты (tools)
-	s.registerTools()
-
-	// Регистрируем шаблоны (prompts)
-	s.registerPrompts()
+	s.register()
 
 	// Запускаем сервер
+	if s.config.Server.Transport == config.TransportSSE {
+		return s.serveSSE()
+	}
+	if s.sampler != nil {
+		return s.sampler.Serve(s.server)
+	}
 	return server.ServeStdio(s.server)
 }
 
-// registerTools регистрирует инструменты (tools) в MCP сервере
+// register регистрирует инструменты, шаблоны и каталог; повторные вызовы ничего не делают
+func (s *Server) register() {
+	s.registerOnce.Do(func() {
+		// Регистрируем инструменты (tools)
+		s.registerTools()
+
+		// Регистрируем шаблоны (prompts)
+		s.registerPrompts()
+
+		// Регистрируем каталог инструментов и шаблонов с примерами (resources)
+		s.registerCatalog()
+	})
+}
+
+// Tools возвращает инструменты, доступные клиентам при текущей конфигурации, в порядке регистрации
+func (s *Server) Tools() []mcp.Tool {
+	s.register()
+	return s.tools
+}
+
+// registerTools регистрирует инструменты (tools) в MCP сервере. Группы, отключенные в features.disabled, пропускаются
 func (s *Server) registerTools() {
-	// Регистрируем инструменты для работы с акциями
-	s.registerStockTools()
+	groups := []struct {
+		feature  string
+		register func()
+	}{
+		// Инструменты для работы с акциями
+		{config.FeatureStocks, s.registerStockTools},
+		// Инструмент и ресурс графиков котировок
+		{config.FeatureStocks, s.registerChartTools},
+		// Инструменты подписки на изменения цен
+		{config.FeatureStocks, s.registerSubscriptionTools},
+		// Инструмент справочника бумаг
+		{config.FeatureStocks, s.registerSecurityTools},
+		// Инструменты биржевых и паевых фондов
+		{config.FeatureStocks, s.registerFundTools},
+		// Инструмент поиска аномальных объемов торгов
+		{config.FeatureStocks, s.registerVolumeTools},
+		// Инструмент сканера гэпов открытия
+		{config.FeatureStocks, s.registerGapTools},
+		// Инструмент маржинальных списков брокеров
+		{config.FeatureStocks, s.registerMarginTools},
+		// Инструмент профилей компаний
+		{config.FeatureProfiles, s.registerProfileTools},
+		// Инструменты биржевых данных реального времени
+		{config.FeatureMarketData, s.registerMarketDataTools},
+		// Инструмент состава биржевых индексов
+		{config.FeatureMarketData, s.registerIndexTools},
+		// Инструмент цен сырьевых товаров
+		{config.FeatureCommodities, s.registerCommodityTools},
+		// Инструмент котировок криптовалют
+		{config.FeatureCrypto, s.registerCryptoTools},
+		// Инструменты ставок и официальных курсов Банка России
+		{config.FeatureCBR, s.registerCBRTools},
+		// Инструмент макроэкономических показателей
+		{config.FeatureMacro, s.registerMacroTools},
+		// Инструменты календаря размещений
+		{config.FeatureListings, s.registerListingTools},
+		// Инструменты календаря корпоративных событий
+		{config.FeatureEvents, s.registerEventTools},
+		// Инструменты для работы с новостями
+		{config.FeatureNews, s.registerNewsTools},
+		// Аналитические инструменты
+		{config.FeatureAnalysis, s.registerAnalysisTools},
+		// Инструменты целевых цен аналитиков
+		{config.FeatureAnalysis, s.registerPriceTargetTools},
+		// Инструменты для работы с портфелем
+		{config.FeaturePortfolio, s.registerPortfolioTools},
+		// Инструмент оценки налогов по портфелю
+		{config.FeaturePortfolio, s.registerTaxTools},
+		// Инструменты журнала сделок портфеля
+		{config.FeaturePortfolio, s.registerTradeJournalTools},
+		// Инструменты для работы со списками наблюдения и уведомлениями
+		{config.FeatureWatchlist, s.registerWatchlistTools},
+		// Инструмент индекса настроения рынка
+		{config.FeatureMood, s.registerMoodTools},
+		// Инструмент выгрузки данных в CSV и JSON
+		{config.FeatureExport, s.registerExportTools},
+		// Инструменты настроек сессии
+		{config.FeaturePreferences, s.registerPreferenceTools},
+		// Диагностические инструменты
+		{config.FeatureDiagnostics, s.registerDiagnosticsTools},
+	}
 
-	// Регистрируем инструменты для работы с новостями
-	s.registerNewsTools()
+	for _, group := range groups {
+		if s.config.Features.Enabled(group.feature) {
+			group.register()
+		}
+	}
+}
+
+// addTool регистрирует инструмент вместе с источниками данных, на которые он опирается.
+// Ко всем инструментам добавляется аргумент языка ответа lang
+func (s *Server) addTool(tool mcp.Tool, handler server.ToolHandlerFunc, sources ...dataSource) {
+	s.withLangArg(&tool)
+	s.formatter.registerTool(tool.Name, sources...)
+	s.tools = append(s.tools, tool)
+	s.server.AddTool(tool, handler)
+}
+
+// addPrompt регистрирует шаблон, если включены все группы, данные которых он использует
+func (s *Server) addPrompt(prompt mcp.Prompt, handler server.PromptHandlerFunc, features ...string) {
+	if !s.config.Features.Enabled(config.FeaturePrompts) {
+		return
+	}
+	for _, feature := range features {
+		if !s.config.Features.Enabled(feature) {
+			return
+		}
+	}
+	s.server.AddPrompt(prompt, handler)
+
+	// Повторная регистрация заменяет шаблон, в том числе в каталоге
+	for i := range s.prompts {
+		if s.prompts[i].Name == prompt.Name {
+			s.prompts[i] = prompt
+			return
+		}
+	}
+	s.prompts = append(s.prompts, prompt)
 }
 
 // registerStockTools регистрирует инструменты для работы с акциями
 func (s *Server) registerStockTools() {
 	// Инструмент для получения информации об акции
 	getStockTool := mcp.NewTool("get_stock_info",
-		mcp.WithDescription("Получить информацию о котировке акции на MOEX"),
+		mcp.WithDescription(s.printer.T("Получить информацию о котировке акции на MOEX или включенной зарубежной бирже (NASDAQ, NYSE, XETRA, EURONEXT)")),
+		mcp.WithString("ticker",
+			mcp.Required(),
+			mcp.Description(s.printer.T("Тикер акции (например, SBER, GAZP, LKOH), ее ISIN (RU0009029540) или FIGI (BBG004730N88); для бумаг других бирж — тикер с префиксом биржи, например NASDAQ:AAPL или XETRA:SAP")),
+		),
+		s.boardArg(),
+		s.marketArg(),
+		s.sparklineArg(),
+	)
+
+	s.addTool(getStockTool, s.handleGetStockInfo, sourceMOEX, sourceYahoo)
+
+	// Инструмент для получения истории котировок
+	getStockHistoryTool := mcp.NewTool("get_stock_history",
+		mcp.WithDescription(s.printer.T("Получить историю котировок акции свечами: дневными или внутридневными (1m, 10m, 1h) для анализа движения внутри торговой сессии")),
 		mcp.WithString("ticker",
 			mcp.Required(),
-			mcp.Description("Тикер акции (например, SBER, GAZP, LKOH)"),
+			mcp.Description(s.printer.T("Тикер акции (например, SBER, GAZP, LKOH); для бумаг других бирж — с префиксом биржи, например NASDAQ:AAPL или XETRA:SAP")),
+		),
+		mcp.WithString("interval",
+			mcp.Description(s.printer.T("Интервал свечей: 1m, 10m, 1h или 1d (по умолчанию 1d). Период внутридневных свечей ограничен: 1m — сутки, 10m — неделя, 1h — месяц")),
+			mcp.Enum(models.IntervalMinute, models.IntervalTenMinute, models.IntervalHour, models.IntervalDay),
+		),
+		mcp.WithString("from",
+			mcp.Description(s.printer.T("Начало периода в формате YYYY-MM-DD или YYYY-MM-DD HH:MM по московскому времени (по умолчанию месяц назад для дневных свечей и сутки назад для внутридневных)")),
+		),
+		mcp.WithString("to",
+			mcp.Description(s.printer.T("Конец периода в формате YYYY-MM-DD (включительно) или YYYY-MM-DD HH:MM по московскому времени (по умолчанию сейчас)")),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description(s.printer.Sprintf("Сколько последних свечей вывести (по умолчанию %d); сводка считается по всему периоду", defaultHistoryCandles)),
 		),
+		s.sparklineArg(),
 	)
 
-	s.server.AddTool(getStockTool, s.handleGetStockInfo)
+	s.addTool(getStockHistoryTool, s.handleGetStockHistory, sourceMOEX, sourceYahoo)
+
+	// Инструмент для загрузки истории дневных свечей за несколько лет
+	backfillHistoryTool := mcp.NewTool("backfill_history",
+		mcp.WithDescription(s.printer.T("Загрузить с Московской Биржи историю дневных свечей тикеров за несколько лет и сохранить ее, чтобы аналитика (корреляции, технические индикаторы, сравнение доходностей) работала по полной истории. Прерванная загрузка продолжается с места остановки, повторная загружает только новые дни")),
+		mcp.WithArray("tickers",
+			mcp.Description(s.printer.T("Тикеры акций (по умолчанию все тикеры универсумов из конфигурации)")),
+			mcp.Items(map[string]interface{}{"type": "string"}),
+		),
+		mcp.WithNumber("years",
+			mcp.Description(s.printer.Sprintf("Глубина истории в годах (по умолчанию %d, не более %d)", models.DefaultHistoryBackfillYears, models.MaxHistoryBackfillYears)),
+		),
+	)
+
+	s.addTool(backfillHistoryTool, s.handleBackfillHistory, sourceMOEX)
 
 	// Инструмент для получения топ растущих акций
 	getTopGainersTool := mcp.NewTool("get_top_gainers",
-		mcp.WithDescription("Получить список топ растущих акций на MOEX"),
+		mcp.WithDescription(s.printer.T("Получить список топ растущих акций на MOEX")),
 		mcp.WithNumber("limit",
-			mcp.Description("Количество акций в списке (по умолчанию 10)"),
+			mcp.Description(s.printer.T("Количество акций в списке (по умолчанию 10)")),
 		),
+		s.universeArg(),
 	)
 
-	s.server.AddTool(getTopGainersTool, s.handleGetTopGainers)
+	s.addTool(getTopGainersTool, s.handleGetTopGainers, sourceMOEX)
 
 	// Инструмент для получения топ падающих акций
 	getTopLosersTool := mcp.NewTool("get_top_losers",
-		mcp.WithDescription("Получить список топ падающих акций на MOEX"),
+		mcp.WithDescription(s.printer.T("Получить список топ падающих акций на MOEX")),
 		mcp.WithNumber("limit",
-			mcp.Description("Количество акций в списке (по умолчанию 10)"),
+			mcp.Description(s.printer.T("Количество акций в списке (по умолчанию 10)")),
 		),
+		s.universeArg(),
 	)
 
-	s.server.AddTool(getTopLosersTool, s.handleGetTopLosers)
+	s.addTool(getTopLosersTool, s.handleGetTopLosers, sourceMOEX)
 
 	// Инструмент для поиска акций
 	searchStocksTool := mcp.NewTool("search_stocks",
-		mcp.WithDescription("Поиск акций по названию или тикеру"),
+		mcp.WithDescription(s.printer.T("Поиск акций по названию или тикеру среди всех бумаг MOEX с учетом опечаток")),
 		mcp.WithString("query",
 			mcp.Required(),
-			mcp.Description("Поисковый запрос (часть названия или тикера)"),
+			mcp.Description(s.printer.T("Поисковый запрос: тикер, его начало или название компании, можно с опечатками")),
+		),
+		s.universeArg(),
+		s.limitArg("акций"),
+		s.offsetArg(),
+	)
+
+	s.addTool(searchStocksTool, s.handleSearchStocks, sourceMOEX)
+
+	// Инструмент для получения ширины рынка
+	getMarketBreadthTool := mcp.NewTool("get_market_breadth",
+		mcp.WithDescription(s.printer.T("Получить ширину рынка: число растущих и падающих акций, среднее изменение и суммарный объем")),
+		s.universeArg(),
+	)
+
+	s.addTool(getMarketBreadthTool, s.handleGetMarketBreadth, sourceMOEX)
+
+	// Инструмент для сравнения нескольких акций
+	compareStocksTool := mcp.NewTool("compare_stocks",
+		mcp.WithDescription(s.printer.T("Сравнить несколько акций бок о бок: цена, изменение за день, объем, P/E, дивидендная доходность и доходность за 1 и 3 месяца")),
+		mcp.WithArray("tickers",
+			mcp.Required(),
+			mcp.Description(s.printer.Sprintf("Тикеры акций для сравнения, от %d до %d (например, [\"SBER\", \"VTBR\"])",
+				models.MinComparedStocks, models.MaxComparedStocks)),
+			mcp.Items(map[string]interface{}{"type": "string"}),
+			mcp.MinItems(models.MinComparedStocks),
+			mcp.MaxItems(models.MaxComparedStocks),
 		),
 	)
 
-	s.server.AddTool(searchStocksTool, s.handleSearchStocks)
+	s.addTool(compareStocksTool, s.handleCompareStocks, sourceMOEX)
+}
+
+// universeArg описывает аргумент universe со списком доступных универсумов
+func (s *Server) universeArg() mcp.ToolOption {
+	universes := s.stockService.GetUniverses()
+	names := make([]string, 0, len(universes))
+	description := s.printer.T("Торговый универсум (по умолчанию full)")
+	for _, universe := range universes {
+		names = append(names, universe.Name)
+		description += fmt.Sprintf("; %s — %s", universe.Name, universe.Description)
+	}
+
+	return mcp.WithString("universe",
+		mcp.Description(description),
+		mcp.Enum(names...),
+	)
 }
 
 // registerNewsTools регистрирует инструменты для работы с новостями
 func (s *Server) registerNewsTools() {
 	// Инструмент для получения новостей за сегодня
 	getTodayNewsTool := mcp.NewTool("get_today_news",
-		mcp.WithDescription("Получить финансовые новости за сегодня"),
-		mcp.WithNumber("limit",
-			mcp.Description("Количество новостей (по умолчанию все)"),
-		),
+		mcp.WithDescription(s.printer.T("Получить финансовые новости за сегодня")),
+		s.limitArg("новостей"),
+		s.offsetArg(),
+		s.newsDetailArg(),
 	)
 
-	s.server.AddTool(getTodayNewsTool, s.handleGetTodayNews)
+	s.addTool(getTodayNewsTool, s.handleGetTodayNews, sourceNews)
 
 	// Инструмент для поиска новостей по ключевому слову
 	searchNewsTool := mcp.NewTool("search_news",
-		mcp.WithDescription("Поиск новостей по ключевому слову"),
+		mcp.WithDescription(s.printer.T("Поиск новостей по ключевому слову")),
 		mcp.WithString("keyword",
 			mcp.Required(),
-			mcp.Description("Ключевое слово для поиска"),
+			mcp.Description(s.printer.T("Ключевое слово для поиска")),
+		),
+		mcp.WithString("from",
+			mcp.Description(s.printer.T("Начало периода публикации в формате YYYY-MM-DD")),
 		),
+		mcp.WithString("to",
+			mcp.Description(s.printer.T("Конец периода публикации в формате YYYY-MM-DD (включительно)")),
+		),
+		mcp.WithArray("sources",
+			mcp.Description(s.printer.T("Издания (идентификаторы NewsAPI, например rbc, kommersant); по умолчанию из конфигурации")),
+			mcp.Items(map[string]interface{}{"type": "string"}),
+		),
+		mcp.WithString("language",
+			mcp.Description(s.printer.T("Язык новостей (по умолчанию ru)")),
+			mcp.Enum("ru", "en", "de", "fr", "es", "it", "zh"),
+		),
+		s.limitArg("новостей"),
+		s.offsetArg(),
+		s.newsDetailArg(),
 	)
 
-	s.server.AddTool(searchNewsTool, s.handleSearchNews)
+	s.addTool(searchNewsTool, s.handleSearchNews, sourceNews)
 
 	// Инструмент для получения новостей по тикеру
 	getNewsByTickerTool := mcp.NewTool("get_news_by_ticker",
-		mcp.WithDescription("Получить новости, связанные с указанным тикером"),
+		mcp.WithDescription(s.printer.T("Получить новости, связанные с указанным тикером, включая официальные сообщения Московской Биржи (остановки торгов, изменения листинга)")),
 		mcp.WithString("ticker",
 			mcp.Required(),
-			mcp.Description("Тикер акции (например, SBER, GAZP, LKOH)"),
+			mcp.Description(s.printer.T("Тикер акции (например, SBER, GAZP, LKOH)")),
 		),
+		s.newsDetailArg(),
 	)
 
-	s.server.AddTool(getNewsByTickerTool, s.handleGetNewsByTicker)
+	s.addTool(getNewsByTickerTool, s.handleGetNewsByTicker, sourceNews, sourceMOEX)
+
+	// Инструмент для получения полного текста статьи
+	getNewsFullTextTool := mcp.NewTool("get_news_fulltext",
+		mcp.WithDescription(s.printer.T("Получить полный текст статьи новости: страница загружается по ссылке новости (с учетом robots.txt сайта), из нее извлекается текст без меню и рекламы")),
+		mcp.WithString("id",
+			mcp.Required(),
+			mcp.Description(s.printer.T("ID новости из результатов инструментов новостей")),
+		),
+	)
+
+	s.addTool(getNewsFullTextTool, s.handleGetNewsFullText, sourceNews)
+
+	// Инструмент для поиска новостей, связанных с указанной
+	getRelatedNewsTool := mcp.NewTool("get_related_news",
+		mcp.WithDescription(s.printer.Sprintf("Найти сохраненные новости, связанные с указанной общими тикерами, темами и словами заголовка (в пределах %d дней до и после нее), в хронологическом порядке — чтобы восстановить развитие сюжета", int(models.RelatedNewsWindow.Hours()/24))),
+		mcp.WithString("id",
+			mcp.Required(),
+			mcp.Description(s.printer.T("ID новости из результатов инструментов новостей")),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description(s.printer.Sprintf("Сколько связанных новостей вернуть (по умолчанию %d, максимум %d)", models.DefaultRelatedNews, models.MaxPageLimit)),
+		),
+	)
+
+	s.addTool(getRelatedNewsTool, s.handleGetRelatedNews, sourceNews)
+
+	// Инструмент для хроники новостей тикера по дням
+	getNewsTimelineTool := mcp.NewTool("get_news_timeline",
+		mcp.WithDescription(s.printer.T("Получить хронику новостей по тикеру: число новостей и главные заголовки за каждый день периода с отметкой дней всплеска новостей — чтобы сопоставить движения цены с новостным фоном")),
+		mcp.WithString("ticker",
+			mcp.Required(),
+			mcp.Description(s.printer.T("Тикер акции (например, SBER, GAZP, LKOH)")),
+		),
+		mcp.WithString("from",
+			mcp.Description(s.printer.Sprintf("Первый день периода в формате YYYY-MM-DD (по умолчанию %d дней назад)", models.DefaultNewsTimelineDays)),
+		),
+		mcp.WithString("to",
+			mcp.Description(s.printer.Sprintf("Последний день периода в формате YYYY-MM-DD, включительно (по умолчанию сегодня); период не длиннее %d дней", models.MaxNewsTimelineDays)),
+		),
+		mcp.WithNumber("headlines",
+			mcp.Description(s.printer.Sprintf("Сколько заголовков показать за каждый день (по умолчанию %d)", models.DefaultTimelineHeadlines)),
+		),
+	)
+
+	s.addTool(getNewsTimelineTool, s.handleGetNewsTimeline, sourceNews)
+
+	// Инструмент для сводки новостей по темам
+	getNewsSummaryTool := mcp.NewTool("get_news_summary",
+		mcp.WithDescription(s.printer.T("Получить сводку новостей за сегодня по темам (банки, нефть и газ, металлы, макроэкономика, валюта): число новостей и самые свежие заголовки по каждой теме")),
+		mcp.WithNumber("headlines",
+			mcp.Description(s.printer.Sprintf("Сколько заголовков показать по каждой теме (по умолчанию %d)", models.DefaultSummaryHeadlines)),
+		),
+	)
+
+	s.addTool(getNewsSummaryTool, s.handleGetNewsSummary, sourceNews)
+
+	// Инструмент для загрузки архива новостей за прошедшие даты
+	backfillNewsTool := mcp.NewTool("backfill_news",
+		mcp.WithDescription(s.printer.Sprintf("Загрузить из NewsAPI архив финансовых новостей за период (не длиннее %d дней) и сохранить его, чтобы поиск и выборки по прошедшим датам возвращали результаты", models.MaxBackfillDays)),
+		mcp.WithString("from",
+			mcp.Required(),
+			mcp.Description(s.printer.T("Начало периода в формате YYYY-MM-DD")),
+		),
+		mcp.WithString("to",
+			mcp.Required(),
+			mcp.Description(s.printer.T("Конец периода в формате YYYY-MM-DD (включительно)")),
+		),
+	)
+
+	s.addTool(backfillNewsTool, s.handleBackfillNews, sourceNews)
 }
 
 // registerPrompts регистрирует шаблоны в MCP сервере
@@ -178,227 +790,498 @@ func (s *Server) registerPrompts() {
 		),
 	)
 
-	s.server.AddPrompt(stockAnalysisPrompt, s.handleStockAnalysisPrompt)
+	s.addPrompt(stockAnalysisPrompt, s.handleStockAnalysisPrompt, config.FeatureStocks)
+
+	// Шаблон технического анализа по рассчитанным индикаторам
+	intervals := make([]string, 0, len(models.QuoteIntervals))
+	for _, interval := range models.QuoteIntervals {
+		intervals = append(intervals, interval.Name)
+	}
+	technicalAnalysisPrompt := mcp.NewPrompt("technical_analysis",
+		mcp.WithPromptDescription("Технический анализ акции: SMA, RSI, MACD и уровни поддержки и сопротивления, рассчитанные по сохраненным свечам"),
+		mcp.WithArgument("ticker",
+			mcp.ArgumentDescription("Тикер акции для анализа"),
+			mcp.RequiredArgument(),
+		),
+		mcp.WithArgument("timeframe",
+			mcp.ArgumentDescription(fmt.Sprintf("Интервал свечей: %s (по умолчанию %s)", strings.Join(intervals, ", "), models.IntervalDay)),
+		),
+	)
+
+	s.addPrompt(technicalAnalysisPrompt, s.handleTechnicalAnalysisPrompt, config.FeatureStocks)
 
 	// Шаблон для обзора рынка
 	marketOverviewPrompt := mcp.NewPrompt("market_overview",
-		mcp.WithPromptDescription("Общий обзор состояния рынка"),
+		mcp.WithPromptDescription("Общий обзор состояния рынка: ширина рынка и лидеры роста и падения по выбранной выборке акций"),
+		mcp.WithArgument("universe",
+			mcp.ArgumentDescription(s.overviewUniverseDescription()),
+		),
 	)
 
-	s.server.AddPrompt(marketOverviewPrompt, s.handleMarketOverviewPrompt)
+	s.addPrompt(marketOverviewPrompt, s.handleMarketOverviewPrompt, config.FeatureStocks)
 
 	// Шаблон для анализа новостей
 	newsAnalysisPrompt := mcp.NewPrompt("news_analysis",
 		mcp.WithPromptDescription("Анализ финансовых новостей за сегодня"),
 	)
 
-	s.server.AddPrompt(newsAnalysisPrompt, s.handleNewsAnalysisPrompt)
+	s.addPrompt(newsAnalysisPrompt, s.handleNewsAnalysisPrompt, config.FeatureNews)
+
+	// Шаблон для сравнения акций
+	stockComparisonPrompt := mcp.NewPrompt("stock_comparison",
+		mcp.WithPromptDescription("Сравнительный анализ нескольких акций"),
+		mcp.WithArgument("tickers",
+			mcp.ArgumentDescription(fmt.Sprintf("Тикеры акций через запятую, от %d до %d (например, SBER,VTBR)",
+				models.MinComparedStocks, models.MaxComparedStocks)),
+			mcp.RequiredArgument(),
+		),
+	)
+
+	s.addPrompt(stockComparisonPrompt, s.handleStockComparisonPrompt, config.FeatureStocks)
+
+	// Шаблон относительной оценки акций с нормированной историей цен
+	compareAnalysisPrompt := mcp.NewPrompt("compare_analysis",
+		mcp.WithPromptDescription(fmt.Sprintf("Относительная оценка акций: нормированная история цен за %d месяцев и фундаментальные показатели", models.CompareAnalysisMonths)),
+		mcp.WithArgument("tickers",
+			mcp.ArgumentDescription(fmt.Sprintf("Тикеры акций через запятую, от %d до %d (например, SBER,VTBR)",
+				models.MinComparedStocks, models.MaxCompareAnalysisStocks)),
+			mcp.RequiredArgument(),
+		),
+	)
+
+	s.addPrompt(compareAnalysisPrompt, s.handleCompareAnalysisPrompt, config.FeatureStocks)
+
+	// Шаблон ежедневного дайджеста рынка
+	dailyDigestPrompt := mcp.NewPrompt("daily_digest",
+		mcp.WithPromptDescription("Ежедневный дайджест: индексы, лидеры роста и падения, аномальные объемы, курсы валют, сырье, секторы и главные новости"),
+		mcp.WithArgument("sections",
+			mcp.ArgumentDescription(fmt.Sprintf("Разделы дайджеста через запятую (по умолчанию все): %s", strings.Join(models.DigestSections, ", "))),
+		),
+		mcp.WithArgument("news_limit",
+			mcp.ArgumentDescription(fmt.Sprintf("Количество новостей, от 1 до %d (по умолчанию %d)", models.MaxDigestNewsLimit, models.DefaultDigestNewsLimit)),
+		),
+	)
+
+	s.addPrompt(dailyDigestPrompt, s.handleDailyDigestPrompt, config.FeatureStocks)
+
+	// Шаблон торговых идей по новостям доступен, если подключена аналитика
+	if s.analysisService != nil {
+		tradeIdeasPrompt := mcp.NewPrompt("trade_ideas_from_news",
+			mcp.WithPromptDescription("Торговые идеи long/short по новостям дня, сгруппированным по упомянутым акциям, с текущими котировками"),
+			mcp.WithArgument("max_tickers",
+				mcp.ArgumentDescription(fmt.Sprintf("Количество акций с наибольшим числом новостей, до %d (по умолчанию %d)", models.MaxNewsClusterTickers, models.DefaultNewsClusterTickers)),
+			),
+		)
+
+		s.addPrompt(tradeIdeasPrompt, s.handleTradeIdeasFromNewsPrompt, config.FeatureAnalysis, config.FeatureNews)
+	}
+
+	// Шаблон обзора рисков доступен, если подключен модуль портфелей
+	if s.portfolioService != nil {
+		portfolioRiskReviewPrompt := mcp.NewPrompt("portfolio_risk_review",
+			mcp.WithPromptDescription("Обзор рисков портфеля: концентрация по секторам, бета, просадки и рекомендации по ребалансировке"),
+			mcp.WithArgument("portfolio",
+				mcp.ArgumentDescription("Имя портфеля (по умолчанию default)"),
+			),
+			mcp.WithArgument("window_days",
+				mcp.ArgumentDescription(fmt.Sprintf("Окно расчета беты и просадок в днях, до %d (по умолчанию %d)", models.MaxRiskWindowDays, models.DefaultRiskWindowDays)),
+			),
+		)
+
+		s.addPrompt(portfolioRiskReviewPrompt, s.handlePortfolioRiskReviewPrompt, config.FeaturePortfolio)
+	}
+
+	// Шаблон плана дивидендного дохода доступен, если подключен календарь корпоративных событий
+	if s.eventService != nil {
+		dividendIncomePlanPrompt := mcp.NewPrompt("dividend_income_plan",
+			mcp.WithPromptDescription("План дивидендного портфеля под целевой ежемесячный доход с учетом графика выплат"),
+			mcp.WithArgument("target_monthly_income",
+				mcp.ArgumentDescription("Целевой ежемесячный дивидендный доход, ₽"),
+				mcp.RequiredArgument(),
+			),
+			mcp.WithArgument("tickers",
+				mcp.ArgumentDescription(fmt.Sprintf("Бумаги-кандидаты через запятую, не более %d; по умолчанию бумаги с самой высокой доходностью из платящих дивиденды за последний год", models.MaxDividendCandidates)),
+			),
+		)
+
+		s.addPrompt(dividendIncomePlanPrompt, s.handleDividendIncomePlanPrompt, config.FeatureEvents)
+	}
+
+	// Шаблон макроэкономического обзора доступен, если подключен модуль макропоказателей
+	if s.macroService != nil {
+		macroOverviewPrompt := mcp.NewPrompt("macro_overview",
+			mcp.WithPromptDescription("Макроэкономический обзор: инфляция, ВВП, ставка, рубль и нефть и их влияние на рынок акций"),
+		)
+
+		s.addPrompt(macroOverviewPrompt, s.handleMacroOverviewPrompt, config.FeatureMacro)
+	}
+
+	// Шаблоны оператора регистрируются последними, чтобы заменять встроенные с теми же именами
+	s.registerCustomPrompts()
 }
 
 // Обработчики инструментов для акций
 
 // handleGetStockInfo обрабатывает запрос на получение информации об акции
 func (s *Server) handleGetStockInfo(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	ticker, ok := request.Params.Arguments["ticker"].(string)
-	if !ok {
-		return mcp.NewToolResultError("параметр ticker должен быть строкой"), nil
+	p := i18n.PrinterFrom(ctx)
+	args := struct {
+		Ticker string `arg:"ticker,required"`
+		boardArgs
+		sparklineArgs
+	}{sparklineArgs: sparklineArgs{Sparkline: true}}
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	ticker := args.Ticker
+	board, err := args.tradingBoard(p)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	stock, err := s.stockService.GetStockInfo(ctx, ticker)
+	stock, err := s.stockService.GetStockInfo(ctx, ticker, board)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("не удалось получить информацию об акции: %v", err)), nil
+		return mcp.NewToolResultError(p.Sprintf("не удалось получить информацию об акции: %v", err)), nil
 	}
 
 	if stock == nil {
-		return mcp.NewToolResultError(fmt.Sprintf("акция с тикером %s не найдена", ticker)), nil
+		return mcp.NewToolResultError(p.Sprintf("акция с тикером %s не найдена", ticker)), nil
 	}
 
-	// Формируем результат
-	result := fmt.Sprintf(`Информация об акции %s (%s):
-Цена: %.2f ₽
-Изменение: %.2f (%.2f%%)
-Объем торгов: %d
-Дата обновления: %s`,
-		stock.Ticker, stock.Name,
-		stock.Price,
-		stock.Change, stock.ChangePerc,
-		stock.Volume,
-		stock.UpdatedAt.Format("2006-01-02 15:04:05"),
-	)
+	card := render.StockCard{Stock: *stock}
+	if args.Sparkline {
+		s.addStockTrend(ctx, &card)
+	}
+	s.addMarginStatus(ctx, &card)
 
-	return mcp.NewToolResultText(result), nil
+	return s.renderResult(p, render.StockInfo, card)
 }
 
 // handleGetTopGainers обрабатывает запрос на получение топ растущих акций
 func (s *Server) handleGetTopGainers(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	limit := 10 // Значение по умолчанию
-	if limitVal, ok := request.Params.Arguments["limit"].(float64); ok {
-		limit = int(limitVal)
+	p := i18n.PrinterFrom(ctx)
+	args := struct {
+		Limit    int    `arg:"limit" min:"1" max:"100"`
+		Universe string `arg:"universe"`
+	}{Limit: 10}
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	stocks, err := s.stockService.GetMOEXTopGainers(ctx, limit)
+	stocks, err := s.stockService.GetMOEXTopGainers(ctx, args.Universe, args.Limit)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("не удалось получить список растущих акций: %v", err)), nil
+		return mcp.NewToolResultError(p.Sprintf("не удалось получить список растущих акций: %v", err)), nil
 	}
 
 	if len(stocks) == 0 {
-		return mcp.NewToolResultText("Не найдено растущих акций"), nil
+		return mcp.NewToolResultText(p.T("Не найдено растущих акций")), nil
 	}
 
-	// Формируем результат
-	result := fmt.Sprintf("Топ %d растущих акций на MOEX:\n\n", len(stocks))
-	for i, stock := range stocks {
-		result += fmt.Sprintf("%d. %s (%s): %.2f ₽ (%.2f%%)\n",
-			i+1, stock.Ticker, stock.Name, stock.Price, stock.ChangePerc)
-	}
-
-	return mcp.NewToolResultText(result), nil
+	return s.renderResult(p, render.TopGainers, render.StockList{Stocks: stocks, Page: render.FullPage(len(stocks))})
 }
 
 // handleGetTopLosers обрабатывает запрос на получение топ падающих акций
 func (s *Server) handleGetTopLosers(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	limit := 10 // Значение по умолчанию
-	if limitVal, ok := request.Params.Arguments["limit"].(float64); ok {
-		limit = int(limitVal)
+	p := i18n.PrinterFrom(ctx)
+	args := struct {
+		Limit    int    `arg:"limit" min:"1" max:"100"`
+		Universe string `arg:"universe"`
+	}{Limit: 10}
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	stocks, err := s.stockService.GetMOEXTopLosers(ctx, limit)
+	stocks, err := s.stockService.GetMOEXTopLosers(ctx, args.Universe, args.Limit)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("не удалось получить список падающих акций: %v", err)), nil
+		return mcp.NewToolResultError(p.Sprintf("не удалось получить список падающих акций: %v", err)), nil
 	}
 
 	if len(stocks) == 0 {
-		return mcp.NewToolResultText("Не найдено падающих акций"), nil
+		return mcp.NewToolResultText(p.T("Не найдено падающих акций")), nil
 	}
 
-	// Формируем результат
-	result := fmt.Sprintf("Топ %d падающих акций на MOEX:\n\n", len(stocks))
-	for i, stock := range stocks {
-		result += fmt.Sprintf("%d. %s (%s): %.2f ₽ (%.2f%%)\n",
-			i+1, stock.Ticker, stock.Name, stock.Price, stock.ChangePerc)
-	}
-
-	return mcp.NewToolResultText(result), nil
+	return s.renderResult(p, render.TopLosers, render.StockList{Stocks: stocks, Page: render.FullPage(len(stocks))})
 }
 
 // handleSearchStocks обрабатывает запрос на поиск акций
 func (s *Server) handleSearchStocks(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	query, ok := request.Params.Arguments["query"].(string)
-	if !ok {
-		return mcp.NewToolResultError("параметр query должен быть строкой"), nil
+	p := i18n.PrinterFrom(ctx)
+	var args struct {
+		Query    string `arg:"query,required"`
+		Universe string `arg:"universe"`
+		pageArgs
 	}
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	query, page := args.Query, args.page()
 
-	stocks, err := s.stockService.SearchStocks(ctx, query)
+	stocks, total, err := s.stockService.SearchStocks(ctx, args.Universe, query, page)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("не удалось выполнить поиск акций: %v", err)), nil
+		return mcp.NewToolResultError(p.Sprintf("не удалось выполнить поиск акций: %v", err)), nil
 	}
 
-	if len(stocks) == 0 {
-		return mcp.NewToolResultText("По запросу не найдено акций"), nil
+	if total == 0 {
+		return mcp.NewToolResultText(p.T("По запросу не найдено акций")), nil
 	}
 
-	// Формируем результат
-	result := fmt.Sprintf("Результаты поиска по запросу '%s':\n\n", query)
-	for i, stock := range stocks {
-		result += fmt.Sprintf("%d. %s (%s): %.2f ₽ (%.2f%%)\n",
-			i+1, stock.Ticker, stock.Name, stock.Price, stock.ChangePerc)
+	return s.renderResult(p, render.SearchStocks, render.StockList{
+		Query:  query,
+		Stocks: stocks,
+		Page:   render.NewPage(page, len(stocks), total),
+	})
+}
+
+// handleGetMarketBreadth обрабатывает запрос на получение ширины рынка
+func (s *Server) handleGetMarketBreadth(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	p := i18n.PrinterFrom(ctx)
+	var args struct {
+		Universe string `arg:"universe"`
+	}
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	breadth, err := s.stockService.GetMarketBreadth(ctx, args.Universe)
+	if err != nil {
+		return mcp.NewToolResultError(p.Sprintf("не удалось получить ширину рынка: %v", err)), nil
+	}
+
+	if breadth.Total == 0 {
+		return mcp.NewToolResultText(p.Sprintf("Нет данных по акциям универсума %s", breadth.Universe)), nil
 	}
 
-	return mcp.NewToolResultText(result), nil
+	return s.renderResult(p, render.MarketBreadth, breadth)
 }
 
 // Обработчики инструментов для новостей
 
 // handleGetTodayNews обрабатывает запрос на получение новостей за сегодня
 func (s *Server) handleGetTodayNews(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	limit := 0 // 0 означает все новости
-	if limitVal, ok := request.Params.Arguments["limit"].(float64); ok {
-		limit = int(limitVal)
+	p := i18n.PrinterFrom(ctx)
+	var args struct {
+		pageArgs
+		newsDetailArgs
 	}
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	page, detail := args.page(), args.detail()
 
-	news, err := s.newsService.GetTodayNews(ctx)
+	news, total, err := s.newsService.GetTodayNews(s.newsDetailContext(ctx, detail), page)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("не удалось получить новости: %v", err)), nil
+		return mcp.NewToolResultError(p.Sprintf("не удалось получить новости: %v", err)), nil
 	}
 
-	if len(news) == 0 {
-		return mcp.NewToolResultText("На сегодня нет финансовых новостей"), nil
+	if total == 0 {
+		return mcp.NewToolResultText(p.T("На сегодня нет финансовых новостей")), nil
+	}
+	news = s.prepareNews(ctx, detail, news)
+
+	return s.renderNewsList(p, render.TodayNews, render.NewsList{
+		Date: time.Now(),
+		News: news,
+		Page: render.NewPage(page, len(news), total),
+	})
+}
+
+// handleSearchNews обрабатывает запрос на поиск новостей по ключевому слову
+func (s *Server) handleSearchNews(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	p := i18n.PrinterFrom(ctx)
+	var args struct {
+		Keyword string `arg:"keyword,required"`
+		newsFilterArgs
+		pageArgs
+		newsDetailArgs
 	}
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	keyword, page, detail := args.Keyword, args.page(), args.detail()
 
-	// Применяем лимит, если он задан
-	if limit > 0 && limit < len(news) {
-		news = news[:limit]
+	filter, err := args.filter(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	news, total, err := s.newsService.SearchNewsByKeyword(s.newsDetailContext(ctx, detail), keyword, filter, page)
+	if err != nil {
+		return mcp.NewToolResultError(p.Sprintf("не удалось выполнить поиск новостей: %v", err)), nil
 	}
 
-	// Формируем результат
-	result := fmt.Sprintf("Финансовые новости за %s:\n\n", time.Now().Format("02.01.2006"))
-	for i, item := range news {
-		result += fmt.Sprintf("%d. %s\n", i+1, item.Title)
-		result += fmt.Sprintf("   %s\n", item.Description)
-		result += fmt.Sprintf("   Источник: %s\n", item.Source)
-		result += fmt.Sprintf("   Опубликовано: %s\n", item.PublishedAt.Format("15:04"))
-		result += fmt.Sprintf("   URL: %s\n\n", item.URL)
+	if total == 0 {
+		return mcp.NewToolResultText(p.Sprintf("По запросу '%s' не найдено новостей", keyword)), nil
 	}
+	news = s.prepareNews(ctx, detail, news)
 
-	return mcp.NewToolResultText(result), nil
+	return s.renderNewsList(p, render.SearchNews, render.NewsList{
+		Query: keyword,
+		News:  news,
+		Page:  render.NewPage(page, len(news), total),
+	})
 }
 
-// handleSearchNews обрабатывает запрос на поиск новостей по ключевому слову
-func (s *Server) handleSearchNews(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	keyword, ok := request.Params.Arguments["keyword"].(string)
-	if !ok {
-		return mcp.NewToolResultError("параметр keyword должен быть строкой"), nil
+// handleBackfillNews обрабатывает запрос на загрузку архива новостей
+func (s *Server) handleBackfillNews(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	p := i18n.PrinterFrom(ctx)
+	var args struct {
+		From string `arg:"from,required"`
+		To   string `arg:"to,required"`
+	}
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	news, err := s.newsService.SearchNewsByKeyword(ctx, keyword)
+	from, err := parseDateArg(ctx, "from", args.From, time.UTC)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("не удалось выполнить поиск новостей: %v", err)), nil
+		return mcp.NewToolResultError(err.Error()), nil
 	}
-
-	if len(news) == 0 {
-		return mcp.NewToolResultText(fmt.Sprintf("По запросу '%s' не найдено новостей", keyword)), nil
+	to, err := parseDateArg(ctx, "to", args.To, time.UTC)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	// Формируем результат
-	result := fmt.Sprintf("Результаты поиска новостей по запросу '%s':\n\n", keyword)
-	for i, item := range news {
-		result += fmt.Sprintf("%d. %s\n", i+1, item.Title)
-		result += fmt.Sprintf("   %s\n", item.Description)
-		result += fmt.Sprintf("   Источник: %s\n", item.Source)
-		result += fmt.Sprintf("   Опубликовано: %s\n", item.PublishedAt.Format("02.01.2006 15:04"))
-		result += fmt.Sprintf("   URL: %s\n\n", item.URL)
+	backfill, err := s.newsService.BackfillNews(ctx, from, to)
+	if err != nil {
+		return mcp.NewToolResultError(p.Sprintf("не удалось загрузить архив новостей: %v", err)), nil
 	}
 
-	return mcp.NewToolResultText(result), nil
+	return s.renderResult(p, render.NewsBackfill, backfill)
 }
 
 // handleGetNewsByTicker обрабатывает запрос на получение новостей по тикеру
 func (s *Server) handleGetNewsByTicker(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	ticker, ok := request.Params.Arguments["ticker"].(string)
-	if !ok {
-		return mcp.NewToolResultError("параметр ticker должен быть строкой"), nil
+	p := i18n.PrinterFrom(ctx)
+	var args struct {
+		Ticker string `arg:"ticker,required"`
+		newsDetailArgs
+	}
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
+	ticker, detail := args.Ticker, args.detail()
 
-	news, err := s.newsService.GetNewsForTicker(ctx, ticker)
+	news, err := s.newsService.GetNewsForTicker(s.newsDetailContext(ctx, detail), ticker)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("не удалось получить новости: %v", err)), nil
+		return mcp.NewToolResultError(p.Sprintf("не удалось получить новости: %v", err)), nil
 	}
 
 	if len(news) == 0 {
-		return mcp.NewToolResultText(fmt.Sprintf("Не найдено новостей, связанных с акцией %s", ticker)), nil
+		return mcp.NewToolResultText(p.Sprintf("Не найдено новостей, связанных с акцией %s", ticker)), nil
+	}
+	news = s.prepareNews(ctx, detail, news)
+
+	return s.renderNewsList(p, render.NewsByTicker, render.NewsList{
+		Ticker: ticker,
+		News:   news,
+		Page:   render.FullPage(len(news)),
+	})
+}
+
+// handleGetNewsFullText обрабатывает запрос на получение полного текста статьи
+func (s *Server) handleGetNewsFullText(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	p := i18n.PrinterFrom(ctx)
+	var args struct {
+		ID string `arg:"id,required"`
+	}
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	fullText, err := s.newsService.GetNewsFullText(ctx, args.ID)
+	if err != nil {
+		return mcp.NewToolResultError(p.Sprintf("не удалось получить полный текст новости: %v", err)), nil
+	}
+
+	return s.renderResult(p, render.NewsFullText, fullText)
+}
+
+// handleGetRelatedNews обрабатывает запрос на поиск связанных новостей
+func (s *Server) handleGetRelatedNews(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	p := i18n.PrinterFrom(ctx)
+	args := struct {
+		ID    string `arg:"id,required"`
+		Limit int    `arg:"limit" min:"1" max:"100"`
+	}{Limit: models.DefaultRelatedNews}
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	relations, err := s.newsService.GetRelatedNews(ctx, args.ID, args.Limit)
+	if err != nil {
+		return mcp.NewToolResultError(p.Sprintf("не удалось найти связанные новости: %v", err)), nil
+	}
+
+	if len(relations.Related) == 0 {
+		return mcp.NewToolResultText(p.Sprintf("Не найдено новостей, связанных с «%s»", relations.Origin.Title)), nil
 	}
 
-	// Формируем результат
-	result := fmt.Sprintf("Новости, связанные с акцией %s:\n\n", ticker)
-	for i, item := range news {
-		result += fmt.Sprintf("%d. %s\n", i+1, item.Title)
-		result += fmt.Sprintf("   %s\n", item.Description)
-		result += fmt.Sprintf("   Источник: %s\n", item.Source)
-		result += fmt.Sprintf("   Опубликовано: %s\n", item.PublishedAt.Format("02.01.2006 15:04"))
-		result += fmt.Sprintf("   URL: %s\n\n", item.URL)
+	return s.renderResult(p, render.RelatedNews, relations)
+}
+
+// handleGetNewsTimeline обрабатывает запрос на получение хроники новостей тикера
+func (s *Server) handleGetNewsTimeline(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	p := i18n.PrinterFrom(ctx)
+	args := struct {
+		Ticker    string `arg:"ticker,required"`
+		From      string `arg:"from"`
+		To        string `arg:"to"`
+		Headlines int    `arg:"headlines" min:"1" max:"20"`
+	}{Headlines: models.DefaultTimelineHeadlines}
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	to := time.Now().In(models.MoscowLocation)
+	if args.To != "" {
+		parsed, err := parseDateArg(ctx, "to", args.To, models.MoscowLocation)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		to = parsed
+	}
+	from := to.AddDate(0, 0, -models.DefaultNewsTimelineDays)
+	if args.From != "" {
+		parsed, err := parseDateArg(ctx, "from", args.From, models.MoscowLocation)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		from = parsed
+	}
+
+	timeline, err := s.newsService.GetNewsTimeline(ctx, args.Ticker, from, to, args.Headlines)
+	if err != nil {
+		return mcp.NewToolResultError(p.Sprintf("не удалось получить хронику новостей: %v", err)), nil
+	}
+
+	if timeline.Total == 0 {
+		return mcp.NewToolResultText(p.Sprintf("Не найдено новостей, связанных с акцией %s, за %s – %s",
+			timeline.Ticker, timeline.From.Format("02.01.2006"), timeline.To.Format("02.01.2006"))), nil
+	}
+
+	return s.renderResult(p, render.NewsTimeline, timeline)
+}
+
+// handleGetNewsSummary обрабатывает запрос на получение сводки новостей по темам
+func (s *Server) handleGetNewsSummary(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	p := i18n.PrinterFrom(ctx)
+	args := struct {
+		Headlines int `arg:"headlines" min:"1"`
+	}{Headlines: models.DefaultSummaryHeadlines}
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	summary, err := s.newsService.GetNewsSummary(ctx, args.Headlines)
+	if err != nil {
+		return mcp.NewToolResultError(p.Sprintf("не удалось получить сводку новостей: %v", err)), nil
+	}
+
+	if summary.Total == 0 {
+		return mcp.NewToolResultText(p.T("На сегодня новостей не найдено")), nil
 	}
 
-	return mcp.NewToolResultText(result), nil
+	return s.renderResult(p, render.NewsSummary, summary)
 }
 
 // Обработчики шаблонов
@@ -411,7 +1294,7 @@ func (s *Server) handleStockAnalysisPrompt(ctx context.Context, request mcp.GetP
 	}
 
 	// Получаем информацию об акции
-	stock, err := s.stockService.GetStockInfo(ctx, ticker)
+	stock, err := s.stockService.GetStockInfo(ctx, ticker, models.TradingBoard{})
 	if err != nil {
 		return nil, fmt.Errorf("не удалось получить информацию об акции: %w", err)
 	}
@@ -435,7 +1318,7 @@ func (s *Server) handleStockAnalysisPrompt(ctx context.Context, request mcp.GetP
 1. Текущее состояние и динамику цены
 2. Технический анализ (если возможно)
 3. Новостной фон (по предоставленным новостям)
-4. Перспективы и возможные сценарии развития`,
+4. Перспективы и возможные сценарии развития с учетом предстоящих корпоративных событий и целевых цен аналитиков, если они указаны`,
 		stock.Ticker, stock.Name,
 		stock.Price,
 		stock.Change, stock.ChangePerc,
@@ -455,6 +1338,22 @@ func (s *Server) handleStockAnalysisPrompt(ctx context.Context, request mcp.GetP
 		newsContent += "Новости не найдены.\n"
 	}
 
+	// Предстоящие корпоративные события — возможные катализаторы движения цены
+	if s.eventService != nil {
+		from := time.Now()
+		events, err := s.eventService.GetEventsByTicker(ctx, ticker, from, from.AddDate(0, 0, models.DefaultTickerEventsHorizonDays))
+		if err != nil {
+			log.Printf("ПРЕДУПРЕЖДЕНИЕ: не удалось получить корпоративные события для акции %s: %v", ticker, err)
+		} else if len(events) > 0 {
+			newsContent += "\n" + formatCorporateEvents(i18n.PrinterFrom(ctx), fmt.Sprintf("Предстоящие корпоративные события %s", stock.Ticker), events, false)
+		}
+	}
+
+	// Целевые цены аналитиков и потенциал до консенсуса
+	if targets := s.priceTargetsContext(ctx, []string{stock.Ticker}); targets != "" {
+		newsContent += "\n" + targets
+	}
+
 	return mcp.NewGetPromptResult(
 		fmt.Sprintf("Анализ акции %s", ticker),
 		[]mcp.PromptMessage{
@@ -472,46 +1371,45 @@ func (s *Server) handleStockAnalysisPrompt(ctx context.Context, request mcp.GetP
 
 // handleMarketOverviewPrompt обрабатывает запрос на шаблон обзора рынка
 func (s *Server) handleMarketOverviewPrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
-	// Получаем топ растущих акций
-	topGainers, err := s.stockService.GetMOEXTopGainers(ctx, 5)
+	universe := request.Params.Arguments["universe"]
+	overview, err := s.stockService.GetMarketOverview(ctx, universe, s.watchlistTickers(ctx, universe), models.DefaultOverviewLeaders)
 	if err != nil {
-		return nil, fmt.Errorf("не удалось получить список растущих акций: %w", err)
+		return nil, fmt.Errorf("не удалось получить обзор рынка: %w", err)
 	}
 
-	// Получаем топ падающих акций
-	topLosers, err := s.stockService.GetMOEXTopLosers(ctx, 5)
-	if err != nil {
-		return nil, fmt.Errorf("не удалось получить список падающих акций: %w", err)
-	}
-
-	// Получаем новости за сегодня
-	todayNews, err := s.newsService.GetTodayNews(ctx)
+	// Получаем новости за сегодня, ограничивая их количество для обзора
+	todayNews, _, err := s.newsService.GetTodayNews(ctx, models.Pagination{Limit: 10})
 	if err != nil {
 		log.Printf("ПРЕДУПРЕЖДЕНИЕ: не удалось получить новости: %v", err)
 		todayNews = []models.News{} // Пустой список, если не удалось получить новости
 	}
 
-	// Ограничиваем количество новостей для обзора
-	newsLimit := 10
-	if len(todayNews) > newsLimit {
-		todayNews = todayNews[:newsLimit]
-	}
-
 	// Формируем системное сообщение
 	systemMessage := `Ты - опытный финансовый аналитик, специализирующийся на российском рынке акций.
 Подготовь краткий обзор состояния рынка на сегодня, используя предоставленные данные.
 Включи в обзор:
-1. Общую оценку настроения рынка
+1. Общую оценку настроения рынка с учетом его ширины
 2. Анализ лидеров роста и падения
-3. Обзор ключевых новостей и их влияние на рынок
+3. Обзор ключевых новостей по секторам и их влияние на рынок
 4. Краткий прогноз на ближайшую перспективу`
 
 	// Формируем контент с данными о рынке
-	marketContent := "Данные о российском рынке акций (MOEX) на сегодня:\n\n"
+	marketContent := fmt.Sprintf("Данные о российском рынке акций (MOEX) на сегодня, %s:\n\n", overviewScopeName(overview))
+
+	// Добавляем ширину рынка по выборке
+	breadth := overview.Breadth
+	marketContent += "Ширина рынка:\n"
+	marketContent += fmt.Sprintf("Акций: %d, растут: %d, падают: %d, без изменений: %d\n",
+		breadth.Total, breadth.Advancers, breadth.Decliners, breadth.Unchanged)
+	marketContent += fmt.Sprintf("Среднее изменение: %.2f%%, суммарный объем торгов: %d\n", breadth.AvgChangePerc, breadth.TotalVolume)
+	if breadth.Index != "" {
+		marketContent += fmt.Sprintf("Изменение с учетом весов индекса %s: %.2f%%\n", breadth.Index, breadth.WeightedChangePerc)
+	}
+	marketContent += "\n"
 
 	// Добавляем информацию о топ растущих акциях
 	marketContent += "Лидеры роста:\n"
-	for i, stock := range topGainers {
+	for i, stock := range overview.Gainers {
 		marketContent += fmt.Sprintf("%d. %s (%s): %.2f ₽ (%.2f%%)\n",
 			i+1, stock.Ticker, stock.Name, stock.Price, stock.ChangePerc)
 	}
@@ -519,12 +1417,50 @@ func (s *Server) handleMarketOverviewPrompt(ctx context.Context, request mcp.Get
 
 	// Добавляем информацию о топ падающих акциях
 	marketContent += "Лидеры падения:\n"
-	for i, stock := range topLosers {
+	for i, stock := range overview.Losers {
 		marketContent += fmt.Sprintf("%d. %s (%s): %.2f ₽ (%.2f%%)\n",
 			i+1, stock.Ticker, stock.Name, stock.Price, stock.ChangePerc)
 	}
 	marketContent += "\n"
 
+	// Добавляем ключевую ставку: от нее зависят оценки акций и доходность облигаций
+	if s.cbrService != nil {
+		if summary, err := s.cbrService.GetKeyRate(ctx); err != nil {
+			log.Printf("ПРЕДУПРЕЖДЕНИЕ: не удалось получить ключевую ставку: %v", err)
+		} else {
+			marketContent += formatKeyRate(i18n.PrinterFrom(ctx), summary) + "\n"
+		}
+	}
+
+	// Добавляем цены сырья, от которых зависят нефтегазовые и металлургические эмитенты
+	if s.commodityService != nil {
+		if quotes, err := s.commodityService.GetCommodityPrices(ctx); err != nil {
+			log.Printf("ПРЕДУПРЕЖДЕНИЕ: не удалось получить цены сырьевых товаров: %v", err)
+		} else {
+			marketContent += formatCommodityQuotes(i18n.PrinterFrom(ctx), quotes) + "\n"
+		}
+	}
+
+	// Криптовалюты — только если модуль включен в конфигурации
+	if s.cryptoService != nil {
+		if quotes, err := s.cryptoService.GetCryptoPrices(ctx); err != nil {
+			log.Printf("ПРЕДУПРЕЖДЕНИЕ: не удалось получить котировки криптовалют: %v", err)
+		} else {
+			marketContent += formatCryptoQuotes(i18n.PrinterFrom(ctx), quotes) + "\n"
+		}
+	}
+
+	// Добавляем сводку новостей по темам, чтобы обзор охватывал все секторы
+	if summary, err := s.newsService.GetNewsSummary(ctx, models.DefaultSummaryHeadlines); err != nil {
+		log.Printf("ПРЕДУПРЕЖДЕНИЕ: не удалось получить сводку новостей: %v", err)
+	} else if summary.Total > 0 {
+		if text, err := s.renderer.Render(i18n.PrinterFrom(ctx), render.NewsSummary, summary); err != nil {
+			log.Printf("ПРЕДУПРЕЖДЕНИЕ: не удалось оформить сводку новостей: %v", err)
+		} else {
+			marketContent += text + "\n"
+		}
+	}
+
 	// Добавляем информацию о ключевых новостях
 	marketContent += "Ключевые новости за сегодня:\n"
 	if len(todayNews) > 0 {
@@ -552,10 +1488,61 @@ func (s *Server) handleMarketOverviewPrompt(ctx context.Context, request mcp.Get
 	), nil
 }
 
+// overviewUniverseDescription описывает аргумент universe шаблона обзора рынка
+func (s *Server) overviewUniverseDescription() string {
+	names := make([]string, 0, len(s.stockService.GetUniverses()))
+	for _, universe := range s.stockService.GetUniverses() {
+		names = append(names, universe.Name)
+	}
+
+	description := fmt.Sprintf("Выборка акций для обзора (по умолчанию full): универсум (%s)", strings.Join(names, ", "))
+	if s.watchlistService != nil {
+		description += ", имя списка наблюдения"
+	}
+	return description + " или сектор. Универсум имеет приоритет над списком наблюдения, список — над сектором"
+}
+
+// watchlistTickers возвращает тикеры списка наблюдения с именем name, если name не совпадает
+// с универсумом из конфигурации. Пустой результат означает, что name — универсум или сектор
+func (s *Server) watchlistTickers(ctx context.Context, name string) []string {
+	if name == "" || s.watchlistService == nil {
+		return nil
+	}
+	for _, universe := range s.stockService.GetUniverses() {
+		if strings.EqualFold(universe.Name, name) {
+			return nil
+		}
+	}
+
+	items, err := s.watchlistService.GetWatchlist(ctx, name)
+	if err != nil {
+		log.Printf("ПРЕДУПРЕЖДЕНИЕ: не удалось получить список наблюдения %s: %v", name, err)
+		return nil
+	}
+
+	tickers := make([]string, 0, len(items))
+	for _, item := range items {
+		tickers = append(tickers, item.Ticker)
+	}
+	return tickers
+}
+
+// overviewScopeName описывает выборку, по которой построен обзор рынка
+func overviewScopeName(overview *models.MarketOverview) string {
+	switch overview.Scope {
+	case models.OverviewScopeSector:
+		return fmt.Sprintf("сектор %s", overview.Name)
+	case models.OverviewScopeTickers:
+		return fmt.Sprintf("список наблюдения %s", overview.Name)
+	default:
+		return fmt.Sprintf("универсум %s", overview.Name)
+	}
+}
+
 // handleNewsAnalysisPrompt обрабатывает запрос на шаблон анализа новостей
 func (s *Server) handleNewsAnalysisPrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
 	// Получаем новости за сегодня
-	todayNews, err := s.newsService.GetTodayNews(ctx)
+	todayNews, _, err := s.newsService.GetTodayNews(ctx, models.Pagination{Limit: models.MaxPageLimit})
 	if err != nil {
 		return nil, fmt.Errorf("не удалось получить новости: %w", err)
 	}
@@ -603,6 +1590,205 @@ func (s *Server) handleNewsAnalysisPrompt(ctx context.Context, request mcp.GetPr
 	), nil
 }
 
+// buildInstructions возвращает инструкции для клиента: из конфигурации или сформированные по умолчанию на языке p
+func buildInstructions(cfg *config.Config, p i18n.Printer) string {
+	if cfg.Server.Instructions != "" {
+		return cfg.Server.Instructions
+	}
+
+	instructions := p.T("Сервер предоставляет данные о российском рынке акций (Московская биржа, MOEX) и финансовые новости на русском языке.\n")
+
+	// Задержка указывается только для данных, срок кэширования которых задан
+	var freshness []string
+	if cfg.Cache.StocksTTL > 0 {
+		freshness = append(freshness, p.Sprintf("котировки — до %s", cfg.Cache.StocksTTL))
+	}
+	if cfg.Cache.NewsTTL > 0 {
+		freshness = append(freshness, p.Sprintf("новости — до %s", cfg.Cache.NewsTTL))
+	}
+	if len(freshness) > 0 {
+		instructions += p.Sprintf("Данные обновляются с задержкой: %s.\n", strings.Join(freshness, ", "))
+	}
+	instructions += p.T("Цены указаны в рублях. Используй инструменты для получения актуальных данных и не выдумывай котировки.")
+
+	return instructions
+}
+
+// enrichNews дополняет новости результатами обогащения, если оно включено
+func (s *Server) enrichNews(ctx context.Context, news []models.News) []models.News {
+	if s.enrichmentService == nil {
+		return news
+	}
+	return s.enrichmentService.EnrichNews(ctx, news)
+}
+
+// newsDetailContext задает подробность, с которой новости читаются из хранилища. Резюме обогащения
+// составляются по полному тексту, поэтому при включенном обогащении для summary он тоже загружается
+func (s *Server) newsDetailContext(ctx context.Context, detail models.NewsDetail) context.Context {
+	if detail == models.NewsDetailSummary && s.enrichmentService != nil && s.enrichmentService.Enabled(ctx) {
+		detail = models.NewsDetailFull
+	}
+	return repositories.WithNewsDetail(ctx, detail)
+}
+
+// prepareNews обогащает новости, если их подробность это предполагает, и убирает поля, не входящие в нее
+func (s *Server) prepareNews(ctx context.Context, detail models.NewsDetail, news []models.News) []models.News {
+	if detail != models.NewsDetailHeadline {
+		news = s.enrichNews(ctx, news)
+	}
+	return detail.Strip(news)
+}
+
+// renderResult оформляет результат инструмента шаблоном name
+func (s *Server) renderResult(p i18n.Printer, name string, data interface{}) (*mcp.CallToolResult, error) {
+	text, err := s.renderer.Render(p, name, data)
+	if err != nil {
+		return mcp.NewToolResultError(p.Sprintf("не удалось оформить результат: %v", err)), nil
+	}
+	return mcp.NewToolResultText(text), nil
+}
+
+// newsFilterArgs аргументы фильтра новостей
+type newsFilterArgs struct {
+	From     string   `arg:"from"`
+	To       string   `arg:"to"`
+	Sources  []string `arg:"sources"`
+	Language string   `arg:"language" enum:"ru|en|de|fr|es|it|zh"`
+}
+
+// filter возвращает фильтр новостей по аргументам
+func (a newsFilterArgs) filter(ctx context.Context) (models.NewsFilter, error) {
+	filter := models.NewsFilter{Language: a.Language}
+
+	if a.From != "" {
+		parsed, err := parseDateArg(ctx, "from", a.From, time.UTC)
+		if err != nil {
+			return filter, err
+		}
+		filter.From = parsed
+	}
+
+	// Конец периода включает весь указанный день
+	if a.To != "" {
+		parsed, err := parseDateArg(ctx, "to", a.To, time.UTC)
+		if err != nil {
+			return filter, err
+		}
+		filter.To = parsed.Add(24 * time.Hour)
+	}
+
+	for _, source := range a.Sources {
+		if source != "" {
+			filter.Sources = append(filter.Sources, source)
+		}
+	}
+
+	return filter, nil
+}
+
+// limitArg описывает аргумент limit для инструментов, возвращающих списки
+func (s *Server) limitArg(items string) mcp.ToolOption {
+	return mcp.WithNumber("limit",
+		mcp.Description(s.printer.Sprintf("Количество %s на странице (по умолчанию %d, максимум %d)",
+			s.printer.T(items), models.DefaultPageLimit, models.MaxPageLimit)),
+	)
+}
+
+// offsetArg описывает аргумент offset для инструментов, возвращающих списки
+func (s *Server) offsetArg() mcp.ToolOption {
+	return mcp.WithNumber("offset",
+		mcp.Description(s.printer.T("Сколько первых результатов пропустить (по умолчанию 0)")),
+	)
+}
+
+// newsDetailArgs аргумент подробности новостей
+type newsDetailArgs struct {
+	Detail string `arg:"detail" enum:"headline|summary|full"`
+}
+
+// detail возвращает подробность новостей; по умолчанию — summary
+func (a newsDetailArgs) detail() models.NewsDetail {
+	if a.Detail == "" {
+		return models.NewsDetailSummary
+	}
+	return models.NewsDetail(a.Detail)
+}
+
+// newsDetailArg описывает аргумент detail для инструментов новостей
+func (s *Server) newsDetailArg() mcp.ToolOption {
+	return mcp.WithString("detail",
+		mcp.Description(s.printer.T("Подробность новостей: headline — только заголовки, summary — с описанием (по умолчанию), full — с полным текстом")),
+		mcp.Enum(string(models.NewsDetailHeadline), string(models.NewsDetailSummary), string(models.NewsDetailFull)),
+	)
+}
+
+// pageArgs аргументы постраничной выдачи; граница limit совпадает с models.MaxPageLimit
+type pageArgs struct {
+	Limit  int `arg:"limit" min:"1" max:"100"`
+	Offset int `arg:"offset" min:"0"`
+}
+
+// page возвращает параметры постраничной выдачи с размером страницы по умолчанию
+func (a pageArgs) page() models.Pagination {
+	return models.Pagination{Limit: a.Limit, Offset: a.Offset}.WithDefaults()
+}
+
+// dryRunArg описывает аргумент dry_run для инструментов, изменяющих данные
+func (s *Server) dryRunArg() mcp.ToolOption {
+	return mcp.WithBoolean("dry_run",
+		mcp.Description(s.printer.T("Только показать, что изменится, ничего не сохраняя (по умолчанию false). Позволяет подтвердить изменение с пользователем перед применением")),
+	)
+}
+
+// sparklineArg описывает аргумент, включающий спарклайн цен в результате
+func (s *Server) sparklineArg() mcp.ToolOption {
+	return mcp.WithBoolean("sparkline",
+		mcp.Description(s.printer.T("Добавить спарклайн — строку из блочных символов ▁▂▃▄▅▆▇█, показывающую движение цены закрытия (по умолчанию true)")),
+	)
+}
+
+// boardArg описывает аргумент режима торгов MOEX
+func (s *Server) boardArg() mcp.ToolOption {
+	return mcp.WithString("board",
+		mcp.Description(s.printer.T("Режим торгов MOEX: TQBR — акции, TQTF — биржевые фонды, FQBR — иностранные акции (по умолчанию основной режим бумаги)")),
+	)
+}
+
+// marketArg описывает аргумент рынка MOEX, к которому относится режим торгов
+func (s *Server) marketArg() mcp.ToolOption {
+	return mcp.WithString("market",
+		mcp.Description(s.printer.T("Рынок MOEX: shares, foreignshares или bonds (по умолчанию определяется по режиму торгов)")),
+		mcp.Enum(models.Markets...),
+	)
+}
+
+// boardArgs аргументы режима торгов и рынка; без них используется основной режим бумаги
+type boardArgs struct {
+	Board  string `arg:"board"`
+	Market string `arg:"market"`
+}
+
+// tradingBoard проверяет режим торгов и рынок; рынок уже проверен перечислением аргумента
+func (a boardArgs) tradingBoard(p i18n.Printer) (models.TradingBoard, error) {
+	if board := strings.ToUpper(strings.TrimSpace(a.Board)); board != "" && !models.IsBoardCode(board) {
+		return models.TradingBoard{}, p.Errorf("некорректный код режима торгов %s: ожидаются латинские буквы и цифры, например TQBR", a.Board)
+	}
+	return models.ParseTradingBoard(a.Board, a.Market)
+}
+
+// sparklineArgs аргумент спарклайна; по умолчанию спарклайн включен
+type sparklineArgs struct {
+	Sparkline bool `arg:"sparkline"`
+}
+
+// dryRunArgs аргумент предпросмотра для инструментов, изменяющих данные
+type dryRunArgs struct {
+	DryRun bool `arg:"dry_run"`
+}
+
+// dryRunNotice предупреждение к результату предпросмотра
+const dryRunNotice = "Предпросмотр (dry_run): изменения не сохранены. Повторите вызов без dry_run, чтобы применить их.\n\n"
+
 // formatTickersList форматирует список тикеров
 func formatTickersList(tickers []string) string {
 	result := ""
@@ -0,0 +1,48 @@
+package mcp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/config"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/i18n"
+)
+
+func TestBuildInstructions(t *testing.T) {
+	tests := []struct {
+		name      string
+		lang      i18n.Language
+		stocksTTL time.Duration
+		newsTTL   time.Duration
+		want      string // Предложение о задержке данных; пусто — предложения нет
+	}{
+		{"оба срока", i18n.Russian, 15 * time.Minute, 30 * time.Minute, "Данные обновляются с задержкой: котировки — до 15m0s, новости — до 30m0s.\n"},
+		{"без срока котировок", i18n.Russian, 0, 30 * time.Minute, "Данные обновляются с задержкой: новости — до 30m0s.\n"},
+		{"без срока новостей", i18n.Russian, 15 * time.Minute, 0, "Данные обновляются с задержкой: котировки — до 15m0s.\n"},
+		{"без сроков", i18n.Russian, 0, 0, ""},
+		{"английский", i18n.English, 0, 30 * time.Minute, "Data is delayed: news by up to 30m0s.\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cfg config.Config
+			cfg.Cache.StocksTTL, cfg.Cache.NewsTTL = tt.stocksTTL, tt.newsTTL
+			p := i18n.NewPrinter(tt.lang)
+
+			got := buildInstructions(&cfg, p)
+			intro := p.T("Сервер предоставляет данные о российском рынке акций (Московская биржа, MOEX) и финансовые новости на русском языке.\n")
+			rules := p.T("Цены указаны в рублях. Используй инструменты для получения актуальных данных и не выдумывай котировки.")
+			if want := intro + tt.want + rules; got != want {
+				t.Fatalf("инструкции:\n%q\nожидалось:\n%q", got, want)
+			}
+		})
+	}
+
+	t.Run("из конфигурации", func(t *testing.T) {
+		var cfg config.Config
+		cfg.Server.Instructions = "Свои инструкции"
+		if got := buildInstructions(&cfg, i18n.NewPrinter(i18n.Russian)); got != "Свои инструкции" {
+			t.Fatalf("инструкции = %q", got)
+		}
+	})
+}
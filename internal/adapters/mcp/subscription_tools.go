@@ -0,0 +1,299 @@
+package mcp
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/i18n"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// quoteSubscriptions подписки сессий клиентов на котировки. Подписки хранятся в памяти
+// и удаляются вместе с сессией
+type quoteSubscriptions struct {
+	mu       sync.Mutex
+	sessions map[string]*sessionQuotes
+}
+
+// sessionQuotes подписки одной сессии
+type sessionQuotes struct {
+	session server.ClientSession
+	// printer язык уведомлений: язык последнего вызова subscribe_quotes
+	printer i18n.Printer
+	tickers map[string]*quoteSubscription
+}
+
+// quoteSubscription подписка на котировки одной бумаги
+type quoteSubscription struct {
+	minChangePerc float64
+	// lastPrice цена при подписке или в последнем уведомлении, от нее считается изменение
+	lastPrice float64
+}
+
+func newQuoteSubscriptions() *quoteSubscriptions {
+	return &quoteSubscriptions{sessions: make(map[string]*sessionQuotes)}
+}
+
+// dropSession удаляет подписки закрытой сессии
+func (q *quoteSubscriptions) dropSession(_ context.Context, session server.ClientSession) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	delete(q.sessions, session.SessionID())
+}
+
+// registerSubscriptionTools регистрирует инструменты подписки на котировки
+func (s *Server) registerSubscriptionTools() {
+	subscribeQuotesTool := mcp.NewTool("subscribe_quotes",
+		mcp.WithDescription(s.printer.Sprintf("Подписаться на изменения цен акций: сервер опрашивает котировки каждые %v и присылает уведомление (notifications/message, logger quotes), когда цена изменилась с прошлого уведомления на заданный процент. Подписка действует до unsubscribe_quotes или закрытия сессии", s.config.Subscriptions.PollInterval)),
+		mcp.WithArray("tickers",
+			mcp.Required(),
+			mcp.Description(s.printer.Sprintf("Тикеры акций (например, SBER, GAZP); в подписках сессии не больше %d бумаг", s.config.Subscriptions.MaxTickers)),
+			mcp.Items(map[string]interface{}{"type": "string"}),
+		),
+		mcp.WithNumber("min_change_perc",
+			mcp.Description(s.printer.Sprintf("Изменение цены с прошлого уведомления в процентах, о котором нужно сообщать (по умолчанию %.1f%%; 0 — о любом изменении)", s.config.Subscriptions.DefaultMinChangePerc)),
+		),
+	)
+
+	s.addTool(subscribeQuotesTool, s.handleSubscribeQuotes, sourceMOEX)
+
+	unsubscribeQuotesTool := mcp.NewTool("unsubscribe_quotes",
+		mcp.WithDescription(s.printer.T("Отменить подписку на изменения цен акций")),
+		mcp.WithArray("tickers",
+			mcp.Description(s.printer.T("Тикеры акций (по умолчанию все подписки сессии)")),
+			mcp.Items(map[string]interface{}{"type": "string"}),
+		),
+	)
+
+	s.addTool(unsubscribeQuotesTool, s.handleUnsubscribeQuotes)
+}
+
+// handleSubscribeQuotes обрабатывает запрос на подписку на котировки
+func (s *Server) handleSubscribeQuotes(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	p := i18n.PrinterFrom(ctx)
+	args := struct {
+		Tickers       []string `arg:"tickers,required" min:"1"`
+		MinChangePerc float64  `arg:"min_change_perc" min:"0" max:"100"`
+	}{MinChangePerc: s.config.Subscriptions.DefaultMinChangePerc}
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	session := server.ClientSessionFromContext(ctx)
+	if session == nil {
+		return mcp.NewToolResultError(p.T("подписки доступны только в сессии MCP, которая принимает уведомления")), nil
+	}
+
+	tickers := make([]string, 0, len(args.Tickers))
+	for _, ticker := range args.Tickers {
+		tickers = append(tickers, models.NormalizeTicker(ticker))
+	}
+
+	// Текущие цены служат отправной точкой: первое уведомление придет после изменения от них
+	stocks, err := s.stockService.GetMultipleStocks(ctx, tickers)
+	if err != nil {
+		return mcp.NewToolResultError(p.Sprintf("не удалось получить котировки: %v", err)), nil
+	}
+	prices := make(map[string]float64, len(stocks))
+	for _, stock := range stocks {
+		prices[stock.Ticker] = stock.Price
+	}
+	var missing []string
+	for _, ticker := range tickers {
+		if _, ok := prices[ticker]; !ok {
+			missing = append(missing, ticker)
+		}
+	}
+	if len(missing) > 0 {
+		return mcp.NewToolResultError(p.Sprintf("не найдены котировки: %s", strings.Join(missing, ", "))), nil
+	}
+
+	q := s.quotes
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	subscriptions, ok := q.sessions[session.SessionID()]
+	if !ok {
+		subscriptions = &sessionQuotes{session: session, tickers: make(map[string]*quoteSubscription)}
+	}
+	added := 0
+	for _, ticker := range tickers {
+		if _, ok := subscriptions.tickers[ticker]; !ok {
+			added++
+		}
+	}
+	if limit := s.config.Subscriptions.MaxTickers; limit > 0 && len(subscriptions.tickers)+added > limit {
+		return mcp.NewToolResultError(p.Sprintf("в подписках сессии не может быть больше %d бумаг, сейчас %d: отмените часть подписок через unsubscribe_quotes",
+			limit, len(subscriptions.tickers))), nil
+	}
+
+	for _, ticker := range tickers {
+		subscriptions.tickers[ticker] = &quoteSubscription{minChangePerc: args.MinChangePerc, lastPrice: prices[ticker]}
+	}
+	subscriptions.printer = p
+	q.sessions[session.SessionID()] = subscriptions
+
+	result := p.Sprintf("Подписка оформлена: %s. Уведомление придет при изменении цены на %.2f%% и больше, котировки опрашиваются каждые %v\n\n",
+		strings.Join(tickers, ", "), args.MinChangePerc, s.config.Subscriptions.PollInterval)
+	result += formatQuoteSubscriptions(p, subscriptions)
+	return mcp.NewToolResultText(result), nil
+}
+
+// handleUnsubscribeQuotes обрабатывает запрос на отмену подписки на котировки
+func (s *Server) handleUnsubscribeQuotes(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	p := i18n.PrinterFrom(ctx)
+	var args struct {
+		Tickers []string `arg:"tickers"`
+	}
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	session := server.ClientSessionFromContext(ctx)
+	if session == nil {
+		return mcp.NewToolResultError(p.T("подписки доступны только в сессии MCP, которая принимает уведомления")), nil
+	}
+
+	q := s.quotes
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	subscriptions, ok := q.sessions[session.SessionID()]
+	if !ok || len(subscriptions.tickers) == 0 {
+		return mcp.NewToolResultText(p.T("Подписок на котировки нет")), nil
+	}
+
+	var removed []string
+	if len(args.Tickers) == 0 {
+		for ticker := range subscriptions.tickers {
+			removed = append(removed, ticker)
+		}
+		sort.Strings(removed)
+	} else {
+		for _, ticker := range args.Tickers {
+			ticker = models.NormalizeTicker(ticker)
+			if _, ok := subscriptions.tickers[ticker]; ok {
+				removed = append(removed, ticker)
+			}
+		}
+	}
+	if len(removed) == 0 {
+		return mcp.NewToolResultText(p.T("Подписок на указанные бумаги нет\n\n") + formatQuoteSubscriptions(p, subscriptions)), nil
+	}
+
+	for _, ticker := range removed {
+		delete(subscriptions.tickers, ticker)
+	}
+	if len(subscriptions.tickers) == 0 {
+		delete(q.sessions, session.SessionID())
+	}
+
+	return mcp.NewToolResultText(p.Sprintf("Подписка отменена: %s\n\n", strings.Join(removed, ", ")) +
+		formatQuoteSubscriptions(p, subscriptions)), nil
+}
+
+// SubscribedTickers возвращает бумаги подписок всех сессий для опроса котировок
+func (s *Server) SubscribedTickers() []string {
+	s.quotes.mu.Lock()
+	defer s.quotes.mu.Unlock()
+
+	seen := make(map[string]bool)
+	tickers := make([]string, 0)
+	for _, subscriptions := range s.quotes.sessions {
+		for ticker := range subscriptions.tickers {
+			if !seen[ticker] {
+				seen[ticker] = true
+				tickers = append(tickers, ticker)
+			}
+		}
+	}
+	sort.Strings(tickers)
+	return tickers
+}
+
+// PublishQuotes сравнивает свежие котировки с ценами прошлых уведомлений и отправляет подписанным сессиям
+// уведомления об изменениях, достигших порога подписки
+func (s *Server) PublishQuotes(stocks []models.Stock) {
+	byTicker := make(map[string]models.Stock, len(stocks))
+	for _, stock := range stocks {
+		byTicker[stock.Ticker] = stock
+	}
+
+	s.quotes.mu.Lock()
+	defer s.quotes.mu.Unlock()
+
+	for _, subscriptions := range s.quotes.sessions {
+		if !subscriptions.session.Initialized() {
+			continue
+		}
+		for ticker, subscription := range subscriptions.tickers {
+			stock, ok := byTicker[ticker]
+			if !ok || stock.Price <= 0 {
+				continue
+			}
+			if subscription.lastPrice <= 0 {
+				subscription.lastPrice = stock.Price
+				continue
+			}
+
+			changePerc := (stock.Price - subscription.lastPrice) / subscription.lastPrice * 100
+			if stock.Price == subscription.lastPrice || math.Abs(changePerc) < subscription.minChangePerc {
+				continue
+			}
+
+			notification := mcp.JSONRPCNotification{
+				JSONRPC: mcp.JSONRPC_VERSION,
+				Notification: mcp.Notification{
+					Method: "notifications/message",
+					Params: mcp.NotificationParams{AdditionalFields: map[string]any{
+						"level":  "info",
+						"logger": "quotes",
+						"data": map[string]any{
+							"ticker":          ticker,
+							"price":           stock.Price,
+							"previous_price":  subscription.lastPrice,
+							"change_perc":     changePerc,
+							"day_change_perc": stock.ChangePerc,
+							"message": subscriptions.printer.Sprintf("%s: %.2f (%+.2f%% с прошлого уведомления, %+.2f%% за день)",
+								ticker, stock.Price, changePerc, stock.ChangePerc),
+						},
+					}},
+				},
+			}
+
+			// Канал уведомлений сессии не блокирует опрос: если клиент не успевает их читать,
+			// цена не запоминается и изменение попадет в следующее уведомление
+			select {
+			case subscriptions.session.NotificationChannel() <- notification:
+				subscription.lastPrice = stock.Price
+			default:
+			}
+		}
+	}
+}
+
+// formatQuoteSubscriptions форматирует список подписок сессии
+func formatQuoteSubscriptions(p i18n.Printer, subscriptions *sessionQuotes) string {
+	if len(subscriptions.tickers) == 0 {
+		return p.T("Подписок на котировки больше нет")
+	}
+
+	tickers := make([]string, 0, len(subscriptions.tickers))
+	for ticker := range subscriptions.tickers {
+		tickers = append(tickers, ticker)
+	}
+	sort.Strings(tickers)
+
+	result := p.Sprintf("Подписки сессии (%d):\n", len(tickers))
+	for _, ticker := range tickers {
+		subscription := subscriptions.tickers[ticker]
+		result += p.Sprintf("- %s: порог %.2f%%, цена отсчета %.2f\n", ticker, subscription.minChangePerc, subscription.lastPrice)
+	}
+	return result
+}
@@ -0,0 +1,93 @@
+package mcp
+
+import (
+	"context"
+	"strings"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/i18n"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Аргументы инструментов, значения которых распознаются как тикеры
+const (
+	tickerArgName  = "ticker"
+	tickersArgName = "tickers"
+)
+
+// symbolMiddleware приводит аргументы ticker и tickers всех инструментов к тикерам бумаг до вызова обработчика:
+// «сбер», «Сбербанк», «sber», ISIN RU0009029540 и FIGI BBG004730N88 превращаются в SBER. Если бумага найдена
+// по приблизительному совпадению или идентификатору, к результату добавляется строка о том, как распознан тикер,
+// чтобы агент мог заметить ошибку
+func (s *Server) symbolMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		p := i18n.PrinterFrom(ctx)
+		arguments := make(map[string]interface{}, len(request.Params.Arguments))
+		for name, value := range request.Params.Arguments {
+			arguments[name] = value
+		}
+
+		var notes []string
+		resolve := func(input string) (string, *mcp.CallToolResult, error) {
+			resolution, err := s.symbolService.ResolveTicker(ctx, input)
+			if err != nil {
+				return "", nil, err
+			}
+			switch {
+			case len(resolution.Candidates) > 0:
+				return "", mcp.NewToolResultError(p.Sprintf("не удалось однозначно определить бумагу «%s», подходят: %s",
+					input, strings.Join(resolution.Candidates, ", "))), nil
+			case !resolution.Resolved() && models.IsFIGI(input):
+				return "", mcp.NewToolResultError(p.Sprintf("FIGI %s не соответствует бумаге, торгующейся на рынке акций MOEX", strings.ToUpper(strings.TrimSpace(input)))), nil
+			case !resolution.Resolved() && models.IsISIN(input):
+				return "", mcp.NewToolResultError(p.Sprintf("бумага с ISIN %s не торгуется на рынке акций MOEX", strings.ToUpper(strings.TrimSpace(input)))), nil
+			case !resolution.Resolved():
+				return "", mcp.NewToolResultError(p.Sprintf("не удалось найти бумагу «%s»: укажите тикер (например, SBER) или название компании", input)), nil
+			case resolution.Match == models.SymbolMatchFuzzy, resolution.Match == models.SymbolMatchISIN, resolution.Match == models.SymbolMatchFIGI:
+				notes = append(notes, p.Sprintf("«%s» распознано как %s", input, resolution.Ticker))
+			}
+			return resolution.Ticker, nil, nil
+		}
+
+		// Значения неверного типа остаются как есть: о них сообщит проверка аргументов обработчика
+		if input, ok := arguments[tickerArgName].(string); ok && strings.TrimSpace(input) != "" {
+			ticker, failure, err := resolve(input)
+			if failure != nil || err != nil {
+				return failure, err
+			}
+			arguments[tickerArgName] = ticker
+		}
+		if inputs, ok := arguments[tickersArgName].([]interface{}); ok {
+			tickers := make([]interface{}, 0, len(inputs))
+			for _, item := range inputs {
+				input, ok := item.(string)
+				if !ok || strings.TrimSpace(input) == "" {
+					tickers = append(tickers, item)
+					continue
+				}
+				ticker, failure, err := resolve(input)
+				if failure != nil || err != nil {
+					return failure, err
+				}
+				tickers = append(tickers, ticker)
+			}
+			arguments[tickersArgName] = tickers
+		}
+		request.Params.Arguments = arguments
+
+		result, err := next(ctx, request)
+		if err != nil || result == nil || result.IsError || len(notes) == 0 {
+			return result, err
+		}
+		for i, content := range result.Content {
+			if text, ok := content.(mcp.TextContent); ok {
+				text.Text = strings.Join(notes, "\n") + "\n\n" + text.Text
+				result.Content[i] = text
+				break
+			}
+		}
+		return result, nil
+	}
+}
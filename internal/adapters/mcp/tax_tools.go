@@ -0,0 +1,134 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/i18n"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// registerTaxTools регистрирует инструмент оценки налогов по портфелю
+func (s *Server) registerTaxTools() {
+	if s.taxService == nil {
+		return
+	}
+
+	estimateTaxesTool := mcp.NewTool("estimate_taxes",
+		mcp.WithDescription(s.printer.T("Оценить НДФЛ по портфелю за год: с результата продаж по FIFO с учетом льготы долгосрочного владения (ЛДВ) и с дивидендов")),
+		mcp.WithString("portfolio",
+			mcp.Description(s.printer.T("Имя портфеля (по умолчанию default)")),
+		),
+		mcp.WithNumber("year",
+			mcp.Description(s.printer.T("Календарный год (по умолчанию текущий)")),
+		),
+	)
+
+	s.addTool(estimateTaxesTool, s.handleEstimateTaxes)
+}
+
+// handleEstimateTaxes обрабатывает запрос на оценку налогов по портфелю
+func (s *Server) handleEstimateTaxes(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		Portfolio string `arg:"portfolio"`
+		Year      int    `arg:"year" min:"2014"`
+	}
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	p := i18n.PrinterFrom(ctx)
+	estimate, err := s.taxService.EstimateTaxes(ctx, args.Portfolio, args.Year)
+	if err != nil {
+		return mcp.NewToolResultError(p.Sprintf("не удалось оценить налоги: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(formatTaxEstimate(p, estimate)), nil
+}
+
+// formatTaxEstimate форматирует оценку НДФЛ по портфелю на языке переводчика p
+func formatTaxEstimate(p i18n.Printer, e *models.TaxEstimate) string {
+	result := p.Sprintf("Оценка НДФЛ по портфелю %s за %d год\n\n", e.Portfolio, e.Year)
+
+	if len(e.Lots) == 0 {
+		result += p.T("Продаж за год не было.\n")
+	} else {
+		result += p.T("Продажи (покупки сопоставлены по FIFO):\n")
+		for i, lot := range e.Lots {
+			result += p.Sprintf("%d. %s: %d шт., ", i+1, lot.Ticker, lot.Quantity)
+			switch {
+			case lot.Unmatched:
+				result += p.T("покупка не найдена в истории")
+			case lot.Opening:
+				result += p.Sprintf("начальный остаток по %.2f ₽", lot.BuyPrice)
+			default:
+				result += p.Sprintf("куплены %s по %.2f ₽", lot.BoughtAt.Format("02.01.2006"), lot.BuyPrice)
+			}
+			result += p.Sprintf(", проданы %s по %.2f ₽: %+.2f ₽", lot.SoldAt.Format("02.01.2006"), lot.SellPrice, lot.Gain)
+			if models.LongTermEligible(lot) {
+				result += p.Sprintf(" (ЛДВ, полных лет владения: %d)", lot.HoldingYears())
+			}
+			result += "\n"
+		}
+		result += p.Sprintf("\nВыручка: %.2f ₽, расходы с комиссиями: %.2f ₽, финансовый результат: %+.2f ₽\n", e.Proceeds, e.Cost, e.Gain)
+		if e.LongTermLimit > 0 {
+			result += p.Sprintf("Льгота долгосрочного владения: результат по бумагам, которыми владели больше %d лет, %+.2f ₽, предел %.2f ₽, освобождено %.2f ₽\n",
+				models.LongTermHoldingYears, e.LongTermGain, e.LongTermLimit, e.LongTermExemption)
+		}
+		result += p.Sprintf("Налоговая база по продажам: %.2f ₽\n", e.TradingBase)
+		result += p.Sprintf("НДФЛ с продаж: %.2f ₽ (удерживает брокер)\n", e.TradingTax)
+	}
+
+	result += "\n"
+	switch {
+	case !e.DividendsKnown:
+		result += p.T("Дивиденды не учтены: календарь корпоративных событий недоступен.\n")
+	case len(e.Dividends) == 0:
+		result += p.T("Дивидендов в рублях за год не было.\n")
+	default:
+		result += p.T("Дивиденды по бумагам, которые были в портфеле на дату закрытия реестра:\n")
+		for i, dividend := range e.Dividends {
+			result += p.Sprintf("%d. %s: реестр %s, %d шт. × %.2f ₽ = %.2f ₽\n",
+				i+1, dividend.Ticker, dividend.RecordDate.Format("02.01.2006"), dividend.Quantity, dividend.PerShare, dividend.Amount)
+		}
+		result += p.Sprintf("Дивиденды: %.2f ₽, НДФЛ с дивидендов: %.2f ₽ (удерживается при выплате)\n", e.DividendIncome, e.DividendTax)
+	}
+
+	result += p.Sprintf("\nИтого НДФЛ: %.2f ₽", e.TotalTax)
+	if threshold := models.NDFLThreshold(e.Year); threshold > 0 {
+		result += p.Sprintf(" (%d%% с дохода до %.0f ₽, %d%% сверх)", models.NDFLRatePerc, threshold, models.NDFLHighRatePerc)
+	} else {
+		result += fmt.Sprintf(" (%d%%)", models.NDFLRatePerc)
+	}
+	result += "\n"
+
+	if len(e.LongTermLots) > 0 {
+		result += p.T("\nЛьгота долгосрочного владения по открытым позициям:\n")
+		for _, lot := range e.LongTermLots {
+			result += p.Sprintf("- %s: %d шт., куплены %s по %.2f ₽ — ", lot.Ticker, lot.Quantity, lot.BoughtAt.Format("02.01.2006"), lot.Price)
+			if lot.Eligible {
+				result += p.T("продажа уже освобождается от НДФЛ\n")
+			} else {
+				result += p.Sprintf("льгота с %s (через %d дн.)\n", lot.EligibleFrom.Format("02.01.2006"), daysUntil(lot.EligibleFrom))
+			}
+		}
+	}
+
+	if e.HasOpening {
+		result += p.T("\nЧасть продаж сопоставлена с начальными остатками позиций, открытых до ведения истории сделок: их дата покупки неизвестна, льгота к ним не применяется.\n")
+	}
+	if e.HasUnmatched {
+		result += p.T("\nДля части продаж покупки не найдены в истории сделок: их стоимость принята нулевой, налог завышен.\n")
+	}
+	result += p.T("\nОценка ориентировочная: не учитывает ИИС, налоговые вычеты, сальдирование с другими счетами и бумаги в иностранной валюте.\n")
+
+	return result
+}
+
+// daysUntil возвращает число полных дней до момента t
+func daysUntil(t time.Time) int {
+	return int(time.Until(t).Hours() / 24)
+}
@@ -0,0 +1,107 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/adapters/render"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// handleTechnicalAnalysisPrompt обрабатывает запрос на шаблон технического анализа.
+// Индикаторы рассчитываются по сохраненным свечам, чтобы модель не выводила их из одной текущей цены
+func (s *Server) handleTechnicalAnalysisPrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	ticker := strings.ToUpper(strings.TrimSpace(request.Params.Arguments["ticker"]))
+	if ticker == "" {
+		return nil, fmt.Errorf("требуется параметр ticker")
+	}
+	timeframe := request.Params.Arguments["timeframe"]
+	if timeframe == "" {
+		timeframe = models.IntervalDay
+	}
+
+	indicators, err := s.stockService.GetTechnicalIndicators(ctx, ticker, timeframe)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось рассчитать индикаторы: %w", err)
+	}
+
+	systemMessage := `Ты - технический аналитик российского рынка акций.
+Проведи технический анализ акции по рассчитанным индикаторам и последним свечам.
+Включи в анализ:
+1. Тренд: положение цены относительно SMA20, SMA50 и SMA200 и их взаимное расположение
+2. Импульс: RSI (перекупленность выше 70, перепроданность ниже 30) и сигналы MACD
+3. Ключевые уровни поддержки и сопротивления и реакцию цены на них
+4. Торговый сценарий: условия входа, цели и уровень стопа, а также что отменит сценарий
+
+Индикаторы уже рассчитаны; не пересчитывай их и не додумывай отсутствующие значения.`
+
+	return mcp.NewGetPromptResult(
+		fmt.Sprintf("Технический анализ %s (%s)", indicators.Ticker, indicators.Interval),
+		[]mcp.PromptMessage{
+			mcp.NewPromptMessage(
+				mcp.RoleAssistant,
+				mcp.NewTextContent(systemMessage),
+			),
+			mcp.NewPromptMessage(
+				mcp.RoleUser,
+				mcp.NewTextContent(formatTechnicalIndicators(indicators)),
+			),
+		},
+	), nil
+}
+
+// formatTechnicalIndicators форматирует технические индикаторы и последние свечи
+func formatTechnicalIndicators(t *models.TechnicalIndicators) string {
+	sign := render.CurrencySign(t.Ticker)
+	result := fmt.Sprintf("Технические индикаторы %s, свечи %s: %d свечей с %s по %s\n\n",
+		t.Ticker, t.Interval, t.Candles, t.From.Format("02.01.2006 15:04"), t.To.Format("02.01.2006 15:04"))
+
+	result += fmt.Sprintf("Последняя цена закрытия: %.2f %s\n", t.LastClose, sign)
+	result += fmt.Sprintf("Диапазон за период: %.2f – %.2f %s\n", t.PeriodLow, t.PeriodHigh, sign)
+
+	result += "\nСкользящие средние:\n"
+	for _, average := range []struct {
+		name  string
+		value float64
+	}{
+		{"SMA20", t.SMA20},
+		{"SMA50", t.SMA50},
+		{"SMA200", t.SMA200},
+	} {
+		if average.value == 0 {
+			result += fmt.Sprintf("- %s: недостаточно свечей\n", average.name)
+			continue
+		}
+		result += fmt.Sprintf("- %s: %.2f (цена %+.2f%% от средней)\n", average.name, average.value, (t.LastClose/average.value-1)*100)
+	}
+
+	result += fmt.Sprintf("\nRSI(14): %.1f\n", t.RSI14)
+	result += fmt.Sprintf("MACD(12, 26, 9): линия %.3f, сигнальная %.3f, гистограмма %+.3f\n", t.MACD, t.MACDSignal, t.MACDHistogram)
+
+	result += "\nУровни поддержки: " + formatLevels(t.Supports) + "\n"
+	result += "Уровни сопротивления: " + formatLevels(t.Resistances) + "\n"
+
+	result += "\nПоследние свечи (открытие / максимум / минимум / закрытие, объем):\n"
+	for _, candle := range t.RecentCandles {
+		result += fmt.Sprintf("- %s: %.2f / %.2f / %.2f / %.2f, %d\n",
+			candle.Date.Format("02.01.2006 15:04"), candle.Open, candle.High, candle.Low, candle.Close, candle.Volume)
+	}
+
+	return result
+}
+
+// formatLevels форматирует список ценовых уровней через запятую
+func formatLevels(levels []float64) string {
+	if len(levels) == 0 {
+		return "не найдены"
+	}
+
+	formatted := make([]string, 0, len(levels))
+	for _, level := range levels {
+		formatted = append(formatted, fmt.Sprintf("%.2f", level))
+	}
+	return strings.Join(formatted, ", ")
+}
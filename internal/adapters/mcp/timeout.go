@@ -0,0 +1,93 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/i18n"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// defaultToolTimeouts ограничения долгих инструментов, которым общего server.timeoutSeconds заведомо мало:
+// загрузки истории, выгрузки и расчеты по всему универсуму. server.toolTimeouts их переопределяет
+var defaultToolTimeouts = map[string]time.Duration{
+	"backfill_history":          10 * time.Minute,
+	"backfill_news":             5 * time.Minute,
+	"reparse_raw":               10 * time.Minute,
+	"export_data":               5 * time.Minute,
+	"run_selftest":              time.Minute,
+	"get_unusual_volume":        3 * time.Minute,
+	"get_gappers":               3 * time.Minute,
+	"get_market_breadth":        2 * time.Minute,
+	"get_sector_performance":    2 * time.Minute,
+	"get_top_gainers":           2 * time.Minute,
+	"get_top_losers":            2 * time.Minute,
+	"get_correlation":           2 * time.Minute,
+	"stress_test_portfolio":     2 * time.Minute,
+	"get_watchlist_performance": 2 * time.Minute,
+}
+
+// toolTimeout возвращает ограничение времени выполнения инструмента: значение из server.toolTimeouts,
+// ограничение долгого инструмента по умолчанию или server.timeoutSeconds; 0 — без ограничения
+func (s *Server) toolTimeout(name string) time.Duration {
+	if timeout, ok := s.config.Server.ToolTimeouts[name]; ok {
+		return timeout
+	}
+	if timeout, ok := defaultToolTimeouts[name]; ok {
+		return timeout
+	}
+	return time.Duration(s.config.Server.TimeoutSeconds) * time.Second
+}
+
+// timeoutMiddleware ограничивает время выполнения инструмента. Обработчик получает контекст с дедлайном,
+// а если он не завершился вовремя (например, клиент API не учитывает контекст), клиенту сразу
+// возвращается ошибка, чтобы медленный источник не блокировал сессию
+func (s *Server) timeoutMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		name := request.Params.Name
+		timeout := s.toolTimeout(name)
+		if timeout <= 0 {
+			return next(ctx, request)
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		type outcome struct {
+			result *mcp.CallToolResult
+			err    error
+		}
+		done := make(chan outcome, 1)
+		go func() {
+			result, err := next(ctx, request)
+			done <- outcome{result, err}
+		}()
+
+		select {
+		case out := <-done:
+			// Ошибку истечения дедлайна, возвращенную самим обработчиком, заменяем понятным сообщением
+			if out.err != nil && errors.Is(out.err, context.DeadlineExceeded) && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return s.timeoutResult(ctx, name, timeout), nil
+			}
+			return out.result, out.err
+		case <-ctx.Done():
+			if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				// Вызов отменен клиентом или сервер останавливается
+				return nil, ctx.Err()
+			}
+			return s.timeoutResult(ctx, name, timeout), nil
+		}
+	}
+}
+
+// timeoutResult формирует ошибку инструмента, не уложившегося в отведенное время
+func (s *Server) timeoutResult(ctx context.Context, name string, timeout time.Duration) *mcp.CallToolResult {
+	log.Printf("Инструмент %s не завершился за %v", name, timeout)
+	p := i18n.PrinterFrom(ctx)
+	return mcp.NewToolResultError(p.Sprintf("инструмент %s не ответил за %d с: источник данных отвечает слишком долго, повторите запрос позже",
+		name, int(timeout.Seconds())))
+}
@@ -0,0 +1,35 @@
+package mcp
+
+import (
+	"context"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/timing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// timingMetaKey ключ разбивки времени в метаданных (_meta) результата инструмента
+const timingMetaKey = "timing"
+
+// timingMiddleware в режиме отладки добавляет к результату разбивку времени выполнения
+// по этапам: кэш, база данных, внешние API и форматирование ответа.
+// Регистрируется первым, чтобы замер охватывал остальные middleware
+func timingMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		ctx, rec := timing.WithRecorder(ctx)
+		start := time.Now()
+
+		result, err := next(ctx, request)
+		if err != nil || result == nil {
+			return result, err
+		}
+
+		if result.Meta == nil {
+			result.Meta = make(map[string]interface{})
+		}
+		result.Meta[timingMetaKey] = rec.Breakdown(time.Since(start))
+		return result, nil
+	}
+}
@@ -0,0 +1,295 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/adapters/render"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/i18n"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// registerTradeJournalTools регистрирует инструменты журнала сделок портфеля
+func (s *Server) registerTradeJournalTools() {
+	if s.portfolioService == nil {
+		return
+	}
+
+	portfolioArg := mcp.WithString("portfolio",
+		mcp.Description(s.printer.T("Имя портфеля (по умолчанию default)")),
+	)
+
+	// Инструмент для записи сделки в журнал
+	recordTradeTool := mcp.NewTool("record_trade",
+		mcp.WithDescription(s.printer.T("Записать совершенную сделку в журнал портфеля с ценой, комиссией и датой; позиция изменяется так же, как add_position и remove_position")),
+		mcp.WithString("ticker",
+			mcp.Required(),
+			mcp.Description(s.printer.T("Тикер акции (например, SBER, GAZP, LKOH)")),
+		),
+		mcp.WithString("side",
+			mcp.Required(),
+			mcp.Description(s.printer.T("Направление сделки: buy — покупка, sell — продажа")),
+			mcp.Enum(models.OrderSides...),
+		),
+		mcp.WithNumber("quantity",
+			mcp.Required(),
+			mcp.Description(s.printer.T("Количество акций")),
+		),
+		mcp.WithNumber("price",
+			mcp.Required(),
+			mcp.Description(s.printer.T("Цена сделки за акцию")),
+		),
+		mcp.WithNumber("fee",
+			mcp.Description(s.printer.T("Комиссия брокера за сделку, ₽ (по умолчанию по тарифу broker из конфигурации)")),
+		),
+		mcp.WithString("date",
+			mcp.Description(s.printer.T("Дата сделки в формате YYYY-MM-DD (по умолчанию сегодня)")),
+		),
+		portfolioArg,
+		s.dryRunArg(),
+	)
+
+	s.addTool(recordTradeTool, s.handleRecordTrade)
+
+	// Инструмент для просмотра журнала сделок
+	getTradeHistoryTool := mcp.NewTool("get_trade_history",
+		mcp.WithDescription(s.printer.T("Получить сделки портфеля за период от новых к старым")),
+		mcp.WithString("ticker",
+			mcp.Description(s.printer.T("Тикер акции; по умолчанию сделки по всем бумагам")),
+		),
+		mcp.WithString("from",
+			mcp.Description(s.printer.T("Первый день периода в формате YYYY-MM-DD (по умолчанию с начала истории)")),
+		),
+		mcp.WithString("to",
+			mcp.Description(s.printer.T("Последний день периода в формате YYYY-MM-DD, включительно (по умолчанию сегодня)")),
+		),
+		portfolioArg,
+		s.limitArg("сделок"),
+		s.offsetArg(),
+	)
+
+	s.addTool(getTradeHistoryTool, s.handleGetTradeHistory)
+
+	// Инструмент для расчета реализованного результата
+	getRealizedPnLTool := mcp.NewTool("get_realized_pnl",
+		mcp.WithDescription(s.printer.T("Рассчитать реализованный результат продаж портфеля за период по методу FIFO с учетом комиссий")),
+		mcp.WithString("from",
+			mcp.Description(s.printer.T("Первый день периода в формате YYYY-MM-DD (по умолчанию начало текущего года)")),
+		),
+		mcp.WithString("to",
+			mcp.Description(s.printer.T("Последний день периода в формате YYYY-MM-DD, включительно (по умолчанию сегодня)")),
+		),
+		portfolioArg,
+	)
+
+	s.addTool(getRealizedPnLTool, s.handleGetRealizedPnL)
+}
+
+// handleRecordTrade обрабатывает запрос на запись сделки в журнал
+func (s *Server) handleRecordTrade(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	p := i18n.PrinterFrom(ctx)
+	var args struct {
+		Ticker    string  `arg:"ticker,required"`
+		Side      string  `arg:"side,required" enum:"buy|sell"`
+		Quantity  int64   `arg:"quantity,required" min:"1"`
+		Price     float64 `arg:"price,required" min:"0"`
+		Fee       float64 `arg:"fee" min:"0"`
+		Date      string  `arg:"date"`
+		Portfolio string  `arg:"portfolio"`
+		dryRunArgs
+	}
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	trade := models.PortfolioTrade{
+		Portfolio:  args.Portfolio,
+		Ticker:     args.Ticker,
+		Side:       args.Side,
+		Quantity:   args.Quantity,
+		Price:      args.Price,
+		Commission: args.Fee,
+	}
+	if _, ok := request.Params.Arguments["fee"]; !ok {
+		trade.Commission = models.CommissionByTariff
+	}
+	if args.Date != "" {
+		date, err := parseDateArg(ctx, "date", args.Date, models.MoscowLocation)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		// Сделка за сегодня записывается текущим моментом, чтобы сохранить порядок с другими сделками дня
+		now := time.Now().In(models.MoscowLocation)
+		if !date.Equal(time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, models.MoscowLocation)) {
+			trade.ExecutedAt = date
+		}
+	}
+
+	change, err := s.portfolioService.RecordTrade(ctx, trade, args.DryRun)
+	if err != nil {
+		return mcp.NewToolResultError(p.Sprintf("не удалось записать сделку: %v", err)), nil
+	}
+
+	result := formatPositionChange(p, change)
+	if change.Trade != nil {
+		result += p.T("\nСделка: ") + formatPortfolioTrade(p, *change.Trade) + "\n"
+	}
+
+	return mcp.NewToolResultText(result), nil
+}
+
+// handleGetTradeHistory обрабатывает запрос на получение журнала сделок
+func (s *Server) handleGetTradeHistory(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	p := i18n.PrinterFrom(ctx)
+	var args struct {
+		Ticker    string `arg:"ticker"`
+		Portfolio string `arg:"portfolio"`
+		tradePeriodArgs
+		pageArgs
+	}
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if args.Portfolio == "" {
+		args.Portfolio = models.DefaultPortfolio
+	}
+
+	from, to, err := args.period(ctx, time.Time{})
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	page := args.page()
+	trades, total, err := s.portfolioService.GetTradeHistory(ctx, args.Portfolio, args.Ticker, from, to, page)
+	if err != nil {
+		return mcp.NewToolResultError(p.Sprintf("не удалось получить историю сделок: %v", err)), nil
+	}
+
+	if total == 0 {
+		return mcp.NewToolResultText(p.T("Сделок за период не найдено")), nil
+	}
+
+	result := p.Sprintf("История сделок портфеля %s:\n\n", args.Portfolio)
+	for i, trade := range trades {
+		result += fmt.Sprintf("%d. %s\n", page.Offset+i+1, formatPortfolioTrade(p, trade))
+	}
+
+	footer, err := s.renderer.Render(p, "page_footer", render.NewPage(page, len(trades), total))
+	if err != nil {
+		return mcp.NewToolResultError(p.Sprintf("ошибка оформления результата: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(result + footer), nil
+}
+
+// handleGetRealizedPnL обрабатывает запрос на расчет реализованного результата
+func (s *Server) handleGetRealizedPnL(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	p := i18n.PrinterFrom(ctx)
+	var args struct {
+		Portfolio string `arg:"portfolio"`
+		tradePeriodArgs
+	}
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	now := time.Now().In(models.MoscowLocation)
+	from, to, err := args.period(ctx, time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, models.MoscowLocation))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	pnl, err := s.portfolioService.GetRealizedPnL(ctx, args.Portfolio, from, to)
+	if err != nil {
+		return mcp.NewToolResultError(p.Sprintf("не удалось рассчитать результат: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(formatRealizedPnL(p, pnl)), nil
+}
+
+// tradePeriodArgs аргументы периода журнала сделок
+type tradePeriodArgs struct {
+	From string `arg:"from"`
+	To   string `arg:"to"`
+}
+
+// period разбирает аргументы from и to по московскому времени; по умолчанию период начинается
+// с defaultFrom и заканчивается сегодня. Последний день включается целиком
+func (a tradePeriodArgs) period(ctx context.Context, defaultFrom time.Time) (time.Time, time.Time, error) {
+	from := defaultFrom
+	if a.From != "" {
+		parsed, err := parseDateArg(ctx, "from", a.From, models.MoscowLocation)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		from = parsed
+	}
+
+	now := time.Now().In(models.MoscowLocation)
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, models.MoscowLocation)
+	if a.To != "" {
+		parsed, err := parseDateArg(ctx, "to", a.To, models.MoscowLocation)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		to = parsed
+	}
+
+	return from, to.Add(24*time.Hour - time.Nanosecond), nil
+}
+
+// formatPortfolioTrade форматирует сделку журнала одной строкой на языке переводчика p
+func formatPortfolioTrade(p i18n.Printer, trade models.PortfolioTrade) string {
+	action := p.T("покупка")
+	if trade.Side == models.OrderSideSell {
+		action = p.T("продажа")
+	}
+
+	result := p.Sprintf("%s %s: %s %d шт. по %.2f ₽ = %.2f ₽",
+		trade.ExecutedAt.In(models.MoscowLocation).Format("02.01.2006"), trade.Ticker, action,
+		trade.Quantity, trade.Price, trade.Price*float64(trade.Quantity))
+	if trade.Commission > 0 {
+		result += p.Sprintf(", комиссия %.2f ₽", trade.Commission)
+	}
+	if trade.Opening {
+		result += p.T(" (начальный остаток позиции, открытой до ведения журнала)")
+	}
+
+	return result
+}
+
+// formatRealizedPnL форматирует реализованный результат портфеля за период на языке переводчика p
+func formatRealizedPnL(p i18n.Printer, pnl *models.RealizedPnL) string {
+	period := p.T("до ") + pnl.To.Format("02.01.2006")
+	if !pnl.From.IsZero() {
+		period = pnl.From.Format("02.01.2006") + " – " + pnl.To.Format("02.01.2006")
+	}
+
+	result := p.Sprintf("Реализованный результат портфеля %s за %s (FIFO):\n\n", pnl.Portfolio, period)
+	if len(pnl.Tickers) == 0 {
+		result += p.T("Продаж за период не было.\n")
+	}
+	for i, ticker := range pnl.Tickers {
+		result += p.Sprintf("%d. %s: продано %d шт., выручка %.2f ₽, стоимость покупки %.2f ₽, результат %+.2f ₽ (%+.2f%%)\n",
+			i+1, ticker.Ticker, ticker.Quantity, ticker.Proceeds, ticker.Cost, ticker.Gain, ticker.GainPerc)
+	}
+
+	if len(pnl.Tickers) > 0 {
+		result += p.Sprintf("\nИтого: выручка %.2f ₽, стоимость покупки %.2f ₽, результат %+.2f ₽ (%+.2f%%)\n",
+			pnl.Proceeds, pnl.Cost, pnl.Gain, pnl.GainPerc)
+		result += p.Sprintf("Бумаг с прибылью: %d, с убытком: %d\n", pnl.Winners, pnl.Losers)
+	}
+	result += p.Sprintf("Комиссии сделок за период: %.2f ₽\n", pnl.Commission)
+
+	if pnl.HasOpening {
+		result += p.T("\nЧасть продаж сопоставлена с начальными остатками позиций по их средней цене.\n")
+	}
+	if pnl.HasUnmatched {
+		result += p.T("\nДля части продаж покупки не найдены в журнале: их стоимость принята нулевой, результат завышен.\n")
+	}
+
+	return result
+}
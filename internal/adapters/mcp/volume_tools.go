@@ -0,0 +1,87 @@
+package mcp
+
+import (
+	"context"
+	"strings"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/i18n"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// registerVolumeTools регистрирует инструмент поиска аномальных объемов торгов
+func (s *Server) registerVolumeTools() {
+	getUnusualVolumeTool := mcp.NewTool("get_unusual_volume",
+		mcp.WithDescription(s.printer.Sprintf("Найти акции с аномальным объемом торгов: объем за день в сравнении со средним за %d предыдущих сессий. Во время торгов объем дня еще не набран, поэтому к вечеру отношение растет", models.VolumeLookbackSessions)),
+		s.universeArg(),
+		mcp.WithNumber("min_ratio",
+			mcp.Description(s.printer.Sprintf("Во сколько раз объем должен превышать средний (по умолчанию %g)", s.config.Volume.MinRatio)),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description(s.printer.Sprintf("Количество акций с наибольшим превышением (по умолчанию %d, максимум %d)", models.DefaultVolumeAnomalyLimit, models.MaxVolumeAnomalyLimit)),
+		),
+	)
+
+	s.addTool(getUnusualVolumeTool, s.handleGetUnusualVolume, sourceMOEX)
+}
+
+// handleGetUnusualVolume обрабатывает запрос на поиск аномальных объемов
+func (s *Server) handleGetUnusualVolume(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	p := i18n.PrinterFrom(ctx)
+	// Граница limit совпадает с models.MaxVolumeAnomalyLimit
+	args := struct {
+		Universe string  `arg:"universe"`
+		MinRatio float64 `arg:"min_ratio" min:"1"`
+		Limit    int     `arg:"limit" min:"1" max:"50"`
+	}{MinRatio: s.config.Volume.MinRatio, Limit: models.DefaultVolumeAnomalyLimit}
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	report, err := s.stockService.GetUnusualVolume(ctx, args.Universe, args.MinRatio, args.Limit)
+	if err != nil {
+		return mcp.NewToolResultError(p.Sprintf("не удалось найти аномальные объемы: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(formatVolumeAnomalies(p, report)), nil
+}
+
+// volumeBackfillListLimit количество тикеров без истории, перечисляемых в ответе
+const volumeBackfillListLimit = 20
+
+// formatVolumeAnomalies форматирует результат поиска аномальных объемов на языке переводчика p
+func formatVolumeAnomalies(p i18n.Printer, report *models.VolumeAnomalyReport) string {
+	result := p.Sprintf("Аномальные объемы в универсуме %s (объем выше среднего в %g раза и более):\n", report.Universe, report.MinRatio)
+	if report.Total == 0 {
+		result += p.T("Аномальных объемов не найдено\n")
+	}
+
+	for i, anomaly := range report.Anomalies {
+		result += p.Sprintf("%d. %s (%s): объем %d при среднем %.0f — в %.1f раза выше; цена %.2f ₽ (%+.2f%%)\n",
+			i+1, anomaly.Ticker, anomaly.Name, anomaly.Volume, anomaly.AverageVolume, anomaly.Ratio, anomaly.Price, anomaly.ChangePerc)
+	}
+	if report.Total > len(report.Anomalies) {
+		result += p.Sprintf("Показано %d из %d\n", len(report.Anomalies), report.Total)
+	}
+
+	result += p.Sprintf("\nПроверено бумаг: %d", report.Scanned)
+	if report.Skipped > 0 {
+		result += p.Sprintf(", пропущено без истории объемов не менее %d сессий: %d", models.VolumeMinSessions, report.Skipped)
+	}
+	result += "\n"
+
+	if len(report.Backfill) > 0 {
+		tickers := report.Backfill
+		if len(tickers) > volumeBackfillListLimit {
+			tickers = tickers[:volumeBackfillListLimit]
+		}
+		result += p.Sprintf("Нужно загрузить историю (backfill_history): %s", strings.Join(tickers, ", "))
+		if rest := len(report.Backfill) - len(tickers); rest > 0 {
+			result += p.Sprintf(" и еще %d", rest)
+		}
+		result += "\n"
+	}
+
+	return result
+}
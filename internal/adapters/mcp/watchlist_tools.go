@@ -0,0 +1,321 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/i18n"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// defaultAlertsDays глубина истории уведомлений по умолчанию, дней
+const defaultAlertsDays = 7
+
+// registerWatchlistTools регистрирует инструменты для работы со списками наблюдения
+func (s *Server) registerWatchlistTools() {
+	if s.watchlistService == nil {
+		return
+	}
+
+	watchlistArg := mcp.WithString("watchlist",
+		mcp.Description(s.printer.T("Имя списка наблюдения (по умолчанию default)")),
+	)
+
+	// Инструмент для просмотра списка наблюдения
+	getWatchlistTool := mcp.NewTool("get_watchlist",
+		mcp.WithDescription(s.printer.T("Получить бумаги списка наблюдения с текущими котировками и порогами уведомлений")),
+		watchlistArg,
+	)
+
+	s.addTool(getWatchlistTool, s.handleGetWatchlist, sourceMOEX)
+
+	// Инструмент для добавления бумаги и настройки ее порога
+	addToWatchlistTool := mcp.NewTool("add_to_watchlist",
+		mcp.WithDescription(s.printer.T("Добавить акцию в список наблюдения или изменить ее порог уведомления. Уведомление приходит, когда изменение цены за день по модулю достигает порога")),
+		mcp.WithString("ticker",
+			mcp.Required(),
+			mcp.Description(s.printer.T("Тикер акции (например, SBER, GAZP, LKOH)")),
+		),
+		mcp.WithNumber("threshold_perc",
+			mcp.Description(s.printer.Sprintf("Порог изменения цены за день в процентах, например 3 для ±3%%. Новая бумага без порога получает порог по умолчанию %.1f%% из конфигурации, у бумаги из списка порог без параметра не меняется", s.config.Watchlist.DefaultThresholdPerc)),
+		),
+		watchlistArg,
+		s.dryRunArg(),
+	)
+
+	s.addTool(addToWatchlistTool, s.handleAddToWatchlist, sourceMOEX)
+
+	// Инструмент для удаления бумаги из списка
+	removeFromWatchlistTool := mcp.NewTool("remove_from_watchlist",
+		mcp.WithDescription(s.printer.T("Удалить акцию из списка наблюдения")),
+		mcp.WithString("ticker",
+			mcp.Required(),
+			mcp.Description(s.printer.T("Тикер акции (например, SBER, GAZP, LKOH)")),
+		),
+		watchlistArg,
+		s.dryRunArg(),
+	)
+
+	s.addTool(removeFromWatchlistTool, s.handleRemoveFromWatchlist)
+
+	// Инструмент для просмотра сработавших уведомлений
+	getAlertsTool := mcp.NewTool("get_watchlist_alerts",
+		mcp.WithDescription(s.printer.T("Получить уведомления о движениях цены, превысивших пороги бумаг списка наблюдения")),
+		mcp.WithNumber("days",
+			mcp.Description(s.printer.Sprintf("За сколько последних дней показать уведомления (по умолчанию %d)", defaultAlertsDays)),
+		),
+		watchlistArg,
+	)
+
+	s.addTool(getAlertsTool, s.handleGetWatchlistAlerts, sourceMOEX)
+
+	// Инструмент для отчета о доходности списка наблюдения
+	getPerformanceTool := mcp.NewTool("get_watchlist_performance",
+		mcp.WithDescription(s.printer.T("Рассчитать доходность бумаг списка наблюдения за период по архивным ценам закрытия, доходность равновзвешенной корзины и сравнение с индексом MOEX")),
+		mcp.WithString("period",
+			mcp.Required(),
+			mcp.Description(s.printer.T("Период: 1w — неделя, 1m — месяц, 3m, 6m, ytd — с начала года, 1y — год")),
+			mcp.Enum(models.PerformancePeriods...),
+		),
+		watchlistArg,
+	)
+
+	s.addTool(getPerformanceTool, s.handleGetWatchlistPerformance, sourceMOEX)
+}
+
+// handleGetWatchlist обрабатывает запрос на получение списка наблюдения
+func (s *Server) handleGetWatchlist(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	p := i18n.PrinterFrom(ctx)
+	args := struct {
+		Watchlist string `arg:"watchlist"`
+	}{Watchlist: models.DefaultWatchlist}
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	watchlist := args.Watchlist
+
+	items, err := s.watchlistService.GetWatchlist(ctx, watchlist)
+	if err != nil {
+		return mcp.NewToolResultError(p.Sprintf("не удалось получить список наблюдения: %v", err)), nil
+	}
+
+	if len(items) == 0 {
+		return mcp.NewToolResultText(p.Sprintf("Список наблюдения %s пуст", watchlist)), nil
+	}
+
+	// Формируем результат
+	result := p.Sprintf("Список наблюдения %s:\n\n", watchlist)
+	for i, item := range items {
+		result += p.Sprintf("%d. %s: %.2f ₽ (%+.2f%% за день)\n", i+1, item.Ticker, item.Price, item.ChangePerc)
+
+		threshold := fmt.Sprintf("±%.2f%%", item.EffectiveThresholdPerc)
+		if item.ThresholdPerc == 0 {
+			threshold += p.T(" (по умолчанию)")
+		}
+		result += p.Sprintf("   Порог уведомления: %s\n", threshold)
+		result += p.Sprintf("   Добавлена %s по %.2f ₽\n", item.AddedAt.Format("02.01.2006"), item.AddedPrice)
+		if !item.LastAlertAt.IsZero() {
+			result += p.Sprintf("   Последнее уведомление: %s\n", item.LastAlertAt.Format("02.01.2006 15:04"))
+		}
+	}
+
+	return mcp.NewToolResultText(result), nil
+}
+
+// handleAddToWatchlist обрабатывает запрос на добавление бумаги в список наблюдения
+func (s *Server) handleAddToWatchlist(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	p := i18n.PrinterFrom(ctx)
+	var args struct {
+		Ticker        string  `arg:"ticker,required"`
+		ThresholdPerc float64 `arg:"threshold_perc" min:"0"`
+		Watchlist     string  `arg:"watchlist"`
+		dryRunArgs
+	}
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Без threshold_perc порог бумаги, уже стоящей в списке, не меняется
+	var threshold *float64
+	if request.Params.Arguments["threshold_perc"] != nil {
+		threshold = &args.ThresholdPerc
+	}
+
+	change, err := s.watchlistService.AddToWatchlist(ctx, args.Watchlist, args.Ticker, threshold, args.DryRun)
+	if err != nil {
+		return mcp.NewToolResultError(p.Sprintf("не удалось добавить бумагу в список наблюдения: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(s.formatWatchlistChange(p, change)), nil
+}
+
+// handleRemoveFromWatchlist обрабатывает запрос на удаление бумаги из списка наблюдения
+func (s *Server) handleRemoveFromWatchlist(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	p := i18n.PrinterFrom(ctx)
+	var args struct {
+		Ticker    string `arg:"ticker,required"`
+		Watchlist string `arg:"watchlist"`
+		dryRunArgs
+	}
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	change, err := s.watchlistService.RemoveFromWatchlist(ctx, args.Watchlist, args.Ticker, args.DryRun)
+	if err != nil {
+		return mcp.NewToolResultError(p.Sprintf("не удалось удалить бумагу из списка наблюдения: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(s.formatWatchlistChange(p, change)), nil
+}
+
+// formatWatchlistChange форматирует изменение бумаги списка наблюдения в виде «было → стало» на языке переводчика p
+func (s *Server) formatWatchlistChange(p i18n.Printer, change *models.WatchlistChange) string {
+	result := ""
+	if change.DryRun {
+		result += p.T(dryRunNotice)
+	}
+
+	switch {
+	case change.Before == nil:
+		if change.DryRun {
+			result += p.Sprintf("%s будет добавлена в список наблюдения %s", change.After.Ticker, change.After.Watchlist)
+		} else {
+			result += p.Sprintf("%s добавлена в список наблюдения %s", change.After.Ticker, change.After.Watchlist)
+		}
+		result += p.Sprintf(" по %.2f ₽, порог уведомления %s\n", change.After.AddedPrice, s.watchlistThreshold(p, change.After.ThresholdPerc))
+	case change.After == nil:
+		if change.DryRun {
+			result += p.Sprintf("%s будет удалена из списка наблюдения %s\n", change.Before.Ticker, change.Before.Watchlist)
+		} else {
+			result += p.Sprintf("%s удалена из списка наблюдения %s\n", change.Before.Ticker, change.Before.Watchlist)
+		}
+	default:
+		result += p.Sprintf("%s в списке наблюдения %s, порог уведомления: %s → %s\n", change.After.Ticker, change.After.Watchlist,
+			s.watchlistThreshold(p, change.Before.ThresholdPerc), s.watchlistThreshold(p, change.After.ThresholdPerc))
+	}
+
+	return result
+}
+
+// watchlistThreshold форматирует порог уведомления; нулевой порог — порог по умолчанию из конфигурации
+func (s *Server) watchlistThreshold(p i18n.Printer, thresholdPerc float64) string {
+	if thresholdPerc == 0 {
+		return p.Sprintf("±%.2f%% (по умолчанию)", s.config.Watchlist.DefaultThresholdPerc)
+	}
+	return fmt.Sprintf("±%.2f%%", thresholdPerc)
+}
+
+// handleGetWatchlistAlerts обрабатывает запрос на получение уведомлений списка наблюдения
+func (s *Server) handleGetWatchlistAlerts(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	p := i18n.PrinterFrom(ctx)
+	args := struct {
+		Days      int    `arg:"days" min:"1"`
+		Watchlist string `arg:"watchlist"`
+	}{Days: defaultAlertsDays, Watchlist: models.DefaultWatchlist}
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	days, watchlist := args.Days, args.Watchlist
+
+	since := time.Now().AddDate(0, 0, -days)
+	alerts, err := s.watchlistService.GetAlerts(ctx, watchlist, since)
+	if err != nil {
+		return mcp.NewToolResultError(p.Sprintf("не удалось получить уведомления: %v", err)), nil
+	}
+
+	if len(alerts) == 0 {
+		return mcp.NewToolResultText(p.Sprintf("За последние %d дн. уведомлений по списку %s не было", days, watchlist)), nil
+	}
+
+	// Формируем результат
+	result := p.Sprintf("Уведомления списка наблюдения %s за последние %d дн.:\n\n", watchlist, days)
+	for i, alert := range alerts {
+		result += fmt.Sprintf("%d. %s — %s\n", i+1, alert.TriggeredAt.Format("02.01.2006 15:04"), formatWatchlistAlert(p, alert))
+	}
+
+	return mcp.NewToolResultText(result), nil
+}
+
+// handleGetWatchlistPerformance обрабатывает запрос на отчет о доходности списка наблюдения
+func (s *Server) handleGetWatchlistPerformance(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	p := i18n.PrinterFrom(ctx)
+	// Допустимые периоды совпадают с models.PerformancePeriods
+	var args struct {
+		Period    string `arg:"period,required" enum:"1w|1m|3m|6m|ytd|1y"`
+		Watchlist string `arg:"watchlist"`
+	}
+	if err := bindArguments(ctx, request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	performance, err := s.watchlistService.GetPerformance(ctx, args.Watchlist, args.Period)
+	if err != nil {
+		return mcp.NewToolResultError(p.Sprintf("не удалось рассчитать доходность списка наблюдения: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(formatWatchlistPerformance(p, performance)), nil
+}
+
+// formatWatchlistPerformance форматирует отчет о доходности списка наблюдения на языке переводчика p
+func formatWatchlistPerformance(p i18n.Printer, perf *models.WatchlistPerformance) string {
+	result := p.Sprintf("Доходность списка наблюдения %s за %s (%s — %s):\n\n",
+		perf.Watchlist, perf.Period, perf.Start.Format("02.01.2006"), perf.End.Format("02.01.2006"))
+
+	noData := 0
+	for i, ticker := range perf.Tickers {
+		if ticker.NoData {
+			noData++
+			result += p.Sprintf("%d. %s: нет исторических данных за период\n", i+1, ticker.Ticker)
+			continue
+		}
+		result += p.Sprintf("%d. %s: %+.2f%% (%.2f → %.2f ₽), макс. просадка %.2f%%",
+			i+1, ticker.Ticker, ticker.ReturnPerc, ticker.StartPrice, ticker.EndPrice, ticker.MaxDrawdownPerc)
+		if !perf.BenchmarkNoData {
+			result += p.Sprintf(", к индексу %+.2f п.п.", ticker.ExcessPerc)
+		}
+		result += "\n"
+	}
+
+	result += "\n"
+	if perf.BasketSize > 0 {
+		result += p.Sprintf("Равновзвешенная корзина (%d бумаг): %+.2f%%\n", perf.BasketSize, perf.BasketReturnPerc)
+	}
+	if perf.BenchmarkNoData {
+		result += p.Sprintf("Бенчмарк %s: нет исторических данных за период\n", perf.Benchmark)
+	} else {
+		result += p.Sprintf("Бенчмарк %s: %+.2f%%\n", perf.Benchmark, perf.BenchmarkReturnPerc)
+		if perf.BasketSize > 0 {
+			result += p.Sprintf("Корзина относительно бенчмарка: %+.2f п.п.\n", perf.BasketReturnPerc-perf.BenchmarkReturnPerc)
+		}
+	}
+	if noData > 0 {
+		result += p.Sprintf("\nДля %d бумаг нет истории за период; загрузите исторические котировки, чтобы учесть их.\n", noData)
+	}
+
+	return result
+}
+
+// NotifyWatchlistAlert отправляет подключенным клиентам уведомление о сработавшем пороге
+func (s *Server) NotifyWatchlistAlert(alert models.WatchlistAlert) {
+	if s.server == nil {
+		log.Printf("MCP сервер не запущен, уведомление по %s не отправлено", alert.Ticker)
+		return
+	}
+
+	s.server.SendNotificationToAllClients("notifications/message", map[string]any{
+		"level":  "warning",
+		"logger": "watchlist",
+		"data":   formatWatchlistAlert(s.printer, alert),
+	})
+}
+
+// formatWatchlistAlert форматирует уведомление о сработавшем пороге на языке переводчика p
+func formatWatchlistAlert(p i18n.Printer, alert models.WatchlistAlert) string {
+	return p.Sprintf("%s (%s): %+.2f%% за день, цена %.2f ₽, порог ±%.2f%%",
+		alert.Ticker, alert.Watchlist, alert.ChangePerc, alert.Price, alert.ThresholdPerc)
+}
@@ -0,0 +1,53 @@
+package monitoring
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/services"
+)
+
+// healthResponse ответ /healthz и /readyz
+type healthResponse struct {
+	Status string `json:"status"` // ok или fail
+	*models.HealthReport
+}
+
+// HealthzHandler возвращает обработчик /healthz для проверки живости: отвечает 200, пока процесс
+// обрабатывает запросы, а в теле сообщает состояние зависимостей. Недоступность базы или внешних API
+// не должна приводить к перезапуску контейнера, поэтому статус ответа от нее не зависит
+func HealthzHandler(healthService services.HealthService) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report := healthService.CheckHealth(r.Context())
+		writeHealth(w, http.StatusOK, report)
+	})
+}
+
+// ReadyzHandler возвращает обработчик /readyz для проверки готовности: 503, если недоступна
+// обязательная зависимость (база данных, Redis) или аварийно остановлена фоновая задача
+func ReadyzHandler(healthService services.HealthService) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report := healthService.CheckHealth(r.Context())
+		status := http.StatusOK
+		if !report.Ready() {
+			status = http.StatusServiceUnavailable
+		}
+		writeHealth(w, status, report)
+	})
+}
+
+// writeHealth записывает отчет о состоянии в JSON
+func writeHealth(w http.ResponseWriter, status int, report *models.HealthReport) {
+	response := healthResponse{Status: models.HealthOK, HealthReport: report}
+	if !report.Ready() {
+		response.Status = models.HealthFail
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		log.Printf("Ошибка вывода состояния сервера: %v", err)
+	}
+}
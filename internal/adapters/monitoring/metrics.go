@@ -0,0 +1,25 @@
+package monitoring
+
+import (
+	"io"
+	"log"
+	"net/http"
+)
+
+// MetricsWriter источник метрик в текстовом формате Prometheus
+type MetricsWriter interface {
+	WriteMetrics(w io.Writer) error
+}
+
+// MetricsHandler возвращает обработчик /metrics, который выводит метрики всех источников подряд
+func MetricsHandler(writers ...MetricsWriter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		for _, writer := range writers {
+			if err := writer.WriteMetrics(w); err != nil {
+				log.Printf("Ошибка вывода метрик: %v", err)
+				return
+			}
+		}
+	})
+}
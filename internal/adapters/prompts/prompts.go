@@ -0,0 +1,356 @@
+// Package prompts загружает шаблоны MCP (prompts), которые оператор описывает файлами YAML или Markdown,
+// чтобы добавлять и менять шаблоны без пересборки сервера.
+//
+// Файл YAML (*.yaml, *.yml) описывает шаблон целиком:
+//
+//	name: dividend_check
+//	description: Проверка дивидендной истории акции
+//	features: [events]
+//	arguments:
+//	  - name: ticker
+//	    description: Тикер акции
+//	    required: true
+//	messages:
+//	  - role: assistant
+//	    content: Ты - финансовый аналитик...
+//	  - role: user
+//	    content: |
+//	      {{stock .ticker}}
+//	      {{events .ticker}}
+//
+// Файл Markdown (*.md) содержит те же поля, кроме messages, во вводном блоке YAML между строками «---»,
+// а текст после него — сообщения: заголовок первого уровня «# assistant» или «# user» начинает новое
+// сообщение с этой ролью. Текст без заголовков — одно сообщение пользователя.
+//
+// Тексты сообщений — шаблоны Go text/template. Аргументы шаблона доступны по имени ({{.ticker}}),
+// данные сервера подставляют функции Resolver (stock, news, today_news, top_gainers, top_losers, events,
+// targets с тикерами через запятую, key_rate, commodities), а также upper, lower, split, join, default и today.
+package prompts
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/config"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// RoleAssistant роль сообщения с инструкциями для модели
+	RoleAssistant = "assistant"
+	// RoleUser роль сообщения с данными и вопросом пользователя
+	RoleUser = "user"
+)
+
+// Argument аргумент шаблона
+type Argument struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	Required    bool   `yaml:"required"`
+}
+
+// Message сообщение шаблона; Content — текст text/template до выполнения и готовый текст после
+type Message struct {
+	Role    string `yaml:"role"`
+	Content string `yaml:"content"`
+}
+
+// Template шаблон MCP из файла оператора
+type Template struct {
+	Name        string     `yaml:"name"`
+	Description string     `yaml:"description"`
+	Arguments   []Argument `yaml:"arguments"`
+	// Features группы инструментов, данные которых использует шаблон: при отключении любой из них
+	// шаблон не регистрируется
+	Features []string  `yaml:"features"`
+	Messages []Message `yaml:"messages"`
+
+	// Path файл, из которого загружен шаблон
+	Path string `yaml:"-"`
+
+	parsed []*template.Template
+}
+
+// Resolver подставляет в сообщения шаблонов данные сервисов сервера
+type Resolver interface {
+	// Stock возвращает карточку акции с текущей котировкой
+	Stock(ctx context.Context, ticker string) (string, error)
+	// News возвращает не более limit новостей по тикеру
+	News(ctx context.Context, ticker string, limit int) (string, error)
+	// TodayNews возвращает не более limit новостей за сегодня
+	TodayNews(ctx context.Context, limit int) (string, error)
+	// TopGainers возвращает limit лидеров роста
+	TopGainers(ctx context.Context, limit int) (string, error)
+	// TopLosers возвращает limit лидеров падения
+	TopLosers(ctx context.Context, limit int) (string, error)
+	// Events возвращает предстоящие корпоративные события эмитента
+	Events(ctx context.Context, ticker string) (string, error)
+	// PriceTargets возвращает целевые цены бумаг в сравнении с текущими котировками
+	PriceTargets(ctx context.Context, tickers []string) (string, error)
+	// KeyRate возвращает ключевую ставку Банка России
+	KeyRate(ctx context.Context) (string, error)
+	// Commodities возвращает цены сырьевых товаров
+	Commodities(ctx context.Context) (string, error)
+}
+
+// namePattern допустимое имя шаблона
+var namePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// roleHeading заголовок Markdown, начинающий сообщение с ролью
+var roleHeading = regexp.MustCompile(`(?m)^#[ \t]+(assistant|user|system)[ \t]*$`)
+
+// Load загружает шаблоны из файлов *.yaml, *.yml и *.md каталога dir в порядке имен файлов;
+// пустой dir — шаблонов нет. Тексты сообщений разбираются сразу, чтобы ошибка в шаблоне
+// останавливала запуск, а не проявлялась только при запросе шаблона
+func Load(dir string) ([]*Template, error) {
+	if dir == "" {
+		return nil, nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось прочитать каталог шаблонов %s: %w", dir, err)
+	}
+
+	var paths []string
+	for _, entry := range entries {
+		switch strings.ToLower(filepath.Ext(entry.Name())) {
+		case ".yaml", ".yml", ".md":
+			if !entry.IsDir() {
+				paths = append(paths, filepath.Join(dir, entry.Name()))
+			}
+		}
+	}
+	sort.Strings(paths)
+
+	var templates []*Template
+	names := make(map[string]string)
+	for _, path := range paths {
+		t, err := loadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if other, ok := names[t.Name]; ok {
+			return nil, fmt.Errorf("шаблон %s описан дважды: в %s и %s", t.Name, other, path)
+		}
+		names[t.Name] = path
+		templates = append(templates, t)
+	}
+
+	return templates, nil
+}
+
+// loadFile загружает и проверяет шаблон из одного файла
+func loadFile(path string) (*Template, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка чтения шаблона %s: %w", path, err)
+	}
+
+	t := &Template{Path: path}
+	if strings.EqualFold(filepath.Ext(path), ".md") {
+		err = parseMarkdown(data, t)
+	} else {
+		err = yaml.Unmarshal(data, t)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ошибка разбора шаблона %s: %w", path, err)
+	}
+
+	if err := t.prepare(); err != nil {
+		return nil, fmt.Errorf("шаблон %s: %w", path, err)
+	}
+
+	return t, nil
+}
+
+// parseMarkdown разбирает вводный блок YAML и сообщения файла Markdown
+func parseMarkdown(data []byte, t *Template) error {
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	if !strings.HasPrefix(text, "---\n") {
+		return fmt.Errorf("файл должен начинаться с вводного блока YAML между строками ---")
+	}
+	front, body, ok := strings.Cut(text[len("---\n"):], "\n---\n")
+	if !ok {
+		return fmt.Errorf("не найден конец вводного блока YAML")
+	}
+	if err := yaml.Unmarshal([]byte(front), t); err != nil {
+		return err
+	}
+	if len(t.Messages) > 0 {
+		return fmt.Errorf("сообщения файла Markdown задаются текстом после вводного блока, а не полем messages")
+	}
+
+	headings := roleHeading.FindAllStringSubmatchIndex(body, -1)
+	if len(headings) == 0 {
+		t.Messages = []Message{{Role: RoleUser, Content: body}}
+		return nil
+	}
+	if leading := strings.TrimSpace(body[:headings[0][0]]); leading != "" {
+		return fmt.Errorf("текст перед первым заголовком роли: начните его с «# user» или «# assistant»")
+	}
+	for i, heading := range headings {
+		end := len(body)
+		if i+1 < len(headings) {
+			end = headings[i+1][0]
+		}
+		t.Messages = append(t.Messages, Message{
+			Role:    body[heading[2]:heading[3]],
+			Content: body[heading[1]:end],
+		})
+	}
+
+	return nil
+}
+
+// prepare проверяет поля шаблона и разбирает тексты сообщений
+func (t *Template) prepare() error {
+	if !namePattern.MatchString(t.Name) {
+		return fmt.Errorf("некорректное имя %q: допустимы строчные латинские буквы, цифры и подчеркивание", t.Name)
+	}
+	if t.Description == "" {
+		return fmt.Errorf("не указано описание")
+	}
+
+	seen := make(map[string]bool)
+	for _, arg := range t.Arguments {
+		if !namePattern.MatchString(arg.Name) {
+			return fmt.Errorf("некорректное имя аргумента %q", arg.Name)
+		}
+		if seen[arg.Name] {
+			return fmt.Errorf("аргумент %s указан дважды", arg.Name)
+		}
+		seen[arg.Name] = true
+	}
+
+	for _, feature := range t.Features {
+		if !slices.Contains(config.Features, feature) {
+			return fmt.Errorf("неизвестная группа инструментов %s, допустимы: %s", feature, strings.Join(config.Features, ", "))
+		}
+	}
+
+	if len(t.Messages) == 0 {
+		return fmt.Errorf("нет сообщений")
+	}
+	for i := range t.Messages {
+		message := &t.Messages[i]
+		// В MCP нет системной роли: инструкции для модели передаются сообщением ассистента, как во встроенных шаблонах
+		if message.Role == "system" {
+			message.Role = RoleAssistant
+		}
+		if message.Role != RoleAssistant && message.Role != RoleUser {
+			return fmt.Errorf("сообщение %d: неизвестная роль %q, допустимы assistant и user", i+1, message.Role)
+		}
+		message.Content = strings.TrimSpace(message.Content)
+
+		parsed, err := template.New(fmt.Sprintf("%s#%d", t.Name, i+1)).
+			Option("missingkey=zero").
+			Funcs(funcs(context.Background(), nil)).
+			Parse(message.Content)
+		if err != nil {
+			return err
+		}
+		t.parsed = append(t.parsed, parsed)
+	}
+
+	return nil
+}
+
+// Execute подставляет аргументы и данные resolver в сообщения шаблона.
+// Обязательные аргументы должны быть заполнены, аргументы, не описанные в шаблоне, не используются
+func (t *Template) Execute(ctx context.Context, resolver Resolver, args map[string]string) ([]Message, error) {
+	data := make(map[string]string, len(t.Arguments))
+	for _, arg := range t.Arguments {
+		value := strings.TrimSpace(args[arg.Name])
+		if arg.Required && value == "" {
+			return nil, fmt.Errorf("требуется параметр %s", arg.Name)
+		}
+		data[arg.Name] = value
+	}
+
+	messages := make([]Message, 0, len(t.parsed))
+	for i, parsed := range t.parsed {
+		// Функции зависят от контекста запроса, поэтому выполняется копия разобранного шаблона
+		clone, err := parsed.Clone()
+		if err != nil {
+			return nil, err
+		}
+
+		var buf bytes.Buffer
+		if err := clone.Funcs(funcs(ctx, resolver)).Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("шаблон %s: %w", t.Name, err)
+		}
+		messages = append(messages, Message{Role: t.Messages[i].Role, Content: strings.TrimSpace(buf.String())})
+	}
+
+	return messages, nil
+}
+
+// funcs функции сообщений шаблонов для запроса ctx. При разборе resolver равен nil: функции
+// только регистрируются по имени и не вызываются
+func funcs(ctx context.Context, r Resolver) template.FuncMap {
+	return template.FuncMap{
+		"stock": func(ticker string) (string, error) {
+			return r.Stock(ctx, ticker)
+		},
+		"news": func(ticker string, limit int) (string, error) {
+			return r.News(ctx, ticker, limit)
+		},
+		"today_news": func(limit int) (string, error) {
+			return r.TodayNews(ctx, limit)
+		},
+		"top_gainers": func(limit int) (string, error) {
+			return r.TopGainers(ctx, limit)
+		},
+		"top_losers": func(limit int) (string, error) {
+			return r.TopLosers(ctx, limit)
+		},
+		"events": func(ticker string) (string, error) {
+			return r.Events(ctx, ticker)
+		},
+		"targets": func(tickers string) (string, error) {
+			return r.PriceTargets(ctx, splitList(tickers, ","))
+		},
+		"key_rate": func() (string, error) {
+			return r.KeyRate(ctx)
+		},
+		"commodities": func() (string, error) {
+			return r.Commodities(ctx)
+		},
+		"upper": strings.ToUpper,
+		"lower": strings.ToLower,
+		"split": splitList,
+		"join":  strings.Join,
+		"default": func(fallback, value string) string {
+			if value == "" {
+				return fallback
+			}
+			return value
+		},
+		"today": func() string {
+			return time.Now().In(models.MoscowLocation).Format("02.01.2006")
+		},
+	}
+}
+
+// splitList разбивает строку по разделителю sep, отбрасывая пробелы и пустые элементы
+func splitList(value, sep string) []string {
+	var parts []string
+	for _, part := range strings.Split(value, sep) {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return parts
+}
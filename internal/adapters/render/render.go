@@ -0,0 +1,198 @@
+package render
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/i18n"
+)
+
+// Имена шаблонов результатов инструментов
+const (
+	StockInfo     = "get_stock_info"
+	TopGainers    = "get_top_gainers"
+	TopLosers     = "get_top_losers"
+	SearchStocks  = "search_stocks"
+	MarketBreadth = "get_market_breadth"
+	TodayNews     = "get_today_news"
+	SearchNews    = "search_news"
+	NewsByTicker  = "get_news_by_ticker"
+	NewsFullText  = "get_news_fulltext"
+	RelatedNews   = "get_related_news"
+	NewsTimeline  = "get_news_timeline"
+	NewsSummary   = "get_news_summary"
+	NewsBackfill  = "backfill_news"
+	FundInfo      = "get_etf_info"
+	SearchFunds   = "search_etfs"
+)
+
+// builtinFiles встроенные шаблоны результатов
+//
+//go:embed templates/*.tmpl
+var builtinFiles embed.FS
+
+// Renderer оформляет результаты инструментов по шаблонам text/template.
+// Встроенные шаблоны можно переопределить файлами *.tmpl из каталога конфигурации: шаблон с тем же именем
+// ({{define "get_stock_info"}}…{{end}}) заменяет встроенный, остальные остаются прежними.
+//
+// Кроме стандартных функций text/template в шаблонах доступны:
+//   - t — перевод строки формата на язык ответа и подстановка аргументов (как fmt.Sprintf);
+//   - add — сумма целых чисел, например номер строки {{add $.Page.Offset $i 1}};
+//   - date — время в заданном формате Go, например {{date .UpdatedAt "02.01.2006"}};
+//   - currency — обозначение валюты, в которой торгуется бумага с тикером;
+//   - join — строки через разделитель.
+type Renderer struct {
+	templates *template.Template
+}
+
+// Default возвращает оформление встроенными шаблонами
+func Default() *Renderer {
+	return &Renderer{templates: template.Must(builtin())}
+}
+
+// New загружает встроенные шаблоны и переопределяет их файлами *.tmpl из каталога dir; пустой dir — только встроенные
+func New(dir string) (*Renderer, error) {
+	templates, err := builtin()
+	if err != nil {
+		return nil, err
+	}
+	if dir == "" {
+		return &Renderer{templates: templates}, nil
+	}
+
+	if _, err := templates.ParseGlob(filepath.Join(dir, "*.tmpl")); err != nil {
+		return nil, fmt.Errorf("не удалось загрузить шаблоны из %s: %w", dir, err)
+	}
+
+	return &Renderer{templates: templates}, nil
+}
+
+// builtin разбирает встроенные шаблоны
+func builtin() (*template.Template, error) {
+	templates, err := template.New("").Funcs(funcs(i18n.NewPrinter(i18n.Default))).ParseFS(builtinFiles, "templates/*.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("не удалось разобрать встроенные шаблоны: %w", err)
+	}
+	return templates, nil
+}
+
+// Render оформляет данные шаблоном name на языке переводчика p
+func (r *Renderer) Render(p i18n.Printer, name string, data interface{}) (string, error) {
+	// Функция t зависит от языка вызова, поэтому выполняется копия набора шаблонов со своими функциями
+	templates, err := r.templates.Clone()
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := templates.Funcs(funcs(p)).ExecuteTemplate(&buf, name, data); err != nil {
+		return "", fmt.Errorf("шаблон %s: %w", name, err)
+	}
+	return buf.String(), nil
+}
+
+// funcs функции шаблонов для языка переводчика p
+func funcs(p i18n.Printer) template.FuncMap {
+	return template.FuncMap{
+		"t": p.Sprintf,
+		"add": func(values ...int) int {
+			sum := 0
+			for _, value := range values {
+				sum += value
+			}
+			return sum
+		},
+		"date": func(t time.Time, layout string) string {
+			return t.Format(layout)
+		},
+		"currency": CurrencySign,
+		"join": func(values []string, sep string) string {
+			return strings.Join(values, sep)
+		},
+	}
+}
+
+// currencySigns обозначения валют в ценах бумаг
+var currencySigns = map[string]string{
+	"RUB": "₽",
+	"USD": "$",
+	"EUR": "€",
+}
+
+// CurrencySign возвращает обозначение валюты, в которой торгуется бумага
+func CurrencySign(ticker string) string {
+	currency := models.TickerCurrency(ticker)
+	if sign, ok := currencySigns[currency]; ok {
+		return sign
+	}
+	return currency
+}
+
+// Page положение страницы в списке результатов
+type Page struct {
+	Offset int // Сколько результатов пропущено
+	Shown  int // Сколько результатов на странице
+	Total  int // Сколько результатов всего
+}
+
+// NewPage возвращает положение страницы page, на которой показано shown из total результатов
+func NewPage(page models.Pagination, shown, total int) Page {
+	return Page{Offset: page.Offset, Shown: shown, Total: total}
+}
+
+// FullPage возвращает положение единственной страницы, на которой показаны все shown результатов
+func FullPage(shown int) Page {
+	return Page{Shown: shown, Total: shown}
+}
+
+// End возвращает количество результатов до конца страницы включительно
+func (p Page) End() int {
+	return p.Offset + p.Shown
+}
+
+// HasNext сообщает, есть ли следующая страница
+func (p Page) HasNext() bool {
+	return p.End() < p.Total
+}
+
+// StockCard данные шаблона get_stock_info: котировка и спарклайн цен закрытия за последние дни
+type StockCard struct {
+	models.Stock
+	Sparkline string  // Пусто, если спарклайн отключен или истории нет
+	TrendDays int     // Торговых дней в спарклайне
+	TrendFrom float64 // Цена закрытия в первый день спарклайна
+	// MarginChecked означает, что маржинальные списки подключены; Margin — условия брокеров по бумаге
+	MarginChecked bool
+	Margin        []models.MarginStatus
+}
+
+// StockList данные шаблонов списков акций
+type StockList struct {
+	Query  string // Поисковый запрос (search_stocks)
+	Stocks []models.Stock
+	Page   Page
+}
+
+// FundList данные шаблона списка фондов
+type FundList struct {
+	Query string // Поисковый запрос; пусто — список без запроса
+	Funds []models.Fund
+	Page  Page
+}
+
+// NewsList данные шаблонов списков новостей
+type NewsList struct {
+	Query  string    // Ключевое слово (search_news)
+	Ticker string    // Тикер (get_news_by_ticker)
+	Date   time.Time // День новостей (get_today_news)
+	News   []models.News
+	Page   Page
+	// Omitted сколько новостей не поместилось в ограничение длины результата
+	Omitted int
+}
@@ -0,0 +1,232 @@
+package apis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/config"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/cache"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/readability"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/robots"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/timing"
+
+	"golang.org/x/text/encoding/htmlindex"
+)
+
+// ErrArticleDisallowed возвращается, если robots.txt сайта запрещает загружать страницу
+var ErrArticleDisallowed = errors.New("robots.txt сайта запрещает загружать эту страницу")
+
+// metaCharsetPattern кодировка из <meta charset> или <meta http-equiv="Content-Type">
+var metaCharsetPattern = regexp.MustCompile(`(?i)<meta[^>]+charset\s*=\s*["']?([\w-]+)`)
+
+// ArticleFetcher загружает страницы новостей и извлекает из них текст статей. Перед загрузкой проверяется
+// robots.txt сайта; извлеченный текст и robots.txt кэшируются
+type ArticleFetcher struct {
+	httpClient  *http.Client
+	cache       cache.Cache
+	cacheExpiry time.Duration
+	userAgent   string
+	maxBytes    int64
+}
+
+// NewArticleFetcher создает загрузчик статей. Страницы не сохраняются в архив необработанных ответов:
+// это не ответы API, и повторно разбирать их не нужно
+func NewArticleFetcher(cfg *config.Config, cache cache.Cache) *ArticleFetcher {
+	f := &ArticleFetcher{
+		cache:       cache,
+		cacheExpiry: cfg.Articles.CacheTTL,
+		userAgent:   cfg.Articles.UserAgent,
+		maxBytes:    int64(cfg.Articles.MaxBytes),
+	}
+	f.httpClient = &http.Client{
+		Timeout:       cfg.Articles.Timeout,
+		Transport:     &timing.Transport{},
+		CheckRedirect: f.checkRedirect,
+	}
+	return f
+}
+
+// maxArticleRedirects предел переадресаций при загрузке страницы, как у http.Client по умолчанию
+const maxArticleRedirects = 10
+
+// checkRedirect проверяет каждую переадресацию так же, как исходный адрес: переадресация может вести
+// на другой сайт или на страницу, которую robots.txt запрещает загружать
+func (f *ArticleFetcher) checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxArticleRedirects {
+		return fmt.Errorf("слишком много переадресаций")
+	}
+	// Переадресации самого robots.txt (например, с http на https) не проверяются: это не страница статьи
+	if via[0].URL.Path == "/robots.txt" {
+		return nil
+	}
+	return f.checkURL(req.Context(), req.URL)
+}
+
+// checkURL проверяет, что адрес указывает на страницу по HTTP(S) и robots.txt сайта разрешает ее загружать
+func (f *ArticleFetcher) checkURL(ctx context.Context, u *url.URL) error {
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("некорректный адрес статьи %q", u.String())
+	}
+	rules, err := f.robots(ctx, u)
+	if err != nil {
+		return err
+	}
+	if !rules.Allowed(u.RequestURI()) {
+		return ErrArticleDisallowed
+	}
+	return nil
+}
+
+// FetchArticle загружает страницу по адресу и извлекает из нее заголовок и текст статьи
+func (f *ArticleFetcher) FetchArticle(ctx context.Context, rawURL string) (*models.Article, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("некорректный адрес статьи %q", rawURL)
+	}
+
+	cacheKey := models.ArticleCacheKey(rawURL)
+	var article models.Article
+	switch err := f.cache.Get(ctx, cacheKey, &article); {
+	case err == nil:
+		return &article, nil
+	case !errors.Is(err, cache.ErrNotFound):
+		log.Printf("Ошибка чтения кэша %s: %v", cacheKey, err)
+	}
+
+	if err := f.checkURL(ctx, u); err != nil {
+		return nil, err
+	}
+
+	body, contentType, err := f.get(ctx, rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if mediaType, _, _ := mime.ParseMediaType(contentType); mediaType != "" && mediaType != "text/html" && mediaType != "application/xhtml+xml" {
+		return nil, fmt.Errorf("страница не является HTML-документом: %s", mediaType)
+	}
+
+	extracted := readability.Extract(decodeHTML(body, contentType))
+	if extracted.Text == "" {
+		return nil, fmt.Errorf("не удалось найти текст статьи на странице")
+	}
+
+	article = models.Article{
+		URL:       rawURL,
+		Title:     extracted.Title,
+		Text:      extracted.Text,
+		FetchedAt: time.Now(),
+	}
+	f.cache.Set(ctx, cacheKey, article, f.cacheExpiry)
+	return &article, nil
+}
+
+// robots возвращает правила robots.txt сайта для агента сервера. Отсутствующий robots.txt (4xx) ничего
+// не запрещает, а недоступный (5xx, ошибка сети) — запрещает все, как предписывает RFC 9309
+func (f *ArticleFetcher) robots(ctx context.Context, u *url.URL) (*robots.Rules, error) {
+	site := u.Scheme + "://" + u.Host
+	cacheKey := models.RobotsCacheKey(site)
+
+	var data string
+	switch err := f.cache.Get(ctx, cacheKey, &data); {
+	case err == nil:
+		return robots.Parse([]byte(data), f.userAgent), nil
+	case !errors.Is(err, cache.ErrNotFound):
+		log.Printf("Ошибка чтения кэша %s: %v", cacheKey, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, site+"/robots.txt", nil)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось создать запрос: %w", err)
+	}
+	req.Header.Set("User-Agent", f.userAgent)
+
+	resp, err := f.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось проверить robots.txt сайта %s: %w", u.Host, err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= http.StatusInternalServerError:
+		return nil, fmt.Errorf("robots.txt сайта %s недоступен: %s", u.Host, resp.Status)
+	case resp.StatusCode >= http.StatusBadRequest:
+		data = ""
+	default:
+		body, err := io.ReadAll(io.LimitReader(resp.Body, 512<<10))
+		if err != nil {
+			return nil, fmt.Errorf("ошибка чтения robots.txt сайта %s: %w", u.Host, err)
+		}
+		data = string(body)
+	}
+
+	f.cache.Set(ctx, cacheKey, data, f.cacheExpiry)
+	return robots.Parse([]byte(data), f.userAgent), nil
+}
+
+// get загружает страницу не больше maxBytes и возвращает ее содержимое и тип
+func (f *ArticleFetcher) get(ctx context.Context, rawURL string) ([]byte, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("не удалось создать запрос: %w", err)
+	}
+	req.Header.Set("User-Agent", f.userAgent)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+
+	resp, err := f.httpClient.Do(req)
+	if errors.Is(err, ErrArticleDisallowed) {
+		return nil, "", ErrArticleDisallowed
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("ошибка загрузки страницы: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("сайт вернул ошибку: %s", resp.Status)
+	}
+
+	// Страница длиннее предела обрезается: текст статьи обычно находится в ее начале
+	body, err := io.ReadAll(io.LimitReader(resp.Body, f.maxBytes))
+	if err != nil {
+		return nil, "", fmt.Errorf("ошибка чтения страницы: %w", err)
+	}
+	return body, resp.Header.Get("Content-Type"), nil
+}
+
+// decodeHTML переводит страницу в UTF-8 по кодировке из заголовка Content-Type или <meta charset>.
+// Многие русскоязычные сайты до сих пор отдают страницы в windows-1251
+func decodeHTML(body []byte, contentType string) string {
+	charset := ""
+	if _, params, err := mime.ParseMediaType(contentType); err == nil {
+		charset = params["charset"]
+	}
+	if charset == "" {
+		head := body[:min(len(body), 2048)]
+		if match := metaCharsetPattern.FindSubmatch(head); match != nil {
+			charset = string(match[1])
+		}
+	}
+	if charset == "" || strings.EqualFold(charset, "utf-8") {
+		return string(body)
+	}
+
+	encoding, err := htmlindex.Get(charset)
+	if err != nil {
+		return string(body)
+	}
+	decoded, err := encoding.NewDecoder().Bytes(body)
+	if err != nil {
+		return string(body)
+	}
+	return string(decoded)
+}
@@ -0,0 +1,217 @@
+package apis
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/config"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/cache"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/timing"
+)
+
+// CBRClient клиент веб-сервиса DailyInfo Банка России. Используются XML-варианты методов,
+// доступные обычным GET-запросом без SOAP-конверта
+type CBRClient struct {
+	baseURL     string
+	httpClient  *http.Client
+	cache       cache.Cache
+	cacheExpiry time.Duration
+}
+
+// NewCBRClient создает новый клиент веб-сервиса Банка России
+func NewCBRClient(cfg *config.Config, cache cache.Cache) *CBRClient {
+	return &CBRClient{
+		baseURL: cfg.CBR.BaseURL,
+		httpClient: &http.Client{
+			Timeout:   cfg.CBR.Timeout,
+			Transport: &timing.Transport{},
+		},
+		cache:       cache,
+		cacheExpiry: cfg.CBR.CacheTTL,
+	}
+}
+
+// cbrKeyRates ответ метода KeyRateXML
+type cbrKeyRates struct {
+	Rows []struct {
+		Date string  `xml:"DT"`
+		Rate float64 `xml:"Rate"`
+	} `xml:"KR"`
+}
+
+// cbrRUONIA ответ метода RuoniaXML
+type cbrRUONIA struct {
+	Rows []struct {
+		Date   string  `xml:"D0"`
+		Rate   float64 `xml:"ruo"`
+		Volume float64 `xml:"vol"`
+	} `xml:"ro"`
+}
+
+// cbrCurs ответ метода GetCursOnDateXML
+type cbrCurs struct {
+	Rows []struct {
+		Name     string  `xml:"Vname"`
+		Nominal  int     `xml:"Vnom"`
+		Rate     float64 `xml:"Vcurs"`
+		Code     string  `xml:"VchCode"`
+		UnitRate float64 `xml:"VunitRate"`
+	} `xml:"ValuteCursOnDate"`
+}
+
+// GetKeyRates получает значения ключевой ставки за период в хронологическом порядке
+func (c *CBRClient) GetKeyRates(ctx context.Context, from, till time.Time) ([]models.KeyRate, error) {
+	cacheKey := fmt.Sprintf("cbr:keyrate:%s:%s", from.Format("2006-01-02"), till.Format("2006-01-02"))
+
+	var rates []models.KeyRate
+	switch err := c.cache.Get(ctx, cacheKey, &rates); {
+	case err == nil:
+		return rates, nil
+	case !errors.Is(err, cache.ErrNotFound):
+		log.Printf("Ошибка чтения кэша %s: %v", cacheKey, err)
+	}
+
+	var response cbrKeyRates
+	if err := c.get(ctx, "KeyRateXML", periodParams(from, till), &response); err != nil {
+		return nil, err
+	}
+
+	for _, row := range response.Rows {
+		date, err := time.Parse(time.RFC3339, strings.TrimSpace(row.Date))
+		if err != nil {
+			continue
+		}
+		rates = append(rates, models.KeyRate{Date: date, Rate: row.Rate})
+	}
+	sort.Slice(rates, func(i, j int) bool { return rates[i].Date.Before(rates[j].Date) })
+
+	if len(rates) > 0 {
+		c.cache.Set(ctx, cacheKey, rates, c.cacheExpiry)
+	}
+
+	return rates, nil
+}
+
+// GetRUONIA получает значения ставки RUONIA за период в хронологическом порядке
+func (c *CBRClient) GetRUONIA(ctx context.Context, from, till time.Time) ([]models.RUONIARate, error) {
+	cacheKey := fmt.Sprintf("cbr:ruonia:%s:%s", from.Format("2006-01-02"), till.Format("2006-01-02"))
+
+	var rates []models.RUONIARate
+	switch err := c.cache.Get(ctx, cacheKey, &rates); {
+	case err == nil:
+		return rates, nil
+	case !errors.Is(err, cache.ErrNotFound):
+		log.Printf("Ошибка чтения кэша %s: %v", cacheKey, err)
+	}
+
+	var response cbrRUONIA
+	if err := c.get(ctx, "RuoniaXML", periodParams(from, till), &response); err != nil {
+		return nil, err
+	}
+
+	for _, row := range response.Rows {
+		date, err := time.Parse(time.RFC3339, strings.TrimSpace(row.Date))
+		if err != nil {
+			continue
+		}
+		rates = append(rates, models.RUONIARate{Date: date, Rate: row.Rate, VolumeBln: row.Volume})
+	}
+	sort.Slice(rates, func(i, j int) bool { return rates[i].Date.Before(rates[j].Date) })
+
+	if len(rates) > 0 {
+		c.cache.Set(ctx, cacheKey, rates, c.cacheExpiry)
+	}
+
+	return rates, nil
+}
+
+// GetOfficialFXRates получает официальные курсы всех валют на дату
+func (c *CBRClient) GetOfficialFXRates(ctx context.Context, date time.Time) ([]models.OfficialFXRate, error) {
+	day := date.In(models.MoscowLocation).Format("2006-01-02")
+	cacheKey := fmt.Sprintf("cbr:fx:%s", day)
+
+	var rates []models.OfficialFXRate
+	switch err := c.cache.Get(ctx, cacheKey, &rates); {
+	case err == nil:
+		return rates, nil
+	case !errors.Is(err, cache.ErrNotFound):
+		log.Printf("Ошибка чтения кэша %s: %v", cacheKey, err)
+	}
+
+	params := url.Values{}
+	params.Add("On_date", day)
+
+	var response cbrCurs
+	if err := c.get(ctx, "GetCursOnDateXML", params, &response); err != nil {
+		return nil, err
+	}
+
+	onDate, _ := time.ParseInLocation("2006-01-02", day, models.MoscowLocation)
+	for _, row := range response.Rows {
+		rate := models.OfficialFXRate{
+			Code:     strings.TrimSpace(row.Code),
+			Name:     strings.TrimSpace(row.Name),
+			Nominal:  row.Nominal,
+			Rate:     row.Rate,
+			UnitRate: row.UnitRate,
+			Date:     onDate,
+		}
+		if rate.UnitRate == 0 && rate.Nominal > 0 {
+			rate.UnitRate = rate.Rate / float64(rate.Nominal)
+		}
+		rates = append(rates, rate)
+	}
+
+	if len(rates) > 0 {
+		c.cache.Set(ctx, cacheKey, rates, c.cacheExpiry)
+	}
+
+	return rates, nil
+}
+
+// periodParams параметры периода для методов веб-сервиса
+func periodParams(from, till time.Time) url.Values {
+	params := url.Values{}
+	params.Add("fromDate", from.In(models.MoscowLocation).Format("2006-01-02"))
+	params.Add("ToDate", till.In(models.MoscowLocation).Format("2006-01-02"))
+	return params
+}
+
+// get вызывает метод веб-сервиса и разбирает XML-ответ в result
+func (c *CBRClient) get(ctx context.Context, method string, params url.Values, result interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/"+method+"?"+params.Encode(), nil)
+	if err != nil {
+		return fmt.Errorf("не удалось создать запрос: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("ошибка выполнения запроса: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("ошибка веб-сервиса Банка России: %s", resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("ошибка чтения ответа: %w", err)
+	}
+
+	if err := xml.Unmarshal(body, result); err != nil {
+		return fmt.Errorf("ошибка при разборе XML: %w", err)
+	}
+
+	return nil
+}
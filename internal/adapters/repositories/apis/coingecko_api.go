@@ -0,0 +1,124 @@
+package apis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/config"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/cache"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/timing"
+)
+
+// CoinGeckoClient клиент API котировок криптовалют CoinGecko
+type CoinGeckoClient struct {
+	baseURL     string
+	httpClient  *http.Client
+	cache       cache.Cache
+	cacheExpiry time.Duration
+	apiKey      string
+}
+
+// NewCoinGeckoClient создает новый клиент CoinGecko. Ответы не сохраняются в архив
+// необработанных ответов: повторный разбор котировок криптовалют не нужен
+func NewCoinGeckoClient(cfg *config.Config, cache cache.Cache) *CoinGeckoClient {
+	return &CoinGeckoClient{
+		baseURL: cfg.Crypto.BaseURL,
+		httpClient: &http.Client{
+			Timeout:   cfg.Crypto.Timeout,
+			Transport: &timing.Transport{},
+		},
+		cache:       cache,
+		cacheExpiry: cfg.Crypto.CacheTTL,
+		apiKey:      cfg.Crypto.APIKey,
+	}
+}
+
+// coinGeckoPrice цена монеты в ответе /simple/price
+type coinGeckoPrice struct {
+	USD          float64 `json:"usd"`
+	RUB          float64 `json:"rub"`
+	USDChange    float64 `json:"usd_24h_change"`
+	USDMarketCap float64 `json:"usd_market_cap"`
+	USDVolume    float64 `json:"usd_24h_vol"`
+	UpdatedAt    int64   `json:"last_updated_at"`
+}
+
+// GetCryptoQuotes получает котировки монет одним запросом; монеты, которых CoinGecko не знает, пропускаются
+func (c *CoinGeckoClient) GetCryptoQuotes(ctx context.Context, coinIDs []string) (map[string]models.CryptoQuote, error) {
+	ids := append([]string(nil), coinIDs...)
+	sort.Strings(ids)
+	cacheKey := fmt.Sprintf("crypto:prices:%s", strings.Join(ids, ","))
+
+	var quotes map[string]models.CryptoQuote
+	switch err := c.cache.Get(ctx, cacheKey, &quotes); {
+	case err == nil:
+		return quotes, nil
+	case !errors.Is(err, cache.ErrNotFound):
+		log.Printf("Ошибка чтения кэша %s: %v", cacheKey, err)
+	}
+
+	params := url.Values{}
+	params.Add("ids", strings.Join(ids, ","))
+	params.Add("vs_currencies", "usd,rub")
+	params.Add("include_market_cap", "true")
+	params.Add("include_24hr_vol", "true")
+	params.Add("include_24hr_change", "true")
+	params.Add("include_last_updated_at", "true")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/simple/price?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось создать запрос: %w", err)
+	}
+	if c.apiKey != "" {
+		req.Header.Set("x-cg-demo-api-key", c.apiKey)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка выполнения запроса: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("ошибка API CoinGecko: %s", resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка чтения ответа: %w", err)
+	}
+
+	var prices map[string]coinGeckoPrice
+	if err := json.Unmarshal(body, &prices); err != nil {
+		return nil, fmt.Errorf("ошибка при разборе JSON: %w", err)
+	}
+
+	quotes = make(map[string]models.CryptoQuote, len(prices))
+	for id, price := range prices {
+		quotes[id] = models.CryptoQuote{
+			CoinID:       id,
+			PriceUSD:     price.USD,
+			PriceRUB:     price.RUB,
+			ChangePerc:   price.USDChange,
+			MarketCapUSD: price.USDMarketCap,
+			VolumeUSD:    price.USDVolume,
+			UpdatedAt:    time.Unix(price.UpdatedAt, 0),
+		}
+	}
+
+	if len(quotes) > 0 {
+		c.cache.Set(ctx, cacheKey, quotes, c.cacheExpiry)
+	}
+
+	return quotes, nil
+}
@@ -0,0 +1,107 @@
+package apis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// corporateEventFeedEntry запись файла календаря корпоративных событий
+type corporateEventFeedEntry struct {
+	// ID постоянный идентификатор события: при переносе события на другую дату запись заменяется, а не дублируется
+	ID      string `json:"id"`
+	Ticker  string `json:"ticker"`
+	Type    string `json:"type"`     // earnings, agm, buyback или dividend
+	Date    string `json:"date"`     // YYYY-MM-DD
+	EndDate string `json:"end_date"` // YYYY-MM-DD, для программ выкупа
+	Title   string `json:"title"`
+	Details string `json:"details"`
+}
+
+// corporateEventTitles заголовки событий по умолчанию
+var corporateEventTitles = map[string]string{
+	models.EventTypeEarnings: "Публикация финансовой отчетности",
+	models.EventTypeAGM:      "Собрание акционеров",
+	models.EventTypeBuyback:  "Программа обратного выкупа акций",
+	models.EventTypeDividend: "Закрытие реестра под дивиденды",
+}
+
+// CorporateEventFeedFile календарь корпоративных событий из JSON-файла, который ведет оператор сервера.
+// ISS не публикует даты отчетности, собраний и программ выкупа, поэтому они берутся из этого файла
+type CorporateEventFeedFile struct {
+	path string
+}
+
+// NewCorporateEventFeedFile создает источник корпоративных событий из файла
+func NewCorporateEventFeedFile(path string) *CorporateEventFeedFile {
+	return &CorporateEventFeedFile{
+		path: path,
+	}
+}
+
+// Name возвращает название источника
+func (f *CorporateEventFeedFile) Name() string {
+	return fmt.Sprintf("файл %s", f.path)
+}
+
+// EventSource возвращает код источника: файл содержит весь календарь, поэтому события,
+// удаленные из него, удаляются и из базы
+func (f *CorporateEventFeedFile) EventSource() string {
+	return models.EventSourceFeed
+}
+
+// GetCorporateEvents читает все события файла независимо от списка эмитентов
+func (f *CorporateEventFeedFile) GetCorporateEvents(ctx context.Context, tickers []string) ([]models.CorporateEvent, error) {
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка чтения календаря событий: %w", err)
+	}
+
+	var entries []corporateEventFeedEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("ошибка разбора календаря событий %s: %w", f.path, err)
+	}
+
+	events := make([]models.CorporateEvent, 0, len(entries))
+	for i, entry := range entries {
+		ticker := strings.ToUpper(strings.TrimSpace(entry.Ticker))
+		if ticker == "" {
+			return nil, fmt.Errorf("запись %d календаря событий: не указан тикер", i+1)
+		}
+		if _, ok := corporateEventTitles[entry.Type]; !ok {
+			return nil, fmt.Errorf("запись %s календаря событий: неизвестный тип события %s", ticker, entry.Type)
+		}
+
+		event := models.CorporateEvent{
+			Ticker:    ticker,
+			Type:      entry.Type,
+			Title:     entry.Title,
+			Details:   entry.Details,
+			Source:    models.EventSourceFeed,
+			UpdatedAt: time.Now(),
+		}
+		if event.Title == "" {
+			event.Title = corporateEventTitles[entry.Type]
+		}
+		if event.Date, err = time.ParseInLocation("2006-01-02", entry.Date, moexLocation); err != nil {
+			return nil, fmt.Errorf("запись %s календаря событий: некорректная дата %s", ticker, entry.Date)
+		}
+		if entry.EndDate != "" {
+			if event.EndDate, err = time.ParseInLocation("2006-01-02", entry.EndDate, moexLocation); err != nil {
+				return nil, fmt.Errorf("запись %s календаря событий: некорректная дата окончания %s", ticker, entry.EndDate)
+			}
+		}
+		event.SourceID = strings.TrimSpace(entry.ID)
+		if event.SourceID == "" {
+			event.SourceID = models.EventSourceID(event.Date)
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,183 @@
+package apis
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/repositories"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// ExchangeRouter направляет запросы котировок клиенту биржи, указанной в тикере (MOEX:SBER).
+// Сам реализует ExchangeClient, поэтому репозитории акций работают с ним как с одной биржей
+type ExchangeRouter struct {
+	clients map[string]repositories.ExchangeClient
+}
+
+// NewExchangeRouter создает маршрутизатор по включенным биржам. available содержит все клиенты,
+// которые умеет создавать сервер; биржа MOEX обязательна, так как к ней относятся тикеры без префикса
+func NewExchangeRouter(enabled []string, available ...repositories.ExchangeClient) (*ExchangeRouter, error) {
+	known := make(map[string]repositories.ExchangeClient, len(available))
+	for _, client := range available {
+		known[client.Exchange()] = client
+	}
+
+	r := &ExchangeRouter{clients: make(map[string]repositories.ExchangeClient, len(enabled))}
+	for _, name := range enabled {
+		name = strings.ToUpper(strings.TrimSpace(name))
+		client, ok := known[name]
+		if !ok {
+			return nil, fmt.Errorf("неизвестная биржа %s", name)
+		}
+		r.clients[name] = client
+	}
+
+	if _, ok := r.clients[models.ExchangeMOEX]; !ok {
+		return nil, fmt.Errorf("биржа %s должна быть включена", models.ExchangeMOEX)
+	}
+
+	return r, nil
+}
+
+// Exchange возвращает код биржи по умолчанию
+func (r *ExchangeRouter) Exchange() string {
+	return models.ExchangeMOEX
+}
+
+// Exchanges возвращает коды включенных бирж в алфавитном порядке
+func (r *ExchangeRouter) Exchanges() []string {
+	names := make([]string, 0, len(r.clients))
+	for name := range r.clients {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetStock возвращает котировку бумаги с биржи, указанной в тикере
+func (r *ExchangeRouter) GetStock(ctx context.Context, ticker string) (*models.Stock, error) {
+	exchange, symbol, client, err := r.route(ticker)
+	if err != nil {
+		return nil, err
+	}
+
+	stock, err := client.GetStock(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+	stock.Ticker = models.QualifyTicker(exchange, stock.Ticker)
+
+	return stock, nil
+}
+
+// GetBoardStock возвращает котировку бумаги в выбранном режиме торгов. Режимы торгов поддерживают не все биржи
+func (r *ExchangeRouter) GetBoardStock(ctx context.Context, ticker string, board models.TradingBoard) (*models.Stock, error) {
+	if board.IsZero() {
+		return r.GetStock(ctx, ticker)
+	}
+
+	exchange, symbol, client, err := r.route(ticker)
+	if err != nil {
+		return nil, err
+	}
+	boardClient, ok := client.(repositories.BoardExchangeClient)
+	if !ok {
+		return nil, fmt.Errorf("биржа %s не поддерживает выбор режима торгов", exchange)
+	}
+
+	stock, err := boardClient.GetBoardStock(ctx, symbol, board)
+	if err != nil {
+		return nil, err
+	}
+	stock.Ticker = models.QualifyTicker(exchange, stock.Ticker)
+
+	return stock, nil
+}
+
+// GetStocks возвращает котировки бумаг, группируя запросы по биржам. Порядок бумаг сохраняется
+func (r *ExchangeRouter) GetStocks(ctx context.Context, tickers []string) ([]models.Stock, error) {
+	symbols := make(map[string][]string)
+	var order []string
+	for _, ticker := range tickers {
+		exchange, symbol, _, err := r.route(ticker)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := symbols[exchange]; !ok {
+			order = append(order, exchange)
+		}
+		symbols[exchange] = append(symbols[exchange], symbol)
+	}
+
+	// Биржи опрашиваются одновременно; число запросов внутри биржи ограничивает пул ее клиента
+	var mu sync.Mutex
+	byTicker := make(map[string]models.Stock, len(tickers))
+	g, gctx := errgroup.WithContext(ctx)
+	for _, exchange := range order {
+		g.Go(func() error {
+			stocks, err := r.clients[exchange].GetStocks(gctx, symbols[exchange])
+			if err != nil {
+				return fmt.Errorf("ошибка получения котировок %s: %w", exchange, err)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			for _, stock := range stocks {
+				stock.Ticker = models.QualifyTicker(exchange, stock.Ticker)
+				byTicker[stock.Ticker] = stock
+			}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	result := make([]models.Stock, 0, len(byTicker))
+	for _, ticker := range tickers {
+		if stock, ok := byTicker[models.NormalizeTicker(ticker)]; ok {
+			result = append(result, stock)
+			delete(byTicker, stock.Ticker)
+		}
+	}
+
+	return result, nil
+}
+
+// GetCandles возвращает свечи бумаги с биржи, указанной в тикере
+func (r *ExchangeRouter) GetCandles(ctx context.Context, ticker, interval string, from, till time.Time) ([]models.StockQuote, error) {
+	exchange, symbol, client, err := r.route(ticker)
+	if err != nil {
+		return nil, err
+	}
+
+	candles, err := client.GetCandles(ctx, symbol, interval, from, till)
+	if err != nil {
+		return nil, err
+	}
+	for i := range candles {
+		candles[i].Ticker = models.QualifyTicker(exchange, candles[i].Ticker)
+	}
+
+	return candles, nil
+}
+
+// route определяет биржу тикера и ее клиент
+func (r *ExchangeRouter) route(ticker string) (string, string, repositories.ExchangeClient, error) {
+	exchange, symbol := models.ParseQualifiedTicker(ticker)
+	if symbol == "" {
+		return "", "", nil, fmt.Errorf("не указан тикер в %q", ticker)
+	}
+
+	client, ok := r.clients[exchange]
+	if !ok {
+		return "", "", nil, fmt.Errorf("биржа %s не поддерживается, доступны: %s", exchange, strings.Join(r.Exchanges(), ", "))
+	}
+
+	return exchange, symbol, client, nil
+}
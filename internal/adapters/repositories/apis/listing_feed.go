@@ -0,0 +1,82 @@
+package apis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// listingFeedEntry запись файла календаря размещений
+type listingFeedEntry struct {
+	Ticker       string `json:"ticker"`
+	Name         string `json:"name"`
+	Kind         string `json:"kind"`          // ipo, spo или listing; по умолчанию ipo
+	ExpectedDate string `json:"expected_date"` // YYYY-MM-DD; пусто — дата не объявлена
+	PriceRange   string `json:"price_range"`
+	Notes        string `json:"notes"`
+}
+
+// ListingFeedFile календарь объявленных размещений из JSON-файла, который ведет оператор сервера.
+// ISS узнает о бумаге только к началу торгов, поэтому будущие IPO берутся из этого файла
+type ListingFeedFile struct {
+	path string
+}
+
+// NewListingFeedFile создает источник объявленных размещений из файла
+func NewListingFeedFile(path string) *ListingFeedFile {
+	return &ListingFeedFile{
+		path: path,
+	}
+}
+
+// GetPlannedListings читает файл календаря; файл перечитывается при каждой сверке, чтобы правки применялись без перезапуска
+func (f *ListingFeedFile) GetPlannedListings(ctx context.Context) ([]models.Listing, error) {
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка чтения календаря размещений: %w", err)
+	}
+
+	var entries []listingFeedEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("ошибка разбора календаря размещений %s: %w", f.path, err)
+	}
+
+	listings := make([]models.Listing, 0, len(entries))
+	for i, entry := range entries {
+		ticker := strings.ToUpper(strings.TrimSpace(entry.Ticker))
+		if ticker == "" {
+			return nil, fmt.Errorf("запись %d календаря размещений: не указан тикер", i+1)
+		}
+
+		listing := models.Listing{
+			Ticker:     ticker,
+			Name:       entry.Name,
+			Kind:       entry.Kind,
+			Status:     models.ListingStatusUpcoming,
+			Source:     models.ListingSourceFeed,
+			PriceRange: entry.PriceRange,
+			Notes:      entry.Notes,
+		}
+		switch listing.Kind {
+		case "":
+			listing.Kind = models.ListingKindIPO
+		case models.ListingKindIPO, models.ListingKindSPO, models.ListingKindDirect:
+		default:
+			return nil, fmt.Errorf("запись %s календаря размещений: неизвестный вид размещения %s", ticker, entry.Kind)
+		}
+		if entry.ExpectedDate != "" {
+			listing.ExpectedDate, err = time.ParseInLocation("2006-01-02", entry.ExpectedDate, moexLocation)
+			if err != nil {
+				return nil, fmt.Errorf("запись %s календаря размещений: некорректная дата %s", ticker, entry.ExpectedDate)
+			}
+		}
+		listings = append(listings, listing)
+	}
+
+	return listings, nil
+}
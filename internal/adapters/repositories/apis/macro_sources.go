@@ -0,0 +1,105 @@
+package apis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/repositories"
+)
+
+// macroKeyRateHistoryYears глубина истории ключевой ставки, загружаемой в макропоказатели, лет
+const macroKeyRateHistoryYears = 5
+
+// CBRMacroSource макропоказатели Банка России: история ключевой ставки и официальный курс доллара
+type CBRMacroSource struct {
+	cbr repositories.CBRSource
+}
+
+// NewCBRMacroSource создает источник макропоказателей на основе веб-сервиса Банка России
+func NewCBRMacroSource(cbr repositories.CBRSource) *CBRMacroSource {
+	return &CBRMacroSource{cbr: cbr}
+}
+
+// Name возвращает название источника
+func (s *CBRMacroSource) Name() string {
+	return "Банк России"
+}
+
+// GetMacroIndicators возвращает изменения ключевой ставки и официальный курс доллара на сегодня.
+// Ставка публикуется на каждый рабочий день, поэтому сохраняются только дни ее изменения
+func (s *CBRMacroSource) GetMacroIndicators(ctx context.Context) ([]models.MacroIndicator, error) {
+	now := time.Now()
+
+	rates, err := s.cbr.GetKeyRates(ctx, now.AddDate(-macroKeyRateHistoryYears, 0, 0), now)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения ключевой ставки: %w", err)
+	}
+
+	var indicators []models.MacroIndicator
+	for i, rate := range rates {
+		if i > 0 && rates[i-1].Rate == rate.Rate {
+			continue
+		}
+		indicators = append(indicators, models.MacroIndicator{
+			Code:      models.MacroKeyRate,
+			Period:    rate.Date,
+			Value:     rate.Rate,
+			Source:    models.MacroSourceCBR,
+			UpdatedAt: now,
+		})
+	}
+
+	fxRates, err := s.cbr.GetOfficialFXRates(ctx, now)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения официальных курсов: %w", err)
+	}
+	for _, rate := range fxRates {
+		if rate.Code == "USD" {
+			indicators = append(indicators, models.MacroIndicator{
+				Code:      models.MacroUSDRUB,
+				Period:    rate.Date,
+				Value:     rate.UnitRate,
+				Source:    models.MacroSourceCBR,
+				UpdatedAt: now,
+			})
+		}
+	}
+
+	return indicators, nil
+}
+
+// MOEXMacroSource макропоказатели по котировкам MOEX: цена нефти Brent по ближайшему фьючерсу
+type MOEXMacroSource struct {
+	commodities repositories.CommoditySource
+}
+
+// NewMOEXMacroSource создает источник макропоказателей на основе срочного рынка MOEX
+func NewMOEXMacroSource(commodities repositories.CommoditySource) *MOEXMacroSource {
+	return &MOEXMacroSource{commodities: commodities}
+}
+
+// Name возвращает название источника
+func (s *MOEXMacroSource) Name() string {
+	return "MOEX"
+}
+
+// GetMacroIndicators возвращает текущую цену Brent как значение за сегодняшний день;
+// история накапливается при каждой загрузке
+func (s *MOEXMacroSource) GetMacroIndicators(ctx context.Context) ([]models.MacroIndicator, error) {
+	spec, _ := models.FindCommodity(models.CommodityBrent)
+	futures, err := s.commodities.GetFrontFutures(ctx, spec.AssetCode)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения цены Brent: %w", err)
+	}
+
+	now := time.Now().In(moexLocation)
+	return []models.MacroIndicator{{
+		Code:      models.MacroBrent,
+		Period:    time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, moexLocation),
+		Value:     futures.Price,
+		Source:    models.MacroSourceMOEX,
+		UpdatedAt: time.Now(),
+	}}, nil
+}
@@ -0,0 +1,91 @@
+package apis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// marginFeedEntry запись файла маржинальных списков брокеров
+type marginFeedEntry struct {
+	Ticker        string  `json:"ticker"`
+	Broker        string  `json:"broker"`
+	Long          bool    `json:"long"`
+	Short         bool    `json:"short"`
+	RiskRateLong  float64 `json:"risk_rate_long"`  // %, необязательно
+	RiskRateShort float64 `json:"risk_rate_short"` // %, необязательно
+	ShortFee      float64 `json:"short_fee"`       // % годовых за перенос короткой позиции, необязательно
+	Date          string  `json:"date"`            // YYYY-MM-DD, дата списка брокера
+}
+
+// MarginFeedFile маржинальные списки брокеров из JSON-файла, который ведет оператор сервера.
+// ISS не публикует, какие бумаги брокеры дают в шорт и с каким плечом, поэтому списки берутся из этого файла
+type MarginFeedFile struct {
+	path string
+}
+
+// NewMarginFeedFile создает источник маржинальных списков из файла
+func NewMarginFeedFile(path string) *MarginFeedFile {
+	return &MarginFeedFile{
+		path: path,
+	}
+}
+
+// Name возвращает название источника
+func (f *MarginFeedFile) Name() string {
+	return fmt.Sprintf("файл %s", f.path)
+}
+
+// GetMarginList читает все записи файла
+func (f *MarginFeedFile) GetMarginList(ctx context.Context) ([]models.MarginStatus, error) {
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка чтения файла маржинальных списков: %w", err)
+	}
+
+	var entries []marginFeedEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("ошибка разбора файла маржинальных списков %s: %w", f.path, err)
+	}
+
+	statuses := make([]models.MarginStatus, 0, len(entries))
+	for i, entry := range entries {
+		ticker := strings.ToUpper(strings.TrimSpace(entry.Ticker))
+		if ticker == "" {
+			return nil, fmt.Errorf("запись %d файла маржинальных списков: не указан тикер", i+1)
+		}
+		broker := strings.TrimSpace(entry.Broker)
+		if broker == "" {
+			return nil, fmt.Errorf("запись %s файла маржинальных списков: не указан брокер", ticker)
+		}
+		for _, rate := range []float64{entry.RiskRateLong, entry.RiskRateShort} {
+			if rate < 0 || rate > 100 {
+				return nil, fmt.Errorf("запись %s от %s файла маржинальных списков: ставка риска должна быть от 0 до 100%%", ticker, broker)
+			}
+		}
+		if entry.ShortFee < 0 {
+			return nil, fmt.Errorf("запись %s от %s файла маржинальных списков: плата за шорт не может быть отрицательной", ticker, broker)
+		}
+
+		status := models.MarginStatus{
+			Ticker:        ticker,
+			Broker:        broker,
+			Long:          entry.Long,
+			Short:         entry.Short,
+			RiskRateLong:  entry.RiskRateLong,
+			RiskRateShort: entry.RiskRateShort,
+			ShortFeePerc:  entry.ShortFee,
+		}
+		if status.UpdatedAt, err = time.ParseInLocation("2006-01-02", entry.Date, moexLocation); err != nil {
+			return nil, fmt.Errorf("запись %s от %s файла маржинальных списков: некорректная дата %s", ticker, broker, entry.Date)
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
@@ -3,8 +3,10 @@ package apis
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
 	"strconv"
 	"time"
@@ -12,6 +14,7 @@ import (
 	"github.com/JkLondon/mcp-stocks-info-server/internal/config"
 	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
 	"github.com/JkLondon/mcp-stocks-info-server/pkg/cache"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/workpool"
 )
 
 // MOEXAPIClient представляет собой клиент для работы с API MOEX
@@ -20,71 +23,82 @@ type MOEXAPIClient struct {
 	httpClient  *http.Client
 	cache       cache.Cache
 	cacheExpiry time.Duration
-	apiKey      string
-	useCache    bool
+	// orderBookTTL срок кэширования стакана, который устаревает значительно быстрее котировок
+	orderBookTTL time.Duration
+	apiKey       string
+	useCache     bool
+	maintenance  *maintenanceGuard
+	pool         *workpool.Pool // Ограничивает число параллельных запросов GetStocks
 }
 
-// NewMOEXAPIClient создает новый клиент для работы с API MOEX
-func NewMOEXAPIClient(cfg *config.Config, cache cache.Cache) *MOEXAPIClient {
+// NewMOEXAPIClient создает новый клиент для работы с API MOEX; pool ограничивает параллельные запросы,
+// nil — запросы выполняются последовательно. ctx — контекст приложения, с ним останавливается
+// фоновая проверка окончания технического обслуживания
+func NewMOEXAPIClient(ctx context.Context, cfg *config.Config, cache cache.Cache, pool *workpool.Pool) *MOEXAPIClient {
+	httpClient := newHTTPClient(cfg, cfg.MOEX.Timeout)
+
+	// Во время технического обслуживания ISS запросы не отправляются, а доступность проверяется в фоне
+	probeURL := fmt.Sprintf("%s/index.json?iss.meta=off&iss.only=engines", cfg.MOEX.BaseURL)
+	maintenance := newMaintenanceGuard(ctx, httpClient.Transport, probeURL, cfg.MOEX.MaintenanceProbeInterval)
+	httpClient.Transport = maintenance
+
 	return &MOEXAPIClient{
-		baseURL: cfg.MOEX.BaseURL,
-		httpClient: &http.Client{
-			Timeout: cfg.MOEX.Timeout,
-		},
-		cache:       cache,
-		cacheExpiry: cfg.Cache.StocksTTL,
-		apiKey:      cfg.MOEX.APIKey,
-		useCache:    cfg.MOEX.UseCache,
+		baseURL:      cfg.MOEX.BaseURL,
+		httpClient:   httpClient,
+		cache:        cache,
+		cacheExpiry:  cfg.Cache.StocksTTL,
+		orderBookTTL: cfg.Cache.OrderBookTTL,
+		apiKey:       cfg.MOEX.APIKey,
+		useCache:     cfg.MOEX.UseCache,
+		maintenance:  maintenance,
+		pool:         pool,
 	}
 }
 
-// GetStock получает информацию о котировке акции по тикеру
+// Exchange возвращает код Московской биржи
+func (m *MOEXAPIClient) Exchange() string {
+	return models.ExchangeMOEX
+}
+
+// GetStock получает котировку бумаги в ее основном режиме торгов
 func (m *MOEXAPIClient) GetStock(ctx context.Context, ticker string) (*models.Stock, error) {
-	cacheKey := fmt.Sprintf("moex:stock:%s", ticker)
+	return m.GetBoardStock(ctx, ticker, models.TradingBoard{})
+}
+
+// GetBoardStock получает котировку бумаги в режиме торгов board: акции — в TQBR, биржевые фонды — в TQTF,
+// иностранные акции — в FQBR на рынке foreignshares. Без режима используется основной режим бумаги
+func (m *MOEXAPIClient) GetBoardStock(ctx context.Context, ticker string, board models.TradingBoard) (*models.Stock, error) {
+	cacheKey := "moex:" + models.BoardStockCacheKey(ticker, board)
 
 	if m.useCache {
 		var cachedStock models.Stock
-		err := m.cache.Get(ctx, cacheKey, &cachedStock)
-		if err == nil && cachedStock.Ticker != "" {
+		switch err := m.cache.Get(ctx, cacheKey, &cachedStock); {
+		case err == nil:
 			return &cachedStock, nil
+		case !errors.Is(err, cache.ErrNotFound):
+			log.Printf("Ошибка чтения кэша %s: %v", cacheKey, err)
 		}
 	}
 
-	// URL для API MOEX (пример)
-	url := fmt.Sprintf("%s/securities/%s.json", m.baseURL, ticker)
-	if m.apiKey != "" {
-		url += fmt.Sprintf("?apikey=%s", m.apiKey)
-	}
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	board, err := m.resolveBoard(ctx, ticker, board)
 	if err != nil {
-		return nil, fmt.Errorf("не удалось создать запрос: %w", err)
+		return nil, err
 	}
 
-	resp, err := m.httpClient.Do(req)
+	data, err := m.getISS(ctx, fmt.Sprintf(
+		"/engines/stock/markets/%s/boards/%s/securities/%s.json?iss.meta=off&iss.only=securities,marketdata"+
+			"&securities.columns=SECID,SHORTNAME,PREVPRICE&marketdata.columns=LAST,CHANGE,LASTTOPREVPRICE,VOLTODAY",
+		board.Market, board.Board, ticker,
+	))
 	if err != nil {
-		return nil, fmt.Errorf("ошибка выполнения запроса: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("ошибка API MOEX: %s", resp.Status)
-	}
-
-	body, err := io.ReadAll(resp.Body)
+	stock, err := parseBoardStock(data, ticker, board)
 	if err != nil {
-		return nil, fmt.Errorf("ошибка чтения ответа: %w", err)
-	}
-
-	// Предполагаем, что ответ имеет структуру JSON
-	var responseData map[string]interface{}
-	if err := json.Unmarshal(body, &responseData); err != nil {
-		return nil, fmt.Errorf("ошибка при разборе JSON: %w", err)
+		return nil, err
 	}
 
-	// Преобразование данных в модель Stock (зависит от формата ответа MOEX API)
-	stock := parseStockFromResponse(responseData, ticker)
-
 	// Сохраняем в кэш
 	if m.useCache {
 		m.cache.Set(ctx, cacheKey, stock, m.cacheExpiry)
@@ -95,17 +109,13 @@ func (m *MOEXAPIClient) GetStock(ctx context.Context, ticker string) (*models.St
 
 // GetStocks получает информацию о нескольких акциях
 func (m *MOEXAPIClient) GetStocks(ctx context.Context, tickers []string) ([]models.Stock, error) {
-	var stocks []models.Stock
-
-	for _, ticker := range tickers {
+	return workpool.Map(ctx, m.pool, tickers, func(ctx context.Context, ticker string) (models.Stock, error) {
 		stock, err := m.GetStock(ctx, ticker)
 		if err != nil {
-			return nil, fmt.Errorf("ошибка получения информации о %s: %w", ticker, err)
+			return models.Stock{}, fmt.Errorf("ошибка получения информации о %s: %w", ticker, err)
 		}
-		stocks = append(stocks, *stock)
-	}
-
-	return stocks, nil
+		return *stock, nil
+	})
 }
 
 // GetTopGainers возвращает топ растущих акций
@@ -114,9 +124,11 @@ func (m *MOEXAPIClient) GetTopGainers(ctx context.Context, limit int) ([]models.
 
 	if m.useCache {
 		var cachedStocks []models.Stock
-		err := m.cache.Get(ctx, cacheKey, &cachedStocks)
-		if err == nil && len(cachedStocks) > 0 {
+		switch err := m.cache.Get(ctx, cacheKey, &cachedStocks); {
+		case err == nil:
 			return cachedStocks, nil
+		case !errors.Is(err, cache.ErrNotFound):
+			log.Printf("Ошибка чтения кэша %s: %v", cacheKey, err)
 		}
 	}
 
@@ -165,47 +177,35 @@ func (m *MOEXAPIClient) GetTopGainers(ctx context.Context, limit int) ([]models.
 
 // Вспомогательные функции для парсинга ответов API
 
-// parseStockFromResponse преобразует JSON-ответ в модель Stock
-func parseStockFromResponse(data map[string]interface{}, ticker string) *models.Stock {
-	// Примечание: реальный парсинг зависит от структуры ответа MOEX API
-	// Это упрощенный пример
+// parseBoardStock разбирает таблицы securities и marketdata котировки бумаги в одном режиме торгов.
+// Вне торговой сессии последней цены нет, и котировкой служит цена закрытия предыдущего дня
+func parseBoardStock(data map[string]interface{}, ticker string, board models.TradingBoard) (*models.Stock, error) {
+	securities := issRows(data, "securities")
+	if len(securities) == 0 {
+		return nil, fmt.Errorf("бумага %s не торгуется в режиме %s", ticker, board)
+	}
 
 	stock := &models.Stock{
 		Ticker:    ticker,
+		Board:     board.Board,
 		UpdatedAt: time.Now(),
 	}
-
-	// Пытаемся извлечь данные из ответа
-	if securities, ok := data["securities"].(map[string]interface{}); ok {
-		if data, ok := securities["data"].([]interface{}); ok && len(data) > 0 {
-			if stockData, ok := data[0].([]interface{}); ok && len(stockData) > 5 {
-				// Предполагаем, что данные имеют определенную структуру
-				if name, ok := stockData[2].(string); ok {
-					stock.Name = name
-				}
-
-				if priceStr, ok := stockData[3].(string); ok {
-					if price, err := strconv.ParseFloat(priceStr, 64); err == nil {
-						stock.Price = price
-					}
-				}
-
-				if changeStr, ok := stockData[4].(string); ok {
-					if change, err := strconv.ParseFloat(changeStr, 64); err == nil {
-						stock.Change = change
-					}
-				}
-
-				if changePercStr, ok := stockData[5].(string); ok {
-					if changePerc, err := strconv.ParseFloat(changePercStr, 64); err == nil {
-						stock.ChangePerc = changePerc
-					}
-				}
-			}
-		}
+	stock.Name, _ = securities[0]["SHORTNAME"].(string)
+	prevPrice, _ := securities[0]["PREVPRICE"].(float64)
+
+	if marketData := issRows(data, "marketdata"); len(marketData) > 0 {
+		stock.Price, _ = marketData[0]["LAST"].(float64)
+		stock.Change, _ = marketData[0]["CHANGE"].(float64)
+		stock.ChangePerc, _ = marketData[0]["LASTTOPREVPRICE"].(float64)
+		volume, _ := marketData[0]["VOLTODAY"].(float64)
+		stock.Volume = int64(volume)
+	}
+	if stock.Price == 0 {
+		stock.Price = prevPrice
+		stock.Change, stock.ChangePerc = 0, 0
 	}
 
-	return stock
+	return stock, nil
 }
 
 // parseStocksFromResponse преобразует JSON-ответ в слайс моделей Stock
@@ -0,0 +1,104 @@
+package apis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/cache"
+)
+
+// securityBoardsTTL срок кэширования режимов торгов бумаги: они меняются редко
+const securityBoardsTTL = 24 * time.Hour
+
+// securityBoard режим торгов бумаги из справочника ISS
+type securityBoard struct {
+	Board   string `json:"board"`
+	Market  string `json:"market"`
+	Primary bool   `json:"primary"`
+	Traded  bool   `json:"traded"`
+}
+
+// resolveBoard дополняет режим торгов бумаги. Указанный режим используется как есть, иначе выбирается
+// основной режим бумаги на указанном рынке или на любом рынке фондовой секции: TQBR для акций, TQTF для фондов
+func (m *MOEXAPIClient) resolveBoard(ctx context.Context, ticker string, board models.TradingBoard) (models.TradingBoard, error) {
+	if board.Board != "" {
+		if board.Market == "" {
+			board.Market = models.BoardMarket(board.Board)
+		}
+		return board, nil
+	}
+
+	boards, err := m.securityBoards(ctx, ticker)
+	if err != nil {
+		return models.TradingBoard{}, err
+	}
+
+	var traded *securityBoard
+	for i, candidate := range boards {
+		if board.Market != "" && candidate.Market != board.Market {
+			continue
+		}
+		if candidate.Primary {
+			return models.TradingBoard{Board: candidate.Board, Market: candidate.Market}, nil
+		}
+		if traded == nil && candidate.Traded {
+			traded = &boards[i]
+		}
+	}
+	if traded != nil {
+		return models.TradingBoard{Board: traded.Board, Market: traded.Market}, nil
+	}
+
+	if board.Market != "" {
+		return models.TradingBoard{}, fmt.Errorf("бумага %s не торгуется на рынке %s", ticker, board.Market)
+	}
+	return models.TradingBoard{}, fmt.Errorf("бумага %s не торгуется на фондовом рынке MOEX", ticker)
+}
+
+// securityBoards получает режимы торгов бумаги на фондовом рынке
+func (m *MOEXAPIClient) securityBoards(ctx context.Context, ticker string) ([]securityBoard, error) {
+	cacheKey := fmt.Sprintf("moex:boards:%s", ticker)
+
+	if m.useCache {
+		var cachedBoards []securityBoard
+		switch err := m.cache.Get(ctx, cacheKey, &cachedBoards); {
+		case err == nil:
+			return cachedBoards, nil
+		case !errors.Is(err, cache.ErrNotFound):
+			log.Printf("Ошибка чтения кэша %s: %v", cacheKey, err)
+		}
+	}
+
+	data, err := m.getISS(ctx, fmt.Sprintf("/securities/%s.json?iss.meta=off&iss.only=boards&boards.columns=boardid,market,engine,is_primary,is_traded", ticker))
+	if err != nil {
+		return nil, err
+	}
+
+	var boards []securityBoard
+	for _, row := range issRows(data, "boards") {
+		if engine, _ := row["engine"].(string); engine != "stock" {
+			continue
+		}
+		board := securityBoard{
+			Primary: issInt(row["is_primary"]) == 1,
+			Traded:  issInt(row["is_traded"]) == 1,
+		}
+		board.Board, _ = row["boardid"].(string)
+		board.Market, _ = row["market"].(string)
+		board.Board = strings.ToUpper(board.Board)
+		if board.Board != "" && board.Market != "" {
+			boards = append(boards, board)
+		}
+	}
+
+	if m.useCache && len(boards) > 0 {
+		m.cache.Set(ctx, cacheKey, boards, securityBoardsTTL)
+	}
+
+	return boards, nil
+}
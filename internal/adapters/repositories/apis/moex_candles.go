@@ -0,0 +1,78 @@
+package apis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// candlesPageSize количество свечей в одной странице ответа ISS
+const candlesPageSize = 500
+
+// GetCandles получает свечи бумаги в ее основном режиме торгов с указанным интервалом за период [from, till].
+// ISS отдает свечи страницами, поэтому запросы повторяются, пока страница заполнена целиком
+func (m *MOEXAPIClient) GetCandles(ctx context.Context, ticker, interval string, from, till time.Time) ([]models.StockQuote, error) {
+	spec, ok := models.FindQuoteInterval(interval)
+	if !ok {
+		return nil, fmt.Errorf("неподдерживаемый интервал свечей %s", interval)
+	}
+
+	// Фонды и иностранные акции торгуются не в TQBR, поэтому свечи запрашиваются по основному режиму бумаги
+	board, err := m.resolveBoard(ctx, ticker, models.TradingBoard{})
+	if err != nil {
+		return nil, err
+	}
+
+	from, till = from.In(moexLocation), till.In(moexLocation)
+
+	var quotes []models.StockQuote
+	for start := 0; ; start += candlesPageSize {
+		data, err := m.getISS(ctx, fmt.Sprintf(
+			"/engines/stock/markets/%s/boards/%s/securities/%s/candles.json?iss.meta=off&interval=%d&from=%s&till=%s&start=%d",
+			board.Market, board.Board, ticker, spec.MOEXCode, from.Format("2006-01-02"), till.Format("2006-01-02"), start,
+		))
+		if err != nil {
+			return nil, err
+		}
+
+		rows := issRows(data, "candles")
+		for _, row := range rows {
+			quote, ok := parseCandle(row, ticker, spec.Name)
+			if !ok || quote.Date.Before(from) || quote.Date.After(till) {
+				continue
+			}
+			quotes = append(quotes, quote)
+		}
+
+		if len(rows) < candlesPageSize {
+			break
+		}
+	}
+
+	return quotes, nil
+}
+
+// parseCandle разбирает строку таблицы candles; время начала свечи ISS возвращает по московскому времени
+func parseCandle(row map[string]interface{}, ticker, interval string) (models.StockQuote, bool) {
+	begin, _ := row["begin"].(string)
+	date, err := time.ParseInLocation("2006-01-02 15:04:05", begin, moexLocation)
+	if err != nil {
+		return models.StockQuote{}, false
+	}
+
+	quote := models.StockQuote{
+		Ticker:   ticker,
+		Interval: interval,
+		Date:     date,
+	}
+	quote.Open, _ = row["open"].(float64)
+	quote.High, _ = row["high"].(float64)
+	quote.Low, _ = row["low"].(float64)
+	quote.Close, _ = row["close"].(float64)
+	volume, _ := row["volume"].(float64)
+	quote.Volume = int64(volume)
+
+	return quote, true
+}
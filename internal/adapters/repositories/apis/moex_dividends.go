@@ -0,0 +1,83 @@
+package apis
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// GetDividends получает даты закрытия реестра под дивиденды: прошедшие и объявленные советом директоров
+func (m *MOEXAPIClient) GetDividends(ctx context.Context, ticker string) ([]models.CorporateEvent, error) {
+	data, err := m.getISS(ctx, fmt.Sprintf("/securities/%s/dividends.json?iss.meta=off", ticker))
+	if err != nil {
+		return nil, err
+	}
+
+	var events []models.CorporateEvent
+	for _, row := range issRows(data, "dividends") {
+		closeDate, _ := row["registryclosedate"].(string)
+		date, err := time.ParseInLocation("2006-01-02", closeDate, moexLocation)
+		if err != nil {
+			continue
+		}
+
+		event := models.CorporateEvent{
+			Ticker:    ticker,
+			Type:      models.EventTypeDividend,
+			Date:      date,
+			Source:    models.EventSourceMOEX,
+			SourceID:  models.EventSourceID(date),
+			UpdatedAt: time.Now(),
+		}
+		event.Value, _ = row["value"].(float64)
+		event.Currency, _ = row["currencyid"].(string)
+		event.Title = "Закрытие реестра под дивиденды"
+		if event.Value > 0 {
+			event.Title = fmt.Sprintf("Закрытие реестра под дивиденды %.2f %s на акцию", event.Value, event.Currency)
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// MOEXDividendSource источник дат закрытия реестра под дивиденды из MOEX ISS
+type MOEXDividendSource struct {
+	client *MOEXAPIClient
+}
+
+// NewMOEXDividendSource создает источник дивидендных событий
+func NewMOEXDividendSource(client *MOEXAPIClient) *MOEXDividendSource {
+	return &MOEXDividendSource{
+		client: client,
+	}
+}
+
+// Name возвращает название источника
+func (s *MOEXDividendSource) Name() string {
+	return "MOEX ISS (дивиденды)"
+}
+
+// GetCorporateEvents запрашивает дивиденды каждого эмитента; ошибка по одной бумаге только записывается в лог
+func (s *MOEXDividendSource) GetCorporateEvents(ctx context.Context, tickers []string) ([]models.CorporateEvent, error) {
+	var events []models.CorporateEvent
+	failed := 0
+	for _, ticker := range tickers {
+		dividends, err := s.client.GetDividends(ctx, ticker)
+		if err != nil {
+			log.Printf("Не удалось получить дивиденды %s: %v", ticker, err)
+			failed++
+			continue
+		}
+		events = append(events, dividends...)
+	}
+
+	if failed > 0 && failed == len(tickers) {
+		return nil, fmt.Errorf("не удалось получить дивиденды ни по одной бумаге")
+	}
+
+	return events, nil
+}
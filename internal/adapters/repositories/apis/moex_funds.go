@@ -0,0 +1,138 @@
+package apis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/cache"
+)
+
+// builtinFundMetadata встроенные сведения о крупнейших фондах. Комиссии меняются, поэтому их
+// задает оператор сервера в funds.metadata
+var builtinFundMetadata = map[string]models.FundMetadata{
+	"TMOS": {Manager: "Т-Капитал", UnderlyingIndex: "IMOEX"},
+	"SBMX": {Manager: "Первая", UnderlyingIndex: "IMOEX"},
+	"EQMX": {Manager: "ВТБ Капитал Управление активами", UnderlyingIndex: "IMOEX"},
+	"SBGB": {Manager: "Первая", UnderlyingIndex: "RGBITR"},
+	"LQDT": {Manager: "ВТБ Капитал Управление активами", UnderlyingIndex: "RUSFAR"},
+	"SBMM": {Manager: "Первая", UnderlyingIndex: "RUSFAR"},
+	"AKMM": {Manager: "Альфа-Капитал", UnderlyingIndex: "RUSFAR"},
+	"TGLD": {Manager: "Т-Капитал", UnderlyingIndex: "Золото"},
+}
+
+// BuiltinFundMetadata возвращает встроенные сведения о фондах по тикерам паев
+func BuiltinFundMetadata() map[string]models.FundMetadata {
+	return builtinFundMetadata
+}
+
+// GetFunds получает паи фондов режимов торгов TQTF (биржевые фонды) и TQIF (паевые фонды) с котировками
+func (m *MOEXAPIClient) GetFunds(ctx context.Context) ([]models.Fund, error) {
+	cacheKey := "moex:funds"
+
+	if m.useCache {
+		var cachedFunds []models.Fund
+		switch err := m.cache.Get(ctx, cacheKey, &cachedFunds); {
+		case err == nil:
+			return cachedFunds, nil
+		case !errors.Is(err, cache.ErrNotFound):
+			log.Printf("Ошибка чтения кэша %s: %v", cacheKey, err)
+		}
+	}
+
+	boards := make([]string, 0, len(models.FundBoards))
+	for board := range models.FundBoards {
+		boards = append(boards, board)
+	}
+	sort.Strings(boards)
+
+	var funds []models.Fund
+	for _, board := range boards {
+		data, err := m.getISS(ctx, fmt.Sprintf(
+			"/engines/stock/markets/shares/boards/%s/securities.json?iss.meta=off&iss.only=securities,marketdata"+
+				"&securities.columns=SECID,SHORTNAME,SECNAME,ISIN,LOTSIZE,PREVPRICE,CURRENCYID"+
+				"&marketdata.columns=SECID,LAST,LASTTOPREVPRICE,VALTODAY",
+			board,
+		))
+		if err != nil {
+			return nil, err
+		}
+		funds = append(funds, parseFunds(data, board, models.FundBoards[board])...)
+	}
+
+	if len(funds) == 0 {
+		return nil, fmt.Errorf("список фондов MOEX пуст")
+	}
+
+	if m.useCache {
+		m.cache.Set(ctx, cacheKey, funds, m.cacheExpiry)
+	}
+
+	return funds, nil
+}
+
+// parseFunds разбирает таблицы securities и marketdata режима торгов паями фондов.
+// Вне торговой сессии последней цены нет, и котировкой служит цена закрытия предыдущего дня
+func parseFunds(data map[string]interface{}, board, fundType string) []models.Fund {
+	marketData := make(map[string]map[string]interface{})
+	for _, row := range issRows(data, "marketdata") {
+		if ticker, _ := row["SECID"].(string); ticker != "" {
+			marketData[ticker] = row
+		}
+	}
+
+	now := time.Now()
+	var funds []models.Fund
+	for _, row := range issRows(data, "securities") {
+		ticker, _ := row["SECID"].(string)
+		if ticker == "" {
+			continue
+		}
+		fund := models.Fund{
+			Ticker:    strings.ToUpper(ticker),
+			Type:      fundType,
+			Board:     board,
+			LotSize:   issInt(row["LOTSIZE"]),
+			UpdatedAt: now,
+		}
+		fund.ShortName, _ = row["SHORTNAME"].(string)
+		fund.Name, _ = row["SECNAME"].(string)
+		fund.ISIN, _ = row["ISIN"].(string)
+		fund.Currency, _ = row["CURRENCYID"].(string)
+		// ISS обозначает рубль устаревшим кодом SUR
+		if fund.Currency == "SUR" {
+			fund.Currency = "RUB"
+		}
+
+		if md, ok := marketData[ticker]; ok {
+			fund.Price, _ = md["LAST"].(float64)
+			fund.ChangePerc, _ = md["LASTTOPREVPRICE"].(float64)
+			fund.Value, _ = md["VALTODAY"].(float64)
+		}
+		if fund.Price == 0 {
+			fund.Price, _ = row["PREVPRICE"].(float64)
+			fund.ChangePerc = 0
+		}
+
+		funds = append(funds, fund)
+	}
+
+	return funds
+}
+
+// GetINAV получает индикативную стоимость пая фонда, которую MOEX транслирует на рынке индексов
+func (m *MOEXAPIClient) GetINAV(ctx context.Context, code string) (float64, error) {
+	indexes, err := m.GetIndexes(ctx, []string{code})
+	if err != nil {
+		return 0, err
+	}
+	if len(indexes) == 0 || indexes[0].Value <= 0 {
+		return 0, fmt.Errorf("индикативная стоимость пая %s недоступна", code)
+	}
+	return indexes[0].Value, nil
+}
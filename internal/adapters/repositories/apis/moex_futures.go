@@ -0,0 +1,151 @@
+package apis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/cache"
+)
+
+const (
+	// usdRubPerpetual вечный фьючерс на курс доллара к рублю
+	usdRubPerpetual = "USDRUBF"
+	// usdRubAssetCode базовый актив квартальных фьючерсов Si, котируемых в рублях за 1000 долларов
+	usdRubAssetCode = "Si"
+)
+
+// GetFrontFutures возвращает наиболее ликвидный из неистекших фьючерсов на базовый актив:
+// контракт с наибольшим открытым интересом, при равенстве — с ближайшим исполнением
+func (m *MOEXAPIClient) GetFrontFutures(ctx context.Context, assetCode string) (*models.FuturesQuote, error) {
+	contracts, err := m.getFuturesBoard(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().In(moexLocation)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, moexLocation)
+	var front *models.FuturesQuote
+	for i := range contracts {
+		contract := &contracts[i]
+		if contract.AssetCode != assetCode || contract.Price <= 0 {
+			continue
+		}
+		if !contract.LastTradeDay.IsZero() && contract.LastTradeDay.Before(today) {
+			continue
+		}
+		if front == nil ||
+			contract.OpenInterest > front.OpenInterest ||
+			(contract.OpenInterest == front.OpenInterest && contract.LastTradeDay.Before(front.LastTradeDay)) {
+			front = contract
+		}
+	}
+	if front == nil {
+		return nil, fmt.Errorf("на срочном рынке MOEX нет торгуемых фьючерсов на %s", assetCode)
+	}
+
+	return front, nil
+}
+
+// GetUSDRUB возвращает курс доллара к рублю по вечному фьючерсу USDRUBF,
+// а если он не торгуется — по ближайшему фьючерсу Si
+func (m *MOEXAPIClient) GetUSDRUB(ctx context.Context) (float64, error) {
+	contracts, err := m.getFuturesBoard(ctx)
+	if err != nil {
+		return 0, err
+	}
+	for _, contract := range contracts {
+		if contract.SecID == usdRubPerpetual && contract.Price > 0 {
+			return contract.Price, nil
+		}
+	}
+
+	si, err := m.GetFrontFutures(ctx, usdRubAssetCode)
+	if err != nil {
+		return 0, fmt.Errorf("курс доллара недоступен: %w", err)
+	}
+	return si.Price / 1000, nil
+}
+
+// getFuturesBoard возвращает котировки всех фьючерсов срочного рынка MOEX одним запросом
+func (m *MOEXAPIClient) getFuturesBoard(ctx context.Context) ([]models.FuturesQuote, error) {
+	cacheKey := "moex:forts"
+
+	if m.useCache {
+		var cachedContracts []models.FuturesQuote
+		switch err := m.cache.Get(ctx, cacheKey, &cachedContracts); {
+		case err == nil:
+			return cachedContracts, nil
+		case !errors.Is(err, cache.ErrNotFound):
+			log.Printf("Ошибка чтения кэша %s: %v", cacheKey, err)
+		}
+	}
+
+	data, err := m.getISS(ctx, "/engines/futures/markets/forts/securities.json?iss.meta=off&iss.only=securities,marketdata"+
+		"&securities.columns=SECID,SHORTNAME,ASSETCODE,LASTTRADEDATE,PREVSETTLEPRICE"+
+		"&marketdata.columns=SECID,LAST,SETTLEPRICE,LASTCHANGEPRCNT,OPENPOSITION,UPDATETIME")
+	if err != nil {
+		return nil, err
+	}
+
+	marketData := make(map[string]map[string]interface{})
+	for _, row := range issRows(data, "marketdata") {
+		if secid, _ := row["SECID"].(string); secid != "" {
+			marketData[secid] = row
+		}
+	}
+
+	now := time.Now()
+	var contracts []models.FuturesQuote
+	for _, row := range issRows(data, "securities") {
+		secid, _ := row["SECID"].(string)
+		if secid == "" {
+			continue
+		}
+		contract := models.FuturesQuote{
+			SecID:     secid,
+			UpdatedAt: now,
+		}
+		contract.Name, _ = row["SHORTNAME"].(string)
+		contract.AssetCode, _ = row["ASSETCODE"].(string)
+		if lastTrade, _ := row["LASTTRADEDATE"].(string); lastTrade != "" {
+			// У вечных фьючерсов дата последнего дня обращения условная — в далеком будущем
+			if date, err := time.ParseInLocation("2006-01-02", lastTrade, moexLocation); err == nil && date.Year() < 2100 {
+				contract.LastTradeDay = date
+			}
+		}
+		prevSettle, _ := row["PREVSETTLEPRICE"].(float64)
+
+		md := marketData[secid]
+		contract.Price, _ = md["LAST"].(float64)
+		if contract.Price == 0 {
+			contract.Price, _ = md["SETTLEPRICE"].(float64)
+		}
+		if contract.Price == 0 {
+			contract.Price = prevSettle
+		}
+		contract.ChangePerc, _ = md["LASTCHANGEPRCNT"].(float64)
+		if contract.ChangePerc == 0 && prevSettle > 0 && contract.Price != prevSettle {
+			contract.ChangePerc = (contract.Price/prevSettle - 1) * 100
+		}
+		openInterest, _ := md["OPENPOSITION"].(float64)
+		contract.OpenInterest = int64(openInterest)
+		if updated, _ := md["UPDATETIME"].(string); updated != "" {
+			if t, err := time.ParseInLocation("15:04:05", updated, moexLocation); err == nil {
+				today := now.In(moexLocation)
+				contract.UpdatedAt = time.Date(today.Year(), today.Month(), today.Day(), t.Hour(), t.Minute(), t.Second(), 0, moexLocation)
+			}
+		}
+
+		contracts = append(contracts, contract)
+	}
+
+	if m.useCache && len(contracts) > 0 {
+		m.cache.Set(ctx, cacheKey, contracts, m.cacheExpiry)
+	}
+
+	return contracts, nil
+}
@@ -0,0 +1,171 @@
+package apis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/cache"
+)
+
+// GetIndexes получает текущие значения индексов Московской биржи в порядке запроса.
+// Индексы, по которым ISS не вернул значение, пропускаются
+func (m *MOEXAPIClient) GetIndexes(ctx context.Context, codes []string) ([]models.MarketIndex, error) {
+	cacheKey := fmt.Sprintf("moex:indexes:%s", strings.Join(codes, ","))
+
+	if m.useCache {
+		var cachedIndexes []models.MarketIndex
+		switch err := m.cache.Get(ctx, cacheKey, &cachedIndexes); {
+		case err == nil:
+			return cachedIndexes, nil
+		case !errors.Is(err, cache.ErrNotFound):
+			log.Printf("Ошибка чтения кэша %s: %v", cacheKey, err)
+		}
+	}
+
+	data, err := m.getISS(ctx, fmt.Sprintf(
+		"/engines/stock/markets/index/securities.json?iss.meta=off&iss.only=securities,marketdata&securities=%s",
+		strings.Join(codes, ","),
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	indexes := parseIndexes(data, codes)
+
+	if m.useCache && len(indexes) > 0 {
+		m.cache.Set(ctx, cacheKey, indexes, m.cacheExpiry)
+	}
+
+	return indexes, nil
+}
+
+// parseIndexes разбирает таблицы securities и marketdata рынка индексов.
+// Индекс может транслироваться в нескольких режимах, берется первая строка со значением
+func parseIndexes(data map[string]interface{}, codes []string) []models.MarketIndex {
+	names := make(map[string]string)
+	for _, row := range issRows(data, "securities") {
+		code, _ := row["SECID"].(string)
+		name, _ := row["SHORTNAME"].(string)
+		if code != "" && names[code] == "" {
+			names[code] = name
+		}
+	}
+
+	byCode := make(map[string]models.MarketIndex)
+	for _, row := range issRows(data, "marketdata") {
+		code, _ := row["SECID"].(string)
+		if _, ok := byCode[code]; ok || code == "" {
+			continue
+		}
+
+		// Вне торговой сессии CURRENTVALUE пуст, используется последнее значение
+		value, _ := row["CURRENTVALUE"].(float64)
+		if value <= 0 {
+			value, _ = row["LASTVALUE"].(float64)
+		}
+		if value <= 0 {
+			continue
+		}
+
+		index := models.MarketIndex{
+			Code:      code,
+			Name:      names[code],
+			Value:     value,
+			UpdatedAt: time.Now(),
+		}
+		index.Change, _ = row["LASTCHANGE"].(float64)
+		index.ChangePerc, _ = row["LASTCHANGEPRC"].(float64)
+
+		date, _ := row["TRADEDATE"].(string)
+		clock, _ := row["UPDATETIME"].(string)
+		if updatedAt, err := time.ParseInLocation("2006-01-02 15:04:05", date+" "+clock, moexLocation); err == nil {
+			index.UpdatedAt = updatedAt
+		}
+
+		byCode[code] = index
+	}
+
+	indexes := make([]models.MarketIndex, 0, len(codes))
+	for _, code := range codes {
+		if index, ok := byCode[code]; ok {
+			indexes = append(indexes, index)
+		}
+	}
+
+	return indexes
+}
+
+// GetIndexConstituents получает состав индекса с весами из аналитики ISS. Ответ разбит на страницы,
+// которые перебираются по таблице analytics.cursor
+func (m *MOEXAPIClient) GetIndexConstituents(ctx context.Context, index string) ([]models.IndexConstituent, error) {
+	index = strings.ToUpper(index)
+
+	var constituents []models.IndexConstituent
+	positions := make(map[string]int)
+	for start := 0; ; {
+		data, err := m.getISS(ctx, fmt.Sprintf(
+			"/statistics/engines/stock/markets/index/analytics/%s.json?iss.meta=off&iss.only=analytics,analytics.cursor&limit=100&start=%d",
+			index, start,
+		))
+		if err != nil {
+			return nil, fmt.Errorf("индекс %s: %w", index, err)
+		}
+
+		rows := issRows(data, "analytics")
+		constituents = appendIndexConstituents(constituents, positions, index, rows)
+
+		cursor := issRows(data, "analytics.cursor")
+		if len(rows) == 0 || len(cursor) == 0 {
+			break
+		}
+		pageSize := issInt(cursor[0]["PAGESIZE"])
+		start = issInt(cursor[0]["INDEX"]) + pageSize
+		if pageSize <= 0 || start >= issInt(cursor[0]["TOTAL"]) {
+			break
+		}
+	}
+
+	if len(constituents) == 0 {
+		return nil, fmt.Errorf("состав индекса %s пуст", index)
+	}
+
+	return constituents, nil
+}
+
+// appendIndexConstituents добавляет бумаги страницы аналитики индекса. Бумага может повторяться
+// для нескольких торговых сессий, тогда берется последняя строка
+func appendIndexConstituents(constituents []models.IndexConstituent, positions map[string]int, index string, rows []map[string]interface{}) []models.IndexConstituent {
+	now := time.Now()
+	for _, row := range rows {
+		ticker, _ := row["ticker"].(string)
+		weight, _ := row["weight"].(float64)
+		if ticker == "" || weight <= 0 {
+			continue
+		}
+
+		constituent := models.IndexConstituent{
+			Index:     index,
+			Ticker:    strings.ToUpper(ticker),
+			Weight:    weight,
+			UpdatedAt: now,
+		}
+		constituent.Name, _ = row["shortnames"].(string)
+		if date, _ := row["tradedate"].(string); date != "" {
+			constituent.TradeDate, _ = time.ParseInLocation("2006-01-02", date, moexLocation)
+		}
+
+		if i, ok := positions[constituent.Ticker]; ok {
+			constituents[i] = constituent
+			continue
+		}
+		positions[constituent.Ticker] = len(constituents)
+		constituents = append(constituents, constituent)
+	}
+
+	return constituents
+}
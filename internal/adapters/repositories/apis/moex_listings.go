@@ -0,0 +1,32 @@
+package apis
+
+import (
+	"context"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// GetListedSecurities получает список акций основного режима торгов (TQBR) с ISIN и уровнем листинга.
+// Список сверяется с сохраненным при прошлой сверке, поэтому не кэшируется
+func (m *MOEXAPIClient) GetListedSecurities(ctx context.Context) ([]models.ListedSecurity, error) {
+	data, err := m.getISS(ctx, "/engines/stock/markets/shares/boards/TQBR/securities.json?iss.meta=off&iss.only=securities&securities.columns=SECID,SHORTNAME,ISIN,LISTLEVEL")
+	if err != nil {
+		return nil, err
+	}
+
+	rows := issRows(data, "securities")
+	securities := make([]models.ListedSecurity, 0, len(rows))
+	for _, row := range rows {
+		ticker, _ := row["SECID"].(string)
+		if ticker == "" {
+			continue
+		}
+		security := models.ListedSecurity{Ticker: ticker}
+		security.Name, _ = row["SHORTNAME"].(string)
+		security.ISIN, _ = row["ISIN"].(string)
+		security.ListingLevel = issInt(row["LISTLEVEL"])
+		securities = append(securities, security)
+	}
+
+	return securities, nil
+}
@@ -0,0 +1,205 @@
+package apis
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrMOEXMaintenance возвращается вместо запросов к MOEX ISS, пока биржа проводит техническое обслуживание
+var ErrMOEXMaintenance = errors.New("MOEX ISS на техническом обслуживании")
+
+// maintenancePageMarkers фразы страницы технического обслуживания ISS (в нижнем регистре). Другие HTML-страницы
+// (ошибка прокси, страница авторизации) обслуживанием не считаются и возвращаются как обычный ответ
+var maintenancePageMarkers = []string{
+	"технические работы",
+	"техническое обслуживание",
+	"профилактические работы",
+	"maintenance",
+	"technical works",
+}
+
+// maintenancePageLimit сколько байт HTML-ответа просматривается в поисках фраз страницы обслуживания
+const maintenancePageLimit = 64 << 10
+
+// maintenanceGuard отслеживает техническое обслуживание MOEX ISS. Обнаружив страницу обслуживания
+// или ответ 502/503/504, он переводит клиент в режим обслуживания: запросы не отправляются
+// и сразу завершаются ошибкой ErrMOEXMaintenance, а фоновая проверка раз в probeInterval
+// обращается к ISS и снимает режим, когда биржа снова отвечает. Проверка останавливается вместе с ctx
+type maintenanceGuard struct {
+	ctx           context.Context
+	base          http.RoundTripper
+	probeURL      string
+	probeInterval time.Duration
+
+	mu      sync.Mutex
+	since   time.Time // Начало обслуживания; нулевое значение — ISS доступна
+	probing bool
+}
+
+// newMaintenanceGuard создает отслеживание обслуживания поверх транспорта base; ctx ограничивает время жизни
+// фоновой проверки восстановления
+func newMaintenanceGuard(ctx context.Context, base http.RoundTripper, probeURL string, probeInterval time.Duration) *maintenanceGuard {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if probeInterval <= 0 {
+		probeInterval = time.Minute
+	}
+	return &maintenanceGuard{
+		ctx:           ctx,
+		base:          base,
+		probeURL:      probeURL,
+		probeInterval: probeInterval,
+	}
+}
+
+// RoundTrip выполняет запрос, если ISS не на обслуживании, и проверяет ответ на признаки обслуживания
+func (g *maintenanceGuard) RoundTrip(req *http.Request) (*http.Response, error) {
+	if since, active := g.status(); active {
+		return nil, maintenanceError(since)
+	}
+
+	resp, err := g.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if isMaintenanceResponse(resp) {
+		resp.Body.Close()
+		return nil, maintenanceError(g.enter(resp.Status))
+	}
+
+	return resp, nil
+}
+
+// status возвращает время начала обслуживания и признак того, что оно продолжается
+func (g *maintenanceGuard) status() (time.Time, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.since, !g.since.IsZero()
+}
+
+// enter включает режим обслуживания и запускает проверку восстановления
+func (g *maintenanceGuard) enter(reason string) time.Time {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.since.IsZero() {
+		g.since = time.Now()
+		log.Printf("MOEX ISS на техническом обслуживании (%s), запросы приостановлены до восстановления", reason)
+	}
+	if !g.probing {
+		g.probing = true
+		go g.probe()
+	}
+
+	return g.since
+}
+
+// probe периодически обращается к ISS и снимает режим обслуживания после первого нормального ответа.
+// При остановке приложения проверка завершается, а режим обслуживания остается включенным
+func (g *maintenanceGuard) probe() {
+	ticker := time.NewTicker(g.probeInterval)
+	defer ticker.Stop()
+
+	client := &http.Client{Transport: g.base, Timeout: g.probeInterval}
+	for {
+		select {
+		case <-g.ctx.Done():
+			g.mu.Lock()
+			g.probing = false
+			g.mu.Unlock()
+			return
+		case <-ticker.C:
+		}
+
+		req, err := http.NewRequestWithContext(g.ctx, http.MethodGet, g.probeURL, nil)
+		if err != nil {
+			continue
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			continue
+		}
+		maintenance := isMaintenanceResponse(resp)
+		resp.Body.Close()
+		if maintenance || resp.StatusCode != http.StatusOK {
+			continue
+		}
+
+		g.mu.Lock()
+		log.Printf("MOEX ISS снова доступна, обслуживание длилось %v", time.Since(g.since).Round(time.Second))
+		g.since = time.Time{}
+		g.probing = false
+		g.mu.Unlock()
+		return
+	}
+}
+
+// isMaintenanceResponse проверяет, что вместо данных ISS вернула страницу обслуживания:
+// ответ шлюза 502/503/504 или HTML-страницу с фразами о технических работах.
+// Просмотренное начало тела возвращается в resp.Body, поэтому остальной ответ можно прочитать как обычно
+func isMaintenanceResponse(resp *http.Response) bool {
+	switch resp.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	if !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/html") {
+		return false
+	}
+
+	head, err := io.ReadAll(io.LimitReader(resp.Body, maintenancePageLimit))
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(head), resp.Body), resp.Body}
+	if err != nil {
+		return false
+	}
+
+	page := strings.ToLower(string(head))
+	for _, marker := range maintenancePageMarkers {
+		if strings.Contains(page, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// maintenanceError возвращает ошибку с временем начала обслуживания
+func maintenanceError(since time.Time) error {
+	return fmt.Errorf("%w с %s, данные будут обновлены после его окончания", ErrMOEXMaintenance, since.Format("15:04"))
+}
+
+// Maintenance возвращает время начала технического обслуживания MOEX ISS
+// и признак того, что оно продолжается
+func (m *MOEXAPIClient) Maintenance() (time.Time, bool) {
+	return m.maintenance.status()
+}
+
+// Ping проверяет доступность MOEX ISS HEAD-запросом списка торговых систем: такие запросы не попадают
+// в архив ответов. Во время обслуживания возвращает ошибку ErrMOEXMaintenance, не обращаясь к ISS
+func (m *MOEXAPIClient) Ping(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, m.maintenance.probeURL, nil)
+	if err != nil {
+		return fmt.Errorf("не удалось создать запрос: %w", err)
+	}
+
+	resp, err := m.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("MOEX ISS вернула статус %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package apis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/cache"
+)
+
+// GetOrderBook получает стакан заявок по бумаге в режиме торгов board, по умолчанию — в основном режиме бумаги.
+// Стакан быстро устаревает, поэтому кэшируется на короткий срок cache.orderBookTTL
+func (m *MOEXAPIClient) GetOrderBook(ctx context.Context, ticker string, board models.TradingBoard) (*models.OrderBook, error) {
+	board, err := m.resolveBoard(ctx, ticker, board)
+	if err != nil {
+		return nil, err
+	}
+	cacheKey := fmt.Sprintf("moex:orderbook:%s:%s", ticker, board)
+
+	if m.useCache {
+		var cachedBook models.OrderBook
+		switch err := m.cache.Get(ctx, cacheKey, &cachedBook); {
+		case err == nil:
+			return &cachedBook, nil
+		case !errors.Is(err, cache.ErrNotFound):
+			log.Printf("Ошибка чтения кэша %s: %v", cacheKey, err)
+		}
+	}
+
+	data, err := m.getISS(ctx, fmt.Sprintf("/engines/stock/markets/%s/boards/%s/securities/%s/orderbook.json?iss.meta=off&iss.only=orderbook", board.Market, board.Board, ticker))
+	if err != nil {
+		return nil, err
+	}
+
+	book := parseOrderBook(data, ticker)
+	book.Board = board.Board
+
+	if m.useCache {
+		m.cache.Set(ctx, cacheKey, book, m.orderBookTTL)
+	}
+
+	return book, nil
+}
+
+// parseOrderBook разбирает таблицу orderbook: сторона заявки (B/S), цена и объем в лотах
+func parseOrderBook(data map[string]interface{}, ticker string) *models.OrderBook {
+	book := &models.OrderBook{
+		Ticker:    ticker,
+		UpdatedAt: time.Now(),
+	}
+
+	for _, row := range issRows(data, "orderbook") {
+		side, _ := row["BUYSELL"].(string)
+		price, _ := row["PRICE"].(float64)
+		quantity, _ := row["QUANTITY"].(float64)
+		if price <= 0 || quantity <= 0 {
+			continue
+		}
+
+		level := models.OrderBookLevel{Price: price, Quantity: int64(quantity)}
+		switch side {
+		case "B":
+			book.Bids = append(book.Bids, level)
+		case "S":
+			book.Asks = append(book.Asks, level)
+		}
+
+		if updated, _ := row["UPDATETIME"].(string); updated != "" {
+			if t, err := time.ParseInLocation("15:04:05", updated, moexLocation); err == nil {
+				now := time.Now().In(moexLocation)
+				book.UpdatedAt = time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), t.Second(), 0, moexLocation)
+			}
+		}
+	}
+
+	sort.Slice(book.Bids, func(i, j int) bool { return book.Bids[i].Price > book.Bids[j].Price })
+	sort.Slice(book.Asks, func(i, j int) bool { return book.Asks[i].Price < book.Asks[j].Price })
+
+	return book
+}
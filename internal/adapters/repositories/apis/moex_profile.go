@@ -0,0 +1,221 @@
+package apis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/cache"
+)
+
+// moexSectorIndices отраслевые индексы MOEX и соответствующие им секторы
+var moexSectorIndices = []struct {
+	index  string
+	sector string
+}{
+	{"MOEXOG", "Нефть и газ"},
+	{"MOEXFN", "Финансы"},
+	{"MOEXMM", "Металлы и добыча"},
+	{"MOEXEU", "Электроэнергетика"},
+	{"MOEXTL", "Телекоммуникации"},
+	{"MOEXCN", "Потребительский сектор"},
+	{"MOEXCH", "Химия и нефтехимия"},
+	{"MOEXTN", "Транспорт"},
+	{"MOEXIT", "Информационные технологии"},
+	{"MOEXRE", "Строительные компании"},
+}
+
+// builtinIndustries отрасли крупнейших эмитентов; ISS отраслевую классификацию не отдает
+var builtinIndustries = map[string]string{
+	"SBER": "Банки",
+	"VTBR": "Банки",
+	"GAZP": "Добыча и транспортировка газа",
+	"NVTK": "Добыча газа и производство СПГ",
+	"LKOH": "Добыча и переработка нефти",
+	"ROSN": "Добыча и переработка нефти",
+	"TATN": "Добыча и переработка нефти",
+	"GMKN": "Добыча никеля и металлов платиновой группы",
+	"POLY": "Золотодобыча",
+	"ALRS": "Алмазодобыча",
+	"MTSS": "Мобильная связь",
+	"MGNT": "Розничная торговля продуктами",
+	"FIVE": "Розничная торговля продуктами",
+	"YNDX": "Интернет-сервисы",
+}
+
+// GetCompanyProfile получает профиль эмитента: описание бумаги, капитализацию, сектор и free float.
+// Сектор и free float необязательны: ошибка их получения только записывается в лог
+func (m *MOEXAPIClient) GetCompanyProfile(ctx context.Context, ticker string) (*models.CompanyProfile, error) {
+	description, err := m.getISS(ctx, fmt.Sprintf("/securities/%s.json?iss.meta=off&iss.only=description", ticker))
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make(map[string]string)
+	for _, row := range issRows(description, "description") {
+		name, _ := row["name"].(string)
+		value, _ := row["value"].(string)
+		fields[name] = value
+	}
+	if fields["SECID"] == "" {
+		return nil, fmt.Errorf("бумага %s не найдена на MOEX", ticker)
+	}
+
+	profile := &models.CompanyProfile{
+		Ticker:    fields["SECID"],
+		Name:      fields["SHORTNAME"],
+		FullName:  fields["NAME"],
+		ISIN:      fields["ISIN"],
+		Industry:  builtinIndustries[ticker],
+		UpdatedAt: time.Now(),
+	}
+	profile.SharesOutstanding, _ = strconv.ParseInt(fields["ISSUESIZE"], 10, 64)
+	profile.ListingLevel, _ = strconv.Atoi(fields["LISTLEVEL"])
+
+	// Капитализация по данным основного режима торгов; если ISS ее не рассчитал — по последней цене
+	marketData, err := m.getISS(ctx, fmt.Sprintf("/engines/stock/markets/shares/boards/TQBR/securities/%s.json?iss.meta=off&iss.only=marketdata&marketdata.columns=ISSUECAPITALIZATION,LAST", ticker))
+	if err != nil {
+		return nil, err
+	}
+	if rows := issRows(marketData, "marketdata"); len(rows) > 0 {
+		capitalization, _ := rows[0]["ISSUECAPITALIZATION"].(float64)
+		last, _ := rows[0]["LAST"].(float64)
+		if capitalization == 0 {
+			capitalization = last * float64(profile.SharesOutstanding)
+		}
+		profile.MarketCap = capitalization
+	}
+
+	if sectors, err := m.getSectorMembership(ctx); err != nil {
+		log.Printf("Не удалось получить состав отраслевых индексов MOEX: %v", err)
+	} else {
+		profile.Sector = sectors[ticker]
+	}
+
+	if freeFloat, err := m.getFreeFloat(ctx, ticker); err != nil {
+		log.Printf("Не удалось получить free float %s: %v", ticker, err)
+	} else {
+		profile.FreeFloatPerc = freeFloat
+	}
+
+	return profile, nil
+}
+
+// getSectorMembership возвращает сектор каждой бумаги по составу отраслевых индексов MOEX
+func (m *MOEXAPIClient) getSectorMembership(ctx context.Context) (map[string]string, error) {
+	cacheKey := "moex:sector_membership"
+
+	if m.useCache {
+		var cachedSectors map[string]string
+		switch err := m.cache.Get(ctx, cacheKey, &cachedSectors); {
+		case err == nil:
+			return cachedSectors, nil
+		case !errors.Is(err, cache.ErrNotFound):
+			log.Printf("Ошибка чтения кэша %s: %v", cacheKey, err)
+		}
+	}
+
+	sectors := make(map[string]string)
+	for _, sectorIndex := range moexSectorIndices {
+		data, err := m.getISS(ctx, fmt.Sprintf("/statistics/engines/stock/markets/index/analytics/%s.json?iss.meta=off&iss.only=analytics&limit=100", sectorIndex.index))
+		if err != nil {
+			return nil, fmt.Errorf("индекс %s: %w", sectorIndex.index, err)
+		}
+		for _, row := range issRows(data, "analytics") {
+			if ticker, _ := row["ticker"].(string); ticker != "" {
+				sectors[ticker] = sectorIndex.sector
+			}
+		}
+	}
+
+	// Состав индексов пересматривается раз в квартал, поэтому кэшируется на сутки
+	if m.useCache && len(sectors) > 0 {
+		m.cache.Set(ctx, cacheKey, sectors, 24*time.Hour)
+	}
+
+	return sectors, nil
+}
+
+// getFreeFloat возвращает долю акций в свободном обращении в процентах; 0 — бумаги нет в списке
+func (m *MOEXAPIClient) getFreeFloat(ctx context.Context, ticker string) (float64, error) {
+	cacheKey := "moex:free_float"
+
+	var freeFloats map[string]float64
+	if m.useCache {
+		switch err := m.cache.Get(ctx, cacheKey, &freeFloats); {
+		case err == nil:
+			return freeFloats[strings.ToUpper(ticker)], nil
+		case !errors.Is(err, cache.ErrNotFound):
+			log.Printf("Ошибка чтения кэша %s: %v", cacheKey, err)
+		}
+	}
+
+	data, err := m.getISS(ctx, "/statistics/engines/stock/markets/shares/freefloat.json?iss.meta=off")
+	if err != nil {
+		return 0, err
+	}
+
+	freeFloats = make(map[string]float64)
+	for _, row := range issRows(data, "freefloat") {
+		secid, _ := row["secid"].(string)
+		freeFloat, _ := row["freefloat"].(float64)
+		if secid == "" {
+			continue
+		}
+		// ISS отдает коэффициент free float долей единицы
+		if freeFloat <= 1 {
+			freeFloat *= 100
+		}
+		freeFloats[strings.ToUpper(secid)] = freeFloat
+	}
+
+	// Коэффициенты free float пересматриваются раз в квартал, поэтому кэшируются на сутки
+	if m.useCache && len(freeFloats) > 0 {
+		m.cache.Set(ctx, cacheKey, freeFloats, 24*time.Hour)
+	}
+
+	return freeFloats[strings.ToUpper(ticker)], nil
+}
+
+// getISS выполняет GET-запрос к ресурсу ISS и разбирает ответ
+func (m *MOEXAPIClient) getISS(ctx context.Context, path string) (map[string]interface{}, error) {
+	url := m.baseURL + path
+	if m.apiKey != "" {
+		url += fmt.Sprintf("&apikey=%s", m.apiKey)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось создать запрос: %w", err)
+	}
+
+	resp, err := m.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка выполнения запроса: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("ошибка API MOEX: %s", resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка чтения ответа: %w", err)
+	}
+
+	var responseData map[string]interface{}
+	if err := json.Unmarshal(body, &responseData); err != nil {
+		return nil, fmt.Errorf("ошибка при разборе JSON: %w", err)
+	}
+
+	return responseData, nil
+}
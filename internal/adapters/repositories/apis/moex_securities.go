@@ -0,0 +1,72 @@
+package apis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// moexPrimaryBoard основной режим торгов акциями; бумага, торгующаяся в нескольких режимах, описывается по нему
+const moexPrimaryBoard = models.BoardShares
+
+// GetSecurities получает справочник всех бумаг рынка акций MOEX во всех режимах торгов:
+// акции, депозитарные расписки и паи фондов
+func (m *MOEXAPIClient) GetSecurities(ctx context.Context) ([]models.Security, error) {
+	responseData, err := m.getISS(ctx, "/engines/stock/markets/shares/securities.json?iss.meta=off&iss.only=securities&securities.columns=SECID,BOARDID,SHORTNAME,SECNAME,LATNAME,ISIN,LOTSIZE,MINSTEP,DECIMALS,LISTLEVEL")
+	if err != nil {
+		return nil, err
+	}
+
+	// Одна бумага возвращается по разу для каждого режима торгов
+	positions := make(map[string]int)
+	var securities []models.Security
+	for _, row := range issRows(responseData, "securities") {
+		ticker, _ := row["SECID"].(string)
+		if ticker == "" {
+			continue
+		}
+		security := models.Security{Ticker: strings.ToUpper(ticker)}
+		security.Board, _ = row["BOARDID"].(string)
+		security.ShortName, _ = row["SHORTNAME"].(string)
+		security.Name, _ = row["SECNAME"].(string)
+		security.LatName, _ = row["LATNAME"].(string)
+		security.ISIN, _ = row["ISIN"].(string)
+		security.LotSize = issInt(row["LOTSIZE"])
+		security.MinStep, _ = row["MINSTEP"].(float64)
+		security.Decimals = issInt(row["DECIMALS"])
+		security.ListLevel = issInt(row["LISTLEVEL"])
+
+		if i, ok := positions[security.Ticker]; ok {
+			boards := append(securities[i].Boards, security.Board)
+			if security.Board == moexPrimaryBoard {
+				securities[i] = security
+			}
+			securities[i].Boards = boards
+			continue
+		}
+		security.Boards = []string{security.Board}
+		positions[security.Ticker] = len(securities)
+		securities = append(securities, security)
+	}
+
+	if len(securities) == 0 {
+		return nil, fmt.Errorf("справочник бумаг MOEX пуст")
+	}
+
+	return securities, nil
+}
+
+// issInt читает целое число из ячейки ISS, которая приходит числом или строкой
+func issInt(value interface{}) int {
+	switch value := value.(type) {
+	case float64:
+		return int(value)
+	case string:
+		n, _ := strconv.Atoi(value)
+		return n
+	}
+	return 0
+}
@@ -0,0 +1,190 @@
+package apis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/cache"
+)
+
+// moexExchangeSource название источника официальных сообщений биржи
+const moexExchangeSource = "Московская Биржа"
+
+// moexLocation часовой пояс, в котором ISS возвращает время публикаций
+var moexLocation = models.MoscowLocation
+
+// moexAnnouncementFeed лента официальных сообщений ISS
+type moexAnnouncementFeed struct {
+	path      string // Путь ресурса ISS
+	table     string // Имя таблицы в ответе
+	dateField string // Поле с временем публикации
+	urlFormat string // Шаблон ссылки на сообщение на сайте биржи
+	tag       string // Тег, которым помечаются сообщения ленты
+}
+
+// moexAnnouncementFeeds новости сайта биржи и события (остановки торгов, изменения листинга)
+var moexAnnouncementFeeds = []moexAnnouncementFeed{
+	{path: "sitenews", table: "sitenews", dateField: "published_at", urlFormat: "https://www.moex.com/n%d", tag: "новости биржи"},
+	{path: "events", table: "events", dateField: "from", urlFormat: "https://www.moex.com/e%d", tag: "события биржи"},
+}
+
+// GetExchangeNews возвращает последние официальные сообщения биржи: новости сайта и события.
+// Ошибка одной ленты не мешает вернуть другую; ошибка возвращается, только если недоступны обе.
+func (m *MOEXAPIClient) GetExchangeNews(ctx context.Context) ([]models.News, error) {
+	cacheKey := "moex:exchange_news"
+
+	if m.useCache {
+		var cachedNews []models.News
+		switch err := m.cache.Get(ctx, cacheKey, &cachedNews); {
+		case err == nil:
+			return cachedNews, nil
+		case !errors.Is(err, cache.ErrNotFound):
+			log.Printf("Ошибка чтения кэша %s: %v", cacheKey, err)
+		}
+	}
+
+	var news []models.News
+	var lastErr error
+	failed := 0
+	for _, feed := range moexAnnouncementFeeds {
+		items, err := m.getAnnouncementFeed(ctx, feed)
+		if err != nil {
+			log.Printf("Не удалось получить ленту MOEX %s: %v", feed.path, err)
+			lastErr = err
+			failed++
+			continue
+		}
+		news = append(news, items...)
+	}
+	if failed == len(moexAnnouncementFeeds) {
+		return nil, lastErr
+	}
+
+	// Сохраняем в кэш
+	if m.useCache && len(news) > 0 {
+		m.cache.Set(ctx, cacheKey, news, m.cacheExpiry)
+	}
+
+	return news, nil
+}
+
+// getAnnouncementFeed получает первую страницу ленты официальных сообщений
+func (m *MOEXAPIClient) getAnnouncementFeed(ctx context.Context, feed moexAnnouncementFeed) ([]models.News, error) {
+	url := fmt.Sprintf("%s/%s.json?iss.meta=off", m.baseURL, feed.path)
+	if m.apiKey != "" {
+		url += fmt.Sprintf("&apikey=%s", m.apiKey)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось создать запрос: %w", err)
+	}
+
+	resp, err := m.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка выполнения запроса: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("ошибка API MOEX: %s", resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка чтения ответа: %w", err)
+	}
+
+	var responseData map[string]interface{}
+	if err := json.Unmarshal(body, &responseData); err != nil {
+		return nil, fmt.Errorf("ошибка при разборе JSON: %w", err)
+	}
+
+	return parseAnnouncementFeed(responseData, feed), nil
+}
+
+// parseAnnouncementFeed преобразует таблицу ленты официальных сообщений в новости
+func parseAnnouncementFeed(responseData map[string]interface{}, feed moexAnnouncementFeed) []models.News {
+	var news []models.News
+	for _, row := range issRows(responseData, feed.table) {
+		id, ok := row["id"].(float64)
+		if !ok {
+			continue
+		}
+		title, _ := row["title"].(string)
+		title = strings.TrimSpace(title)
+		if title == "" {
+			continue
+		}
+
+		published := time.Now()
+		if value, ok := row[feed.dateField].(string); ok {
+			if parsed, err := time.ParseInLocation("2006-01-02 15:04:05", value, moexLocation); err == nil {
+				published = parsed
+			}
+		}
+
+		tags := []string{feed.tag}
+		if tag, ok := row["tag"].(string); ok && tag != "" {
+			tags = append(tags, tag)
+		}
+
+		news = append(news, models.News{
+			ID:          fmt.Sprintf("moex_%s_%d", feed.path, int64(id)),
+			Title:       title,
+			Description: title,
+			URL:         fmt.Sprintf(feed.urlFormat, int64(id)),
+			Source:      moexExchangeSource,
+			PublishedAt: published,
+			CreatedAt:   time.Now(),
+			Tags:        tags,
+			RelatedTo:   extractTickers(title),
+			Language:    models.DefaultNewsLanguage,
+		})
+	}
+
+	return news
+}
+
+// issRows преобразует таблицу ответа ISS (columns + data) в список строк с доступом по имени колонки
+func issRows(data map[string]interface{}, table string) []map[string]interface{} {
+	block, ok := data[table].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	columns, ok := block["columns"].([]interface{})
+	if !ok {
+		return nil
+	}
+	rowsData, ok := block["data"].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	rows := make([]map[string]interface{}, 0, len(rowsData))
+	for _, item := range rowsData {
+		values, ok := item.([]interface{})
+		if !ok {
+			continue
+		}
+		row := make(map[string]interface{}, len(columns))
+		for i, column := range columns {
+			name, ok := column.(string)
+			if !ok || i >= len(values) {
+				continue
+			}
+			row[name] = values[i]
+		}
+		rows = append(rows, row)
+	}
+
+	return rows
+}
@@ -0,0 +1,91 @@
+package apis
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// tradesPageSize максимальное количество сделок в одной странице ответа ISS
+const tradesPageSize = 1000
+
+// GetRecentTrades получает последние limit сделок по бумаге в режиме торгов board, по умолчанию — в основном режиме бумаги.
+// ISS с параметром reversed отдает ленту от последней сделки, поэтому страницы запрашиваются, пока не набрано limit сделок.
+// Новые сделки сдвигают ленту между запросами страниц, и следующая страница повторяет часть предыдущей,
+// поэтому повторы отбрасываются по номеру сделки, а страницы запрашиваются целиком: короткая страница
+// при активных торгах состояла бы из одних повторов. Лента меняется с каждой сделкой, поэтому не кэшируется
+func (m *MOEXAPIClient) GetRecentTrades(ctx context.Context, ticker string, board models.TradingBoard, limit int) ([]models.Trade, error) {
+	board, err := m.resolveBoard(ctx, ticker, board)
+	if err != nil {
+		return nil, err
+	}
+
+	var trades []models.Trade
+	seen := make(map[int64]bool)
+	for start := 0; len(trades) < limit; start += tradesPageSize {
+		data, err := m.getISS(ctx, fmt.Sprintf(
+			"/engines/stock/markets/%s/boards/%s/securities/%s/trades.json?iss.meta=off&iss.only=trades&reversed=1&start=%d&limit=%d",
+			board.Market, board.Board, ticker, start, tradesPageSize,
+		))
+		if err != nil {
+			return nil, err
+		}
+
+		rows := issRows(data, "trades")
+		for _, row := range rows {
+			trade, ok := parseTrade(row)
+			if !ok || seen[trade.TradeNo] || len(trades) == limit {
+				continue
+			}
+			seen[trade.TradeNo] = true
+			trades = append(trades, trade)
+		}
+
+		if len(rows) < tradesPageSize {
+			break
+		}
+	}
+
+	sort.Slice(trades, func(i, j int) bool {
+		return trades[i].TradeNo < trades[j].TradeNo
+	})
+
+	return trades, nil
+}
+
+// parseTrade разбирает строку таблицы trades; дата и время сделки ISS возвращает по московскому времени
+func parseTrade(row map[string]interface{}) (models.Trade, bool) {
+	date, _ := row["TRADEDATE"].(string)
+	clock, _ := row["TRADETIME"].(string)
+	tradeTime, err := time.ParseInLocation("2006-01-02 15:04:05", date+" "+clock, moexLocation)
+	if err != nil {
+		return models.Trade{}, false
+	}
+
+	price, _ := row["PRICE"].(float64)
+	quantity, _ := row["QUANTITY"].(float64)
+	if price <= 0 || quantity <= 0 {
+		return models.Trade{}, false
+	}
+
+	tradeNo, _ := row["TRADENO"].(float64)
+	trade := models.Trade{
+		TradeNo:  int64(tradeNo),
+		Time:     tradeTime,
+		Price:    price,
+		Quantity: int64(quantity),
+	}
+	trade.Value, _ = row["VALUE"].(float64)
+
+	switch side, _ := row["BUYSELL"].(string); side {
+	case "B":
+		trade.Side = models.TradeSideBuy
+	case "S":
+		trade.Side = models.TradeSideSell
+	}
+
+	return trade, true
+}
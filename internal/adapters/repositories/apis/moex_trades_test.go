@@ -0,0 +1,65 @@
+package apis
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// tradesTape заглушка ленты сделок ISS: с параметром reversed отдает сделки от последней, а после
+// каждой страницы на бирже проходит новая сделка и сдвигает ленту, как при активных торгах
+type tradesTape struct {
+	mu     sync.Mutex
+	latest int // Номер последней сделки; сделки нумеруются с 1
+}
+
+func (t *tradesTape) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	start, _ := strconv.Atoi(r.URL.Query().Get("start"))
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+	data := [][]interface{}{}
+	for tradeNo := t.latest - start; tradeNo >= 1 && len(data) < limit; tradeNo-- {
+		data = append(data, []interface{}{tradeNo, "2026-10-16", "10:00:00", 250.5, 10, 2505.0, "B"})
+	}
+	t.latest++
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"trades": map[string]interface{}{
+			"columns": []string{"TRADENO", "TRADEDATE", "TRADETIME", "PRICE", "QUANTITY", "VALUE", "BUYSELL"},
+			"data":    data,
+		},
+	})
+}
+
+func TestGetRecentTradesShiftedPages(t *testing.T) {
+	server := httptest.NewServer(&tradesTape{latest: 5000})
+	defer server.Close()
+	client := &MOEXAPIClient{baseURL: server.URL, httpClient: server.Client()}
+
+	board := models.TradingBoard{Board: "TQBR", Market: "shares"}
+	for _, limit := range []int{1500, 2500, 4999} {
+		trades, err := client.GetRecentTrades(context.Background(), "SBER", board, limit)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(trades) != limit {
+			t.Fatalf("limit=%d: получено %d сделок", limit, len(trades))
+		}
+		// Вторая страница начинается со сделки, уже полученной первой: повтор отбрасывается,
+		// а следующая страница продолжает ленту без пропусков
+		for i := 1; i < len(trades); i++ {
+			if trades[i].TradeNo != trades[i-1].TradeNo+1 {
+				t.Fatalf("limit=%d: после сделки %d идет %d", limit, trades[i-1].TradeNo, trades[i].TradeNo)
+			}
+		}
+	}
+}
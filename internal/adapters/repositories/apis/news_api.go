@@ -3,10 +3,13 @@ package apis
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -15,6 +18,19 @@ import (
 	"github.com/JkLondon/mcp-stocks-info-server/pkg/cache"
 )
 
+const (
+	// newsAPITimeLayout формат времени параметров from и to в NewsAPI
+	newsAPITimeLayout = "2006-01-02T15:04:05"
+	// financeNewsQuery запрос, по которому отбираются финансовые новости
+	financeNewsQuery = "финансы OR экономика OR рынок OR биржа OR акции OR MOEX"
+	// NewsAPIPageSize максимальный размер страницы выдачи NewsAPI
+	NewsAPIPageSize = 100
+)
+
+// ErrNewsAPIResultsLimit возвращается, когда запрошенная страница превышает
+// доступную тарифу глубину выдачи NewsAPI
+var ErrNewsAPIResultsLimit = errors.New("достигнут лимит глубины выдачи NewsAPI")
+
 // NewsAPIClient представляет собой клиент для работы с API новостей
 type NewsAPIClient struct {
 	baseURL     string
@@ -29,10 +45,8 @@ type NewsAPIClient struct {
 // NewNewsAPIClient создает новый клиент для работы с API новостей
 func NewNewsAPIClient(cfg *config.Config, cache cache.Cache) *NewsAPIClient {
 	return &NewsAPIClient{
-		baseURL: cfg.NewsAPI.BaseURL,
-		httpClient: &http.Client{
-			Timeout: cfg.NewsAPI.Timeout,
-		},
+		baseURL:     cfg.NewsAPI.BaseURL,
+		httpClient:  newHTTPClient(cfg, cfg.NewsAPI.Timeout),
 		cache:       cache,
 		cacheExpiry: cfg.Cache.NewsTTL,
 		apiKey:      cfg.NewsAPI.APIKey,
@@ -41,16 +55,38 @@ func NewNewsAPIClient(cfg *config.Config, cache cache.Cache) *NewsAPIClient {
 	}
 }
 
+// Ping проверяет, что NewsAPI отвечает. Отправляется HEAD-запрос без ключа, чтобы проверки
+// доступности не расходовали суточный лимит запросов; ответ с любым статусом, кроме 5xx, считается успешным
+func (n *NewsAPIClient) Ping(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, n.baseURL, nil)
+	if err != nil {
+		return fmt.Errorf("не удалось создать запрос: %w", err)
+	}
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("NewsAPI вернул статус %s", resp.Status)
+	}
+	return nil
+}
+
 // GetTodayNews получает финансовые новости за сегодняшний день
 func (n *NewsAPIClient) GetTodayNews(ctx context.Context) ([]models.News, error) {
 	today := time.Now().Format("2006-01-02")
-	cacheKey := fmt.Sprintf("news:date:%s", today)
+	cacheKey := models.NewsDateCacheKey(today)
 
 	if n.useCache {
 		var cachedNews []models.News
-		err := n.cache.Get(ctx, cacheKey, &cachedNews)
-		if err == nil && len(cachedNews) > 0 {
+		switch err := n.cache.Get(ctx, cacheKey, &cachedNews); {
+		case err == nil:
 			return cachedNews, nil
+		case !errors.Is(err, cache.ErrNotFound):
+			log.Printf("Ошибка чтения кэша %s: %v", cacheKey, err)
 		}
 	}
 
@@ -59,7 +95,7 @@ func (n *NewsAPIClient) GetTodayNews(ctx context.Context) ([]models.News, error)
 
 	// Создаем query-параметры
 	params := url.Values{}
-	params.Add("q", "финансы OR экономика OR рынок OR биржа OR акции OR MOEX")
+	params.Add("q", financeNewsQuery)
 	params.Add("from", today)
 	params.Add("to", today)
 	params.Add("language", "ru")
@@ -94,49 +130,9 @@ func (n *NewsAPIClient) GetTodayNews(ctx context.Context) ([]models.News, error)
 		return nil, fmt.Errorf("ошибка чтения ответа: %w", err)
 	}
 
-	var newsResponse struct {
-		Status       string `json:"status"`
-		TotalResults int    `json:"totalResults"`
-		Articles     []struct {
-			Source struct {
-				ID   string `json:"id"`
-				Name string `json:"name"`
-			} `json:"source"`
-			Author      string    `json:"author"`
-			Title       string    `json:"title"`
-			Description string    `json:"description"`
-			URL         string    `json:"url"`
-			URLToImage  string    `json:"urlToImage"`
-			PublishedAt time.Time `json:"publishedAt"`
-			Content     string    `json:"content"`
-		} `json:"articles"`
-	}
-
-	if err := json.Unmarshal(body, &newsResponse); err != nil {
-		return nil, fmt.Errorf("ошибка при разборе JSON: %w", err)
-	}
-
-	// Преобразуем в нашу доменную модель
-	var news []models.News
-	for _, article := range newsResponse.Articles {
-		// Генерируем уникальный ID на основе URL новости
-		id := generateNewsID(article.URL)
-
-		// Создаем новость
-		newsItem := models.News{
-			ID:          id,
-			Title:       article.Title,
-			Description: article.Description,
-			Content:     article.Content,
-			URL:         article.URL,
-			Source:      article.Source.Name,
-			PublishedAt: article.PublishedAt,
-			CreatedAt:   time.Now(),
-			Tags:        extractTags(article.Title + " " + article.Description),
-			RelatedTo:   extractTickers(article.Title + " " + article.Description),
-		}
-
-		news = append(news, newsItem)
+	news, err := parseNewsAPIArticles(body, models.DefaultNewsLanguage)
+	if err != nil {
+		return nil, err
 	}
 
 	// Сохраняем в кэш
@@ -147,19 +143,21 @@ func (n *NewsAPIClient) GetTodayNews(ctx context.Context) ([]models.News, error)
 	return news, nil
 }
 
-// GetNewsByKeyword ищет новости по ключевому слову
-func (n *NewsAPIClient) GetNewsByKeyword(ctx context.Context, keyword string) ([]models.News, error) {
+// GetNewsByKeyword ищет новости по ключевому слову с учетом периода, изданий и языка
+func (n *NewsAPIClient) GetNewsByKeyword(ctx context.Context, keyword string, filter models.NewsFilter) ([]models.News, error) {
 	if keyword == "" {
 		return nil, fmt.Errorf("ключевое слово не может быть пустым")
 	}
 
-	cacheKey := fmt.Sprintf("news:keyword:%s", keyword)
+	cacheKey := models.NewsKeywordCacheKey(keyword, filter)
 
 	if n.useCache {
 		var cachedNews []models.News
-		err := n.cache.Get(ctx, cacheKey, &cachedNews)
-		if err == nil && len(cachedNews) > 0 {
+		switch err := n.cache.Get(ctx, cacheKey, &cachedNews); {
+		case err == nil:
 			return cachedNews, nil
+		case !errors.Is(err, cache.ErrNotFound):
+			log.Printf("Ошибка чтения кэша %s: %v", cacheKey, err)
 		}
 	}
 
@@ -169,12 +167,22 @@ func (n *NewsAPIClient) GetNewsByKeyword(ctx context.Context, keyword string) ([
 	// Создаем query-параметры
 	params := url.Values{}
 	params.Add("q", keyword)
-	params.Add("language", "ru")
+	params.Add("language", filter.Lang())
 	params.Add("sortBy", "publishedAt")
 	params.Add("apiKey", n.apiKey)
 
-	// Добавляем источники, если они указаны
-	if len(n.sources) > 0 {
+	// Период публикации: граница to в фильтре не включительная, а в NewsAPI — включительная
+	if !filter.From.IsZero() {
+		params.Add("from", filter.From.UTC().Format(newsAPITimeLayout))
+	}
+	if !filter.To.IsZero() {
+		params.Add("to", filter.To.Add(-time.Second).UTC().Format(newsAPITimeLayout))
+	}
+
+	// Добавляем источники: из фильтра или из конфигурации
+	if len(filter.Sources) > 0 {
+		params.Add("sources", strings.Join(filter.Sources, ","))
+	} else if len(n.sources) > 0 {
 		params.Add("sources", strings.Join(n.sources, ","))
 	}
 
@@ -201,57 +209,69 @@ func (n *NewsAPIClient) GetNewsByKeyword(ctx context.Context, keyword string) ([
 		return nil, fmt.Errorf("ошибка чтения ответа: %w", err)
 	}
 
-	var newsResponse struct {
-		Status       string `json:"status"`
-		TotalResults int    `json:"totalResults"`
-		Articles     []struct {
-			Source struct {
-				ID   string `json:"id"`
-				Name string `json:"name"`
-			} `json:"source"`
-			Author      string    `json:"author"`
-			Title       string    `json:"title"`
-			Description string    `json:"description"`
-			URL         string    `json:"url"`
-			URLToImage  string    `json:"urlToImage"`
-			PublishedAt time.Time `json:"publishedAt"`
-			Content     string    `json:"content"`
-		} `json:"articles"`
+	news, err := parseNewsAPIArticles(body, filter.Lang())
+	if err != nil {
+		return nil, err
 	}
 
-	if err := json.Unmarshal(body, &newsResponse); err != nil {
-		return nil, fmt.Errorf("ошибка при разборе JSON: %w", err)
+	// Сохраняем в кэш
+	if n.useCache && len(news) > 0 {
+		n.cache.Set(ctx, cacheKey, news, n.cacheExpiry)
 	}
 
-	// Преобразуем в нашу доменную модель
-	var news []models.News
-	for _, article := range newsResponse.Articles {
-		// Генерируем уникальный ID на основе URL новости
-		id := generateNewsID(article.URL)
+	return news, nil
+}
 
-		// Создаем новость
-		newsItem := models.News{
-			ID:          id,
-			Title:       article.Title,
-			Description: article.Description,
-			Content:     article.Content,
-			URL:         article.URL,
-			Source:      article.Source.Name,
-			PublishedAt: article.PublishedAt,
-			CreatedAt:   time.Now(),
-			Tags:        extractTags(article.Title + " " + article.Description),
-			RelatedTo:   extractTickers(article.Title + " " + article.Description),
-		}
+// GetNewsArchivePage получает страницу финансовых новостей за период [from, to).
+// Возвращает новости и общее число результатов по запросу
+func (n *NewsAPIClient) GetNewsArchivePage(ctx context.Context, from, to time.Time, page int) ([]models.News, int, error) {
+	apiURL := fmt.Sprintf("%s/everything", n.baseURL)
+
+	params := url.Values{}
+	params.Add("q", financeNewsQuery)
+	params.Add("from", from.UTC().Format(newsAPITimeLayout))
+	params.Add("to", to.Add(-time.Second).UTC().Format(newsAPITimeLayout))
+	params.Add("language", models.DefaultNewsLanguage)
+	params.Add("sortBy", "publishedAt")
+	params.Add("pageSize", strconv.Itoa(NewsAPIPageSize))
+	params.Add("page", strconv.Itoa(page))
+	params.Add("apiKey", n.apiKey)
 
-		news = append(news, newsItem)
+	if len(n.sources) > 0 {
+		params.Add("sources", strings.Join(n.sources, ","))
 	}
 
-	// Сохраняем в кэш
-	if n.useCache && len(news) > 0 {
-		n.cache.Set(ctx, cacheKey, news, n.cacheExpiry)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("не удалось создать запрос: %w", err)
 	}
 
-	return news, nil
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("ошибка выполнения запроса: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, fmt.Errorf("ошибка чтения ответа: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		// NewsAPI сообщает о превышении доступной глубины выдачи отдельным кодом
+		var errorResponse newsAPIResponse
+		if json.Unmarshal(body, &errorResponse) == nil && errorResponse.Code == "maximumResultsReached" {
+			return nil, 0, ErrNewsAPIResultsLimit
+		}
+		return nil, 0, fmt.Errorf("ошибка API новостей: %s", resp.Status)
+	}
+
+	var newsResponse newsAPIResponse
+	if err := json.Unmarshal(body, &newsResponse); err != nil {
+		return nil, 0, fmt.Errorf("ошибка при разборе JSON: %w", err)
+	}
+
+	return newsResponse.toNews(models.DefaultNewsLanguage), newsResponse.TotalResults, nil
 }
 
 // GetNewsByTicker находит новости, связанные с указанным тикером
@@ -260,13 +280,15 @@ func (n *NewsAPIClient) GetNewsByTicker(ctx context.Context, ticker string) ([]m
 		return nil, fmt.Errorf("тикер не может быть пустым")
 	}
 
-	cacheKey := fmt.Sprintf("news:ticker:%s", ticker)
+	cacheKey := models.NewsTickerCacheKey(ticker)
 
 	if n.useCache {
 		var cachedNews []models.News
-		err := n.cache.Get(ctx, cacheKey, &cachedNews)
-		if err == nil && len(cachedNews) > 0 {
+		switch err := n.cache.Get(ctx, cacheKey, &cachedNews); {
+		case err == nil:
 			return cachedNews, nil
+		case !errors.Is(err, cache.ErrNotFound):
+			log.Printf("Ошибка чтения кэша %s: %v", cacheKey, err)
 		}
 	}
 
@@ -294,6 +316,59 @@ func (n *NewsAPIClient) GetNewsByTicker(ctx context.Context, ticker string) ([]m
 
 // Вспомогательные функции
 
+// newsAPIResponse ответ эндпоинта /everything NewsAPI
+type newsAPIResponse struct {
+	Status       string `json:"status"`
+	Code         string `json:"code"`
+	Message      string `json:"message"`
+	TotalResults int    `json:"totalResults"`
+	Articles     []struct {
+		Source struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		} `json:"source"`
+		Author      string    `json:"author"`
+		Title       string    `json:"title"`
+		Description string    `json:"description"`
+		URL         string    `json:"url"`
+		URLToImage  string    `json:"urlToImage"`
+		PublishedAt time.Time `json:"publishedAt"`
+		Content     string    `json:"content"`
+	} `json:"articles"`
+}
+
+// toNews преобразует статьи ответа в доменную модель
+func (r *newsAPIResponse) toNews(language string) []models.News {
+	news := make([]models.News, 0, len(r.Articles))
+	for _, article := range r.Articles {
+		news = append(news, models.News{
+			// Генерируем уникальный ID на основе URL новости
+			ID:          generateNewsID(article.URL),
+			Title:       article.Title,
+			Description: article.Description,
+			Content:     article.Content,
+			URL:         article.URL,
+			Source:      article.Source.Name,
+			PublishedAt: article.PublishedAt,
+			CreatedAt:   time.Now(),
+			Tags:        extractTags(article.Title + " " + article.Description),
+			RelatedTo:   extractTickers(article.Title + " " + article.Description),
+			Language:    language,
+			Summary:     summarizeText(article.Content),
+		})
+	}
+	return news
+}
+
+// parseNewsAPIArticles разбирает ответ /everything и преобразует статьи в доменную модель
+func parseNewsAPIArticles(body []byte, language string) ([]models.News, error) {
+	var newsResponse newsAPIResponse
+	if err := json.Unmarshal(body, &newsResponse); err != nil {
+		return nil, fmt.Errorf("ошибка при разборе JSON: %w", err)
+	}
+	return newsResponse.toNews(language), nil
+}
+
 // generateNewsID генерирует ID новости на основе URL
 func generateNewsID(url string) string {
 	// Простой способ - возвращаем последнюю часть URL без расширения
@@ -309,7 +384,7 @@ func generateNewsID(url string) string {
 	return fmt.Sprintf("news_%d", time.Now().Unix())
 }
 
-// extractTags извлекает ключевые слова/теги из текста
+// extractTags извлекает ключевые слова/теги и темы новости из текста
 func extractTags(text string) []string {
 	// Простая реализация - ищем ключевые финансовые термины
 	keywords := []string{
@@ -326,27 +401,13 @@ func extractTags(text string) []string {
 		}
 	}
 
-	return tags
+	// Темы сводки новостей (банки, нефть и газ и т.д.) тоже сохраняем тегами
+	return append(tags, models.ClassifyNewsTopics(text, extractTickers(text))...)
 }
 
-// extractTickers извлекает тикеры акций из текста
+// extractTickers извлекает из текста тикеры упомянутых бумаг по текущему словарю тикеров и названий компаний
 func extractTickers(text string) []string {
-	// Список наиболее популярных российских тикеров
-	popularTickers := []string{
-		"SBER", "GAZP", "LKOH", "GMKN", "ROSN", "NVTK", "TATN",
-		"MTSS", "MGNT", "YNDX", "FIVE", "POLY", "ALRS", "VTBR",
-	}
-
-	var tickers []string
-	textUpper := strings.ToUpper(text)
-
-	for _, ticker := range popularTickers {
-		if strings.Contains(textUpper, ticker) {
-			tickers = append(tickers, ticker)
-		}
-	}
-
-	return tickers
+	return tickerDictionary.Load().Extract(text)
 }
 
 // containsTicker проверяет, связана ли новость с указанным тикером
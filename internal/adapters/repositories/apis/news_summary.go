@@ -0,0 +1,39 @@
+package apis
+
+import (
+	"regexp"
+	"sync/atomic"
+	"unicode/utf8"
+
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/textrank"
+)
+
+// SummaryOptions параметры резюме, которые парсеры новостей составляют при загрузке статей
+type SummaryOptions struct {
+	Sentences int // Число предложений резюме
+	MinLength int // Длина текста, начиная с которой статья резюмируется
+}
+
+// summaryOptions параметры резюме для парсеров новостей; nil — резюме не составляются
+var summaryOptions atomic.Pointer[SummaryOptions]
+
+// SetSummaryOptions включает резюме загружаемых новостей методом TextRank; nil отключает их
+func SetSummaryOptions(options *SummaryOptions) {
+	summaryOptions.Store(options)
+}
+
+// truncatedContentMarker отметка, которой NewsAPI заканчивает сокращенный текст статьи: «… [+1234 chars]»
+var truncatedContentMarker = regexp.MustCompile(`\s*\[\+\d+ chars\]\s*$`)
+
+// summarizeText возвращает резюме текста статьи или пустую строку, если резюме отключены или статья короткая
+func summarizeText(text string) string {
+	options := summaryOptions.Load()
+	if options == nil {
+		return ""
+	}
+	text = truncatedContentMarker.ReplaceAllString(text, "")
+	if utf8.RuneCountInString(text) < options.MinLength {
+		return ""
+	}
+	return textrank.Summarize(text, options.Sentences)
+}
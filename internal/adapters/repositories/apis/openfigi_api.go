@@ -0,0 +1,140 @@
+package apis
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/config"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/cache"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/timing"
+)
+
+// OpenFIGIClient клиент API сопоставления идентификаторов OpenFIGI
+type OpenFIGIClient struct {
+	baseURL     string
+	httpClient  *http.Client
+	cache       cache.Cache
+	cacheExpiry time.Duration
+	apiKey      string
+}
+
+// NewOpenFIGIClient создает новый клиент OpenFIGI
+func NewOpenFIGIClient(cfg *config.Config, cache cache.Cache) *OpenFIGIClient {
+	return &OpenFIGIClient{
+		baseURL: cfg.OpenFIGI.BaseURL,
+		httpClient: &http.Client{
+			Timeout:   cfg.OpenFIGI.Timeout,
+			Transport: &timing.Transport{},
+		},
+		cache:       cache,
+		cacheExpiry: cfg.OpenFIGI.CacheTTL,
+		apiKey:      cfg.OpenFIGI.APIKey,
+	}
+}
+
+// openFIGIJob запрос сопоставления одного идентификатора
+type openFIGIJob struct {
+	IDType  string `json:"idType"`
+	IDValue string `json:"idValue"`
+}
+
+// openFIGIResult ответ на запрос сопоставления: найденные бумаги или сообщение об ошибке
+type openFIGIResult struct {
+	Data []struct {
+		FIGI     string `json:"figi"`
+		Ticker   string `json:"ticker"`
+		ExchCode string `json:"exchCode"`
+		Name     string `json:"name"`
+	} `json:"data"`
+	Warning string `json:"warning"`
+	Error   string `json:"error"`
+}
+
+// ResolveFIGI сопоставляет FIGI бумаге. Сопоставления кэшируются надолго, в том числе отсутствие бумаги:
+// FIGI присваивается один раз и не меняется
+func (c *OpenFIGIClient) ResolveFIGI(ctx context.Context, figi string) (*models.FIGIMapping, error) {
+	figi = strings.ToUpper(strings.TrimSpace(figi))
+	cacheKey := fmt.Sprintf("figi:%s", figi)
+
+	var mapping models.FIGIMapping
+	switch err := c.cache.Get(ctx, cacheKey, &mapping); {
+	case err == nil:
+		if mapping.Ticker == "" {
+			return nil, nil
+		}
+		return &mapping, nil
+	case !errors.Is(err, cache.ErrNotFound):
+		log.Printf("Ошибка чтения кэша %s: %v", cacheKey, err)
+	}
+
+	body, err := json.Marshal([]openFIGIJob{{IDType: "ID_BB_GLOBAL", IDValue: figi}})
+	if err != nil {
+		return nil, fmt.Errorf("не удалось сформировать запрос: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/mapping", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("не удалось создать запрос: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.apiKey != "" {
+		req.Header.Set("X-OPENFIGI-APIKEY", c.apiKey)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка выполнения запроса: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("ошибка API OpenFIGI: %s", resp.Status)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка чтения ответа: %w", err)
+	}
+
+	var results []openFIGIResult
+	if err := json.Unmarshal(data, &results); err != nil {
+		return nil, fmt.Errorf("ошибка при разборе JSON: %w", err)
+	}
+	if len(results) == 0 {
+		return nil, fmt.Errorf("пустой ответ OpenFIGI")
+	}
+	if results[0].Error != "" {
+		return nil, fmt.Errorf("ошибка API OpenFIGI: %s", results[0].Error)
+	}
+
+	// Неизвестный FIGI OpenFIGI возвращает с предупреждением и без данных. Из нескольких листингов
+	// предпочитается листинг MOEX, код биржи сохраняется в кэше вместе с тикером
+	mapping = models.FIGIMapping{FIGI: figi}
+	if len(results[0].Data) > 0 {
+		item := results[0].Data[0]
+		for _, candidate := range results[0].Data {
+			if models.IsMOEXExchCode(candidate.ExchCode) {
+				item = candidate
+				break
+			}
+		}
+		mapping.Ticker = strings.ToUpper(item.Ticker)
+		mapping.ExchCode = item.ExchCode
+		mapping.Name = item.Name
+	}
+	c.cache.Set(ctx, cacheKey, mapping, c.cacheExpiry)
+
+	if mapping.Ticker == "" {
+		return nil, nil
+	}
+	return &mapping, nil
+}
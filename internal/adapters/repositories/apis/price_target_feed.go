@@ -0,0 +1,88 @@
+package apis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// priceTargetFeedEntry запись файла целевых цен аналитиков
+type priceTargetFeedEntry struct {
+	Ticker  string  `json:"ticker"`
+	Analyst string  `json:"analyst"` // Брокер или аналитик, опубликовавший прогноз
+	Target  float64 `json:"target"`
+	Rating  string  `json:"rating"` // buy, hold или sell; необязательно
+	Date    string  `json:"date"`   // YYYY-MM-DD, дата публикации прогноза
+	Note    string  `json:"note"`
+}
+
+// PriceTargetFeedFile целевые цены аналитиков из JSON-файла, который ведет оператор сервера.
+// ISS не публикует прогнозы брокеров, поэтому консенсус собирается из этого файла
+type PriceTargetFeedFile struct {
+	path string
+}
+
+// NewPriceTargetFeedFile создает источник целевых цен из файла
+func NewPriceTargetFeedFile(path string) *PriceTargetFeedFile {
+	return &PriceTargetFeedFile{
+		path: path,
+	}
+}
+
+// Name возвращает название источника
+func (f *PriceTargetFeedFile) Name() string {
+	return fmt.Sprintf("файл %s", f.path)
+}
+
+// GetPriceTargets читает все цели файла
+func (f *PriceTargetFeedFile) GetPriceTargets(ctx context.Context) ([]models.PriceTarget, error) {
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка чтения файла целевых цен: %w", err)
+	}
+
+	var entries []priceTargetFeedEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("ошибка разбора файла целевых цен %s: %w", f.path, err)
+	}
+
+	targets := make([]models.PriceTarget, 0, len(entries))
+	for i, entry := range entries {
+		ticker := strings.ToUpper(strings.TrimSpace(entry.Ticker))
+		if ticker == "" {
+			return nil, fmt.Errorf("запись %d файла целевых цен: не указан тикер", i+1)
+		}
+		analyst := strings.TrimSpace(entry.Analyst)
+		if analyst == "" {
+			return nil, fmt.Errorf("запись %s файла целевых цен: не указан аналитик", ticker)
+		}
+		if entry.Target <= 0 {
+			return nil, fmt.Errorf("запись %s от %s файла целевых цен: цель должна быть положительной", ticker, analyst)
+		}
+		rating := strings.ToLower(entry.Rating)
+		if rating != "" && !slices.Contains(models.PriceTargetRatings, rating) {
+			return nil, fmt.Errorf("запись %s от %s файла целевых цен: неизвестная рекомендация %s", ticker, analyst, entry.Rating)
+		}
+
+		target := models.PriceTarget{
+			Ticker:  ticker,
+			Source:  models.PriceTargetSourceFeed,
+			Analyst: analyst,
+			Target:  entry.Target,
+			Rating:  rating,
+			Note:    entry.Note,
+		}
+		if target.SetAt, err = time.ParseInLocation("2006-01-02", entry.Date, moexLocation); err != nil {
+			return nil, fmt.Errorf("запись %s от %s файла целевых цен: некорректная дата %s", ticker, analyst, entry.Date)
+		}
+		targets = append(targets, target)
+	}
+
+	return targets, nil
+}
@@ -0,0 +1,108 @@
+package apis
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/config"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/rawarchive"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/timing"
+)
+
+// ErrRawPayloadUnsupported возвращается для сохраненных ответов ресурсов, которые не разбираются повторно
+var ErrRawPayloadUnsupported = errors.New("повторный разбор ответа этого ресурса не поддерживается")
+
+// newHTTPClient создает HTTP-клиент внешнего API с замером времени запросов и,
+// если архив включен, записью необработанных ответов в архив
+func newHTTPClient(cfg *config.Config, timeout time.Duration) *http.Client {
+	var base http.RoundTripper
+	if cfg.RawArchive.Enabled {
+		base = &rawarchive.Transport{Archive: rawarchive.New(cfg.RawArchive.Dir, cfg.RawArchive.Retention)}
+	}
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: &timing.Transport{Base: base},
+	}
+}
+
+// boardQuotePath путь запроса котировки бумаги в режиме торгов: рынок, режим и тикер
+var boardQuotePath = regexp.MustCompile(`/markets/([^/]+)/boards/([^/]+)/securities/([^/]+)\.json$`)
+
+// ParseRawPayload повторно разбирает сохраненный в архиве ответ MOEX или NewsAPI теми же парсерами,
+// что используются при запросах. Время создания новостей и обновления котировок берется из времени получения ответа
+func ParseRawPayload(rec rawarchive.Record) ([]models.News, []models.Stock, error) {
+	u, err := url.Parse(rec.URL)
+	if err != nil {
+		return nil, nil, fmt.Errorf("некорректный URL %q: %w", rec.URL, err)
+	}
+
+	if strings.HasSuffix(u.Path, "/everything") {
+		language := u.Query().Get("language")
+		if language == "" {
+			language = models.DefaultNewsLanguage
+		}
+		news, err := parseNewsAPIArticles(rec.Body, language)
+		if err != nil {
+			return nil, nil, err
+		}
+		for i := range news {
+			news[i].CreatedAt = rec.FetchedAt
+		}
+		return news, nil, nil
+	}
+
+	if !strings.HasSuffix(u.Path, ".json") {
+		return nil, nil, ErrRawPayloadUnsupported
+	}
+
+	var responseData map[string]interface{}
+	if err := json.Unmarshal(rec.Body, &responseData); err != nil {
+		return nil, nil, fmt.Errorf("ошибка при разборе JSON: %w", err)
+	}
+
+	resource := strings.TrimSuffix(path.Base(u.Path), ".json")
+
+	// Официальные сообщения биржи
+	for _, feed := range moexAnnouncementFeeds {
+		if resource == feed.path {
+			news := parseAnnouncementFeed(responseData, feed)
+			for i := range news {
+				news[i].CreatedAt = rec.FetchedAt
+			}
+			return news, nil, nil
+		}
+	}
+
+	// Котировка бумаги в режиме торгов; запросы отдельных таблиц режима (для профиля компании) ее не содержат
+	if match := boardQuotePath.FindStringSubmatch(u.Path); match != nil && u.Query().Get("iss.only") == "securities,marketdata" {
+		board := models.TradingBoard{Market: match[1], Board: match[2]}
+		stock, err := parseBoardStock(responseData, match[3], board)
+		if err != nil {
+			return nil, nil, err
+		}
+		stock.UpdatedAt = rec.FetchedAt
+		return nil, []models.Stock{*stock}, nil
+	}
+
+	// Запросы отдельных таблиц (описание бумаги, данные режима торгов для профиля компании) котировок не содержат
+	if resource != "topgainers" || u.Query().Get("iss.only") != "" {
+		return nil, nil, ErrRawPayloadUnsupported
+	}
+
+	// Рейтинг растущих акций
+	stocks := parseStocksFromResponse(responseData)
+	for i := range stocks {
+		stocks[i].UpdatedAt = rec.FetchedAt
+	}
+
+	return nil, stocks, nil
+}
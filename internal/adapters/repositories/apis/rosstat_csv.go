@@ -0,0 +1,105 @@
+package apis
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// RosstatCSVFile макропоказатели из CSV-выгрузки Росстата, которую ведет оператор сервера.
+// Файл содержит заголовок и столбцы indicator, period, value; разделитель — точка с запятой или запятая.
+// Период задается как YYYY-MM (месяц), YYYY-QN (квартал) или YYYY-MM-DD (день)
+type RosstatCSVFile struct {
+	path string
+}
+
+// NewRosstatCSVFile создает источник макропоказателей из CSV-файла
+func NewRosstatCSVFile(path string) *RosstatCSVFile {
+	return &RosstatCSVFile{path: path}
+}
+
+// Name возвращает название источника
+func (f *RosstatCSVFile) Name() string {
+	return fmt.Sprintf("файл Росстата %s", f.path)
+}
+
+// GetMacroIndicators читает все значения файла; файл перечитывается при каждой загрузке
+func (f *RosstatCSVFile) GetMacroIndicators(ctx context.Context) ([]models.MacroIndicator, error) {
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка чтения выгрузки Росстата: %w", err)
+	}
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	if firstLine, _, _ := bytes.Cut(data, []byte("\n")); bytes.Contains(firstLine, []byte(";")) {
+		reader.Comma = ';'
+	}
+	reader.TrimLeadingSpace = true
+
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("ошибка разбора выгрузки Росстата %s: %w", f.path, err)
+	}
+	if len(rows) < 2 {
+		return nil, nil
+	}
+
+	now := time.Now()
+	indicators := make([]models.MacroIndicator, 0, len(rows)-1)
+	for i, row := range rows[1:] {
+		line := i + 2
+		if len(row) < 3 {
+			return nil, fmt.Errorf("строка %d выгрузки Росстата: ожидается 3 столбца", line)
+		}
+
+		code := strings.ToLower(strings.TrimSpace(row[0]))
+		if _, ok := models.FindMacroIndicator(code); !ok {
+			return nil, fmt.Errorf("строка %d выгрузки Росстата: неизвестный показатель %s", line, code)
+		}
+		period, err := parseMacroPeriod(strings.TrimSpace(row[1]))
+		if err != nil {
+			return nil, fmt.Errorf("строка %d выгрузки Росстата: %w", line, err)
+		}
+		// Росстат публикует числа с десятичной запятой
+		value, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(row[2]), ",", "."), 64)
+		if err != nil {
+			return nil, fmt.Errorf("строка %d выгрузки Росстата: некорректное значение %s", line, row[2])
+		}
+
+		indicators = append(indicators, models.MacroIndicator{
+			Code:      code,
+			Period:    period,
+			Value:     value,
+			Source:    models.MacroSourceRosstat,
+			UpdatedAt: now,
+		})
+	}
+
+	return indicators, nil
+}
+
+// parseMacroPeriod разбирает период выгрузки и возвращает его начало
+func parseMacroPeriod(value string) (time.Time, error) {
+	if year, quarter, ok := strings.Cut(strings.ToUpper(value), "-Q"); ok {
+		y, yearErr := strconv.Atoi(year)
+		q, quarterErr := strconv.Atoi(quarter)
+		if yearErr != nil || quarterErr != nil || q < 1 || q > 4 {
+			return time.Time{}, fmt.Errorf("некорректный квартал %s", value)
+		}
+		return time.Date(y, time.Month(3*(q-1)+1), 1, 0, 0, 0, 0, moexLocation), nil
+	}
+	if period, err := time.ParseInLocation("2006-01-02", value, moexLocation); err == nil {
+		return period, nil
+	}
+	if period, err := time.ParseInLocation("2006-01", value, moexLocation); err == nil {
+		return period, nil
+	}
+	return time.Time{}, fmt.Errorf("некорректный период %s", value)
+}
@@ -0,0 +1,198 @@
+package apis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/config"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/timing"
+)
+
+const (
+	// telegramTitleLength максимальная длина заголовка, составленного из первой строки сообщения
+	telegramTitleLength = 120
+	// telegramDescriptionLength максимальная длина описания новости
+	telegramDescriptionLength = 300
+)
+
+// TelegramClient представляет собой клиент Telegram Bot API для чтения сообщений каналов
+type TelegramClient struct {
+	baseURL    string
+	httpClient *http.Client
+	token      string
+	channels   map[string]bool
+
+	mu     sync.Mutex
+	offset int64 // Идентификатор следующего обновления после последнего подтвержденного чтения
+}
+
+// NewTelegramClient создает новый клиент Telegram Bot API
+func NewTelegramClient(cfg *config.Config) *TelegramClient {
+	channels := make(map[string]bool, len(cfg.Telegram.Channels))
+	for _, channel := range cfg.Telegram.Channels {
+		channels[normalizeChannel(channel)] = true
+	}
+
+	return &TelegramClient{
+		baseURL: cfg.Telegram.BaseURL,
+		httpClient: &http.Client{
+			Timeout:   cfg.Telegram.Timeout,
+			Transport: &timing.Transport{},
+		},
+		token:    cfg.Telegram.BotToken,
+		channels: channels,
+	}
+}
+
+// telegramMessage сообщение канала в формате Bot API
+type telegramMessage struct {
+	MessageID int64 `json:"message_id"`
+	Date      int64 `json:"date"`
+	Chat      struct {
+		Username string `json:"username"`
+		Title    string `json:"title"`
+	} `json:"chat"`
+	Text    string `json:"text"`
+	Caption string `json:"caption"`
+}
+
+// GetChannelPosts возвращает новые сообщения настроенных каналов с последнего подтвержденного обновления
+// и смещение, с которого начнется следующее чтение. Смещение применяется только через CommitOffset,
+// после сохранения сообщений: иначе ошибка сохранения потеряла бы их, так как Telegram больше их не вернет.
+// Сообщения других чатов пропускаются, но тоже учитываются в смещении
+func (t *TelegramClient) GetChannelPosts(ctx context.Context) ([]models.News, int64, error) {
+	t.mu.Lock()
+	offset := t.offset
+	t.mu.Unlock()
+
+	params := url.Values{}
+	params.Add("offset", strconv.FormatInt(offset, 10))
+	params.Add("allowed_updates", `["channel_post","edited_channel_post"]`)
+
+	apiURL := fmt.Sprintf("%s/bot%s/getUpdates?%s", t.baseURL, t.token, params.Encode())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
+	if err != nil {
+		return nil, offset, fmt.Errorf("не удалось создать запрос: %w", err)
+	}
+
+	resp, err := t.httpClient.Do(req)
+	if err != nil {
+		// Ошибка содержит URL с токеном бота, поэтому в сообщение ее не включаем
+		return nil, offset, fmt.Errorf("ошибка выполнения запроса к Telegram Bot API")
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, offset, fmt.Errorf("ошибка чтения ответа: %w", err)
+	}
+
+	var updatesResponse struct {
+		OK          bool   `json:"ok"`
+		Description string `json:"description"`
+		Result      []struct {
+			UpdateID          int64            `json:"update_id"`
+			ChannelPost       *telegramMessage `json:"channel_post"`
+			EditedChannelPost *telegramMessage `json:"edited_channel_post"`
+		} `json:"result"`
+	}
+
+	if err := json.Unmarshal(body, &updatesResponse); err != nil {
+		return nil, offset, fmt.Errorf("ошибка при разборе JSON: %w", err)
+	}
+	if !updatesResponse.OK {
+		return nil, offset, fmt.Errorf("ошибка Telegram Bot API: %s", updatesResponse.Description)
+	}
+
+	var news []models.News
+	next := offset
+	for _, update := range updatesResponse.Result {
+		if update.UpdateID >= next {
+			next = update.UpdateID + 1
+		}
+
+		message := update.ChannelPost
+		if message == nil {
+			message = update.EditedChannelPost
+		}
+		if message == nil || !t.channels[normalizeChannel(message.Chat.Username)] {
+			continue
+		}
+
+		if item, ok := telegramPostToNews(message); ok {
+			news = append(news, item)
+		}
+	}
+
+	return news, next, nil
+}
+
+// CommitOffset подтверждает чтение обновлений до смещения next, полученного от GetChannelPosts
+func (t *TelegramClient) CommitOffset(next int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if next > t.offset {
+		t.offset = next
+	}
+}
+
+// telegramPostToNews преобразует сообщение канала в новость; сообщения без текста пропускаются
+func telegramPostToNews(message *telegramMessage) (models.News, bool) {
+	text := strings.TrimSpace(message.Text)
+	if text == "" {
+		text = strings.TrimSpace(message.Caption)
+	}
+	if text == "" {
+		return models.News{}, false
+	}
+
+	channel := normalizeChannel(message.Chat.Username)
+	title, _, _ := strings.Cut(text, "\n")
+
+	source := "Telegram @" + channel
+	if message.Chat.Title != "" {
+		source = fmt.Sprintf("Telegram: %s (@%s)", message.Chat.Title, channel)
+	}
+
+	return models.News{
+		// ID повторяет сообщение канала, поэтому отредактированный пост заменяет исходный
+		ID:          fmt.Sprintf("tg_%s_%d", channel, message.MessageID),
+		Title:       truncateText(title, telegramTitleLength),
+		Description: truncateText(text, telegramDescriptionLength),
+		Content:     text,
+		URL:         fmt.Sprintf("https://t.me/%s/%d", channel, message.MessageID),
+		Source:      source,
+		PublishedAt: time.Unix(message.Date, 0),
+		CreatedAt:   time.Now(),
+		Tags:        extractTags(text),
+		RelatedTo:   extractTickers(text),
+		Language:    models.DefaultNewsLanguage,
+		Summary:     summarizeText(text),
+	}, true
+}
+
+// normalizeChannel приводит имя канала к виду без @ в нижнем регистре
+func normalizeChannel(channel string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(channel), "@"))
+}
+
+// truncateText обрезает текст до указанного числа символов, добавляя многоточие
+func truncateText(text string, limit int) string {
+	text = strings.TrimSpace(text)
+	if utf8.RuneCountInString(text) <= limit {
+		return text
+	}
+	runes := []rune(text)
+	return strings.TrimSpace(string(runes[:limit-1])) + "…"
+}
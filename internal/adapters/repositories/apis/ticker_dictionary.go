@@ -0,0 +1,356 @@
+package apis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+	"unicode"
+
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/cache"
+)
+
+// builtinTickerAliases названия компаний, упоминания которых сопоставляются тикерам без загрузки списка бумаг MOEX
+var builtinTickerAliases = map[string][]string{
+	"SBER": {"Сбербанк", "Сбер"},
+	"GAZP": {"Газпром"},
+	"LKOH": {"Лукойл"},
+	"GMKN": {"Норникель", "Норильский никель"},
+	"ROSN": {"Роснефть"},
+	"NVTK": {"Новатэк"},
+	"TATN": {"Татнефть"},
+	"MTSS": {"МТС"},
+	"MGNT": {"Магнит"},
+	"YNDX": {"Яндекс", "Yandex"},
+	"FIVE": {"X5 Group", "X5 Retail"},
+	"POLY": {"Полиметалл", "Polymetal"},
+	"ALRS": {"Алроса"},
+	"VTBR": {"ВТБ"},
+}
+
+// BuiltinTickerAliases возвращает встроенные названия компаний по тикерам
+func BuiltinTickerAliases() map[string][]string {
+	return builtinTickerAliases
+}
+
+// russianEndings падежные окончания существительных, с которыми название компании на кириллице считается упомянутым:
+// «Сбербанка», «Газпромом», «Алросы», «Норникелю»
+var russianEndings = []string{
+	"а", "я", "у", "ю", "е", "и", "ы", "ом", "ем", "ой", "ей", "ов", "ев", "ам", "ям", "ах", "ях", "ами", "ями",
+}
+
+// adjectiveEndings окончания прилагательных в названиях: «Норильского никеля», «Московской биржи»
+var adjectiveEndings = []string{
+	"ий", "ый", "ой", "ая", "яя", "ое", "ее", "ого", "его", "ому", "ему", "им", "ым", "ом", "ем",
+	"ую", "юю", "ые", "ие", "ых", "их", "ыми", "ими",
+}
+
+// minInflectedAlias минимальная длина слова названия, к которому допускаются окончания;
+// короткие названия (МТС, ВТБ) сопоставляются только целиком
+const minInflectedAlias = 4
+
+// TickerDictionary словарь для поиска упоминаний бумаг в тексте: тикеры и названия компаний
+type TickerDictionary struct {
+	tickers map[string]bool
+	aliases []tickerAlias
+}
+
+// tickerAlias название компании, разбитое на слова
+type tickerAlias struct {
+	ticker string
+	words  []string
+}
+
+// tickerDictionary словарь, которым пользуются парсеры новостей; заменяется после загрузки списка бумаг MOEX
+var tickerDictionary atomic.Pointer[TickerDictionary]
+
+func init() {
+	tickerDictionary.Store(NewTickerDictionary(nil, nil))
+}
+
+// SetTickerDictionary задает словарь, по которому из новостей извлекаются связанные тикеры
+func SetTickerDictionary(d *TickerDictionary) {
+	tickerDictionary.Store(d)
+}
+
+// NewTickerDictionary создает словарь из встроенных названий, списка бумаг securities (тикер → названия)
+// и дополнительных названий из конфигурации
+func NewTickerDictionary(securities map[string][]string, configAliases map[string][]string) *TickerDictionary {
+	d := &TickerDictionary{tickers: make(map[string]bool)}
+	seen := make(map[string]bool)
+
+	add := func(source map[string][]string) {
+		for ticker, names := range source {
+			ticker = strings.ToUpper(strings.TrimSpace(ticker))
+			if ticker == "" {
+				continue
+			}
+			d.tickers[ticker] = true
+
+			for _, name := range names {
+				words := splitWords(name)
+				if len(words) == 0 {
+					continue
+				}
+				key := ticker + "|" + strings.Join(words, " ")
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+				d.aliases = append(d.aliases, tickerAlias{ticker: ticker, words: words})
+			}
+		}
+	}
+	// Порядок задает приоритет при совпадении названий: конфигурация, затем список бумаг MOEX
+	// (например, «Яндекс» — YDEX после смены тикера), затем встроенный словарь
+	add(configAliases)
+	add(securities)
+	add(builtinTickerAliases)
+
+	// Длинные названия проверяются первыми, чтобы «Норильский никель» не разбирался по частям
+	sort.SliceStable(d.aliases, func(i, j int) bool {
+		return len(d.aliases[i].words) > len(d.aliases[j].words)
+	})
+
+	return d
+}
+
+// Extract возвращает тикеры бумаг, упомянутых в тексте тикером или названием компании.
+// Тикер должен быть отдельным словом в верхнем регистре, названия сравниваются по словам без учета регистра
+func (d *TickerDictionary) Extract(text string) []string {
+	var tickers []string
+	found := make(map[string]bool)
+	addTicker := func(ticker string) {
+		if !found[ticker] {
+			found[ticker] = true
+			tickers = append(tickers, ticker)
+		}
+	}
+
+	// Тикеры: слово целиком в верхнем регистре, чтобы английское «five» не превращалось в FIVE
+	for _, word := range strings.FieldsFunc(text, isNotWordRune) {
+		if d.tickers[word] {
+			addTicker(word)
+		}
+	}
+
+	// Названия: каждое слово текста относится не более чем к одному названию,
+	// поэтому «Газпром нефть» не засчитывается одновременно как упоминание «Газпрома»
+	words := splitWords(text)
+	used := make([]bool, len(words))
+	for _, alias := range d.aliases {
+		for i := 0; i+len(alias.words) <= len(words); i++ {
+			if aliasMatchesAt(words, used, i, alias.words) {
+				for j := range alias.words {
+					used[i+j] = true
+				}
+				addTicker(alias.ticker)
+			}
+		}
+	}
+
+	return tickers
+}
+
+// aliasMatchesAt проверяет, что название встречается в тексте начиная со слова i и его слова еще не заняты
+func aliasMatchesAt(words []string, used []bool, i int, alias []string) bool {
+	for j, aliasWord := range alias {
+		if used[i+j] || !wordMatches(words[i+j], aliasWord) {
+			return false
+		}
+	}
+	return true
+}
+
+// wordMatches сравнивает слово текста со словом названия с учетом русских падежных окончаний
+func wordMatches(word, aliasWord string) bool {
+	if word == aliasWord {
+		return true
+	}
+	runes := []rune(aliasWord)
+	if len(runes) < minInflectedAlias || !isCyrillic(aliasWord) {
+		return false
+	}
+
+	// Прилагательные склоняются по своей парадигме: «норильский» → «норильского»
+	for _, suffix := range []string{"ий", "ый", "ой"} {
+		if strings.HasSuffix(aliasWord, suffix) {
+			return hasEnding(word, strings.TrimSuffix(aliasWord, suffix), adjectiveEndings)
+		}
+	}
+
+	if hasEnding(word, aliasWord, russianEndings) {
+		return true
+	}
+	// Конечные «а», «я», «ь», «й» при склонении заменяются окончанием: «Алроса» → «Алросы», «Норникель» → «Норникелю»
+	switch runes[len(runes)-1] {
+	case 'а', 'я', 'ь', 'й':
+		return hasEnding(word, string(runes[:len(runes)-1]), russianEndings)
+	}
+	return false
+}
+
+// hasEnding проверяет, что слово состоит из основы и одного из окончаний
+func hasEnding(word, stem string, endings []string) bool {
+	if !strings.HasPrefix(word, stem) {
+		return false
+	}
+	ending := strings.TrimPrefix(word, stem)
+	for _, candidate := range endings {
+		if ending == candidate {
+			return true
+		}
+	}
+	return false
+}
+
+// splitWords разбивает текст на слова в нижнем регистре, «ё» приводится к «е»
+func splitWords(text string) []string {
+	text = strings.ReplaceAll(strings.ToLower(text), "ё", "е")
+	return strings.FieldsFunc(text, isNotWordRune)
+}
+
+// isNotWordRune проверяет, что символ не может быть частью слова
+func isNotWordRune(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
+
+// isCyrillic проверяет, что слово записано кириллицей
+func isCyrillic(word string) bool {
+	for _, r := range word {
+		if !unicode.Is(unicode.Cyrillic, r) {
+			return false
+		}
+	}
+	return true
+}
+
+// LoadTickerDictionary загружает список акций основного режима торгов MOEX и строит по нему словарь
+// с названиями компаний вместе с дополнительными названиями из конфигурации
+func (m *MOEXAPIClient) LoadTickerDictionary(ctx context.Context, configAliases map[string][]string) (*TickerDictionary, error) {
+	securities, err := m.SecurityNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return NewTickerDictionary(securities, configAliases), nil
+}
+
+// SecurityNames возвращает названия акций режима TQBR по тикерам
+func (m *MOEXAPIClient) SecurityNames(ctx context.Context) (map[string][]string, error) {
+	cacheKey := "moex:security_names"
+
+	if m.useCache {
+		var cachedNames map[string][]string
+		switch err := m.cache.Get(ctx, cacheKey, &cachedNames); {
+		case err == nil:
+			return cachedNames, nil
+		case !errors.Is(err, cache.ErrNotFound):
+			log.Printf("Ошибка чтения кэша %s: %v", cacheKey, err)
+		}
+	}
+
+	url := fmt.Sprintf("%s/engines/stock/markets/shares/boards/TQBR/securities.json?iss.meta=off&iss.only=securities&securities.columns=SECID,SHORTNAME,SECNAME", m.baseURL)
+	if m.apiKey != "" {
+		url += fmt.Sprintf("&apikey=%s", m.apiKey)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось создать запрос: %w", err)
+	}
+
+	resp, err := m.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка выполнения запроса: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("ошибка API MOEX: %s", resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка чтения ответа: %w", err)
+	}
+
+	var responseData map[string]interface{}
+	if err := json.Unmarshal(body, &responseData); err != nil {
+		return nil, fmt.Errorf("ошибка при разборе JSON: %w", err)
+	}
+
+	names := make(map[string][]string)
+	for _, row := range issRows(responseData, "securities") {
+		ticker, _ := row["SECID"].(string)
+		if ticker == "" {
+			continue
+		}
+		names[ticker] = nil
+
+		// Привилегированные акции упоминаются в новостях под тем же названием, что и обыкновенные,
+		// поэтому названия берутся только у обыкновенных
+		shortName, _ := row["SHORTNAME"].(string)
+		secName, _ := row["SECNAME"].(string)
+		if isPreferredShare(shortName, secName) {
+			continue
+		}
+		for _, name := range []string{shortName, secName} {
+			if alias := companyName(name); alias != "" {
+				names[ticker] = append(names[ticker], alias)
+			}
+		}
+	}
+
+	// Список бумаг меняется редко, поэтому кэшируется на сутки
+	if m.useCache && len(names) > 0 {
+		m.cache.Set(ctx, cacheKey, names, 24*time.Hour)
+	}
+
+	return names, nil
+}
+
+// legalFormWords организационно-правовые формы в названиях бумаг
+var legalFormWords = map[string]bool{
+	"пао": true, "оао": true, "зао": true, "мкпао": true, "plc": true, "ltd": true,
+}
+
+// shareKindWord обозначение вида акций в названии бумаги: «ао», «ап», «3ао», «ао2»
+var shareKindWord = regexp.MustCompile(`^\d?а[оп]\d?$`)
+
+// preferredShareName признак привилегированной акции в конце названия: «Сбербанк-п», «Татнефть ПАО 3ап»
+var preferredShareName = regexp.MustCompile(`(^|[\s-])\d?ап\d?$|-п$`)
+
+// companyName выделяет из названия бумаги название компании: без организационно-правовой формы и вида акций.
+// Названия короче minInflectedAlias символов не используются, чтобы не сопоставлять случайные слова
+func companyName(name string) string {
+	name = strings.NewReplacer("\"", " ", "«", " ", "»", " ", "(", " ", ")", " ", "-", " ").Replace(name)
+
+	var kept []string
+	for _, word := range strings.Fields(name) {
+		lower := strings.ToLower(word)
+		if legalFormWords[lower] || shareKindWord.MatchString(lower) {
+			continue
+		}
+		kept = append(kept, word)
+	}
+
+	alias := strings.Join(kept, " ")
+	if len([]rune(alias)) < minInflectedAlias {
+		return ""
+	}
+	return alias
+}
+
+// isPreferredShare проверяет по названиям, что бумага — привилегированная акция
+func isPreferredShare(shortName, secName string) bool {
+	return preferredShareName.MatchString(strings.ToLower(strings.TrimSpace(shortName))) ||
+		preferredShareName.MatchString(strings.ToLower(strings.TrimSpace(secName)))
+}
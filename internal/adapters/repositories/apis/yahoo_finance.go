@@ -0,0 +1,347 @@
+package apis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/config"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/repositories"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/cache"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/timing"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/workpool"
+)
+
+// yahooExchanges биржи, котировки которых запрашиваются у Yahoo Finance, и суффиксы тикеров этих бирж в Yahoo
+var yahooExchanges = []struct {
+	code   string
+	suffix string
+}{
+	{code: models.ExchangeNASDAQ},
+	{code: models.ExchangeNYSE},
+	{code: models.ExchangeXETRA, suffix: ".DE"},
+	{code: models.ExchangeEuronext, suffix: ".PA"},
+}
+
+// yahooIntervals интервалы свечей Yahoo Finance; десятиминутных свечей Yahoo не отдает
+var yahooIntervals = map[string]string{
+	models.IntervalMinute: "1m",
+	models.IntervalHour:   "60m",
+	models.IntervalDay:    "1d",
+}
+
+// YahooFinanceClient клиент неофициального API графиков Yahoo Finance для акций бирж США и Европы.
+// Запросы выполняются не чаще заданной частоты, ответы кэшируются с отдельными сроками для котировок и свечей
+type YahooFinanceClient struct {
+	baseURL    string
+	httpClient *http.Client
+	cache      cache.Cache
+	quoteTTL   time.Duration
+	candlesTTL time.Duration
+	pool       *workpool.Pool // Ограничивает число параллельных запросов GetStocks
+
+	mu          sync.Mutex
+	minInterval time.Duration // Минимальный промежуток между запросами
+	nextRequest time.Time     // Время, раньше которого нельзя отправить следующий запрос
+}
+
+// NewYahooFinanceClient создает новый клиент Yahoo Finance; pool ограничивает параллельные запросы,
+// nil — запросы выполняются последовательно
+func NewYahooFinanceClient(cfg *config.Config, cache cache.Cache, pool *workpool.Pool) *YahooFinanceClient {
+	return &YahooFinanceClient{
+		baseURL: cfg.Yahoo.BaseURL,
+		httpClient: &http.Client{
+			Timeout:   cfg.Yahoo.Timeout,
+			Transport: &timing.Transport{},
+		},
+		cache:       cache,
+		quoteTTL:    cfg.Yahoo.QuoteTTL,
+		candlesTTL:  cfg.Yahoo.CandlesTTL,
+		pool:        pool,
+		minInterval: time.Minute / time.Duration(cfg.Yahoo.RequestsPerMinute),
+	}
+}
+
+// ExchangeClients возвращает клиенты бирж, котировки которых поставляет Yahoo Finance
+func (y *YahooFinanceClient) ExchangeClients() []repositories.ExchangeClient {
+	clients := make([]repositories.ExchangeClient, 0, len(yahooExchanges))
+	for _, exchange := range yahooExchanges {
+		clients = append(clients, &yahooExchangeClient{client: y, exchange: exchange.code, suffix: exchange.suffix})
+	}
+	return clients
+}
+
+// yahooChartResponse ответ /v8/finance/chart; отсутствующие значения свечей приходят как null
+type yahooChartResponse struct {
+	Chart struct {
+		Result []struct {
+			Meta struct {
+				Symbol             string  `json:"symbol"`
+				ShortName          string  `json:"shortName"`
+				LongName           string  `json:"longName"`
+				RegularMarketPrice float64 `json:"regularMarketPrice"`
+				ChartPreviousClose float64 `json:"chartPreviousClose"`
+				RegularMarketVol   int64   `json:"regularMarketVolume"`
+				RegularMarketTime  int64   `json:"regularMarketTime"`
+			} `json:"meta"`
+			Timestamp  []int64 `json:"timestamp"`
+			Indicators struct {
+				Quote []struct {
+					Open   []*float64 `json:"open"`
+					High   []*float64 `json:"high"`
+					Low    []*float64 `json:"low"`
+					Close  []*float64 `json:"close"`
+					Volume []*int64   `json:"volume"`
+				} `json:"quote"`
+			} `json:"indicators"`
+		} `json:"result"`
+		Error *struct {
+			Code        string `json:"code"`
+			Description string `json:"description"`
+		} `json:"error"`
+	} `json:"chart"`
+}
+
+// getStock получает текущую котировку бумаги по тикеру Yahoo (с суффиксом биржи)
+func (y *YahooFinanceClient) getStock(ctx context.Context, symbol string) (*models.Stock, error) {
+	cacheKey := "yahoo:" + models.StockCacheKey(symbol)
+
+	var cachedStock models.Stock
+	switch err := y.cache.Get(ctx, cacheKey, &cachedStock); {
+	case err == nil:
+		return &cachedStock, nil
+	case !errors.Is(err, cache.ErrNotFound):
+		log.Printf("Ошибка чтения кэша %s: %v", cacheKey, err)
+	}
+
+	params := url.Values{}
+	params.Add("range", "1d")
+	params.Add("interval", "1d")
+
+	chart, err := y.getChart(ctx, symbol, params)
+	if err != nil {
+		return nil, err
+	}
+
+	meta := chart.Chart.Result[0].Meta
+	stock := &models.Stock{
+		Ticker:    symbol,
+		Name:      meta.LongName,
+		Price:     meta.RegularMarketPrice,
+		Volume:    meta.RegularMarketVol,
+		UpdatedAt: time.Unix(meta.RegularMarketTime, 0),
+	}
+	if stock.Name == "" {
+		stock.Name = meta.ShortName
+	}
+	if meta.ChartPreviousClose > 0 {
+		stock.Change = meta.RegularMarketPrice - meta.ChartPreviousClose
+		stock.ChangePerc = stock.Change / meta.ChartPreviousClose * 100
+	}
+
+	y.cache.Set(ctx, cacheKey, stock, y.quoteTTL)
+
+	return stock, nil
+}
+
+// getCandles получает свечи бумаги по тикеру Yahoo за период [from, till]
+func (y *YahooFinanceClient) getCandles(ctx context.Context, symbol, interval string, from, till time.Time) ([]models.StockQuote, error) {
+	spec, ok := models.FindQuoteInterval(interval)
+	if !ok {
+		return nil, fmt.Errorf("неподдерживаемый интервал свечей %s", interval)
+	}
+	yahooInterval, ok := yahooIntervals[spec.Name]
+	if !ok {
+		return nil, fmt.Errorf("Yahoo Finance не поддерживает свечи с интервалом %s", spec.Name)
+	}
+
+	cacheKey := fmt.Sprintf("yahoo:candles:%s:%s:%d:%d", symbol, spec.Name, from.Unix(), till.Unix())
+
+	var quotes []models.StockQuote
+	switch err := y.cache.Get(ctx, cacheKey, &quotes); {
+	case err == nil:
+		return quotes, nil
+	case !errors.Is(err, cache.ErrNotFound):
+		log.Printf("Ошибка чтения кэша %s: %v", cacheKey, err)
+	}
+
+	params := url.Values{}
+	params.Add("period1", strconv.FormatInt(from.Unix(), 10))
+	params.Add("period2", strconv.FormatInt(till.Unix(), 10))
+	params.Add("interval", yahooInterval)
+
+	chart, err := y.getChart(ctx, symbol, params)
+	if err != nil {
+		return nil, err
+	}
+
+	result := chart.Chart.Result[0]
+	if len(result.Indicators.Quote) == 0 {
+		return nil, nil
+	}
+	values := result.Indicators.Quote[0]
+
+	for i, timestamp := range result.Timestamp {
+		// Свечи без сделок Yahoo возвращает с пустыми значениями
+		if i >= len(values.Close) || values.Close[i] == nil {
+			continue
+		}
+		quote := models.StockQuote{
+			Ticker:   symbol,
+			Interval: spec.Name,
+			Date:     time.Unix(timestamp, 0),
+			Close:    *values.Close[i],
+		}
+		if i < len(values.Open) && values.Open[i] != nil {
+			quote.Open = *values.Open[i]
+		}
+		if i < len(values.High) && values.High[i] != nil {
+			quote.High = *values.High[i]
+		}
+		if i < len(values.Low) && values.Low[i] != nil {
+			quote.Low = *values.Low[i]
+		}
+		if i < len(values.Volume) && values.Volume[i] != nil {
+			quote.Volume = *values.Volume[i]
+		}
+		quotes = append(quotes, quote)
+	}
+
+	if len(quotes) > 0 {
+		y.cache.Set(ctx, cacheKey, quotes, y.candlesTTL)
+	}
+
+	return quotes, nil
+}
+
+// getChart выполняет запрос /v8/finance/chart с учетом ограничения частоты запросов
+func (y *YahooFinanceClient) getChart(ctx context.Context, symbol string, params url.Values) (*yahooChartResponse, error) {
+	if err := y.wait(ctx); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, y.baseURL+"/v8/finance/chart/"+url.PathEscape(symbol)+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось создать запрос: %w", err)
+	}
+	// Без User-Agent браузера Yahoo отвечает 429
+	req.Header.Set("User-Agent", "Mozilla/5.0")
+
+	resp, err := y.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка выполнения запроса: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка чтения ответа: %w", err)
+	}
+
+	var chart yahooChartResponse
+	if err := json.Unmarshal(body, &chart); err != nil {
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("ошибка API Yahoo Finance: %s", resp.Status)
+		}
+		return nil, fmt.Errorf("ошибка при разборе JSON: %w", err)
+	}
+
+	// Для неизвестного тикера Yahoo возвращает 404 с описанием ошибки в теле ответа
+	if chart.Chart.Error != nil {
+		return nil, fmt.Errorf("ошибка API Yahoo Finance: %s", chart.Chart.Error.Description)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("ошибка API Yahoo Finance: %s", resp.Status)
+	}
+	if len(chart.Chart.Result) == 0 {
+		return nil, fmt.Errorf("бумага %s не найдена в Yahoo Finance", symbol)
+	}
+
+	return &chart, nil
+}
+
+// wait дожидается момента, когда очередной запрос не превысит ограничение частоты
+func (y *YahooFinanceClient) wait(ctx context.Context) error {
+	y.mu.Lock()
+	now := time.Now()
+	at := y.nextRequest
+	if at.Before(now) {
+		at = now
+	}
+	y.nextRequest = at.Add(y.minInterval)
+	y.mu.Unlock()
+
+	delay := time.Until(at)
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// yahooExchangeClient клиент одной биржи поверх Yahoo Finance: добавляет к тикерам суффикс биржи
+type yahooExchangeClient struct {
+	client   *YahooFinanceClient
+	exchange string
+	suffix   string
+}
+
+// Exchange возвращает код биржи
+func (e *yahooExchangeClient) Exchange() string {
+	return e.exchange
+}
+
+// GetStock возвращает текущую котировку бумаги
+func (e *yahooExchangeClient) GetStock(ctx context.Context, ticker string) (*models.Stock, error) {
+	stock, err := e.client.getStock(ctx, ticker+e.suffix)
+	if err != nil {
+		return nil, err
+	}
+
+	result := *stock
+	result.Ticker = ticker
+	return &result, nil
+}
+
+// GetStocks возвращает текущие котировки нескольких бумаг
+func (e *yahooExchangeClient) GetStocks(ctx context.Context, tickers []string) ([]models.Stock, error) {
+	return workpool.Map(ctx, e.client.pool, tickers, func(ctx context.Context, ticker string) (models.Stock, error) {
+		stock, err := e.GetStock(ctx, ticker)
+		if err != nil {
+			return models.Stock{}, fmt.Errorf("ошибка получения котировки %s: %w", ticker, err)
+		}
+		return *stock, nil
+	})
+}
+
+// GetCandles возвращает свечи бумаги с указанным интервалом за период
+func (e *yahooExchangeClient) GetCandles(ctx context.Context, ticker, interval string, from, till time.Time) ([]models.StockQuote, error) {
+	candles, err := e.client.getCandles(ctx, ticker+e.suffix, interval, from, till)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]models.StockQuote, len(candles))
+	for i, candle := range candles {
+		candle.Ticker = ticker
+		result[i] = candle
+	}
+	return result, nil
+}
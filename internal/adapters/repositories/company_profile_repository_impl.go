@@ -0,0 +1,125 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/adapters/repositories/apis"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/repositories"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/workpool"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// CompanyProfileRepositoryImpl реализация интерфейса CompanyProfileRepository на MongoDB.
+// Профили меняются редко, поэтому хранятся в базе долго и запрашиваются у MOEX только по истечении срока
+type CompanyProfileRepositoryImpl struct {
+	db      *mongo.Collection
+	moexAPI *apis.MOEXAPIClient
+	pool    *workpool.Pool // Ограничивает параллельные запросы профилей к MOEX
+	ttl     time.Duration
+}
+
+// NewCompanyProfileRepository создает новый экземпляр репозитория профилей компаний
+func NewCompanyProfileRepository(db *mongo.Database, moexAPI *apis.MOEXAPIClient, pool *workpool.Pool, ttl time.Duration) repositories.CompanyProfileRepository {
+	return &CompanyProfileRepositoryImpl{
+		db:      db.Collection("company_profiles"),
+		moexAPI: moexAPI,
+		pool:    pool,
+		ttl:     ttl,
+	}
+}
+
+// GetCompanyProfile возвращает профиль компании по тикеру; устаревший профиль обновляется из MOEX.
+// Если MOEX недоступна, возвращается сохраненный профиль, даже устаревший
+func (r *CompanyProfileRepositoryImpl) GetCompanyProfile(ctx context.Context, ticker string) (*models.CompanyProfile, error) {
+	var stored models.CompanyProfile
+	err := r.db.FindOne(ctx, bson.M{"ticker": ticker}).Decode(&stored)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, fmt.Errorf("ошибка поиска в базе данных: %w", err)
+	}
+	found := err == nil
+	if found && time.Since(stored.UpdatedAt) < r.ttl {
+		return &stored, nil
+	}
+
+	profile, err := r.moexAPI.GetCompanyProfile(ctx, ticker)
+	if err != nil {
+		if found {
+			log.Printf("Не удалось обновить профиль %s, используем сохраненный от %s: %v", ticker, stored.UpdatedAt.Format("02.01.2006"), err)
+			return &stored, nil
+		}
+		return nil, err
+	}
+
+	_, err = r.db.ReplaceOne(ctx, bson.M{"ticker": profile.Ticker}, profile, options.Replace().SetUpsert(true))
+	if err != nil {
+		log.Printf("Ошибка сохранения профиля %s: %v", ticker, err)
+	}
+
+	return profile, nil
+}
+
+// GetCompanyProfiles возвращает профили нескольких компаний. Сохраненные профили читаются одним запросом,
+// у MOEX запрашиваются только отсутствующие и устаревшие; недоступные профили пропускаются.
+// Профили возвращаются в порядке tickers
+func (r *CompanyProfileRepositoryImpl) GetCompanyProfiles(ctx context.Context, tickers []string) ([]models.CompanyProfile, error) {
+	cursor, err := r.db.Find(ctx, bson.M{"ticker": bson.M{"$in": tickers}})
+	if err != nil {
+		return nil, fmt.Errorf("ошибка поиска в базе данных: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var stored []models.CompanyProfile
+	if err = cursor.All(ctx, &stored); err != nil {
+		return nil, fmt.Errorf("ошибка декодирования результатов: %w", err)
+	}
+
+	fresh := make(map[string]models.CompanyProfile, len(stored))
+	for _, profile := range stored {
+		if time.Since(profile.UpdatedAt) < r.ttl {
+			fresh[profile.Ticker] = profile
+		}
+	}
+
+	// Отсутствующие и устаревшие профили запрашиваются у MOEX параллельно в пределах общего лимита пула
+	var missing []string
+	for _, ticker := range tickers {
+		if _, ok := fresh[ticker]; !ok {
+			missing = append(missing, ticker)
+		}
+	}
+	loaded := make([]*models.CompanyProfile, len(missing))
+	err = r.pool.Run(ctx, len(missing), func(ctx context.Context, i int) error {
+		profile, err := r.GetCompanyProfile(ctx, missing[i])
+		if err != nil {
+			log.Printf("Не удалось получить профиль %s: %v", missing[i], err)
+			return nil
+		}
+		loaded[i] = profile
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i, profile := range loaded {
+		if profile != nil {
+			fresh[missing[i]] = *profile
+		}
+	}
+
+	profiles := make([]models.CompanyProfile, 0, len(tickers))
+	for _, ticker := range tickers {
+		if profile, ok := fresh[ticker]; ok {
+			profiles = append(profiles, profile)
+		}
+	}
+
+	return profiles, nil
+}
@@ -0,0 +1,102 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/repositories"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// CorporateEventRepositoryImpl реализация интерфейса CorporateEventRepository на MongoDB
+type CorporateEventRepositoryImpl struct {
+	db *mongo.Collection
+}
+
+// NewCorporateEventRepository создает новый экземпляр репозитория корпоративных событий
+func NewCorporateEventRepository(db *mongo.Database) repositories.CorporateEventRepository {
+	return &CorporateEventRepositoryImpl{
+		db: db.Collection("corporate_events"),
+	}
+}
+
+// SaveEvents сохраняет события одним запросом, заменяя ранее сохраненные с теми же тикером, типом, источником
+// и идентификатором в источнике: событие, перенесенное на другую дату, обновляется, а не дублируется
+func (r *CorporateEventRepositoryImpl) SaveEvents(ctx context.Context, events []models.CorporateEvent) (int, error) {
+	if len(events) == 0 {
+		return 0, nil
+	}
+
+	writes := make([]mongo.WriteModel, 0, len(events))
+	for _, event := range events {
+		filter := eventKey(event)
+		writes = append(writes, mongo.NewReplaceOneModel().SetFilter(filter).SetReplacement(event).SetUpsert(true))
+	}
+
+	result, err := r.db.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		return 0, fmt.Errorf("ошибка сохранения корпоративных событий: %w", err)
+	}
+
+	return int(result.UpsertedCount + result.ModifiedCount), nil
+}
+
+// DeleteMissingEvents удаляет события источника source, которых нет среди events: так из календаря уходят
+// события, удаленные из источника
+func (r *CorporateEventRepositoryImpl) DeleteMissingEvents(ctx context.Context, source string, events []models.CorporateEvent) (int, error) {
+	query := bson.M{"source": source}
+	if len(events) > 0 {
+		keep := make(bson.A, 0, len(events))
+		for _, event := range events {
+			keep = append(keep, eventKey(event))
+		}
+		query["$nor"] = keep
+	}
+
+	result, err := r.db.DeleteMany(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка удаления корпоративных событий: %w", err)
+	}
+
+	return int(result.DeletedCount), nil
+}
+
+// eventKey условие поиска сохраненной записи события
+func eventKey(event models.CorporateEvent) bson.M {
+	return bson.M{"ticker": event.Ticker, "type": event.Type, "source": event.Source, "source_id": event.SourceID}
+}
+
+// GetEvents возвращает события, подходящие под фильтр, по возрастанию даты.
+// Программа выкупа попадает в период, если пересекается с ним
+func (r *CorporateEventRepositoryImpl) GetEvents(ctx context.Context, filter models.CorporateEventsFilter) ([]models.CorporateEvent, error) {
+	query := bson.M{
+		"$or": bson.A{
+			bson.M{"date": bson.M{"$gte": filter.From, "$lte": filter.To}},
+			bson.M{"date": bson.M{"$lt": filter.From}, "end_date": bson.M{"$gte": filter.From}},
+		},
+	}
+	if filter.Ticker != "" {
+		query["ticker"] = filter.Ticker
+	}
+	if len(filter.Types) > 0 {
+		query["type"] = bson.M{"$in": filter.Types}
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "date", Value: 1}, {Key: "ticker", Value: 1}})
+	cursor, err := r.db.Find(ctx, query, opts)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка поиска в базе данных: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var events []models.CorporateEvent
+	if err = cursor.All(ctx, &events); err != nil {
+		return nil, fmt.Errorf("ошибка декодирования результатов: %w", err)
+	}
+
+	return events, nil
+}
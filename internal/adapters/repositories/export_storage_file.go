@@ -0,0 +1,50 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/repositories"
+)
+
+// ExportStorageFile хранилище выгрузок в локальном каталоге. Если каталог раздается по HTTP (baseURL),
+// клиент получает ссылку на файл, иначе — абсолютный путь к нему
+type ExportStorageFile struct {
+	dir     string
+	baseURL string
+}
+
+// NewExportStorageFile создает хранилище выгрузок в каталоге dir
+func NewExportStorageFile(dir, baseURL string) repositories.ExportStorage {
+	return &ExportStorageFile{dir: dir, baseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+// Store записывает файл выгрузки в каталог
+func (s *ExportStorageFile) Store(ctx context.Context, name, contentType string, data []byte) (string, error) {
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return "", fmt.Errorf("не удалось создать каталог выгрузок: %w", err)
+	}
+
+	path := filepath.Join(s.dir, filepath.Base(name))
+	// Запись через временный файл: клиент не увидит недописанную выгрузку
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return "", fmt.Errorf("не удалось записать выгрузку: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return "", fmt.Errorf("не удалось записать выгрузку: %w", err)
+	}
+
+	if s.baseURL != "" {
+		return s.baseURL + "/" + url.PathEscape(filepath.Base(name)), nil
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	return path, nil
+}
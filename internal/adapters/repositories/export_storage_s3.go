@@ -0,0 +1,47 @@
+package repositories
+
+import (
+	"context"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/config"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/repositories"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/s3"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/timing"
+)
+
+// ExportStorageS3 хранилище выгрузок в бакете S3-совместимого хранилища. Клиент получает подписанную ссылку
+// на скачивание, поэтому бакет может оставаться закрытым
+type ExportStorageS3 struct {
+	client    *s3.Client
+	prefix    string
+	urlExpiry time.Duration
+}
+
+// NewExportStorageS3 создает хранилище выгрузок в бакете из настроек export.s3
+func NewExportStorageS3(cfg config.ExportS3Config) repositories.ExportStorage {
+	return &ExportStorageS3{
+		client: &s3.Client{
+			Endpoint:   cfg.Endpoint,
+			Region:     cfg.Region,
+			Bucket:     cfg.Bucket,
+			AccessKey:  cfg.AccessKeyID,
+			SecretKey:  cfg.SecretAccessKey,
+			PathStyle:  cfg.PathStyle,
+			HTTPClient: &http.Client{Timeout: time.Minute, Transport: &timing.Transport{}},
+		},
+		prefix:    cfg.Prefix,
+		urlExpiry: cfg.URLExpiry,
+	}
+}
+
+// Store загружает файл выгрузки в бакет и возвращает ссылку на скачивание
+func (s *ExportStorageS3) Store(ctx context.Context, name, contentType string, data []byte) (string, error) {
+	key := path.Join(s.prefix, path.Base(name))
+	if err := s.client.PutObject(ctx, key, contentType, data); err != nil {
+		return "", err
+	}
+	return s.client.PresignGet(key, s.urlExpiry)
+}
@@ -0,0 +1,68 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/repositories"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// IndexRepositoryImpl реализация интерфейса IndexRepository на MongoDB
+type IndexRepositoryImpl struct {
+	db *mongo.Collection
+}
+
+// NewIndexRepository создает новый экземпляр репозитория состава индексов
+func NewIndexRepository(db *mongo.Database) repositories.IndexRepository {
+	return &IndexRepositoryImpl{
+		db: db.Collection("index_constituents"),
+	}
+}
+
+// SaveConstituents заменяет состав индекса: сохраняет бумаги одним запросом и удаляет выбывшие
+func (r *IndexRepositoryImpl) SaveConstituents(ctx context.Context, index string, constituents []models.IndexConstituent) (int, error) {
+	index = strings.ToUpper(index)
+	if len(constituents) == 0 {
+		return 0, nil
+	}
+
+	tickers := make([]string, 0, len(constituents))
+	writes := make([]mongo.WriteModel, 0, len(constituents)+1)
+	for _, constituent := range constituents {
+		constituent.Index = index
+		tickers = append(tickers, constituent.Ticker)
+		filter := bson.M{"index": index, "ticker": constituent.Ticker}
+		writes = append(writes, mongo.NewReplaceOneModel().SetFilter(filter).SetReplacement(constituent).SetUpsert(true))
+	}
+	writes = append(writes, mongo.NewDeleteManyModel().SetFilter(bson.M{"index": index, "ticker": bson.M{"$nin": tickers}}))
+
+	result, err := r.db.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		return 0, fmt.Errorf("ошибка сохранения состава индекса %s: %w", index, err)
+	}
+
+	return int(result.UpsertedCount + result.ModifiedCount), nil
+}
+
+// GetConstituents возвращает состав индекса по убыванию веса
+func (r *IndexRepositoryImpl) GetConstituents(ctx context.Context, index string) ([]models.IndexConstituent, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "weight", Value: -1}, {Key: "ticker", Value: 1}})
+	cursor, err := r.db.Find(ctx, bson.M{"index": strings.ToUpper(index)}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка поиска в базе данных: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var constituents []models.IndexConstituent
+	if err = cursor.All(ctx, &constituents); err != nil {
+		return nil, fmt.Errorf("ошибка декодирования результатов: %w", err)
+	}
+
+	return constituents, nil
+}
@@ -0,0 +1,142 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/repositories"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// knownSecuritiesID идентификатор документа со списком бумаг на момент прошлой сверки
+const knownSecuritiesID = "moex_tqbr"
+
+// knownSecurities список тикеров основного режима торгов на момент сверки
+type knownSecurities struct {
+	ID        string    `bson:"_id"`
+	Tickers   []string  `bson:"tickers"`
+	UpdatedAt time.Time `bson:"updated_at"`
+}
+
+// ListingRepositoryImpl реализация интерфейса ListingRepository на MongoDB
+type ListingRepositoryImpl struct {
+	db         *mongo.Collection
+	securities *mongo.Collection
+}
+
+// NewListingRepository создает новый экземпляр репозитория календаря размещений
+func NewListingRepository(db *mongo.Database) repositories.ListingRepository {
+	return &ListingRepositoryImpl{
+		db:         db.Collection("listings"),
+		securities: db.Collection("listing_securities"),
+	}
+}
+
+// GetListing возвращает размещение по тикеру; nil, если его нет
+func (r *ListingRepositoryImpl) GetListing(ctx context.Context, ticker string) (*models.Listing, error) {
+	var listing models.Listing
+	err := r.db.FindOne(ctx, bson.M{"ticker": ticker}).Decode(&listing)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ошибка поиска в базе данных: %w", err)
+	}
+
+	return &listing, nil
+}
+
+// SaveListing сохраняет размещение, заменяя ранее сохраненное с тем же тикером
+func (r *ListingRepositoryImpl) SaveListing(ctx context.Context, listing *models.Listing) error {
+	_, err := r.db.ReplaceOne(ctx, bson.M{"ticker": listing.Ticker}, listing, options.Replace().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("ошибка сохранения размещения %s: %w", listing.Ticker, err)
+	}
+
+	return nil
+}
+
+// GetUpcomingListings возвращает объявленные размещения по ожидаемой дате начала торгов;
+// размещения без объявленной даты — в конце
+func (r *ListingRepositoryImpl) GetUpcomingListings(ctx context.Context) ([]models.Listing, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "expected_date", Value: 1}, {Key: "ticker", Value: 1}})
+	listings, err := r.find(ctx, bson.M{"status": models.ListingStatusUpcoming}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Документы без даты MongoDB сортирует первыми, переносим их в конец
+	dated := make([]models.Listing, 0, len(listings))
+	var undated []models.Listing
+	for _, listing := range listings {
+		if listing.ExpectedDate.IsZero() {
+			undated = append(undated, listing)
+			continue
+		}
+		dated = append(dated, listing)
+	}
+
+	return append(dated, undated...), nil
+}
+
+// GetRecentListings возвращает бумаги, начавшие торговаться не раньше since, от новых к старым
+func (r *ListingRepositoryImpl) GetRecentListings(ctx context.Context, since time.Time) ([]models.Listing, error) {
+	filter := bson.M{
+		"status":    models.ListingStatusListed,
+		"listed_at": bson.M{"$gte": since},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "listed_at", Value: -1}, {Key: "ticker", Value: 1}})
+
+	return r.find(ctx, filter, opts)
+}
+
+// GetKnownSecurities возвращает тикеры бумаг, торговавшихся на момент прошлой сверки
+func (r *ListingRepositoryImpl) GetKnownSecurities(ctx context.Context) ([]string, error) {
+	var known knownSecurities
+	err := r.securities.FindOne(ctx, bson.M{"_id": knownSecuritiesID}).Decode(&known)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ошибка поиска в базе данных: %w", err)
+	}
+
+	return known.Tickers, nil
+}
+
+// SaveKnownSecurities сохраняет тикеры бумаг, торгующихся на момент сверки
+func (r *ListingRepositoryImpl) SaveKnownSecurities(ctx context.Context, tickers []string) error {
+	known := knownSecurities{
+		ID:        knownSecuritiesID,
+		Tickers:   tickers,
+		UpdatedAt: time.Now(),
+	}
+	_, err := r.securities.ReplaceOne(ctx, bson.M{"_id": knownSecuritiesID}, known, options.Replace().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("ошибка сохранения списка бумаг: %w", err)
+	}
+
+	return nil
+}
+
+// find выполняет запрос к коллекции размещений
+func (r *ListingRepositoryImpl) find(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]models.Listing, error) {
+	cursor, err := r.db.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка поиска в базе данных: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var listings []models.Listing
+	if err = cursor.All(ctx, &listings); err != nil {
+		return nil, fmt.Errorf("ошибка декодирования результатов: %w", err)
+	}
+
+	return listings, nil
+}
@@ -0,0 +1,84 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/repositories"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MacroRepositoryImpl реализация интерфейса MacroRepository на MongoDB
+type MacroRepositoryImpl struct {
+	db *mongo.Collection
+}
+
+// NewMacroRepository создает новый экземпляр репозитория макроэкономических показателей
+func NewMacroRepository(db *mongo.Database) repositories.MacroRepository {
+	return &MacroRepositoryImpl{
+		db: db.Collection("macro_indicators"),
+	}
+}
+
+// SaveIndicators сохраняет значения одним запросом, заменяя ранее сохраненные с тем же кодом и периодом
+func (r *MacroRepositoryImpl) SaveIndicators(ctx context.Context, indicators []models.MacroIndicator) (int, error) {
+	if len(indicators) == 0 {
+		return 0, nil
+	}
+
+	writes := make([]mongo.WriteModel, 0, len(indicators))
+	for _, indicator := range indicators {
+		filter := bson.M{"code": indicator.Code, "period": indicator.Period}
+		writes = append(writes, mongo.NewReplaceOneModel().SetFilter(filter).SetReplacement(indicator).SetUpsert(true))
+	}
+
+	result, err := r.db.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		return 0, fmt.Errorf("ошибка сохранения макропоказателей: %w", err)
+	}
+
+	return int(result.UpsertedCount + result.ModifiedCount), nil
+}
+
+// GetIndicators возвращает значения показателя за период [from, to] по возрастанию периода
+func (r *MacroRepositoryImpl) GetIndicators(ctx context.Context, code string, from, to time.Time) ([]models.MacroIndicator, error) {
+	query := bson.M{
+		"code":   code,
+		"period": bson.M{"$gte": from, "$lte": to},
+	}
+
+	cursor, err := r.db.Find(ctx, query, options.Find().SetSort(bson.D{{Key: "period", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("ошибка поиска в базе данных: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var indicators []models.MacroIndicator
+	if err = cursor.All(ctx, &indicators); err != nil {
+		return nil, fmt.Errorf("ошибка декодирования результатов: %w", err)
+	}
+
+	return indicators, nil
+}
+
+// GetLatestIndicators возвращает limit последних значений показателя, начиная с самого свежего
+func (r *MacroRepositoryImpl) GetLatestIndicators(ctx context.Context, code string, limit int) ([]models.MacroIndicator, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "period", Value: -1}}).SetLimit(int64(limit))
+	cursor, err := r.db.Find(ctx, bson.M{"code": code}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка поиска в базе данных: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var indicators []models.MacroIndicator
+	if err = cursor.All(ctx, &indicators); err != nil {
+		return nil, fmt.Errorf("ошибка декодирования результатов: %w", err)
+	}
+
+	return indicators, nil
+}
@@ -0,0 +1,350 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoIndexOptions параметры создания индексов MongoDB
+type MongoIndexOptions struct {
+	// QuotesRetention срок хранения котировок; 0 — хранить бессрочно
+	QuotesRetention time.Duration
+	// NewsRetention срок хранения новостей; 0 — хранить бессрочно
+	NewsRetention time.Duration
+}
+
+// Коды ошибок MongoDB, возникающие при создании индекса с другими параметрами
+const (
+	mongoIndexOptionsConflict  = 85
+	mongoIndexKeySpecsConflict = 86
+)
+
+// mongoIndexNotFound код ошибки MongoDB при запросе $text без текстового индекса или удалении несуществующего индекса
+const mongoIndexNotFound = 27
+
+// mongoNamespaceNotFound код ошибки MongoDB при удалении индекса еще не созданной коллекции
+const mongoNamespaceNotFound = 26
+
+// newsTextIndex имя текстового индекса коллекции новостей
+const newsTextIndex = "news_text"
+
+// EnsureMongoIndexes создает индексы, которые используют запросы репозиториев.
+// Операция идемпотентна и выполняется при каждом запуске сервера.
+func EnsureMongoIndexes(ctx context.Context, db *mongo.Database, opts MongoIndexOptions) error {
+	stocksIndexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "ticker", Value: 1}},
+			Options: options.Index().SetName("ticker"),
+		},
+		{
+			// Котировки хранятся в той же коллекции и ищутся по тикеру, интервалу свечей и диапазону дат
+			Keys:    bson.D{{Key: "ticker", Value: 1}, {Key: "interval", Value: 1}, {Key: "date", Value: 1}},
+			Options: options.Index().SetName("ticker_date"),
+		},
+	}
+	if opts.QuotesRetention > 0 {
+		// TTL-индекс затрагивает только документы с полем date, то есть котировки
+		stocksIndexes = append(stocksIndexes, mongo.IndexModel{
+			Keys: bson.D{{Key: "date", Value: 1}},
+			Options: options.Index().
+				SetName("date_ttl").
+				SetExpireAfterSeconds(int32(opts.QuotesRetention.Seconds())),
+		})
+	} else {
+		stocksIndexes = append(stocksIndexes, mongo.IndexModel{
+			Keys:    bson.D{{Key: "date", Value: 1}},
+			Options: options.Index().SetName("date"),
+		})
+	}
+
+	newsIndexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "related_to", Value: 1}},
+			Options: options.Index().SetName("related_to"),
+		},
+		{
+			// Полнотекстовый поиск по новостям; совпадения в заголовке и тегах важнее, чем в тексте
+			Keys: bson.D{
+				{Key: "title", Value: "text"},
+				{Key: "description", Value: "text"},
+				{Key: "content", Value: "text"},
+				{Key: "tags", Value: "text"},
+			},
+			Options: options.Index().
+				SetName(newsTextIndex).
+				SetDefaultLanguage("russian").
+				SetWeights(bson.D{
+					{Key: "title", Value: 10},
+					{Key: "tags", Value: 5},
+					{Key: "description", Value: 3},
+					{Key: "content", Value: 1},
+				}),
+		},
+	}
+	if opts.NewsRetention > 0 {
+		newsIndexes = append(newsIndexes, mongo.IndexModel{
+			Keys: bson.D{{Key: "published_at", Value: -1}},
+			Options: options.Index().
+				SetName("published_at_ttl").
+				SetExpireAfterSeconds(int32(opts.NewsRetention.Seconds())),
+		})
+	} else {
+		newsIndexes = append(newsIndexes, mongo.IndexModel{
+			Keys:    bson.D{{Key: "published_at", Value: -1}},
+			Options: options.Index().SetName("published_at"),
+		})
+	}
+
+	portfolioIndexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "portfolio", Value: 1}, {Key: "ticker", Value: 1}},
+			Options: options.Index().SetName("portfolio_ticker").SetUnique(true),
+		},
+	}
+
+	tradeIndexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "portfolio", Value: 1}, {Key: "ticker", Value: 1}, {Key: "executed_at", Value: 1}},
+			Options: options.Index().SetName("portfolio_ticker_executed_at"),
+		},
+	}
+
+	watchlistIndexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "watchlist", Value: 1}, {Key: "ticker", Value: 1}},
+			Options: options.Index().SetName("watchlist_ticker").SetUnique(true),
+		},
+	}
+
+	alertsIndexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "watchlist", Value: 1}, {Key: "triggered_at", Value: -1}},
+			Options: options.Index().SetName("watchlist_triggered_at"),
+		},
+	}
+
+	moodIndexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "date", Value: 1}},
+			Options: options.Index().SetName("date").SetUnique(true),
+		},
+	}
+
+	profileIndexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "ticker", Value: 1}},
+			Options: options.Index().SetName("ticker").SetUnique(true),
+		},
+	}
+
+	listingIndexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "ticker", Value: 1}},
+			Options: options.Index().SetName("ticker").SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "status", Value: 1}, {Key: "listed_at", Value: -1}},
+			Options: options.Index().SetName("status_listed_at"),
+		},
+	}
+
+	eventIndexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "ticker", Value: 1}, {Key: "type", Value: 1},
+				{Key: "source", Value: 1}, {Key: "source_id", Value: 1},
+			},
+			Options: options.Index().SetName("ticker_type_source_id").SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "date", Value: 1}},
+			Options: options.Index().SetName("date"),
+		},
+	}
+
+	priceTargetIndexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "ticker", Value: 1}, {Key: "source", Value: 1}, {Key: "analyst", Value: 1}},
+			Options: options.Index().SetName("ticker_source_analyst").SetUnique(true),
+		},
+	}
+
+	macroIndexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "code", Value: 1}, {Key: "period", Value: 1}},
+			Options: options.Index().SetName("code_period").SetUnique(true),
+		},
+	}
+
+	indexConstituentIndexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "index", Value: 1}, {Key: "ticker", Value: 1}},
+			Options: options.Index().SetName("index_ticker").SetUnique(true),
+		},
+	}
+
+	securityIndexes := []mongo.IndexModel{
+		{
+			// Поиск бумаги по ISIN; тикер служит идентификатором документа
+			Keys:    bson.D{{Key: "isin", Value: 1}},
+			Options: options.Index().SetName("isin"),
+		},
+	}
+
+	if err := ensureIndexes(ctx, db.Collection("stocks"), stocksIndexes); err != nil {
+		return err
+	}
+	if err := ensureIndexes(ctx, db.Collection("news"), newsIndexes); err != nil {
+		return err
+	}
+	if err := ensureIndexes(ctx, db.Collection("portfolio"), portfolioIndexes); err != nil {
+		return err
+	}
+	if err := ensureIndexes(ctx, db.Collection("portfolio_trades"), tradeIndexes); err != nil {
+		return err
+	}
+	if err := ensureIndexes(ctx, db.Collection("watchlist"), watchlistIndexes); err != nil {
+		return err
+	}
+	if err := ensureIndexes(ctx, db.Collection("watchlist_alerts"), alertsIndexes); err != nil {
+		return err
+	}
+	if err := ensureIndexes(ctx, db.Collection("market_mood"), moodIndexes); err != nil {
+		return err
+	}
+	if err := ensureIndexes(ctx, db.Collection("company_profiles"), profileIndexes); err != nil {
+		return err
+	}
+	if err := ensureIndexes(ctx, db.Collection("securities"), securityIndexes); err != nil {
+		return err
+	}
+	if err := ensureIndexes(ctx, db.Collection("listings"), listingIndexes); err != nil {
+		return err
+	}
+	if err := migrateCorporateEvents(ctx, db.Collection("corporate_events")); err != nil {
+		return err
+	}
+	if err := ensureIndexes(ctx, db.Collection("corporate_events"), eventIndexes); err != nil {
+		return err
+	}
+	if err := ensureIndexes(ctx, db.Collection("price_targets"), priceTargetIndexes); err != nil {
+		return err
+	}
+	if err := ensureIndexes(ctx, db.Collection("macro_indicators"), macroIndexes); err != nil {
+		return err
+	}
+	return ensureIndexes(ctx, db.Collection("index_constituents"), indexConstituentIndexes)
+}
+
+// migrateCorporateEvents переводит корпоративные события на ключ (тикер, тип, источник, идентификатор):
+// записям без идентификатора в источнике проставляется их дата, а прежний уникальный индекс
+// (тикер, тип, дата) удаляется, иначе события MOEX и файла календаря на одну дату не уживутся
+func migrateCorporateEvents(ctx context.Context, coll *mongo.Collection) error {
+	setSourceID := mongo.Pipeline{{{Key: "$set", Value: bson.M{
+		"source_id": bson.M{"$dateToString": bson.M{"format": "%Y-%m-%d", "date": "$date", "timezone": "Europe/Moscow"}},
+	}}}}
+	if _, err := coll.UpdateMany(ctx, bson.M{"source_id": bson.M{"$exists": false}}, setSourceID); err != nil {
+		return fmt.Errorf("ошибка миграции корпоративных событий: %w", err)
+	}
+
+	_, err := coll.Indexes().DropOne(ctx, "ticker_type_date")
+	var cmdErr mongo.CommandError
+	if err != nil && !(errors.As(err, &cmdErr) && (cmdErr.Code == mongoIndexNotFound || cmdErr.Code == mongoNamespaceNotFound)) {
+		return fmt.Errorf("ошибка удаления индекса ticker_type_date: %w", err)
+	}
+	return nil
+}
+
+// ensureIndexes создает индексы коллекции. Если индекс с тем же назначением уже существует
+// с другими параметрами (например, изменился срок хранения), он пересоздается.
+func ensureIndexes(ctx context.Context, coll *mongo.Collection, indexes []mongo.IndexModel) error {
+	for _, index := range indexes {
+		_, err := coll.Indexes().CreateOne(ctx, index)
+		if err == nil {
+			continue
+		}
+
+		var cmdErr mongo.CommandError
+		if !errors.As(err, &cmdErr) ||
+			(cmdErr.Code != mongoIndexOptionsConflict && cmdErr.Code != mongoIndexKeySpecsConflict) {
+			return fmt.Errorf("ошибка создания индекса в коллекции %s: %w", coll.Name(), err)
+		}
+
+		name, err := conflictingIndexName(ctx, coll, index)
+		if err != nil {
+			return err
+		}
+		log.Printf("Пересоздаем индекс %s в коллекции %s", name, coll.Name())
+
+		if _, err := coll.Indexes().DropOne(ctx, name); err != nil {
+			return fmt.Errorf("ошибка удаления индекса %s: %w", name, err)
+		}
+		if _, err := coll.Indexes().CreateOne(ctx, index); err != nil {
+			return fmt.Errorf("ошибка создания индекса в коллекции %s: %w", coll.Name(), err)
+		}
+	}
+
+	return nil
+}
+
+// conflictingIndexName находит существующий индекс, мешающий созданию нового:
+// индекс с тем же именем, по тем же полям или другой текстовый индекс
+func conflictingIndexName(ctx context.Context, coll *mongo.Collection, index mongo.IndexModel) (string, error) {
+	cursor, err := coll.Indexes().List(ctx)
+	if err != nil {
+		return "", fmt.Errorf("ошибка чтения индексов коллекции %s: %w", coll.Name(), err)
+	}
+	defer cursor.Close(ctx)
+
+	var existing []bson.M
+	if err := cursor.All(ctx, &existing); err != nil {
+		return "", fmt.Errorf("ошибка чтения индексов коллекции %s: %w", coll.Name(), err)
+	}
+
+	wantName := ""
+	if index.Options != nil && index.Options.Name != nil {
+		wantName = *index.Options.Name
+	}
+	wantKeys, _ := bson.Marshal(index.Keys)
+	wantText := isTextIndex(index.Keys)
+
+	for _, idx := range existing {
+		name, _ := idx["name"].(string)
+		if name == wantName {
+			return name, nil
+		}
+
+		keys, _ := bson.Marshal(idx["key"])
+		if string(keys) == string(wantKeys) {
+			return name, nil
+		}
+
+		// В коллекции может быть только один текстовый индекс
+		if _, ok := idx["textIndexVersion"]; ok && wantText {
+			return name, nil
+		}
+	}
+
+	return "", fmt.Errorf("не удалось найти конфликтующий индекс в коллекции %s", coll.Name())
+}
+
+// isTextIndex проверяет, является ли индекс текстовым
+func isTextIndex(keys interface{}) bool {
+	doc, ok := keys.(bson.D)
+	if !ok {
+		return false
+	}
+	for _, elem := range doc {
+		if elem.Value == "text" {
+			return true
+		}
+	}
+	return false
+}
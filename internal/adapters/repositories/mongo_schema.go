@@ -0,0 +1,185 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"reflect"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// schemaVersionField поле документа с версией схемы; у документов, сохраненных до версионирования, его нет
+const schemaVersionField = "schema_version"
+
+// documentMigration переводит документ на следующую версию схемы
+type documentMigration func(doc bson.M)
+
+// documentSchema описывает версии схемы документов одного типа.
+// migrations[i] переводит документ из версии i в версию i+1, поэтому миграций столько же, сколько версий
+type documentSchema struct {
+	name string
+	// version текущая версия схемы из models: ее же записывают в документы репозитории и ключи кэша
+	version    int
+	migrations []documentMigration
+}
+
+// Схемы документов, хранимых в MongoDB
+var (
+	stockSchema = documentSchema{
+		name:    "stock",
+		version: models.StockSchemaVersion,
+		migrations: []documentMigration{
+			// 0 → 1: формат не изменился, документ только получает версию
+			func(doc bson.M) {},
+		},
+	}
+
+	stockQuoteSchema = documentSchema{
+		name:    "stock_quote",
+		version: models.StockQuoteSchemaVersion,
+		migrations: []documentMigration{
+			// 0 → 1: формат не изменился, документ только получает версию
+			func(doc bson.M) {},
+			// 1 → 2: до появления внутридневных свечей хранились только дневные котировки
+			func(doc bson.M) {
+				if interval, _ := doc["interval"].(string); interval == "" {
+					doc["interval"] = models.IntervalDay
+				}
+			},
+		},
+	}
+
+	newsSchema = documentSchema{
+		name:    "news",
+		version: models.NewsSchemaVersion,
+		migrations: []documentMigration{
+			// 0 → 1: новости до появления поля language загружались на языке по умолчанию
+			func(doc bson.M) {
+				if language, _ := doc["language"].(string); language == "" {
+					doc["language"] = models.DefaultNewsLanguage
+				}
+			},
+		},
+	}
+)
+
+// init проверяет, что для каждой версии схемы есть миграция: версия в models, увеличенная без миграции
+// (или миграция без увеличения версии), остановит сервер при запуске, а не испортит документы
+func init() {
+	for _, schema := range []documentSchema{stockSchema, stockQuoteSchema, newsSchema} {
+		if len(schema.migrations) != schema.version {
+			panic(fmt.Sprintf("схема %s: версия %d, а миграций %d", schema.name, schema.version, len(schema.migrations)))
+		}
+	}
+}
+
+// decode декодирует документ в dest, предварительно обновив его до текущей версии схемы.
+// Обновленный документ сохраняется обратно в коллекцию, чтобы миграция выполнялась один раз;
+// ошибка сохранения только логируется — чтение от нее не зависит
+func (s documentSchema) decode(ctx context.Context, coll *mongo.Collection, raw bson.Raw, dest interface{}) error {
+	version := documentVersion(raw)
+	if version >= s.version {
+		// Документы более новых версий декодируются как есть: неизвестные поля игнорируются
+		return bson.Unmarshal(raw, dest)
+	}
+
+	var doc bson.M
+	if err := bson.Unmarshal(raw, &doc); err != nil {
+		return err
+	}
+	original := make(bson.M, len(doc))
+	for key, value := range doc {
+		original[key] = value
+	}
+
+	for _, migrate := range s.migrations[version:] {
+		migrate(doc)
+	}
+	doc[schemaVersionField] = s.version
+
+	migrated, err := bson.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("ошибка миграции документа %s: %w", s.name, err)
+	}
+	if err := bson.Unmarshal(migrated, dest); err != nil {
+		return err
+	}
+
+	if err := s.saveMigrated(ctx, coll, original, doc); err != nil {
+		log.Printf("Не удалось сохранить документ %s после миграции на версию %d: %v", s.name, s.version, err)
+	}
+
+	return nil
+}
+
+// saveMigrated записывает в коллекцию только поля, измененные миграциями,
+// чтобы не сохранить в документ вычисляемые поля запроса (например, оценку релевантности)
+func (s documentSchema) saveMigrated(ctx context.Context, coll *mongo.Collection, original, migrated bson.M) error {
+	id, ok := original["_id"]
+	if !ok {
+		return nil
+	}
+
+	set := bson.M{}
+	for key, value := range migrated {
+		if previous, ok := original[key]; !ok || !reflect.DeepEqual(previous, value) {
+			set[key] = value
+		}
+	}
+	unset := bson.M{}
+	for key := range original {
+		if _, ok := migrated[key]; !ok {
+			unset[key] = ""
+		}
+	}
+
+	update := bson.M{"$set": set}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+
+	_, err := coll.UpdateOne(ctx, bson.M{"_id": id}, update)
+	return err
+}
+
+// decodeOne декодирует результат FindOne с миграцией документа
+func (s documentSchema) decodeOne(ctx context.Context, coll *mongo.Collection, result *mongo.SingleResult, dest interface{}) error {
+	raw, err := result.Raw()
+	if err != nil {
+		return err
+	}
+	return s.decode(ctx, coll, raw, dest)
+}
+
+// decodeAll декодирует все документы курсора с миграцией
+func decodeAll[T any](ctx context.Context, s documentSchema, coll *mongo.Collection, cursor *mongo.Cursor) ([]T, error) {
+	var items []T
+	for cursor.Next(ctx) {
+		var item T
+		if err := s.decode(ctx, coll, cursor.Current, &item); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// documentVersion возвращает версию схемы документа; 0 — документ сохранен до версионирования
+func documentVersion(raw bson.Raw) int {
+	value, err := raw.LookupErr(schemaVersionField)
+	if err != nil {
+		return 0
+	}
+	if version, ok := value.AsInt64OK(); ok {
+		return int(version)
+	}
+	return 0
+}
@@ -0,0 +1,55 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/repositories"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MoodRepositoryImpl реализация интерфейса MoodRepository на MongoDB
+type MoodRepositoryImpl struct {
+	db *mongo.Collection
+}
+
+// NewMoodRepository создает новый экземпляр репозитория индекса настроения рынка
+func NewMoodRepository(db *mongo.Database) repositories.MoodRepository {
+	return &MoodRepositoryImpl{
+		db: db.Collection("market_mood"),
+	}
+}
+
+// SaveMood сохраняет значение индекса за день, заменяя ранее сохраненное
+func (r *MoodRepositoryImpl) SaveMood(ctx context.Context, mood *models.MarketMood) error {
+	_, err := r.db.ReplaceOne(ctx, bson.M{"date": mood.Date}, mood, options.Replace().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("ошибка сохранения индекса настроения: %w", err)
+	}
+
+	return nil
+}
+
+// GetMoodHistory возвращает значения индекса за период [from, to] от старых дней к новым
+func (r *MoodRepositoryImpl) GetMoodHistory(ctx context.Context, from, to time.Time) ([]models.MarketMood, error) {
+	filter := bson.M{"date": bson.M{"$gte": from, "$lte": to}}
+
+	opts := options.Find().SetSort(bson.D{{Key: "date", Value: 1}})
+	cursor, err := r.db.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка поиска в базе данных: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var moods []models.MarketMood
+	if err = cursor.All(ctx, &moods); err != nil {
+		return nil, fmt.Errorf("ошибка декодирования результатов: %w", err)
+	}
+
+	return moods, nil
+}
@@ -0,0 +1,97 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/adapters/repositories/apis"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/cache"
+)
+
+// backfillMaxPages максимальное число страниц NewsAPI, запрашиваемых за один день
+const backfillMaxPages = 5
+
+// backfillNews постранично загружает из NewsAPI новости за каждый день периода [from, to],
+// отбрасывает повторы по сгенерированному ID и сохраняет новости через save.
+// Запросы идут по дням, чтобы ограничение NewsAPI на глубину выдачи действовало на каждый день отдельно.
+// Кэш новостей за загруженные дни сбрасывается, чтобы выборки по дате читали их из базы
+func backfillNews(
+	ctx context.Context,
+	newsAPI *apis.NewsAPIClient,
+	c cache.Cache,
+	from, to time.Time,
+	save func(ctx context.Context, news []models.News) error,
+) (*models.NewsBackfill, error) {
+	firstDay := from.UTC().Truncate(24 * time.Hour)
+	lastDay := to.UTC().Truncate(24 * time.Hour)
+
+	result := &models.NewsBackfill{
+		From:   firstDay,
+		To:     lastDay,
+		Failed: make(map[string]string),
+	}
+	seen := make(map[string]bool)
+
+	for day := firstDay; !day.After(lastDay); day = day.Add(24 * time.Hour) {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		result.Days++
+		date := day.Format("2006-01-02")
+
+		var dayNews []models.News
+		for page := 1; page <= backfillMaxPages; page++ {
+			items, total, err := newsAPI.GetNewsArchivePage(ctx, day, day.Add(24*time.Hour), page)
+			result.Requests++
+			if errors.Is(err, apis.ErrNewsAPIResultsLimit) {
+				result.Truncated = append(result.Truncated, date)
+				break
+			}
+			if err != nil {
+				result.Failed[date] = err.Error()
+				break
+			}
+
+			result.Fetched += len(items)
+			for _, item := range items {
+				if item.ID == "" || seen[item.ID] {
+					result.Duplicates++
+					continue
+				}
+				seen[item.ID] = true
+				dayNews = append(dayNews, item)
+			}
+
+			if len(items) < apis.NewsAPIPageSize || page*apis.NewsAPIPageSize >= total {
+				break
+			}
+			if page == backfillMaxPages {
+				result.Truncated = append(result.Truncated, date)
+			}
+		}
+
+		if len(dayNews) == 0 {
+			continue
+		}
+
+		if err := save(ctx, dayNews); err != nil {
+			result.Failed[date] = fmt.Sprintf("ошибка сохранения: %v", err)
+			continue
+		}
+		result.Saved += len(dayNews)
+
+		// Сбрасываем выборки за день во всех подробностях
+		for _, detail := range []models.NewsDetail{models.NewsDetailFull, models.NewsDetailSummary, models.NewsDetailHeadline} {
+			if err := c.Delete(ctx, models.NewsDateCacheKey(date)+detail.CacheKey()); err != nil {
+				log.Printf("Ошибка сброса кэша новостей за %s: %v", date, err)
+			}
+		}
+	}
+
+	return result, nil
+}
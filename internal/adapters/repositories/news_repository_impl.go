@@ -2,8 +2,11 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"regexp"
+	"sort"
 	"time"
 
 	"github.com/JkLondon/mcp-stocks-info-server/internal/adapters/repositories/apis"
@@ -12,7 +15,9 @@ import (
 	"github.com/JkLondon/mcp-stocks-info-server/pkg/cache"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 // NewsRepositoryImpl реализация интерфейса NewsRepository
@@ -43,20 +48,22 @@ func NewNewsRepository(
 
 // GetNews возвращает новость по ID
 func (r *NewsRepositoryImpl) GetNews(ctx context.Context, id string) (*models.News, error) {
-	cacheKey := fmt.Sprintf("news:%s", id)
+	cacheKey := models.NewsCacheKey(id)
 
 	// Проверяем кэш, если включено использование кэша
 	if r.useCache {
 		var cachedNews models.News
-		err := r.cache.Get(ctx, cacheKey, &cachedNews)
-		if err == nil && cachedNews.ID != "" {
+		switch err := r.cache.Get(ctx, cacheKey, &cachedNews); {
+		case err == nil:
 			return &cachedNews, nil
+		case !errors.Is(err, cache.ErrNotFound):
+			log.Printf("Ошибка чтения кэша %s: %v", cacheKey, err)
 		}
 	}
 
 	// Ищем в базе данных
 	var news models.News
-	err := r.db.FindOne(ctx, bson.M{"_id": id}).Decode(&news)
+	err := newsSchema.decodeOne(ctx, r.db, r.db.FindOne(ctx, bson.M{"_id": id}), &news)
 	if err == nil {
 		// Сохраняем в кэш
 		if r.useCache {
@@ -74,14 +81,17 @@ func (r *NewsRepositoryImpl) GetNewsByDate(ctx context.Context, date time.Time)
 	startDate := date.Truncate(24 * time.Hour)
 	endDate := startDate.Add(24 * time.Hour)
 
-	cacheKey := fmt.Sprintf("news:date:%s", startDate.Format("2006-01-02"))
+	detail := repositories.NewsDetailFromContext(ctx)
+	cacheKey := models.NewsDateCacheKey(startDate.Format("2006-01-02")) + detail.CacheKey()
 
 	// Проверяем кэш, если включено использование кэша
 	if r.useCache {
 		var cachedNews []models.News
-		err := r.cache.Get(ctx, cacheKey, &cachedNews)
-		if err == nil && len(cachedNews) > 0 {
+		switch err := r.cache.Get(ctx, cacheKey, &cachedNews); {
+		case err == nil:
 			return cachedNews, nil
+		case !errors.Is(err, cache.ErrNotFound):
+			log.Printf("Ошибка чтения кэша %s: %v", cacheKey, err)
 		}
 	}
 
@@ -91,14 +101,14 @@ func (r *NewsRepositoryImpl) GetNewsByDate(ctx context.Context, date time.Time)
 			"$gte": startDate,
 			"$lt":  endDate,
 		},
-	})
+	}, options.Find().SetProjection(newsProjection(detail)))
 	if err != nil {
 		return nil, fmt.Errorf("ошибка поиска в базе данных: %w", err)
 	}
 	defer cursor.Close(ctx)
 
-	var news []models.News
-	if err = cursor.All(ctx, &news); err != nil {
+	news, err := decodeAll[models.News](ctx, newsSchema, r.db, cursor)
+	if err != nil {
 		return nil, fmt.Errorf("ошибка декодирования результатов: %w", err)
 	}
 
@@ -120,48 +130,61 @@ func (r *NewsRepositoryImpl) GetNewsByDate(ctx context.Context, date time.Time)
 	return []models.News{}, nil
 }
 
-// GetNewsForToday возвращает новости за сегодня
-func (r *NewsRepositoryImpl) GetNewsForToday(ctx context.Context) ([]models.News, error) {
-	// Используем метод GetNewsByDate с сегодняшней датой
-	return r.GetNewsByDate(ctx, time.Now())
+// GetNewsForToday возвращает страницу новостей за сегодня (от новых к старым) и их общее количество
+func (r *NewsRepositoryImpl) GetNewsForToday(ctx context.Context, page models.Pagination) ([]models.News, int, error) {
+	news, err := r.GetNewsByDate(ctx, time.Now())
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Порядок должен быть стабильным, чтобы страницы не пересекались
+	sort.SliceStable(news, func(i, j int) bool {
+		return news[i].PublishedAt.After(news[j].PublishedAt)
+	})
+
+	start, end := page.Bounds(len(news))
+	return news[start:end], len(news), nil
 }
 
-// GetNewsByKeyword возвращает новости по ключевому слову
-func (r *NewsRepositoryImpl) GetNewsByKeyword(ctx context.Context, keyword string) ([]models.News, error) {
+// GetNewsByKeyword возвращает страницу новостей по ключевому слову и их общее количество.
+// Кэшируется полный результат поиска, страница выделяется из него.
+func (r *NewsRepositoryImpl) GetNewsByKeyword(ctx context.Context, keyword string, filter models.NewsFilter, page models.Pagination) ([]models.News, int, error) {
+	news, err := r.searchNews(ctx, keyword, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	start, end := page.Bounds(len(news))
+	return news[start:end], len(news), nil
+}
+
+// searchNews возвращает все новости по ключевому слову, удовлетворяющие фильтру
+func (r *NewsRepositoryImpl) searchNews(ctx context.Context, keyword string, filter models.NewsFilter) ([]models.News, error) {
 	if keyword == "" {
 		return nil, fmt.Errorf("ключевое слово не может быть пустым")
 	}
 
-	cacheKey := fmt.Sprintf("news:keyword:%s", keyword)
+	cacheKey := models.NewsKeywordCacheKey(keyword, filter) + repositories.NewsDetailFromContext(ctx).CacheKey()
 
 	// Проверяем кэш, если включено использование кэша
 	if r.useCache {
 		var cachedNews []models.News
-		err := r.cache.Get(ctx, cacheKey, &cachedNews)
-		if err == nil && len(cachedNews) > 0 {
+		switch err := r.cache.Get(ctx, cacheKey, &cachedNews); {
+		case err == nil:
 			return cachedNews, nil
+		case !errors.Is(err, cache.ErrNotFound):
+			log.Printf("Ошибка чтения кэша %s: %v", cacheKey, err)
 		}
 	}
 
-	// Ищем в базе данных
-	// Для простоты используем поиск по title и description
-	// Для более точного поиска можно использовать полнотекстовый индекс
-	cursor, err := r.db.Find(ctx, bson.M{
-		"$or": []bson.M{
-			{"title": bson.M{"$regex": keyword, "$options": "i"}},
-			{"description": bson.M{"$regex": keyword, "$options": "i"}},
-			{"content": bson.M{"$regex": keyword, "$options": "i"}},
-			{"tags": keyword},
-		},
-	})
-	if err != nil {
-		return nil, fmt.Errorf("ошибка поиска в базе данных: %w", err)
+	// Ищем в базе данных по текстовому индексу, а если он недоступен — регулярным выражением
+	news, err := r.searchNewsByText(ctx, keyword, filter)
+	if isTextIndexMissing(err) {
+		log.Printf("Текстовый индекс новостей недоступен, используем поиск по регулярному выражению")
+		news, err = r.searchNewsByRegex(ctx, keyword, filter)
 	}
-	defer cursor.Close(ctx)
-
-	var news []models.News
-	if err = cursor.All(ctx, &news); err != nil {
-		return nil, fmt.Errorf("ошибка декодирования результатов: %w", err)
+	if err != nil {
+		return nil, err
 	}
 
 	// Если нашли новости в базе, возвращаем их
@@ -174,7 +197,7 @@ func (r *NewsRepositoryImpl) GetNewsByKeyword(ctx context.Context, keyword strin
 	}
 
 	// Если не нашли в базе, делаем запрос к NewsAPI
-	return r.fetchNewsByKeywordFromAPI(ctx, keyword)
+	return r.fetchNewsByKeywordFromAPI(ctx, keyword, filter)
 }
 
 // GetNewsByTicker возвращает новости, связанные с указанным тикером
@@ -183,14 +206,17 @@ func (r *NewsRepositoryImpl) GetNewsByTicker(ctx context.Context, ticker string)
 		return nil, fmt.Errorf("тикер не может быть пустым")
 	}
 
-	cacheKey := fmt.Sprintf("news:ticker:%s", ticker)
+	detail := repositories.NewsDetailFromContext(ctx)
+	cacheKey := models.NewsTickerCacheKey(ticker) + detail.CacheKey()
 
 	// Проверяем кэш, если включено использование кэша
 	if r.useCache {
 		var cachedNews []models.News
-		err := r.cache.Get(ctx, cacheKey, &cachedNews)
-		if err == nil && len(cachedNews) > 0 {
+		switch err := r.cache.Get(ctx, cacheKey, &cachedNews); {
+		case err == nil:
 			return cachedNews, nil
+		case !errors.Is(err, cache.ErrNotFound):
+			log.Printf("Ошибка чтения кэша %s: %v", cacheKey, err)
 		}
 	}
 
@@ -203,14 +229,14 @@ func (r *NewsRepositoryImpl) GetNewsByTicker(ctx context.Context, ticker string)
 			{"description": bson.M{"$regex": ticker, "$options": "i"}},
 			{"content": bson.M{"$regex": ticker, "$options": "i"}},
 		},
-	})
+	}, options.Find().SetProjection(newsProjection(detail)))
 	if err != nil {
 		return nil, fmt.Errorf("ошибка поиска в базе данных: %w", err)
 	}
 	defer cursor.Close(ctx)
 
-	var news []models.News
-	if err = cursor.All(ctx, &news); err != nil {
+	news, err := decodeAll[models.News](ctx, newsSchema, r.db, cursor)
+	if err != nil {
 		return nil, fmt.Errorf("ошибка декодирования результатов: %w", err)
 	}
 
@@ -224,7 +250,104 @@ func (r *NewsRepositoryImpl) GetNewsByTicker(ctx context.Context, ticker string)
 	}
 
 	// Если не нашли в базе, делаем запрос к NewsAPI по ключевому слову (тикеру)
-	return r.fetchNewsByKeywordFromAPI(ctx, ticker)
+	return r.fetchNewsByKeywordFromAPI(ctx, ticker, models.NewsFilter{})
+}
+
+// FindRelatedNews возвращает новости периода с общими тикерами или тегами
+func (r *NewsRepositoryImpl) FindRelatedNews(ctx context.Context, news models.News, from, to time.Time, limit int) ([]models.News, error) {
+	var terms []bson.M
+	if len(news.RelatedTo) > 0 {
+		terms = append(terms, bson.M{"related_to": bson.M{"$in": news.RelatedTo}})
+	}
+	if len(news.Tags) > 0 {
+		terms = append(terms, bson.M{"tags": bson.M{"$in": news.Tags}})
+	}
+	if len(terms) == 0 {
+		return nil, nil
+	}
+
+	opts := options.Find().
+		SetProjection(newsProjection(models.NewsDetailSummary)).
+		SetSort(bson.D{{Key: "published_at", Value: -1}}).
+		SetLimit(int64(limit))
+	cursor, err := r.db.Find(ctx, bson.M{
+		"_id":          bson.M{"$ne": news.ID},
+		"published_at": bson.M{"$gte": from, "$lt": to},
+		"$or":          terms,
+	}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка поиска в базе данных: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	related, err := decodeAll[models.News](ctx, newsSchema, r.db, cursor)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка декодирования результатов: %w", err)
+	}
+
+	return related, nil
+}
+
+// GetNewsTimeline возвращает число новостей тикера и свежие заголовки по дням периода.
+// Новости группируются по дню публикации на стороне MongoDB
+func (r *NewsRepositoryImpl) GetNewsTimeline(ctx context.Context, ticker string, from, to time.Time, headlines int) ([]models.NewsDay, error) {
+	if ticker == "" {
+		return nil, fmt.Errorf("тикер не может быть пустым")
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"related_to":   ticker,
+			"published_at": bson.M{"$gte": from, "$lt": to},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "published_at", Value: -1}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id": bson.M{"$dateToString": bson.M{
+				"format":   "%Y-%m-%d",
+				"date":     "$published_at",
+				"timezone": models.MoscowLocation.String(),
+			}},
+			"count": bson.M{"$sum": 1},
+			"headlines": bson.M{"$push": bson.M{
+				"_id":          "$_id",
+				"title":        "$title",
+				"source":       "$source",
+				"url":          "$url",
+				"published_at": "$published_at",
+			}},
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"count":     1,
+			"headlines": bson.M{"$slice": bson.A{"$headlines", headlines}},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "_id", Value: 1}}}},
+	}
+
+	cursor, err := r.db.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка агрегации в базе данных: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var groups []struct {
+		Day       string        `bson:"_id"`
+		Count     int           `bson:"count"`
+		Headlines []models.News `bson:"headlines"`
+	}
+	if err := cursor.All(ctx, &groups); err != nil {
+		return nil, fmt.Errorf("ошибка декодирования результатов: %w", err)
+	}
+
+	days := make([]models.NewsDay, 0, len(groups))
+	for _, group := range groups {
+		date, err := time.ParseInLocation("2006-01-02", group.Day, models.MoscowLocation)
+		if err != nil {
+			return nil, fmt.Errorf("некорректный день в результатах агрегации %q: %w", group.Day, err)
+		}
+		days = append(days, models.NewsDay{Date: date, Count: group.Count, Headlines: group.Headlines})
+	}
+
+	return days, nil
 }
 
 // SaveNews сохраняет новость
@@ -232,6 +355,7 @@ func (r *NewsRepositoryImpl) SaveNews(ctx context.Context, news *models.News) er
 	if news == nil {
 		return fmt.Errorf("новость не может быть nil")
 	}
+	news.SchemaVersion = models.NewsSchemaVersion
 
 	// Проверяем, существует ли новость с таким ID
 	var existingNews models.News
@@ -250,24 +374,43 @@ func (r *NewsRepositoryImpl) SaveNews(ctx context.Context, news *models.News) er
 
 	// Обновляем кэш
 	if r.useCache {
-		cacheKey := fmt.Sprintf("news:%s", news.ID)
+		cacheKey := models.NewsCacheKey(news.ID)
 		r.cache.Set(ctx, cacheKey, news, r.cacheExpiry)
 	}
 
 	return nil
 }
 
-// SaveNewsCollection сохраняет набор новостей
+// SaveNewsCollection сохраняет набор новостей одной пакетной операцией.
+// Новости идентифицируются по ID; кэш не обновляется, он заполнится при чтении.
 func (r *NewsRepositoryImpl) SaveNewsCollection(ctx context.Context, newsCollection []models.News) error {
+	if len(newsCollection) == 0 {
+		return nil
+	}
+
+	writes := make([]mongo.WriteModel, 0, len(newsCollection))
 	for _, news := range newsCollection {
-		err := r.SaveNews(ctx, &news)
-		if err != nil {
-			return err
-		}
+		news.SchemaVersion = models.NewsSchemaVersion
+		writes = append(writes, mongo.NewReplaceOneModel().
+			SetFilter(bson.M{"_id": news.ID}).
+			SetReplacement(news).
+			SetUpsert(true))
 	}
+
+	// Неупорядоченная запись продолжает обработку пакета при ошибке отдельного документа
+	_, err := r.db.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		return fmt.Errorf("ошибка пакетного сохранения новостей: %w", err)
+	}
+
 	return nil
 }
 
+// BackfillNews загружает из NewsAPI архив новостей за период [from, to] и сохраняет его в базу
+func (r *NewsRepositoryImpl) BackfillNews(ctx context.Context, from, to time.Time) (*models.NewsBackfill, error) {
+	return backfillNews(ctx, r.newsAPI, r.cache, from, to, r.SaveNewsCollection)
+}
+
 // Вспомогательные методы
 
 // fetchTodayNewsFromAPI получает новости за сегодня из NewsAPI
@@ -279,46 +422,152 @@ func (r *NewsRepositoryImpl) fetchTodayNewsFromAPI(ctx context.Context) ([]model
 	}
 
 	// Сохраняем полученные новости в базу данных
-	for i := range news {
-		if err := r.SaveNews(ctx, &news[i]); err != nil {
-			log.Printf("Ошибка сохранения новости %s: %v", news[i].ID, err)
-		}
+	if err := r.SaveNewsCollection(ctx, news); err != nil {
+		log.Printf("Ошибка сохранения новостей: %v", err)
 	}
 
 	// Обновляем кэш
 	if r.useCache && len(news) > 0 {
 		today := time.Now().Format("2006-01-02")
-		cacheKey := fmt.Sprintf("news:date:%s", today)
+		cacheKey := models.NewsDateCacheKey(today)
 		if err := r.cache.Set(ctx, cacheKey, news, r.cacheExpiry); err != nil {
 			log.Printf("Ошибка кэширования новостей за сегодня: %v", err)
 		}
 	}
 
+	return repositories.NewsDetailFromContext(ctx).Strip(news), nil
+}
+
+// searchNewsByText ищет новости по текстовому индексу и сортирует их по релевантности
+func (r *NewsRepositoryImpl) searchNewsByText(ctx context.Context, keyword string, filter models.NewsFilter) ([]models.News, error) {
+	score := bson.M{"$meta": "textScore"}
+	projection := newsProjection(repositories.NewsDetailFromContext(ctx))
+	projection["score"] = score
+	opts := options.Find().
+		SetProjection(projection).
+		SetSort(bson.D{{Key: "score", Value: score}, {Key: "published_at", Value: -1}})
+
+	query := newsFilterQuery(filter)
+	query["$text"] = bson.M{"$search": keyword}
+
+	cursor, err := r.db.Find(ctx, query, opts)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка полнотекстового поиска в базе данных: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	news, err := decodeAll[models.News](ctx, newsSchema, r.db, cursor)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка декодирования результатов: %w", err)
+	}
+
 	return news, nil
 }
 
+// searchNewsByRegex ищет новости по вхождению ключевого слова в заголовок, описание, текст и теги
+func (r *NewsRepositoryImpl) searchNewsByRegex(ctx context.Context, keyword string, filter models.NewsFilter) ([]models.News, error) {
+	pattern := regexp.QuoteMeta(keyword)
+	opts := options.Find().
+		SetProjection(newsProjection(repositories.NewsDetailFromContext(ctx))).
+		SetSort(bson.D{{Key: "published_at", Value: -1}})
+
+	query := newsFilterQuery(filter)
+	query["$or"] = []bson.M{
+		{"title": bson.M{"$regex": pattern, "$options": "i"}},
+		{"description": bson.M{"$regex": pattern, "$options": "i"}},
+		{"content": bson.M{"$regex": pattern, "$options": "i"}},
+		{"tags": keyword},
+	}
+
+	cursor, err := r.db.Find(ctx, query, opts)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка поиска в базе данных: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	news, err := decodeAll[models.News](ctx, newsSchema, r.db, cursor)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка декодирования результатов: %w", err)
+	}
+
+	return news, nil
+}
+
+// newsProjection возвращает проекцию MongoDB, исключающую поля, которые не входят в подробность detail:
+// полный текст новостей занимает большую часть документа, и без него выборки заметно легче
+func newsProjection(detail models.NewsDetail) bson.M {
+	projection := bson.M{}
+	switch detail {
+	case models.NewsDetailHeadline:
+		projection["description"] = 0
+		projection["summary"] = 0
+		fallthrough
+	case models.NewsDetailSummary:
+		projection["content"] = 0
+	}
+	return projection
+}
+
+// newsFilterQuery строит условия запроса MongoDB по фильтру новостей
+func newsFilterQuery(filter models.NewsFilter) bson.M {
+	query := bson.M{}
+
+	published := bson.M{}
+	if !filter.From.IsZero() {
+		published["$gte"] = filter.From
+	}
+	if !filter.To.IsZero() {
+		published["$lt"] = filter.To
+	}
+	if len(published) > 0 {
+		query["published_at"] = published
+	}
+
+	// Издания сравниваются без учета регистра: в фильтре могут быть и идентификаторы, и названия
+	if len(filter.Sources) > 0 {
+		sources := make([]interface{}, 0, len(filter.Sources))
+		for _, source := range filter.Sources {
+			sources = append(sources, primitive.Regex{Pattern: "^" + regexp.QuoteMeta(source) + "$", Options: "i"})
+		}
+		query["source"] = bson.M{"$in": sources}
+	}
+
+	// Новости, сохраненные до появления поля language, загружались на языке по умолчанию
+	if filter.Lang() == models.DefaultNewsLanguage {
+		query["language"] = bson.M{"$in": []interface{}{models.DefaultNewsLanguage, nil}}
+	} else {
+		query["language"] = filter.Lang()
+	}
+
+	return query
+}
+
+// isTextIndexMissing проверяет, что запрос $text не выполнен из-за отсутствия текстового индекса
+func isTextIndexMissing(err error) bool {
+	var cmdErr mongo.CommandError
+	return errors.As(err, &cmdErr) && cmdErr.Code == mongoIndexNotFound
+}
+
 // fetchNewsByKeywordFromAPI получает новости по ключевому слову из NewsAPI
-func (r *NewsRepositoryImpl) fetchNewsByKeywordFromAPI(ctx context.Context, keyword string) ([]models.News, error) {
+func (r *NewsRepositoryImpl) fetchNewsByKeywordFromAPI(ctx context.Context, keyword string, filter models.NewsFilter) ([]models.News, error) {
 	// Делаем запрос к NewsAPI
-	news, err := r.newsAPI.GetNewsByKeyword(ctx, keyword)
+	news, err := r.newsAPI.GetNewsByKeyword(ctx, keyword, filter)
 	if err != nil {
 		return nil, fmt.Errorf("ошибка получения данных из NewsAPI: %w", err)
 	}
 
 	// Сохраняем полученные новости в базу данных
-	for i := range news {
-		if err := r.SaveNews(ctx, &news[i]); err != nil {
-			log.Printf("Ошибка сохранения новости %s: %v", news[i].ID, err)
-		}
+	if err := r.SaveNewsCollection(ctx, news); err != nil {
+		log.Printf("Ошибка сохранения новостей: %v", err)
 	}
 
 	// Обновляем кэш
 	if r.useCache && len(news) > 0 {
-		cacheKey := fmt.Sprintf("news:keyword:%s", keyword)
+		cacheKey := models.NewsKeywordCacheKey(keyword, filter)
 		if err := r.cache.Set(ctx, cacheKey, news, r.cacheExpiry); err != nil {
 			log.Printf("Ошибка кэширования новостей по ключевому слову %s: %v", keyword, err)
 		}
 	}
 
-	return news, nil
+	return repositories.NewsDetailFromContext(ctx).Strip(news), nil
 }
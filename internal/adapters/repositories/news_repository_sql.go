@@ -0,0 +1,508 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/adapters/repositories/apis"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/repositories"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/cache"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/timing"
+)
+
+const newsColumns = `id, title, description, content, url, source, published_at, created_at, tags, related_to, language, summary`
+
+// newsColumnsFor возвращает столбцы выборки новостей для подробности detail: текст, который в нее не входит,
+// заменяется пустой строкой, чтобы не передавать его из базы, а порядок столбцов для scanNews не меняется
+func newsColumnsFor(detail models.NewsDetail) string {
+	switch detail {
+	case models.NewsDetailHeadline:
+		return `id, title, '' AS description, '' AS content, url, source, published_at, created_at, tags, related_to, language, '' AS summary`
+	case models.NewsDetailSummary:
+		return `id, title, description, '' AS content, url, source, published_at, created_at, tags, related_to, language, summary`
+	}
+	return newsColumns
+}
+
+// SQLNewsRepository реализация интерфейса NewsRepository на основе SQL-базы данных
+// (PostgreSQL или SQLite)
+type SQLNewsRepository struct {
+	db          *sql.DB
+	dialect     SQLDialect
+	cache       cache.Cache
+	newsAPI     *apis.NewsAPIClient
+	cacheExpiry time.Duration
+	useCache    bool
+}
+
+// NewSQLNewsRepository создает новый экземпляр репозитория новостей на основе SQL-базы данных
+func NewSQLNewsRepository(
+	db *sql.DB,
+	dialect SQLDialect,
+	cache cache.Cache,
+	newsAPI *apis.NewsAPIClient,
+	cacheExpiry time.Duration,
+	useCache bool,
+) repositories.NewsRepository {
+	return &SQLNewsRepository{
+		db:          db,
+		dialect:     dialect,
+		cache:       cache,
+		newsAPI:     newsAPI,
+		cacheExpiry: cacheExpiry,
+		useCache:    useCache,
+	}
+}
+
+// GetNews возвращает новость по ID
+func (r *SQLNewsRepository) GetNews(ctx context.Context, id string) (*models.News, error) {
+	cacheKey := models.NewsCacheKey(id)
+
+	// Проверяем кэш, если включено использование кэша
+	if r.useCache {
+		var cachedNews models.News
+		switch err := r.cache.Get(ctx, cacheKey, &cachedNews); {
+		case err == nil:
+			return &cachedNews, nil
+		case !errors.Is(err, cache.ErrNotFound):
+			log.Printf("Ошибка чтения кэша %s: %v", cacheKey, err)
+		}
+	}
+
+	// Ищем в базе данных
+	stop := timing.Track(ctx, timing.StageDB)
+	news, err := r.scanNews(r.db.QueryRowContext(ctx, `SELECT `+newsColumns+` FROM news WHERE id = $1`, id))
+	stop()
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("новость с ID %s не найдена", id)
+		}
+		return nil, fmt.Errorf("ошибка поиска в базе данных: %w", err)
+	}
+
+	// Сохраняем в кэш
+	if r.useCache {
+		r.cache.Set(ctx, cacheKey, news, r.cacheExpiry)
+	}
+
+	return &news, nil
+}
+
+// GetNewsByDate возвращает новости за указанную дату
+func (r *SQLNewsRepository) GetNewsByDate(ctx context.Context, date time.Time) ([]models.News, error) {
+	// Нормализуем дату, отбрасывая время
+	startDate := date.Truncate(24 * time.Hour)
+	endDate := startDate.Add(24 * time.Hour)
+
+	detail := repositories.NewsDetailFromContext(ctx)
+	cacheKey := models.NewsDateCacheKey(startDate.Format("2006-01-02")) + detail.CacheKey()
+
+	// Проверяем кэш, если включено использование кэша
+	if r.useCache {
+		var cachedNews []models.News
+		switch err := r.cache.Get(ctx, cacheKey, &cachedNews); {
+		case err == nil:
+			return cachedNews, nil
+		case !errors.Is(err, cache.ErrNotFound):
+			log.Printf("Ошибка чтения кэша %s: %v", cacheKey, err)
+		}
+	}
+
+	// Ищем в базе данных
+	news, err := r.queryNews(ctx,
+		`SELECT `+newsColumnsFor(detail)+` FROM news
+		WHERE published_at >= $1 AND published_at < $2
+		ORDER BY published_at DESC`,
+		startDate.UTC(), endDate.UTC(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Если нашли новости в базе, возвращаем их
+	if len(news) > 0 {
+		// Сохраняем в кэш
+		if r.useCache {
+			r.cache.Set(ctx, cacheKey, news, r.cacheExpiry)
+		}
+		return news, nil
+	}
+
+	// Если не нашли в базе, и сегодняшний день, делаем запрос к NewsAPI
+	now := time.Now()
+	if startDate.Year() == now.Year() && startDate.Month() == now.Month() && startDate.Day() == now.Day() {
+		news, err = r.newsAPI.GetTodayNews(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка получения данных из NewsAPI: %w", err)
+		}
+		r.storeFetched(ctx, models.NewsDateCacheKey(startDate.Format("2006-01-02")), news)
+		return detail.Strip(news), nil
+	}
+
+	// Для исторических дат просто возвращаем пустой результат
+	return []models.News{}, nil
+}
+
+// GetNewsForToday возвращает страницу новостей за сегодня (от новых к старым) и их общее количество
+func (r *SQLNewsRepository) GetNewsForToday(ctx context.Context, page models.Pagination) ([]models.News, int, error) {
+	news, err := r.GetNewsByDate(ctx, time.Now())
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Порядок должен быть стабильным, чтобы страницы не пересекались
+	sort.SliceStable(news, func(i, j int) bool {
+		return news[i].PublishedAt.After(news[j].PublishedAt)
+	})
+
+	start, end := page.Bounds(len(news))
+	return news[start:end], len(news), nil
+}
+
+// GetNewsByKeyword возвращает страницу новостей по ключевому слову и их общее количество.
+// Кэшируется полный результат поиска, страница выделяется из него.
+func (r *SQLNewsRepository) GetNewsByKeyword(ctx context.Context, keyword string, filter models.NewsFilter, page models.Pagination) ([]models.News, int, error) {
+	news, err := r.searchNews(ctx, keyword, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	start, end := page.Bounds(len(news))
+	return news[start:end], len(news), nil
+}
+
+// searchNews возвращает все новости по ключевому слову, удовлетворяющие фильтру
+func (r *SQLNewsRepository) searchNews(ctx context.Context, keyword string, filter models.NewsFilter) ([]models.News, error) {
+	if keyword == "" {
+		return nil, fmt.Errorf("ключевое слово не может быть пустым")
+	}
+
+	detail := repositories.NewsDetailFromContext(ctx)
+	cacheKey := models.NewsKeywordCacheKey(keyword, filter) + detail.CacheKey()
+
+	// Проверяем кэш, если включено использование кэша
+	if r.useCache {
+		var cachedNews []models.News
+		switch err := r.cache.Get(ctx, cacheKey, &cachedNews); {
+		case err == nil:
+			return cachedNews, nil
+		case !errors.Is(err, cache.ErrNotFound):
+			log.Printf("Ошибка чтения кэша %s: %v", cacheKey, err)
+		}
+	}
+
+	// Ищем в базе данных
+	pattern := "%" + keyword + "%"
+	conditions, args := newsFilterConditions(filter, []any{pattern, keyword})
+	news, err := r.queryNews(ctx,
+		`SELECT `+newsColumnsFor(detail)+` FROM news
+		WHERE (title `+r.dialect.ILike+` $1 OR description `+r.dialect.ILike+` $1
+			OR content `+r.dialect.ILike+` $1 OR `+r.dialect.ArrayContains("tags", "$2")+`)`+conditions+`
+		ORDER BY published_at DESC`,
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Если нашли новости в базе, возвращаем их
+	if len(news) > 0 {
+		if r.useCache {
+			r.cache.Set(ctx, cacheKey, news, r.cacheExpiry)
+		}
+		return news, nil
+	}
+
+	// Если не нашли в базе, делаем запрос к NewsAPI
+	return r.fetchNewsByKeywordFromAPI(ctx, keyword, filter)
+}
+
+// GetNewsByTicker возвращает новости, связанные с указанным тикером
+func (r *SQLNewsRepository) GetNewsByTicker(ctx context.Context, ticker string) ([]models.News, error) {
+	if ticker == "" {
+		return nil, fmt.Errorf("тикер не может быть пустым")
+	}
+
+	detail := repositories.NewsDetailFromContext(ctx)
+	cacheKey := models.NewsTickerCacheKey(ticker) + detail.CacheKey()
+
+	// Проверяем кэш, если включено использование кэша
+	if r.useCache {
+		var cachedNews []models.News
+		switch err := r.cache.Get(ctx, cacheKey, &cachedNews); {
+		case err == nil:
+			return cachedNews, nil
+		case !errors.Is(err, cache.ErrNotFound):
+			log.Printf("Ошибка чтения кэша %s: %v", cacheKey, err)
+		}
+	}
+
+	// Ищем в базе данных по связанным тикерам и тексту новости
+	pattern := "%" + ticker + "%"
+	news, err := r.queryNews(ctx,
+		`SELECT `+newsColumnsFor(detail)+` FROM news
+		WHERE `+r.dialect.ArrayContains("related_to", "$1")+` OR title `+r.dialect.ILike+` $2
+			OR description `+r.dialect.ILike+` $2 OR content `+r.dialect.ILike+` $2
+		ORDER BY published_at DESC`,
+		ticker, pattern,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Если нашли новости в базе, возвращаем их
+	if len(news) > 0 {
+		if r.useCache {
+			r.cache.Set(ctx, cacheKey, news, r.cacheExpiry)
+		}
+		return news, nil
+	}
+
+	// Если не нашли в базе, делаем запрос к NewsAPI по ключевому слову (тикеру)
+	return r.fetchNewsByKeywordFromAPI(ctx, ticker, models.NewsFilter{})
+}
+
+// FindRelatedNews возвращает новости периода с общими тикерами или тегами
+func (r *SQLNewsRepository) FindRelatedNews(ctx context.Context, news models.News, from, to time.Time, limit int) ([]models.News, error) {
+	args := []any{news.ID, from.UTC(), to.UTC()}
+	var terms []string
+	for _, ticker := range news.RelatedTo {
+		args = append(args, ticker)
+		terms = append(terms, r.dialect.ArrayContains("related_to", fmt.Sprintf("$%d", len(args))))
+	}
+	for _, tag := range news.Tags {
+		args = append(args, tag)
+		terms = append(terms, r.dialect.ArrayContains("tags", fmt.Sprintf("$%d", len(args))))
+	}
+	if len(terms) == 0 {
+		return nil, nil
+	}
+	args = append(args, limit)
+
+	return r.queryNews(ctx,
+		`SELECT `+newsColumnsFor(models.NewsDetailSummary)+` FROM news
+		WHERE id <> $1 AND published_at >= $2 AND published_at < $3 AND (`+strings.Join(terms, " OR ")+`)
+		ORDER BY published_at DESC
+		LIMIT `+fmt.Sprintf("$%d", len(args)),
+		args...,
+	)
+}
+
+// GetNewsTimeline возвращает число новостей тикера и свежие заголовки по дням периода.
+// Функции работы с датами в PostgreSQL и SQLite различаются, поэтому новости группируются по дням здесь
+func (r *SQLNewsRepository) GetNewsTimeline(ctx context.Context, ticker string, from, to time.Time, headlines int) ([]models.NewsDay, error) {
+	if ticker == "" {
+		return nil, fmt.Errorf("тикер не может быть пустым")
+	}
+
+	news, err := r.queryNews(ctx,
+		`SELECT `+newsColumnsFor(models.NewsDetailHeadline)+` FROM news
+		WHERE `+r.dialect.ArrayContains("related_to", "$1")+` AND published_at >= $2 AND published_at < $3
+		ORDER BY published_at DESC`,
+		ticker, from.UTC(), to.UTC(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var days []models.NewsDay
+	index := make(map[time.Time]int)
+	for _, item := range news {
+		published := item.PublishedAt.In(models.MoscowLocation)
+		date := time.Date(published.Year(), published.Month(), published.Day(), 0, 0, 0, 0, models.MoscowLocation)
+		i, ok := index[date]
+		if !ok {
+			i = len(days)
+			index[date] = i
+			days = append(days, models.NewsDay{Date: date})
+		}
+		days[i].Count++
+		if len(days[i].Headlines) < headlines {
+			days[i].Headlines = append(days[i].Headlines, item)
+		}
+	}
+
+	sort.Slice(days, func(i, j int) bool {
+		return days[i].Date.Before(days[j].Date)
+	})
+	return days, nil
+}
+
+// SaveNews сохраняет новость
+func (r *SQLNewsRepository) SaveNews(ctx context.Context, news *models.News) error {
+	if news == nil {
+		return fmt.Errorf("новость не может быть nil")
+	}
+
+	if err := r.upsertNews(ctx, r.db, news); err != nil {
+		return err
+	}
+
+	// Обновляем кэш
+	if r.useCache {
+		cacheKey := models.NewsCacheKey(news.ID)
+		r.cache.Set(ctx, cacheKey, news, r.cacheExpiry)
+	}
+
+	return nil
+}
+
+// SaveNewsCollection сохраняет набор новостей в одной транзакции
+func (r *SQLNewsRepository) SaveNewsCollection(ctx context.Context, newsCollection []models.News) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("ошибка открытия транзакции: %w", err)
+	}
+
+	for i := range newsCollection {
+		if err := r.upsertNews(ctx, tx, &newsCollection[i]); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("ошибка фиксации транзакции: %w", err)
+	}
+
+	return nil
+}
+
+// BackfillNews загружает из NewsAPI архив новостей за период [from, to] и сохраняет его в базу
+func (r *SQLNewsRepository) BackfillNews(ctx context.Context, from, to time.Time) (*models.NewsBackfill, error) {
+	return backfillNews(ctx, r.newsAPI, r.cache, from, to, r.SaveNewsCollection)
+}
+
+// Вспомогательные методы
+
+// queryNews выполняет запрос и декодирует найденные новости
+func (r *SQLNewsRepository) queryNews(ctx context.Context, query string, args ...any) ([]models.News, error) {
+	defer timing.Track(ctx, timing.StageDB)()
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка поиска в базе данных: %w", err)
+	}
+	defer rows.Close()
+
+	var news []models.News
+	for rows.Next() {
+		item, err := r.scanNews(rows)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка декодирования результатов: %w", err)
+		}
+		news = append(news, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка декодирования результатов: %w", err)
+	}
+
+	return news, nil
+}
+
+// fetchNewsByKeywordFromAPI получает новости по ключевому слову из NewsAPI
+func (r *SQLNewsRepository) fetchNewsByKeywordFromAPI(ctx context.Context, keyword string, filter models.NewsFilter) ([]models.News, error) {
+	news, err := r.newsAPI.GetNewsByKeyword(ctx, keyword, filter)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения данных из NewsAPI: %w", err)
+	}
+
+	r.storeFetched(ctx, models.NewsKeywordCacheKey(keyword, filter), news)
+	return repositories.NewsDetailFromContext(ctx).Strip(news), nil
+}
+
+// storeFetched сохраняет полученные из API новости в базу данных и кэш
+func (r *SQLNewsRepository) storeFetched(ctx context.Context, cacheKey string, news []models.News) {
+	for i := range news {
+		if err := r.SaveNews(ctx, &news[i]); err != nil {
+			log.Printf("Ошибка сохранения новости %s: %v", news[i].ID, err)
+		}
+	}
+
+	if r.useCache && len(news) > 0 {
+		if err := r.cache.Set(ctx, cacheKey, news, r.cacheExpiry); err != nil {
+			log.Printf("Ошибка кэширования новостей (%s): %v", cacheKey, err)
+		}
+	}
+}
+
+// upsertNews вставляет или обновляет новость
+func (r *SQLNewsRepository) upsertNews(ctx context.Context, db sqlExecer, news *models.News) error {
+	defer timing.Track(ctx, timing.StageDB)()
+
+	_, err := db.ExecContext(ctx,
+		`INSERT INTO news (`+newsColumns+`) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		ON CONFLICT (id) DO UPDATE SET
+			title = EXCLUDED.title,
+			description = EXCLUDED.description,
+			content = EXCLUDED.content,
+			url = EXCLUDED.url,
+			source = EXCLUDED.source,
+			published_at = EXCLUDED.published_at,
+			tags = EXCLUDED.tags,
+			related_to = EXCLUDED.related_to,
+			language = EXCLUDED.language,
+			summary = EXCLUDED.summary`,
+		news.ID, news.Title, news.Description, news.Content, news.URL, news.Source,
+		news.PublishedAt.UTC(), news.CreatedAt.UTC(), r.dialect.ArrayValue(news.Tags), r.dialect.ArrayValue(news.RelatedTo),
+		newsLanguage(news), news.Summary,
+	)
+	if err != nil {
+		return fmt.Errorf("ошибка сохранения в базу данных: %w", err)
+	}
+	return nil
+}
+
+// scanNews читает строку таблицы news
+func (r *SQLNewsRepository) scanNews(row rowScanner) (models.News, error) {
+	var news models.News
+	err := row.Scan(&news.ID, &news.Title, &news.Description, &news.Content, &news.URL,
+		&news.Source, &news.PublishedAt, &news.CreatedAt,
+		r.dialect.ArrayScan(&news.Tags), r.dialect.ArrayScan(&news.RelatedTo), &news.Language, &news.Summary)
+	return news, err
+}
+
+// newsFilterConditions дополняет условие WHERE ограничениями фильтра новостей.
+// Плейсхолдеры нумеруются после уже переданных аргументов args.
+func newsFilterConditions(filter models.NewsFilter, args []any) (string, []any) {
+	conditions := ""
+	next := func(value any) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if !filter.From.IsZero() {
+		conditions += " AND published_at >= " + next(filter.From.UTC())
+	}
+	if !filter.To.IsZero() {
+		conditions += " AND published_at < " + next(filter.To.UTC())
+	}
+
+	// Издания сравниваются без учета регистра: в фильтре могут быть и идентификаторы, и названия
+	if len(filter.Sources) > 0 {
+		placeholders := make([]string, 0, len(filter.Sources))
+		for _, source := range filter.Sources {
+			placeholders = append(placeholders, next(strings.ToLower(source)))
+		}
+		conditions += " AND lower(source) IN (" + strings.Join(placeholders, ", ") + ")"
+	}
+
+	conditions += " AND language = " + next(filter.Lang())
+
+	return conditions, args
+}
+
+// newsLanguage возвращает язык новости или язык по умолчанию
+func newsLanguage(news *models.News) string {
+	if news.Language == "" {
+		return models.DefaultNewsLanguage
+	}
+	return news.Language
+}
@@ -0,0 +1,117 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/repositories"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// PortfolioRepositoryImpl реализация интерфейса PortfolioRepository на MongoDB
+type PortfolioRepositoryImpl struct {
+	db     *mongo.Collection
+	trades *mongo.Collection
+}
+
+// NewPortfolioRepository создает новый экземпляр репозитория портфелей
+func NewPortfolioRepository(db *mongo.Database) repositories.PortfolioRepository {
+	return &PortfolioRepositoryImpl{
+		db:     db.Collection("portfolio"),
+		trades: db.Collection("portfolio_trades"),
+	}
+}
+
+// GetPositions возвращает все позиции портфеля
+func (r *PortfolioRepositoryImpl) GetPositions(ctx context.Context, portfolio string) ([]models.Position, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "ticker", Value: 1}})
+	cursor, err := r.db.Find(ctx, bson.M{"portfolio": portfolio}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка поиска в базе данных: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var positions []models.Position
+	if err = cursor.All(ctx, &positions); err != nil {
+		return nil, fmt.Errorf("ошибка декодирования результатов: %w", err)
+	}
+
+	return positions, nil
+}
+
+// GetPosition возвращает позицию портфеля по тикеру или nil, если ее нет
+func (r *PortfolioRepositoryImpl) GetPosition(ctx context.Context, portfolio, ticker string) (*models.Position, error) {
+	var position models.Position
+	err := r.db.FindOne(ctx, bson.M{"portfolio": portfolio, "ticker": ticker}).Decode(&position)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ошибка поиска в базе данных: %w", err)
+	}
+
+	return &position, nil
+}
+
+// SavePosition сохраняет позицию
+func (r *PortfolioRepositoryImpl) SavePosition(ctx context.Context, position *models.Position) error {
+	filter := bson.M{"portfolio": position.Portfolio, "ticker": position.Ticker}
+	_, err := r.db.ReplaceOne(ctx, filter, position, options.Replace().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("ошибка сохранения позиции: %w", err)
+	}
+
+	return nil
+}
+
+// DeletePosition удаляет позицию
+func (r *PortfolioRepositoryImpl) DeletePosition(ctx context.Context, portfolio, ticker string) error {
+	_, err := r.db.DeleteOne(ctx, bson.M{"portfolio": portfolio, "ticker": ticker})
+	if err != nil {
+		return fmt.Errorf("ошибка удаления позиции: %w", err)
+	}
+
+	return nil
+}
+
+// SaveTrade сохраняет сделку в историю портфеля; сделке без идентификатора он присваивается
+func (r *PortfolioRepositoryImpl) SaveTrade(ctx context.Context, trade *models.PortfolioTrade) error {
+	if trade.ID == "" {
+		trade.ID = primitive.NewObjectID().Hex()
+	}
+
+	_, err := r.trades.ReplaceOne(ctx, bson.M{"_id": trade.ID}, trade, options.Replace().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("ошибка сохранения сделки: %w", err)
+	}
+
+	return nil
+}
+
+// GetTrades возвращает сделки портфеля по возрастанию времени исполнения; пустой тикер — сделки по всем бумагам
+func (r *PortfolioRepositoryImpl) GetTrades(ctx context.Context, portfolio, ticker string) ([]models.PortfolioTrade, error) {
+	filter := bson.M{"portfolio": portfolio}
+	if ticker != "" {
+		filter["ticker"] = ticker
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "executed_at", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := r.trades.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка поиска в базе данных: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var trades []models.PortfolioTrade
+	if err = cursor.All(ctx, &trades); err != nil {
+		return nil, fmt.Errorf("ошибка декодирования результатов: %w", err)
+	}
+
+	return trades, nil
+}
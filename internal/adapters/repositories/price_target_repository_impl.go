@@ -0,0 +1,92 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/repositories"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// PriceTargetRepositoryImpl реализация интерфейса PriceTargetRepository на MongoDB
+type PriceTargetRepositoryImpl struct {
+	db *mongo.Collection
+}
+
+// NewPriceTargetRepository создает новый экземпляр репозитория целевых цен
+func NewPriceTargetRepository(db *mongo.Database) repositories.PriceTargetRepository {
+	return &PriceTargetRepositoryImpl{
+		db: db.Collection("price_targets"),
+	}
+}
+
+// GetTargets возвращает целевые цены бумаги от новых к старым; пустой тикер — цели всех бумаг
+func (r *PriceTargetRepositoryImpl) GetTargets(ctx context.Context, ticker string) ([]models.PriceTarget, error) {
+	filter := bson.M{}
+	if ticker != "" {
+		filter["ticker"] = ticker
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "ticker", Value: 1}, {Key: "set_at", Value: -1}})
+	cursor, err := r.db.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка поиска в базе данных: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var targets []models.PriceTarget
+	if err = cursor.All(ctx, &targets); err != nil {
+		return nil, fmt.Errorf("ошибка декодирования результатов: %w", err)
+	}
+
+	return targets, nil
+}
+
+// GetTarget возвращает цель бумаги от источника и аналитика или nil, если ее нет
+func (r *PriceTargetRepositoryImpl) GetTarget(ctx context.Context, ticker, source, analyst string) (*models.PriceTarget, error) {
+	var target models.PriceTarget
+	err := r.db.FindOne(ctx, bson.M{"ticker": ticker, "source": source, "analyst": analyst}).Decode(&target)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ошибка поиска в базе данных: %w", err)
+	}
+
+	return &target, nil
+}
+
+// SaveTargets сохраняет цели, заменяя прежние цели тех же бумаг, источников и аналитиков
+func (r *PriceTargetRepositoryImpl) SaveTargets(ctx context.Context, targets []models.PriceTarget) (int, error) {
+	if len(targets) == 0 {
+		return 0, nil
+	}
+
+	writes := make([]mongo.WriteModel, 0, len(targets))
+	for _, target := range targets {
+		filter := bson.M{"ticker": target.Ticker, "source": target.Source, "analyst": target.Analyst}
+		writes = append(writes, mongo.NewReplaceOneModel().SetFilter(filter).SetReplacement(target).SetUpsert(true))
+	}
+
+	result, err := r.db.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		return 0, fmt.Errorf("ошибка сохранения целевых цен: %w", err)
+	}
+
+	return int(result.UpsertedCount + result.ModifiedCount), nil
+}
+
+// DeleteTarget удаляет цель бумаги от источника и аналитика
+func (r *PriceTargetRepositoryImpl) DeleteTarget(ctx context.Context, ticker, source, analyst string) error {
+	_, err := r.db.DeleteOne(ctx, bson.M{"ticker": ticker, "source": source, "analyst": analyst})
+	if err != nil {
+		return fmt.Errorf("ошибка удаления целевой цены: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/repositories"
+)
+
+// SecurityRepositoryFile реализация интерфейса SecurityRepository в JSON-файле.
+// Справочник не зависит от базы данных, поэтому поиск бумаг работает и без MongoDB и PostgreSQL
+type SecurityRepositoryFile struct {
+	path string
+}
+
+// NewSecurityRepositoryFile создает новый экземпляр хранилища справочника бумаг в файле path
+func NewSecurityRepositoryFile(path string) repositories.SecurityRepository {
+	return &SecurityRepositoryFile{path: path}
+}
+
+// GetSecuritySnapshot читает справочник из файла; nil, если файла еще нет
+func (r *SecurityRepositoryFile) GetSecuritySnapshot(ctx context.Context) (*models.SecuritySnapshot, error) {
+	data, err := os.ReadFile(r.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("не удалось прочитать справочник бумаг: %w", err)
+	}
+
+	var snapshot models.SecuritySnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("ошибка при разборе справочника бумаг %s: %w", r.path, err)
+	}
+
+	return &snapshot, nil
+}
+
+// SaveSecuritySnapshot записывает справочник в файл
+func (r *SecurityRepositoryFile) SaveSecuritySnapshot(ctx context.Context, snapshot *models.SecuritySnapshot) error {
+	dir := filepath.Dir(r.path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("не удалось создать каталог справочника бумаг: %w", err)
+	}
+
+	// Пишем во временный файл и переименовываем, чтобы при сбое не остался неполный справочник
+	tmp, err := os.CreateTemp(dir, ".securities-*")
+	if err != nil {
+		return fmt.Errorf("не удалось создать файл справочника бумаг: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := json.NewEncoder(tmp).Encode(snapshot); err != nil {
+		tmp.Close()
+		return fmt.Errorf("ошибка записи справочника бумаг: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("ошибка записи справочника бумаг: %w", err)
+	}
+
+	return os.Rename(tmp.Name(), r.path)
+}
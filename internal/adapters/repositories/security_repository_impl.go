@@ -0,0 +1,89 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/repositories"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// securityDocument бумага справочника с временем загрузки
+type securityDocument struct {
+	models.Security `bson:",inline"`
+	UpdatedAt       time.Time `bson:"updated_at"`
+}
+
+// SecurityRepositoryImpl реализация интерфейса SecurityRepository на MongoDB: по документу на бумагу
+// в коллекции securities, чтобы справочник был доступен и другим сервисам с этой базой
+type SecurityRepositoryImpl struct {
+	db *mongo.Collection
+}
+
+// NewSecurityRepository создает новый экземпляр хранилища справочника бумаг
+func NewSecurityRepository(db *mongo.Database) repositories.SecurityRepository {
+	return &SecurityRepositoryImpl{
+		db: db.Collection("securities"),
+	}
+}
+
+// GetSecuritySnapshot читает справочник из коллекции; nil, если он еще не загружался
+func (r *SecurityRepositoryImpl) GetSecuritySnapshot(ctx context.Context) (*models.SecuritySnapshot, error) {
+	cursor, err := r.db.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("ошибка чтения справочника бумаг: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var documents []securityDocument
+	if err := cursor.All(ctx, &documents); err != nil {
+		return nil, fmt.Errorf("ошибка при декодировании справочника бумаг: %w", err)
+	}
+	if len(documents) == 0 {
+		return nil, nil
+	}
+
+	// Свежесть справочника определяет самая старая бумага: после полной загрузки у всех бумаг одно время
+	snapshot := &models.SecuritySnapshot{
+		UpdatedAt:  documents[0].UpdatedAt,
+		Securities: make([]models.Security, len(documents)),
+	}
+	for i, document := range documents {
+		snapshot.Securities[i] = document.Security
+		if document.UpdatedAt.Before(snapshot.UpdatedAt) {
+			snapshot.UpdatedAt = document.UpdatedAt
+		}
+	}
+
+	return snapshot, nil
+}
+
+// SaveSecuritySnapshot заменяет бумаги справочника и удаляет бумаги, которых в нем больше нет
+func (r *SecurityRepositoryImpl) SaveSecuritySnapshot(ctx context.Context, snapshot *models.SecuritySnapshot) error {
+	if len(snapshot.Securities) == 0 {
+		return nil
+	}
+
+	writes := make([]mongo.WriteModel, 0, len(snapshot.Securities))
+	for _, security := range snapshot.Securities {
+		writes = append(writes, mongo.NewReplaceOneModel().
+			SetFilter(bson.M{"_id": security.Ticker}).
+			SetReplacement(securityDocument{Security: security, UpdatedAt: snapshot.UpdatedAt}).
+			SetUpsert(true))
+	}
+	if _, err := r.db.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false)); err != nil {
+		return fmt.Errorf("ошибка сохранения справочника бумаг: %w", err)
+	}
+
+	// Бумаги, исключенные из списка торгуемых, не обновились при загрузке
+	if _, err := r.db.DeleteMany(ctx, bson.M{"updated_at": bson.M{"$lt": snapshot.UpdatedAt}}); err != nil {
+		return fmt.Errorf("ошибка удаления исключенных бумаг из справочника: %w", err)
+	}
+
+	return nil
+}
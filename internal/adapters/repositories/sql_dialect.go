@@ -0,0 +1,77 @@
+package repositories
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+// SQLDialect описывает различия SQL-хранилищ, которые поддерживают SQL-репозитории.
+// Запросы используют плейсхолдеры вида $1, которые понимают и PostgreSQL, и SQLite.
+type SQLDialect struct {
+	// Name название диалекта для логов
+	Name string
+	// ILike оператор регистронезависимого сравнения с шаблоном
+	ILike string
+	// ArrayContains возвращает условие "массив в колонке column содержит значение param"
+	ArrayContains func(column, param string) string
+	// ArrayValue преобразует список строк в значение для записи в колонку-массив
+	ArrayValue func(values []string) any
+	// ArrayScan возвращает приемник для чтения колонки-массива
+	ArrayScan func(dest *[]string) any
+}
+
+// PostgresDialect диалект PostgreSQL: массивы хранятся в колонках TEXT[]
+var PostgresDialect = SQLDialect{
+	Name:  "postgres",
+	ILike: "ILIKE",
+	ArrayContains: func(column, param string) string {
+		return fmt.Sprintf("%s = ANY(%s)", param, column)
+	},
+	ArrayValue: func(values []string) any { return pq.Array(values) },
+	ArrayScan:  func(dest *[]string) any { return pq.Array(dest) },
+}
+
+// SQLiteDialect диалект SQLite: массивы хранятся как JSON-строки.
+// Оператор LIKE в SQLite не учитывает регистр только для латиницы.
+var SQLiteDialect = SQLDialect{
+	Name:  "sqlite",
+	ILike: "LIKE",
+	ArrayContains: func(column, param string) string {
+		return fmt.Sprintf("EXISTS (SELECT 1 FROM json_each(%s) WHERE json_each.value = %s)", column, param)
+	},
+	ArrayValue: func(values []string) any { return jsonStringArray(values) },
+	ArrayScan:  func(dest *[]string) any { return (*jsonStringArray)(dest) },
+}
+
+// jsonStringArray список строк, который хранится в базе как JSON-массив
+type jsonStringArray []string
+
+// Value реализует driver.Valuer
+func (a jsonStringArray) Value() (driver.Value, error) {
+	if a == nil {
+		return "[]", nil
+	}
+	data, err := json.Marshal([]string(a))
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan реализует sql.Scanner
+func (a *jsonStringArray) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*a = nil
+		return nil
+	case string:
+		return json.Unmarshal([]byte(v), (*[]string)(a))
+	case []byte:
+		return json.Unmarshal(v, (*[]string)(a))
+	default:
+		return fmt.Errorf("неподдерживаемый тип для JSON-массива: %T", src)
+	}
+}
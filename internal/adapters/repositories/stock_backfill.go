@@ -0,0 +1,153 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/repositories"
+)
+
+// historyBackfillChunkDays период одного запроса дневных свечей при загрузке истории: после каждого
+// запроса свечи и ход загрузки сохраняются, поэтому прерванная загрузка теряет не больше одного запроса
+const historyBackfillChunkDays = 365
+
+// historyProgressStore хранилище хода загрузки истории; у MongoDB и SQL оно свое
+type historyProgressStore interface {
+	// loadHistoryProgress возвращает ход загрузки истории тикера; nil, если история еще не загружалась
+	loadHistoryProgress(ctx context.Context, ticker string) (*models.HistoryProgress, error)
+	// saveHistoryProgress сохраняет ход загрузки истории тикера
+	saveHistoryProgress(ctx context.Context, progress *models.HistoryProgress) error
+}
+
+// backfillHistory загружает с биржи дневные свечи тикера за период [from, to], пропуская уже загруженный период.
+// Дни после него загружаются от старых к новым, дни до него — от новых к старым, чтобы загруженный период
+// после каждого запроса оставался непрерывным и его можно было запомнить двумя датами
+func backfillHistory(
+	ctx context.Context,
+	exchange repositories.ExchangeClient,
+	store historyProgressStore,
+	ticker string,
+	from, to time.Time,
+	save func(ctx context.Context, quotes []models.StockQuote) error,
+) (*models.TickerHistoryBackfill, error) {
+	from, to = tradingDay(from), tradingDay(to)
+	if to.Before(from) {
+		return nil, fmt.Errorf("начало периода должно быть не позже его конца")
+	}
+
+	result := &models.TickerHistoryBackfill{Ticker: ticker}
+	progress, err := store.loadHistoryProgress(ctx, ticker)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось прочитать ход загрузки истории %s: %w", ticker, err)
+	}
+	if progress != nil {
+		result.Resumed = true
+	} else {
+		// Пустой загруженный период сразу после to: загрузка пойдет от to к более ранним дням
+		progress = &models.HistoryProgress{Ticker: ticker, LoadedFrom: to.AddDate(0, 0, 1), LoadedTo: to}
+	}
+
+	// load загружает и сохраняет свечи за [start, end] и возвращает их число
+	load := func(start, end time.Time) (int, error) {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+
+		result.Requests++
+		candles, err := fetchDailyCandles(ctx, exchange, ticker, start, end)
+		if err != nil {
+			return 0, err
+		}
+		if err := save(ctx, candles); err != nil {
+			return 0, err
+		}
+		result.Saved += len(candles)
+
+		progress.UpdatedAt = time.Now()
+		return len(candles), nil
+	}
+
+	// Новые дни после загруженного периода
+	for progress.LoadedTo.Before(to) {
+		start := progress.LoadedTo.AddDate(0, 0, 1)
+		end := start.AddDate(0, 0, historyBackfillChunkDays-1)
+		if end.After(to) {
+			end = to
+		}
+		if _, err := load(start, end); err != nil {
+			return result, err
+		}
+		progress.LoadedTo = end
+		if err := store.saveHistoryProgress(ctx, progress); err != nil {
+			return result, fmt.Errorf("не удалось сохранить ход загрузки истории %s: %w", ticker, err)
+		}
+	}
+
+	// Ранние дни до загруженного периода
+	for progress.LoadedFrom.After(from) {
+		end := progress.LoadedFrom.AddDate(0, 0, -1)
+		start := end.AddDate(0, 0, -historyBackfillChunkDays+1)
+		if start.Before(from) {
+			start = from
+		}
+		count, err := load(start, end)
+		if err != nil {
+			return result, err
+		}
+		progress.LoadedFrom = start
+		// Целый год без торгов означает, что раньше бумага не торговалась: загружать дальше нечего
+		if count == 0 {
+			progress.LoadedFrom = from
+		}
+		if err := store.saveHistoryProgress(ctx, progress); err != nil {
+			return result, fmt.Errorf("не удалось сохранить ход загрузки истории %s: %w", ticker, err)
+		}
+	}
+
+	return result, nil
+}
+
+// fetchDailyCandles запрашивает у биржи дневные свечи тикера за даты торгов [from, to]
+func fetchDailyCandles(ctx context.Context, exchange repositories.ExchangeClient, ticker string, from, to time.Time) ([]models.StockQuote, error) {
+	from, to = tradingDay(from), tradingDay(to)
+
+	// Биржа отбирает свечи по московскому времени начала, поэтому границы задаются московскими сутками
+	candles, err := exchange.GetCandles(ctx, ticker, models.IntervalDay,
+		time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, models.MoscowLocation),
+		time.Date(to.Year(), to.Month(), to.Day(), 23, 59, 59, 0, models.MoscowLocation))
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения свечей %s за %s – %s: %w", ticker, from.Format("2006-01-02"), to.Format("2006-01-02"), err)
+	}
+
+	// Дневные котировки хранятся под датой торгов в UTC, как и котировки из других источников
+	for i := range candles {
+		candles[i].Interval = models.IntervalDay
+		candles[i].Date = tradingDay(candles[i].Date)
+	}
+	return candles, nil
+}
+
+// historyGapDays наибольший разрыв в днях между границей периода и сохраненными дневными свечами,
+// который объясняется выходными и праздниками. При большем разрыве свечи за период запрашиваются у биржи
+const historyGapDays = 10
+
+// coversPeriod сообщает, покрывают ли сохраненные дневные свечи (по возрастанию даты) период [from, to]
+func coversPeriod(history []models.StockQuote, from, to time.Time) bool {
+	if len(history) == 0 {
+		return false
+	}
+	first, last := history[0].Date, history[len(history)-1].Date
+	if now := tradingDay(time.Now()); to.After(now) {
+		to = now
+	}
+	return !first.After(tradingDay(from).AddDate(0, 0, historyGapDays)) &&
+		!last.Before(tradingDay(to).AddDate(0, 0, -historyGapDays))
+}
+
+// tradingDay возвращает дату торгов t (по московскому времени) в полночь UTC
+func tradingDay(t time.Time) time.Time {
+	t = t.In(models.MoscowLocation)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,25 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/repositories"
+)
+
+// fetchBoardStock запрашивает котировку бумаги в выбранном режиме торгов у биржи, если та поддерживает режимы.
+// Хранилища акций содержат котировки основного режима, поэтому котировки других режимов в них не сохраняются
+func fetchBoardStock(ctx context.Context, exchange repositories.ExchangeClient, ticker string, board models.TradingBoard) (*models.Stock, error) {
+	client, ok := exchange.(repositories.BoardExchangeClient)
+	if !ok {
+		return nil, fmt.Errorf("источник котировок не поддерживает выбор режима торгов")
+	}
+
+	stock, err := client.GetBoardStock(ctx, ticker, board)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения данных с биржи: %w", err)
+	}
+
+	return stock, nil
+}
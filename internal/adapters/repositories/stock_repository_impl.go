@@ -2,83 +2,124 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
 	"time"
 
-	"github.com/JkLondon/mcp-stocks-info-server/internal/adapters/repositories/apis"
 	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
 	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/repositories"
 	"github.com/JkLondon/mcp-stocks-info-server/pkg/cache"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/workpool"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+// defaultTickers список популярных российских тикеров, используемый,
+// когда в хранилище еще нет ни одной акции
+var defaultTickers = []string{
+	"SBER", "GAZP", "LKOH", "GMKN", "ROSN", "NVTK", "TATN",
+	"MTSS", "MGNT", "YNDX", "FIVE", "POLY", "ALRS", "VTBR",
+}
+
 // StockRepositoryImpl реализация интерфейса StockRepository
 type StockRepositoryImpl struct {
 	db          *mongo.Collection
+	progress    *mongo.Collection // Ход загрузки истории дневных свечей
 	cache       cache.Cache
-	moexAPI     *apis.MOEXAPIClient
+	exchange    repositories.ExchangeClient
+	pool        *workpool.Pool
 	cacheExpiry time.Duration
 	useCache    bool
+	// stocks кэш котировок акций: после cacheExpiry котировки еще staleTTL отдаются из кэша, пока обновляются в фоне
+	stocks   *cache.SoftCache
+	staleTTL time.Duration
 }
 
 // NewStockRepository создает новый экземпляр репозитория для работы с акциями
 func NewStockRepository(
 	db *mongo.Database,
-	cache cache.Cache,
-	moexAPI *apis.MOEXAPIClient,
+	c cache.Cache,
+	exchange repositories.ExchangeClient,
+	pool *workpool.Pool,
 	cacheExpiry time.Duration,
+	staleTTL time.Duration,
 	useCache bool,
 ) repositories.StockRepository {
 	return &StockRepositoryImpl{
 		db:          db.Collection("stocks"),
-		cache:       cache,
-		moexAPI:     moexAPI,
+		progress:    db.Collection("history_backfill"),
+		cache:       c,
+		exchange:    exchange,
+		pool:        pool,
 		cacheExpiry: cacheExpiry,
 		useCache:    useCache,
+		stocks:      cache.NewSoftCache(c),
+		staleTTL:    staleTTL,
 	}
 }
 
 // GetStock возвращает информацию об акции по тикеру
 func (r *StockRepositoryImpl) GetStock(ctx context.Context, ticker string) (*models.Stock, error) {
-	cacheKey := fmt.Sprintf("stock:%s", ticker)
-
-	// Проверяем кэш, если включено использование кэша
-	if r.useCache {
-		var cachedStock models.Stock
-		err := r.cache.Get(ctx, cacheKey, &cachedStock)
-		if err == nil && cachedStock.Ticker != "" {
-			return &cachedStock, nil
-		}
+	ticker = models.NormalizeTicker(ticker)
+	if !r.useCache {
+		return r.loadStock(ctx, ticker)
 	}
 
-	// Ищем в базе данных
 	var stock models.Stock
-	err := r.db.FindOne(ctx, bson.M{"ticker": ticker}).Decode(&stock)
-	if err == nil {
-		// Сохраняем в кэш
-		if r.useCache {
-			r.cache.Set(ctx, cacheKey, stock, r.cacheExpiry)
-		}
-		return &stock, nil
+	err := r.stocks.Fetch(ctx, models.StockCacheKey(ticker), &stock, r.cacheExpiry, r.cacheExpiry+r.staleTTL,
+		func(ctx context.Context) (interface{}, error) {
+			return r.loadStock(ctx, ticker)
+		})
+	if err != nil {
+		return nil, err
 	}
 
-	// Если не нашли в базе, делаем запрос к MOEX API
-	stock, err = r.fetchStockFromAPI(ctx, ticker)
+	return &stock, nil
+}
+
+// loadStock запрашивает котировку акции у биржи и сохраняет ее в базу.
+// Если биржа недоступна, возвращается последняя сохраненная котировка
+func (r *StockRepositoryImpl) loadStock(ctx context.Context, ticker string) (*models.Stock, error) {
+	stock, err := r.fetchStockFromAPI(ctx, ticker)
 	if err != nil {
+		var saved models.Stock
+		if dbErr := stockSchema.decodeOne(ctx, r.db, r.db.FindOne(ctx, bson.M{"ticker": ticker}), &saved); dbErr != nil {
+			return nil, err
+		}
+		log.Printf("Не удалось получить котировку %s с биржи, используем сохраненную: %v", ticker, err)
+		return &saved, nil
+	}
+
+	// Сохраняем в базу данных; кэш обновит вызывающий SoftCache
+	if err := r.upsertStocks(ctx, []models.Stock{stock}); err != nil {
 		return nil, err
 	}
 
-	// Сохраняем в базу данных
-	_, err = r.db.InsertOne(ctx, stock)
-	if err != nil {
-		return nil, fmt.Errorf("ошибка сохранения в базу данных: %w", err)
+	return &stock, nil
+}
+
+// GetBoardStock возвращает котировку бумаги в режиме торгов board. Котировки основного режима хранятся в базе,
+// котировки других режимов только кэшируются под ключом, включающим режим
+func (r *StockRepositoryImpl) GetBoardStock(ctx context.Context, ticker string, board models.TradingBoard) (*models.Stock, error) {
+	if board.IsZero() {
+		return r.GetStock(ctx, ticker)
 	}
 
-	// Сохраняем в кэш
-	if r.useCache {
-		r.cache.Set(ctx, cacheKey, stock, r.cacheExpiry)
+	ticker = models.NormalizeTicker(ticker)
+	if !r.useCache {
+		return fetchBoardStock(ctx, r.exchange, ticker, board)
+	}
+
+	var stock models.Stock
+	err := r.stocks.Fetch(ctx, models.BoardStockCacheKey(ticker, board), &stock, r.cacheExpiry, r.cacheExpiry+r.staleTTL,
+		func(ctx context.Context) (interface{}, error) {
+			return fetchBoardStock(ctx, r.exchange, ticker, board)
+		})
+	if err != nil {
+		return nil, err
 	}
 
 	return &stock, nil
@@ -91,40 +132,35 @@ func (r *StockRepositoryImpl) GetStocks(ctx context.Context, tickers []string) (
 		return r.getAllStocks(ctx)
 	}
 
-	var stocks []models.Stock
-	for _, ticker := range tickers {
+	// Котировки запрашиваются параллельно в пределах общего лимита пула
+	return workpool.Map(ctx, r.pool, tickers, func(ctx context.Context, ticker string) (models.Stock, error) {
 		stock, err := r.GetStock(ctx, ticker)
 		if err != nil {
-			return nil, fmt.Errorf("ошибка получения информации о %s: %w", ticker, err)
+			return models.Stock{}, fmt.Errorf("ошибка получения информации о %s: %w", ticker, err)
 		}
-		stocks = append(stocks, *stock)
-	}
-
-	return stocks, nil
+		return *stock, nil
+	})
 }
 
-// GetStockQuote возвращает детальные котировки акции за указанную дату
+// GetStockQuote возвращает дневную свечу акции за указанную дату; если ее нет в базе, свеча запрашивается у биржи
 func (r *StockRepositoryImpl) GetStockQuote(ctx context.Context, ticker string, date time.Time) (*models.StockQuote, error) {
-	cacheKey := fmt.Sprintf("stock_quote:%s:%s", ticker, date.Format("2006-01-02"))
+	ticker = models.NormalizeTicker(ticker)
+	cacheKey := quoteCacheKey(ticker, models.IntervalDay, date)
 
 	// Проверяем кэш, если включено использование кэша
 	if r.useCache {
 		var cachedQuote models.StockQuote
-		err := r.cache.Get(ctx, cacheKey, &cachedQuote)
-		if err == nil && cachedQuote.Ticker != "" {
+		switch err := r.cache.Get(ctx, cacheKey, &cachedQuote); {
+		case err == nil:
 			return &cachedQuote, nil
+		case !errors.Is(err, cache.ErrNotFound):
+			log.Printf("Ошибка чтения кэша %s: %v", cacheKey, err)
 		}
 	}
 
 	// Ищем в базе данных
 	var quote models.StockQuote
-	err := r.db.FindOne(ctx, bson.M{
-		"ticker": ticker,
-		"date": bson.M{
-			"$gte": date.Truncate(24 * time.Hour),
-			"$lt":  date.Add(24 * time.Hour).Truncate(24 * time.Hour),
-		},
-	}).Decode(&quote)
+	err := stockQuoteSchema.decodeOne(ctx, r.db, r.db.FindOne(ctx, quoteFilter(ticker, models.IntervalDay, date)), &quote)
 	if err == nil {
 		// Сохраняем в кэш
 		if r.useCache {
@@ -133,32 +169,19 @@ func (r *StockRepositoryImpl) GetStockQuote(ctx context.Context, ticker string,
 		return &quote, nil
 	}
 
-	// Если не нашли в базе, делаем запрос к MOEX API
-	// Этот метод нужно реализовать в MOEX API клиенте
-	// Для примера просто создаем заглушку
-
-	// Заглушка для примера
-	quote = models.StockQuote{
-		Ticker: ticker,
-		Date:   date,
-	}
-
-	// Заполняем данными из обычной акции
-	stock, err := r.GetStock(ctx, ticker)
+	// Если не нашли в базе, запрашиваем дневную свечу у биржи
+	candles, err := fetchDailyCandles(ctx, r.exchange, ticker, date, date)
 	if err != nil {
 		return nil, err
 	}
-
-	quote.Open = stock.Price - stock.Change
-	quote.Close = stock.Price
-	quote.High = stock.Price + (stock.Change * 0.1)
-	quote.Low = stock.Price - (stock.Change * 0.1)
-	quote.Volume = stock.Volume
+	if len(candles) == 0 {
+		return nil, fmt.Errorf("нет торгов %s за %s", ticker, tradingDay(date).Format("2006-01-02"))
+	}
+	quote = candles[len(candles)-1]
 
 	// Сохраняем в базу данных
-	_, err = r.db.InsertOne(ctx, quote)
-	if err != nil {
-		return nil, fmt.Errorf("ошибка сохранения в базу данных: %w", err)
+	if err := r.SaveStockQuote(ctx, &quote); err != nil {
+		return nil, err
 	}
 
 	// Сохраняем в кэш
@@ -169,61 +192,54 @@ func (r *StockRepositoryImpl) GetStockQuote(ctx context.Context, ticker string,
 	return &quote, nil
 }
 
-// GetStockHistory возвращает исторические данные по акции за период
-func (r *StockRepositoryImpl) GetStockHistory(ctx context.Context, ticker string, startDate, endDate time.Time) ([]models.StockQuote, error) {
-	cacheKey := fmt.Sprintf("stock_history:%s:%s:%s", ticker, startDate.Format("2006-01-02"), endDate.Format("2006-01-02"))
+// GetStockHistory возвращает свечи акции с указанным интервалом за период.
+// Внутридневные свечи запрашиваются у биржи и сохраняются в базу; база используется, только если биржа недоступна.
+// Дневные свечи берутся из базы, а если они не покрывают период — запрашиваются у биржи и сохраняются
+func (r *StockRepositoryImpl) GetStockHistory(ctx context.Context, ticker, interval string, startDate, endDate time.Time) ([]models.StockQuote, error) {
+	ticker = models.NormalizeTicker(ticker)
+	cacheKey := historyCacheKey(ticker, interval, startDate, endDate)
 
 	// Проверяем кэш, если включено использование кэша
 	if r.useCache {
 		var cachedHistory []models.StockQuote
-		err := r.cache.Get(ctx, cacheKey, &cachedHistory)
-		if err == nil && len(cachedHistory) > 0 {
+		switch err := r.cache.Get(ctx, cacheKey, &cachedHistory); {
+		case err == nil:
 			return cachedHistory, nil
+		case !errors.Is(err, cache.ErrNotFound):
+			log.Printf("Ошибка чтения кэша %s: %v", cacheKey, err)
 		}
 	}
 
-	// Ищем в базе данных
-	cursor, err := r.db.Find(ctx, bson.M{
-		"ticker": ticker,
-		"date": bson.M{
-			"$gte": startDate.Truncate(24 * time.Hour),
-			"$lte": endDate.Add(24 * time.Hour).Truncate(24 * time.Hour),
-		},
-	})
-	if err != nil {
-		return nil, fmt.Errorf("ошибка поиска в базе данных: %w", err)
-	}
-	defer cursor.Close(ctx)
-
-	var history []models.StockQuote
-	if err = cursor.All(ctx, &history); err != nil {
-		return nil, fmt.Errorf("ошибка декодирования результатов: %w", err)
-	}
-
-	// Если нашли историю в базе, возвращаем ее
-	if len(history) > 0 {
-		// Сохраняем в кэш
-		if r.useCache {
+	if models.IsIntraday(interval) {
+		history, err := r.getIntradayHistory(ctx, ticker, interval, startDate, endDate)
+		if err != nil {
+			return nil, err
+		}
+		if r.useCache && len(history) > 0 {
 			r.cache.Set(ctx, cacheKey, history, r.cacheExpiry)
 		}
 		return history, nil
 	}
 
-	// Если не нашли в базе, делаем запрос к MOEX API
-	// Этот метод нужно реализовать в MOEX API клиенте
-	// Для примера просто создаем заглушку
+	// Ищем в базе данных
+	history, err := r.findHistory(ctx, ticker, interval, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
 
-	// Генерируем историю для примера
-	currentDate := startDate
-	for currentDate.Before(endDate) || currentDate.Equal(endDate) {
-		// Пропускаем выходные
-		if currentDate.Weekday() != time.Saturday && currentDate.Weekday() != time.Sunday {
-			quote, err := r.GetStockQuote(ctx, ticker, currentDate)
-			if err == nil {
-				history = append(history, *quote)
+	// Если сохраненные свечи не покрывают период, запрашиваем его у биржи. Неполная сохраненная история
+	// не возвращается вместо ответа биржи: выгрузки и расчеты по ней выглядели бы полными
+	if !coversPeriod(history, startDate, endDate) {
+		candles, err := fetchDailyCandles(ctx, r.exchange, ticker, startDate, endDate)
+		if err != nil {
+			return nil, err
+		}
+		if len(candles) > 0 {
+			if err := r.SaveStockQuotes(ctx, candles); err != nil {
+				log.Printf("Не удалось сохранить свечи %s: %v", ticker, err)
 			}
+			history = candles
 		}
-		currentDate = currentDate.Add(24 * time.Hour)
 	}
 
 	// Сохраняем в кэш
@@ -234,6 +250,35 @@ func (r *StockRepositoryImpl) GetStockHistory(ctx context.Context, ticker string
 	return history, nil
 }
 
+// GetStoredHistory возвращает сохраненные в базе свечи акции за период
+func (r *StockRepositoryImpl) GetStoredHistory(ctx context.Context, ticker, interval string, startDate, endDate time.Time) ([]models.StockQuote, error) {
+	return r.findHistory(ctx, models.NormalizeTicker(ticker), interval, startDate, endDate)
+}
+
+// BackfillHistory загружает с биржи дневные свечи тикера за период и сохраняет их
+func (r *StockRepositoryImpl) BackfillHistory(ctx context.Context, ticker string, from, to time.Time) (*models.TickerHistoryBackfill, error) {
+	return backfillHistory(ctx, r.exchange, r, models.NormalizeTicker(ticker), from, to, r.SaveStockQuotes)
+}
+
+// loadHistoryProgress возвращает ход загрузки истории тикера
+func (r *StockRepositoryImpl) loadHistoryProgress(ctx context.Context, ticker string) (*models.HistoryProgress, error) {
+	var progress models.HistoryProgress
+	err := r.progress.FindOne(ctx, bson.M{"_id": ticker}).Decode(&progress)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &progress, nil
+}
+
+// saveHistoryProgress сохраняет ход загрузки истории тикера
+func (r *StockRepositoryImpl) saveHistoryProgress(ctx context.Context, progress *models.HistoryProgress) error {
+	_, err := r.progress.ReplaceOne(ctx, bson.M{"_id": progress.Ticker}, progress, options.Replace().SetUpsert(true))
+	return err
+}
+
 // SaveStock сохраняет информацию об акции
 func (r *StockRepositoryImpl) SaveStock(ctx context.Context, stock *models.Stock) error {
 	if stock == nil {
@@ -242,6 +287,7 @@ func (r *StockRepositoryImpl) SaveStock(ctx context.Context, stock *models.Stock
 
 	// Обновляем время
 	stock.UpdatedAt = time.Now()
+	stock.SchemaVersion = models.StockSchemaVersion
 
 	// Ищем существующую акцию
 	var existingStock models.Stock
@@ -258,10 +304,12 @@ func (r *StockRepositoryImpl) SaveStock(ctx context.Context, stock *models.Stock
 		return fmt.Errorf("ошибка сохранения в базу данных: %w", err)
 	}
 
-	// Обновляем кэш
+	// Обновляем кэш акции и сбрасываем список всех акций, в котором осталась прежняя котировка
 	if r.useCache {
-		cacheKey := fmt.Sprintf("stock:%s", stock.Ticker)
-		r.cache.Set(ctx, cacheKey, stock, r.cacheExpiry)
+		r.stocks.Store(ctx, models.StockCacheKey(stock.Ticker), stock, r.cacheExpiry, r.cacheExpiry+r.staleTTL)
+		if err := r.cache.Delete(ctx, models.AllStocksCacheKey()); err != nil {
+			log.Printf("Ошибка сброса кэша списка акций: %v", err)
+		}
 	}
 
 	return nil
@@ -272,25 +320,17 @@ func (r *StockRepositoryImpl) SaveStockQuote(ctx context.Context, quote *models.
 	if quote == nil {
 		return fmt.Errorf("котировка не может быть nil")
 	}
+	quote.SchemaVersion = models.StockQuoteSchemaVersion
+	if quote.Interval == "" {
+		quote.Interval = models.IntervalDay
+	}
 
 	// Ищем существующую котировку
 	var existingQuote models.StockQuote
-	err := r.db.FindOne(ctx, bson.M{
-		"ticker": quote.Ticker,
-		"date": bson.M{
-			"$gte": quote.Date.Truncate(24 * time.Hour),
-			"$lt":  quote.Date.Add(24 * time.Hour).Truncate(24 * time.Hour),
-		},
-	}).Decode(&existingQuote)
+	err := r.db.FindOne(ctx, quoteFilter(quote.Ticker, quote.Interval, quote.Date)).Decode(&existingQuote)
 	if err == nil {
 		// Обновляем существующую
-		_, err = r.db.ReplaceOne(ctx, bson.M{
-			"ticker": quote.Ticker,
-			"date": bson.M{
-				"$gte": quote.Date.Truncate(24 * time.Hour),
-				"$lt":  quote.Date.Add(24 * time.Hour).Truncate(24 * time.Hour),
-			},
-		}, quote)
+		_, err = r.db.ReplaceOne(ctx, quoteFilter(quote.Ticker, quote.Interval, quote.Date), quote)
 	} else {
 		// Вставляем новую
 		_, err = r.db.InsertOne(ctx, quote)
@@ -302,39 +342,154 @@ func (r *StockRepositoryImpl) SaveStockQuote(ctx context.Context, quote *models.
 
 	// Обновляем кэш
 	if r.useCache {
-		cacheKey := fmt.Sprintf("stock_quote:%s:%s", quote.Ticker, quote.Date.Format("2006-01-02"))
-		r.cache.Set(ctx, cacheKey, quote, r.cacheExpiry)
+		r.cache.Set(ctx, quoteCacheKey(quote.Ticker, quote.Interval, quote.Date), quote, r.cacheExpiry)
 	}
 
 	return nil
 }
 
-// SaveStockQuotes сохраняет список котировок акций
+// SaveStockQuotes сохраняет список котировок акций одной пакетной операцией.
+// Котировки идентифицируются тикером, интервалом и датой; кэш не обновляется, он заполнится при чтении.
 func (r *StockRepositoryImpl) SaveStockQuotes(ctx context.Context, quotes []models.StockQuote) error {
+	if len(quotes) == 0 {
+		return nil
+	}
+
+	writes := make([]mongo.WriteModel, 0, len(quotes))
 	for _, quote := range quotes {
-		err := r.SaveStockQuote(ctx, &quote)
-		if err != nil {
-			return err
+		quote.SchemaVersion = models.StockQuoteSchemaVersion
+		if quote.Interval == "" {
+			quote.Interval = models.IntervalDay
 		}
+		writes = append(writes, mongo.NewReplaceOneModel().
+			SetFilter(quoteFilter(quote.Ticker, quote.Interval, quote.Date)).
+			SetReplacement(quote).
+			SetUpsert(true))
+	}
+
+	// Неупорядоченная запись продолжает обработку пакета при ошибке отдельного документа
+	_, err := r.db.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		return fmt.Errorf("ошибка пакетного сохранения котировок: %w", err)
 	}
+
 	return nil
 }
 
 // Вспомогательные методы
 
+// getIntradayHistory запрашивает внутридневные свечи у биржи и сохраняет их в базу.
+// Если биржа недоступна, возвращаются ранее сохраненные свечи
+func (r *StockRepositoryImpl) getIntradayHistory(ctx context.Context, ticker, interval string, startDate, endDate time.Time) ([]models.StockQuote, error) {
+	candles, err := r.exchange.GetCandles(ctx, ticker, interval, startDate, endDate)
+	if err != nil {
+		history, dbErr := r.findHistory(ctx, ticker, interval, startDate, endDate)
+		if dbErr != nil || len(history) == 0 {
+			return nil, fmt.Errorf("ошибка получения данных с биржи: %w", err)
+		}
+		log.Printf("Не удалось получить свечи %s (%s) с биржи, используем сохраненные: %v", ticker, interval, err)
+		return history, nil
+	}
+
+	if err := r.SaveStockQuotes(ctx, candles); err != nil {
+		log.Printf("Не удалось сохранить свечи %s (%s): %v", ticker, interval, err)
+	}
+
+	return candles, nil
+}
+
+// findHistory ищет в базе свечи акции с указанным интервалом за период
+func (r *StockRepositoryImpl) findHistory(ctx context.Context, ticker, interval string, startDate, endDate time.Time) ([]models.StockQuote, error) {
+	filter := bson.M{
+		"ticker":   ticker,
+		"interval": intervalFilter(interval),
+		"date": bson.M{
+			"$gte": startDate.Truncate(24 * time.Hour),
+			"$lte": endDate.Add(24 * time.Hour).Truncate(24 * time.Hour),
+		},
+	}
+	if models.IsIntraday(interval) {
+		filter["date"] = bson.M{"$gte": startDate, "$lte": endDate}
+	}
+
+	cursor, err := r.db.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "date", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("ошибка поиска в базе данных: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	history, err := decodeAll[models.StockQuote](ctx, stockQuoteSchema, r.db, cursor)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка декодирования результатов: %w", err)
+	}
+
+	return history, nil
+}
+
+// quoteCacheKey возвращает ключ кэша свечи; общий для реализаций репозитория на MongoDB и SQL
+func quoteCacheKey(ticker, interval string, date time.Time) string {
+	if models.IsIntraday(interval) {
+		return fmt.Sprintf("stock_quote:v%d:%s:%s:%s", models.StockQuoteSchemaVersion, ticker, interval, date.UTC().Format(time.RFC3339))
+	}
+	return fmt.Sprintf("stock_quote:v%d:%s:%s:%s", models.StockQuoteSchemaVersion, ticker, models.IntervalDay, date.Format("2006-01-02"))
+}
+
+// historyCacheKey возвращает ключ кэша истории свечей; внутридневной период учитывает время с точностью до минуты
+func historyCacheKey(ticker, interval string, startDate, endDate time.Time) string {
+	layout := "2006-01-02"
+	if models.IsIntraday(interval) {
+		layout = "2006-01-02T15:04"
+	} else {
+		interval = models.IntervalDay
+	}
+	return fmt.Sprintf("stock_history:v%d:%s:%s:%s:%s", models.StockQuoteSchemaVersion, ticker, interval, startDate.Format(layout), endDate.Format(layout))
+}
+
+// quoteFilter возвращает условие поиска свечи: дневная ищется по календарному дню, внутридневная — по времени начала
+func quoteFilter(ticker, interval string, date time.Time) bson.M {
+	if models.IsIntraday(interval) {
+		return bson.M{"ticker": ticker, "interval": interval, "date": date}
+	}
+	return bson.M{
+		"ticker":   ticker,
+		"interval": intervalFilter(interval),
+		"date": bson.M{
+			"$gte": date.Truncate(24 * time.Hour),
+			"$lt":  date.Add(24 * time.Hour).Truncate(24 * time.Hour),
+		},
+	}
+}
+
+// intervalFilter возвращает условие на интервал свечи.
+// Дневные котировки, сохраненные до появления интервала, поля interval не имеют, пока их не обновит миграция схемы
+func intervalFilter(interval string) interface{} {
+	if models.IsIntraday(interval) {
+		return interval
+	}
+	return bson.M{"$in": bson.A{models.IntervalDay, nil}}
+}
+
 // getAllStocks возвращает все акции
 func (r *StockRepositoryImpl) getAllStocks(ctx context.Context) ([]models.Stock, error) {
-	cacheKey := "all_stocks"
+	if !r.useCache {
+		return r.loadAllStocks(ctx)
+	}
 
-	// Проверяем кэш, если включено использование кэша
-	if r.useCache {
-		var cachedStocks []models.Stock
-		err := r.cache.Get(ctx, cacheKey, &cachedStocks)
-		if err == nil && len(cachedStocks) > 0 {
-			return cachedStocks, nil
-		}
+	var stocks []models.Stock
+	err := r.stocks.Fetch(ctx, models.AllStocksCacheKey(), &stocks, r.cacheExpiry, r.cacheExpiry+r.staleTTL,
+		func(ctx context.Context) (interface{}, error) {
+			return r.loadAllStocks(ctx)
+		})
+	if err != nil {
+		return nil, err
 	}
 
+	return stocks, nil
+}
+
+// loadAllStocks запрашивает у биржи котировки всех сохраненных акций (или популярных, если база пуста)
+// и сохраняет их. Если биржа недоступна, возвращаются сохраненные котировки
+func (r *StockRepositoryImpl) loadAllStocks(ctx context.Context) ([]models.Stock, error) {
 	// Ищем в базе данных
 	cursor, err := r.db.Find(ctx, bson.M{})
 	if err != nil {
@@ -342,61 +497,64 @@ func (r *StockRepositoryImpl) getAllStocks(ctx context.Context) ([]models.Stock,
 	}
 	defer cursor.Close(ctx)
 
-	var stocks []models.Stock
-	if err = cursor.All(ctx, &stocks); err != nil {
+	saved, err := decodeAll[models.Stock](ctx, stockSchema, r.db, cursor)
+	if err != nil {
 		return nil, fmt.Errorf("ошибка декодирования результатов: %w", err)
 	}
 
-	// Если нашли акции в базе, возвращаем их
-	if len(stocks) > 0 {
-		// Сохраняем в кэш
-		if r.useCache {
-			r.cache.Set(ctx, cacheKey, stocks, r.cacheExpiry)
+	tickers := defaultTickers
+	if len(saved) > 0 {
+		tickers = make([]string, len(saved))
+		for i, stock := range saved {
+			tickers[i] = stock.Ticker
 		}
-		return stocks, nil
 	}
 
-	// Если не нашли в базе, делаем запрос к MOEX API
-	// Этот метод нужно реализовать в MOEX API клиенте
-	stocks, err = r.fetchAllStocksFromAPI(ctx)
+	stocks, err := r.exchange.GetStocks(ctx, tickers)
 	if err != nil {
-		return nil, err
+		if len(saved) == 0 {
+			return nil, fmt.Errorf("ошибка получения данных с биржи: %w", err)
+		}
+		log.Printf("Не удалось получить котировки с биржи, используем сохраненные: %v", err)
+		return saved, nil
 	}
 
 	// Сохраняем в базу данных
-	for _, stock := range stocks {
-		_, err = r.db.InsertOne(ctx, stock)
-		if err != nil {
-			return nil, fmt.Errorf("ошибка сохранения в базу данных: %w", err)
-		}
+	if err := r.upsertStocks(ctx, stocks); err != nil {
+		return nil, err
 	}
 
-	// Сохраняем в кэш
-	if r.useCache && len(stocks) > 0 {
-		r.cache.Set(ctx, cacheKey, stocks, r.cacheExpiry)
+	return stocks, nil
+}
+
+// upsertStocks вставляет или обновляет записи об акциях, не трогая кэш
+func (r *StockRepositoryImpl) upsertStocks(ctx context.Context, stocks []models.Stock) error {
+	if len(stocks) == 0 {
+		return nil
 	}
 
-	return stocks, nil
+	writes := make([]mongo.WriteModel, 0, len(stocks))
+	for _, stock := range stocks {
+		stock.SchemaVersion = models.StockSchemaVersion
+		writes = append(writes, mongo.NewReplaceOneModel().
+			SetFilter(bson.M{"ticker": stock.Ticker}).
+			SetReplacement(stock).
+			SetUpsert(true))
+	}
+
+	if _, err := r.db.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false)); err != nil {
+		return fmt.Errorf("ошибка сохранения в базу данных: %w", err)
+	}
+	return nil
 }
 
 // fetchStockFromAPI получает информацию об акции из MOEX API
 func (r *StockRepositoryImpl) fetchStockFromAPI(ctx context.Context, ticker string) (models.Stock, error) {
 	// Делаем запрос к MOEX API
-	stockPtr, err := r.moexAPI.GetStock(ctx, ticker)
+	stockPtr, err := r.exchange.GetStock(ctx, ticker)
 	if err != nil {
-		return models.Stock{}, fmt.Errorf("ошибка получения данных из MOEX API: %w", err)
+		return models.Stock{}, fmt.Errorf("ошибка получения данных с биржи: %w", err)
 	}
 
 	return *stockPtr, nil
 }
-
-// fetchAllStocksFromAPI получает список всех акций из MOEX API
-func (r *StockRepositoryImpl) fetchAllStocksFromAPI(ctx context.Context) ([]models.Stock, error) {
-	// Список популярных российских тикеров
-	defaultTickers := []string{
-		"SBER", "GAZP", "LKOH", "GMKN", "ROSN", "NVTK", "TATN",
-		"MTSS", "MGNT", "YNDX", "FIVE", "POLY", "ALRS", "VTBR",
-	}
-
-	return r.moexAPI.GetStocks(ctx, defaultTickers)
-}
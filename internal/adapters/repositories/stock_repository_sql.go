@@ -0,0 +1,575 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/repositories"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/cache"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/timing"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/workpool"
+)
+
+const (
+	stockColumns = `ticker, name, price, change, change_perc, volume, updated_at`
+	quoteColumns = `ticker, candle_interval, date, open, high, low, close, volume, market_cap_bln, pe, dividend_yield, sector, trading_session`
+)
+
+// SQLStockRepository реализация интерфейса StockRepository на основе SQL-базы данных
+// (PostgreSQL или SQLite). Запросы к таблицам акций совпадают для обоих диалектов.
+type SQLStockRepository struct {
+	db          *sql.DB
+	cache       cache.Cache
+	exchange    repositories.ExchangeClient
+	pool        *workpool.Pool
+	cacheExpiry time.Duration
+	useCache    bool
+	// stocks кэш котировок акций: после cacheExpiry котировки еще staleTTL отдаются из кэша, пока обновляются в фоне
+	stocks   *cache.SoftCache
+	staleTTL time.Duration
+}
+
+// NewSQLStockRepository создает новый экземпляр репозитория акций на основе SQL-базы данных
+func NewSQLStockRepository(
+	db *sql.DB,
+	c cache.Cache,
+	exchange repositories.ExchangeClient,
+	pool *workpool.Pool,
+	cacheExpiry time.Duration,
+	staleTTL time.Duration,
+	useCache bool,
+) repositories.StockRepository {
+	return &SQLStockRepository{
+		db:          db,
+		cache:       c,
+		exchange:    exchange,
+		pool:        pool,
+		cacheExpiry: cacheExpiry,
+		useCache:    useCache,
+		stocks:      cache.NewSoftCache(c),
+		staleTTL:    staleTTL,
+	}
+}
+
+// GetStock возвращает информацию об акции по тикеру
+func (r *SQLStockRepository) GetStock(ctx context.Context, ticker string) (*models.Stock, error) {
+	ticker = models.NormalizeTicker(ticker)
+	if !r.useCache {
+		return r.loadStock(ctx, ticker)
+	}
+
+	var stock models.Stock
+	err := r.stocks.Fetch(ctx, models.StockCacheKey(ticker), &stock, r.cacheExpiry, r.cacheExpiry+r.staleTTL,
+		func(ctx context.Context) (interface{}, error) {
+			return r.loadStock(ctx, ticker)
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return &stock, nil
+}
+
+// loadStock запрашивает котировку акции у биржи и сохраняет ее в базу.
+// Если биржа недоступна, возвращается последняя сохраненная котировка
+func (r *SQLStockRepository) loadStock(ctx context.Context, ticker string) (*models.Stock, error) {
+	stock, err := r.exchange.GetStock(ctx, ticker)
+	if err != nil {
+		stop := timing.Track(ctx, timing.StageDB)
+		row := r.db.QueryRowContext(ctx, `SELECT `+stockColumns+` FROM stocks WHERE ticker = $1`, ticker)
+		saved, dbErr := scanStock(row)
+		stop()
+		if dbErr != nil {
+			return nil, fmt.Errorf("ошибка получения данных с биржи: %w", err)
+		}
+		log.Printf("Не удалось получить котировку %s с биржи, используем сохраненную: %v", ticker, err)
+		return &saved, nil
+	}
+
+	// Сохраняем в базу данных; кэш обновит вызывающий SoftCache
+	if err := r.upsertStock(ctx, stock); err != nil {
+		return nil, err
+	}
+
+	return stock, nil
+}
+
+// GetBoardStock возвращает котировку бумаги в режиме торгов board. Котировки основного режима хранятся в базе,
+// котировки других режимов только кэшируются под ключом, включающим режим
+func (r *SQLStockRepository) GetBoardStock(ctx context.Context, ticker string, board models.TradingBoard) (*models.Stock, error) {
+	if board.IsZero() {
+		return r.GetStock(ctx, ticker)
+	}
+
+	ticker = models.NormalizeTicker(ticker)
+	if !r.useCache {
+		return fetchBoardStock(ctx, r.exchange, ticker, board)
+	}
+
+	var stock models.Stock
+	err := r.stocks.Fetch(ctx, models.BoardStockCacheKey(ticker, board), &stock, r.cacheExpiry, r.cacheExpiry+r.staleTTL,
+		func(ctx context.Context) (interface{}, error) {
+			return fetchBoardStock(ctx, r.exchange, ticker, board)
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return &stock, nil
+}
+
+// GetStocks возвращает список акций по указанным тикерам
+func (r *SQLStockRepository) GetStocks(ctx context.Context, tickers []string) ([]models.Stock, error) {
+	if len(tickers) == 0 {
+		// Возвращаем все акции
+		return r.getAllStocks(ctx)
+	}
+
+	// Котировки запрашиваются параллельно в пределах общего лимита пула
+	return workpool.Map(ctx, r.pool, tickers, func(ctx context.Context, ticker string) (models.Stock, error) {
+		stock, err := r.GetStock(ctx, ticker)
+		if err != nil {
+			return models.Stock{}, fmt.Errorf("ошибка получения информации о %s: %w", ticker, err)
+		}
+		return *stock, nil
+	})
+}
+
+// GetStockQuote возвращает дневную свечу акции за указанную дату; если ее нет в базе, свеча запрашивается у биржи
+func (r *SQLStockRepository) GetStockQuote(ctx context.Context, ticker string, date time.Time) (*models.StockQuote, error) {
+	ticker = models.NormalizeTicker(ticker)
+	cacheKey := quoteCacheKey(ticker, models.IntervalDay, date)
+
+	// Проверяем кэш, если включено использование кэша
+	if r.useCache {
+		var cachedQuote models.StockQuote
+		switch err := r.cache.Get(ctx, cacheKey, &cachedQuote); {
+		case err == nil:
+			return &cachedQuote, nil
+		case !errors.Is(err, cache.ErrNotFound):
+			log.Printf("Ошибка чтения кэша %s: %v", cacheKey, err)
+		}
+	}
+
+	// Ищем в базе данных
+	stop := timing.Track(ctx, timing.StageDB)
+	row := r.db.QueryRowContext(ctx,
+		`SELECT `+quoteColumns+` FROM stock_quotes WHERE ticker = $1 AND candle_interval = $2 AND date = $3`,
+		ticker, models.IntervalDay, date.Format("2006-01-02"),
+	)
+	quote, err := scanQuote(row)
+	stop()
+	if err == nil {
+		// Сохраняем в кэш
+		if r.useCache {
+			r.cache.Set(ctx, cacheKey, quote, r.cacheExpiry)
+		}
+		return &quote, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("ошибка поиска в базе данных: %w", err)
+	}
+
+	// Если котировки нет в базе, запрашиваем дневную свечу у биржи
+	candles, err := fetchDailyCandles(ctx, r.exchange, ticker, date, date)
+	if err != nil {
+		return nil, err
+	}
+	if len(candles) == 0 {
+		return nil, fmt.Errorf("нет торгов %s за %s", ticker, tradingDay(date).Format("2006-01-02"))
+	}
+	quote = candles[len(candles)-1]
+
+	// Сохраняем в базу данных
+	if err := r.upsertQuote(ctx, r.db, &quote); err != nil {
+		return nil, err
+	}
+
+	// Сохраняем в кэш
+	if r.useCache {
+		r.cache.Set(ctx, cacheKey, quote, r.cacheExpiry)
+	}
+
+	return &quote, nil
+}
+
+// GetStockHistory возвращает свечи акции с указанным интервалом за период.
+// Внутридневные свечи запрашиваются у биржи и сохраняются в базу; база используется, только если биржа недоступна.
+// Дневные свечи берутся из базы, а если они не покрывают период — запрашиваются у биржи и сохраняются
+func (r *SQLStockRepository) GetStockHistory(ctx context.Context, ticker, interval string, startDate, endDate time.Time) ([]models.StockQuote, error) {
+	ticker = models.NormalizeTicker(ticker)
+	cacheKey := historyCacheKey(ticker, interval, startDate, endDate)
+
+	// Проверяем кэш, если включено использование кэша
+	if r.useCache {
+		var cachedHistory []models.StockQuote
+		switch err := r.cache.Get(ctx, cacheKey, &cachedHistory); {
+		case err == nil:
+			return cachedHistory, nil
+		case !errors.Is(err, cache.ErrNotFound):
+			log.Printf("Ошибка чтения кэша %s: %v", cacheKey, err)
+		}
+	}
+
+	if models.IsIntraday(interval) {
+		history, err := r.getIntradayHistory(ctx, ticker, interval, startDate, endDate)
+		if err != nil {
+			return nil, err
+		}
+		if r.useCache && len(history) > 0 {
+			r.cache.Set(ctx, cacheKey, history, r.cacheExpiry)
+		}
+		return history, nil
+	}
+
+	// Ищем в базе данных
+	history, err := r.findHistory(ctx, ticker, interval, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	// Если сохраненные свечи не покрывают период, запрашиваем его у биржи. Неполная сохраненная история
+	// не возвращается вместо ответа биржи: выгрузки и расчеты по ней выглядели бы полными
+	if !coversPeriod(history, startDate, endDate) {
+		candles, err := fetchDailyCandles(ctx, r.exchange, ticker, startDate, endDate)
+		if err != nil {
+			return nil, err
+		}
+		if len(candles) > 0 {
+			if err := r.SaveStockQuotes(ctx, candles); err != nil {
+				log.Printf("Не удалось сохранить свечи %s: %v", ticker, err)
+			}
+			history = candles
+		}
+	}
+
+	// Сохраняем в кэш
+	if r.useCache && len(history) > 0 {
+		r.cache.Set(ctx, cacheKey, history, r.cacheExpiry)
+	}
+
+	return history, nil
+}
+
+// GetStoredHistory возвращает сохраненные в базе свечи акции за период
+func (r *SQLStockRepository) GetStoredHistory(ctx context.Context, ticker, interval string, startDate, endDate time.Time) ([]models.StockQuote, error) {
+	return r.findHistory(ctx, models.NormalizeTicker(ticker), interval, startDate, endDate)
+}
+
+// BackfillHistory загружает с биржи дневные свечи тикера за период и сохраняет их
+func (r *SQLStockRepository) BackfillHistory(ctx context.Context, ticker string, from, to time.Time) (*models.TickerHistoryBackfill, error) {
+	return backfillHistory(ctx, r.exchange, r, models.NormalizeTicker(ticker), from, to, r.SaveStockQuotes)
+}
+
+// loadHistoryProgress возвращает ход загрузки истории тикера
+func (r *SQLStockRepository) loadHistoryProgress(ctx context.Context, ticker string) (*models.HistoryProgress, error) {
+	defer timing.Track(ctx, timing.StageDB)()
+
+	progress := models.HistoryProgress{Ticker: ticker}
+	err := r.db.QueryRowContext(ctx,
+		`SELECT loaded_from, loaded_to, updated_at FROM history_backfill WHERE ticker = $1`, ticker,
+	).Scan(&progress.LoadedFrom, &progress.LoadedTo, &progress.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &progress, nil
+}
+
+// saveHistoryProgress сохраняет ход загрузки истории тикера
+func (r *SQLStockRepository) saveHistoryProgress(ctx context.Context, progress *models.HistoryProgress) error {
+	defer timing.Track(ctx, timing.StageDB)()
+
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO history_backfill (ticker, loaded_from, loaded_to, updated_at) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (ticker) DO UPDATE SET
+			loaded_from = EXCLUDED.loaded_from,
+			loaded_to = EXCLUDED.loaded_to,
+			updated_at = EXCLUDED.updated_at`,
+		progress.Ticker, sqlQuoteDate(models.IntervalDay, progress.LoadedFrom), sqlQuoteDate(models.IntervalDay, progress.LoadedTo), progress.UpdatedAt,
+	)
+	return err
+}
+
+// SaveStock сохраняет информацию об акции
+func (r *SQLStockRepository) SaveStock(ctx context.Context, stock *models.Stock) error {
+	if stock == nil {
+		return fmt.Errorf("акция не может быть nil")
+	}
+
+	// Обновляем время
+	stock.UpdatedAt = time.Now()
+
+	if err := r.upsertStock(ctx, stock); err != nil {
+		return err
+	}
+
+	// Обновляем кэш акции и сбрасываем список всех акций, в котором осталась прежняя котировка
+	if r.useCache {
+		r.stocks.Store(ctx, models.StockCacheKey(stock.Ticker), stock, r.cacheExpiry, r.cacheExpiry+r.staleTTL)
+		if err := r.cache.Delete(ctx, models.AllStocksCacheKey()); err != nil {
+			log.Printf("Ошибка сброса кэша списка акций: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// SaveStockQuote сохраняет котировки акции
+func (r *SQLStockRepository) SaveStockQuote(ctx context.Context, quote *models.StockQuote) error {
+	if quote == nil {
+		return fmt.Errorf("котировка не может быть nil")
+	}
+
+	if err := r.upsertQuote(ctx, r.db, quote); err != nil {
+		return err
+	}
+
+	// Обновляем кэш
+	if r.useCache {
+		r.cache.Set(ctx, quoteCacheKey(quote.Ticker, quote.Interval, quote.Date), quote, r.cacheExpiry)
+	}
+
+	return nil
+}
+
+// SaveStockQuotes сохраняет список котировок акций в одной транзакции
+func (r *SQLStockRepository) SaveStockQuotes(ctx context.Context, quotes []models.StockQuote) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("ошибка открытия транзакции: %w", err)
+	}
+
+	for i := range quotes {
+		if err := r.upsertQuote(ctx, tx, &quotes[i]); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("ошибка фиксации транзакции: %w", err)
+	}
+
+	return nil
+}
+
+// Вспомогательные методы
+
+// getIntradayHistory запрашивает внутридневные свечи у биржи и сохраняет их в базу.
+// Если биржа недоступна, возвращаются ранее сохраненные свечи
+func (r *SQLStockRepository) getIntradayHistory(ctx context.Context, ticker, interval string, startDate, endDate time.Time) ([]models.StockQuote, error) {
+	candles, err := r.exchange.GetCandles(ctx, ticker, interval, startDate, endDate)
+	if err != nil {
+		history, dbErr := r.findHistory(ctx, ticker, interval, startDate, endDate)
+		if dbErr != nil || len(history) == 0 {
+			return nil, fmt.Errorf("ошибка получения данных с биржи: %w", err)
+		}
+		log.Printf("Не удалось получить свечи %s (%s) с биржи, используем сохраненные: %v", ticker, interval, err)
+		return history, nil
+	}
+
+	if err := r.SaveStockQuotes(ctx, candles); err != nil {
+		log.Printf("Не удалось сохранить свечи %s (%s): %v", ticker, interval, err)
+	}
+
+	return candles, nil
+}
+
+// findHistory ищет в базе свечи акции с указанным интервалом за период
+func (r *SQLStockRepository) findHistory(ctx context.Context, ticker, interval string, startDate, endDate time.Time) ([]models.StockQuote, error) {
+	if !models.IsIntraday(interval) {
+		interval = models.IntervalDay
+	}
+
+	stop := timing.Track(ctx, timing.StageDB)
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+quoteColumns+` FROM stock_quotes
+		WHERE ticker = $1 AND candle_interval = $2 AND date BETWEEN $3 AND $4
+		ORDER BY date`,
+		ticker, interval, sqlQuoteDate(interval, startDate), sqlQuoteDate(interval, endDate),
+	)
+	stop()
+	if err != nil {
+		return nil, fmt.Errorf("ошибка поиска в базе данных: %w", err)
+	}
+	defer rows.Close()
+
+	var history []models.StockQuote
+	for rows.Next() {
+		quote, err := scanQuote(rows)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка декодирования результатов: %w", err)
+		}
+		history = append(history, quote)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка декодирования результатов: %w", err)
+	}
+
+	return history, nil
+}
+
+// sqlExecer общий интерфейс *sql.DB и *sql.Tx для выполнения запросов
+type sqlExecer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// rowScanner общий интерфейс *sql.Row и *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// getAllStocks возвращает все акции
+func (r *SQLStockRepository) getAllStocks(ctx context.Context) ([]models.Stock, error) {
+	if !r.useCache {
+		return r.loadAllStocks(ctx)
+	}
+
+	var stocks []models.Stock
+	err := r.stocks.Fetch(ctx, models.AllStocksCacheKey(), &stocks, r.cacheExpiry, r.cacheExpiry+r.staleTTL,
+		func(ctx context.Context) (interface{}, error) {
+			return r.loadAllStocks(ctx)
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return stocks, nil
+}
+
+// loadAllStocks запрашивает у биржи котировки всех сохраненных акций (или популярных, если база пуста)
+// и сохраняет их. Если биржа недоступна, возвращаются сохраненные котировки
+func (r *SQLStockRepository) loadAllStocks(ctx context.Context) ([]models.Stock, error) {
+	// Ищем в базе данных
+	stop := timing.Track(ctx, timing.StageDB)
+	rows, err := r.db.QueryContext(ctx, `SELECT `+stockColumns+` FROM stocks ORDER BY ticker`)
+	stop()
+	if err != nil {
+		return nil, fmt.Errorf("ошибка поиска в базе данных: %w", err)
+	}
+	defer rows.Close()
+
+	var saved []models.Stock
+	for rows.Next() {
+		stock, err := scanStock(rows)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка декодирования результатов: %w", err)
+		}
+		saved = append(saved, stock)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка декодирования результатов: %w", err)
+	}
+
+	tickers := defaultTickers
+	if len(saved) > 0 {
+		tickers = make([]string, len(saved))
+		for i, stock := range saved {
+			tickers[i] = stock.Ticker
+		}
+	}
+
+	stocks, err := r.exchange.GetStocks(ctx, tickers)
+	if err != nil {
+		if len(saved) == 0 {
+			return nil, fmt.Errorf("ошибка получения данных с биржи: %w", err)
+		}
+		log.Printf("Не удалось получить котировки с биржи, используем сохраненные: %v", err)
+		return saved, nil
+	}
+
+	for i := range stocks {
+		if err := r.upsertStock(ctx, &stocks[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return stocks, nil
+}
+
+// upsertStock вставляет или обновляет запись об акции
+func (r *SQLStockRepository) upsertStock(ctx context.Context, stock *models.Stock) error {
+	defer timing.Track(ctx, timing.StageDB)()
+
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO stocks (`+stockColumns+`) VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (ticker) DO UPDATE SET
+			name = EXCLUDED.name,
+			price = EXCLUDED.price,
+			change = EXCLUDED.change,
+			change_perc = EXCLUDED.change_perc,
+			volume = EXCLUDED.volume,
+			updated_at = EXCLUDED.updated_at`,
+		stock.Ticker, stock.Name, stock.Price, stock.Change, stock.ChangePerc, stock.Volume, stock.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("ошибка сохранения в базу данных: %w", err)
+	}
+	return nil
+}
+
+// upsertQuote вставляет или обновляет свечу
+func (r *SQLStockRepository) upsertQuote(ctx context.Context, db sqlExecer, quote *models.StockQuote) error {
+	defer timing.Track(ctx, timing.StageDB)()
+
+	if quote.Interval == "" {
+		quote.Interval = models.IntervalDay
+	}
+
+	_, err := db.ExecContext(ctx,
+		`INSERT INTO stock_quotes (`+quoteColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		ON CONFLICT (ticker, candle_interval, date) DO UPDATE SET
+			open = EXCLUDED.open,
+			high = EXCLUDED.high,
+			low = EXCLUDED.low,
+			close = EXCLUDED.close,
+			volume = EXCLUDED.volume,
+			market_cap_bln = EXCLUDED.market_cap_bln,
+			pe = EXCLUDED.pe,
+			dividend_yield = EXCLUDED.dividend_yield,
+			sector = EXCLUDED.sector,
+			trading_session = EXCLUDED.trading_session`,
+		quote.Ticker, quote.Interval, sqlQuoteDate(quote.Interval, quote.Date),
+		quote.Open, quote.High, quote.Low, quote.Close, quote.Volume,
+		quote.MarketCapBln, quote.PE, quote.DividendYield, quote.Sector, quote.TradingSession,
+	)
+	if err != nil {
+		return fmt.Errorf("ошибка сохранения в базу данных: %w", err)
+	}
+	return nil
+}
+
+// scanStock читает строку таблицы stocks
+func scanStock(row rowScanner) (models.Stock, error) {
+	var stock models.Stock
+	err := row.Scan(&stock.Ticker, &stock.Name, &stock.Price, &stock.Change,
+		&stock.ChangePerc, &stock.Volume, &stock.UpdatedAt)
+	return stock, err
+}
+
+// scanQuote читает строку таблицы stock_quotes
+func scanQuote(row rowScanner) (models.StockQuote, error) {
+	var quote models.StockQuote
+	err := row.Scan(&quote.Ticker, &quote.Interval, &quote.Date, &quote.Open, &quote.High, &quote.Low,
+		&quote.Close, &quote.Volume, &quote.MarketCapBln, &quote.PE, &quote.DividendYield,
+		&quote.Sector, &quote.TradingSession)
+	return quote, err
+}
+
+// sqlQuoteDate возвращает значение столбца date: дневные котировки хранятся датой,
+// внутридневные — временем начала свечи в UTC, чтобы одинаково сравниваться в PostgreSQL и SQLite
+func sqlQuoteDate(interval string, date time.Time) string {
+	if models.IsIntraday(interval) {
+		return date.UTC().Format("2006-01-02 15:04:05")
+	}
+	return date.Format("2006-01-02")
+}
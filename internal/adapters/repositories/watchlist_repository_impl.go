@@ -0,0 +1,117 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/repositories"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// WatchlistRepositoryImpl реализация интерфейса WatchlistRepository на MongoDB
+type WatchlistRepositoryImpl struct {
+	db     *mongo.Collection
+	alerts *mongo.Collection
+}
+
+// NewWatchlistRepository создает новый экземпляр репозитория списков наблюдения
+func NewWatchlistRepository(db *mongo.Database) repositories.WatchlistRepository {
+	return &WatchlistRepositoryImpl{
+		db:     db.Collection("watchlist"),
+		alerts: db.Collection("watchlist_alerts"),
+	}
+}
+
+// GetEntries возвращает бумаги списка наблюдения; пустое имя — бумаги всех списков
+func (r *WatchlistRepositoryImpl) GetEntries(ctx context.Context, watchlist string) ([]models.WatchlistEntry, error) {
+	filter := bson.M{}
+	if watchlist != "" {
+		filter["watchlist"] = watchlist
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "watchlist", Value: 1}, {Key: "ticker", Value: 1}})
+	cursor, err := r.db.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка поиска в базе данных: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var entries []models.WatchlistEntry
+	if err = cursor.All(ctx, &entries); err != nil {
+		return nil, fmt.Errorf("ошибка декодирования результатов: %w", err)
+	}
+
+	return entries, nil
+}
+
+// GetEntry возвращает бумагу списка наблюдения по тикеру или nil, если ее нет
+func (r *WatchlistRepositoryImpl) GetEntry(ctx context.Context, watchlist, ticker string) (*models.WatchlistEntry, error) {
+	var entry models.WatchlistEntry
+	err := r.db.FindOne(ctx, bson.M{"watchlist": watchlist, "ticker": ticker}).Decode(&entry)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ошибка поиска в базе данных: %w", err)
+	}
+
+	return &entry, nil
+}
+
+// SaveEntry сохраняет бумагу списка наблюдения
+func (r *WatchlistRepositoryImpl) SaveEntry(ctx context.Context, entry *models.WatchlistEntry) error {
+	filter := bson.M{"watchlist": entry.Watchlist, "ticker": entry.Ticker}
+	_, err := r.db.ReplaceOne(ctx, filter, entry, options.Replace().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("ошибка сохранения списка наблюдения: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteEntry удаляет бумагу из списка наблюдения
+func (r *WatchlistRepositoryImpl) DeleteEntry(ctx context.Context, watchlist, ticker string) error {
+	_, err := r.db.DeleteOne(ctx, bson.M{"watchlist": watchlist, "ticker": ticker})
+	if err != nil {
+		return fmt.Errorf("ошибка удаления из списка наблюдения: %w", err)
+	}
+
+	return nil
+}
+
+// SaveAlert сохраняет сработавшее уведомление
+func (r *WatchlistRepositoryImpl) SaveAlert(ctx context.Context, alert *models.WatchlistAlert) error {
+	if _, err := r.alerts.InsertOne(ctx, alert); err != nil {
+		return fmt.Errorf("ошибка сохранения уведомления: %w", err)
+	}
+
+	return nil
+}
+
+// GetAlerts возвращает уведомления списка наблюдения начиная с since (от новых к старым)
+func (r *WatchlistRepositoryImpl) GetAlerts(ctx context.Context, watchlist string, since time.Time) ([]models.WatchlistAlert, error) {
+	filter := bson.M{
+		"watchlist":    watchlist,
+		"triggered_at": bson.M{"$gte": since},
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "triggered_at", Value: -1}})
+	cursor, err := r.alerts.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка поиска в базе данных: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var alerts []models.WatchlistAlert
+	if err = cursor.All(ctx, &alerts); err != nil {
+		return nil, fmt.Errorf("ошибка декодирования результатов: %w", err)
+	}
+
+	return alerts, nil
+}
@@ -0,0 +1,227 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/repositories"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/progress"
+)
+
+// minCorrelationSessions минимальное количество общих сессий, по которым корреляция и бета считаются значимыми
+const minCorrelationSessions = 10
+
+// GetCorrelation рассчитывает попарные корреляции дневных доходностей акций и их беты относительно IMOEX за windowDays дней.
+// Доходности сопоставляются по датам сессий; акции с недостаточной историей исключаются из расчета
+func (s *AnalysisServiceImpl) GetCorrelation(ctx context.Context, tickers []string, windowDays int) (*models.CorrelationReport, error) {
+	if windowDays <= 0 {
+		windowDays = models.DefaultCorrelationWindowDays
+	}
+	if windowDays > models.MaxCorrelationWindowDays {
+		return nil, fmt.Errorf("окно расчета не может превышать %d дней", models.MaxCorrelationWindowDays)
+	}
+
+	unique := make([]string, 0, len(tickers))
+	for _, ticker := range tickers {
+		ticker = strings.ToUpper(strings.TrimSpace(ticker))
+		if ticker != "" && !containsTicker(unique, ticker) {
+			unique = append(unique, ticker)
+		}
+	}
+	if len(unique) == 0 {
+		return nil, fmt.Errorf("список тикеров не может быть пустым")
+	}
+	if len(unique) > models.MaxCorrelationTickers {
+		return nil, fmt.Errorf("можно передать не более %d тикеров", models.MaxCorrelationTickers)
+	}
+
+	to := time.Now()
+	report := &models.CorrelationReport{
+		IndexTicker: indexTicker,
+		WindowDays:  windowDays,
+		From:        dayStart(to.AddDate(0, 0, -windowDays)),
+		To:          to,
+	}
+
+	returns := make(map[string]map[string]float64, len(unique))
+	for i, ticker := range unique {
+		// Индекс считается последним шагом
+		progress.Report(ctx, i, len(unique)+1, "Доходности %s (%d из %d)", ticker, i+1, len(unique))
+		tickerReturns, err := s.dailyReturns(ctx, ticker, report.From, report.To)
+		if err != nil {
+			log.Printf("Не удалось получить доходности %s: %v", ticker, err)
+		}
+		if len(tickerReturns) < minCorrelationSessions {
+			report.Skipped = append(report.Skipped, ticker)
+			continue
+		}
+		report.Tickers = append(report.Tickers, ticker)
+		returns[ticker] = tickerReturns
+	}
+	if len(report.Tickers) == 0 {
+		return nil, fmt.Errorf("недостаточно истории котировок за %d дней: нужно не менее %d сессий", windowDays, minCorrelationSessions+1)
+	}
+
+	progress.Report(ctx, len(unique), len(unique)+1, "Доходности индекса %s", indexTicker)
+	indexReturns, err := s.dailyReturns(ctx, indexTicker, report.From, report.To)
+	if err != nil {
+		log.Printf("Не удалось получить доходности индекса %s: %v", indexTicker, err)
+	}
+	report.HasIndex = len(indexReturns) >= minCorrelationSessions
+
+	for _, ticker := range report.Tickers {
+		beta := models.TickerBeta{
+			Ticker:         ticker,
+			VolatilityPerc: stdDev(returnValues(returns[ticker])) * 100,
+		}
+		if report.HasIndex {
+			// Бета и корреляция не определены на короткой истории и на постоянном ряду: Observations остается 0
+			xs, ys := alignReturns(returns[ticker], indexReturns)
+			indexCorrelation, ok := correlation(xs, ys)
+			if len(xs) >= minCorrelationSessions && ok {
+				beta.Observations = len(xs)
+				beta.IndexCorrelation = indexCorrelation
+				beta.Beta = covariance(xs, ys) / covariance(ys, ys)
+			}
+		}
+		report.Betas = append(report.Betas, beta)
+	}
+
+	var totalCorrelation float64
+	for i := 0; i < len(report.Tickers); i++ {
+		for j := i + 1; j < len(report.Tickers); j++ {
+			a, b := report.Tickers[i], report.Tickers[j]
+			xs, ys := alignReturns(returns[a], returns[b])
+			pairCorrelation, ok := correlation(xs, ys)
+			if len(xs) < minCorrelationSessions || !ok {
+				continue
+			}
+			pair := models.PairCorrelation{
+				TickerA:      a,
+				TickerB:      b,
+				Correlation:  pairCorrelation,
+				Observations: len(xs),
+			}
+			totalCorrelation += pair.Correlation
+			report.Pairs = append(report.Pairs, pair)
+		}
+	}
+	if len(report.Pairs) > 0 {
+		report.AvgPairCorrelation = totalCorrelation / float64(len(report.Pairs))
+	}
+
+	return report, nil
+}
+
+// dailyReturns возвращает дневные доходности акции за период по датам сессий.
+// Доходность считается к закрытию предыдущей сохраненной сессии
+func (s *AnalysisServiceImpl) dailyReturns(ctx context.Context, ticker string, from, to time.Time) (map[string]float64, error) {
+	history, err := dailyCloses(ctx, s.stockRepo, ticker, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return closeReturns(history), nil
+}
+
+// dailyCloses возвращает дневные свечи акции за период в хронологическом порядке
+func dailyCloses(ctx context.Context, stockRepo repositories.StockRepository, ticker string, from, to time.Time) ([]models.StockQuote, error) {
+	history, err := stockRepo.GetStockHistory(ctx, ticker, models.IntervalDay, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось получить историю котировок %s: %w", ticker, err)
+	}
+
+	sort.Slice(history, func(i, j int) bool {
+		return history[i].Date.Before(history[j].Date)
+	})
+
+	return history, nil
+}
+
+// closeReturns возвращает доходности по датам сессий к закрытию предыдущей свечи; свечи должны идти в хронологическом порядке
+func closeReturns(history []models.StockQuote) map[string]float64 {
+	returns := make(map[string]float64, len(history))
+	for i := 1; i < len(history); i++ {
+		if history[i-1].Close == 0 {
+			continue
+		}
+		returns[history[i].Date.Format("2006-01-02")] = history[i].Close/history[i-1].Close - 1
+	}
+
+	return returns
+}
+
+// alignReturns возвращает доходности двух рядов за общие даты в хронологическом порядке
+func alignReturns(a, b map[string]float64) ([]float64, []float64) {
+	dates := make([]string, 0, len(a))
+	for date := range a {
+		if _, ok := b[date]; ok {
+			dates = append(dates, date)
+		}
+	}
+	sort.Strings(dates)
+
+	xs := make([]float64, 0, len(dates))
+	ys := make([]float64, 0, len(dates))
+	for _, date := range dates {
+		xs = append(xs, a[date])
+		ys = append(ys, b[date])
+	}
+
+	return xs, ys
+}
+
+// returnValues возвращает значения доходностей без учета дат
+func returnValues(returns map[string]float64) []float64 {
+	values := make([]float64, 0, len(returns))
+	for _, value := range returns {
+		values = append(values, value)
+	}
+	return values
+}
+
+// meanOf возвращает среднее значение ряда
+func meanOf(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, value := range values {
+		sum += value
+	}
+	return sum / float64(len(values))
+}
+
+// covariance возвращает выборочную ковариацию двух рядов одинаковой длины
+func covariance(xs, ys []float64) float64 {
+	if len(xs) < 2 || len(xs) != len(ys) {
+		return 0
+	}
+	meanX, meanY := meanOf(xs), meanOf(ys)
+	var sum float64
+	for i := range xs {
+		sum += (xs[i] - meanX) * (ys[i] - meanY)
+	}
+	return sum / float64(len(xs)-1)
+}
+
+// stdDev возвращает выборочное стандартное отклонение ряда
+func stdDev(values []float64) float64 {
+	return math.Sqrt(covariance(values, values))
+}
+
+// correlation возвращает коэффициент корреляции Пирсона; false, если он не определен:
+// ряды короче двух значений или один из них постоянен
+func correlation(xs, ys []float64) (float64, bool) {
+	denominator := stdDev(xs) * stdDev(ys)
+	if denominator == 0 {
+		return 0, false
+	}
+	return covariance(xs, ys) / denominator, true
+}
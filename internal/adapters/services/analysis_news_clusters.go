@@ -0,0 +1,89 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// GetNewsTickerClusters группирует новости дня по упомянутым акциям и дополняет их текущими котировками.
+// Тикеры берутся из связанных бумаг новости, найденных по словарю названий компаний.
+// Кластеры упорядочены по количеству новостей, затем по силе тональности
+func (s *AnalysisServiceImpl) GetNewsTickerClusters(ctx context.Context, maxTickers int) ([]models.NewsTickerCluster, error) {
+	if maxTickers <= 0 {
+		maxTickers = models.DefaultNewsClusterTickers
+	}
+	if maxTickers > models.MaxNewsClusterTickers {
+		return nil, fmt.Errorf("количество акций не может превышать %d", models.MaxNewsClusterTickers)
+	}
+
+	news, err := s.newsRepo.GetNewsByDate(ctx, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("не удалось получить новости за сегодня: %w", err)
+	}
+
+	sort.Slice(news, func(i, j int) bool {
+		return news[i].PublishedAt.After(news[j].PublishedAt)
+	})
+
+	clusters := make(map[string]*models.NewsTickerCluster)
+	for _, item := range news {
+		seen := make(map[string]bool)
+		for _, ticker := range item.RelatedTo {
+			ticker = strings.ToUpper(ticker)
+			if seen[ticker] {
+				continue
+			}
+			seen[ticker] = true
+
+			cluster, ok := clusters[ticker]
+			if !ok {
+				cluster = &models.NewsTickerCluster{Ticker: ticker}
+				clusters[ticker] = cluster
+			}
+			cluster.TotalNews++
+			cluster.Sentiment += newsSentiment(item)
+			if len(cluster.News) < models.MaxClusterNews {
+				cluster.News = append(cluster.News, item)
+			}
+		}
+	}
+	if len(clusters) == 0 {
+		return nil, fmt.Errorf("в новостях за сегодня не упомянута ни одна акция")
+	}
+
+	result := make([]models.NewsTickerCluster, 0, len(clusters))
+	for _, cluster := range clusters {
+		cluster.Sentiment /= float64(cluster.TotalNews)
+		result = append(result, *cluster)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].TotalNews != result[j].TotalNews {
+			return result[i].TotalNews > result[j].TotalNews
+		}
+		if a, b := math.Abs(result[i].Sentiment), math.Abs(result[j].Sentiment); a != b {
+			return a > b
+		}
+		return result[i].Ticker < result[j].Ticker
+	})
+	if len(result) > maxTickers {
+		result = result[:maxTickers]
+	}
+
+	for i := range result {
+		stock, err := s.stockRepo.GetStock(ctx, result[i].Ticker)
+		if err != nil {
+			log.Printf("Не удалось получить котировку %s: %v", result[i].Ticker, err)
+			continue
+		}
+		result[i].Stock = stock
+	}
+
+	return result, nil
+}
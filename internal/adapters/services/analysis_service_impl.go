@@ -0,0 +1,443 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/repositories"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/services"
+)
+
+const (
+	// indexTicker тикер индекса Московской биржи, с которым сравнивается движение акции
+	indexTicker = "IMOEX"
+
+	// historyLookback глубина истории для поиска предыдущей сессии и среднего объема
+	historyLookback = 45 * 24 * time.Hour
+
+	// volumeSessions количество предыдущих сессий для расчета среднего объема
+	volumeSessions = 20
+
+	// marketNewsLimit количество общих новостей дня в результате
+	marketNewsLimit = 5
+)
+
+// AnalysisServiceImpl реализация интерфейса AnalysisService
+type AnalysisServiceImpl struct {
+	stockRepo repositories.StockRepository
+	newsRepo  repositories.NewsRepository
+}
+
+// NewAnalysisService создает новый экземпляр сервиса аналитики
+func NewAnalysisService(stockRepo repositories.StockRepository, newsRepo repositories.NewsRepository) services.AnalysisService {
+	return &AnalysisServiceImpl{
+		stockRepo: stockRepo,
+		newsRepo:  newsRepo,
+	}
+}
+
+// dayMove описывает торговую сессию и предшествующие ей сессии
+type dayMove struct {
+	quote    models.StockQuote
+	prev     *models.StockQuote
+	previous []models.StockQuote // Предыдущие сессии, от новых к старым
+}
+
+// changePerc возвращает изменение цены закрытия к предыдущей сессии (или к открытию, если ее нет)
+func (m dayMove) changePerc() float64 {
+	base := m.quote.Open
+	if m.prev != nil {
+		base = m.prev.Close
+	}
+	return percent(m.quote.Close, base)
+}
+
+// ExplainMove собирает вероятные причины движения акции за указанную дату
+func (s *AnalysisServiceImpl) ExplainMove(ctx context.Context, ticker string, date time.Time) (*models.MoveExplanation, error) {
+	if ticker == "" {
+		return nil, fmt.Errorf("тикер не может быть пустым")
+	}
+	if date.IsZero() {
+		date = time.Now()
+	}
+	ticker = strings.ToUpper(ticker)
+
+	move, err := s.loadDayMove(ctx, ticker, date)
+	if err != nil {
+		return nil, err
+	}
+
+	explanation := &models.MoveExplanation{
+		Ticker:     ticker,
+		Date:       move.quote.Date,
+		Quote:      move.quote,
+		ChangePerc: move.changePerc(),
+		Candle:     candleShape(move.quote),
+		Sector:     move.quote.Sector,
+	}
+	if move.prev != nil {
+		explanation.PrevClose = move.prev.Close
+		explanation.GapPerc = percent(move.quote.Open, move.prev.Close)
+	}
+
+	// Аномалия объема относительно предыдущих сессий
+	sessions := move.previous
+	if len(sessions) > volumeSessions {
+		sessions = sessions[:volumeSessions]
+	}
+	if len(sessions) > 0 {
+		var total int64
+		for _, quote := range sessions {
+			total += quote.Volume
+		}
+		explanation.AvgVolume = float64(total) / float64(len(sessions))
+		explanation.VolumeSessions = len(sessions)
+		if explanation.AvgVolume > 0 {
+			explanation.VolumeRatio = float64(move.quote.Volume) / explanation.AvgVolume
+		}
+	}
+
+	// Новости: по компании — с конца предыдущей сессии, общие — за сам день
+	windowStart := dayStart(date)
+	if move.prev != nil {
+		windowStart = dayStart(move.prev.Date).Add(24 * time.Hour)
+	}
+	windowEnd := dayStart(date).Add(24 * time.Hour)
+	explanation.TickerNews, explanation.MarketNews = s.collectNews(ctx, ticker, date, windowStart, windowEnd)
+
+	// Внутридневной профиль: в какой час цена изменилась сильнее всего и какие новости вышли до этого
+	explanation.Intraday, explanation.MoveBar = s.intradayProfile(ctx, ticker, date, explanation.PrevClose)
+	if explanation.MoveBar != nil {
+		moveEnd := explanation.MoveBar.Time.Add(time.Hour)
+		for _, item := range explanation.TickerNews {
+			if item.PublishedAt.Before(moveEnd) {
+				explanation.NewsBeforeMove++
+			}
+		}
+	}
+
+	// Движение индекса
+	if index, err := s.loadDayMove(ctx, indexTicker, date); err == nil {
+		explanation.IndexTicker = indexTicker
+		explanation.IndexChangePerc = index.changePerc()
+		explanation.HasIndex = true
+	} else {
+		log.Printf("Не удалось получить движение индекса %s: %v", indexTicker, err)
+	}
+
+	// Движение аналогов из того же сектора
+	if explanation.Sector != "" {
+		explanation.SectorPeers, explanation.SectorChangePerc = s.sectorMoves(ctx, ticker, explanation.Sector, date)
+	}
+
+	explanation.Drivers = likelyDrivers(explanation)
+
+	return explanation, nil
+}
+
+// loadDayMove находит сессию за указанную дату и предшествующие ей сессии.
+// Если дневной свечи сессии еще нет (торги идут), сессия собирается из часовых свечей дня
+func (s *AnalysisServiceImpl) loadDayMove(ctx context.Context, ticker string, date time.Time) (*dayMove, error) {
+	history, err := s.stockRepo.GetStockHistory(ctx, ticker, models.IntervalDay, date.Add(-historyLookback), date)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось получить историю котировок %s: %w", ticker, err)
+	}
+
+	sort.Slice(history, func(i, j int) bool {
+		return history[i].Date.After(history[j].Date)
+	})
+
+	day := date.Format("2006-01-02")
+	for i, quote := range history {
+		if quote.Date.Format("2006-01-02") != day {
+			continue
+		}
+		move := &dayMove{quote: quote, previous: history[i+1:]}
+		if len(move.previous) > 0 {
+			move.prev = &move.previous[0]
+		}
+		return move, nil
+	}
+
+	quote, ok := s.intradaySession(ctx, ticker, date)
+	if !ok {
+		return nil, fmt.Errorf("нет данных о торгах %s за %s", ticker, day)
+	}
+	move := &dayMove{quote: quote}
+	for i := range history {
+		if history[i].Date.Before(quote.Date) {
+			move.previous = history[i:]
+			move.prev = &move.previous[0]
+			break
+		}
+	}
+	return move, nil
+}
+
+// intradaySession собирает дневную свечу сессии из часовых свечей дня; false, если их нет
+func (s *AnalysisServiceImpl) intradaySession(ctx context.Context, ticker string, date time.Time) (models.StockQuote, bool) {
+	from := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, models.MoscowLocation)
+	candles, err := s.stockRepo.GetStockHistory(ctx, ticker, models.IntervalHour, from, from.Add(24*time.Hour))
+	if err != nil {
+		log.Printf("Не удалось получить часовые свечи %s: %v", ticker, err)
+		return models.StockQuote{}, false
+	}
+	if len(candles) == 0 {
+		return models.StockQuote{}, false
+	}
+
+	sort.Slice(candles, func(i, j int) bool {
+		return candles[i].Date.Before(candles[j].Date)
+	})
+
+	quote := models.StockQuote{
+		Ticker:   ticker,
+		Interval: models.IntervalDay,
+		Date:     time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC),
+		Open:     candles[0].Open,
+		High:     candles[0].High,
+		Low:      candles[0].Low,
+		Close:    candles[len(candles)-1].Close,
+	}
+	for _, candle := range candles {
+		quote.High = math.Max(quote.High, candle.High)
+		quote.Low = math.Min(quote.Low, candle.Low)
+		quote.Volume += candle.Volume
+	}
+	return quote, true
+}
+
+// intradayProfile возвращает часовые свечи сессии и свечу с самым сильным изменением цены.
+// Без часовых свечей профиль пуст: объяснение движения строится по дневной свече
+func (s *AnalysisServiceImpl) intradayProfile(ctx context.Context, ticker string, date time.Time, prevClose float64) ([]models.IntradayBar, *models.IntradayBar) {
+	from := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, models.MoscowLocation)
+	candles, err := s.stockRepo.GetStockHistory(ctx, ticker, models.IntervalHour, from, from.Add(24*time.Hour))
+	if err != nil {
+		log.Printf("Не удалось получить часовые свечи %s: %v", ticker, err)
+		return nil, nil
+	}
+	if len(candles) == 0 {
+		return nil, nil
+	}
+
+	sort.Slice(candles, func(i, j int) bool {
+		return candles[i].Date.Before(candles[j].Date)
+	})
+
+	var total int64
+	for _, candle := range candles {
+		total += candle.Volume
+	}
+
+	bars := make([]models.IntradayBar, len(candles))
+	moveIdx := 0
+	base := prevClose
+	if base == 0 {
+		base = candles[0].Open
+	}
+	for i, candle := range candles {
+		bars[i] = models.IntradayBar{
+			Time:       candle.Date.In(models.MoscowLocation),
+			Close:      candle.Close,
+			ChangePerc: percent(candle.Close, base),
+			Volume:     candle.Volume,
+		}
+		if total > 0 {
+			bars[i].VolumeShare = float64(candle.Volume) / float64(total) * 100
+		}
+		if math.Abs(bars[i].ChangePerc) > math.Abs(bars[moveIdx].ChangePerc) {
+			moveIdx = i
+		}
+		base = candle.Close
+	}
+
+	moveBar := bars[moveIdx]
+	return bars, &moveBar
+}
+
+// collectNews возвращает новости по тикеру в окне [from, to) и общие новости за день
+func (s *AnalysisServiceImpl) collectNews(ctx context.Context, ticker string, date, from, to time.Time) ([]models.News, []models.News) {
+	var tickerNews, marketNews []models.News
+	seen := make(map[string]bool)
+
+	related, err := s.newsRepo.GetNewsByTicker(ctx, ticker)
+	if err != nil {
+		log.Printf("Не удалось получить новости по тикеру %s: %v", ticker, err)
+	}
+	for _, item := range related {
+		if item.PublishedAt.Before(from) || !item.PublishedAt.Before(to) {
+			continue
+		}
+		seen[item.ID] = true
+		tickerNews = append(tickerNews, item)
+	}
+
+	daily, err := s.newsRepo.GetNewsByDate(ctx, date)
+	if err != nil {
+		log.Printf("Не удалось получить новости за %s: %v", date.Format("2006-01-02"), err)
+	}
+	for _, item := range daily {
+		if seen[item.ID] {
+			continue
+		}
+		if containsTicker(item.RelatedTo, ticker) {
+			tickerNews = append(tickerNews, item)
+			continue
+		}
+		if len(marketNews) < marketNewsLimit {
+			marketNews = append(marketNews, item)
+		}
+	}
+
+	sort.Slice(tickerNews, func(i, j int) bool {
+		return tickerNews[i].PublishedAt.Before(tickerNews[j].PublishedAt)
+	})
+
+	return tickerNews, marketNews
+}
+
+// sectorMoves возвращает изменения цен аналогов из сектора и их среднее изменение
+func (s *AnalysisServiceImpl) sectorMoves(ctx context.Context, ticker, sector string, date time.Time) ([]models.PeerMove, float64) {
+	stocks, err := s.stockRepo.GetStocks(ctx, []string{})
+	if err != nil {
+		log.Printf("Не удалось получить список акций для сравнения с сектором: %v", err)
+		return nil, 0
+	}
+
+	var peers []models.PeerMove
+	var total float64
+	for _, stock := range stocks {
+		if stock.Ticker == ticker {
+			continue
+		}
+		move, err := s.loadDayMove(ctx, stock.Ticker, date)
+		if err != nil || move.quote.Sector != sector {
+			continue
+		}
+		peer := models.PeerMove{Ticker: stock.Ticker, ChangePerc: move.changePerc()}
+		peers = append(peers, peer)
+		total += peer.ChangePerc
+	}
+
+	if len(peers) == 0 {
+		return nil, 0
+	}
+	return peers, total / float64(len(peers))
+}
+
+// candleShape определяет форму дневной свечи по ценам OHLC
+func candleShape(quote models.StockQuote) models.CandleShape {
+	dayRange := quote.High - quote.Low
+	if dayRange <= 0 {
+		return models.CandleShape{Pattern: "без движения"}
+	}
+
+	body := math.Abs(quote.Close - quote.Open)
+	upper := quote.High - math.Max(quote.Open, quote.Close)
+	lower := math.Min(quote.Open, quote.Close) - quote.Low
+
+	shape := models.CandleShape{
+		BodyPerc:        body / dayRange * 100,
+		UpperShadowPerc: upper / dayRange * 100,
+		LowerShadowPerc: lower / dayRange * 100,
+		RangePerc:       percent(quote.Open+dayRange, quote.Open),
+	}
+
+	switch {
+	case shape.BodyPerc < 10:
+		shape.Pattern = "доджи — неопределенность, покупатели и продавцы в равновесии"
+	case shape.BodyPerc > 80 && quote.Close > quote.Open:
+		shape.Pattern = "полнотелая растущая свеча — уверенные покупки весь день"
+	case shape.BodyPerc > 80:
+		shape.Pattern = "полнотелая падающая свеча — уверенные продажи весь день"
+	case upper > 2*body && upper > lower:
+		shape.Pattern = "длинная верхняя тень — рост был продан"
+	case lower > 2*body && lower > upper:
+		shape.Pattern = "длинная нижняя тень — снижение было выкуплено"
+	case quote.Close > quote.Open:
+		shape.Pattern = "растущая свеча"
+	default:
+		shape.Pattern = "падающая свеча"
+	}
+
+	return shape
+}
+
+// likelyDrivers формирует список вероятных драйверов движения в порядке значимости
+func likelyDrivers(e *models.MoveExplanation) []string {
+	var drivers []string
+
+	if len(e.TickerNews) > 0 {
+		drivers = append(drivers, fmt.Sprintf("Новости по компании: %d публикаций между предыдущей сессией и концом дня", len(e.TickerNews)))
+	}
+
+	if math.Abs(e.GapPerc) >= 1 {
+		drivers = append(drivers, fmt.Sprintf("Гэп на открытии %+.2f%% — реакция на события вне торговой сессии", e.GapPerc))
+	}
+
+	if e.MoveBar != nil && math.Abs(e.MoveBar.ChangePerc) >= 1 {
+		driver := fmt.Sprintf("Резкое движение в %s: %+.2f%% за час на %.0f%% объема сессии",
+			e.MoveBar.Time.Format("15:04"), e.MoveBar.ChangePerc, e.MoveBar.VolumeShare)
+		if e.NewsBeforeMove > 0 {
+			driver += fmt.Sprintf(" — после %d публикаций о компании", e.NewsBeforeMove)
+		}
+		drivers = append(drivers, driver)
+	}
+
+	if e.VolumeRatio >= 2 {
+		drivers = append(drivers, fmt.Sprintf("Аномальный объем: в %.1f раза выше среднего за %d сессий", e.VolumeRatio, e.VolumeSessions))
+	}
+
+	if e.HasIndex {
+		sameDirection := e.IndexChangePerc*e.ChangePerc > 0
+		switch {
+		case sameDirection && math.Abs(e.IndexChangePerc) >= 0.5 && math.Abs(e.ChangePerc-e.IndexChangePerc) < 1:
+			drivers = append(drivers, fmt.Sprintf("Движение вместе с рынком: индекс %s %+.2f%%", e.IndexTicker, e.IndexChangePerc))
+		case math.Abs(e.ChangePerc-e.IndexChangePerc) >= 2:
+			drivers = append(drivers, fmt.Sprintf("Собственное движение акции: отклонение от индекса %s на %+.2f п.п.",
+				e.IndexTicker, e.ChangePerc-e.IndexChangePerc))
+		}
+	}
+
+	if len(e.SectorPeers) > 0 && e.SectorChangePerc*e.ChangePerc > 0 && math.Abs(e.SectorChangePerc) >= 1 {
+		drivers = append(drivers, fmt.Sprintf("Движение сектора «%s»: в среднем %+.2f%% по %d аналогам",
+			e.Sector, e.SectorChangePerc, len(e.SectorPeers)))
+	}
+
+	if len(drivers) == 0 {
+		drivers = append(drivers, "Явных драйверов не найдено: движение в пределах обычного шума")
+	}
+
+	return drivers
+}
+
+// Вспомогательные функции
+
+// percent возвращает изменение value относительно base в процентах
+func percent(value, base float64) float64 {
+	if base == 0 {
+		return 0
+	}
+	return (value - base) / base * 100
+}
+
+// dayStart возвращает начало суток для указанного времени
+func dayStart(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// containsTicker проверяет, содержится ли тикер в списке без учета регистра
+func containsTicker(tickers []string, ticker string) bool {
+	for _, t := range tickers {
+		if strings.EqualFold(t, ticker) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,37 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/services"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/cache"
+)
+
+// CacheServiceImpl реализация интерфейса CacheService
+type CacheServiceImpl struct {
+	cache cache.Cache
+}
+
+// NewCacheService создает сервис обслуживания кэша. Ключи удаляются только внутри пространства имен кэша,
+// поэтому данные других окружений, работающих с тем же Redis, не затрагиваются
+func NewCacheService(cache cache.Cache) services.CacheService {
+	return &CacheServiceImpl{cache: cache}
+}
+
+// InvalidateCache удаляет ключи кэша, соответствующие шаблону
+func (s *CacheServiceImpl) InvalidateCache(ctx context.Context, pattern string) error {
+	pattern = strings.TrimSpace(pattern)
+	if pattern == "" {
+		return fmt.Errorf("шаблон ключей не может быть пустым")
+	}
+
+	if err := s.cache.Invalidate(ctx, pattern); err != nil {
+		return fmt.Errorf("ошибка удаления ключей кэша: %w", err)
+	}
+
+	log.Printf("Удалены ключи кэша по шаблону %s", pattern)
+	return nil
+}
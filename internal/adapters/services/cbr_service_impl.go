@@ -0,0 +1,107 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/repositories"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/services"
+)
+
+// ruoniaLookbackDays период поиска последнего значения RUONIA: ставка публикуется с задержкой и не считается в выходные
+const ruoniaLookbackDays = 14
+
+// CBRServiceImpl реализация интерфейса CBRService
+type CBRServiceImpl struct {
+	source repositories.CBRSource
+}
+
+// NewCBRService создает новый экземпляр сервиса ставок и официальных курсов Банка России
+func NewCBRService(source repositories.CBRSource) services.CBRService {
+	return &CBRServiceImpl{source: source}
+}
+
+// GetKeyRate возвращает текущую ключевую ставку и ее последнее изменение за KeyRateHistoryDays.
+// RUONIA необязательна: если она недоступна, сводка возвращается без нее
+func (s *CBRServiceImpl) GetKeyRate(ctx context.Context) (*models.KeyRateSummary, error) {
+	now := time.Now()
+
+	rates, err := s.source.GetKeyRates(ctx, now.AddDate(0, 0, -models.KeyRateHistoryDays), now)
+	if err != nil {
+		return nil, err
+	}
+	if len(rates) == 0 {
+		return nil, fmt.Errorf("нет данных о ключевой ставке")
+	}
+
+	current := rates[len(rates)-1]
+	summary := &models.KeyRateSummary{
+		Current:   current,
+		ChangedAt: rates[0].Date,
+	}
+
+	// Ставка публикуется на каждый рабочий день, поэтому изменение ищется с конца ряда
+	for i := len(rates) - 2; i >= 0; i-- {
+		if rates[i].Rate != current.Rate {
+			previous := rates[i]
+			summary.Previous = &previous
+			summary.ChangedAt = rates[i+1].Date
+			break
+		}
+	}
+
+	ruonia, err := s.source.GetRUONIA(ctx, now.AddDate(0, 0, -ruoniaLookbackDays), now)
+	if err != nil {
+		log.Printf("Не удалось получить ставку RUONIA: %v", err)
+	} else if len(ruonia) > 0 {
+		latest := ruonia[len(ruonia)-1]
+		summary.RUONIA = &latest
+	}
+
+	return summary, nil
+}
+
+// GetOfficialFXRates возвращает официальные курсы валют на дату в порядке запроса
+func (s *CBRServiceImpl) GetOfficialFXRates(ctx context.Context, currencies []string, date time.Time) ([]models.OfficialFXRate, error) {
+	if len(currencies) == 0 {
+		currencies = models.DefaultOfficialFXCurrencies
+	}
+	if date.IsZero() {
+		date = time.Now()
+	}
+
+	rates, err := s.source.GetOfficialFXRates(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+	if len(rates) == 0 {
+		return nil, fmt.Errorf("Банк России не установил курсы на %s", date.Format("02.01.2006"))
+	}
+
+	byCode := make(map[string]models.OfficialFXRate, len(rates))
+	for _, rate := range rates {
+		byCode[rate.Code] = rate
+	}
+
+	result := make([]models.OfficialFXRate, 0, len(currencies))
+	for _, currency := range currencies {
+		currency = strings.ToUpper(strings.TrimSpace(currency))
+		rate, ok := byCode[currency]
+		if !ok {
+			codes := make([]string, 0, len(byCode))
+			for code := range byCode {
+				codes = append(codes, code)
+			}
+			sort.Strings(codes)
+			return nil, fmt.Errorf("Банк России не устанавливает курс %s, доступны: %s", currency, strings.Join(codes, ", "))
+		}
+		result = append(result, rate)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/repositories"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/services"
+)
+
+// CommodityServiceImpl реализация интерфейса CommodityService
+type CommodityServiceImpl struct {
+	source repositories.CommoditySource
+	// uralsDiscountUSD дисконт Urals к Brent, долларов за баррель
+	uralsDiscountUSD float64
+}
+
+// NewCommodityService создает новый экземпляр сервиса цен сырьевых товаров
+func NewCommodityService(source repositories.CommoditySource, uralsDiscountUSD float64) services.CommodityService {
+	return &CommodityServiceImpl{
+		source:           source,
+		uralsDiscountUSD: uralsDiscountUSD,
+	}
+}
+
+// GetCommodityPrice возвращает цену сырьевого товара в долларах и рублях.
+// Если курс доллара недоступен, возвращается только долларовая цена
+func (s *CommodityServiceImpl) GetCommodityPrice(ctx context.Context, code string) (*models.CommodityQuote, error) {
+	spec, ok := models.FindCommodity(strings.ToLower(strings.TrimSpace(code)))
+	if !ok {
+		codes := make([]string, 0, len(models.Commodities))
+		for _, commodity := range models.Commodities {
+			codes = append(codes, commodity.Code)
+		}
+		return nil, fmt.Errorf("неизвестный сырьевой товар %s, допустимые: %s", code, strings.Join(codes, ", "))
+	}
+
+	usdRub, err := s.source.GetUSDRUB(ctx)
+	if err != nil {
+		log.Printf("Не удалось получить курс доллара для пересчета цен сырья: %v", err)
+	}
+
+	return s.commodityQuote(ctx, spec, usdRub)
+}
+
+// GetCommodityPrices возвращает цены всех поддерживаемых сырьевых товаров; недоступные пропускаются
+func (s *CommodityServiceImpl) GetCommodityPrices(ctx context.Context) ([]models.CommodityQuote, error) {
+	usdRub, err := s.source.GetUSDRUB(ctx)
+	if err != nil {
+		log.Printf("Не удалось получить курс доллара для пересчета цен сырья: %v", err)
+	}
+
+	quotes := make([]models.CommodityQuote, 0, len(models.Commodities))
+	for _, spec := range models.Commodities {
+		quote, err := s.commodityQuote(ctx, spec, usdRub)
+		if err != nil {
+			log.Printf("Не удалось получить цену %s: %v", spec.Code, err)
+			continue
+		}
+		quotes = append(quotes, *quote)
+	}
+	if len(quotes) == 0 {
+		return nil, fmt.Errorf("цены сырьевых товаров недоступны")
+	}
+
+	return quotes, nil
+}
+
+// commodityQuote рассчитывает цену товара по ближайшему ликвидному фьючерсу; usdRub = 0 — без рублевой цены
+func (s *CommodityServiceImpl) commodityQuote(ctx context.Context, spec models.CommoditySpec, usdRub float64) (*models.CommodityQuote, error) {
+	futures, err := s.source.GetFrontFutures(ctx, spec.AssetCode)
+	if err != nil {
+		return nil, err
+	}
+
+	quote := &models.CommodityQuote{
+		Code:       spec.Code,
+		Name:       spec.Name,
+		Unit:       spec.Unit,
+		Contract:   futures.SecID,
+		Expiration: futures.LastTradeDay,
+		PriceUSD:   futures.Price,
+		ChangePerc: futures.ChangePerc,
+		UpdatedAt:  futures.UpdatedAt,
+	}
+
+	if spec.Code == models.CommodityUrals {
+		quote.PriceUSD = futures.Price - s.uralsDiscountUSD
+		quote.ChangePerc = 0
+		if prev := futures.Price / (1 + futures.ChangePerc/100); prev > s.uralsDiscountUSD {
+			quote.ChangePerc = (quote.PriceUSD/(prev-s.uralsDiscountUSD) - 1) * 100
+		}
+		quote.Estimated = true
+		quote.Note = fmt.Sprintf("оценка: Brent минус дисконт $%.2f за баррель", s.uralsDiscountUSD)
+	}
+
+	if usdRub > 0 {
+		quote.USDRUB = usdRub
+		quote.PriceRUB = quote.PriceUSD * usdRub
+	}
+
+	return quote, nil
+}
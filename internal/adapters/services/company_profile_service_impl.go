@@ -0,0 +1,33 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/repositories"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/services"
+)
+
+// CompanyProfileServiceImpl реализация интерфейса CompanyProfileService
+type CompanyProfileServiceImpl struct {
+	profileRepo repositories.CompanyProfileRepository
+}
+
+// NewCompanyProfileService создает новый экземпляр сервиса профилей компаний
+func NewCompanyProfileService(profileRepo repositories.CompanyProfileRepository) services.CompanyProfileService {
+	return &CompanyProfileServiceImpl{
+		profileRepo: profileRepo,
+	}
+}
+
+// GetCompanyProfile возвращает профиль компании по тикеру
+func (s *CompanyProfileServiceImpl) GetCompanyProfile(ctx context.Context, ticker string) (*models.CompanyProfile, error) {
+	ticker = strings.ToUpper(strings.TrimSpace(ticker))
+	if ticker == "" {
+		return nil, fmt.Errorf("тикер не может быть пустым")
+	}
+
+	return s.profileRepo.GetCompanyProfile(ctx, ticker)
+}
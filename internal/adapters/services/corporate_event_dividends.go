@@ -0,0 +1,151 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// GetDividendProfiles возвращает дивидендную доходность и график выплат бумаг по убыванию доходности.
+// Без тикеров рассматриваются все бумаги, закрывавшие реестр под дивиденды за последний год
+func (s *CorporateEventServiceImpl) GetDividendProfiles(ctx context.Context, tickers []string) ([]models.DividendProfile, error) {
+	now := time.Now()
+	from := dayStart(now.AddDate(0, 0, -models.DividendHistoryDays))
+	until := now.AddDate(0, 0, models.DividendHistoryDays)
+	dividendTypes := []string{models.EventTypeDividend}
+
+	// Период выборки ограничен MaxEventsRangeDays, поэтому прошедшие и объявленные выплаты запрашиваются отдельно
+	past, err := s.GetEventsCalendar(ctx, from, now, dividendTypes)
+	if err != nil {
+		return nil, err
+	}
+	upcoming, err := s.GetEventsCalendar(ctx, now, until, dividendTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	byTicker := make(map[string][]models.CorporateEvent)
+	for _, event := range uniqueDividends(append(past, upcoming...)) {
+		byTicker[event.Ticker] = append(byTicker[event.Ticker], event)
+	}
+
+	candidates := make([]string, 0, len(tickers))
+	for _, ticker := range tickers {
+		ticker = strings.ToUpper(strings.TrimSpace(ticker))
+		if ticker != "" && !containsTicker(candidates, ticker) {
+			candidates = append(candidates, ticker)
+		}
+	}
+	if len(candidates) == 0 {
+		for ticker := range byTicker {
+			candidates = append(candidates, ticker)
+		}
+		sort.Strings(candidates)
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("в календаре нет дивидендов за последний год: загрузите корпоративные события")
+	}
+	if len(tickers) > 0 && len(candidates) > models.MaxDividendCandidates {
+		return nil, fmt.Errorf("можно передать не более %d тикеров", models.MaxDividendCandidates)
+	}
+
+	profiles := make([]models.DividendProfile, 0, len(candidates))
+	for _, ticker := range candidates {
+		profile := models.DividendProfile{Ticker: ticker}
+		if stock, err := s.stockRepo.GetStock(ctx, ticker); err == nil {
+			profile.Name, profile.Price = stock.Name, stock.Price
+		} else {
+			log.Printf("Не удалось получить цену %s для расчета дивидендной доходности: %v", ticker, err)
+		}
+
+		months := make(map[int]bool)
+		for _, event := range byTicker[ticker] {
+			payment := models.DividendPayment{
+				RecordDate: event.Date,
+				Value:      event.Value,
+				Currency:   event.Currency,
+				Upcoming:   event.Date.After(now),
+			}
+			profile.Payments = append(profile.Payments, payment)
+			if payment.Upcoming {
+				continue
+			}
+			months[int(event.Date.Month())] = true
+			if isRubleCurrency(event.Currency) {
+				profile.TrailingDividend += event.Value
+			}
+		}
+		for month := range months {
+			profile.PayoutMonths = append(profile.PayoutMonths, month)
+		}
+		sort.Ints(profile.PayoutMonths)
+
+		if profile.Price > 0 {
+			profile.TrailingYieldPerc = profile.TrailingDividend / profile.Price * 100
+		}
+		profiles = append(profiles, profile)
+	}
+
+	sort.SliceStable(profiles, func(i, j int) bool {
+		return profiles[i].TrailingYieldPerc > profiles[j].TrailingYieldPerc
+	})
+	if len(profiles) > models.MaxDividendCandidates {
+		profiles = profiles[:models.MaxDividendCandidates]
+	}
+
+	return profiles, nil
+}
+
+// uniqueDividends оставляет по одной записи на бумагу и дату закрытия реестра: одна выплата может прийти
+// и из ISS, и из файла календаря. Результат упорядочен по дате и тикеру
+func uniqueDividends(events []models.CorporateEvent) []models.CorporateEvent {
+	best := make(map[string]models.CorporateEvent, len(events))
+	for _, event := range events {
+		key := event.Ticker + "|" + event.Date.In(models.MoscowLocation).Format("2006-01-02")
+		if current, ok := best[key]; !ok || preferDividend(event, current) {
+			best[key] = event
+		}
+	}
+
+	result := make([]models.CorporateEvent, 0, len(best))
+	for _, event := range best {
+		result = append(result, event)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].Date.Equal(result[j].Date) {
+			return result[i].Date.Before(result[j].Date)
+		}
+		return result[i].Ticker < result[j].Ticker
+	})
+	return result
+}
+
+// preferDividend сообщает, что запись a о выплате полнее записи b: запись с размером дивиденда лучше
+// записи без него, а при равенстве данные MOEX надежнее файла календаря. Остальные записи упорядочиваются
+// по источнику и идентификатору, чтобы выбор не зависел от порядка выборки
+func preferDividend(a, b models.CorporateEvent) bool {
+	if (a.Value > 0) != (b.Value > 0) {
+		return a.Value > 0
+	}
+	if (a.Source == models.EventSourceMOEX) != (b.Source == models.EventSourceMOEX) {
+		return a.Source == models.EventSourceMOEX
+	}
+	if a.Source != b.Source {
+		return a.Source < b.Source
+	}
+	return a.SourceID < b.SourceID
+}
+
+// isRubleCurrency проверяет, что дивиденд выплачивается в рублях; ISS обозначает рубль как RUB или SUR
+func isRubleCurrency(currency string) bool {
+	switch strings.ToUpper(currency) {
+	case "", "RUB", "SUR":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,44 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/services"
+)
+
+// CorporateEventIngestor периодически загружает корпоративные события из источников в календарь
+type CorporateEventIngestor struct {
+	eventService services.CorporateEventService
+	interval     time.Duration
+}
+
+// NewCorporateEventIngestor создает фоновую загрузку корпоративных событий с указанным периодом
+func NewCorporateEventIngestor(eventService services.CorporateEventService, interval time.Duration) *CorporateEventIngestor {
+	return &CorporateEventIngestor{
+		eventService: eventService,
+		interval:     interval,
+	}
+}
+
+// Run загружает события до отмены контекста
+func (i *CorporateEventIngestor) Run(ctx context.Context) {
+	ticker := time.NewTicker(i.interval)
+	defer ticker.Stop()
+
+	for {
+		result, err := i.eventService.IngestEvents(ctx)
+		if err != nil {
+			log.Printf("Ошибка загрузки корпоративных событий: %v", err)
+		} else {
+			log.Printf("Загружены корпоративные события: получено %d, сохранено %d, удалено %d", result.Fetched, result.Saved, result.Removed)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
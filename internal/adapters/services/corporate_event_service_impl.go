@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/repositories"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/services"
+)
+
+// CorporateEventServiceImpl реализация интерфейса CorporateEventService
+type CorporateEventServiceImpl struct {
+	eventRepo repositories.CorporateEventRepository
+	stockRepo repositories.StockRepository // Текущие цены для расчета дивидендной доходности
+	sources   []repositories.CorporateEventSource
+	tickers   []string
+}
+
+// NewCorporateEventService создает новый экземпляр сервиса календаря корпоративных событий.
+// Источники опрашиваются по эмитентам tickers
+func NewCorporateEventService(
+	eventRepo repositories.CorporateEventRepository,
+	stockRepo repositories.StockRepository,
+	sources []repositories.CorporateEventSource,
+	tickers []string,
+) services.CorporateEventService {
+	return &CorporateEventServiceImpl{
+		eventRepo: eventRepo,
+		stockRepo: stockRepo,
+		sources:   sources,
+		tickers:   tickers,
+	}
+}
+
+// GetEventsByTicker возвращает события эмитента за период [from, to]
+func (s *CorporateEventServiceImpl) GetEventsByTicker(ctx context.Context, ticker string, from, to time.Time) ([]models.CorporateEvent, error) {
+	ticker = strings.ToUpper(strings.TrimSpace(ticker))
+	if ticker == "" {
+		return nil, fmt.Errorf("тикер не может быть пустым")
+	}
+	if err := validateEventsRange(from, to); err != nil {
+		return nil, err
+	}
+
+	return s.eventRepo.GetEvents(ctx, models.CorporateEventsFilter{
+		Ticker: ticker,
+		From:   from,
+		To:     to,
+	})
+}
+
+// GetEventsCalendar возвращает события всех эмитентов за период [from, to]; types ограничивает типы событий
+func (s *CorporateEventServiceImpl) GetEventsCalendar(ctx context.Context, from, to time.Time, types []string) ([]models.CorporateEvent, error) {
+	if err := validateEventsRange(from, to); err != nil {
+		return nil, err
+	}
+	normalized := make([]string, 0, len(types))
+	for _, eventType := range types {
+		eventType = strings.ToLower(strings.TrimSpace(eventType))
+		if !containsTicker(models.CorporateEventTypes, eventType) {
+			return nil, fmt.Errorf("неизвестный тип события %s, допустимые: %s", eventType, strings.Join(models.CorporateEventTypes, ", "))
+		}
+		normalized = append(normalized, eventType)
+	}
+
+	return s.eventRepo.GetEvents(ctx, models.CorporateEventsFilter{
+		Types: normalized,
+		From:  from,
+		To:    to,
+	})
+}
+
+// IngestEvents загружает события из всех источников и сохраняет их. События, пропавшие из источника-снимка,
+// удаляются. Недоступный источник не прерывает загрузку из остальных
+func (s *CorporateEventServiceImpl) IngestEvents(ctx context.Context) (*models.CorporateEventsIngestResult, error) {
+	result := &models.CorporateEventsIngestResult{}
+
+	for _, source := range s.sources {
+		events, err := source.GetCorporateEvents(ctx, s.tickers)
+		if err != nil {
+			log.Printf("Не удалось загрузить корпоративные события из источника %s: %v", source.Name(), err)
+			result.Failed = append(result.Failed, source.Name())
+			continue
+		}
+		result.Fetched += len(events)
+
+		saved, err := s.eventRepo.SaveEvents(ctx, events)
+		if err != nil {
+			return nil, err
+		}
+		result.Saved += saved
+
+		if snapshot, ok := source.(repositories.SnapshotCorporateEventSource); ok {
+			removed, err := s.eventRepo.DeleteMissingEvents(ctx, snapshot.EventSource(), events)
+			if err != nil {
+				return nil, err
+			}
+			result.Removed += removed
+		}
+	}
+
+	if len(s.sources) > 0 && len(result.Failed) == len(s.sources) {
+		return result, fmt.Errorf("не удалось загрузить события ни из одного источника")
+	}
+
+	return result, nil
+}
+
+// validateEventsRange проверяет период выборки корпоративных событий
+func validateEventsRange(from, to time.Time) error {
+	if to.Before(from) {
+		return fmt.Errorf("начало периода позже его окончания")
+	}
+	if to.Sub(from) > time.Duration(models.MaxEventsRangeDays)*24*time.Hour {
+		return fmt.Errorf("период не может превышать %d дней", models.MaxEventsRangeDays)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/repositories"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/services"
+)
+
+// CryptoServiceImpl реализация интерфейса CryptoService
+type CryptoServiceImpl struct {
+	source repositories.CryptoSource
+	// coins идентификаторы монет у источника котировок по тикерам
+	coins map[string]string
+}
+
+// NewCryptoService создает новый экземпляр сервиса котировок криптовалют для монет coins (тикер → идентификатор у источника)
+func NewCryptoService(source repositories.CryptoSource, coins map[string]string) services.CryptoService {
+	normalized := make(map[string]string, len(coins))
+	for symbol, id := range coins {
+		normalized[strings.ToUpper(symbol)] = id
+	}
+
+	return &CryptoServiceImpl{
+		source: source,
+		coins:  normalized,
+	}
+}
+
+// GetCryptoPrice возвращает котировку монеты по тикеру
+func (s *CryptoServiceImpl) GetCryptoPrice(ctx context.Context, symbol string) (*models.CryptoQuote, error) {
+	symbol = strings.ToUpper(strings.TrimSpace(symbol))
+	id, ok := s.coins[symbol]
+	if !ok {
+		return nil, fmt.Errorf("монета %s не настроена, доступные: %s", symbol, strings.Join(s.GetSymbols(), ", "))
+	}
+
+	quotes, err := s.source.GetCryptoQuotes(ctx, []string{id})
+	if err != nil {
+		return nil, err
+	}
+	quote, ok := quotes[id]
+	if !ok {
+		return nil, fmt.Errorf("источник котировок не знает монету %s (%s)", symbol, id)
+	}
+	quote.Symbol = symbol
+
+	return &quote, nil
+}
+
+// GetCryptoPrices возвращает котировки всех монет из конфигурации одним запросом к источнику
+func (s *CryptoServiceImpl) GetCryptoPrices(ctx context.Context) ([]models.CryptoQuote, error) {
+	symbols := s.GetSymbols()
+	ids := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		ids = append(ids, s.coins[symbol])
+	}
+
+	quotes, err := s.source.GetCryptoQuotes(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]models.CryptoQuote, 0, len(symbols))
+	for _, symbol := range symbols {
+		if quote, ok := quotes[s.coins[symbol]]; ok {
+			quote.Symbol = symbol
+			result = append(result, quote)
+		}
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("котировки криптовалют недоступны")
+	}
+
+	return result, nil
+}
+
+// GetSymbols возвращает тикеры монет из конфигурации в алфавитном порядке
+func (s *CryptoServiceImpl) GetSymbols() []string {
+	symbols := make([]string, 0, len(s.coins))
+	for symbol := range s.coins {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+	return symbols
+}
@@ -0,0 +1,141 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/config"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/services"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/cache"
+)
+
+// Шаги обогащения новостей
+const (
+	enrichStepSummary   = "summary"
+	enrichStepCategory  = "category"
+	enrichStepTranslate = "translate"
+)
+
+// newsCategories категории, по которым модель классифицирует новости
+var newsCategories = []string{
+	"банки", "нефть и газ", "металлы", "ритейл", "технологии", "телеком",
+	"энергетика", "макроэкономика", "валюта", "прочее",
+}
+
+// EnrichmentServiceImpl реализация интерфейса EnrichmentService на основе MCP sampling
+type EnrichmentServiceImpl struct {
+	cfg     config.EnrichmentConfig
+	sampler services.Sampler
+	cache   cache.Cache
+}
+
+// NewEnrichmentService создает новый экземпляр сервиса обогащения новостей
+func NewEnrichmentService(cfg config.EnrichmentConfig, sampler services.Sampler, cache cache.Cache) services.EnrichmentService {
+	return &EnrichmentServiceImpl{
+		cfg:     cfg,
+		sampler: sampler,
+		cache:   cache,
+	}
+}
+
+// Enabled сообщает, включен ли хотя бы один шаг обогащения и доступна ли модель
+func (s *EnrichmentServiceImpl) Enabled(ctx context.Context) bool {
+	if !s.cfg.Summarize && !s.cfg.Classify && s.cfg.TranslateTo == "" {
+		return false
+	}
+	return s.sampler != nil && s.sampler.Available(ctx)
+}
+
+// EnrichNews дополняет новости результатами включенных шагов обогащения
+func (s *EnrichmentServiceImpl) EnrichNews(ctx context.Context, news []models.News) []models.News {
+	if !s.Enabled(ctx) {
+		return news
+	}
+
+	// Каждый шаг — отдельный запрос к модели клиента, поэтому обрабатываем ограниченное число новостей
+	limit := len(news)
+	if s.cfg.MaxItems > 0 && s.cfg.MaxItems < limit {
+		limit = s.cfg.MaxItems
+	}
+
+	for i := 0; i < limit; i++ {
+		item := &news[i]
+
+		if s.cfg.Summarize && item.Summary == "" && len(item.Content) >= s.cfg.SummarizeMinLength {
+			item.Summary = s.runStep(ctx, enrichStepSummary,
+				"Ты финансовый редактор. Кратко перескажи новость в 2–3 предложениях на русском языке без оценок.",
+				item.Title+"\n\n"+item.Content)
+		}
+
+		// Классифицируем только «трудные» новости, по которым не удалось определить теги и тикеры
+		if s.cfg.Classify && item.Category == "" && len(item.Tags) == 0 && len(item.RelatedTo) == 0 {
+			category := s.runStep(ctx, enrichStepCategory,
+				fmt.Sprintf("Определи категорию финансовой новости. Ответь одним вариантом из списка: %s.",
+					strings.Join(newsCategories, ", ")),
+				item.Title+"\n\n"+item.Description)
+			item.Category = strings.ToLower(strings.Trim(category, " .\n"))
+		}
+
+		if s.cfg.TranslateTo != "" && item.Translation == "" {
+			item.Translation = s.runStep(ctx, enrichStepTranslate+":"+s.cfg.TranslateTo,
+				fmt.Sprintf("Переведи заголовок и краткое описание новости на язык %q. Верни только перевод.", s.cfg.TranslateTo),
+				item.Title+"\n"+item.Description)
+		}
+	}
+
+	return news
+}
+
+// runStep выполняет шаг обогащения, используя кэш результатов по хэшу содержимого
+func (s *EnrichmentServiceImpl) runStep(ctx context.Context, step, systemPrompt, text string) string {
+	cacheKey := fmt.Sprintf("enrich:%s:%s", step, contentHash(text))
+
+	var cached string
+	switch err := s.cache.Get(ctx, cacheKey, &cached); {
+	case err == nil:
+		return cached
+	case !errors.Is(err, cache.ErrNotFound):
+		log.Printf("Ошибка чтения кэша %s: %v", cacheKey, err)
+	}
+
+	stepCtx := ctx
+	if s.cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		stepCtx, cancel = context.WithTimeout(ctx, s.cfg.Timeout)
+		defer cancel()
+	}
+
+	result, err := s.sampler.CreateMessage(stepCtx, systemPrompt, text, s.cfg.MaxTokens)
+	if err != nil {
+		log.Printf("Ошибка обогащения новости (шаг %s): %v", step, err)
+		return ""
+	}
+
+	result = strings.TrimSpace(result)
+	if result != "" {
+		s.cache.Set(ctx, cacheKey, result, s.cacheTTL())
+	}
+
+	return result
+}
+
+// cacheTTL возвращает срок хранения результатов обогащения
+func (s *EnrichmentServiceImpl) cacheTTL() time.Duration {
+	if s.cfg.CacheTTL > 0 {
+		return s.cfg.CacheTTL
+	}
+	return 24 * time.Hour
+}
+
+// contentHash вычисляет хэш содержимого, по которому кэшируются результаты обогащения
+func contentHash(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:])
+}
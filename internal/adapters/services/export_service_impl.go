@@ -0,0 +1,245 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/repositories"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/services"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/progress"
+)
+
+// ExportServiceImpl реализация интерфейса ExportService
+type ExportServiceImpl struct {
+	stockService   services.StockService
+	newsService    services.NewsService
+	storage        repositories.ExportStorage // nil — выгрузки возвращаются только в ответе
+	inlineMaxBytes int
+}
+
+// NewExportService создает новый экземпляр сервиса выгрузки данных
+func NewExportService(stockService services.StockService, newsService services.NewsService, storage repositories.ExportStorage, inlineMaxBytes int) services.ExportService {
+	return &ExportServiceImpl{
+		stockService:   stockService,
+		newsService:    newsService,
+		storage:        storage,
+		inlineMaxBytes: inlineMaxBytes,
+	}
+}
+
+// Export выгружает историю котировок или новости. Свечи, которых нет в базе, репозиторий запрашивает у биржи;
+// если биржа недоступна, выгрузка завершается ошибкой. При назначении auto выгрузка больше inlineMaxBytes
+// сохраняется в хранилище, а если хранилище не настроено — возвращается ошибка с просьбой сузить период
+func (s *ExportServiceImpl) Export(ctx context.Context, request models.ExportRequest) (*models.ExportResult, error) {
+	if request.Format == "" {
+		request.Format = models.ExportCSV
+	}
+	if request.Destination == "" {
+		request.Destination = models.ExportAuto
+	}
+	if request.Destination == models.ExportStorage && s.storage == nil {
+		return nil, fmt.Errorf("хранилище выгрузок не настроено: задайте export.dir или export.s3.bucket")
+	}
+
+	var (
+		header []string
+		rows   [][]string
+		items  interface{}
+		count  int
+		name   string
+	)
+	// Этапы выгрузки: загрузка данных, формирование файла и сохранение в хранилище
+	const exportSteps = 3
+	progress.Report(ctx, 0, exportSteps, "Загрузка данных для выгрузки")
+	switch request.Dataset {
+	case models.ExportHistory:
+		quotes, err := s.stockService.GetStockHistoricalData(ctx, request.Ticker, request.Interval, request.From, request.To)
+		if err != nil {
+			return nil, err
+		}
+		header, rows = historyRows(quotes)
+		items, count = quotes, len(quotes)
+		interval := request.Interval
+		if interval == "" {
+			interval = models.IntervalDay
+		}
+		name = fmt.Sprintf("%s_%s", models.NormalizeTicker(request.Ticker), interval)
+	case models.ExportNews:
+		news, err := s.exportNews(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+		header, rows = newsRows(news)
+		items, count = news, len(news)
+		name = "news_" + exportSlug(request.Ticker+" "+request.Query)
+	default:
+		return nil, fmt.Errorf("неизвестный набор данных %q, доступны: %s, %s", request.Dataset, models.ExportHistory, models.ExportNews)
+	}
+	if count == 0 {
+		return nil, fmt.Errorf("за указанный период нет данных для выгрузки")
+	}
+
+	result := &models.ExportResult{
+		Dataset: request.Dataset,
+		Format:  request.Format,
+		Name:    fmt.Sprintf("%s_%s.%s", name, time.Now().In(models.MoscowLocation).Format("20060102_150405"), request.Format),
+		Rows:    count,
+	}
+
+	progress.Report(ctx, 1, exportSteps, "Формирование %s: строк %d", strings.ToUpper(string(request.Format)), count)
+	var data []byte
+	switch request.Format {
+	case models.ExportCSV:
+		var buf bytes.Buffer
+		writer := csv.NewWriter(&buf)
+		writer.Write(header)
+		writer.WriteAll(rows)
+		if err := writer.Error(); err != nil {
+			return nil, fmt.Errorf("ошибка формирования CSV: %w", err)
+		}
+		data, result.ContentType = buf.Bytes(), "text/csv; charset=utf-8"
+	case models.ExportJSON:
+		encoded, err := json.MarshalIndent(items, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("ошибка формирования JSON: %w", err)
+		}
+		data, result.ContentType = encoded, "application/json"
+	default:
+		return nil, fmt.Errorf("неизвестный формат %q, доступны: %s, %s", request.Format, models.ExportCSV, models.ExportJSON)
+	}
+	result.Bytes = len(data)
+
+	inline := request.Destination == models.ExportInline ||
+		(request.Destination == models.ExportAuto && len(data) <= s.inlineMaxBytes)
+	if inline {
+		if request.Destination == models.ExportInline && len(data) > s.inlineMaxBytes {
+			return nil, fmt.Errorf("выгрузка занимает %d байт, в ответ помещается не больше %d: сузьте период или выберите destination=storage", len(data), s.inlineMaxBytes)
+		}
+		result.Content = string(data)
+		return result, nil
+	}
+	if s.storage == nil {
+		return nil, fmt.Errorf("выгрузка занимает %d байт, в ответ помещается не больше %d, а хранилище выгрузок не настроено: сузьте период", len(data), s.inlineMaxBytes)
+	}
+
+	progress.Report(ctx, 2, exportSteps, "Сохранение выгрузки: %d байт", len(data))
+	location, err := s.storage.Store(ctx, result.Name, result.ContentType, data)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось сохранить выгрузку: %w", err)
+	}
+	result.Location = location
+	return result, nil
+}
+
+// exportNews возвращает новости тикера или поиска по ключевому слову за период, не больше MaxExportNews
+func (s *ExportServiceImpl) exportNews(ctx context.Context, request models.ExportRequest) ([]models.News, error) {
+	filter := models.NewsFilter{From: request.From}
+	if !request.To.IsZero() {
+		filter.To = request.To.Add(time.Nanosecond)
+	}
+
+	var news []models.News
+	switch {
+	case request.Query != "":
+		// Поиск отдает новости страницами, выгрузка собирает их до предела
+		for offset := 0; offset < models.MaxExportNews; offset += models.MaxPageLimit {
+			page, total, err := s.newsService.SearchNewsByKeyword(ctx, request.Query, filter, models.Pagination{Limit: models.MaxPageLimit, Offset: offset})
+			if err != nil {
+				return nil, err
+			}
+			news = append(news, page...)
+			if len(page) == 0 || offset+len(page) >= total {
+				break
+			}
+		}
+		if request.Ticker != "" {
+			ticker := models.NormalizeTicker(request.Ticker)
+			filtered := news[:0]
+			for _, item := range news {
+				if containsTickerInNews(item, ticker) {
+					filtered = append(filtered, item)
+				}
+			}
+			news = filtered
+		}
+	case request.Ticker != "":
+		all, err := s.newsService.GetNewsForTicker(ctx, models.NormalizeTicker(request.Ticker))
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range all {
+			if (filter.From.IsZero() || !item.PublishedAt.Before(filter.From)) && (filter.To.IsZero() || item.PublishedAt.Before(filter.To)) {
+				news = append(news, item)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("для выгрузки новостей укажите ticker или query")
+	}
+
+	if len(news) > models.MaxExportNews {
+		news = news[:models.MaxExportNews]
+	}
+	return news, nil
+}
+
+// historyRows возвращает заголовок и строки CSV свечей. Время указывается по Москве, как на бирже
+func historyRows(quotes []models.StockQuote) ([]string, [][]string) {
+	header := []string{"date", "open", "high", "low", "close", "volume"}
+	rows := make([][]string, 0, len(quotes))
+	for _, quote := range quotes {
+		date := quote.Date.Format("2006-01-02")
+		if models.IsIntraday(quote.Interval) {
+			date = quote.Date.In(models.MoscowLocation).Format("2006-01-02 15:04")
+		}
+		rows = append(rows, []string{
+			date,
+			formatExportFloat(quote.Open),
+			formatExportFloat(quote.High),
+			formatExportFloat(quote.Low),
+			formatExportFloat(quote.Close),
+			strconv.FormatInt(quote.Volume, 10),
+		})
+	}
+	return header, rows
+}
+
+// newsRows возвращает заголовок и строки CSV новостей
+func newsRows(news []models.News) ([]string, [][]string) {
+	header := []string{"id", "published_at", "source", "title", "description", "url", "tickers"}
+	rows := make([][]string, 0, len(news))
+	for _, item := range news {
+		rows = append(rows, []string{
+			item.ID,
+			item.PublishedAt.In(models.MoscowLocation).Format("2006-01-02 15:04"),
+			item.Source,
+			item.Title,
+			item.Description,
+			item.URL,
+			strings.Join(item.RelatedTo, ";"),
+		})
+	}
+	return header, rows
+}
+
+// formatExportFloat записывает число с точкой и без лишних нулей: такой формат понимают и Excel, и pandas
+func formatExportFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// exportSlug возвращает часть имени файла из тикера и запроса: латиница и кириллица, цифры и подчеркивания
+func exportSlug(value string) string {
+	slug := strings.Join(strings.FieldsFunc(strings.ToLower(value), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'а' && r <= 'я' || r == 'ё' || r >= '0' && r <= '9')
+	}), "_")
+	if runes := []rune(slug); len(runes) > 40 {
+		slug = string(runes[:40])
+	}
+	return slug
+}
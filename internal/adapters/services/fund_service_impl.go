@@ -0,0 +1,175 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/config"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/repositories"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/services"
+)
+
+// FundServiceImpl реализация интерфейса FundService
+type FundServiceImpl struct {
+	source repositories.FundSource
+	// metadata сведения о фондах по тикерам паев: встроенные, дополненные конфигурацией
+	metadata map[string]models.FundMetadata
+}
+
+// NewFundService создает новый экземпляр сервиса фондов. Сведения о фондах из конфигурации configured
+// заменяют встроенные builtin поле за полем
+func NewFundService(source repositories.FundSource, configured map[string]config.FundConfig, builtin map[string]models.FundMetadata) services.FundService {
+	metadata := make(map[string]models.FundMetadata, len(builtin)+len(configured))
+	for ticker, meta := range builtin {
+		metadata[strings.ToUpper(ticker)] = meta
+	}
+	for ticker, fund := range configured {
+		ticker = strings.ToUpper(ticker)
+		meta := metadata[ticker]
+		if fund.Manager != "" {
+			meta.Manager = fund.Manager
+		}
+		if fund.ManagementFee > 0 {
+			meta.ManagementFee = fund.ManagementFee
+		}
+		if fund.Index != "" {
+			meta.UnderlyingIndex = fund.Index
+		}
+		if fund.INAVCode != "" {
+			meta.INAVCode = strings.ToUpper(fund.INAVCode)
+		}
+		metadata[ticker] = meta
+	}
+
+	return &FundServiceImpl{
+		source:   source,
+		metadata: metadata,
+	}
+}
+
+// GetFund возвращает фонд по тикеру пая с котировкой, сведениями о фонде и iNAV; nil — фонд не найден
+func (s *FundServiceImpl) GetFund(ctx context.Context, ticker string) (*models.Fund, error) {
+	ticker = strings.ToUpper(strings.TrimSpace(ticker))
+	if ticker == "" {
+		return nil, fmt.Errorf("тикер не может быть пустым")
+	}
+
+	funds, err := s.funds(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range funds {
+		if funds[i].Ticker != ticker {
+			continue
+		}
+		fund := funds[i]
+		if fund.INAVCode != "" {
+			nav, err := s.source.GetINAV(ctx, fund.INAVCode)
+			if err != nil {
+				log.Printf("Не удалось получить iNAV фонда %s: %v", ticker, err)
+			} else if nav > 0 {
+				fund.NAV = nav
+				if fund.Price > 0 {
+					fund.PremiumPerc = (fund.Price/nav - 1) * 100
+				}
+			}
+		}
+		return &fund, nil
+	}
+
+	return nil, nil
+}
+
+// SearchFunds ищет фонды по условиям filter и возвращает страницу результатов и их общее количество
+func (s *FundServiceImpl) SearchFunds(ctx context.Context, filter models.FundFilter, page models.Pagination) ([]models.Fund, int, error) {
+	funds, err := s.funds(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var result []models.Fund
+	for _, fund := range funds {
+		if matchFund(fund, filter) {
+			result = append(result, fund)
+		}
+	}
+	sortFunds(result, filter.Sort)
+
+	start, end := page.WithDefaults().Bounds(len(result))
+	return result[start:end], len(result), nil
+}
+
+// funds возвращает паи фондов источника со сведениями о фондах
+func (s *FundServiceImpl) funds(ctx context.Context) ([]models.Fund, error) {
+	funds, err := s.source.GetFunds(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось получить список фондов: %w", err)
+	}
+
+	// Источник может вернуть закэшированный список, поэтому сведения дописываются в копию
+	result := make([]models.Fund, len(funds))
+	for i, fund := range funds {
+		fund.FundMetadata = s.metadata[fund.Ticker]
+		result[i] = fund
+	}
+
+	return result, nil
+}
+
+// matchFund проверяет, что фонд удовлетворяет условиям поиска
+func matchFund(fund models.Fund, filter models.FundFilter) bool {
+	if filter.Type != "" && fund.Type != filter.Type {
+		return false
+	}
+	if filter.Currency != "" && !strings.EqualFold(fund.Currency, filter.Currency) {
+		return false
+	}
+	if filter.MaxFee > 0 && (fund.ManagementFee == 0 || fund.ManagementFee > filter.MaxFee) {
+		return false
+	}
+	if filter.Index != "" && !containsIgnoreCase(fund.UnderlyingIndex, filter.Index) {
+		return false
+	}
+	if query := filter.Query; query != "" {
+		return containsIgnoreCase(fund.Ticker, query) || containsIgnoreCase(fund.ShortName, query) ||
+			containsIgnoreCase(fund.Name, query) || containsIgnoreCase(fund.Manager, query) ||
+			containsIgnoreCase(fund.UnderlyingIndex, query)
+	}
+	return true
+}
+
+// sortFunds упорядочивает фонды; при равенстве — по тикеру
+func sortFunds(funds []models.Fund, by string) {
+	sort.SliceStable(funds, func(i, j int) bool {
+		a, b := funds[i], funds[j]
+		switch by {
+		case models.FundSortFee:
+			// Фонды с неизвестной комиссией — в конце списка
+			feeA, feeB := a.ManagementFee, b.ManagementFee
+			if feeA == 0 {
+				feeA = math.Inf(1)
+			}
+			if feeB == 0 {
+				feeB = math.Inf(1)
+			}
+			if feeA != feeB {
+				return feeA < feeB
+			}
+		case models.FundSortChange:
+			if a.ChangePerc != b.ChangePerc {
+				return a.ChangePerc > b.ChangePerc
+			}
+		default:
+			if a.Value != b.Value {
+				return a.Value > b.Value
+			}
+		}
+		return a.Ticker < b.Ticker
+	})
+}
@@ -0,0 +1,60 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/services"
+)
+
+// GapScanner ежедневно после открытия торгов рассчитывает гэпы открытия и обновляет их в кэше,
+// чтобы get_gappers не ждал расчета по всему универсуму
+type GapScanner struct {
+	gapService   services.GapService
+	hour, minute int
+}
+
+// NewGapScanner создает ежедневный расчет гэпов; at — время по Москве
+func NewGapScanner(gapService services.GapService, at time.Time) *GapScanner {
+	return &GapScanner{
+		gapService: gapService,
+		hour:       at.Hour(),
+		minute:     at.Minute(),
+	}
+}
+
+// Run рассчитывает гэпы каждый день до отмены контекста
+func (g *GapScanner) Run(ctx context.Context) {
+	for {
+		timer := time.NewTimer(time.Until(g.next(time.Now())))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		report, err := g.gapService.ScanGaps(ctx)
+		if err != nil {
+			log.Printf("Ошибка расчета гэпов открытия: %v", err)
+			continue
+		}
+		if report.Scanned == 0 {
+			log.Printf("Гэпы открытия не рассчитаны: нет свечей сессии %s", report.Session.Format("02.01.2006"))
+			continue
+		}
+		log.Printf("Рассчитаны гэпы открытия универсума %s: проверено %d бумаг, с гэпом %d", report.Universe, report.Scanned, len(report.Gaps))
+	}
+}
+
+// next возвращает ближайшее после now время расчета
+func (g *GapScanner) next(now time.Time) time.Time {
+	now = now.In(models.MoscowLocation)
+	next := time.Date(now.Year(), now.Month(), now.Day(), g.hour, g.minute, 0, 0, models.MoscowLocation)
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
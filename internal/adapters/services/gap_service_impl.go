@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/services"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/cache"
+)
+
+// GapServiceImpl реализация интерфейса GapService. Гэпы рассчитываются раз за сессию после открытия
+// и хранятся в кэше под ключом models.GappersTodayCacheKey, поэтому общий Redis отдает их всем экземплярам сервера
+type GapServiceImpl struct {
+	stockService services.StockService
+	cache        cache.Cache
+	universe     string
+	hour, minute int
+}
+
+// NewGapService создает новый экземпляр сканера гэпов открытия по универсуму universe;
+// scanAt — время ежедневного расчета по Москве
+func NewGapService(stockService services.StockService, cache cache.Cache, universe string, scanAt time.Time) services.GapService {
+	return &GapServiceImpl{
+		stockService: stockService,
+		cache:        cache,
+		universe:     universe,
+		hour:         scanAt.Hour(),
+		minute:       scanAt.Minute(),
+	}
+}
+
+// ScanGaps рассчитывает гэпы открытия текущей сессии и сохраняет их в кэш до конца дня по Москве.
+// Пустой расчет (выходной или торги еще не начались) тоже кэшируется, чтобы каждый запрос не проверял
+// весь универсум заново, но только до ежедневного расчета, если он сегодня еще не прошел
+func (s *GapServiceImpl) ScanGaps(ctx context.Context) (*models.GapReport, error) {
+	report, err := s.stockService.GetOpeningGaps(ctx, s.universe)
+	if err != nil {
+		return nil, err
+	}
+
+	expires := report.Session.AddDate(0, 0, 1)
+	scanAt := report.Session.Add(time.Duration(s.hour)*time.Hour + time.Duration(s.minute)*time.Minute)
+	if report.Scanned == 0 && scanAt.After(report.ComputedAt) {
+		expires = scanAt
+	}
+	ttl := expires.Sub(report.ComputedAt)
+	if err := s.cache.Set(ctx, models.GappersTodayCacheKey, report, ttl); err != nil {
+		log.Printf("Ошибка записи кэша %s: %v", models.GappersTodayCacheKey, err)
+	}
+	return report, nil
+}
+
+// GetGappers возвращает гэпы текущей сессии из кэша с отбором по величине и направлению.
+// Гэпы прошлой сессии не возвращаются: они рассчитываются заново
+func (s *GapServiceImpl) GetGappers(ctx context.Context, minGapPerc float64, direction string, limit int) (*models.GapReport, error) {
+	direction = strings.ToLower(strings.TrimSpace(direction))
+	if direction != "" && direction != models.GapDirectionUp && direction != models.GapDirectionDown {
+		return nil, fmt.Errorf("неизвестное направление %s, допустимые: %s", direction, strings.Join(models.GapDirections, ", "))
+	}
+	if minGapPerc < 0 {
+		return nil, fmt.Errorf("порог гэпа не может быть отрицательным")
+	}
+	if limit <= 0 {
+		limit = models.DefaultGappersLimit
+	}
+
+	var report models.GapReport
+	err := s.cache.Get(ctx, models.GappersTodayCacheKey, &report)
+	if err != nil && !errors.Is(err, cache.ErrNotFound) {
+		log.Printf("Ошибка чтения кэша %s: %v", models.GappersTodayCacheKey, err)
+	}
+	if err != nil || !report.Session.Equal(dayStart(time.Now().In(models.MoscowLocation))) {
+		scanned, err := s.ScanGaps(ctx)
+		if err != nil {
+			return nil, err
+		}
+		report = *scanned
+	}
+
+	gaps := make([]models.Gap, 0, limit)
+	for _, gap := range report.Gaps {
+		if math.Abs(gap.GapPerc) < minGapPerc || direction != "" && gap.Direction() != direction {
+			continue
+		}
+		report.Matched++
+		if len(gaps) < limit {
+			gaps = append(gaps, gap)
+		}
+	}
+	report.Gaps = gaps
+
+	return &report, nil
+}
@@ -0,0 +1,86 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/services"
+)
+
+// healthProbeTimeout ограничение времени одной проверки
+const healthProbeTimeout = 5 * time.Second
+
+// HealthProbe проверка одной зависимости сервера
+type HealthProbe struct {
+	Name string
+	// Critical обязательная зависимость: при ее недоступности сервер не готов (/readyz отвечает 503)
+	Critical bool
+	Check    func(ctx context.Context) error
+}
+
+// HealthServiceImpl реализация интерфейса HealthService
+type HealthServiceImpl struct {
+	probes    []HealthProbe
+	startedAt time.Time
+	// cacheTTL срок, в течение которого повторные проверки возвращают прежний результат,
+	// чтобы частые запросы /readyz не нагружали базу и внешние API
+	cacheTTL time.Duration
+
+	mu   sync.Mutex
+	last *models.HealthReport
+}
+
+// NewHealthService создает сервис проверки состояния; startedAt — время запуска сервера
+func NewHealthService(startedAt time.Time, cacheTTL time.Duration, probes ...HealthProbe) services.HealthService {
+	return &HealthServiceImpl{
+		probes:    probes,
+		startedAt: startedAt,
+		cacheTTL:  cacheTTL,
+	}
+}
+
+// CheckHealth выполняет все проверки параллельно
+func (s *HealthServiceImpl) CheckHealth(ctx context.Context) *models.HealthReport {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.last != nil && time.Since(s.last.CheckedAt) < s.cacheTTL {
+		return s.last
+	}
+
+	report := &models.HealthReport{
+		CheckedAt: time.Now(),
+		Uptime:    time.Since(s.startedAt),
+		Checks:    make([]models.HealthCheck, len(s.probes)),
+	}
+
+	var wg sync.WaitGroup
+	for i, probe := range s.probes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			report.Checks[i] = runProbe(ctx, probe)
+		}()
+	}
+	wg.Wait()
+
+	s.last = report
+	return report
+}
+
+// runProbe выполняет проверку с ограничением времени
+func runProbe(ctx context.Context, probe HealthProbe) models.HealthCheck {
+	ctx, cancel := context.WithTimeout(ctx, healthProbeTimeout)
+	defer cancel()
+
+	check := models.HealthCheck{Name: probe.Name, Critical: probe.Critical, Status: models.HealthOK}
+	start := time.Now()
+	if err := probe.Check(ctx); err != nil {
+		check.Status = models.HealthFail
+		check.Error = err.Error()
+	}
+	check.Latency = time.Since(start)
+	return check
+}
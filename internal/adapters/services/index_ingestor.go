@@ -0,0 +1,44 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/services"
+)
+
+// IndexIngestor периодически загружает состав и веса биржевых индексов
+type IndexIngestor struct {
+	indexService services.IndexService
+	interval     time.Duration
+}
+
+// NewIndexIngestor создает фоновую загрузку состава индексов с указанным периодом
+func NewIndexIngestor(indexService services.IndexService, interval time.Duration) *IndexIngestor {
+	return &IndexIngestor{
+		indexService: indexService,
+		interval:     interval,
+	}
+}
+
+// Run загружает состав индексов до отмены контекста
+func (i *IndexIngestor) Run(ctx context.Context) {
+	ticker := time.NewTicker(i.interval)
+	defer ticker.Stop()
+
+	for {
+		result, err := i.indexService.IngestConstituents(ctx)
+		if err != nil {
+			log.Printf("Ошибка загрузки состава индексов: %v", err)
+		} else {
+			log.Printf("Загружен состав индексов: получено бумаг %d, сохранено %d", result.Fetched, result.Saved)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/repositories"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/services"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/workpool"
+)
+
+// IndexServiceImpl реализация интерфейса IndexService
+type IndexServiceImpl struct {
+	indexRepo repositories.IndexRepository
+	stockRepo repositories.StockRepository
+	source    repositories.IndexConstituentSource
+	indexes   []string
+	pool      *workpool.Pool // Ограничивает параллельные запросы котировок бумаг индекса
+}
+
+// NewIndexService создает новый экземпляр сервиса состава индексов
+func NewIndexService(
+	indexRepo repositories.IndexRepository,
+	stockRepo repositories.StockRepository,
+	source repositories.IndexConstituentSource,
+	indexes []string,
+	pool *workpool.Pool,
+) services.IndexService {
+	codes := make([]string, 0, len(indexes))
+	for _, index := range indexes {
+		codes = append(codes, strings.ToUpper(index))
+	}
+
+	return &IndexServiceImpl{
+		indexRepo: indexRepo,
+		stockRepo: stockRepo,
+		source:    source,
+		indexes:   codes,
+		pool:      pool,
+	}
+}
+
+// GetIndexes возвращает коды индексов, состав которых загружается
+func (s *IndexServiceImpl) GetIndexes() []string {
+	return s.indexes
+}
+
+// GetIndexConstituents возвращает состав индекса с котировками по убыванию веса
+func (s *IndexServiceImpl) GetIndexConstituents(ctx context.Context, index string) (*models.IndexComposition, error) {
+	code, err := s.findIndex(index)
+	if err != nil {
+		return nil, err
+	}
+
+	constituents, err := s.indexRepo.GetConstituents(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if len(constituents) == 0 {
+		return nil, fmt.Errorf("состав индекса %s еще не загружен", code)
+	}
+
+	composition := &models.IndexComposition{
+		Index:        code,
+		Constituents: make([]models.IndexConstituentQuote, len(constituents)),
+	}
+
+	// Котировки бумаг запрашиваются параллельно
+	s.pool.Run(ctx, len(constituents), func(ctx context.Context, i int) error {
+		quote := models.IndexConstituentQuote{IndexConstituent: constituents[i]}
+		if stock, err := s.stockRepo.GetStock(ctx, quote.Ticker); err == nil {
+			quote.Price = stock.Price
+			quote.ChangePerc = stock.ChangePerc
+			quote.Contribution = quote.Weight * stock.ChangePerc / 100
+		} else {
+			log.Printf("Не удалось получить котировку %s: %v", quote.Ticker, err)
+		}
+		quote.NoQuote = quote.Price <= 0
+		composition.Constituents[i] = quote
+		return nil
+	})
+
+	var weightedChange float64
+	for _, quote := range composition.Constituents {
+		if quote.TradeDate.After(composition.TradeDate) {
+			composition.TradeDate = quote.TradeDate
+		}
+		if quote.NoQuote {
+			continue
+		}
+		composition.TotalWeight += quote.Weight
+		weightedChange += quote.Weight * quote.ChangePerc
+	}
+	if composition.TotalWeight > 0 {
+		composition.WeightedChangePerc = weightedChange / composition.TotalWeight
+	}
+
+	return composition, nil
+}
+
+// IngestConstituents загружает состав всех индексов и сохраняет его.
+// Недоступный индекс не прерывает загрузку остальных
+func (s *IndexServiceImpl) IngestConstituents(ctx context.Context) (*models.IndexIngestResult, error) {
+	result := &models.IndexIngestResult{}
+
+	for _, index := range s.indexes {
+		constituents, err := s.source.GetIndexConstituents(ctx, index)
+		if err != nil {
+			log.Printf("Не удалось загрузить состав индекса %s: %v", index, err)
+			result.Failed = append(result.Failed, index)
+			continue
+		}
+		result.Fetched += len(constituents)
+
+		saved, err := s.indexRepo.SaveConstituents(ctx, index, constituents)
+		if err != nil {
+			return nil, err
+		}
+		result.Saved += saved
+	}
+
+	if len(s.indexes) > 0 && len(result.Failed) == len(s.indexes) {
+		return result, fmt.Errorf("не удалось загрузить состав ни одного индекса")
+	}
+
+	return result, nil
+}
+
+// findIndex возвращает код индекса из загружаемых; регистр не учитывается
+func (s *IndexServiceImpl) findIndex(index string) (string, error) {
+	code := strings.ToUpper(strings.TrimSpace(index))
+	for _, known := range s.indexes {
+		if known == code {
+			return code, nil
+		}
+	}
+	return "", fmt.Errorf("неизвестный индекс %s, доступны: %s", index, strings.Join(s.indexes, ", "))
+}
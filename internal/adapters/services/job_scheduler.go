@@ -0,0 +1,85 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// JobScheduler запускает фоновые задачи и отслеживает их состояние для проверки готовности сервера.
+// Задача, завершившаяся до отмены контекста или с паникой, считается остановленной аварийно
+type JobScheduler struct {
+	mu   sync.Mutex
+	jobs []*models.JobStatus
+}
+
+// NewJobScheduler создает планировщик фоновых задач
+func NewJobScheduler() *JobScheduler {
+	return &JobScheduler{}
+}
+
+// Go запускает задачу run в отдельной горутине; interval — период задачи, только для отчета
+func (s *JobScheduler) Go(ctx context.Context, name string, interval time.Duration, run func(ctx context.Context)) {
+	job := &models.JobStatus{
+		Name:      name,
+		Interval:  interval,
+		StartedAt: time.Now(),
+		Running:   true,
+	}
+	s.mu.Lock()
+	s.jobs = append(s.jobs, job)
+	s.mu.Unlock()
+
+	go func() {
+		defer func() {
+			reason := ""
+			if r := recover(); r != nil {
+				reason = fmt.Sprintf("паника: %v", r)
+			} else if ctx.Err() == nil {
+				reason = "задача завершилась до остановки сервера"
+			}
+			if reason != "" {
+				log.Printf("Фоновая задача %s остановлена: %s", name, reason)
+			}
+
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			job.Running = false
+			job.StoppedAt = time.Now()
+			job.Error = reason
+		}()
+
+		run(ctx)
+	}()
+}
+
+// Jobs возвращает состояние задач в порядке запуска
+func (s *JobScheduler) Jobs() []models.JobStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	jobs := make([]models.JobStatus, len(s.jobs))
+	for i, job := range s.jobs {
+		jobs[i] = *job
+	}
+	return jobs
+}
+
+// Check возвращает ошибку, если какая-то задача остановлена аварийно
+func (s *JobScheduler) Check(ctx context.Context) error {
+	var failed []string
+	for _, job := range s.Jobs() {
+		if job.Error != "" {
+			failed = append(failed, fmt.Sprintf("%s (%s)", job.Name, job.Error))
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("остановлены задачи: %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
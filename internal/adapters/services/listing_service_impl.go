@@ -0,0 +1,198 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/repositories"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/services"
+)
+
+// ListingServiceImpl реализация интерфейса ListingService.
+// Новые листинги определяются сравнением списка бумаг ISS со списком прошлой сверки,
+// объявленные размещения берутся из файла календаря
+type ListingServiceImpl struct {
+	listingRepo repositories.ListingRepository
+	source      repositories.ListingSource
+	feed        repositories.ListingFeed
+}
+
+// NewListingService создает новый экземпляр сервиса календаря размещений; feed может быть nil
+func NewListingService(
+	listingRepo repositories.ListingRepository,
+	source repositories.ListingSource,
+	feed repositories.ListingFeed,
+) services.ListingService {
+	return &ListingServiceImpl{
+		listingRepo: listingRepo,
+		source:      source,
+		feed:        feed,
+	}
+}
+
+// GetUpcomingIPOs возвращает объявленные размещения, торги по которым еще не начались
+func (s *ListingServiceImpl) GetUpcomingIPOs(ctx context.Context) ([]models.Listing, error) {
+	return s.listingRepo.GetUpcomingListings(ctx)
+}
+
+// GetRecentListings возвращает бумаги, начавшие торговаться за последние days дней
+func (s *ListingServiceImpl) GetRecentListings(ctx context.Context, days int) ([]models.Listing, error) {
+	if days <= 0 {
+		days = models.DefaultRecentListingsDays
+	}
+	if days > models.MaxRecentListingsDays {
+		return nil, fmt.Errorf("глубина поиска не может превышать %d дней", models.MaxRecentListingsDays)
+	}
+
+	return s.listingRepo.GetRecentListings(ctx, dayStart(time.Now().AddDate(0, 0, -days)))
+}
+
+// RefreshListings сверяет список бумаг MOEX с сохраненным и обновляет календарь из файла объявленных размещений.
+// При первой сверке список бумаг только сохраняется: иначе новыми листингами оказались бы все торгуемые акции
+func (s *ListingServiceImpl) RefreshListings(ctx context.Context) (*models.ListingRefreshResult, error) {
+	securities, err := s.source.GetListedSecurities(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось получить список бумаг MOEX: %w", err)
+	}
+	// Пустой ответ ISS не должен затереть список прошлой сверки
+	if len(securities) == 0 {
+		return nil, fmt.Errorf("MOEX вернула пустой список бумаг")
+	}
+
+	known, err := s.listingRepo.GetKnownSecurities(ctx)
+	if err != nil {
+		return nil, err
+	}
+	knownSet := make(map[string]bool, len(known))
+	for _, ticker := range known {
+		knownSet[ticker] = true
+	}
+
+	now := time.Now()
+	result := &models.ListingRefreshResult{
+		Securities: len(securities),
+		Baseline:   len(known) == 0,
+	}
+
+	listed := make(map[string]models.ListedSecurity, len(securities))
+	tickers := make([]string, 0, len(securities))
+	for _, security := range securities {
+		listed[security.Ticker] = security
+		tickers = append(tickers, security.Ticker)
+
+		if result.Baseline || knownSet[security.Ticker] {
+			continue
+		}
+		if err := s.markListed(ctx, security, now); err != nil {
+			return nil, err
+		}
+		result.NewListed = append(result.NewListed, security.Ticker)
+	}
+
+	// Снятые с торгов бумаги остаются в списке, чтобы их возвращение не считалось новым листингом
+	for _, ticker := range known {
+		if _, ok := listed[ticker]; !ok {
+			tickers = append(tickers, ticker)
+		}
+	}
+	sort.Strings(tickers)
+	if err := s.listingRepo.SaveKnownSecurities(ctx, tickers); err != nil {
+		return nil, err
+	}
+
+	if s.feed != nil {
+		planned, err := s.feed.GetPlannedListings(ctx)
+		if err != nil {
+			log.Printf("Не удалось прочитать календарь размещений: %v", err)
+		}
+		for _, listing := range planned {
+			if err := s.syncPlanned(ctx, listing, listed, now); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	upcoming, err := s.listingRepo.GetUpcomingListings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result.Upcoming = len(upcoming)
+
+	return result, nil
+}
+
+// markListed отмечает начало торгов бумагой, появившейся в списке MOEX. Объявленное размещение
+// переводится в торгуемые с сохранением сведений из календаря, бумага вне календаря считается прямым листингом
+func (s *ListingServiceImpl) markListed(ctx context.Context, security models.ListedSecurity, listedAt time.Time) error {
+	listing, err := s.listingRepo.GetListing(ctx, security.Ticker)
+	if err != nil {
+		return err
+	}
+	if listing != nil && listing.Status == models.ListingStatusListed {
+		return nil
+	}
+	if listing == nil {
+		listing = &models.Listing{
+			Ticker: security.Ticker,
+			Kind:   models.ListingKindDirect,
+			Source: models.ListingSourceMOEX,
+		}
+	}
+
+	applyListedSecurity(listing, security, listedAt)
+	return s.listingRepo.SaveListing(ctx, listing)
+}
+
+// syncPlanned сохраняет объявленное размещение из календаря. Если бумага уже торгуется,
+// размещение сохраняется как состоявшееся с датой начала торгов из календаря
+func (s *ListingServiceImpl) syncPlanned(ctx context.Context, planned models.Listing, listed map[string]models.ListedSecurity, now time.Time) error {
+	existing, err := s.listingRepo.GetListing(ctx, planned.Ticker)
+	if err != nil {
+		return err
+	}
+
+	if existing != nil && existing.Status == models.ListingStatusListed {
+		// Бумага могла появиться в ISS раньше, чем в календаре: дополняем листинг сведениями о размещении
+		if existing.Source != models.ListingSourceMOEX {
+			return nil
+		}
+		existing.Kind = planned.Kind
+		existing.Source = models.ListingSourceFeed
+		existing.ExpectedDate = planned.ExpectedDate
+		existing.PriceRange = planned.PriceRange
+		existing.Notes = planned.Notes
+		if planned.Name != "" {
+			existing.Name = planned.Name
+		}
+		existing.UpdatedAt = now
+		return s.listingRepo.SaveListing(ctx, existing)
+	}
+
+	listing := planned
+	listing.UpdatedAt = now
+	if security, ok := listed[planned.Ticker]; ok {
+		listedAt := planned.ExpectedDate
+		if listedAt.IsZero() || listedAt.After(now) {
+			listedAt = now
+		}
+		applyListedSecurity(&listing, security, listedAt)
+	}
+
+	return s.listingRepo.SaveListing(ctx, &listing)
+}
+
+// applyListedSecurity переводит размещение в торгуемые и дополняет его данными MOEX
+func applyListedSecurity(listing *models.Listing, security models.ListedSecurity, listedAt time.Time) {
+	if listing.Name == "" {
+		listing.Name = security.Name
+	}
+	listing.ISIN = security.ISIN
+	listing.ListingLevel = security.ListingLevel
+	listing.Status = models.ListingStatusListed
+	listing.ListedAt = listedAt
+	listing.UpdatedAt = time.Now()
+}
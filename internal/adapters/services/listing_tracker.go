@@ -0,0 +1,48 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/services"
+)
+
+// ListingTracker периодически сверяет календарь размещений со списком бумаг MOEX,
+// чтобы новые листинги обнаруживались независимо от обращений к инструментам
+type ListingTracker struct {
+	listingService services.ListingService
+	interval       time.Duration
+}
+
+// NewListingTracker создает фоновую сверку календаря размещений с указанным периодом
+func NewListingTracker(listingService services.ListingService, interval time.Duration) *ListingTracker {
+	return &ListingTracker{
+		listingService: listingService,
+		interval:       interval,
+	}
+}
+
+// Run сверяет календарь до отмены контекста
+func (t *ListingTracker) Run(ctx context.Context) {
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+
+	for {
+		result, err := t.listingService.RefreshListings(ctx)
+		switch {
+		case err != nil:
+			log.Printf("Ошибка сверки календаря размещений: %v", err)
+		case result.Baseline:
+			log.Printf("Сохранен исходный список бумаг MOEX для поиска новых листингов: %d бумаг", result.Securities)
+		case len(result.NewListed) > 0:
+			log.Printf("Новые листинги на MOEX: %v", result.NewListed)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
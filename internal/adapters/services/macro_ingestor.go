@@ -0,0 +1,44 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/services"
+)
+
+// MacroIngestor периодически загружает макроэкономические показатели из источников
+type MacroIngestor struct {
+	macroService services.MacroService
+	interval     time.Duration
+}
+
+// NewMacroIngestor создает фоновую загрузку макропоказателей с указанным периодом
+func NewMacroIngestor(macroService services.MacroService, interval time.Duration) *MacroIngestor {
+	return &MacroIngestor{
+		macroService: macroService,
+		interval:     interval,
+	}
+}
+
+// Run загружает показатели до отмены контекста
+func (i *MacroIngestor) Run(ctx context.Context) {
+	ticker := time.NewTicker(i.interval)
+	defer ticker.Stop()
+
+	for {
+		result, err := i.macroService.IngestIndicators(ctx)
+		if err != nil {
+			log.Printf("Ошибка загрузки макропоказателей: %v", err)
+		} else {
+			log.Printf("Загружены макропоказатели: получено %d, сохранено %d", result.Fetched, result.Saved)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/repositories"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/services"
+)
+
+// MacroServiceImpl реализация интерфейса MacroService
+type MacroServiceImpl struct {
+	macroRepo repositories.MacroRepository
+	sources   []repositories.MacroSource
+}
+
+// NewMacroService создает новый экземпляр сервиса макроэкономических показателей
+func NewMacroService(macroRepo repositories.MacroRepository, sources []repositories.MacroSource) services.MacroService {
+	return &MacroServiceImpl{
+		macroRepo: macroRepo,
+		sources:   sources,
+	}
+}
+
+// GetIndicator возвращает историю показателя за период [from, to]
+func (s *MacroServiceImpl) GetIndicator(ctx context.Context, code string, from, to time.Time) (*models.MacroSeries, error) {
+	spec, ok := models.FindMacroIndicator(strings.ToLower(strings.TrimSpace(code)))
+	if !ok {
+		codes := make([]string, 0, len(models.MacroIndicators))
+		for _, indicator := range models.MacroIndicators {
+			codes = append(codes, indicator.Code)
+		}
+		return nil, fmt.Errorf("неизвестный показатель %s, допустимые: %s", code, strings.Join(codes, ", "))
+	}
+	if to.Before(from) {
+		return nil, fmt.Errorf("начало периода позже его окончания")
+	}
+
+	values, err := s.macroRepo.GetIndicators(ctx, spec.Code, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.MacroSeries{Spec: spec, Values: values}, nil
+}
+
+// GetSnapshots возвращает последние значения всех показателей, по которым есть данные
+func (s *MacroServiceImpl) GetSnapshots(ctx context.Context) ([]models.MacroSnapshot, error) {
+	var snapshots []models.MacroSnapshot
+	for _, spec := range models.MacroIndicators {
+		latest, err := s.macroRepo.GetLatestIndicators(ctx, spec.Code, 2)
+		if err != nil {
+			return nil, err
+		}
+		if len(latest) == 0 {
+			continue
+		}
+
+		snapshot := models.MacroSnapshot{Spec: spec, Latest: latest[0]}
+		if len(latest) > 1 {
+			snapshot.Previous = &latest[1]
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+
+	return snapshots, nil
+}
+
+// IngestIndicators загружает показатели из всех источников и сохраняет их.
+// Недоступный источник не прерывает загрузку из остальных
+func (s *MacroServiceImpl) IngestIndicators(ctx context.Context) (*models.MacroIngestResult, error) {
+	result := &models.MacroIngestResult{}
+
+	for _, source := range s.sources {
+		indicators, err := source.GetMacroIndicators(ctx)
+		if err != nil {
+			log.Printf("Не удалось загрузить макропоказатели из источника %s: %v", source.Name(), err)
+			result.Failed = append(result.Failed, source.Name())
+			continue
+		}
+		result.Fetched += len(indicators)
+
+		saved, err := s.macroRepo.SaveIndicators(ctx, indicators)
+		if err != nil {
+			return nil, err
+		}
+		result.Saved += saved
+	}
+
+	if len(s.sources) > 0 && len(result.Failed) == len(s.sources) {
+		return result, fmt.Errorf("не удалось загрузить макропоказатели ни из одного источника")
+	}
+
+	return result, nil
+}
@@ -0,0 +1,44 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/services"
+)
+
+// MarginRefresher периодически перечитывает маржинальные списки брокеров
+type MarginRefresher struct {
+	marginService services.MarginService
+	interval      time.Duration
+}
+
+// NewMarginRefresher создает фоновое обновление маржинальных списков с указанным периодом
+func NewMarginRefresher(marginService services.MarginService, interval time.Duration) *MarginRefresher {
+	return &MarginRefresher{
+		marginService: marginService,
+		interval:      interval,
+	}
+}
+
+// Run обновляет маржинальные списки до отмены контекста
+func (r *MarginRefresher) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		count, err := r.marginService.RefreshMarginLists(ctx)
+		if err != nil {
+			log.Printf("Ошибка загрузки маржинальных списков: %v", err)
+		} else {
+			log.Printf("Загружены маржинальные списки: записей %d", count)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
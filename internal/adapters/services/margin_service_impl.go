@@ -0,0 +1,139 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/repositories"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/services"
+)
+
+// MarginServiceImpl реализация интерфейса MarginService. Списки небольшие и меняются раз в день,
+// поэтому хранятся в памяти и перечитываются из источников целиком
+type MarginServiceImpl struct {
+	sources []repositories.MarginSource
+
+	mu       sync.Mutex
+	statuses []models.MarginStatus // По тикеру и брокеру
+	loaded   bool
+}
+
+// NewMarginService создает новый экземпляр сервиса маржинальных списков
+func NewMarginService(sources []repositories.MarginSource) services.MarginService {
+	return &MarginServiceImpl{
+		sources: sources,
+	}
+}
+
+// GetMarginStatus возвращает условия маржинальной торговли бумагой у брокеров
+func (s *MarginServiceImpl) GetMarginStatus(ctx context.Context, ticker string) ([]models.MarginStatus, error) {
+	ticker = strings.ToUpper(strings.TrimSpace(ticker))
+	if ticker == "" {
+		return nil, fmt.Errorf("тикер не может быть пустым")
+	}
+
+	statuses, err := s.currentStatuses(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []models.MarginStatus
+	for _, status := range statuses {
+		if status.Ticker == ticker {
+			result = append(result, status)
+		}
+	}
+	return result, nil
+}
+
+// GetMarginableStocks возвращает страницу бумаг, доступных на стороне side, по возрастанию ставки риска:
+// первыми идут бумаги с наибольшим плечом, бумаги без ставки — в конце
+func (s *MarginServiceImpl) GetMarginableStocks(ctx context.Context, side, broker string, page models.Pagination) ([]models.MarginStatus, int, error) {
+	side = strings.ToLower(strings.TrimSpace(side))
+	if side != "" && side != models.MarginSideLong && side != models.MarginSideShort {
+		return nil, 0, fmt.Errorf("неизвестная сторона %s, допустимые: %s", side, strings.Join(models.MarginSides, ", "))
+	}
+
+	statuses, err := s.currentStatuses(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var matched []models.MarginStatus
+	for _, status := range statuses {
+		if status.Available(side) && (broker == "" || strings.EqualFold(status.Broker, broker)) {
+			matched = append(matched, status)
+		}
+	}
+
+	sort.SliceStable(matched, func(i, j int) bool {
+		iRate, jRate := matched[i].RiskRate(side), matched[j].RiskRate(side)
+		if (iRate > 0) != (jRate > 0) {
+			return iRate > 0
+		}
+		return iRate < jRate
+	})
+
+	start, end := page.WithDefaults().Bounds(len(matched))
+	return matched[start:end], len(matched), nil
+}
+
+// RefreshMarginLists перечитывает списки из всех источников. Если ни один источник не доступен,
+// остаются прежние списки
+func (s *MarginServiceImpl) RefreshMarginLists(ctx context.Context) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.reload(ctx); err != nil {
+		return 0, err
+	}
+	return len(s.statuses), nil
+}
+
+// currentStatuses возвращает загруженные списки; при первом обращении загружает их из источников
+func (s *MarginServiceImpl) currentStatuses(ctx context.Context) ([]models.MarginStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.loaded {
+		if err := s.reload(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return s.statuses, nil
+}
+
+// reload читает списки из источников; недоступный источник не прерывает загрузку из остальных.
+// Вызывается под s.mu
+func (s *MarginServiceImpl) reload(ctx context.Context) error {
+	var statuses []models.MarginStatus
+	failed := 0
+	for _, source := range s.sources {
+		list, err := source.GetMarginList(ctx)
+		if err != nil {
+			log.Printf("Не удалось загрузить маржинальные списки из источника %s: %v", source.Name(), err)
+			failed++
+			continue
+		}
+		statuses = append(statuses, list...)
+	}
+	if len(s.sources) > 0 && failed == len(s.sources) {
+		return fmt.Errorf("не удалось загрузить маржинальные списки ни из одного источника")
+	}
+
+	sort.SliceStable(statuses, func(i, j int) bool {
+		if statuses[i].Ticker != statuses[j].Ticker {
+			return statuses[i].Ticker < statuses[j].Ticker
+		}
+		return statuses[i].Broker < statuses[j].Broker
+	})
+	s.statuses = statuses
+	s.loaded = true
+
+	return nil
+}
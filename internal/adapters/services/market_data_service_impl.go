@@ -0,0 +1,208 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/repositories"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/services"
+)
+
+// MarketDataServiceImpl реализация интерфейса MarketDataService
+type MarketDataServiceImpl struct {
+	source repositories.MarketDataSource
+}
+
+// NewMarketDataService создает новый экземпляр сервиса биржевых данных реального времени
+func NewMarketDataService(source repositories.MarketDataSource) services.MarketDataService {
+	return &MarketDataServiceImpl{
+		source: source,
+	}
+}
+
+// GetOrderBook возвращает стакан заявок глубиной depth уровней с каждой стороны со спредом и дисбалансом
+func (s *MarketDataServiceImpl) GetOrderBook(ctx context.Context, ticker string, board models.TradingBoard, depth int) (*models.OrderBook, error) {
+	ticker = strings.ToUpper(strings.TrimSpace(ticker))
+	if ticker == "" {
+		return nil, fmt.Errorf("тикер не может быть пустым")
+	}
+	if depth <= 0 {
+		depth = models.DefaultOrderBookDepth
+	}
+	if depth > models.MaxOrderBookDepth {
+		return nil, fmt.Errorf("глубина стакана не может превышать %d уровней", models.MaxOrderBookDepth)
+	}
+
+	book, err := s.source.GetOrderBook(ctx, ticker, board)
+	if err != nil {
+		return nil, err
+	}
+	if len(book.Bids) == 0 && len(book.Asks) == 0 {
+		return nil, fmt.Errorf("стакан %s пуст: бумага не торгуется или данные стакана недоступны без подписки на ISS", ticker)
+	}
+
+	// Источник кэширует полный стакан, поэтому обрезаем копию, не затрагивая закэшированные данные
+	result := *book
+	if len(result.Bids) > depth {
+		result.Bids = result.Bids[:depth]
+	}
+	if len(result.Asks) > depth {
+		result.Asks = result.Asks[:depth]
+	}
+	summarizeOrderBook(&result)
+
+	return &result, nil
+}
+
+// summarizeOrderBook рассчитывает лучшие цены, спред и дисбаланс объемов по уровням стакана
+func summarizeOrderBook(book *models.OrderBook) {
+	book.BidVolume, book.AskVolume = 0, 0
+	for _, level := range book.Bids {
+		book.BidVolume += level.Quantity
+	}
+	for _, level := range book.Asks {
+		book.AskVolume += level.Quantity
+	}
+	if total := book.BidVolume + book.AskVolume; total > 0 {
+		book.Imbalance = float64(book.BidVolume-book.AskVolume) / float64(total)
+	}
+
+	if len(book.Bids) > 0 {
+		book.BestBid = book.Bids[0].Price
+	}
+	if len(book.Asks) > 0 {
+		book.BestAsk = book.Asks[0].Price
+	}
+	if book.BestBid > 0 && book.BestAsk > 0 {
+		book.MidPrice = (book.BestBid + book.BestAsk) / 2
+		book.Spread = book.BestAsk - book.BestBid
+		book.SpreadPerc = book.Spread / book.MidPrice * 100
+	}
+}
+
+// GetRecentTrades возвращает последние limit сделок; при большом limit лента сворачивается по минутам
+func (s *MarketDataServiceImpl) GetRecentTrades(ctx context.Context, ticker string, board models.TradingBoard, limit int) (*models.RecentTrades, error) {
+	ticker = strings.ToUpper(strings.TrimSpace(ticker))
+	if ticker == "" {
+		return nil, fmt.Errorf("тикер не может быть пустым")
+	}
+	if limit <= 0 {
+		limit = models.DefaultRecentTrades
+	}
+	if limit > models.MaxRecentTrades {
+		return nil, fmt.Errorf("количество сделок не может превышать %d", models.MaxRecentTrades)
+	}
+
+	trades, err := s.source.GetRecentTrades(ctx, ticker, board, limit)
+	if err != nil {
+		return nil, err
+	}
+	if len(trades) == 0 {
+		return nil, fmt.Errorf("по %s нет сделок: бумага не торгуется в текущей сессии", ticker)
+	}
+
+	result := &models.RecentTrades{
+		Ticker: ticker,
+		Trades: trades,
+		From:   trades[0].Time,
+		To:     trades[len(trades)-1].Time,
+	}
+
+	var totalQuantity int64
+	var weightedPrice float64
+	for _, trade := range trades {
+		switch trade.Side {
+		case models.TradeSideBuy:
+			result.BuyQuantity += trade.Quantity
+		case models.TradeSideSell:
+			result.SellQuantity += trade.Quantity
+		}
+		totalQuantity += trade.Quantity
+		weightedPrice += trade.Price * float64(trade.Quantity)
+		result.TotalValue += trade.Value
+	}
+	if totalQuantity > 0 {
+		result.VWAP = weightedPrice / float64(totalQuantity)
+	}
+
+	if len(trades) > models.TradesAggregationThreshold {
+		result.Minutes = aggregateTradesByMinute(trades)
+		result.Aggregated = true
+	}
+
+	return result, nil
+}
+
+// aggregateTradesByMinute сворачивает сделки в хронологическом порядке в минутные интервалы
+func aggregateTradesByMinute(trades []models.Trade) []models.TradeMinute {
+	var minutes []models.TradeMinute
+	var weightedPrice float64
+
+	for _, trade := range trades {
+		minute := trade.Time.Truncate(time.Minute)
+		if len(minutes) == 0 || !minutes[len(minutes)-1].Minute.Equal(minute) {
+			if len(minutes) > 0 {
+				finishTradeMinute(&minutes[len(minutes)-1], weightedPrice)
+			}
+			minutes = append(minutes, models.TradeMinute{
+				Minute: minute,
+				Open:   trade.Price,
+				High:   trade.Price,
+				Low:    trade.Price,
+			})
+			weightedPrice = 0
+		}
+
+		current := &minutes[len(minutes)-1]
+		current.High = math.Max(current.High, trade.Price)
+		current.Low = math.Min(current.Low, trade.Price)
+		current.Close = trade.Price
+		current.Trades++
+		current.Quantity += trade.Quantity
+		current.Value += trade.Value
+		weightedPrice += trade.Price * float64(trade.Quantity)
+		switch trade.Side {
+		case models.TradeSideBuy:
+			current.BuyQuantity += trade.Quantity
+		case models.TradeSideSell:
+			current.SellQuantity += trade.Quantity
+		}
+	}
+	if len(minutes) > 0 {
+		finishTradeMinute(&minutes[len(minutes)-1], weightedPrice)
+	}
+
+	return minutes
+}
+
+// finishTradeMinute рассчитывает средневзвешенную цену минутного интервала
+func finishTradeMinute(minute *models.TradeMinute, weightedPrice float64) {
+	if minute.Quantity > 0 {
+		minute.VWAP = weightedPrice / float64(minute.Quantity)
+	}
+}
+
+// GetIndexes возвращает текущие значения индексов; без кодов возвращаются индексы дайджеста рынка
+func (s *MarketDataServiceImpl) GetIndexes(ctx context.Context, codes []string) ([]models.MarketIndex, error) {
+	if len(codes) == 0 {
+		codes = models.DigestIndexCodes
+	}
+	normalized := make([]string, 0, len(codes))
+	for _, code := range codes {
+		normalized = append(normalized, strings.ToUpper(strings.TrimSpace(code)))
+	}
+
+	indexes, err := s.source.GetIndexes(ctx, normalized)
+	if err != nil {
+		return nil, err
+	}
+	if len(indexes) == 0 {
+		return nil, fmt.Errorf("нет данных по индексам %s", strings.Join(normalized, ", "))
+	}
+
+	return indexes, nil
+}
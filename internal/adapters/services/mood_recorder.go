@@ -0,0 +1,42 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/services"
+)
+
+// MoodRecorder периодически пересчитывает индекс настроения рынка за текущий день,
+// чтобы история индекса пополнялась независимо от обращений к инструменту
+type MoodRecorder struct {
+	moodService services.MoodService
+	interval    time.Duration
+}
+
+// NewMoodRecorder создает фоновый расчет индекса настроения с указанным периодом
+func NewMoodRecorder(moodService services.MoodService, interval time.Duration) *MoodRecorder {
+	return &MoodRecorder{
+		moodService: moodService,
+		interval:    interval,
+	}
+}
+
+// Run пересчитывает индекс до отмены контекста
+func (r *MoodRecorder) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := r.moodService.RecordMood(ctx); err != nil {
+			log.Printf("Ошибка расчета индекса настроения рынка: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
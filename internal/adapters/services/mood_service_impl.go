@@ -0,0 +1,164 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/repositories"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/services"
+)
+
+const (
+	// moodFXTicker инструмент валютного рынка MOEX, по которому оценивается курс рубля
+	moodFXTicker = "USD000UTSTOM"
+	// calmDispersionPerc разброс дневных изменений акций, соответствующий нейтральной волатильности
+	calmDispersionPerc = 1.5
+	// fxScaleChangePerc изменение курса доллара, при котором составляющая курса достигает предела
+	fxScaleChangePerc = 1.0
+)
+
+// Веса составляющих индекса настроения; недоступные составляющие исключаются с перераспределением веса
+const (
+	moodBreadthWeight    = 0.35
+	moodVolatilityWeight = 0.25
+	moodNewsWeight       = 0.25
+	moodFXWeight         = 0.15
+)
+
+// MoodServiceImpl реализация интерфейса MoodService
+type MoodServiceImpl struct {
+	moodRepo  repositories.MoodRepository
+	stockRepo repositories.StockRepository
+	newsRepo  repositories.NewsRepository
+}
+
+// NewMoodService создает новый экземпляр сервиса индекса настроения рынка
+func NewMoodService(
+	moodRepo repositories.MoodRepository,
+	stockRepo repositories.StockRepository,
+	newsRepo repositories.NewsRepository,
+) services.MoodService {
+	return &MoodServiceImpl{
+		moodRepo:  moodRepo,
+		stockRepo: stockRepo,
+		newsRepo:  newsRepo,
+	}
+}
+
+// GetMarketMood рассчитывает индекс за сегодня и возвращает его вместе с историей за historyDays дней
+func (s *MoodServiceImpl) GetMarketMood(ctx context.Context, historyDays int) (*models.MarketMoodReport, error) {
+	if historyDays <= 0 {
+		historyDays = models.DefaultMoodHistoryDays
+	}
+	if historyDays > models.MaxMoodHistoryDays {
+		return nil, fmt.Errorf("глубина истории не может превышать %d дней", models.MaxMoodHistoryDays)
+	}
+
+	today, err := s.RecordMood(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := s.moodRepo.GetMoodHistory(ctx, today.Date.AddDate(0, 0, -historyDays+1), today.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.MarketMoodReport{
+		Today:   *today,
+		History: history,
+	}, nil
+}
+
+// RecordMood рассчитывает и сохраняет индекс за сегодня
+func (s *MoodServiceImpl) RecordMood(ctx context.Context) (*models.MarketMood, error) {
+	stocks, err := s.stockRepo.GetStocks(ctx, []string{})
+	if err != nil {
+		return nil, fmt.Errorf("не удалось получить котировки: %w", err)
+	}
+	if len(stocks) == 0 {
+		return nil, fmt.Errorf("нет котировок для расчета индекса настроения")
+	}
+
+	now := time.Now()
+	mood := &models.MarketMood{
+		Date:      dayStart(now),
+		FXTicker:  moodFXTicker,
+		UpdatedAt: now,
+	}
+
+	// Ширина рынка и разброс дневных изменений
+	var sum, sumSquares float64
+	for _, stock := range stocks {
+		switch {
+		case stock.ChangePerc > 0:
+			mood.Advancers++
+		case stock.ChangePerc < 0:
+			mood.Decliners++
+		}
+		sum += stock.ChangePerc
+		sumSquares += stock.ChangePerc * stock.ChangePerc
+	}
+	if moved := mood.Advancers + mood.Decliners; moved > 0 {
+		mood.BreadthScore = float64(mood.Advancers-mood.Decliners) / float64(moved)
+	}
+	mean := sum / float64(len(stocks))
+	mood.DispersionPerc = math.Sqrt(math.Max(sumSquares/float64(len(stocks))-mean*mean, 0))
+	mood.VolatilityScore = clampScore((calmDispersionPerc - mood.DispersionPerc) / calmDispersionPerc)
+
+	// Тональность новостей дня
+	if news, err := s.newsRepo.GetNewsByDate(ctx, now); err != nil {
+		log.Printf("Не удалось получить новости для индекса настроения: %v", err)
+	} else if len(news) > 0 {
+		var total float64
+		for _, item := range news {
+			total += newsSentiment(item)
+		}
+		mood.NewsCount = len(news)
+		mood.NewsScore = total / float64(len(news))
+		mood.HasNews = true
+	}
+
+	// Курс рубля: рост курса доллара означает ослабление рубля
+	if fx, err := s.stockRepo.GetStock(ctx, moodFXTicker); err != nil {
+		log.Printf("Не удалось получить курс %s для индекса настроения: %v", moodFXTicker, err)
+	} else if fx.Price > 0 {
+		mood.FXChangePerc = fx.ChangePerc
+		mood.FXScore = clampScore(-fx.ChangePerc / fxScaleChangePerc)
+		mood.HasFX = true
+	}
+
+	mood.Score = moodScore(mood)
+	mood.Label = models.MoodLabel(mood.Score)
+
+	if err := s.moodRepo.SaveMood(ctx, mood); err != nil {
+		return nil, err
+	}
+
+	return mood, nil
+}
+
+// moodScore сводит доступные составляющие в индекс от 0 до 100
+func moodScore(mood *models.MarketMood) float64 {
+	total := moodBreadthWeight*mood.BreadthScore + moodVolatilityWeight*mood.VolatilityScore
+	weight := moodBreadthWeight + moodVolatilityWeight
+	if mood.HasNews {
+		total += moodNewsWeight * mood.NewsScore
+		weight += moodNewsWeight
+	}
+	if mood.HasFX {
+		total += moodFXWeight * mood.FXScore
+		weight += moodFXWeight
+	}
+
+	return 50 + 50*total/weight
+}
+
+// clampScore ограничивает значение составляющей диапазоном [-1, 1]
+func clampScore(value float64) float64 {
+	return math.Max(-1, math.Min(1, value))
+}
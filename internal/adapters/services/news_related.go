@@ -0,0 +1,126 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+const (
+	// relatedCandidates сколько новостей с общими тикерами или тегами оценивается при поиске связанных
+	relatedCandidates = 500
+	// minRelatedScore порог связи: один общий тикер, две общие темы или тема и похожий заголовок
+	minRelatedScore = 2
+)
+
+// GetRelatedNews возвращает новости, наиболее связанные с новостью id, в хронологическом порядке
+func (s *NewsServiceImpl) GetRelatedNews(ctx context.Context, id string, limit int) (*models.NewsRelations, error) {
+	if limit <= 0 {
+		limit = models.DefaultRelatedNews
+	}
+
+	origin, err := s.GetNewsById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates, err := s.newsRepo.FindRelatedNews(ctx, *origin,
+		origin.PublishedAt.Add(-models.RelatedNewsWindow), origin.PublishedAt.Add(models.RelatedNewsWindow), relatedCandidates)
+	if err != nil {
+		return nil, err
+	}
+
+	originWords := titleWords(origin.Title)
+	related := make([]models.RelatedNews, 0, len(candidates))
+	for _, candidate := range candidates {
+		// Перепечатки той же новости другими изданиями развития сюжета не добавляют
+		if strings.EqualFold(candidate.Title, origin.Title) {
+			continue
+		}
+
+		item := models.RelatedNews{
+			News:          candidate,
+			SharedTickers: intersect(origin.RelatedTo, candidate.RelatedTo),
+			SharedTags:    intersect(origin.Tags, candidate.Tags),
+		}
+		item.Score = 3*float64(len(item.SharedTickers)) + float64(len(item.SharedTags)) +
+			4*jaccard(originWords, titleWords(candidate.Title))
+		if item.Score >= minRelatedScore {
+			related = append(related, item)
+		}
+	}
+
+	// Из самых связанных при равной степени связи выбираем ближайшие по времени
+	distance := func(n models.News) float64 {
+		d := n.PublishedAt.Sub(origin.PublishedAt)
+		if d < 0 {
+			d = -d
+		}
+		return d.Hours()
+	}
+	sort.SliceStable(related, func(i, j int) bool {
+		if related[i].Score != related[j].Score {
+			return related[i].Score > related[j].Score
+		}
+		return distance(related[i].News) < distance(related[j].News)
+	})
+	if len(related) > limit {
+		related = related[:limit]
+	}
+	sort.SliceStable(related, func(i, j int) bool {
+		return related[i].News.PublishedAt.Before(related[j].News.PublishedAt)
+	})
+
+	return &models.NewsRelations{Origin: *origin, Related: related}, nil
+}
+
+// intersect возвращает значения a, которые есть в b, без учета регистра
+func intersect(a, b []string) []string {
+	set := make(map[string]bool, len(b))
+	for _, value := range b {
+		set[strings.ToLower(value)] = true
+	}
+	var common []string
+	for _, value := range a {
+		if key := strings.ToLower(value); set[key] {
+			common = append(common, value)
+			delete(set, key)
+		}
+	}
+	return common
+}
+
+// titleWords возвращает значимые слова заголовка, обрезанные до шести букв, чтобы разные формы слова совпадали
+func titleWords(title string) map[string]bool {
+	words := make(map[string]bool)
+	for _, word := range strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if utf8.RuneCountInString(word) < 4 {
+			continue
+		}
+		if runes := []rune(word); len(runes) > 6 {
+			word = string(runes[:6])
+		}
+		words[word] = true
+	}
+	return words
+}
+
+// jaccard коэффициент Жаккара двух множеств слов
+func jaccard(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	common := 0
+	for word := range a {
+		if b[word] {
+			common++
+		}
+	}
+	return float64(common) / float64(len(a)+len(b)-common)
+}
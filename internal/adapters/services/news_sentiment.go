@@ -0,0 +1,50 @@
+package services
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// positiveStems основы слов, характерных для позитивных финансовых новостей
+var positiveStems = []string{
+	"рост", "растут", "вырос", "подня", "повыш", "увелич", "прибыл", "рекорд", "улучш", "укреп",
+	"восстанов", "позитив", "оптимизм", "одобр", "дивиденд", "выкуп", "превыс", "ралли",
+}
+
+// negativeStems основы слов, характерных для негативных финансовых новостей
+var negativeStems = []string{
+	"паден", "упал", "упад", "сниж", "сниз", "убыт", "санкц", "обвал", "дефолт", "штраф", "кризис",
+	"ухудш", "ослаб", "негатив", "пессимизм", "распрод", "банкрот", "потер", "отзыв", "запрет", "сокращ",
+}
+
+// newsSentiment оценивает тональность новости по заголовку и описанию от -1 (негативная) до 1 (позитивная).
+// Новость без характерных слов считается нейтральной
+func newsSentiment(news models.News) float64 {
+	text := strings.ToLower(news.Title + " " + news.Description)
+	positive, negative := 0, 0
+	for _, word := range strings.FieldsFunc(text, func(r rune) bool { return !unicode.IsLetter(r) }) {
+		if hasAnyPrefix(word, positiveStems) {
+			positive++
+		}
+		if hasAnyPrefix(word, negativeStems) {
+			negative++
+		}
+	}
+
+	if positive+negative == 0 {
+		return 0
+	}
+	return float64(positive-negative) / float64(positive+negative)
+}
+
+// hasAnyPrefix проверяет, начинается ли слово с одной из основ
+func hasAnyPrefix(word string, stems []string) bool {
+	for _, stem := range stems {
+		if strings.HasPrefix(word, stem) {
+			return true
+		}
+	}
+	return false
+}
@@ -3,22 +3,34 @@ package services
 import (
 	"context"
 	"fmt"
+	"log"
+	"sort"
 	"time"
 
 	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
 	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/repositories"
 	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/services"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/listutil"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/workpool"
 )
 
 // NewsServiceImpl реализация интерфейса NewsService
 type NewsServiceImpl struct {
-	newsRepo repositories.NewsRepository
+	newsRepo     repositories.NewsRepository
+	exchangeNews repositories.ExchangeNewsSource
+	articles     repositories.ArticleSource
+	pool         *workpool.Pool // Ограничивает параллельные запросы новостей по нескольким тикерам
 }
 
-// NewNewsService создает новый экземпляр сервиса для работы с новостями
-func NewNewsService(newsRepo repositories.NewsRepository) services.NewsService {
+// NewNewsService создает новый экземпляр сервиса для работы с новостями.
+// Источник официальных сообщений биржи необязателен: при nil новости по тикеру берутся только из СМИ.
+// Без источника статей (nil) полный текст новостей недоступен
+func NewNewsService(newsRepo repositories.NewsRepository, exchangeNews repositories.ExchangeNewsSource, articles repositories.ArticleSource, pool *workpool.Pool) services.NewsService {
 	return &NewsServiceImpl{
-		newsRepo: newsRepo,
+		newsRepo:     newsRepo,
+		exchangeNews: exchangeNews,
+		articles:     articles,
+		pool:         pool,
 	}
 }
 
@@ -31,6 +43,28 @@ func (s *NewsServiceImpl) GetNewsById(ctx context.Context, id string) (*models.N
 	return s.newsRepo.GetNews(ctx, id)
 }
 
+// GetNewsFullText возвращает новость вместе с полным текстом статьи, загруженным с ее страницы
+func (s *NewsServiceImpl) GetNewsFullText(ctx context.Context, id string) (*models.NewsFullText, error) {
+	if s.articles == nil {
+		return nil, fmt.Errorf("загрузка полного текста статей не настроена")
+	}
+
+	news, err := s.GetNewsById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if news.URL == "" {
+		return nil, fmt.Errorf("у новости %s нет ссылки на статью", id)
+	}
+
+	article, err := s.articles.FetchArticle(ctx, news.URL)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось загрузить статью %s: %w", news.URL, err)
+	}
+
+	return &models.NewsFullText{News: *news, Article: *article}, nil
+}
+
 // GetNewsByDate возвращает новости за указанную дату
 func (s *NewsServiceImpl) GetNewsByDate(ctx context.Context, date time.Time) ([]models.News, error) {
 	if date.IsZero() {
@@ -40,9 +74,9 @@ func (s *NewsServiceImpl) GetNewsByDate(ctx context.Context, date time.Time) ([]
 	return s.newsRepo.GetNewsByDate(ctx, date)
 }
 
-// GetTodayNews возвращает новости за сегодняшний день
-func (s *NewsServiceImpl) GetTodayNews(ctx context.Context) ([]models.News, error) {
-	return s.newsRepo.GetNewsForToday(ctx)
+// GetTodayNews возвращает страницу новостей за сегодняшний день и их общее количество
+func (s *NewsServiceImpl) GetTodayNews(ctx context.Context, page models.Pagination) ([]models.News, int, error) {
+	return s.newsRepo.GetNewsForToday(ctx, page.WithDefaults())
 }
 
 // GetRecentNews возвращает последние новости
@@ -52,36 +86,28 @@ func (s *NewsServiceImpl) GetRecentNews(ctx context.Context, limit int) ([]model
 	}
 
 	// Получаем новости за сегодня
-	news, err := s.newsRepo.GetNewsForToday(ctx)
+	news, _, err := s.newsRepo.GetNewsForToday(ctx, models.Pagination{})
 	if err != nil {
 		return nil, err
 	}
 
-	// Сортируем по дате публикации в порядке убывания (от новых к старым)
-	// По хорошему нужно имплементировать sort.Interface, но для простоты используем bubble sort
-	n := len(news)
-	for i := 0; i < n-1; i++ {
-		for j := 0; j < n-i-1; j++ {
-			if news[j].PublishedAt.Before(news[j+1].PublishedAt) {
-				news[j], news[j+1] = news[j+1], news[j]
-			}
-		}
-	}
-
-	// Возвращаем топ N новостей
-	if limit > len(news) {
-		limit = len(news)
-	}
-	return news[:limit], nil
+	// Топ N новостей по дате публикации в порядке убывания (от новых к старым)
+	return listutil.TopN(news, func(a, b models.News) bool {
+		return a.PublishedAt.After(b.PublishedAt)
+	}, limit), nil
 }
 
-// SearchNewsByKeyword ищет новости по ключевому слову
-func (s *NewsServiceImpl) SearchNewsByKeyword(ctx context.Context, keyword string) ([]models.News, error) {
+// SearchNewsByKeyword ищет новости по ключевому слову с учетом фильтра и возвращает страницу результатов и их общее количество
+func (s *NewsServiceImpl) SearchNewsByKeyword(ctx context.Context, keyword string, filter models.NewsFilter, page models.Pagination) ([]models.News, int, error) {
 	if keyword == "" {
-		return nil, fmt.Errorf("ключевое слово не может быть пустым")
+		return nil, 0, fmt.Errorf("ключевое слово не может быть пустым")
 	}
 
-	return s.newsRepo.GetNewsByKeyword(ctx, keyword)
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		return nil, 0, fmt.Errorf("начало периода должно быть раньше его конца")
+	}
+
+	return s.newsRepo.GetNewsByKeyword(ctx, keyword, filter, page.WithDefaults())
 }
 
 // GetNewsForTicker возвращает новости, связанные с указанным тикером
@@ -90,40 +116,94 @@ func (s *NewsServiceImpl) GetNewsForTicker(ctx context.Context, ticker string) (
 		return nil, fmt.Errorf("тикер не может быть пустым")
 	}
 
-	return s.newsRepo.GetNewsByTicker(ctx, ticker)
+	news, err := s.newsRepo.GetNewsByTicker(ctx, ticker)
+	if err != nil {
+		return nil, err
+	}
+	if s.exchangeNews == nil {
+		return news, nil
+	}
+
+	// Дополняем новости СМИ официальными сообщениями биржи об эмитенте
+	announcements, err := s.exchangeNews.GetExchangeNews(ctx)
+	if err != nil {
+		log.Printf("Не удалось получить сообщения биржи для %s: %v", ticker, err)
+		return news, nil
+	}
+
+	seen := make(map[string]bool, len(news))
+	for _, item := range news {
+		seen[item.ID] = true
+	}
+	for _, item := range announcements {
+		if !seen[item.ID] && containsTickerInNews(item, ticker) {
+			news = append(news, item)
+			seen[item.ID] = true
+		}
+	}
+
+	sort.SliceStable(news, func(i, j int) bool {
+		return news[i].PublishedAt.After(news[j].PublishedAt)
+	})
+
+	return news, nil
 }
 
-// GetNewsForMultipleTickers возвращает новости, связанные с несколькими тикерами
+// GetNewsForMultipleTickers возвращает новости, связанные с несколькими тикерами, от новых к старым.
+// Новости по тикерам запрашиваются параллельно
 func (s *NewsServiceImpl) GetNewsForMultipleTickers(ctx context.Context, tickers []string) ([]models.News, error) {
 	if len(tickers) == 0 {
 		return nil, fmt.Errorf("список тикеров не может быть пустым")
 	}
 
-	// Получаем все новости за сегодня
-	allNews, err := s.newsRepo.GetNewsForToday(ctx)
+	byTicker, err := workpool.Map(ctx, s.pool, tickers, s.GetNewsForTicker)
 	if err != nil {
 		return nil, err
 	}
 
-	// Фильтруем новости, связанные с тикерами
+	// Объединяем новости тикеров без дублей
 	var result []models.News
-	seen := make(map[string]bool) // Для предотвращения дублей
-
-	for _, news := range allNews {
-		for _, ticker := range tickers {
-			if containsTickerInNews(news, ticker) {
-				if !seen[news.ID] {
-					result = append(result, news)
-					seen[news.ID] = true
-				}
-				break
+	seen := make(map[string]bool)
+	for _, news := range byTicker {
+		for _, item := range news {
+			if !seen[item.ID] {
+				result = append(result, item)
+				seen[item.ID] = true
 			}
 		}
 	}
 
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].PublishedAt.After(result[j].PublishedAt)
+	})
+
 	return result, nil
 }
 
+// BackfillNews загружает архив новостей за период [from, to]
+func (s *NewsServiceImpl) BackfillNews(ctx context.Context, from, to time.Time) (*models.NewsBackfill, error) {
+	if from.IsZero() || to.IsZero() {
+		return nil, fmt.Errorf("необходимо указать начало и конец периода")
+	}
+	if to.Before(from) {
+		return nil, fmt.Errorf("начало периода должно быть не позже его конца")
+	}
+
+	// Будущие дни загружать бессмысленно
+	if today := time.Now().UTC().Truncate(24 * time.Hour); to.After(today) {
+		to = today
+		if to.Before(from) {
+			return nil, fmt.Errorf("период целиком находится в будущем")
+		}
+	}
+
+	if days := int(to.Sub(from).Hours()/24) + 1; days > models.MaxBackfillDays {
+		return nil, fmt.Errorf("период не может быть длиннее %d дней, получено %d", models.MaxBackfillDays, days)
+	}
+
+	return s.newsRepo.BackfillNews(ctx, from, to)
+}
+
 // RefreshNews запускает обновление новостей
 func (s *NewsServiceImpl) RefreshNews(ctx context.Context) error {
 	// Реализация зависит от источника данных
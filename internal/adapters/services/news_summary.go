@@ -0,0 +1,74 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// GetNewsSummary группирует новости за сегодня по темам и возвращает по каждой теме
+// число новостей и до headlines самых свежих заголовков
+func (s *NewsServiceImpl) GetNewsSummary(ctx context.Context, headlines int) (*models.NewsSummary, error) {
+	if headlines <= 0 {
+		headlines = models.DefaultSummaryHeadlines
+	}
+
+	news, _, err := s.newsRepo.GetNewsForToday(ctx, models.Pagination{})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(news, func(i, j int) bool {
+		return news[i].PublishedAt.After(news[j].PublishedAt)
+	})
+
+	groups := make(map[string]*models.NewsTopicGroup)
+	for _, item := range news {
+		for _, topic := range newsTopics(item) {
+			group, ok := groups[topic]
+			if !ok {
+				group = &models.NewsTopicGroup{Topic: topic}
+				groups[topic] = group
+			}
+			group.Count++
+			if len(group.Headlines) < headlines {
+				group.Headlines = append(group.Headlines, item)
+			}
+		}
+	}
+
+	summary := &models.NewsSummary{
+		Date:  dayStart(time.Now()),
+		Total: len(news),
+	}
+	for _, topic := range models.NewsTopics {
+		if group, ok := groups[topic.Name]; ok {
+			summary.Groups = append(summary.Groups, *group)
+		}
+	}
+
+	// Крупные темы первыми, при равенстве — в порядке NewsTopics; «прочее» всегда в конце
+	sort.SliceStable(summary.Groups, func(i, j int) bool {
+		return summary.Groups[i].Count > summary.Groups[j].Count
+	})
+	if group, ok := groups[models.OtherNewsTopic]; ok {
+		summary.Groups = append(summary.Groups, *group)
+	}
+
+	return summary, nil
+}
+
+// newsTopics возвращает темы новости по ее тегам. Новости, сохраненные до появления тем в тегах,
+// и новости без тегов классифицируются заново по заголовку, описанию и связанным тикерам
+func newsTopics(news models.News) []string {
+	topics := models.NewsTopicsFromTags(news.Tags)
+	if len(topics) == 0 {
+		topics = models.ClassifyNewsTopics(news.Title+" "+news.Description, news.RelatedTo)
+	}
+	if len(topics) == 0 {
+		topics = []string{models.OtherNewsTopic}
+	}
+	return topics
+}
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+const (
+	// burstFactor во сколько раз новостей за день должно быть больше среднего, чтобы день считался всплеском
+	burstFactor = 2
+	// minBurstCount меньше этого числа новостей за день всплеском не считается даже при редких новостях
+	minBurstCount = 3
+)
+
+// GetNewsTimeline возвращает хронику новостей тикера по дням периода [from, to] (даты включительно,
+// по московскому времени): число новостей, свежие заголовки и отметку всплеска новостей
+func (s *NewsServiceImpl) GetNewsTimeline(ctx context.Context, ticker string, from, to time.Time, headlines int) (*models.NewsTimeline, error) {
+	ticker = strings.ToUpper(strings.TrimSpace(ticker))
+	if ticker == "" {
+		return nil, fmt.Errorf("тикер не может быть пустым")
+	}
+	if headlines <= 0 {
+		headlines = models.DefaultTimelineHeadlines
+	}
+
+	from, to = dayStart(from.In(models.MoscowLocation)), dayStart(to.In(models.MoscowLocation))
+	if to.Before(from) {
+		return nil, fmt.Errorf("начало периода должно быть не позже его конца")
+	}
+	if days := int(to.Sub(from).Hours()/24) + 1; days > models.MaxNewsTimelineDays {
+		return nil, fmt.Errorf("период не может быть длиннее %d дней, получено %d", models.MaxNewsTimelineDays, days)
+	}
+
+	found, err := s.newsRepo.GetNewsTimeline(ctx, ticker, from, to.AddDate(0, 0, 1), headlines)
+	if err != nil {
+		return nil, err
+	}
+	byDate := make(map[time.Time]models.NewsDay, len(found))
+	for _, day := range found {
+		// Время заголовков показывается по тому же московскому времени, что и дни хроники
+		for i := range day.Headlines {
+			day.Headlines[i].PublishedAt = day.Headlines[i].PublishedAt.In(models.MoscowLocation)
+		}
+		byDate[dayStart(day.Date.In(models.MoscowLocation))] = day
+	}
+
+	// Дни без новостей тоже входят в хронику, чтобы затишье было видно рядом с движениями цены
+	timeline := &models.NewsTimeline{Ticker: ticker, From: from, To: to}
+	for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+		day := byDate[date]
+		day.Date = date
+		timeline.Days = append(timeline.Days, day)
+		timeline.Total += day.Count
+	}
+
+	average := float64(timeline.Total) / float64(len(timeline.Days))
+	for i, day := range timeline.Days {
+		timeline.Days[i].Burst = day.Count >= minBurstCount && float64(day.Count) >= burstFactor*average
+	}
+
+	return timeline, nil
+}
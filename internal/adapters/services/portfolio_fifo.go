@@ -0,0 +1,98 @@
+package services
+
+import (
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// fifoLot покупка, от которой еще осталось quantity бумаг, не сопоставленных с продажами
+type fifoLot struct {
+	trade    models.PortfolioTrade
+	quantity int64
+}
+
+// matchTradesFIFO сопоставляет продажи с покупками той же бумаги в порядке FIFO и возвращает реализованные
+// лоты и оставшиеся открытыми покупки. Сделки должны идти по возрастанию времени исполнения.
+// Комиссии сделок распределяются по лотам пропорционально количеству бумаг
+func matchTradesFIFO(trades []models.PortfolioTrade) ([]models.RealizedLot, []models.OpenLot) {
+	queues := make(map[string][]fifoLot)
+	var tickers []string
+	var realized []models.RealizedLot
+
+	for _, trade := range trades {
+		if trade.Quantity <= 0 {
+			continue
+		}
+		if _, ok := queues[trade.Ticker]; !ok {
+			tickers = append(tickers, trade.Ticker)
+		}
+
+		if trade.Side != models.OrderSideSell {
+			queues[trade.Ticker] = append(queues[trade.Ticker], fifoLot{trade: trade, quantity: trade.Quantity})
+			continue
+		}
+
+		queue := queues[trade.Ticker]
+		remaining := trade.Quantity
+		for remaining > 0 && len(queue) > 0 {
+			buy := &queue[0]
+			quantity := min(remaining, buy.quantity)
+			realized = append(realized, realizeLot(buy.trade, trade, quantity))
+
+			buy.quantity -= quantity
+			remaining -= quantity
+			if buy.quantity == 0 {
+				queue = queue[1:]
+			}
+		}
+		queues[trade.Ticker] = queue
+
+		// Продано больше, чем куплено по истории: стоимость покупки остатка неизвестна
+		if remaining > 0 {
+			lot := realizeLot(models.PortfolioTrade{Ticker: trade.Ticker, ExecutedAt: trade.ExecutedAt, Quantity: remaining}, trade, remaining)
+			lot.Unmatched = true
+			realized = append(realized, lot)
+		}
+	}
+
+	var open []models.OpenLot
+	for _, ticker := range tickers {
+		for _, lot := range queues[ticker] {
+			open = append(open, models.OpenLot{
+				Ticker:   ticker,
+				Quantity: lot.quantity,
+				BoughtAt: lot.trade.ExecutedAt,
+				Price:    lot.trade.Price,
+				Opening:  lot.trade.Opening,
+			})
+		}
+	}
+
+	return realized, open
+}
+
+// realizeLot рассчитывает результат продажи quantity бумаг из покупки buy сделкой sell
+func realizeLot(buy, sell models.PortfolioTrade, quantity int64) models.RealizedLot {
+	lot := models.RealizedLot{
+		Ticker:    sell.Ticker,
+		Quantity:  quantity,
+		BoughtAt:  buy.ExecutedAt,
+		SoldAt:    sell.ExecutedAt,
+		BuyPrice:  buy.Price,
+		SellPrice: sell.Price,
+		Opening:   buy.Opening,
+	}
+
+	lot.Cost = buy.Price*float64(quantity) + shareOf(buy.Commission, quantity, buy.Quantity)
+	lot.Proceeds = sell.Price*float64(quantity) - shareOf(sell.Commission, quantity, sell.Quantity)
+	lot.Gain = lot.Proceeds - lot.Cost
+
+	return lot
+}
+
+// shareOf возвращает долю суммы total, приходящуюся на part из whole бумаг
+func shareOf(total float64, part, whole int64) float64 {
+	if whole <= 0 {
+		return 0
+	}
+	return total * float64(part) / float64(whole)
+}
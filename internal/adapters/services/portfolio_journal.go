@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// GetTradeHistory возвращает сделки портфеля за период [from, to] от новых к старым и их общее количество;
+// пустой тикер — сделки по всем бумагам, нулевое from — с начала истории
+func (s *PortfolioServiceImpl) GetTradeHistory(ctx context.Context, portfolio, ticker string, from, to time.Time, page models.Pagination) ([]models.PortfolioTrade, int, error) {
+	if !from.IsZero() && to.Before(from) {
+		return nil, 0, fmt.Errorf("начало периода позже его окончания")
+	}
+	portfolio = portfolioName(portfolio)
+
+	trades, err := s.portfolioRepo.GetTrades(ctx, portfolio, strings.ToUpper(ticker))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var result []models.PortfolioTrade
+	for i := len(trades) - 1; i >= 0; i-- {
+		if inPeriod(trades[i].ExecutedAt, from, to) {
+			result = append(result, trades[i])
+		}
+	}
+
+	start, end := page.WithDefaults().Bounds(len(result))
+	return result[start:end], len(result), nil
+}
+
+// GetRealizedPnL рассчитывает реализованный результат продаж портфеля за период [from, to] по FIFO.
+// Покупки сопоставляются по всей истории сделок, а не только за период
+func (s *PortfolioServiceImpl) GetRealizedPnL(ctx context.Context, portfolio string, from, to time.Time) (*models.RealizedPnL, error) {
+	if !from.IsZero() && to.Before(from) {
+		return nil, fmt.Errorf("начало периода позже его окончания")
+	}
+	portfolio = portfolioName(portfolio)
+
+	trades, err := s.portfolioRepo.GetTrades(ctx, portfolio, "")
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.RealizedPnL{Portfolio: portfolio, From: from, To: to}
+	for _, trade := range trades {
+		if inPeriod(trade.ExecutedAt, from, to) {
+			result.Commission += trade.Commission
+		}
+	}
+
+	realized, _ := matchTradesFIFO(trades)
+	positions := make(map[string]int)
+	for _, lot := range realized {
+		if !inPeriod(lot.SoldAt, from, to) {
+			continue
+		}
+
+		i, ok := positions[lot.Ticker]
+		if !ok {
+			i = len(result.Tickers)
+			positions[lot.Ticker] = i
+			result.Tickers = append(result.Tickers, models.TickerPnL{Ticker: lot.Ticker})
+		}
+		ticker := &result.Tickers[i]
+		ticker.Quantity += lot.Quantity
+		ticker.Proceeds += lot.Proceeds
+		ticker.Cost += lot.Cost
+		ticker.Gain += lot.Gain
+
+		result.HasUnmatched = result.HasUnmatched || lot.Unmatched
+		result.HasOpening = result.HasOpening || lot.Opening
+	}
+
+	for i := range result.Tickers {
+		ticker := &result.Tickers[i]
+		ticker.GainPerc = percent(ticker.Proceeds, ticker.Cost)
+		result.Proceeds += ticker.Proceeds
+		result.Cost += ticker.Cost
+		result.Gain += ticker.Gain
+		switch {
+		case ticker.Gain > 0:
+			result.Winners++
+		case ticker.Gain < 0:
+			result.Losers++
+		}
+	}
+	result.GainPerc = percent(result.Proceeds, result.Cost)
+
+	sort.SliceStable(result.Tickers, func(i, j int) bool {
+		return result.Tickers[i].Gain > result.Tickers[j].Gain
+	})
+
+	return result, nil
+}
+
+// inPeriod проверяет, что момент t попадает в период [from, to]; нулевое from не ограничивает начало
+func inPeriod(t, from, to time.Time) bool {
+	return (from.IsZero() || !t.Before(from)) && !t.After(to)
+}
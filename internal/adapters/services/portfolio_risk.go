@@ -0,0 +1,200 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// GetRiskReview рассчитывает концентрацию по секторам, бету и просадки текущих позиций портфеля за windowDays дней.
+// Позиции, у которых за окно меньше minCorrelationSessions сессий с ценой, отмечаются NoHistory
+// и учитываются только в концентрации
+func (s *PortfolioServiceImpl) GetRiskReview(ctx context.Context, portfolio string, windowDays int) (*models.PortfolioRiskReview, error) {
+	if windowDays <= 0 {
+		windowDays = models.DefaultRiskWindowDays
+	}
+	if windowDays > models.MaxRiskWindowDays {
+		return nil, fmt.Errorf("окно расчета не может превышать %d дней", models.MaxRiskWindowDays)
+	}
+
+	summary, err := s.GetPortfolio(ctx, portfolio)
+	if err != nil {
+		return nil, err
+	}
+	if len(summary.Positions) == 0 {
+		return nil, fmt.Errorf("портфель %s пуст", summary.Name)
+	}
+	if summary.TotalValue <= 0 {
+		return nil, fmt.Errorf("не удалось оценить стоимость портфеля %s", summary.Name)
+	}
+
+	to := time.Now()
+	review := &models.PortfolioRiskReview{
+		Portfolio:  summary.Name,
+		WindowDays: windowDays,
+		From:       dayStart(to.AddDate(0, 0, -windowDays)),
+		To:         to,
+		TotalValue: summary.TotalValue,
+	}
+
+	index, err := dailyCloses(ctx, s.stockRepo, indexTicker, review.From, review.To)
+	if err != nil {
+		log.Printf("Не удалось получить историю индекса %s: %v", indexTicker, err)
+	}
+	indexReturns := closeReturns(index)
+	review.HasIndex = len(indexReturns) >= minCorrelationSessions
+
+	// Стоимость текущего состава портфеля по датам сессий для расчета его просадки
+	portfolioValues := make(map[string]float64)
+	sessionPositions := make(map[string]int)
+	var withHistory int
+	var betaWeight float64
+
+	for _, position := range summary.Positions {
+		risk := models.PositionRisk{
+			Ticker:     position.Ticker,
+			Value:      position.Value,
+			WeightPerc: position.Value / summary.TotalValue * 100,
+		}
+		review.HHI += risk.WeightPerc * risk.WeightPerc
+
+		history, err := dailyCloses(ctx, s.stockRepo, position.Ticker, review.From, review.To)
+		if err != nil {
+			log.Printf("Не удалось получить историю %s: %v", position.Ticker, err)
+		}
+		returns := closeReturns(history)
+		if len(returns) < minCorrelationSessions {
+			risk.NoHistory = true
+			review.Positions = append(review.Positions, risk)
+			continue
+		}
+
+		closes := make([]float64, 0, len(history))
+		for _, quote := range history {
+			if quote.Close <= 0 {
+				continue
+			}
+			closes = append(closes, quote.Close)
+			date := quote.Date.Format("2006-01-02")
+			portfolioValues[date] += quote.Close * float64(position.Quantity)
+			sessionPositions[date]++
+		}
+		risk.ReturnPerc = percent(closes[len(closes)-1], closes[0])
+		risk.MaxDrawdownPerc = maxDrawdownPerc(closes)
+		risk.VolatilityPerc = stdDev(returnValues(returns)) * 100
+
+		if review.HasIndex {
+			xs, ys := alignReturns(returns, indexReturns)
+			// На постоянном ряду бета не определена, а не равна нулю
+			if _, ok := correlation(xs, ys); len(xs) >= minCorrelationSessions && ok {
+				risk.Beta = covariance(xs, ys) / covariance(ys, ys)
+				risk.HasBeta = true
+				review.Beta += risk.Beta * risk.Value
+				betaWeight += risk.Value
+			}
+		}
+
+		withHistory++
+		review.Positions = append(review.Positions, risk)
+	}
+	if betaWeight > 0 {
+		review.Beta /= betaWeight
+		review.HasBeta = true
+	}
+
+	// Просадка портфеля считается только по сессиям, в которые торговались все позиции с историей
+	dates := make([]string, 0, len(portfolioValues))
+	for date, count := range sessionPositions {
+		if count == withHistory {
+			dates = append(dates, date)
+		}
+	}
+	sort.Strings(dates)
+	if len(dates) >= 2 {
+		values := make([]float64, 0, len(dates))
+		for _, date := range dates {
+			values = append(values, portfolioValues[date])
+		}
+		review.ReturnPerc = percent(values[len(values)-1], values[0])
+		review.MaxDrawdownPerc = maxDrawdownPerc(values)
+	}
+
+	if err := s.fillSectorExposure(ctx, review); err != nil {
+		log.Printf("Не удалось рассчитать концентрацию портфеля %s по секторам: %v", review.Portfolio, err)
+	}
+
+	sort.Slice(review.Positions, func(i, j int) bool {
+		return review.Positions[i].Value > review.Positions[j].Value
+	})
+
+	return review, nil
+}
+
+// fillSectorExposure заполняет секторы позиций и доли секторов по профилям компаний
+func (s *PortfolioServiceImpl) fillSectorExposure(ctx context.Context, review *models.PortfolioRiskReview) error {
+	if s.profileRepo == nil {
+		return fmt.Errorf("профили компаний недоступны в текущей конфигурации")
+	}
+
+	tickers := make([]string, 0, len(review.Positions))
+	for _, position := range review.Positions {
+		tickers = append(tickers, position.Ticker)
+	}
+
+	profiles, err := s.profileRepo.GetCompanyProfiles(ctx, tickers)
+	if err != nil {
+		return err
+	}
+	sectors := make(map[string]string, len(profiles))
+	for _, profile := range profiles {
+		sectors[profile.Ticker] = profile.Sector
+	}
+
+	exposures := make(map[string]*models.SectorExposure)
+	for i := range review.Positions {
+		position := &review.Positions[i]
+		position.Sector = sectorName(sectors[position.Ticker])
+
+		exposure, ok := exposures[position.Sector]
+		if !ok {
+			exposure = &models.SectorExposure{Sector: position.Sector}
+			exposures[position.Sector] = exposure
+		}
+		exposure.Value += position.Value
+		exposure.WeightPerc += position.WeightPerc
+		exposure.Tickers = append(exposure.Tickers, position.Ticker)
+	}
+
+	for _, exposure := range exposures {
+		review.Sectors = append(review.Sectors, *exposure)
+	}
+	sort.Slice(review.Sectors, func(i, j int) bool {
+		return review.Sectors[i].Value > review.Sectors[j].Value
+	})
+
+	return nil
+}
+
+// maxDrawdownPerc возвращает максимальную просадку ряда от предыдущего максимума, % (отрицательное число или 0)
+func maxDrawdownPerc(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	peak := values[0]
+	drawdown := 0.0
+	for _, value := range values[1:] {
+		if value > peak {
+			peak = value
+		}
+		if dd := percent(value, peak); dd < drawdown {
+			drawdown = dd
+		}
+	}
+
+	return drawdown
+}
@@ -0,0 +1,578 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/config"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/repositories"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/services"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/workpool"
+)
+
+// stressScenarios исторические кризисные периоды российского рынка для стресс-тестов
+var stressScenarios = []models.StressScenario{
+	{
+		ID:          "feb2022",
+		Name:        "Февраль 2022",
+		Description: "Обвал 24 февраля 2022 года, санкции и приостановка торгов до 24 марта",
+		Start:       time.Date(2022, time.February, 1, 0, 0, 0, 0, time.UTC),
+		End:         time.Date(2022, time.March, 25, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		ID:          "mar2020",
+		Name:        "Март 2020",
+		Description: "Пандемия COVID-19 и развал сделки ОПЕК+, обвал нефти",
+		Start:       time.Date(2020, time.February, 19, 0, 0, 0, 0, time.UTC),
+		End:         time.Date(2020, time.March, 31, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		ID:          "dec2014",
+		Name:        "Декабрь 2014",
+		Description: "Валютный кризис: девальвация рубля и повышение ключевой ставки до 17%",
+		Start:       time.Date(2014, time.December, 1, 0, 0, 0, 0, time.UTC),
+		End:         time.Date(2014, time.December, 31, 0, 0, 0, 0, time.UTC),
+	},
+}
+
+// PortfolioServiceImpl реализация интерфейса PortfolioService
+type PortfolioServiceImpl struct {
+	portfolioRepo repositories.PortfolioRepository
+	stockRepo     repositories.StockRepository
+	profileRepo   repositories.CompanyProfileRepository // Необязателен: без него концентрация по секторам не рассчитывается
+	// securityService необязателен: без справочника лоты и шаг цены не учитываются
+	securityService services.SecurityService
+	tariff          models.CommissionTariff
+	pool            *workpool.Pool // Ограничивает параллельные запросы цен позиций
+}
+
+// NewPortfolioService создает новый экземпляр сервиса для работы с портфелями. Комиссии рассчитываются
+// по тарифу broker, лоты и шаг цены берутся из справочника бумаг securityService
+func NewPortfolioService(portfolioRepo repositories.PortfolioRepository, stockRepo repositories.StockRepository, profileRepo repositories.CompanyProfileRepository, securityService services.SecurityService, broker config.BrokerConfig, pool *workpool.Pool) services.PortfolioService {
+	return &PortfolioServiceImpl{
+		portfolioRepo:   portfolioRepo,
+		stockRepo:       stockRepo,
+		profileRepo:     profileRepo,
+		securityService: securityService,
+		tariff:          models.CommissionTariff{Perc: broker.CommissionPerc, Min: broker.MinCommission},
+		pool:            pool,
+	}
+}
+
+// GetPortfolio возвращает оценку портфеля по текущим ценам
+func (s *PortfolioServiceImpl) GetPortfolio(ctx context.Context, portfolio string) (*models.PortfolioSummary, error) {
+	portfolio = portfolioName(portfolio)
+
+	positions, err := s.portfolioRepo.GetPositions(ctx, portfolio)
+	if err != nil {
+		return nil, err
+	}
+
+	// Текущие цены и условия торгов позиций запрашиваются параллельно
+	prices := make([]float64, len(positions))
+	terms := make([]models.TradingTerms, len(positions))
+	s.pool.Run(ctx, len(positions), func(ctx context.Context, i int) error {
+		prices[i] = positions[i].AvgPrice
+		if stock, err := s.stockRepo.GetStock(ctx, positions[i].Ticker); err == nil {
+			prices[i] = stock.Price
+		} else {
+			log.Printf("Не удалось получить цену %s, используем цену покупки: %v", positions[i].Ticker, err)
+		}
+		terms[i] = s.tradingTerms(ctx, positions[i].Ticker)
+		return nil
+	})
+
+	summary := &models.PortfolioSummary{Name: portfolio}
+	for i, position := range positions {
+		value := models.PositionValue{Position: position, TradingTerms: terms[i], Price: prices[i]}
+		if terms[i].Known() {
+			value.Lots = position.Quantity / int64(terms[i].LotSize)
+			value.OddLot = terms[i].OddLot(position.Quantity)
+		}
+
+		cost := position.AvgPrice * float64(position.Quantity)
+		value.Value = value.Price * float64(position.Quantity)
+		value.PnL = value.Value - cost
+		value.PnLPerc = percent(value.Value, cost)
+		value.Commission = s.tariff.For(value.Value)
+		value.NetValue = value.Value - value.Commission
+
+		summary.Positions = append(summary.Positions, value)
+		summary.TotalValue += value.Value
+		summary.TotalCost += cost
+		summary.TotalCommission += value.Commission
+	}
+	summary.TotalPnL = summary.TotalValue - summary.TotalCost
+	summary.NetValue = summary.TotalValue - summary.TotalCommission
+
+	return summary, nil
+}
+
+// AddPosition добавляет бумаги в портфель; при нулевой цене используется текущая. Покупка сохраняется
+// в историю сделок. При dryRun изменение только рассчитывается и не сохраняется
+func (s *PortfolioServiceImpl) AddPosition(ctx context.Context, portfolio, ticker string, quantity int64, price float64, dryRun bool) (*models.PositionChange, error) {
+	if ticker == "" {
+		return nil, fmt.Errorf("тикер не может быть пустым")
+	}
+	if quantity <= 0 {
+		return nil, fmt.Errorf("количество должно быть положительным")
+	}
+	if price < 0 {
+		return nil, fmt.Errorf("цена не может быть отрицательной")
+	}
+	portfolio = portfolioName(portfolio)
+	ticker = strings.ToUpper(ticker)
+
+	terms := s.tradingTerms(ctx, ticker)
+	if price == 0 {
+		stock, err := s.stockRepo.GetStock(ctx, ticker)
+		if err != nil {
+			return nil, fmt.Errorf("не удалось получить текущую цену %s: %w", ticker, err)
+		}
+		price = stock.Price
+	} else {
+		// Сделки совершаются по ценам, кратным шагу цены
+		price = terms.RoundPrice(price)
+	}
+
+	before, err := s.portfolioRepo.GetPosition(ctx, portfolio, ticker)
+	if err != nil {
+		return nil, err
+	}
+
+	trade := models.PortfolioTrade{
+		Portfolio:  portfolio,
+		Ticker:     ticker,
+		Side:       models.OrderSideBuy,
+		Quantity:   quantity,
+		Price:      price,
+		Commission: s.tariff.For(price * float64(quantity)),
+		ExecutedAt: time.Now(),
+	}
+	return s.applyTrade(ctx, before, trade, terms.LotSize, dryRun)
+}
+
+// RemovePosition уменьшает позицию; при нулевом количестве позиция удаляется полностью.
+// Продажа по цене price, при нулевой цене — по текущей, сохраняется в историю сделок.
+// При dryRun изменение только рассчитывается и не сохраняется
+func (s *PortfolioServiceImpl) RemovePosition(ctx context.Context, portfolio, ticker string, quantity int64, price float64, dryRun bool) (*models.PositionChange, error) {
+	if ticker == "" {
+		return nil, fmt.Errorf("тикер не может быть пустым")
+	}
+	if quantity < 0 {
+		return nil, fmt.Errorf("количество не может быть отрицательным")
+	}
+	if price < 0 {
+		return nil, fmt.Errorf("цена не может быть отрицательной")
+	}
+	portfolio = portfolioName(portfolio)
+	ticker = strings.ToUpper(ticker)
+
+	before, err := s.portfolioRepo.GetPosition(ctx, portfolio, ticker)
+	if err != nil {
+		return nil, err
+	}
+	if before == nil {
+		return nil, fmt.Errorf("в портфеле %s нет позиции %s", portfolio, ticker)
+	}
+	if quantity == 0 || quantity > before.Quantity {
+		quantity = before.Quantity
+	}
+
+	// Цена продажи нужна только для истории сделок, поэтому при предпросмотре не запрашивается
+	terms := s.tradingTerms(ctx, ticker)
+	if price == 0 && !dryRun {
+		stock, err := s.stockRepo.GetStock(ctx, ticker)
+		if err != nil {
+			return nil, fmt.Errorf("не удалось получить текущую цену %s, укажите цену продажи: %w", ticker, err)
+		}
+		price = stock.Price
+	} else {
+		price = terms.RoundPrice(price)
+	}
+
+	trade := models.PortfolioTrade{
+		Portfolio:  portfolio,
+		Ticker:     ticker,
+		Side:       models.OrderSideSell,
+		Quantity:   quantity,
+		Price:      price,
+		Commission: s.tariff.For(price * float64(quantity)),
+		ExecutedAt: time.Now(),
+	}
+	return s.applyTrade(ctx, before, trade, terms.LotSize, dryRun)
+}
+
+// RecordTrade записывает в журнал сделку с указанными ценой, комиссией и датой и применяет ее к позиции.
+// Комиссия CommissionByTariff рассчитывается по тарифу брокера, нулевая дата — текущий момент.
+// При dryRun изменение только рассчитывается и не сохраняется
+func (s *PortfolioServiceImpl) RecordTrade(ctx context.Context, trade models.PortfolioTrade, dryRun bool) (*models.PositionChange, error) {
+	if trade.Ticker == "" {
+		return nil, fmt.Errorf("тикер не может быть пустым")
+	}
+	trade.Side = strings.ToLower(trade.Side)
+	if trade.Side != models.OrderSideBuy && trade.Side != models.OrderSideSell {
+		return nil, fmt.Errorf("неизвестное направление сделки %s, доступны: %s", trade.Side, strings.Join(models.OrderSides, ", "))
+	}
+	if trade.Quantity <= 0 {
+		return nil, fmt.Errorf("количество должно быть положительным")
+	}
+	if trade.Price <= 0 {
+		return nil, fmt.Errorf("цена должна быть положительной")
+	}
+	if trade.Commission < 0 && trade.Commission != models.CommissionByTariff {
+		return nil, fmt.Errorf("комиссия не может быть отрицательной")
+	}
+	now := time.Now()
+	if trade.ExecutedAt.IsZero() {
+		trade.ExecutedAt = now
+	}
+	if trade.ExecutedAt.After(now) {
+		return nil, fmt.Errorf("дата сделки не может быть в будущем")
+	}
+	trade.ID = ""
+	trade.Opening = false
+	trade.Portfolio = portfolioName(trade.Portfolio)
+	trade.Ticker = strings.ToUpper(trade.Ticker)
+
+	terms := s.tradingTerms(ctx, trade.Ticker)
+	trade.Price = terms.RoundPrice(trade.Price)
+	if trade.Commission == models.CommissionByTariff {
+		trade.Commission = s.tariff.For(trade.Price * float64(trade.Quantity))
+	}
+
+	before, err := s.portfolioRepo.GetPosition(ctx, trade.Portfolio, trade.Ticker)
+	if err != nil {
+		return nil, err
+	}
+	if trade.Side == models.OrderSideSell {
+		if before == nil {
+			return nil, fmt.Errorf("в портфеле %s нет позиции %s", trade.Portfolio, trade.Ticker)
+		}
+		if trade.Quantity > before.Quantity {
+			return nil, fmt.Errorf("в позиции %s только %d шт., продать %d нельзя", trade.Ticker, before.Quantity, trade.Quantity)
+		}
+
+		// Продажа задним числом проверяется по журналу: она уменьшает остаток на дату сделки и на все
+		// последующие, и ни один из них не должен стать отрицательным
+		trades, err := s.portfolioRepo.GetTrades(ctx, trade.Portfolio, trade.Ticker)
+		if err != nil {
+			return nil, err
+		}
+		if available := availableToSell(trades, before, trade.ExecutedAt); trade.Quantity > available {
+			return nil, fmt.Errorf("на %s в позиции %s было доступно к продаже только %d шт., продать %d нельзя",
+				trade.ExecutedAt.In(models.MoscowLocation).Format("02.01.2006"), trade.Ticker, available, trade.Quantity)
+		}
+	}
+
+	return s.applyTrade(ctx, before, trade, terms.LotSize, dryRun)
+}
+
+// availableToSell возвращает, сколько бумаг позиции можно продать сделкой, датированной at, по журналу trades,
+// упорядоченному по времени исполнения. Продажа уменьшает остаток на всех последующих датах, поэтому берется
+// наименьший остаток начиная с at. Позиция без журнала считается открытой раньше любой сделки
+func availableToSell(trades []models.PortfolioTrade, position *models.Position, at time.Time) int64 {
+	if len(trades) == 0 {
+		return position.Quantity
+	}
+
+	var held int64
+	available := int64(-1)
+	for _, trade := range trades {
+		later := trade.ExecutedAt.After(at)
+		if later && available < 0 {
+			available = held
+		}
+		if trade.Side == models.OrderSideSell {
+			held -= trade.Quantity
+		} else {
+			held += trade.Quantity
+		}
+		if later {
+			available = min(available, held)
+		}
+	}
+	if available < 0 {
+		available = held
+	}
+
+	return max(min(available, position.Quantity), 0)
+}
+
+// applyTrade применяет сделку к позиции before: покупка пересчитывает среднюю цену, продажа уменьшает
+// количество или закрывает позицию. Без dryRun позиция и сделка сохраняются
+func (s *PortfolioServiceImpl) applyTrade(ctx context.Context, before *models.Position, trade models.PortfolioTrade, lotSize int, dryRun bool) (*models.PositionChange, error) {
+	change := &models.PositionChange{Before: before, DryRun: dryRun, LotSize: lotSize, Trade: &trade}
+
+	if trade.Side == models.OrderSideBuy {
+		position := models.Position{Portfolio: trade.Portfolio, Ticker: trade.Ticker}
+		if before != nil {
+			position = *before
+		}
+
+		// Средняя цена пересчитывается с учетом новой покупки
+		total := position.Quantity + trade.Quantity
+		position.AvgPrice = (position.AvgPrice*float64(position.Quantity) + trade.Price*float64(trade.Quantity)) / float64(total)
+		position.Quantity = total
+		position.UpdatedAt = time.Now()
+		change.After = &position
+	} else if trade.Quantity < before.Quantity {
+		position := *before
+		position.Quantity -= trade.Quantity
+		position.UpdatedAt = time.Now()
+		change.After = &position
+	}
+	if dryRun {
+		return change, nil
+	}
+
+	var err error
+	if change.After == nil {
+		err = s.portfolioRepo.DeletePosition(ctx, trade.Portfolio, trade.Ticker)
+	} else {
+		err = s.portfolioRepo.SavePosition(ctx, change.After)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.recordTrade(ctx, before, &trade); err != nil {
+		return nil, err
+	}
+
+	return change, nil
+}
+
+// recordTrade сохраняет сделку в историю портфеля. Если позиция before открыта до ведения истории сделок,
+// сначала сохраняется ее начальный остаток, чтобы продажи было с чем сопоставить. Остаток датируется
+// не позже сделки, чтобы и сделка задним числом шла в журнале после него
+func (s *PortfolioServiceImpl) recordTrade(ctx context.Context, before *models.Position, trade *models.PortfolioTrade) error {
+	if before != nil {
+		trades, err := s.portfolioRepo.GetTrades(ctx, trade.Portfolio, trade.Ticker)
+		if err != nil {
+			return fmt.Errorf("позиция изменена, но сделка не записана в историю: %w", err)
+		}
+		if len(trades) == 0 {
+			openedAt := before.UpdatedAt
+			if !openedAt.Before(trade.ExecutedAt) {
+				openedAt = trade.ExecutedAt.Add(-time.Second)
+			}
+			opening := models.PortfolioTrade{
+				Portfolio:  before.Portfolio,
+				Ticker:     before.Ticker,
+				Side:       models.OrderSideBuy,
+				Quantity:   before.Quantity,
+				Price:      before.AvgPrice,
+				ExecutedAt: openedAt,
+				Opening:    true,
+			}
+			if err := s.portfolioRepo.SaveTrade(ctx, &opening); err != nil {
+				return fmt.Errorf("позиция изменена, но сделка не записана в историю: %w", err)
+			}
+		}
+	}
+
+	if err := s.portfolioRepo.SaveTrade(ctx, trade); err != nil {
+		return fmt.Errorf("позиция изменена, но сделка не записана в историю: %w", err)
+	}
+	return nil
+}
+
+// EstimateOrderCost оценивает стоимость заявки на quantity бумаг: число лотов, сумму и комиссию брокера.
+// При нулевой цене используется текущая, указанная цена округляется до шага цены
+func (s *PortfolioServiceImpl) EstimateOrderCost(ctx context.Context, ticker string, quantity int64, side string, price float64) (*models.OrderCostEstimate, error) {
+	if ticker == "" {
+		return nil, fmt.Errorf("тикер не может быть пустым")
+	}
+	if quantity <= 0 {
+		return nil, fmt.Errorf("количество должно быть положительным")
+	}
+	if price < 0 {
+		return nil, fmt.Errorf("цена не может быть отрицательной")
+	}
+	side = strings.ToLower(side)
+	if side != models.OrderSideBuy && side != models.OrderSideSell {
+		return nil, fmt.Errorf("неизвестное направление сделки %s, доступны: %s", side, strings.Join(models.OrderSides, ", "))
+	}
+	ticker = strings.ToUpper(ticker)
+
+	estimate := &models.OrderCostEstimate{
+		Ticker:       ticker,
+		Side:         side,
+		TradingTerms: s.tradingTerms(ctx, ticker),
+		Quantity:     quantity,
+		Price:        price,
+		LimitPrice:   price > 0,
+		Tariff:       s.tariff,
+	}
+
+	if estimate.LimitPrice {
+		estimate.Price = estimate.RoundPrice(price)
+	} else {
+		stock, err := s.stockRepo.GetStock(ctx, ticker)
+		if err != nil {
+			return nil, fmt.Errorf("не удалось получить текущую цену %s: %w", ticker, err)
+		}
+		estimate.Price = stock.Price
+	}
+
+	// Заявка исполняется только целыми лотами
+	estimate.Lots = estimate.TradingTerms.Lots(quantity)
+	estimate.LotQuantity = quantity
+	if estimate.Known() {
+		estimate.LotQuantity = estimate.Lots * int64(estimate.LotSize)
+	}
+
+	estimate.Amount = math.Round(estimate.Price*float64(estimate.LotQuantity)*100) / 100
+	estimate.Commission = s.tariff.For(estimate.Amount)
+	if side == models.OrderSideBuy {
+		estimate.Total = estimate.Amount + estimate.Commission
+	} else {
+		estimate.Total = estimate.Amount - estimate.Commission
+	}
+
+	return estimate, nil
+}
+
+// tradingTerms возвращает лот и шаг цены бумаги из справочника; если справочник недоступен
+// или бумаги в нем нет, условия нулевые и не учитываются
+func (s *PortfolioServiceImpl) tradingTerms(ctx context.Context, ticker string) models.TradingTerms {
+	if s.securityService == nil {
+		return models.TradingTerms{}
+	}
+
+	security, err := s.securityService.LookupSecurity(ctx, ticker)
+	if err != nil {
+		log.Printf("Не удалось получить условия торгов %s из справочника: %v", ticker, err)
+		return models.TradingTerms{}
+	}
+	if security == nil {
+		return models.TradingTerms{}
+	}
+
+	return models.TradingTerms{LotSize: security.LotSize, MinStep: security.MinStep}
+}
+
+// StressScenarios возвращает доступные стресс-сценарии
+func (s *PortfolioServiceImpl) StressScenarios() []models.StressScenario {
+	return stressScenarios
+}
+
+// StressTest применяет исторический кризисный сценарий к текущим позициям портфеля
+func (s *PortfolioServiceImpl) StressTest(ctx context.Context, portfolio, scenario string) (*models.StressTestResult, error) {
+	var selected *models.StressScenario
+	for i := range stressScenarios {
+		if strings.EqualFold(stressScenarios[i].ID, scenario) {
+			selected = &stressScenarios[i]
+			break
+		}
+	}
+	if selected == nil {
+		return nil, fmt.Errorf("неизвестный сценарий %s", scenario)
+	}
+
+	summary, err := s.GetPortfolio(ctx, portfolio)
+	if err != nil {
+		return nil, err
+	}
+	if len(summary.Positions) == 0 {
+		return nil, fmt.Errorf("портфель %s пуст", summary.Name)
+	}
+
+	result := &models.StressTestResult{
+		Portfolio:  summary.Name,
+		Scenario:   *selected,
+		TotalValue: summary.TotalValue,
+	}
+
+	// История индекса используется вместо отсутствующей истории бумаги
+	index, indexOK := windowMove(ctx, s.stockRepo, indexTicker, selected.Start, selected.End)
+
+	for _, position := range summary.Positions {
+		stress := models.PositionStress{Ticker: position.Ticker, Value: position.Value}
+
+		move, ok := windowMove(ctx, s.stockRepo, position.Ticker, selected.Start, selected.End)
+		switch {
+		case ok:
+			stress.ReturnPerc, stress.MaxDrawdownPerc = move.returnPerc, move.maxDrawdownPerc
+		case indexOK:
+			stress.ReturnPerc, stress.MaxDrawdownPerc = index.returnPerc, index.maxDrawdownPerc
+			stress.Proxy = indexTicker
+		default:
+			stress.NoData = true
+		}
+		stress.PnL = stress.Value * stress.ReturnPerc / 100
+
+		result.Positions = append(result.Positions, stress)
+		result.PnL += stress.PnL
+		if summary.TotalValue > 0 {
+			result.MaxDrawdownPerc += stress.MaxDrawdownPerc * stress.Value / summary.TotalValue
+		}
+	}
+	result.ReturnPerc = percent(summary.TotalValue+result.PnL, summary.TotalValue)
+
+	sort.Slice(result.Positions, func(i, j int) bool {
+		return result.Positions[i].PnL < result.Positions[j].PnL
+	})
+
+	return result, nil
+}
+
+// windowStats динамика бумаги за период по ценам закрытия
+type windowStats struct {
+	startPrice      float64
+	endPrice        float64
+	returnPerc      float64
+	maxDrawdownPerc float64
+}
+
+// windowMove возвращает динамику бумаги за период по ценам закрытия. Свечи периода, которых нет в базе,
+// репозиторий запрашивает у биржи; признак ok равен false, если биржа не вернула хотя бы двух сессий за период
+func windowMove(ctx context.Context, stockRepo repositories.StockRepository, ticker string, start, end time.Time) (windowStats, bool) {
+	history, err := stockRepo.GetStockHistory(ctx, ticker, models.IntervalDay, start, end)
+	if err != nil {
+		log.Printf("Не удалось получить историю %s за %s — %s: %v",
+			ticker, start.Format("2006-01-02"), end.Format("2006-01-02"), err)
+		return windowStats{}, false
+	}
+
+	var closes []models.StockQuote
+	for _, quote := range history {
+		if quote.Close > 0 && !quote.Date.Before(start) && !quote.Date.After(end.Add(24*time.Hour)) {
+			closes = append(closes, quote)
+		}
+	}
+	if len(closes) < 2 {
+		return windowStats{}, false
+	}
+	sort.Slice(closes, func(i, j int) bool {
+		return closes[i].Date.Before(closes[j].Date)
+	})
+
+	prices := make([]float64, 0, len(closes))
+	for _, quote := range closes {
+		prices = append(prices, quote.Close)
+	}
+
+	first, last := closes[0].Close, closes[len(closes)-1].Close
+	return windowStats{
+		startPrice:      first,
+		endPrice:        last,
+		returnPerc:      percent(last, first),
+		maxDrawdownPerc: maxDrawdownPerc(prices),
+	}, true
+}
+
+// portfolioName возвращает имя портфеля по умолчанию, если имя не указано
+func portfolioName(name string) string {
+	if name == "" {
+		return models.DefaultPortfolio
+	}
+	return name
+}
@@ -0,0 +1,44 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/services"
+)
+
+// PriceTargetIngestor периодически загружает целевые цены аналитиков из источников
+type PriceTargetIngestor struct {
+	targetService services.PriceTargetService
+	interval      time.Duration
+}
+
+// NewPriceTargetIngestor создает фоновую загрузку целевых цен с указанным периодом
+func NewPriceTargetIngestor(targetService services.PriceTargetService, interval time.Duration) *PriceTargetIngestor {
+	return &PriceTargetIngestor{
+		targetService: targetService,
+		interval:      interval,
+	}
+}
+
+// Run загружает целевые цены до отмены контекста
+func (i *PriceTargetIngestor) Run(ctx context.Context) {
+	ticker := time.NewTicker(i.interval)
+	defer ticker.Stop()
+
+	for {
+		result, err := i.targetService.IngestTargets(ctx)
+		if err != nil {
+			log.Printf("Ошибка загрузки целевых цен: %v", err)
+		} else {
+			log.Printf("Загружены целевые цены: получено %d, сохранено %d", result.Fetched, result.Saved)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
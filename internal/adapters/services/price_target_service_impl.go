@@ -0,0 +1,244 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/repositories"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/services"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/workpool"
+)
+
+// PriceTargetServiceImpl реализация интерфейса PriceTargetService
+type PriceTargetServiceImpl struct {
+	targetRepo repositories.PriceTargetRepository
+	stockRepo  repositories.StockRepository
+	sources    []repositories.PriceTargetSource
+	pool       *workpool.Pool // Ограничивает параллельные запросы котировок
+}
+
+// NewPriceTargetService создает новый экземпляр сервиса целевых цен.
+// Источники необязательны: без них цели задает только пользователь
+func NewPriceTargetService(
+	targetRepo repositories.PriceTargetRepository,
+	stockRepo repositories.StockRepository,
+	sources []repositories.PriceTargetSource,
+	pool *workpool.Pool,
+) services.PriceTargetService {
+	return &PriceTargetServiceImpl{
+		targetRepo: targetRepo,
+		stockRepo:  stockRepo,
+		sources:    sources,
+		pool:       pool,
+	}
+}
+
+// SetPriceTarget устанавливает целевую цену бумаги; нулевая цель удаляет прежнюю цель того же аналитика.
+// При dryRun изменение только рассчитывается и не сохраняется
+func (s *PriceTargetServiceImpl) SetPriceTarget(ctx context.Context, target models.PriceTarget, dryRun bool) (*models.PriceTargetChange, error) {
+	if target.Ticker == "" {
+		return nil, fmt.Errorf("тикер не может быть пустым")
+	}
+	if target.Target < 0 {
+		return nil, fmt.Errorf("целевая цена не может быть отрицательной")
+	}
+	target.Ticker = strings.ToUpper(target.Ticker)
+	target.Source = models.PriceTargetSourceUser
+	target.Analyst = strings.TrimSpace(target.Analyst)
+	target.Rating = strings.ToLower(target.Rating)
+	if target.Rating != "" && !slices.Contains(models.PriceTargetRatings, target.Rating) {
+		return nil, fmt.Errorf("неизвестная рекомендация %s, допустимы: %s", target.Rating, strings.Join(models.PriceTargetRatings, ", "))
+	}
+
+	before, err := s.targetRepo.GetTarget(ctx, target.Ticker, target.Source, target.Analyst)
+	if err != nil {
+		return nil, err
+	}
+
+	// Нулевая цель снимает прежнюю
+	if target.Target == 0 {
+		if before == nil {
+			return nil, fmt.Errorf("целевая цена %s %s не задана", target.Ticker, targetAuthor(target))
+		}
+
+		change := &models.PriceTargetChange{Before: before, DryRun: dryRun}
+		if dryRun {
+			return change, nil
+		}
+		if err := s.targetRepo.DeleteTarget(ctx, target.Ticker, target.Source, target.Analyst); err != nil {
+			return nil, err
+		}
+		return change, nil
+	}
+
+	stock, err := s.stockRepo.GetStock(ctx, target.Ticker)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось получить котировку %s: %w", target.Ticker, err)
+	}
+	target.PriceAtSet = stock.Price
+	target.SetAt = time.Now()
+
+	change := &models.PriceTargetChange{Before: before, After: &target, DryRun: dryRun}
+	if dryRun {
+		return change, nil
+	}
+
+	if _, err := s.targetRepo.SaveTargets(ctx, []models.PriceTarget{target}); err != nil {
+		return nil, err
+	}
+
+	return change, nil
+}
+
+// GetPriceTargetVsMarket сравнивает целевые цены и консенсус с текущими котировками по убыванию потенциала роста.
+// Бумаги без котировки или без актуальных целей идут в конце
+func (s *PriceTargetServiceImpl) GetPriceTargetVsMarket(ctx context.Context, tickers []string) ([]models.PriceTargetComparison, error) {
+	if len(tickers) > models.MaxPriceTargetTickers {
+		return nil, fmt.Errorf("не более %d бумаг в одном сравнении", models.MaxPriceTargetTickers)
+	}
+
+	var targets []models.PriceTarget
+	if len(tickers) == 0 {
+		all, err := s.targetRepo.GetTargets(ctx, "")
+		if err != nil {
+			return nil, err
+		}
+		targets = all
+	} else {
+		seen := make(map[string]bool)
+		for _, ticker := range tickers {
+			ticker = strings.ToUpper(strings.TrimSpace(ticker))
+			if ticker == "" || seen[ticker] {
+				continue
+			}
+			seen[ticker] = true
+
+			tickerTargets, err := s.targetRepo.GetTargets(ctx, ticker)
+			if err != nil {
+				return nil, err
+			}
+			if len(tickerTargets) == 0 {
+				// Бумага без целей попадает в отчет, чтобы было видно, что цели не заданы
+				tickerTargets = []models.PriceTarget{{Ticker: ticker}}
+			}
+			targets = append(targets, tickerTargets...)
+		}
+	}
+
+	// Цели сгруппированы по тикеру в порядке выборки
+	var comparisons []models.PriceTargetComparison
+	positions := make(map[string]int)
+	for _, target := range targets {
+		i, ok := positions[target.Ticker]
+		if !ok {
+			i = len(comparisons)
+			positions[target.Ticker] = i
+			comparisons = append(comparisons, models.PriceTargetComparison{Ticker: target.Ticker})
+		}
+		if target.Target > 0 {
+			comparisons[i].Targets = append(comparisons[i].Targets, target)
+		}
+	}
+
+	// Котировки бумаг запрашиваются параллельно
+	now := time.Now()
+	s.pool.Run(ctx, len(comparisons), func(ctx context.Context, i int) error {
+		comparison := &comparisons[i]
+		if stock, err := s.stockRepo.GetStock(ctx, comparison.Ticker); err == nil {
+			comparison.Name = stock.Name
+			comparison.Price = stock.Price
+		} else {
+			log.Printf("Не удалось получить котировку %s: %v", comparison.Ticker, err)
+		}
+		comparison.NoQuote = comparison.Price <= 0
+		summarizeTargets(comparison, now)
+		return nil
+	})
+
+	sort.SliceStable(comparisons, func(i, j int) bool {
+		iRanked := !comparisons[i].NoQuote && comparisons[i].Fresh > 0
+		jRanked := !comparisons[j].NoQuote && comparisons[j].Fresh > 0
+		if iRanked != jRanked {
+			return iRanked
+		}
+		return comparisons[i].UpsidePerc > comparisons[j].UpsidePerc
+	})
+
+	return comparisons, nil
+}
+
+// IngestTargets загружает целевые цены из всех источников и сохраняет их
+func (s *PriceTargetServiceImpl) IngestTargets(ctx context.Context) (*models.PriceTargetsIngestResult, error) {
+	result := &models.PriceTargetsIngestResult{}
+
+	for _, source := range s.sources {
+		targets, err := source.GetPriceTargets(ctx)
+		if err != nil {
+			log.Printf("Не удалось загрузить целевые цены из источника %s: %v", source.Name(), err)
+			result.Failed = append(result.Failed, source.Name())
+			continue
+		}
+		result.Fetched += len(targets)
+
+		saved, err := s.targetRepo.SaveTargets(ctx, targets)
+		if err != nil {
+			return nil, err
+		}
+		result.Saved += saved
+	}
+
+	if len(s.sources) > 0 && len(result.Failed) == len(s.sources) {
+		return result, fmt.Errorf("не удалось загрузить целевые цены ни из одного источника")
+	}
+
+	return result, nil
+}
+
+// summarizeTargets рассчитывает консенсус по актуальным на момент now целям и потенциал до него
+func summarizeTargets(c *models.PriceTargetComparison, now time.Time) {
+	var values []float64
+	for _, target := range c.Targets {
+		if target.Stale(now) {
+			continue
+		}
+		values = append(values, target.Target)
+
+		switch target.Rating {
+		case models.PriceTargetRatingBuy:
+			c.Buy++
+		case models.PriceTargetRatingHold:
+			c.Hold++
+		case models.PriceTargetRatingSell:
+			c.Sell++
+		}
+	}
+
+	c.Fresh = len(values)
+	if c.Fresh == 0 {
+		return
+	}
+
+	sort.Float64s(values)
+	c.Consensus = meanOf(values)
+	c.Low = values[0]
+	c.High = values[len(values)-1]
+	c.Median = values[len(values)/2]
+	if len(values)%2 == 0 {
+		c.Median = (values[len(values)/2-1] + values[len(values)/2]) / 2
+	}
+	c.UpsidePerc = c.UpsideTo(c.Consensus)
+}
+
+// targetAuthor описывает автора цели для сообщений об ошибках
+func targetAuthor(target models.PriceTarget) string {
+	if target.Analyst == "" {
+		return "пользователя"
+	}
+	return "от " + target.Analyst
+}
@@ -0,0 +1,67 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/services"
+)
+
+// QuotePoller периодически запрашивает котировки бумаг, на которые подписаны клиенты,
+// и передает их получателю. Пока подписок нет, биржа не опрашивается
+type QuotePoller struct {
+	stockService services.StockService
+	interval     time.Duration
+	tickers      func() []string
+	publish      func(stocks []models.Stock)
+}
+
+// NewQuotePoller создает фоновый опрос котировок с указанным периодом.
+// tickers возвращает бумаги всех подписок, publish получает их свежие котировки
+func NewQuotePoller(
+	stockService services.StockService,
+	interval time.Duration,
+	tickers func() []string,
+	publish func(stocks []models.Stock),
+) *QuotePoller {
+	return &QuotePoller{
+		stockService: stockService,
+		interval:     interval,
+		tickers:      tickers,
+		publish:      publish,
+	}
+}
+
+// Run выполняет опрос до отмены контекста
+func (p *QuotePoller) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		p.poll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll выполняет один опрос котировок подписанных бумаг
+func (p *QuotePoller) poll(ctx context.Context) {
+	tickers := p.tickers()
+	if len(tickers) == 0 {
+		return
+	}
+
+	stocks, err := p.stockService.GetMultipleStocks(ctx, tickers)
+	if err != nil {
+		log.Printf("Ошибка опроса котировок подписок: %v", err)
+		return
+	}
+
+	p.publish(stocks)
+}
@@ -0,0 +1,44 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/rawarchive"
+)
+
+// RawArchivePruner периодически удаляет из архива ответы старше срока хранения
+type RawArchivePruner struct {
+	archive  *rawarchive.Archive
+	interval time.Duration
+}
+
+// NewRawArchivePruner создает фоновую очистку архива ответов
+func NewRawArchivePruner(archive *rawarchive.Archive, interval time.Duration) *RawArchivePruner {
+	return &RawArchivePruner{
+		archive:  archive,
+		interval: interval,
+	}
+}
+
+// Run очищает архив до отмены контекста
+func (p *RawArchivePruner) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		removed, err := p.archive.Prune(time.Now())
+		if err != nil {
+			log.Printf("Ошибка очистки архива ответов: %v", err)
+		} else if removed > 0 {
+			log.Printf("Из архива ответов удалено дней: %d", removed)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
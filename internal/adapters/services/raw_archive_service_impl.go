@@ -0,0 +1,127 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/adapters/repositories/apis"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/repositories"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/services"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/cache"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/rawarchive"
+)
+
+// maxReparseErrors число ошибок разбора, которые попадают в итог; остальные только учитываются
+const maxReparseErrors = 10
+
+// RawArchiveServiceImpl реализация интерфейса RawArchiveService
+type RawArchiveServiceImpl struct {
+	archive   *rawarchive.Archive
+	newsRepo  repositories.NewsRepository
+	stockRepo repositories.StockRepository
+	cache     cache.Cache
+}
+
+// NewRawArchiveService создает сервис повторного разбора архива ответов
+func NewRawArchiveService(
+	archive *rawarchive.Archive,
+	newsRepo repositories.NewsRepository,
+	stockRepo repositories.StockRepository,
+	cache cache.Cache,
+) services.RawArchiveService {
+	return &RawArchiveServiceImpl{
+		archive:   archive,
+		newsRepo:  newsRepo,
+		stockRepo: stockRepo,
+		cache:     cache,
+	}
+}
+
+// ReparseRaw повторно разбирает ответы за период. Новости сохраняются с заменой ранее разобранных,
+// по каждой бумаге сохраняется последний снимок котировки за период
+func (s *RawArchiveServiceImpl) ReparseRaw(ctx context.Context, from, to time.Time) (*models.RawReparse, error) {
+	if to.Before(from) {
+		return nil, fmt.Errorf("начало периода должно быть не позже его конца")
+	}
+
+	result := &models.RawReparse{From: from, To: to}
+	newsByID := make(map[string]models.News)
+	var newsOrder []string
+	stocksByTicker := make(map[string]models.Stock)
+
+	err := s.archive.Walk(from, to, func(rec rawarchive.Record) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		result.Payloads++
+
+		news, stocks, err := apis.ParseRawPayload(rec)
+		if errors.Is(err, apis.ErrRawPayloadUnsupported) {
+			result.Unsupported++
+			return nil
+		}
+		if err != nil {
+			if len(result.Errors) < maxReparseErrors {
+				result.Errors = append(result.Errors, fmt.Sprintf("%s (%s): %v",
+					rec.URL, rec.FetchedAt.Format(time.RFC3339), err))
+			}
+			return nil
+		}
+		result.Parsed++
+
+		// Ответы обходятся в порядке получения, поэтому более поздний разбор заменяет ранний
+		for _, item := range news {
+			if item.ID == "" {
+				continue
+			}
+			if _, ok := newsByID[item.ID]; !ok {
+				newsOrder = append(newsOrder, item.ID)
+			}
+			newsByID[item.ID] = item
+		}
+		for _, stock := range stocks {
+			if stock.Ticker != "" {
+				stocksByTicker[stock.Ticker] = stock
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ошибка чтения архива: %w", err)
+	}
+
+	if len(newsOrder) > 0 {
+		newsCollection := make([]models.News, 0, len(newsOrder))
+		days := make(map[string]bool)
+		for _, id := range newsOrder {
+			item := newsByID[id]
+			newsCollection = append(newsCollection, item)
+			days[item.PublishedAt.UTC().Format("2006-01-02")] = true
+		}
+		if err := s.newsRepo.SaveNewsCollection(ctx, newsCollection); err != nil {
+			return nil, fmt.Errorf("ошибка сохранения новостей: %w", err)
+		}
+		result.News = len(newsCollection)
+
+		// Сбрасываем кэш выборок за затронутые дни, чтобы исправленные новости сразу попадали в результаты
+		for day := range days {
+			if err := s.cache.Delete(ctx, models.NewsDateCacheKey(day)); err != nil {
+				log.Printf("Ошибка сброса кэша новостей за %s: %v", day, err)
+			}
+		}
+	}
+
+	for _, stock := range stocksByTicker {
+		stock := stock
+		if err := s.stockRepo.SaveStock(ctx, &stock); err != nil {
+			return nil, fmt.Errorf("ошибка сохранения котировки %s: %w", stock.Ticker, err)
+		}
+		result.Stocks++
+	}
+
+	return result, nil
+}
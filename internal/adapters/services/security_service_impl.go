@@ -0,0 +1,292 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/repositories"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/services"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/trigram"
+
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// securityRetryInterval период повторной загрузки справочника после неудачи
+	securityRetryInterval = 10 * time.Minute
+	// securityLoadTimeout ограничение времени загрузки справочника с биржи
+	securityLoadTimeout = 2 * time.Minute
+)
+
+// Ранги совпадений, которые важнее нечеткого: доля найденных триграмм не превышает 1
+const (
+	securityRankTicker       = 3    // Тикер совпадает с запросом
+	securityRankTickerPrefix = 2    // Тикер начинается с запроса
+	securityRankNamePrefix   = 1.75 // Слово названия начинается с запроса
+	securityRankSubstring    = 1.5  // Запрос содержится в названии
+)
+
+// SecurityServiceImpl реализация интерфейса SecurityService
+type SecurityServiceImpl struct {
+	source          repositories.SecuritySource
+	repo            repositories.SecurityRepository
+	refreshInterval time.Duration
+
+	// mu защищает только поля каталога: справочник загружается без блокировки, одной загрузкой на всех через loads
+	mu        sync.Mutex
+	catalog   *securityCatalog
+	updatedAt time.Time // Время загрузки справочника, по которому построен каталог
+	triedAt   time.Time // Время последней попытки загрузки с биржи
+	loads     singleflight.Group
+}
+
+// NewSecurityService создает новый экземпляр сервиса поиска бумаг. Справочник загружается из source
+// раз в refreshInterval и сохраняется в repo; если биржа недоступна, поиск идет по сохраненному справочнику
+func NewSecurityService(source repositories.SecuritySource, repo repositories.SecurityRepository, refreshInterval time.Duration) services.SecurityService {
+	return &SecurityServiceImpl{
+		source:          source,
+		repo:            repo,
+		refreshInterval: refreshInterval,
+	}
+}
+
+// SearchSecurities ищет бумаги по тикеру и названию: точные совпадения и совпадения начала идут раньше
+// найденных по триграммам
+func (s *SecurityServiceImpl) SearchSecurities(ctx context.Context, query string) ([]models.Security, error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, fmt.Errorf("поисковый запрос не может быть пустым")
+	}
+
+	catalog, err := s.currentCatalog(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return catalog.search(query), nil
+}
+
+// LookupSecurity ищет бумагу по точному тикеру или ISIN
+func (s *SecurityServiceImpl) LookupSecurity(ctx context.Context, id string) (*models.Security, error) {
+	id = strings.ToUpper(strings.TrimSpace(id))
+	if id == "" {
+		return nil, fmt.Errorf("тикер или ISIN не может быть пустым")
+	}
+
+	catalog, err := s.currentCatalog(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if i, ok := catalog.byTicker[id]; ok {
+		security := catalog.securities[i]
+		return &security, nil
+	}
+	if i, ok := catalog.byISIN[id]; ok {
+		security := catalog.securities[i]
+		return &security, nil
+	}
+	return nil, nil
+}
+
+// SyncSecurities загружает справочник с биржи и сохраняет его
+func (s *SecurityServiceImpl) SyncSecurities(ctx context.Context) (int, error) {
+	s.mu.Lock()
+	s.triedAt = time.Now()
+	s.mu.Unlock()
+
+	catalog, err := s.reload(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return len(catalog.securities), nil
+}
+
+// currentCatalog возвращает каталог бумаг, при необходимости обновляя справочник
+func (s *SecurityServiceImpl) currentCatalog(ctx context.Context) (*securityCatalog, error) {
+	// При первом обращении используется сохраненный справочник, если он еще свежий
+	s.mu.Lock()
+	empty := s.catalog == nil
+	s.mu.Unlock()
+	if empty {
+		s.loadSnapshot(ctx)
+	}
+
+	s.mu.Lock()
+	catalog, updatedAt := s.catalog, s.updatedAt
+	due := time.Since(s.updatedAt) >= s.refreshInterval && time.Since(s.triedAt) >= securityRetryInterval
+	if due {
+		s.triedAt = time.Now()
+	}
+	s.mu.Unlock()
+
+	if !due {
+		if catalog == nil {
+			return nil, fmt.Errorf("справочник бумаг недоступен")
+		}
+		return catalog, nil
+	}
+
+	reloaded, err := s.reload(ctx)
+	if err != nil {
+		if catalog == nil {
+			return nil, err
+		}
+		log.Printf("Не удалось обновить справочник бумаг, поиск идет по справочнику от %s: %v",
+			updatedAt.Format("2006-01-02 15:04"), err)
+		return catalog, nil
+	}
+
+	return reloaded, nil
+}
+
+// loadSnapshot строит каталог по сохраненному справочнику, если каталога еще нет
+func (s *SecurityServiceImpl) loadSnapshot(ctx context.Context) {
+	s.loads.Do("snapshot", func() (interface{}, error) {
+		snapshot, err := s.repo.GetSecuritySnapshot(ctx)
+		if err != nil {
+			log.Printf("Не удалось загрузить сохраненный справочник бумаг: %v", err)
+			return nil, nil
+		}
+		if snapshot == nil || len(snapshot.Securities) == 0 {
+			return nil, nil
+		}
+
+		catalog := newSecurityCatalog(snapshot.Securities)
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.catalog == nil {
+			s.catalog, s.updatedAt = catalog, snapshot.UpdatedAt
+		}
+		return nil, nil
+	})
+}
+
+// reload загружает справочник с биржи, сохраняет его и заменяет каталог. Одновременные загрузки объединяются в одну,
+// которая не прерывается отменой запроса, начавшего ее; блокировка берется только на замену каталога
+func (s *SecurityServiceImpl) reload(ctx context.Context) (*securityCatalog, error) {
+	loaded := s.loads.DoChan("reload", func() (interface{}, error) {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), securityLoadTimeout)
+		defer cancel()
+
+		securities, err := s.source.GetSecurities(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("не удалось загрузить справочник бумаг: %w", err)
+		}
+
+		snapshot := &models.SecuritySnapshot{UpdatedAt: time.Now(), Securities: securities}
+		if err := s.repo.SaveSecuritySnapshot(ctx, snapshot); err != nil {
+			log.Printf("Не удалось сохранить справочник бумаг: %v", err)
+		}
+
+		catalog := newSecurityCatalog(securities)
+		s.mu.Lock()
+		s.catalog, s.updatedAt = catalog, snapshot.UpdatedAt
+		s.mu.Unlock()
+		return catalog, nil
+	})
+
+	select {
+	case result := <-loaded:
+		if result.Err != nil {
+			return nil, result.Err
+		}
+		return result.Val.(*securityCatalog), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// securityCatalog бумаги справочника, индекс триграмм их тикеров и названий и позиции бумаг по тикеру и ISIN
+type securityCatalog struct {
+	securities []models.Security
+	texts      []string // Тикер и названия бумаги в нижнем регистре через пробел
+	index      *trigram.Index
+	byTicker   map[string]int
+	byISIN     map[string]int
+}
+
+// newSecurityCatalog строит индекс по справочнику бумаг
+func newSecurityCatalog(securities []models.Security) *securityCatalog {
+	catalog := &securityCatalog{
+		securities: securities,
+		texts:      make([]string, len(securities)),
+		byTicker:   make(map[string]int, len(securities)),
+		byISIN:     make(map[string]int, len(securities)),
+	}
+	for i, security := range securities {
+		catalog.byTicker[security.Ticker] = i
+		if security.ISIN != "" {
+			// Если один ISIN указан у нескольких тикеров, поиск по нему возвращает первый
+			if _, ok := catalog.byISIN[security.ISIN]; !ok {
+				catalog.byISIN[security.ISIN] = i
+			}
+		}
+		catalog.texts[i] = strings.Join(trigram.Words(strings.Join([]string{
+			security.Ticker, security.ShortName, security.Name, security.LatName,
+		}, " ")), " ")
+	}
+	catalog.index = trigram.New(catalog.texts)
+	return catalog
+}
+
+// search возвращает бумаги, похожие на запрос, в порядке убывания ранга
+func (c *securityCatalog) search(query string) []models.Security {
+	type ranked struct {
+		doc        int
+		rank       float64
+		similarity float64
+	}
+
+	words := trigram.Words(query)
+	if len(words) == 0 {
+		return nil
+	}
+	normalized := strings.Join(words, " ")
+	ticker := strings.ToUpper(strings.Join(words, ""))
+
+	fuzzy := make(map[int]trigram.Match)
+	for _, match := range c.index.Search(normalized, models.MinSecuritySearchScore) {
+		fuzzy[match.Doc] = match
+	}
+
+	var results []ranked
+	for doc, security := range c.securities {
+		match, found := fuzzy[doc]
+		result := ranked{doc: doc, rank: match.Score, similarity: match.Similarity}
+		switch {
+		case security.Ticker == ticker:
+			result.rank = securityRankTicker
+		case strings.HasPrefix(security.Ticker, ticker):
+			result.rank = securityRankTickerPrefix
+		case strings.HasPrefix(c.texts[doc], normalized) || strings.Contains(c.texts[doc], " "+normalized):
+			result.rank = securityRankNamePrefix
+		case strings.Contains(c.texts[doc], normalized):
+			result.rank = securityRankSubstring
+		case !found:
+			continue
+		}
+		results = append(results, result)
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].rank != results[j].rank {
+			return results[i].rank > results[j].rank
+		}
+		if results[i].similarity != results[j].similarity {
+			return results[i].similarity > results[j].similarity
+		}
+		return c.securities[results[i].doc].Ticker < c.securities[results[j].doc].Ticker
+	})
+
+	securities := make([]models.Security, len(results))
+	for i, result := range results {
+		securities[i] = c.securities[result.doc]
+	}
+	return securities
+}
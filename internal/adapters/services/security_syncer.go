@@ -0,0 +1,56 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/services"
+)
+
+// SecuritySyncer ежедневно загружает справочник бумаг MOEX в заданное время по Москве, чтобы поиск
+// и справки по бумагам не ждали загрузки при первом обращении
+type SecuritySyncer struct {
+	securityService services.SecurityService
+	hour, minute    int
+}
+
+// NewSecuritySyncer создает ежедневную загрузку справочника бумаг; at — время по Москве
+func NewSecuritySyncer(securityService services.SecurityService, at time.Time) *SecuritySyncer {
+	return &SecuritySyncer{
+		securityService: securityService,
+		hour:            at.Hour(),
+		minute:          at.Minute(),
+	}
+}
+
+// Run загружает справочник каждый день до отмены контекста
+func (s *SecuritySyncer) Run(ctx context.Context) {
+	for {
+		timer := time.NewTimer(time.Until(s.next(time.Now())))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		count, err := s.securityService.SyncSecurities(ctx)
+		if err != nil {
+			log.Printf("Ошибка загрузки справочника бумаг: %v", err)
+			continue
+		}
+		log.Printf("Справочник бумаг MOEX загружен: %d бумаг", count)
+	}
+}
+
+// next возвращает ближайшее после now время загрузки
+func (s *SecuritySyncer) next(now time.Time) time.Time {
+	now = now.In(models.MoscowLocation)
+	next := time.Date(now.Year(), now.Month(), now.Day(), s.hour, s.minute, 0, 0, models.MoscowLocation)
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
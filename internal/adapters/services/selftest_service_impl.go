@@ -0,0 +1,136 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/adapters/repositories/apis"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/config"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/services"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/cache"
+)
+
+const (
+	// selfTestTicker тикер контрольного запроса котировки
+	selfTestTicker = "SBER"
+	// selfTestKeyword ключевое слово контрольного поиска новостей
+	selfTestKeyword = "Сбербанк"
+)
+
+// SelfTestServiceImpl реализация интерфейса SelfTestService
+type SelfTestServiceImpl struct {
+	moexAPI *apis.MOEXAPIClient
+	newsAPI *apis.NewsAPIClient
+}
+
+// NewSelfTestService создает сервис самопроверки. Клиенты API создаются с отключенным кэшем,
+// чтобы каждая проверка действительно обращалась к источнику. ctx — контекст приложения
+func NewSelfTestService(ctx context.Context, cfg *config.Config, cacheClient cache.Cache) services.SelfTestService {
+	uncached := *cfg
+	uncached.MOEX.UseCache = false
+	uncached.NewsAPI.UseCache = false
+
+	return &SelfTestServiceImpl{
+		moexAPI: apis.NewMOEXAPIClient(ctx, &uncached, cacheClient, nil),
+		newsAPI: apis.NewNewsAPIClient(&uncached, cacheClient),
+	}
+}
+
+// RunSelfTest выполняет контрольные запросы ко всем источникам
+func (s *SelfTestServiceImpl) RunSelfTest(ctx context.Context) *models.SelfTestReport {
+	return &models.SelfTestReport{
+		StartedAt: time.Now(),
+		Checks: []models.SourceCheck{
+			s.checkMOEX(ctx),
+			s.checkNewsAPI(ctx),
+		},
+	}
+}
+
+// checkMOEX запрашивает котировку контрольного тикера и проверяет разобранные поля
+func (s *SelfTestServiceImpl) checkMOEX(ctx context.Context) models.SourceCheck {
+	check := models.SourceCheck{
+		Source:  "MOEX",
+		Request: fmt.Sprintf("котировка %s", selfTestTicker),
+	}
+
+	start := time.Now()
+	stock, err := s.moexAPI.GetStock(ctx, selfTestTicker)
+	check.Duration = time.Since(start)
+	if err != nil {
+		check.Error = err.Error()
+		return check
+	}
+
+	if stock.Name == "" {
+		check.Problems = append(check.Problems, "пустое поле name")
+	}
+	if stock.Price == 0 {
+		check.Problems = append(check.Problems, "нулевое поле price")
+	}
+	if stock.Change == 0 && stock.ChangePerc == 0 {
+		check.Problems = append(check.Problems, "нулевые поля change и change_perc")
+	}
+
+	check.Summary = fmt.Sprintf("%s (%s): цена %.2f, изменение %.2f (%.2f%%)",
+		stock.Ticker, stock.Name, stock.Price, stock.Change, stock.ChangePerc)
+	check.Passed = len(check.Problems) == 0
+	return check
+}
+
+// checkNewsAPI выполняет контрольный поиск новостей и проверяет разобранные поля статей
+func (s *SelfTestServiceImpl) checkNewsAPI(ctx context.Context) models.SourceCheck {
+	check := models.SourceCheck{
+		Source:  "NewsAPI",
+		Request: fmt.Sprintf("поиск новостей по запросу '%s'", selfTestKeyword),
+	}
+
+	start := time.Now()
+	news, err := s.newsAPI.GetNewsByKeyword(ctx, selfTestKeyword, models.NewsFilter{})
+	check.Duration = time.Since(start)
+	if err != nil {
+		check.Error = err.Error()
+		return check
+	}
+
+	if len(news) == 0 {
+		check.Problems = append(check.Problems, "ответ не содержит статей")
+		return check
+	}
+
+	var noID, noTitle, noURL, noDate int
+	for _, item := range news {
+		if item.ID == "" {
+			noID++
+		}
+		if item.Title == "" {
+			noTitle++
+		}
+		if item.URL == "" {
+			noURL++
+		}
+		if item.PublishedAt.IsZero() {
+			noDate++
+		}
+	}
+
+	for _, field := range []struct {
+		name  string
+		count int
+	}{
+		{"id", noID},
+		{"title", noTitle},
+		{"url", noURL},
+		{"published_at", noDate},
+	} {
+		if field.count > 0 {
+			check.Problems = append(check.Problems, fmt.Sprintf("пустое поле %s у %d из %d статей", field.name, field.count, len(news)))
+		}
+	}
+
+	check.Summary = fmt.Sprintf("статей: %d, последняя: %s", len(news), news[0].Title)
+	check.Passed = len(check.Problems) == 0
+	return check
+}
@@ -0,0 +1,54 @@
+package services
+
+import (
+	"context"
+	"runtime"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/services"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/cache"
+)
+
+// ServerStatsServiceImpl реализация интерфейса ServerStatsService
+type ServerStatsServiceImpl struct {
+	cache     *cache.MeteredCache
+	startedAt time.Time
+}
+
+// NewServerStatsService создает сервис статистики; startedAt — время запуска сервера
+func NewServerStatsService(cache *cache.MeteredCache, startedAt time.Time) services.ServerStatsService {
+	return &ServerStatsServiceImpl{
+		cache:     cache,
+		startedAt: startedAt,
+	}
+}
+
+// GetServerStats возвращает статистику работы сервера
+func (s *ServerStatsServiceImpl) GetServerStats(ctx context.Context) *models.ServerStats {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	stats := &models.ServerStats{
+		StartedAt:  s.startedAt,
+		Uptime:     time.Since(s.startedAt),
+		Goroutines: runtime.NumGoroutine(),
+		HeapAlloc:  mem.HeapAlloc,
+	}
+
+	for _, prefix := range s.cache.Stats() {
+		stats.Cache = append(stats.Cache, models.CacheStats{
+			Prefix:     prefix.Prefix,
+			Hits:       prefix.Hits,
+			Misses:     prefix.Misses,
+			Sets:       prefix.Sets,
+			Deletes:    prefix.Deletes,
+			Errors:     prefix.Errors,
+			Calls:      prefix.Calls,
+			HitRate:    prefix.HitRate(),
+			AvgLatency: prefix.AvgLatency(),
+		})
+	}
+
+	return stats
+}
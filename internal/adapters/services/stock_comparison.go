@@ -0,0 +1,180 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// CompareStocks сравнивает от 2 до 5 акций по цене, объему, мультипликаторам и доходности за 1 и 3 месяца.
+// Мультипликаторы и доходности необязательны: если данных нет, акция остается в сравнении без них
+func (s *StockServiceImpl) CompareStocks(ctx context.Context, tickers []string) (*models.StockComparison, error) {
+	unique := make([]string, 0, len(tickers))
+	for _, ticker := range tickers {
+		ticker = strings.ToUpper(strings.TrimSpace(ticker))
+		if ticker != "" && !containsTicker(unique, ticker) {
+			unique = append(unique, ticker)
+		}
+	}
+	if len(unique) < models.MinComparedStocks || len(unique) > models.MaxComparedStocks {
+		return nil, fmt.Errorf("для сравнения нужно от %d до %d разных тикеров, передано %d",
+			models.MinComparedStocks, models.MaxComparedStocks, len(unique))
+	}
+
+	now := time.Now()
+	comparison := &models.StockComparison{
+		Stocks:    make([]models.ComparedStock, 0, len(unique)),
+		UpdatedAt: now,
+	}
+
+	for _, ticker := range unique {
+		stock, err := s.stockRepo.GetStock(ctx, ticker)
+		if err != nil {
+			return nil, fmt.Errorf("не удалось получить акцию %s: %w", ticker, err)
+		}
+
+		compared := models.ComparedStock{Stock: *stock}
+
+		if quote, err := s.stockRepo.GetStockQuote(ctx, ticker, now); err != nil {
+			log.Printf("Не удалось получить мультипликаторы %s: %v", ticker, err)
+		} else {
+			compared.PE = quote.PE
+			compared.DividendYield = quote.DividendYield
+		}
+
+		// Берем историю с запасом на выходные и праздники перед началом трехмесячного периода
+		history, err := s.stockRepo.GetStockHistory(ctx, ticker, models.IntervalDay, now.AddDate(0, -3, -7), now)
+		if err != nil {
+			log.Printf("Не удалось получить историю котировок %s: %v", ticker, err)
+		} else {
+			sort.Slice(history, func(i, j int) bool {
+				return history[i].Date.Before(history[j].Date)
+			})
+			compared.Return1MPerc, compared.HasReturn1M = returnSince(history, now.AddDate(0, -1, 0), stock.Price)
+			compared.Return3MPerc, compared.HasReturn3M = returnSince(history, now.AddDate(0, -3, 0), stock.Price)
+		}
+
+		comparison.Stocks = append(comparison.Stocks, compared)
+	}
+
+	return comparison, nil
+}
+
+// returnSince возвращает доходность от цены закрытия последней сессии не позже since до текущей цены.
+// История должна быть отсортирована по возрастанию даты; если она начинается позже since, доходность не рассчитывается
+func returnSince(history []models.StockQuote, since time.Time, price float64) (float64, bool) {
+	var base *models.StockQuote
+	for i := range history {
+		if history[i].Date.After(since) {
+			break
+		}
+		base = &history[i]
+	}
+	if base == nil || base.Close == 0 {
+		return 0, false
+	}
+
+	return percent(price, base.Close), true
+}
+
+// normalizedStartGapDays сколько дней после начала периода может не быть сессий из-за выходных и праздников
+const normalizedStartGapDays = 10
+
+// GetNormalizedReturns возвращает цены закрытия акций за months месяцев, приведенные к 100 на начало периода.
+// Акции без истории котировок с начала периода пропускаются; ошибка возвращается, если истории нет ни по одной
+func (s *StockServiceImpl) GetNormalizedReturns(ctx context.Context, tickers []string, months int) (*models.NormalizedReturns, error) {
+	if months <= 0 {
+		months = models.CompareAnalysisMonths
+	}
+
+	now := time.Now()
+	result := &models.NormalizedReturns{
+		From: dayStart(now.AddDate(0, -months, 0)),
+		To:   now,
+	}
+
+	closes := make(map[string]map[string]float64)
+	for _, ticker := range tickers {
+		ticker = strings.ToUpper(strings.TrimSpace(ticker))
+		if ticker == "" || containsTicker(result.Tickers, ticker) || containsTicker(result.Skipped, ticker) {
+			continue
+		}
+
+		history, err := dailyCloses(ctx, s.stockRepo, ticker, result.From, result.To)
+		if err != nil {
+			log.Printf("Не удалось получить историю котировок %s: %v", ticker, err)
+		}
+		byDate := make(map[string]float64, len(history))
+		var first time.Time
+		for _, quote := range history {
+			if quote.Close > 0 {
+				byDate[quote.Date.Format("2006-01-02")] = quote.Close
+				if first.IsZero() {
+					first = quote.Date
+				}
+			}
+		}
+		// История, начавшаяся позже начала периода, сократила бы общие сессии остальных акций
+		if len(byDate) < 2 || first.After(result.From.AddDate(0, 0, normalizedStartGapDays)) {
+			result.Skipped = append(result.Skipped, ticker)
+			continue
+		}
+		result.Tickers = append(result.Tickers, ticker)
+		closes[ticker] = byDate
+	}
+
+	// Общие сессии всех акций
+	var dates []string
+	if len(result.Tickers) > 0 {
+		for date := range closes[result.Tickers[0]] {
+			common := true
+			for _, ticker := range result.Tickers[1:] {
+				if _, ok := closes[ticker][date]; !ok {
+					common = false
+					break
+				}
+			}
+			if common {
+				dates = append(dates, date)
+			}
+		}
+	}
+	if len(dates) < 2 {
+		return nil, fmt.Errorf("недостаточно общей истории котировок за %d месяцев", months)
+	}
+	sort.Strings(dates)
+
+	series := make([][]float64, len(result.Tickers))
+	for i, ticker := range result.Tickers {
+		base := closes[ticker][dates[0]]
+		series[i] = make([]float64, len(dates))
+		for j, date := range dates {
+			series[i][j] = closes[ticker][date] / base * 100
+		}
+		result.Stats = append(result.Stats, models.TickerPeriodStats{
+			Ticker:          ticker,
+			ReturnPerc:      series[i][len(dates)-1] - 100,
+			MaxDrawdownPerc: maxDrawdownPerc(series[i]),
+		})
+	}
+
+	for j, date := range dates {
+		// Последняя сессия выводится всегда, чтобы ряд заканчивался текущим значением
+		if j%models.NormalizedSeriesStep != 0 && j != len(dates)-1 {
+			continue
+		}
+		sessionDate, _ := time.ParseInLocation("2006-01-02", date, now.Location())
+		point := models.NormalizedPoint{Date: sessionDate, Values: make([]float64, len(result.Tickers))}
+		for i := range result.Tickers {
+			point.Values[i] = series[i][j]
+		}
+		result.Points = append(result.Points, point)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,123 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/progress"
+)
+
+// gapHistoryDays календарная глубина дневной истории, в которой ищется предыдущая сессия с учетом праздников
+const gapHistoryDays = 10
+
+// GetOpeningGaps рассчитывает разрывы открытия текущей сессии к закрытию предыдущей для бумаг универсума.
+// Предыдущее закрытие берется из дневных свечей базы или биржи, открытие — из дневной свечи сессии,
+// а если ее еще нет — из первой десятиминутной свечи. Бумаги без этих свечей пропускаются
+func (s *StockServiceImpl) GetOpeningGaps(ctx context.Context, universe string) (*models.GapReport, error) {
+	selected, stocks, _, err := s.getWeightedUniverseStocks(ctx, universe)
+	if err != nil {
+		return nil, err
+	}
+	if len(stocks) == 0 {
+		return nil, fmt.Errorf("нет котировок универсума %s", selected.Name)
+	}
+
+	now := time.Now()
+	session := dayStart(now.In(models.MoscowLocation))
+
+	gaps := make([]*models.Gap, len(stocks))
+	counter := progress.NewCounter(ctx, len(stocks), "Проверено гэпов: %d из %d")
+	s.pool.Run(ctx, len(stocks), func(ctx context.Context, i int) error {
+		defer counter.Done()
+		gap, err := s.openingGap(ctx, stocks[i], session)
+		if err != nil {
+			log.Printf("Не удалось рассчитать гэп %s: %v", stocks[i].Ticker, err)
+			return nil
+		}
+		gaps[i] = gap
+		return nil
+	})
+
+	report := &models.GapReport{
+		Universe:   selected.Name,
+		Session:    session,
+		ComputedAt: now,
+	}
+	for _, gap := range gaps {
+		if gap == nil {
+			report.Skipped++
+			continue
+		}
+		report.Scanned++
+		if gap.GapPerc != 0 {
+			report.Gaps = append(report.Gaps, *gap)
+		}
+	}
+
+	sort.Slice(report.Gaps, func(i, j int) bool {
+		return math.Abs(report.Gaps[i].GapPerc) > math.Abs(report.Gaps[j].GapPerc)
+	})
+
+	return report, nil
+}
+
+// openingGap рассчитывает гэп бумаги в сессии session; nil без ошибки, если свечей сессии
+// или предыдущей сессии нет
+func (s *StockServiceImpl) openingGap(ctx context.Context, stock models.Stock, session time.Time) (*models.Gap, error) {
+	history, err := dailyCloses(ctx, s.stockRepo, stock.Ticker, session.AddDate(0, 0, -gapHistoryDays), session)
+	if err != nil {
+		return nil, err
+	}
+
+	day := session.Format("2006-01-02")
+	var prevClose, open float64
+	for _, candle := range history {
+		switch candleDay := candle.Date.In(models.MoscowLocation).Format("2006-01-02"); {
+		case candleDay < day && candle.Close > 0:
+			prevClose = candle.Close
+		case candleDay == day && candle.Open > 0:
+			open = candle.Open
+		}
+	}
+	if prevClose == 0 {
+		return nil, nil
+	}
+
+	if open == 0 {
+		intraday, err := s.stockRepo.GetStockHistory(ctx, stock.Ticker, models.IntervalTenMinute, session, session.Add(24*time.Hour))
+		if err != nil {
+			return nil, err
+		}
+		sort.Slice(intraday, func(i, j int) bool {
+			return intraday[i].Date.Before(intraday[j].Date)
+		})
+		for _, candle := range intraday {
+			if candle.Open > 0 {
+				open = candle.Open
+				break
+			}
+		}
+	}
+	if open == 0 {
+		return nil, nil
+	}
+
+	gap := &models.Gap{
+		Ticker:    stock.Ticker,
+		Name:      stock.Name,
+		PrevClose: prevClose,
+		Open:      open,
+		GapPerc:   percent(open, prevClose),
+		Price:     stock.Price,
+	}
+	if stock.Price > 0 {
+		gap.FromOpenPerc = percent(stock.Price, open)
+		gap.Filled = gap.GapPerc > 0 && stock.Price <= prevClose || gap.GapPerc < 0 && stock.Price >= prevClose
+	}
+	return gap, nil
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/progress"
+)
+
+// BackfillHistory загружает дневные свечи тикеров за последние years лет. Тикеры загружаются по очереди,
+// чтобы не нагружать ISS; ошибка одного тикера не останавливает загрузку остальных
+func (s *StockServiceImpl) BackfillHistory(ctx context.Context, tickers []string, years int) (*models.HistoryBackfill, error) {
+	if years <= 0 {
+		years = models.DefaultHistoryBackfillYears
+	}
+	if years > models.MaxHistoryBackfillYears {
+		return nil, fmt.Errorf("глубина истории не может быть больше %d лет, получено %d", models.MaxHistoryBackfillYears, years)
+	}
+
+	if len(tickers) == 0 {
+		for _, universe := range s.universes {
+			tickers = append(tickers, universe.Tickers...)
+		}
+	}
+	seen := make(map[string]bool)
+	var unique []string
+	for _, ticker := range tickers {
+		if ticker = models.NormalizeTicker(ticker); ticker != "" && !seen[ticker] {
+			seen[ticker] = true
+			unique = append(unique, ticker)
+		}
+	}
+	tickers = unique
+	if len(tickers) == 0 {
+		return nil, fmt.Errorf("не указаны тикеры, и в универсумах конфигурации нет ни одного тикера")
+	}
+
+	// Текущая сессия еще не закончилась: ее свеча загрузится при следующем запуске
+	now := time.Now().In(models.MoscowLocation)
+	to := time.Date(now.Year(), now.Month(), now.Day()-1, 0, 0, 0, 0, time.UTC)
+	result := &models.HistoryBackfill{From: to.AddDate(-years, 0, 1), To: to}
+
+	// Тикеры загружаются параллельно в пределах общего лимита запросов к бирже
+	items := make([]models.TickerHistoryBackfill, len(tickers))
+	counter := progress.NewCounter(ctx, len(tickers), "Загружена история тикеров: %d из %d")
+	s.pool.Run(ctx, len(tickers), func(ctx context.Context, i int) error {
+		defer counter.Done()
+		item, err := s.stockRepo.BackfillHistory(ctx, tickers[i], result.From, result.To)
+		if item == nil {
+			item = &models.TickerHistoryBackfill{Ticker: tickers[i]}
+		}
+		if err != nil {
+			item.Error = err.Error()
+			log.Printf("Не удалось загрузить историю %s: %v", tickers[i], err)
+		}
+		items[i] = *item
+		return nil
+	})
+	for _, item := range items {
+		if item.Ticker == "" {
+			// До тикера не дошла очередь: запрос отменен
+			continue
+		}
+		if item.Error != "" {
+			result.Failed++
+		}
+		result.Saved += item.Saved
+		result.Tickers = append(result.Tickers, item)
+	}
+	if err := ctx.Err(); err != nil {
+		return result, err
+	}
+	progress.Report(ctx, len(tickers), len(tickers), "История загружена: сохранено свечей %d", result.Saved)
+
+	return result, nil
+}
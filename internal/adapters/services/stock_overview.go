@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/listutil"
+)
+
+// GetMarketOverview возвращает ширину рынка и лидеров роста и падения по выборке акций.
+// Если tickers заданы, выборка состоит из них под именем universe; иначе universe — имя
+// универсума из конфигурации или, если такого нет, сектор всего рынка
+func (s *StockServiceImpl) GetMarketOverview(ctx context.Context, universe string, tickers []string, limit int) (*models.MarketOverview, error) {
+	if limit <= 0 {
+		limit = models.DefaultOverviewLeaders
+	}
+
+	overview := &models.MarketOverview{Name: universeName(universe)}
+	var (
+		stocks  []models.Stock
+		index   string
+		weights map[string]float64
+		uniErr  error
+	)
+	switch {
+	case len(tickers) > 0:
+		overview.Scope = models.OverviewScopeTickers
+		overview.Name = universe
+		found, errs := s.fetchStocks(ctx, tickers)
+		for i, stock := range found {
+			if errs[i] != nil {
+				log.Printf("Не удалось получить акцию %s из выборки %s: %v", tickers[i], overview.Name, errs[i])
+				continue
+			}
+			stocks = append(stocks, *stock)
+		}
+	default:
+		if _, uniErr = s.findUniverse(universe); uniErr == nil {
+			selected, universeStocks, universeWeights, err := s.getWeightedUniverseStocks(ctx, universe)
+			if err != nil {
+				return nil, err
+			}
+			overview.Scope = models.OverviewScopeUniverse
+			overview.Name = selected.Name
+			stocks, index, weights = universeStocks, selected.Index, universeWeights
+			break
+		}
+
+		sector, err := s.GetStocksBySector(ctx, universe, models.UniverseFull)
+		if err != nil {
+			return nil, fmt.Errorf("%w; как сектор: %v", uniErr, err)
+		}
+		overview.Scope = models.OverviewScopeSector
+		overview.Name = sector.Performance.Sector
+		for _, stock := range sector.Stocks {
+			stocks = append(stocks, stock.Stock)
+		}
+	}
+
+	overview.Breadth = *marketBreadth(overview.Name, stocks)
+	applyIndexWeights(&overview.Breadth, index, stocks, weights)
+	overview.Gainers = listutil.TopN(stocks, func(a, b models.Stock) bool {
+		return a.ChangePerc > b.ChangePerc
+	}, limit)
+	overview.Losers = listutil.TopN(stocks, func(a, b models.Stock) bool {
+		return a.ChangePerc < b.ChangePerc
+	}, limit)
+
+	return overview, nil
+}
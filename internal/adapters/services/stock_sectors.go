@@ -0,0 +1,197 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// GetSectorPerformance возвращает динамику секторов универсума по данным профилей компаний
+func (s *StockServiceImpl) GetSectorPerformance(ctx context.Context, universe string) (*models.SectorReport, error) {
+	selected, stocks, err := s.getSectorStocks(ctx, universe)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make(map[string][]models.SectorStock)
+	for _, stock := range stocks {
+		sector := sectorName(stock.Sector)
+		groups[sector] = append(groups[sector], stock.SectorStock)
+	}
+
+	report := &models.SectorReport{
+		Universe:  selected.Name,
+		Index:     selected.Index,
+		Sectors:   make([]models.SectorPerformance, 0, len(groups)),
+		UpdatedAt: time.Now(),
+	}
+	for sector, sectorStocks := range groups {
+		report.Sectors = append(report.Sectors, sectorPerformance(sector, sectorStocks))
+	}
+
+	sort.Slice(report.Sectors, func(i, j int) bool {
+		if report.Sectors[i].CapWeightedChangePerc != report.Sectors[j].CapWeightedChangePerc {
+			return report.Sectors[i].CapWeightedChangePerc > report.Sectors[j].CapWeightedChangePerc
+		}
+		return report.Sectors[i].Sector < report.Sectors[j].Sector
+	})
+
+	return report, nil
+}
+
+// GetStocksBySector возвращает акции универсума, относящиеся к сектору.
+// Сектор сравнивается без учета регистра; если точного совпадения нет, ищется по подстроке
+func (s *StockServiceImpl) GetStocksBySector(ctx context.Context, sector, universe string) (*models.SectorStocks, error) {
+	if sector == "" {
+		return nil, fmt.Errorf("сектор не может быть пустым")
+	}
+
+	selected, stocks, err := s.getSectorStocks(ctx, universe)
+	if err != nil {
+		return nil, err
+	}
+
+	var exact, partial []models.SectorStock
+	exactName, partialName := "", ""
+	for _, stock := range stocks {
+		name := sectorName(stock.Sector)
+		switch {
+		case strings.EqualFold(name, sector):
+			exact = append(exact, stock.SectorStock)
+			exactName = name
+		case containsIgnoreCase(name, sector):
+			// Подстрока может совпасть с несколькими секторами — берем первый найденный
+			if partialName == "" || partialName == name {
+				partial = append(partial, stock.SectorStock)
+				partialName = name
+			}
+		}
+	}
+
+	matched, matchedName := exact, exactName
+	if len(matched) == 0 {
+		matched, matchedName = partial, partialName
+	}
+	if len(matched) == 0 {
+		return nil, fmt.Errorf("в универсуме %s нет акций сектора %s", universeName(universe), sector)
+	}
+
+	sort.Slice(matched, func(i, j int) bool {
+		return matched[i].ChangePerc > matched[j].ChangePerc
+	})
+
+	return &models.SectorStocks{
+		Universe:    selected.Name,
+		Index:       selected.Index,
+		Performance: sectorPerformance(matchedName, matched),
+		Stocks:      matched,
+	}, nil
+}
+
+// classifiedStock акция универсума с сектором из профиля компании
+type classifiedStock struct {
+	models.SectorStock
+	Sector string
+}
+
+// getSectorStocks возвращает универсум и его акции, дополненные сектором, отраслью и капитализацией из профилей
+// компаний, а для универсума индекса — и весом в индексе. Акции без профиля попадают в сектор «Без сектора»
+func (s *StockServiceImpl) getSectorStocks(ctx context.Context, universe string) (*models.Universe, []classifiedStock, error) {
+	if s.profileRepo == nil {
+		return nil, nil, fmt.Errorf("профили компаний недоступны в текущей конфигурации")
+	}
+
+	selected, stocks, weights, err := s.getWeightedUniverseStocks(ctx, universe)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tickers := make([]string, 0, len(stocks))
+	for _, stock := range stocks {
+		tickers = append(tickers, stock.Ticker)
+	}
+
+	profiles, err := s.profileRepo.GetCompanyProfiles(ctx, tickers)
+	if err != nil {
+		return nil, nil, fmt.Errorf("не удалось получить профили компаний: %w", err)
+	}
+
+	byTicker := make(map[string]models.CompanyProfile, len(profiles))
+	for _, profile := range profiles {
+		byTicker[profile.Ticker] = profile
+	}
+
+	result := make([]classifiedStock, 0, len(stocks))
+	for _, stock := range stocks {
+		profile := byTicker[stock.Ticker]
+		result = append(result, classifiedStock{
+			SectorStock: models.SectorStock{
+				Stock:        stock,
+				Industry:     profile.Industry,
+				MarketCapRub: profile.MarketCap,
+				IndexWeight:  weights[stock.Ticker],
+			},
+			Sector: profile.Sector,
+		})
+	}
+
+	return selected, result, nil
+}
+
+// sectorPerformance рассчитывает сводную динамику акций сектора
+func sectorPerformance(sector string, stocks []models.SectorStock) models.SectorPerformance {
+	perf := models.SectorPerformance{
+		Sector: sector,
+		Stocks: len(stocks),
+	}
+	if len(stocks) == 0 {
+		return perf
+	}
+
+	var totalChange, weightedChange, indexWeightedChange float64
+	for i, stock := range stocks {
+		switch {
+		case stock.ChangePerc > 0:
+			perf.Advancers++
+		case stock.ChangePerc < 0:
+			perf.Decliners++
+		}
+		totalChange += stock.ChangePerc
+		weightedChange += stock.ChangePerc * stock.MarketCapRub
+		perf.TotalVolume += stock.Volume
+		perf.TurnoverRub += stock.Price * float64(stock.Volume)
+		perf.MarketCapRub += stock.MarketCapRub
+		perf.IndexWeightPerc += stock.IndexWeight
+		indexWeightedChange += stock.ChangePerc * stock.IndexWeight
+
+		if i == 0 || stock.ChangePerc > perf.LeaderChangePerc {
+			perf.Leader, perf.LeaderChangePerc = stock.Ticker, stock.ChangePerc
+		}
+		if i == 0 || stock.ChangePerc < perf.LaggardChangePerc {
+			perf.Laggard, perf.LaggardChangePerc = stock.Ticker, stock.ChangePerc
+		}
+	}
+
+	perf.AvgChangePerc = totalChange / float64(len(stocks))
+	perf.CapWeightedChangePerc = perf.AvgChangePerc
+	if perf.MarketCapRub > 0 {
+		perf.CapWeightedChangePerc = weightedChange / perf.MarketCapRub
+	}
+	if perf.IndexWeightPerc > 0 {
+		perf.IndexWeightedChangePerc = indexWeightedChange / perf.IndexWeightPerc
+	}
+
+	return perf
+}
+
+// sectorName возвращает название сектора, подставляя «Без сектора» для бумаг вне отраслевых индексов
+func sectorName(sector string) string {
+	if sector == "" {
+		return models.UnclassifiedSector
+	}
+	return sector
+}
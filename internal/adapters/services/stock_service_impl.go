@@ -3,33 +3,83 @@ package services
 import (
 	"context"
 	"fmt"
+	"log"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/JkLondon/mcp-stocks-info-server/internal/config"
 	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
 	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/repositories"
 	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/services"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/listutil"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/progress"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/workpool"
 )
 
 // StockServiceImpl реализация интерфейса StockService
 type StockServiceImpl struct {
-	stockRepo repositories.StockRepository
+	stockRepo   repositories.StockRepository
+	profileRepo repositories.CompanyProfileRepository // Необязателен: без него секторная аналитика недоступна
+	indexRepo   repositories.IndexRepository          // Необязателен: без него универсумы индексов недоступны
+	// securityService необязателен: без него поиск идет по подстроке среди акций универсума
+	securityService services.SecurityService
+	universes       []models.Universe
+	pool            *workpool.Pool // Ограничивает параллельные запросы котировок универсума
 }
 
-// NewStockService создает новый экземпляр сервиса для работы с акциями
-func NewStockService(stockRepo repositories.StockRepository) services.StockService {
-	return &StockServiceImpl{
-		stockRepo: stockRepo,
+// NewStockService создает новый экземпляр сервиса для работы с акциями. Для каждого индекса из indexes
+// создается универсум с тем же именем в нижнем регистре, если индексы хранятся в indexRepo
+// и универсум с таким именем не задан в конфигурации
+func NewStockService(stockRepo repositories.StockRepository, profileRepo repositories.CompanyProfileRepository, indexRepo repositories.IndexRepository, securityService services.SecurityService, universes map[string]config.UniverseConfig, indexes []string, pool *workpool.Pool) services.StockService {
+	s := &StockServiceImpl{
+		stockRepo:       stockRepo,
+		profileRepo:     profileRepo,
+		indexRepo:       indexRepo,
+		securityService: securityService,
+		pool:            pool,
+		universes:       []models.Universe{{Name: models.UniverseFull, Description: "Весь рынок"}},
 	}
+
+	names := make([]string, 0, len(universes))
+	for name := range universes {
+		if name != models.UniverseFull {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		s.universes = append(s.universes, models.Universe{
+			Name:        name,
+			Description: universes[name].Description,
+			Tickers:     universes[name].Tickers,
+		})
+	}
+
+	if indexRepo != nil {
+		for _, index := range indexes {
+			name := strings.ToLower(index)
+			if _, ok := universes[name]; ok || name == models.UniverseFull {
+				continue
+			}
+			s.universes = append(s.universes, models.Universe{
+				Name:        name,
+				Description: fmt.Sprintf("Состав индекса %s с весами по данным Московской биржи", strings.ToUpper(index)),
+				Index:       strings.ToUpper(index),
+			})
+		}
+	}
+
+	return s
 }
 
-// GetStockInfo возвращает информацию о котировке акции
-func (s *StockServiceImpl) GetStockInfo(ctx context.Context, ticker string) (*models.Stock, error) {
+// GetStockInfo возвращает информацию о котировке бумаги в режиме торгов board; пустой режим — основной режим бумаги
+func (s *StockServiceImpl) GetStockInfo(ctx context.Context, ticker string, board models.TradingBoard) (*models.Stock, error) {
 	if ticker == "" {
 		return nil, fmt.Errorf("тикер не может быть пустым")
 	}
 
-	return s.stockRepo.GetStock(ctx, ticker)
+	return s.stockRepo.GetBoardStock(ctx, ticker, board)
 }
 
 // GetMultipleStocks возвращает информацию о нескольких акциях
@@ -54,139 +104,237 @@ func (s *StockServiceImpl) GetStockQuote(ctx context.Context, ticker string, dat
 	return s.stockRepo.GetStockQuote(ctx, ticker, date)
 }
 
-// GetStockHistoricalData возвращает историю котировок акции за период
-func (s *StockServiceImpl) GetStockHistoricalData(ctx context.Context, ticker string, startDate, endDate time.Time) ([]models.StockQuote, error) {
+// GetStockHistoricalData возвращает историю котировок акции за период: дневные или внутридневные свечи (1m, 10m, 1h)
+func (s *StockServiceImpl) GetStockHistoricalData(ctx context.Context, ticker, interval string, startDate, endDate time.Time) ([]models.StockQuote, error) {
 	if ticker == "" {
 		return nil, fmt.Errorf("тикер не может быть пустым")
 	}
 
-	if startDate.IsZero() {
-		startDate = time.Now().AddDate(0, -1, 0) // 1 месяц назад по умолчанию
+	spec, ok := models.FindQuoteInterval(interval)
+	if !ok {
+		names := make([]string, 0, len(models.QuoteIntervals))
+		for _, supported := range models.QuoteIntervals {
+			names = append(names, supported.Name)
+		}
+		return nil, fmt.Errorf("неподдерживаемый интервал %s, доступны: %s", interval, strings.Join(names, ", "))
 	}
 
 	if endDate.IsZero() {
 		endDate = time.Now()
 	}
 
-	return s.stockRepo.GetStockHistory(ctx, ticker, startDate, endDate)
+	if startDate.IsZero() {
+		if models.IsIntraday(spec.Name) {
+			startDate = endDate.Add(-24 * time.Hour) // Последние сутки по умолчанию
+		} else {
+			startDate = endDate.AddDate(0, -1, 0) // 1 месяц назад по умолчанию
+		}
+	}
+
+	if endDate.Sub(startDate) > spec.MaxRange {
+		return nil, fmt.Errorf("период для интервала %s не может превышать %s", spec.Name, formatRange(spec.MaxRange))
+	}
+
+	return s.stockRepo.GetStockHistory(ctx, ticker, spec.Name, startDate, endDate)
 }
 
-// GetMOEXTopGainers возвращает топ растущих акций на MOEX
-func (s *StockServiceImpl) GetMOEXTopGainers(ctx context.Context, limit int) ([]models.Stock, error) {
+// GetMOEXTopGainers возвращает топ растущих акций универсума на MOEX
+func (s *StockServiceImpl) GetMOEXTopGainers(ctx context.Context, universe string, limit int) ([]models.Stock, error) {
 	if limit <= 0 {
 		limit = 10 // Значение по умолчанию
 	}
 
-	// Здесь мы сначала получаем список всех акций
-	stocks, err := s.stockRepo.GetStocks(ctx, []string{})
+	// Здесь мы сначала получаем список акций универсума
+	stocks, err := s.getUniverseStocks(ctx, universe)
 	if err != nil {
 		return nil, err
 	}
 
-	// Сортируем по изменению цены (в процентах) в порядке убывания
-	// По хорошему нужно имплементировать sort.Interface, но для простоты используем bubble sort
-	// В реальном проекте стоит использовать более эффективные методы сортировки
-	n := len(stocks)
-	for i := 0; i < n-1; i++ {
-		for j := 0; j < n-i-1; j++ {
-			if stocks[j].ChangePerc < stocks[j+1].ChangePerc {
-				stocks[j], stocks[j+1] = stocks[j+1], stocks[j]
-			}
-		}
+	// Топ N акций по изменению цены (в процентах) в порядке убывания
+	return listutil.TopN(stocks, func(a, b models.Stock) bool {
+		return a.ChangePerc > b.ChangePerc
+	}, limit), nil
+}
+
+// GetMOEXTopLosers возвращает топ падающих акций универсума на MOEX
+func (s *StockServiceImpl) GetMOEXTopLosers(ctx context.Context, universe string, limit int) ([]models.Stock, error) {
+	if limit <= 0 {
+		limit = 10 // Значение по умолчанию
 	}
 
-	// Возвращаем топ N акций
-	if limit > len(stocks) {
-		limit = len(stocks)
+	// Здесь мы сначала получаем список акций универсума
+	stocks, err := s.getUniverseStocks(ctx, universe)
+	if err != nil {
+		return nil, err
 	}
-	return stocks[:limit], nil
+
+	// Топ N акций по изменению цены (в процентах) в порядке возрастания
+	return listutil.TopN(stocks, func(a, b models.Stock) bool {
+		return a.ChangePerc < b.ChangePerc
+	}, limit), nil
 }
 
-// GetMOEXTopLosers возвращает топ падающих акций на MOEX
-func (s *StockServiceImpl) GetMOEXTopLosers(ctx context.Context, limit int) ([]models.Stock, error) {
+// GetMOEXTopVolume возвращает акции универсума с наибольшим объемом торгов на MOEX
+func (s *StockServiceImpl) GetMOEXTopVolume(ctx context.Context, universe string, limit int) ([]models.Stock, error) {
 	if limit <= 0 {
 		limit = 10 // Значение по умолчанию
 	}
 
-	// Здесь мы сначала получаем список всех акций
-	stocks, err := s.stockRepo.GetStocks(ctx, []string{})
+	// Здесь мы сначала получаем список акций универсума
+	stocks, err := s.getUniverseStocks(ctx, universe)
 	if err != nil {
 		return nil, err
 	}
 
-	// Сортируем по изменению цены (в процентах) в порядке возрастания
-	n := len(stocks)
-	for i := 0; i < n-1; i++ {
-		for j := 0; j < n-i-1; j++ {
-			if stocks[j].ChangePerc > stocks[j+1].ChangePerc {
-				stocks[j], stocks[j+1] = stocks[j+1], stocks[j]
-			}
-		}
+	// Топ N акций по объему в порядке убывания
+	return listutil.TopN(stocks, func(a, b models.Stock) bool {
+		return a.Volume > b.Volume
+	}, limit), nil
+}
+
+// SearchStocks ищет акции универсума по названию или тикеру и возвращает страницу результатов и их общее количество.
+// Поиск идет по справочнику всех бумаг биржи с учетом опечаток; котировки загружаются только для бумаг страницы
+func (s *StockServiceImpl) SearchStocks(ctx context.Context, universe, query string, page models.Pagination) ([]models.Stock, int, error) {
+	if query == "" {
+		return nil, 0, fmt.Errorf("поисковый запрос не может быть пустым")
 	}
 
-	// Возвращаем топ N акций
-	if limit > len(stocks) {
-		limit = len(stocks)
+	selected, err := s.findUniverse(universe)
+	if err != nil {
+		return nil, 0, err
 	}
-	return stocks[:limit], nil
-}
 
-// GetMOEXTopVolume возвращает акции с наибольшим объемом торгов на MOEX
-func (s *StockServiceImpl) GetMOEXTopVolume(ctx context.Context, limit int) ([]models.Stock, error) {
-	if limit <= 0 {
-		limit = 10 // Значение по умолчанию
+	if s.securityService != nil {
+		securities, err := s.securityService.SearchSecurities(ctx, query)
+		if err == nil {
+			return s.securityPage(ctx, selected, securities, page)
+		}
+		log.Printf("Поиск по справочнику бумаг недоступен, ищем среди акций универсума: %v", err)
 	}
 
-	// Здесь мы сначала получаем список всех акций
-	stocks, err := s.stockRepo.GetStocks(ctx, []string{})
+	stocks, err := s.getUniverseStocks(ctx, universe)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
+	}
+
+	// Фильтруем акции по поисковому запросу
+	var result []models.Stock
+	for _, stock := range stocks {
+		if containsIgnoreCase(stock.Ticker, query) || containsIgnoreCase(stock.Name, query) {
+			result = append(result, stock)
+		}
 	}
 
-	// Сортируем по объему в порядке убывания
-	n := len(stocks)
-	for i := 0; i < n-1; i++ {
-		for j := 0; j < n-i-1; j++ {
-			if stocks[j].Volume < stocks[j+1].Volume {
-				stocks[j], stocks[j+1] = stocks[j+1], stocks[j]
+	start, end := page.WithDefaults().Bounds(len(result))
+	return result[start:end], len(result), nil
+}
+
+// securityPage оставляет найденные бумаги универсума и возвращает котировки бумаг страницы.
+// Бумаги без котировок (например, не торгующиеся сегодня) пропускаются
+func (s *StockServiceImpl) securityPage(ctx context.Context, universe *models.Universe, securities []models.Security, page models.Pagination) ([]models.Stock, int, error) {
+	if universe.Name != models.UniverseFull {
+		tickers, _, err := s.universeMembers(ctx, universe)
+		if err != nil {
+			return nil, 0, err
+		}
+		members := make(map[string]bool, len(tickers))
+		for _, ticker := range tickers {
+			members[strings.ToUpper(ticker)] = true
+		}
+		var filtered []models.Security
+		for _, security := range securities {
+			if members[security.Ticker] {
+				filtered = append(filtered, security)
 			}
 		}
+		securities = filtered
 	}
 
-	// Возвращаем топ N акций
-	if limit > len(stocks) {
-		limit = len(stocks)
+	total := len(securities)
+	start, end := page.WithDefaults().Bounds(total)
+	securities = securities[start:end]
+	tickers := make([]string, 0, len(securities))
+	for _, security := range securities {
+		tickers = append(tickers, security.Ticker)
 	}
-	return stocks[:limit], nil
-}
 
-// SearchStocks ищет акции по названию или тикеру
-func (s *StockServiceImpl) SearchStocks(ctx context.Context, query string) ([]models.Stock, error) {
-	if query == "" {
-		return nil, fmt.Errorf("поисковый запрос не может быть пустым")
+	found, errs := s.fetchStocks(ctx, tickers)
+	stocks := make([]models.Stock, 0, len(found))
+	for i, stock := range found {
+		if errs[i] != nil {
+			log.Printf("Не удалось получить котировку найденной бумаги %s: %v", tickers[i], errs[i])
+			continue
+		}
+		if stock.Name == "" {
+			stock.Name = securities[i].ShortName
+		}
+		stocks = append(stocks, *stock)
 	}
 
-	// Здесь реализуем поиск по всем акциям
-	// В реальном проекте эту функциональность лучше реализовать на уровне репозитория
+	return stocks, total, nil
+}
+
+// GetUniverses возвращает доступные торговые универсумы
+func (s *StockServiceImpl) GetUniverses() []models.Universe {
+	return s.universes
+}
 
-	// Получаем все акции
-	stocks, err := s.stockRepo.GetStocks(ctx, []string{})
+// GetMarketBreadth возвращает статистику ширины рынка по универсуму
+func (s *StockServiceImpl) GetMarketBreadth(ctx context.Context, universe string) (*models.MarketBreadth, error) {
+	selected, stocks, weights, err := s.getWeightedUniverseStocks(ctx, universe)
 	if err != nil {
 		return nil, err
 	}
 
-	// Фильтруем акции по поисковому запросу
-	var result []models.Stock
-	queryLower := query
+	breadth := marketBreadth(selected.Name, stocks)
+	applyIndexWeights(breadth, selected.Index, stocks, weights)
+	return breadth, nil
+}
 
+// marketBreadth рассчитывает ширину рынка по акциям выборки name
+func marketBreadth(name string, stocks []models.Stock) *models.MarketBreadth {
+	breadth := &models.MarketBreadth{
+		Universe:  name,
+		Total:     len(stocks),
+		UpdatedAt: time.Now(),
+	}
+
+	var totalChange float64
 	for _, stock := range stocks {
-		// Проверяем, содержится ли запрос в тикере или названии акции
-		if containsIgnoreCase(stock.Ticker, queryLower) || containsIgnoreCase(stock.Name, queryLower) {
-			result = append(result, stock)
+		switch {
+		case stock.ChangePerc > 0:
+			breadth.Advancers++
+		case stock.ChangePerc < 0:
+			breadth.Decliners++
+		default:
+			breadth.Unchanged++
 		}
+		totalChange += stock.ChangePerc
+		breadth.TotalVolume += stock.Volume
+	}
+	if len(stocks) > 0 {
+		breadth.AvgChangePerc = totalChange / float64(len(stocks))
+	}
+
+	return breadth
+}
+
+// applyIndexWeights добавляет к ширине рынка изменение, взвешенное по весам бумаг в индексе.
+// Бумаги без котировок в расчет не входят, поэтому веса нормируются на сумму весов найденных бумаг
+func applyIndexWeights(breadth *models.MarketBreadth, index string, stocks []models.Stock, weights map[string]float64) {
+	if index == "" {
+		return
 	}
 
-	return result, nil
+	var totalWeight, weightedChange float64
+	for _, stock := range stocks {
+		totalWeight += weights[stock.Ticker]
+		weightedChange += weights[stock.Ticker] * stock.ChangePerc
+	}
+	breadth.Index = index
+	if totalWeight > 0 {
+		breadth.WeightedChangePerc = weightedChange / totalWeight
+	}
 }
 
 // RefreshStockData запускает обновление данных по котировкам
@@ -198,6 +346,110 @@ func (s *StockServiceImpl) RefreshStockData(ctx context.Context) error {
 
 // Вспомогательные функции
 
+// getUniverseStocks возвращает акции универсума. Для универсума full возвращаются все акции,
+// для остальных — акции из списка универсума; недоступные тикеры пропускаются.
+func (s *StockServiceImpl) getUniverseStocks(ctx context.Context, universe string) ([]models.Stock, error) {
+	_, stocks, _, err := s.getWeightedUniverseStocks(ctx, universe)
+	return stocks, err
+}
+
+// getWeightedUniverseStocks возвращает универсум, его акции и веса акций в индексе; для универсумов,
+// не связанных с индексом, веса не заполняются
+func (s *StockServiceImpl) getWeightedUniverseStocks(ctx context.Context, universe string) (*models.Universe, []models.Stock, map[string]float64, error) {
+	selected, err := s.findUniverse(universe)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if selected.Name == models.UniverseFull {
+		stocks, err := s.stockRepo.GetStocks(ctx, []string{})
+		return selected, stocks, nil, err
+	}
+
+	tickers, weights, err := s.universeMembers(ctx, selected)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	found, errs := s.fetchStocks(ctx, tickers)
+	stocks := make([]models.Stock, 0, len(found))
+	for i, stock := range found {
+		if errs[i] != nil {
+			log.Printf("Не удалось получить акцию %s из универсума %s: %v", tickers[i], selected.Name, errs[i])
+			continue
+		}
+		stocks = append(stocks, *stock)
+	}
+
+	return selected, stocks, weights, nil
+}
+
+// universeMembers возвращает тикеры универсума. Состав универсума индекса берется из загруженного
+// состава индекса вместе с весами бумаг
+func (s *StockServiceImpl) universeMembers(ctx context.Context, universe *models.Universe) ([]string, map[string]float64, error) {
+	if universe.Index == "" {
+		return universe.Tickers, nil, nil
+	}
+
+	constituents, err := s.indexRepo.GetConstituents(ctx, universe.Index)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(constituents) == 0 {
+		return nil, nil, fmt.Errorf("состав индекса %s еще не загружен", universe.Index)
+	}
+
+	tickers := make([]string, 0, len(constituents))
+	weights := make(map[string]float64, len(constituents))
+	for _, constituent := range constituents {
+		tickers = append(tickers, constituent.Ticker)
+		weights[constituent.Ticker] = constituent.Weight
+	}
+	return tickers, weights, nil
+}
+
+// fetchStocks параллельно запрашивает котировки тикеров. Котировки и ошибки возвращаются
+// в порядке тикеров, чтобы вызывающий сам решил, пропускать ли недоступные бумаги
+func (s *StockServiceImpl) fetchStocks(ctx context.Context, tickers []string) ([]*models.Stock, []error) {
+	stocks := make([]*models.Stock, len(tickers))
+	errs := make([]error, len(tickers))
+	counter := progress.NewCounter(ctx, len(tickers), "Получено котировок: %d из %d")
+	s.pool.Run(ctx, len(tickers), func(ctx context.Context, i int) error {
+		stocks[i], errs[i] = s.stockRepo.GetStock(ctx, tickers[i])
+		counter.Done()
+		return nil
+	})
+	return stocks, errs
+}
+
+// findUniverse возвращает универсум по имени; пустое имя — универсум full
+func (s *StockServiceImpl) findUniverse(universe string) (*models.Universe, error) {
+	universe = universeName(universe)
+	names := make([]string, 0, len(s.universes))
+	for i := range s.universes {
+		if s.universes[i].Name == universe {
+			return &s.universes[i], nil
+		}
+		names = append(names, s.universes[i].Name)
+	}
+	return nil, fmt.Errorf("неизвестный универсум %s, доступны: %s", universe, strings.Join(names, ", "))
+}
+
+// universeName возвращает универсум full, если имя не указано
+func universeName(name string) string {
+	if name == "" {
+		return models.UniverseFull
+	}
+	return strings.ToLower(name)
+}
+
+// formatRange форматирует продолжительность в днях или часах
+func formatRange(d time.Duration) string {
+	if d >= 24*time.Hour {
+		return fmt.Sprintf("%d дн.", int(d/(24*time.Hour)))
+	}
+	return fmt.Sprintf("%d ч", int(d/time.Hour))
+}
+
 // containsIgnoreCase проверяет, содержит ли строка подстроку без учета регистра
 func containsIgnoreCase(s, substr string) bool {
 	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
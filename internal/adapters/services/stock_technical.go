@@ -0,0 +1,247 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+const (
+	// pivotWindow количество свечей с каждой стороны, выше (ниже) которых должен быть локальный максимум (минимум)
+	pivotWindow = 2
+	// levelMergePerc уровни, отстоящие друг от друга меньше чем на этот процент, объединяются в один
+	levelMergePerc = 0.5
+	// maxLevels максимальное количество уровней поддержки и сопротивления
+	maxLevels = 3
+)
+
+// GetTechnicalIndicators рассчитывает SMA, RSI, MACD и уровни поддержки и сопротивления по свечам интервала из базы или с биржи.
+// Глубина истории — TechnicalLookbackDays, но не больше допустимого периода интервала
+func (s *StockServiceImpl) GetTechnicalIndicators(ctx context.Context, ticker, interval string) (*models.TechnicalIndicators, error) {
+	spec, ok := models.FindQuoteInterval(interval)
+	if !ok {
+		return nil, fmt.Errorf("неподдерживаемый интервал %s", interval)
+	}
+
+	lookback := time.Duration(models.TechnicalLookbackDays) * 24 * time.Hour
+	if spec.MaxRange < lookback {
+		lookback = spec.MaxRange
+	}
+	now := time.Now()
+
+	history, err := s.GetStockHistoricalData(ctx, ticker, spec.Name, now.Add(-lookback), now)
+	if err != nil {
+		return nil, err
+	}
+
+	candles := make([]models.StockQuote, 0, len(history))
+	for _, candle := range history {
+		if candle.Close > 0 {
+			candles = append(candles, candle)
+		}
+	}
+	sort.Slice(candles, func(i, j int) bool {
+		return candles[i].Date.Before(candles[j].Date)
+	})
+	if len(candles) < models.TechnicalMinCandles {
+		return nil, fmt.Errorf("недостаточно свечей %s для расчета индикаторов: %d из %d, загрузите историю котировок",
+			spec.Name, len(candles), models.TechnicalMinCandles)
+	}
+
+	closes := make([]float64, len(candles))
+	for i, candle := range candles {
+		closes[i] = candle.Close
+	}
+
+	last := candles[len(candles)-1]
+	result := &models.TechnicalIndicators{
+		Ticker:     last.Ticker,
+		Interval:   spec.Name,
+		From:       candles[0].Date,
+		To:         last.Date,
+		Candles:    len(candles),
+		LastClose:  last.Close,
+		PeriodHigh: candles[0].High,
+		PeriodLow:  candles[0].Low,
+		SMA20:      sma(closes, 20),
+		SMA50:      sma(closes, 50),
+		SMA200:     sma(closes, 200),
+		RSI14:      rsi(closes, 14),
+	}
+	if result.Ticker == "" {
+		result.Ticker = ticker
+	}
+	for _, candle := range candles {
+		result.PeriodHigh = math.Max(result.PeriodHigh, candle.High)
+		if candle.Low > 0 && (result.PeriodLow == 0 || candle.Low < result.PeriodLow) {
+			result.PeriodLow = candle.Low
+		}
+	}
+
+	macdLine := subtractSeries(ema(closes, 12), ema(closes, 26))
+	signal := ema(macdLine, 9)
+	result.MACD = macdLine[len(macdLine)-1]
+	result.MACDSignal = signal[len(signal)-1]
+	result.MACDHistogram = result.MACD - result.MACDSignal
+
+	result.Supports, result.Resistances = supportResistance(candles, last.Close)
+
+	recent := candles
+	if len(recent) > models.TechnicalRecentCandles {
+		recent = recent[len(recent)-models.TechnicalRecentCandles:]
+	}
+	result.RecentCandles = recent
+
+	return result, nil
+}
+
+// sma возвращает простую скользящую среднюю последних period значений; 0, если значений меньше period
+func sma(values []float64, period int) float64 {
+	if len(values) < period {
+		return 0
+	}
+	return meanOf(values[len(values)-period:])
+}
+
+// ema возвращает ряд экспоненциальной скользящей средней, начинающийся с period-го значения;
+// первое значение ряда — простая средняя первых period значений
+func ema(values []float64, period int) []float64 {
+	if len(values) < period {
+		return nil
+	}
+
+	alpha := 2 / float64(period+1)
+	result := make([]float64, 0, len(values)-period+1)
+	current := meanOf(values[:period])
+	result = append(result, current)
+	for _, value := range values[period:] {
+		current = alpha*value + (1-alpha)*current
+		result = append(result, current)
+	}
+
+	return result
+}
+
+// subtractSeries возвращает разность рядов a − b, выровненных по последнему значению
+func subtractSeries(a, b []float64) []float64 {
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+
+	result := make([]float64, n)
+	for i := 0; i < n; i++ {
+		result[i] = a[len(a)-n+i] - b[len(b)-n+i]
+	}
+
+	return result
+}
+
+// rsi возвращает индекс относительной силы со сглаживанием Уайлдера; 0, если значений недостаточно.
+// Без изменений цены за период RSI нейтрален (50), а не перекуплен
+func rsi(values []float64, period int) float64 {
+	if len(values) <= period {
+		return 0
+	}
+
+	var avgGain, avgLoss float64
+	for i := 1; i <= period; i++ {
+		change := values[i] - values[i-1]
+		if change > 0 {
+			avgGain += change
+		} else {
+			avgLoss -= change
+		}
+	}
+	avgGain /= float64(period)
+	avgLoss /= float64(period)
+
+	for i := period + 1; i < len(values); i++ {
+		change := values[i] - values[i-1]
+		gain, loss := math.Max(change, 0), math.Max(-change, 0)
+		avgGain = (avgGain*float64(period-1) + gain) / float64(period)
+		avgLoss = (avgLoss*float64(period-1) + loss) / float64(period)
+	}
+
+	switch {
+	case avgGain == 0 && avgLoss == 0:
+		return 50
+	case avgLoss == 0:
+		return 100
+	}
+	return 100 - 100/(1+avgGain/avgLoss)
+}
+
+// supportResistance находит уровни поддержки и сопротивления по локальным минимумам и максимумам свечей.
+// Близкие уровни объединяются; возвращается не более maxLevels ближайших к цене уровней с каждой стороны
+func supportResistance(candles []models.StockQuote, price float64) ([]float64, []float64) {
+	var lows, highs []float64
+	for i := pivotWindow; i < len(candles)-pivotWindow; i++ {
+		isLow, isHigh := candles[i].Low > 0, true
+		for j := i - pivotWindow; j <= i+pivotWindow; j++ {
+			if j == i {
+				continue
+			}
+			if candles[j].Low < candles[i].Low {
+				isLow = false
+			}
+			if candles[j].High > candles[i].High {
+				isHigh = false
+			}
+		}
+		if isLow {
+			lows = append(lows, candles[i].Low)
+		}
+		if isHigh {
+			highs = append(highs, candles[i].High)
+		}
+	}
+
+	var supports, resistances []float64
+	for _, level := range mergeLevels(append(lows, highs...)) {
+		switch {
+		case level < price:
+			supports = append(supports, level)
+		case level > price:
+			resistances = append(resistances, level)
+		}
+	}
+
+	// Ближайшие к цене уровни идут первыми
+	sort.Sort(sort.Reverse(sort.Float64Slice(supports)))
+	sort.Float64s(resistances)
+	if len(supports) > maxLevels {
+		supports = supports[:maxLevels]
+	}
+	if len(resistances) > maxLevels {
+		resistances = resistances[:maxLevels]
+	}
+
+	return supports, resistances
+}
+
+// mergeLevels объединяет уровни, отстоящие друг от друга меньше чем на levelMergePerc, в их среднее
+func mergeLevels(levels []float64) []float64 {
+	if len(levels) == 0 {
+		return nil
+	}
+	sort.Float64s(levels)
+
+	var merged []float64
+	group := []float64{levels[0]}
+	for _, level := range levels[1:] {
+		if percent(level, group[0]) < levelMergePerc {
+			group = append(group, level)
+			continue
+		}
+		merged = append(merged, meanOf(group))
+		group = []float64{level}
+	}
+	merged = append(merged, meanOf(group))
+
+	return merged
+}
@@ -0,0 +1,115 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/progress"
+)
+
+// volumeHistoryDays календарная глубина истории, в которую укладываются VolumeLookbackSessions сессий с учетом выходных и праздников
+const volumeHistoryDays = 2 * models.VolumeLookbackSessions
+
+// GetUnusualVolume сравнивает объем торгов бумаг универсума за день со средним за VolumeLookbackSessions
+// предыдущих сессий. Средний объем считается только по сохраненным дневным свечам, без запросов к бирже по каждой бумаге:
+// бумаги, у которых сохранено меньше VolumeMinSessions сессий, пропускаются и попадают в список для загрузки истории
+func (s *StockServiceImpl) GetUnusualVolume(ctx context.Context, universe string, minRatio float64, limit int) (*models.VolumeAnomalyReport, error) {
+	if minRatio <= 0 {
+		minRatio = models.DefaultVolumeAnomalyRatio
+	}
+	if limit <= 0 {
+		limit = models.DefaultVolumeAnomalyLimit
+	}
+
+	selected, stocks, _, err := s.getWeightedUniverseStocks(ctx, universe)
+	if err != nil {
+		return nil, err
+	}
+	if len(stocks) == 0 {
+		return nil, fmt.Errorf("нет котировок универсума %s", selected.Name)
+	}
+
+	now := time.Now()
+	to := dayStart(now)
+	from := to.AddDate(0, 0, -volumeHistoryDays)
+
+	averages := make([]float64, len(stocks))
+	sessions := make([]int, len(stocks))
+	failed := make([]bool, len(stocks))
+	counter := progress.NewCounter(ctx, len(stocks), "Проверено объемов: %d из %d")
+	s.pool.Run(ctx, len(stocks), func(ctx context.Context, i int) error {
+		defer counter.Done()
+		history, err := s.stockRepo.GetStoredHistory(ctx, stocks[i].Ticker, models.IntervalDay, from, to)
+		if err != nil {
+			log.Printf("Не удалось получить историю объемов %s: %v", stocks[i].Ticker, err)
+			failed[i] = true
+			return nil
+		}
+		averages[i], sessions[i] = averageVolume(history, dayStart(stocks[i].UpdatedAt))
+		return nil
+	})
+
+	report := &models.VolumeAnomalyReport{
+		Universe:  selected.Name,
+		MinRatio:  minRatio,
+		CheckedAt: now,
+	}
+	for i, stock := range stocks {
+		if sessions[i] < models.VolumeMinSessions || averages[i] <= 0 {
+			report.Skipped++
+			if !failed[i] {
+				report.Backfill = append(report.Backfill, stock.Ticker)
+			}
+			continue
+		}
+		report.Scanned++
+
+		ratio := float64(stock.Volume) / averages[i]
+		if ratio < minRatio {
+			continue
+		}
+		report.Anomalies = append(report.Anomalies, models.VolumeAnomaly{
+			Ticker:        stock.Ticker,
+			Name:          stock.Name,
+			Price:         stock.Price,
+			ChangePerc:    stock.ChangePerc,
+			Volume:        stock.Volume,
+			AverageVolume: averages[i],
+			Sessions:      sessions[i],
+			Ratio:         ratio,
+		})
+	}
+
+	sort.Slice(report.Anomalies, func(i, j int) bool {
+		return report.Anomalies[i].Ratio > report.Anomalies[j].Ratio
+	})
+	report.Total = len(report.Anomalies)
+	if len(report.Anomalies) > limit {
+		report.Anomalies = report.Anomalies[:limit]
+	}
+
+	return report, nil
+}
+
+// averageVolume возвращает средний объем последних VolumeLookbackSessions сессий до session и их количество.
+// Свечи должны идти в хронологическом порядке, как их возвращает репозиторий; свечи текущей сессии и сессии без сделок не учитываются
+func averageVolume(history []models.StockQuote, session time.Time) (float64, int) {
+	var total int64
+	count := 0
+	for i := len(history) - 1; i >= 0 && count < models.VolumeLookbackSessions; i-- {
+		candle := history[i]
+		if !candle.Date.Before(session) || candle.Volume <= 0 {
+			continue
+		}
+		total += candle.Volume
+		count++
+	}
+	if count == 0 {
+		return 0, 0
+	}
+	return float64(total) / float64(count), count
+}
@@ -0,0 +1,345 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/repositories"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/services"
+
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// symbolReloadInterval период обновления справочника бумаг; после неудачной загрузки — период повторной попытки
+	symbolReloadInterval = 24 * time.Hour
+	symbolRetryInterval  = 10 * time.Minute
+	// symbolLoadTimeout ограничение времени загрузки справочника бумаг
+	symbolLoadTimeout = 30 * time.Second
+	// minFuzzySymbol минимальная длина строки, для которой ищутся похожие названия
+	minFuzzySymbol = 3
+)
+
+// SymbolServiceImpl реализация интерфейса SymbolService
+type SymbolServiceImpl struct {
+	directory repositories.SecurityDirectory
+	// securities справочник бумаг для ISIN, figi — сопоставление FIGI; оба необязательны
+	securities services.SecurityService
+	figi       repositories.FIGIResolver
+	// aliases названия компаний по тикерам в порядке приоритета; справочник бумаг идет после первого набора
+	aliases []map[string][]string
+
+	// mu защищает только поля индекса: справочник загружается без блокировки, одной загрузкой на всех через loads
+	mu       sync.Mutex
+	index    *symbolIndex
+	loaded   bool      // Справочник бумаг загружен
+	loadedAt time.Time // Время последней попытки загрузки
+	loads    singleflight.Group
+}
+
+// NewSymbolService создает новый экземпляр сервиса распознавания тикеров.
+// configAliases — названия из конфигурации, они важнее справочника бумаг directory; builtinAliases — встроенные, они используются последними.
+// ISIN распознаются по справочнику securities, FIGI — через figi; без них такие строки не распознаются
+func NewSymbolService(directory repositories.SecurityDirectory, securities services.SecurityService, figi repositories.FIGIResolver, configAliases, builtinAliases map[string][]string) services.SymbolService {
+	return &SymbolServiceImpl{
+		directory:  directory,
+		securities: securities,
+		figi:       figi,
+		aliases:    []map[string][]string{configAliases, builtinAliases},
+	}
+}
+
+// ResolveTicker сопоставляет введенную строку тикеру: сначала точно по тикеру и названию,
+// затем по ближайшему написанию и началу названия
+func (s *SymbolServiceImpl) ResolveTicker(ctx context.Context, input string) (models.SymbolResolution, error) {
+	result := models.SymbolResolution{Input: input}
+	query := strings.TrimSpace(input)
+	if query == "" {
+		return result, nil
+	}
+
+	// Бумаги других бирж (NASDAQ:AAPL) справочник MOEX не описывает
+	if strings.Contains(query, ":") {
+		result.Ticker, result.Match = strings.ToUpper(query), models.SymbolMatchExact
+		return result, nil
+	}
+
+	upper := strings.ToUpper(query)
+
+	// ISIN и FIGI похожи на тикеры, поэтому распознаются раньше них и не передаются источнику котировок как есть.
+	// FIGI проверяется первым: по форме он тоже подходит под ISIN
+	switch {
+	case models.IsFIGI(upper):
+		return s.resolveFIGI(ctx, result, upper)
+	case models.IsISIN(upper):
+		return s.resolveISIN(ctx, result, upper)
+	}
+
+	index := s.currentIndex(ctx)
+
+	if index.tickers[upper] {
+		result.Ticker, result.Match = upper, models.SymbolMatchExact
+		return result, nil
+	}
+
+	name := normalizeSymbolName(query)
+	if ticker, ok := index.names[name]; ok {
+		result.Ticker, result.Match = ticker, models.SymbolMatchAlias
+		return result, nil
+	}
+
+	if candidates := index.closest(name); len(candidates) == 1 {
+		result.Ticker, result.Match = candidates[0], models.SymbolMatchFuzzy
+		return result, nil
+	} else if len(candidates) > 1 {
+		result.Candidates = candidates
+		return result, nil
+	}
+
+	// Без справочника бумаг, а также для бумаг вне основного режима торгов (облигации, фонды)
+	// строка, похожая на тикер, передается как есть: бумагу найдет или не найдет источник котировок
+	if looksLikeTicker(query) {
+		result.Ticker, result.Match = upper, models.SymbolMatchExact
+	}
+
+	return result, nil
+}
+
+// resolveISIN находит тикер бумаги по ISIN в справочнике бумаг MOEX
+func (s *SymbolServiceImpl) resolveISIN(ctx context.Context, result models.SymbolResolution, isin string) (models.SymbolResolution, error) {
+	if s.securities == nil {
+		return result, nil
+	}
+	security, err := s.securities.LookupSecurity(ctx, isin)
+	if err != nil {
+		return result, err
+	}
+	if security != nil {
+		result.Ticker, result.Match = security.Ticker, models.SymbolMatchISIN
+	}
+	return result, nil
+}
+
+// resolveFIGI сопоставляет FIGI тикеру через OpenFIGI и принимает только листинги MOEX: FIGI зарубежного
+// листинга (например, расписки на LSE) тикеру MOEX не соответствует, даже если тикеры совпадают.
+// Дополнительно тикер проверяется по справочнику бумаг
+func (s *SymbolServiceImpl) resolveFIGI(ctx context.Context, result models.SymbolResolution, figi string) (models.SymbolResolution, error) {
+	if s.figi == nil {
+		return result, nil
+	}
+	mapping, err := s.figi.ResolveFIGI(ctx, figi)
+	if err != nil {
+		return result, fmt.Errorf("не удалось сопоставить FIGI %s: %w", figi, err)
+	}
+	if mapping == nil || !models.IsMOEXExchCode(mapping.ExchCode) {
+		return result, nil
+	}
+	if s.securities != nil {
+		security, err := s.securities.LookupSecurity(ctx, mapping.Ticker)
+		if err != nil {
+			return result, err
+		}
+		if security == nil {
+			return result, nil
+		}
+	}
+	result.Ticker, result.Match = mapping.Ticker, models.SymbolMatchFIGI
+	return result, nil
+}
+
+// currentIndex возвращает индекс названий, при необходимости загружая справочник бумаг.
+// Устаревший индекс возвращается сразу и обновляется в фоне; без индекса запрос ждет первой загрузки.
+// Если справочник недоступен, индекс строится по названиям из конфигурации и встроенным
+func (s *SymbolServiceImpl) currentIndex(ctx context.Context) *symbolIndex {
+	s.mu.Lock()
+	index := s.index
+	interval := symbolReloadInterval
+	if !s.loaded {
+		interval = symbolRetryInterval
+	}
+	fresh := index != nil && time.Since(s.loadedAt) < interval
+	s.mu.Unlock()
+	if fresh {
+		return index
+	}
+
+	// Загрузка не прерывается отменой запроса, который ее начал: ее результат нужен всем
+	loaded := s.loads.DoChan("index", func() (interface{}, error) {
+		loadCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), symbolLoadTimeout)
+		defer cancel()
+		return s.loadIndex(loadCtx), nil
+	})
+	if index != nil {
+		return index
+	}
+
+	select {
+	case result := <-loaded:
+		return result.Val.(*symbolIndex)
+	case <-ctx.Done():
+		return newSymbolIndex(s.aliases...)
+	}
+}
+
+// loadIndex загружает справочник бумаг и заменяет индекс; если справочник недоступен, остается прежний индекс
+func (s *SymbolServiceImpl) loadIndex(ctx context.Context) *symbolIndex {
+	securities, err := s.directory.SecurityNames(ctx)
+	var index *symbolIndex
+	if err != nil {
+		log.Printf("Не удалось загрузить справочник бумаг для распознавания тикеров: %v", err)
+	} else {
+		index = newSymbolIndex(s.aliases[0], securities, s.aliases[1])
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.loadedAt = time.Now()
+	switch {
+	case index != nil:
+		s.index, s.loaded = index, true
+	case s.index == nil:
+		s.index = newSymbolIndex(s.aliases...)
+	}
+	return s.index
+}
+
+// symbolIndex тикеры и названия компаний для распознавания введенных строк
+type symbolIndex struct {
+	tickers map[string]bool
+	names   map[string]string // Нормализованное название → тикер
+}
+
+// newSymbolIndex строит индекс из наборов названий по тикерам; при совпадении названий побеждает более ранний набор
+func newSymbolIndex(sources ...map[string][]string) *symbolIndex {
+	index := &symbolIndex{
+		tickers: make(map[string]bool),
+		names:   make(map[string]string),
+	}
+	for _, source := range sources {
+		// Тикеры перебираются по порядку, чтобы совпадение названий внутри набора разрешалось одинаково
+		tickers := make([]string, 0, len(source))
+		for ticker := range source {
+			tickers = append(tickers, ticker)
+		}
+		sort.Strings(tickers)
+
+		for _, ticker := range tickers {
+			normalized := strings.ToUpper(strings.TrimSpace(ticker))
+			if normalized == "" {
+				continue
+			}
+			index.tickers[normalized] = true
+			for _, name := range source[ticker] {
+				key := normalizeSymbolName(name)
+				if _, ok := index.names[key]; key != "" && !ok {
+					index.names[key] = normalized
+				}
+			}
+		}
+	}
+	return index
+}
+
+// closest возвращает тикеры, названия которых ближе всего к строке по расстоянию редактирования
+// (не дальше четверти длины строки), а если таких нет — тикеры названий, начинающихся со строки.
+// С тикерами строка приблизительно не сравнивается: настоящий тикер бумаги вне справочника (фонда, облигации)
+// не должен подменяться похожим тикером акции
+func (idx *symbolIndex) closest(name string) []string {
+	length := len([]rune(name))
+	if length < minFuzzySymbol {
+		return nil
+	}
+	maxDistance := length / 4
+	if maxDistance < 1 {
+		maxDistance = 1
+	}
+
+	best := maxDistance + 1
+	matches := make(map[string]bool)
+	consider := func(candidate, ticker string) {
+		distance := editDistance(name, candidate)
+		switch {
+		case distance < best:
+			best = distance
+			matches = map[string]bool{ticker: true}
+		case distance == best:
+			matches[ticker] = true
+		}
+	}
+	for candidate, ticker := range idx.names {
+		consider(candidate, ticker)
+	}
+
+	if len(matches) == 0 {
+		for candidate, ticker := range idx.names {
+			if strings.HasPrefix(candidate, name) {
+				matches[ticker] = true
+			}
+		}
+	}
+
+	tickers := make([]string, 0, len(matches))
+	for ticker := range matches {
+		tickers = append(tickers, ticker)
+	}
+	sort.Strings(tickers)
+	if len(tickers) > models.MaxSymbolCandidates {
+		tickers = tickers[:models.MaxSymbolCandidates]
+	}
+	return tickers
+}
+
+// normalizeSymbolName приводит название к нижнему регистру, заменяет «ё» на «е» и убирает кавычки и лишние пробелы
+func normalizeSymbolName(name string) string {
+	name = strings.ReplaceAll(strings.ToLower(name), "ё", "е")
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(words, " ")
+}
+
+// looksLikeTicker проверяет, что строка может быть тикером: латинские буквы и цифры без пробелов
+func looksLikeTicker(s string) bool {
+	for _, r := range s {
+		if r > unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	return true
+}
+
+// editDistance возвращает расстояние Дамерау — Левенштейна между строками: число вставок, удалений,
+// замен и перестановок соседних символов
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				cur[j] = min(cur[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+
+	return prev[len(rb)]
+}
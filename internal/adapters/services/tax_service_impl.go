@@ -0,0 +1,185 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/repositories"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/services"
+)
+
+// TaxServiceImpl реализация интерфейса TaxService
+type TaxServiceImpl struct {
+	portfolioRepo repositories.PortfolioRepository
+	// eventRepo необязателен: без календаря корпоративных событий дивиденды не учитываются
+	eventRepo repositories.CorporateEventRepository
+}
+
+// NewTaxService создает новый экземпляр сервиса оценки налогов
+func NewTaxService(portfolioRepo repositories.PortfolioRepository, eventRepo repositories.CorporateEventRepository) services.TaxService {
+	return &TaxServiceImpl{
+		portfolioRepo: portfolioRepo,
+		eventRepo:     eventRepo,
+	}
+}
+
+// EstimateTaxes оценивает НДФЛ по портфелю за календарный год: с результата продаж с учетом льготы
+// долгосрочного владения и с дивидендов
+func (s *TaxServiceImpl) EstimateTaxes(ctx context.Context, portfolio string, year int) (*models.TaxEstimate, error) {
+	portfolio = portfolioName(portfolio)
+	now := time.Now().In(models.MoscowLocation)
+	if year == 0 {
+		year = now.Year()
+	}
+	if year < 2014 || year > now.Year() {
+		return nil, fmt.Errorf("год должен быть от 2014 до %d", now.Year())
+	}
+
+	trades, err := s.portfolioRepo.GetTrades(ctx, portfolio, "")
+	if err != nil {
+		return nil, err
+	}
+	if len(trades) == 0 {
+		return nil, fmt.Errorf("в портфеле %s нет истории сделок: она ведется с момента изменения позиций через add_position и remove_position", portfolio)
+	}
+
+	estimate := &models.TaxEstimate{Portfolio: portfolio, Year: year}
+
+	realized, open := matchTradesFIFO(trades)
+	estimate.Lots = yearLots(realized, year)
+	applyTradingTax(estimate)
+
+	if s.eventRepo != nil {
+		estimate.DividendsKnown = true
+		estimate.Dividends, err = s.dividendIncome(ctx, trades, year)
+		if err != nil {
+			log.Printf("Не удалось получить дивиденды за %d год: %v", year, err)
+			estimate.DividendsKnown = false
+		}
+		for _, dividend := range estimate.Dividends {
+			estimate.DividendIncome += dividend.Amount
+		}
+	}
+
+	// Инвестиционные доходы образуют одну налоговую базу для прогрессивной шкалы; налог с дивидендов
+	// удерживается при выплате, поэтому на продажи приходится остаток налога с общей базы
+	estimate.DividendTax = models.NDFL(estimate.DividendIncome, year)
+	estimate.TotalTax = models.NDFL(estimate.TradingBase+estimate.DividendIncome, year)
+	estimate.TradingTax = estimate.TotalTax - estimate.DividendTax
+
+	estimate.LongTermLots = longTermLots(open, now)
+
+	return estimate, nil
+}
+
+// yearLots отбирает реализованные лоты, проданные в году year по московскому времени
+func yearLots(realized []models.RealizedLot, year int) []models.RealizedLot {
+	var result []models.RealizedLot
+	for _, lot := range realized {
+		if lot.SoldAt.In(models.MoscowLocation).Year() == year {
+			result = append(result, lot)
+		}
+	}
+	return result
+}
+
+// applyTradingTax рассчитывает финансовый результат продаж и льготу долгосрочного владения.
+// Предел льготы — Кцб × 3 млн ₽, где Кцб — средневзвешенное по выручке число полных лет владения
+// бумагами, проданными после трех лет владения
+func applyTradingTax(estimate *models.TaxEstimate) {
+	var longTermProceeds, weightedYears float64
+	for _, lot := range estimate.Lots {
+		estimate.Proceeds += lot.Proceeds
+		estimate.Cost += lot.Cost
+		estimate.Gain += lot.Gain
+		estimate.HasUnmatched = estimate.HasUnmatched || lot.Unmatched
+		estimate.HasOpening = estimate.HasOpening || lot.Opening
+
+		if models.LongTermEligible(lot) {
+			estimate.LongTermGain += lot.Gain
+			longTermProceeds += lot.Proceeds
+			weightedYears += lot.Proceeds * float64(lot.HoldingYears())
+		}
+	}
+
+	if longTermProceeds > 0 {
+		estimate.LongTermLimit = weightedYears / longTermProceeds * models.LongTermExemptionPerYear
+	}
+	estimate.LongTermExemption = max(0, min(estimate.LongTermGain, estimate.LongTermLimit, estimate.Gain))
+	estimate.TradingBase = max(0, estimate.Gain-estimate.LongTermExemption)
+}
+
+// dividendIncome рассчитывает рублевые дивиденды года year по бумагам, которые были в портфеле
+// на дату закрытия реестра
+func (s *TaxServiceImpl) dividendIncome(ctx context.Context, trades []models.PortfolioTrade, year int) ([]models.DividendIncome, error) {
+	from := time.Date(year, time.January, 1, 0, 0, 0, 0, models.MoscowLocation)
+	events, err := s.eventRepo.GetEvents(ctx, models.CorporateEventsFilter{
+		Types: []string{models.EventTypeDividend},
+		From:  from,
+		To:    from.AddDate(1, 0, 0).Add(-time.Nanosecond),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result []models.DividendIncome
+	for _, event := range uniqueDividends(events) {
+		if event.Value <= 0 || !isRubleCurrency(event.Currency) {
+			continue
+		}
+		quantity := heldOnRecordDate(trades, event.Ticker, event.Date)
+		if quantity <= 0 {
+			continue
+		}
+		result = append(result, models.DividendIncome{
+			Ticker:     event.Ticker,
+			RecordDate: event.Date,
+			Quantity:   quantity,
+			PerShare:   event.Value,
+			Amount:     event.Value * float64(quantity),
+		})
+	}
+
+	return result, nil
+}
+
+// heldOnRecordDate возвращает количество бумаг в портфеле на дату закрытия реестра. С расчетами Т+1
+// в реестр попадают бумаги, купленные до даты закрытия, поэтому учитываются сделки предыдущих дней
+func heldOnRecordDate(trades []models.PortfolioTrade, ticker string, recordDate time.Time) int64 {
+	recordDay := dayStart(recordDate.In(models.MoscowLocation))
+
+	var quantity int64
+	for _, trade := range trades {
+		if trade.Ticker != ticker || !trade.ExecutedAt.Before(recordDay) {
+			continue
+		}
+		if trade.Side == models.OrderSideSell {
+			quantity -= trade.Quantity
+		} else {
+			quantity += trade.Quantity
+		}
+	}
+	return quantity
+}
+
+// longTermLots отбирает открытые лоты, к продаже которых применима льгота долгосрочного владения,
+// по дате начала ее действия
+func longTermLots(open []models.OpenLot, now time.Time) []models.LongTermLot {
+	var result []models.LongTermLot
+	for _, lot := range open {
+		from := models.LongTermFrom(lot)
+		if from.IsZero() {
+			continue
+		}
+		result = append(result, models.LongTermLot{OpenLot: lot, EligibleFrom: from, Eligible: !from.After(now)})
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].EligibleFrom.Before(result[j].EligibleFrom)
+	})
+	return result
+}
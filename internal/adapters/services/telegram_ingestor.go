@@ -0,0 +1,108 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/adapters/repositories/apis"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/repositories"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/cache"
+)
+
+// TelegramIngestor периодически забирает сообщения Telegram-каналов и сохраняет их
+// в репозиторий новостей, чтобы они попадали в поиск, выборки по дате и по тикерам
+type TelegramIngestor struct {
+	client   *apis.TelegramClient
+	newsRepo repositories.NewsRepository
+	cache    cache.Cache
+	interval time.Duration
+}
+
+// NewTelegramIngestor создает фоновую загрузку сообщений Telegram-каналов
+func NewTelegramIngestor(
+	client *apis.TelegramClient,
+	newsRepo repositories.NewsRepository,
+	cache cache.Cache,
+	interval time.Duration,
+) *TelegramIngestor {
+	return &TelegramIngestor{
+		client:   client,
+		newsRepo: newsRepo,
+		cache:    cache,
+		interval: interval,
+	}
+}
+
+// Run загружает сообщения до отмены контекста
+func (i *TelegramIngestor) Run(ctx context.Context) {
+	ticker := time.NewTicker(i.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := i.ingest(ctx); err != nil {
+			log.Printf("Ошибка загрузки новостей из Telegram: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ingest выполняет одну загрузку новых сообщений
+func (i *TelegramIngestor) ingest(ctx context.Context) error {
+	news, next, err := i.client.GetChannelPosts(ctx)
+	if err != nil {
+		return err
+	}
+	if len(news) == 0 {
+		i.client.CommitOffset(next)
+		return nil
+	}
+
+	// Смещение подтверждается только после сохранения: при ошибке сообщения будут прочитаны повторно
+	if err := i.newsRepo.SaveNewsCollection(ctx, news); err != nil {
+		return err
+	}
+	i.client.CommitOffset(next)
+
+	// Сбрасываем кэш выборок за дни новых сообщений, чтобы они сразу попадали в результаты
+	days := make(map[string]bool)
+	for _, item := range news {
+		days[item.PublishedAt.UTC().Format("2006-01-02")] = true
+	}
+	for day := range days {
+		if err := i.cache.Delete(ctx, models.NewsDateCacheKey(day)); err != nil {
+			log.Printf("Ошибка сброса кэша новостей за %s: %v", day, err)
+		}
+	}
+
+	log.Printf("Из Telegram загружено сообщений: %d (%s)", len(news), telegramSummary(news))
+	return nil
+}
+
+// telegramSummary возвращает количество сообщений по источникам для лога
+func telegramSummary(news []models.News) string {
+	counts := make(map[string]int)
+	var order []string
+	for _, item := range news {
+		if counts[item.Source] == 0 {
+			order = append(order, item.Source)
+		}
+		counts[item.Source]++
+	}
+
+	summary := ""
+	for idx, source := range order {
+		if idx > 0 {
+			summary += ", "
+		}
+		summary += fmt.Sprintf("%s: %d", source, counts[source])
+	}
+	return summary
+}
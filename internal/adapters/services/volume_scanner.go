@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/services"
+)
+
+// VolumeScanner периодически ищет бумаги универсума с аномальным объемом торгов и отмечает в журнале
+// новые за день аномалии. Заодно проверка прогревает кэш истории объемов для get_unusual_volume
+type VolumeScanner struct {
+	stockService services.StockService
+	universe     string
+	minRatio     float64
+	interval     time.Duration
+
+	flagged    map[string]bool // Тикеры, уже отмеченные за день flaggedDay
+	flaggedDay time.Time
+}
+
+// NewVolumeScanner создает фоновый поиск аномальных объемов с указанным периодом
+func NewVolumeScanner(stockService services.StockService, universe string, minRatio float64, interval time.Duration) *VolumeScanner {
+	return &VolumeScanner{
+		stockService: stockService,
+		universe:     universe,
+		minRatio:     minRatio,
+		interval:     interval,
+		flagged:      make(map[string]bool),
+	}
+}
+
+// Run ищет аномальные объемы до отмены контекста
+func (v *VolumeScanner) Run(ctx context.Context) {
+	ticker := time.NewTicker(v.interval)
+	defer ticker.Stop()
+
+	for {
+		v.scan(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// scan выполняет одну проверку объемов
+func (v *VolumeScanner) scan(ctx context.Context) {
+	report, err := v.stockService.GetUnusualVolume(ctx, v.universe, v.minRatio, models.MaxVolumeAnomalyLimit)
+	if err != nil {
+		log.Printf("Ошибка поиска аномальных объемов: %v", err)
+		return
+	}
+
+	if day := dayStart(report.CheckedAt); !day.Equal(v.flaggedDay) {
+		v.flagged = make(map[string]bool)
+		v.flaggedDay = day
+	}
+	for _, anomaly := range report.Anomalies {
+		if v.flagged[anomaly.Ticker] {
+			continue
+		}
+		v.flagged[anomaly.Ticker] = true
+		log.Printf("Аномальный объем %s: %d при среднем %.0f за %d сессий (в %.1f раза выше), цена %+.2f%%",
+			anomaly.Ticker, anomaly.Volume, anomaly.AverageVolume, anomaly.Sessions, anomaly.Ratio, anomaly.ChangePerc)
+	}
+}
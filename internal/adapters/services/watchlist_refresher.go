@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/services"
+)
+
+// WatchlistRefresher периодически проверяет пороги уведомлений списков наблюдения
+// и передает сработавшие уведомления получателю
+type WatchlistRefresher struct {
+	watchlistService services.WatchlistService
+	interval         time.Duration
+	notify           func(alert models.WatchlistAlert)
+}
+
+// NewWatchlistRefresher создает фоновую проверку порогов с указанным периодом
+func NewWatchlistRefresher(
+	watchlistService services.WatchlistService,
+	interval time.Duration,
+	notify func(alert models.WatchlistAlert),
+) *WatchlistRefresher {
+	return &WatchlistRefresher{
+		watchlistService: watchlistService,
+		interval:         interval,
+		notify:           notify,
+	}
+}
+
+// Run выполняет проверки до отмены контекста
+func (w *WatchlistRefresher) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.refresh(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// refresh выполняет одну проверку порогов
+func (w *WatchlistRefresher) refresh(ctx context.Context) {
+	alerts, err := w.watchlistService.CheckThresholds(ctx)
+	if err != nil {
+		log.Printf("Ошибка проверки порогов списков наблюдения: %v", err)
+	}
+
+	for _, alert := range alerts {
+		log.Printf("Сработал порог %s (%s): %+.2f%% при пороге ±%.2f%%",
+			alert.Ticker, alert.Watchlist, alert.ChangePerc, alert.ThresholdPerc)
+		if w.notify != nil {
+			w.notify(alert)
+		}
+	}
+}
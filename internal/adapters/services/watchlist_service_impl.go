@@ -0,0 +1,307 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/config"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/repositories"
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/ports/services"
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/workpool"
+)
+
+// WatchlistServiceImpl реализация интерфейса WatchlistService
+type WatchlistServiceImpl struct {
+	watchlistRepo    repositories.WatchlistRepository
+	stockRepo        repositories.StockRepository
+	defaultThreshold float64
+	pool             *workpool.Pool // Ограничивает параллельные запросы котировок списка
+}
+
+// NewWatchlistService создает новый экземпляр сервиса списков наблюдения
+func NewWatchlistService(
+	watchlistRepo repositories.WatchlistRepository,
+	stockRepo repositories.StockRepository,
+	cfg config.WatchlistConfig,
+	pool *workpool.Pool,
+) services.WatchlistService {
+	return &WatchlistServiceImpl{
+		watchlistRepo:    watchlistRepo,
+		stockRepo:        stockRepo,
+		defaultThreshold: cfg.DefaultThresholdPerc,
+		pool:             pool,
+	}
+}
+
+// GetWatchlist возвращает бумаги списка наблюдения с текущими котировками
+func (s *WatchlistServiceImpl) GetWatchlist(ctx context.Context, watchlist string) ([]models.WatchlistItem, error) {
+	entries, err := s.watchlistRepo.GetEntries(ctx, watchlistName(watchlist))
+	if err != nil {
+		return nil, err
+	}
+
+	// Котировки бумаг списка запрашиваются параллельно
+	items := make([]models.WatchlistItem, len(entries))
+	s.pool.Run(ctx, len(entries), func(ctx context.Context, i int) error {
+		entry := entries[i]
+		items[i] = models.WatchlistItem{
+			WatchlistEntry:         entry,
+			EffectiveThresholdPerc: s.threshold(entry),
+		}
+		if stock, err := s.stockRepo.GetStock(ctx, entry.Ticker); err == nil {
+			items[i].Price = stock.Price
+			items[i].ChangePerc = stock.ChangePerc
+		} else {
+			log.Printf("Не удалось получить котировку %s: %v", entry.Ticker, err)
+		}
+		return nil
+	})
+
+	return items, nil
+}
+
+// AddToWatchlist добавляет бумагу в список наблюдения или меняет ее порог уведомления.
+// Без порога (nil) бумага, уже стоящая в списке, сохраняет свой порог, а новая получает порог по умолчанию.
+// При dryRun изменение только рассчитывается и не сохраняется
+func (s *WatchlistServiceImpl) AddToWatchlist(ctx context.Context, watchlist, ticker string, thresholdPerc *float64, dryRun bool) (*models.WatchlistChange, error) {
+	if ticker == "" {
+		return nil, fmt.Errorf("тикер не может быть пустым")
+	}
+	if thresholdPerc != nil && *thresholdPerc < 0 {
+		return nil, fmt.Errorf("порог не может быть отрицательным: изменение проверяется по модулю")
+	}
+	watchlist = watchlistName(watchlist)
+	ticker = strings.ToUpper(ticker)
+
+	before, err := s.watchlistRepo.GetEntry(ctx, watchlist, ticker)
+	if err != nil {
+		return nil, err
+	}
+
+	var entry models.WatchlistEntry
+	if before != nil {
+		entry = *before
+	} else {
+		stock, err := s.stockRepo.GetStock(ctx, ticker)
+		if err != nil {
+			return nil, fmt.Errorf("не удалось получить котировку %s: %w", ticker, err)
+		}
+		entry = models.WatchlistEntry{
+			Watchlist:  watchlist,
+			Ticker:     ticker,
+			AddedAt:    time.Now(),
+			AddedPrice: stock.Price,
+		}
+	}
+	if thresholdPerc != nil {
+		entry.ThresholdPerc = *thresholdPerc
+	}
+
+	change := &models.WatchlistChange{Before: before, After: &entry, DryRun: dryRun}
+	if dryRun {
+		return change, nil
+	}
+
+	if err := s.watchlistRepo.SaveEntry(ctx, &entry); err != nil {
+		return nil, err
+	}
+
+	return change, nil
+}
+
+// RemoveFromWatchlist удаляет бумагу из списка наблюдения.
+// При dryRun изменение только рассчитывается и не сохраняется
+func (s *WatchlistServiceImpl) RemoveFromWatchlist(ctx context.Context, watchlist, ticker string, dryRun bool) (*models.WatchlistChange, error) {
+	if ticker == "" {
+		return nil, fmt.Errorf("тикер не может быть пустым")
+	}
+	watchlist = watchlistName(watchlist)
+	ticker = strings.ToUpper(ticker)
+
+	before, err := s.watchlistRepo.GetEntry(ctx, watchlist, ticker)
+	if err != nil {
+		return nil, err
+	}
+	if before == nil {
+		return nil, fmt.Errorf("в списке %s нет бумаги %s", watchlist, ticker)
+	}
+
+	change := &models.WatchlistChange{Before: before, DryRun: dryRun}
+	if dryRun {
+		return change, nil
+	}
+
+	if err := s.watchlistRepo.DeleteEntry(ctx, watchlist, ticker); err != nil {
+		return nil, err
+	}
+
+	return change, nil
+}
+
+// CheckThresholds проверяет пороги всех списков наблюдения. Изменение цены за день
+// сравнивается по модулю с порогом бумаги; по каждой бумаге уведомление отправляется не чаще раза в день
+func (s *WatchlistServiceImpl) CheckThresholds(ctx context.Context) ([]models.WatchlistAlert, error) {
+	entries, err := s.watchlistRepo.GetEntries(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var alerts []models.WatchlistAlert
+	for _, entry := range entries {
+		if !entry.LastAlertAt.IsZero() && dayStart(entry.LastAlertAt.In(now.Location())).Equal(dayStart(now)) {
+			continue
+		}
+
+		stock, err := s.stockRepo.GetStock(ctx, entry.Ticker)
+		if err != nil {
+			log.Printf("Не удалось получить котировку %s для проверки порога: %v", entry.Ticker, err)
+			continue
+		}
+
+		threshold := s.threshold(entry)
+		if math.Abs(stock.ChangePerc) < threshold {
+			continue
+		}
+
+		alert := models.WatchlistAlert{
+			Watchlist:     entry.Watchlist,
+			Ticker:        entry.Ticker,
+			Price:         stock.Price,
+			ChangePerc:    stock.ChangePerc,
+			ThresholdPerc: threshold,
+			TriggeredAt:   now,
+		}
+		// Ошибка по одной бумаге не прерывает проверку остальных
+		if err := s.watchlistRepo.SaveAlert(ctx, &alert); err != nil {
+			log.Printf("Не удалось сохранить уведомление по %s: %v", entry.Ticker, err)
+			continue
+		}
+
+		entry.LastAlertAt = now
+		if err := s.watchlistRepo.SaveEntry(ctx, &entry); err != nil {
+			log.Printf("Не удалось отметить уведомление по %s в списке %s: %v", entry.Ticker, entry.Watchlist, err)
+		}
+
+		alerts = append(alerts, alert)
+	}
+
+	return alerts, nil
+}
+
+// GetAlerts возвращает уведомления списка наблюдения начиная с since
+func (s *WatchlistServiceImpl) GetAlerts(ctx context.Context, watchlist string, since time.Time) ([]models.WatchlistAlert, error) {
+	return s.watchlistRepo.GetAlerts(ctx, watchlistName(watchlist), since)
+}
+
+// GetPerformance рассчитывает доходность списка наблюдения за период по архивным ценам закрытия
+func (s *WatchlistServiceImpl) GetPerformance(ctx context.Context, watchlist, period string) (*models.WatchlistPerformance, error) {
+	watchlist = watchlistName(watchlist)
+
+	end := dayStart(time.Now())
+	start, err := periodStart(period, end)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.watchlistRepo.GetEntries(ctx, watchlist)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("список наблюдения %s пуст", watchlist)
+	}
+
+	result := &models.WatchlistPerformance{
+		Watchlist: watchlist,
+		Period:    period,
+		Start:     start,
+		End:       end,
+		Benchmark: indexTicker,
+	}
+
+	benchmark, benchmarkOK := windowMove(ctx, s.stockRepo, indexTicker, start, end)
+	result.BenchmarkReturnPerc = benchmark.returnPerc
+	result.BenchmarkNoData = !benchmarkOK
+
+	var basket float64
+	for _, entry := range entries {
+		performance := models.TickerPerformance{Ticker: entry.Ticker}
+
+		move, ok := windowMove(ctx, s.stockRepo, entry.Ticker, start, end)
+		if !ok {
+			performance.NoData = true
+			result.Tickers = append(result.Tickers, performance)
+			continue
+		}
+
+		performance.StartPrice = move.startPrice
+		performance.EndPrice = move.endPrice
+		performance.ReturnPerc = move.returnPerc
+		performance.MaxDrawdownPerc = move.maxDrawdownPerc
+		if benchmarkOK {
+			performance.ExcessPerc = move.returnPerc - benchmark.returnPerc
+		}
+
+		basket += move.returnPerc
+		result.BasketSize++
+		result.Tickers = append(result.Tickers, performance)
+	}
+	if result.BasketSize > 0 {
+		result.BasketReturnPerc = basket / float64(result.BasketSize)
+	}
+
+	// Бумаги без истории в конце, остальные — от лучших к худшим
+	sort.SliceStable(result.Tickers, func(i, j int) bool {
+		a, b := result.Tickers[i], result.Tickers[j]
+		if a.NoData != b.NoData {
+			return !a.NoData
+		}
+		return a.ReturnPerc > b.ReturnPerc
+	})
+
+	return result, nil
+}
+
+// periodStart возвращает начало периода отчета, заканчивающегося в end
+func periodStart(period string, end time.Time) (time.Time, error) {
+	switch strings.ToLower(period) {
+	case "1w":
+		return end.AddDate(0, 0, -7), nil
+	case "1m":
+		return end.AddDate(0, -1, 0), nil
+	case "3m":
+		return end.AddDate(0, -3, 0), nil
+	case "6m":
+		return end.AddDate(0, -6, 0), nil
+	case "ytd":
+		return time.Date(end.Year(), time.January, 1, 0, 0, 0, 0, end.Location()), nil
+	case "1y":
+		return end.AddDate(-1, 0, 0), nil
+	default:
+		return time.Time{}, fmt.Errorf("неизвестный период %s, допустимые значения: %s",
+			period, strings.Join(models.PerformancePeriods, ", "))
+	}
+}
+
+// threshold возвращает действующий порог бумаги с учетом значения по умолчанию
+func (s *WatchlistServiceImpl) threshold(entry models.WatchlistEntry) float64 {
+	if entry.ThresholdPerc > 0 {
+		return entry.ThresholdPerc
+	}
+	return s.defaultThreshold
+}
+
+// watchlistName возвращает имя списка наблюдения по умолчанию, если имя не указано
+func watchlistName(name string) string {
+	if name == "" {
+		return models.DefaultWatchlist
+	}
+	return name
+}
@@ -1,48 +1,171 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/spf13/viper"
 )
 
+// Поддерживаемые драйверы хранилища данных
+const (
+	DriverMongo    = "mongo"
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+)
+
+// Транспорты MCP
+const (
+	TransportStdio = "stdio"
+	TransportSSE   = "sse"
+)
+
+// Способы составления резюме новостей
+const (
+	SummarizerTextRank = "textrank"
+	SummarizerLLM      = "llm"
+	SummarizerOff      = "off"
+)
+
 // Config хранит все конфигурационные параметры приложения
 type Config struct {
-	Server      ServerConfig
-	Database    DatabaseConfig
-	Cache       CacheConfig
-	MOEX        MOEXConfig
-	NewsAPI     NewsAPIConfig
-	APIKeys     APIKeysConfig
-	LogLevel    string
-	Environment string
+	Server        ServerConfig
+	Database      DatabaseConfig
+	Cache         CacheConfig
+	MOEX          MOEXConfig
+	NewsAPI       NewsAPIConfig
+	Telegram      TelegramConfig
+	APIKeys       APIKeysConfig
+	Attribution   AttributionConfig
+	Templates     TemplatesConfig
+	Enrichment    EnrichmentConfig
+	Summarizer    SummarizerConfig
+	Articles      ArticlesConfig
+	Universes     map[string]UniverseConfig
+	Watchlist     WatchlistConfig
+	Subscriptions QuoteSubscriptionsConfig
+	RawArchive    RawArchiveConfig
+	Export        ExportConfig
+	Listings      ListingsConfig
+	Securities    SecuritiesConfig
+	Funds         FundsConfig
+	Broker        BrokerConfig
+	Events        EventsConfig
+	Targets       TargetsConfig
+	Margin        MarginConfig
+	Volume        VolumeConfig
+	Gaps          GapsConfig
+	Commodities   CommoditiesConfig
+	Crypto        CryptoConfig
+	OpenFIGI      OpenFIGIConfig
+	CBR           CBRConfig
+	Macro         MacroConfig
+	Indexes       IndexesConfig
+	Exchanges     ExchangesConfig
+	Yahoo         YahooConfig
+	Features      FeaturesConfig
+	Auth          AuthConfig
+	RateLimit     RateLimitConfig
+	// TickerAliases дополнительные названия компаний по тикерам для поиска упоминаний бумаг в новостях
+	TickerAliases map[string][]string
+	LogLevel      string
+	Environment   string
 }
 
 // ServerConfig конфигурация сервера
 type ServerConfig struct {
-	Port           int
-	Host           string
+	// Transport транспорт MCP: stdio (по умолчанию) или sse — HTTP-сервер на Host:Port
+	// с конечными точками /sse и /message, доступный только клиентам с ключами из auth.keys
+	Transport string
+	// BaseURL внешний адрес сервера для ссылок на /message, если он отличается от http://Host:Port (прокси)
+	BaseURL string
+	Port    int
+	Host    string
+	// TimeoutSeconds ограничение времени выполнения инструмента по умолчанию
 	TimeoutSeconds int
+	// ToolTimeouts ограничения для отдельных инструментов по имени; 0 — без ограничения
+	ToolTimeouts map[string]time.Duration
+	// MaxResultChars ограничение длины результата инструмента в символах, чтобы он поместился в контекст модели;
+	// ToolMaxChars — для отдельных инструментов по имени. 0 — без ограничения
+	MaxResultChars int
+	ToolMaxChars   map[string]int
+	// FetchConcurrency число одновременных запросов котировок и новостей при пакетных запросах
+	// (списки наблюдения, портфели, универсумы); лимит общий для всех репозиториев
+	FetchConcurrency int
+	// Name, Version и Instructions передаются клиенту при инициализации MCP-сессии
+	Name         string
+	Version      string
+	Instructions string
+	// Debug добавляет в метаданные результатов разбивку времени: кэш, БД, внешние API, форматирование
+	Debug bool
+	// Language язык описаний инструментов и результатов по умолчанию: ru или en.
+	// Клиент может выбрать язык отдельного вызова аргументом lang
+	Language string
+	// AdminTools включает инструменты администрирования (invalidate_cache)
+	AdminTools bool
+	// MonitoringAddr адрес HTTP-сервера мониторинга, например :9090: метрики Prometheus (/metrics),
+	// проверки живости (/healthz) и готовности (/readyz); пусто — не запускать
+	MonitoringAddr string
 }
 
 // DatabaseConfig конфигурация базы данных
 type DatabaseConfig struct {
+	Driver     string // mongo, postgres или sqlite
 	URI        string
+	Path       string // Путь к файлу базы данных для драйвера sqlite
 	Database   string
 	Collection string
 	Username   string
 	Password   string
 	Timeout    time.Duration
+	// Сроки хранения котировок и новостей в MongoDB (TTL-индексы); 0 — хранить бессрочно
+	QuotesRetention time.Duration
+	NewsRetention   time.Duration
 }
 
 // CacheConfig конфигурация кэша
 type CacheConfig struct {
-	RedisURI   string
-	RedisDB    int
+	RedisURI string
+	RedisDB  int
+	// RedisMode топология Redis: standalone (по умолчанию), sentinel или cluster.
+	// RedisAddrs адреса Sentinel или узлов кластера; для standalone достаточно RedisURI
+	RedisMode             string
+	RedisAddrs            []string
+	RedisMasterName       string
+	RedisUsername         string
+	RedisPassword         string
+	RedisSentinelPassword string
+	RedisTLS              bool
+	RedisTLSSkipVerify    bool
+	// Параметры пула соединений и таймауты; 0 — значения go-redis по умолчанию
+	RedisPoolSize     int
+	RedisMinIdleConns int
+	RedisDialTimeout  time.Duration
+	RedisReadTimeout  time.Duration
+	RedisWriteTimeout time.Duration
+	// L1Size и L1TTL ограничивают кэш в памяти процесса перед Redis: число ключей и срок их хранения
+	L1Size int
+	L1TTL  time.Duration
+	// Codec формат значений в Redis: json, msgpack или gob; Compress сжимает крупные значения gzip.
+	// Значения, записанные в другом формате, по-прежнему читаются
+	Codec    string
+	Compress bool
+	// Namespace префикс всех ключей кэша; по умолчанию совпадает с Environment,
+	// чтобы окружения, работающие с одним Redis, не пересекались
+	Namespace  string
 	DefaultTTL time.Duration
 	StocksTTL  time.Duration
-	NewsTTL    time.Duration
+	// StaleTTL сколько после истечения StocksTTL котировки еще отдаются из кэша, пока обновляются в фоне
+	StaleTTL time.Duration
+	NewsTTL  time.Duration
+	// ProfileTTL срок, после которого профиль компании в MongoDB запрашивается у MOEX заново
+	ProfileTTL time.Duration
+	// OrderBookTTL срок кэширования стакана заявок
+	OrderBookTTL time.Duration
 }
 
 // MOEXConfig конфигурация API для работы с MOEX
@@ -51,6 +174,8 @@ type MOEXConfig struct {
 	Timeout  time.Duration
 	UseCache bool
 	APIKey   string
+	// MaintenanceProbeInterval период проверки доступности ISS во время технического обслуживания
+	MaintenanceProbeInterval time.Duration
 }
 
 // NewsAPIConfig конфигурация API для получения новостей
@@ -62,19 +187,367 @@ type NewsAPIConfig struct {
 	Sources  []string
 }
 
+// TelegramConfig конфигурация источника новостей из Telegram-каналов (Bot API).
+// Бот получает сообщения только тех каналов, в которые он добавлен администратором.
+type TelegramConfig struct {
+	BaseURL      string
+	BotToken     string
+	Channels     []string // Имена каналов без @; пустой список — источник отключен
+	PollInterval time.Duration
+	Timeout      time.Duration
+}
+
 // APIKeysConfig конфигурация API ключей
 type APIKeysConfig struct {
 	MOEXKey    string
 	NewsAPIKey string
 }
 
+// AttributionConfig строки об источниках данных, которые добавляются к результатам инструментов
+// для соблюдения условий распространения данных MOEX и новостных изданий
+type AttributionConfig struct {
+	Disabled bool
+	MOEX     string
+	News     string
+	Crypto   string
+	Yahoo    string
+	CBR      string
+}
+
+// TemplatesConfig настройки шаблонов результатов инструментов акций и новостей и шаблонов MCP (prompts)
+type TemplatesConfig struct {
+	// Dir каталог с файлами *.tmpl (text/template), которые переопределяют встроенные шаблоны по имени;
+	// пусто — используются только встроенные шаблоны
+	Dir string
+	// PromptsDir каталог с шаблонами MCP в файлах *.yaml, *.yml и *.md; шаблон с именем встроенного
+	// заменяет его. Пусто — только встроенные шаблоны
+	PromptsDir string
+}
+
+// EnrichmentConfig настройки обогащения новостей с помощью модели MCP-клиента (sampling)
+type EnrichmentConfig struct {
+	Summarize          bool   // Краткое резюме длинных статей
+	SummarizeMinLength int    // Длина текста, начиная с которой статья считается длинной
+	Classify           bool   // Классификация новостей без тегов и тикеров
+	TranslateTo        string // Язык перевода заголовков (например, "en"); пусто — без перевода
+	MaxItems           int    // Максимум новостей, обогащаемых за один вызов инструмента
+	MaxTokens          int
+	Timeout            time.Duration
+	CacheTTL           time.Duration
+}
+
+// SummarizerConfig настройки резюме длинных статей, которые показываются при подробности новостей summary
+type SummarizerConfig struct {
+	// Method textrank — ключевые предложения статьи, выбранные при загрузке новости и сохраненные вместе с ней;
+	// llm — пересказ моделью клиента при выдаче (включает enrichment.summarize); off — без резюме
+	Method    string
+	Sentences int // Число предложений резюме textrank
+	MinLength int // Длина текста, начиная с которой статья резюмируется методом textrank
+}
+
+// ArticlesConfig настройки загрузки полного текста статей со страниц новостей (get_news_fulltext)
+type ArticlesConfig struct {
+	UserAgent string // Агент, которым представляется сервер; по нему же выбираются правила robots.txt
+	Timeout   time.Duration
+	MaxBytes  int           // Предел размера загружаемой страницы
+	CacheTTL  time.Duration // Срок хранения извлеченного текста и robots.txt
+}
+
+// WatchlistConfig настройки списков наблюдения и уведомлений по ним
+type WatchlistConfig struct {
+	DefaultThresholdPerc float64       // Порог изменения цены за день для бумаг без собственного порога
+	RefreshInterval      time.Duration // Период проверки порогов; 0 — проверка отключена
+}
+
+// QuoteSubscriptionsConfig настройки подписок на котировки. MOEX ISS не отдает котировки потоком без платного
+// доступа, поэтому котировки подписанных бумаг опрашиваются с периодом PollInterval, а об изменениях цены
+// подписчики узнают из уведомлений MCP
+type QuoteSubscriptionsConfig struct {
+	PollInterval         time.Duration // Период опроса котировок подписанных бумаг
+	MaxTickers           int           // Наибольшее число бумаг в подписках одной сессии
+	DefaultMinChangePerc float64       // Изменение цены с прошлого уведомления, о котором сообщается, если подписчик не задал свое
+}
+
+// RawArchiveConfig настройки архива необработанных ответов MOEX и NewsAPI. Архив позволяет
+// повторно разобрать ответы после исправления парсеров, не обращаясь к API с лимитами запросов.
+type RawArchiveConfig struct {
+	Enabled   bool
+	Dir       string        // Каталог архива
+	Retention time.Duration // Срок хранения ответов
+}
+
+// ExportConfig настройки выгрузки данных инструментом export_data. Небольшие выгрузки возвращаются
+// в ответе, крупные сохраняются в каталог Dir или в бакет S3, и клиент получает путь или ссылку
+type ExportConfig struct {
+	InlineMaxBytes int    // Наибольший размер выгрузки, возвращаемой в ответе
+	Dir            string // Каталог выгрузок; пусто — выгрузки в каталог отключены
+	// BaseURL адрес, по которому каталог Dir раздается клиентам; пусто — в ответе указывается путь к файлу
+	BaseURL string
+	S3      ExportS3Config
+}
+
+// ExportS3Config бакет S3-совместимого хранилища для выгрузок; если задан Bucket, он используется вместо каталога
+type ExportS3Config struct {
+	Endpoint        string // Адрес хранилища, например https://s3.eu-central-1.amazonaws.com
+	Region          string
+	Bucket          string
+	Prefix          string // Префикс ключей объектов
+	AccessKeyID     string
+	SecretAccessKey string
+	PathStyle       bool          // Адресация бакета путем вместо поддомена (MinIO)
+	URLExpiry       time.Duration // Срок действия ссылки на скачивание, не больше 7 дней
+}
+
+// ListingsConfig настройки календаря размещений. Новые листинги определяются сверкой списка бумаг MOEX,
+// объявленные IPO и SPO берутся из JSON-файла, который ведет оператор сервера
+type ListingsConfig struct {
+	FeedPath        string        // Файл календаря объявленных размещений; пусто — только сверка со списком бумаг
+	RefreshInterval time.Duration // Период сверки
+}
+
+// SecuritiesConfig настройки справочника бумаг рынка акций MOEX, по которому ищут search_stocks и lookup_security.
+// Справочник сохраняется в файл или коллекцию securities MongoDB, чтобы поиск работал и при недоступности ISS
+type SecuritiesConfig struct {
+	Path            string        // Файл справочника, если база данных не MongoDB
+	RefreshInterval time.Duration // Наибольший возраст справочника, после которого он загружается при обращении
+	SyncAt          string        // Время ежедневной загрузки справочника по Москве, ЧЧ:ММ
+}
+
+// FundsConfig сведения о биржевых и паевых фондах, которых нет в ISS
+type FundsConfig struct {
+	// Metadata сведения о фондах по тикерам паев; заданные поля заменяют встроенные сведения
+	Metadata map[string]FundConfig
+}
+
+// FundConfig сведения об одном фонде
+type FundConfig struct {
+	Manager       string  // Управляющая компания
+	ManagementFee float64 // Комиссия управляющего, % годовых
+	Index         string  // Индекс, который повторяет фонд
+	INAVCode      string  // Код индикативной стоимости пая (iNAV) на индексном рынке MOEX
+}
+
+// BrokerConfig тариф брокера, по которому оцениваются комиссии в estimate_order_cost и чистая стоимость портфеля.
+// Нулевой тариф означает, что комиссия не учитывается
+type BrokerConfig struct {
+	CommissionPerc float64 // Комиссия за сделку, % от суммы
+	MinCommission  float64 // Минимальная комиссия за сделку, ₽
+}
+
+// EventsConfig настройки календаря корпоративных событий. Даты закрытия реестра под дивиденды
+// загружаются из MOEX по бумагам универсумов, отчетность, собрания и выкупы — из JSON-файла оператора
+type EventsConfig struct {
+	FeedPath        string        // Файл календаря событий; пусто — только дивиденды из MOEX
+	RefreshInterval time.Duration // Период загрузки событий
+}
+
+// TargetsConfig настройки целевых цен аналитиков. Пользователь задает цели через set_price_target,
+// а прогнозы брокеров дополнительно загружаются из JSON-файла оператора
+type TargetsConfig struct {
+	FeedPath        string        // Файл целевых цен аналитиков; пусто — только цели пользователя
+	RefreshInterval time.Duration // Период загрузки целей из файла
+}
+
+// MarginConfig настройки маржинальных списков брокеров: какие бумаги доступны для покупки с плечом
+// и продажи без покрытия. Списки берутся из JSON-файла оператора и хранятся в памяти
+type MarginConfig struct {
+	FeedPath        string        // Файл маржинальных списков; пусто — модуль отключен
+	RefreshInterval time.Duration // Период перечитывания файла
+}
+
+// VolumeConfig настройки поиска аномальных объемов торгов: объем за день сравнивается
+// со средним за 30 предыдущих сессий
+type VolumeConfig struct {
+	Universe     string        // Универсум фоновой проверки и дайджеста
+	MinRatio     float64       // Во сколько раз объем должен превышать средний, чтобы считаться аномальным
+	ScanInterval time.Duration // Период фоновой проверки; 0 — проверка отключена
+}
+
+// GapsConfig настройки сканера гэпов открытия: разрыв открытия сессии к закрытию предыдущей
+type GapsConfig struct {
+	Universe   string  // Универсум, по которому рассчитываются гэпы
+	MinGapPerc float64 // Порог get_gappers по умолчанию, % по модулю
+	ScanAt     string  // Время ежедневного расчета после открытия торгов по Москве, ЧЧ:ММ
+}
+
+// CommoditiesConfig настройки цен сырьевых товаров по фьючерсам срочного рынка MOEX
+type CommoditiesConfig struct {
+	// UralsDiscountUSD дисконт Urals к Brent в долларах за баррель: фьючерса на Urals на MOEX нет
+	UralsDiscountUSD float64
+}
+
+// CryptoConfig настройки необязательного модуля котировок криптовалют (CoinGecko)
+type CryptoConfig struct {
+	Enabled  bool
+	BaseURL  string
+	APIKey   string // Демо-ключ CoinGecko; без ключа действуют общие лимиты запросов
+	Timeout  time.Duration
+	CacheTTL time.Duration
+	// Coins идентификаторы монет в CoinGecko по тикерам
+	Coins map[string]string
+}
+
+// OpenFIGIConfig настройки клиента OpenFIGI, через который FIGI в аргументах инструментов сопоставляются тикерам
+type OpenFIGIConfig struct {
+	BaseURL  string
+	APIKey   string // Ключ OpenFIGI; без ключа действуют общие лимиты запросов
+	Timeout  time.Duration
+	CacheTTL time.Duration // Срок хранения сопоставлений: FIGI бумаги не меняется
+}
+
+// CBRConfig настройки клиента веб-сервиса Банка России (ключевая ставка, RUONIA, официальные курсы валют)
+type CBRConfig struct {
+	BaseURL  string
+	Timeout  time.Duration
+	CacheTTL time.Duration
+}
+
+// MacroConfig настройки загрузки макроэкономических показателей. Показатели хранятся только в MongoDB
+type MacroConfig struct {
+	// Sources включенные источники: cbr (ключевая ставка, курс доллара), moex (нефть Brent), rosstat (CSV-выгрузка)
+	Sources []string
+	// RosstatCSVPath CSV-файл с инфляцией, ВВП и безработицей из выгрузки Росстата; нужен источнику rosstat
+	RosstatCSVPath  string
+	RefreshInterval time.Duration
+}
+
+// IndexesConfig настройки загрузки состава и весов биржевых индексов. Состав хранится только в MongoDB;
+// для каждого индекса появляется универсум с именем кода в нижнем регистре (imoex, rtsi)
+type IndexesConfig struct {
+	Codes           []string // Коды индексов MOEX, например IMOEX, RTSI
+	RefreshInterval time.Duration
+}
+
+// ExchangesConfig набор бирж, котировки которых доступны по тикерам вида БИРЖА:ТИКЕР (MOEX:SBER)
+type ExchangesConfig struct {
+	// Enabled коды включенных бирж; MOEX обязательна, к ней относятся тикеры без префикса
+	Enabled []string
+}
+
+// YahooConfig настройки клиента Yahoo Finance, поставляющего котировки бирж США и Европы
+// (NASDAQ, NYSE, XETRA, EURONEXT), если они включены в exchanges
+type YahooConfig struct {
+	BaseURL    string
+	Timeout    time.Duration
+	QuoteTTL   time.Duration // Срок кэширования текущих котировок
+	CandlesTTL time.Duration // Срок кэширования свечей
+	// RequestsPerMinute ограничение частоты запросов: Yahoo Finance блокирует слишком частые обращения
+	RequestsPerMinute int
+}
+
+// Группы инструментов и шаблонов, которые можно отключить в features.disabled
+const (
+	FeatureStocks      = "stocks"
+	FeatureProfiles    = "profiles"
+	FeatureMarketData  = "market_data"
+	FeatureCommodities = "commodities"
+	FeatureCrypto      = "crypto"
+	FeatureCBR         = "cbr"
+	FeatureMacro       = "macro"
+	FeatureListings    = "listings"
+	FeatureEvents      = "events"
+	FeatureNews        = "news"
+	FeatureAnalysis    = "analysis"
+	FeaturePortfolio   = "portfolio"
+	FeatureWatchlist   = "watchlist"
+	FeatureMood        = "mood"
+	FeatureExport      = "export"
+	FeaturePreferences = "preferences"
+	FeatureDiagnostics = "diagnostics"
+	FeaturePrompts     = "prompts"
+)
+
+// Features перечисляет все группы инструментов и шаблонов
+var Features = []string{
+	FeatureStocks, FeatureProfiles, FeatureMarketData, FeatureCommodities, FeatureCrypto, FeatureCBR,
+	FeatureMacro, FeatureListings, FeatureEvents, FeatureNews, FeatureAnalysis, FeaturePortfolio,
+	FeatureWatchlist, FeatureMood, FeatureExport, FeaturePreferences, FeatureDiagnostics, FeaturePrompts,
+}
+
+// FeaturesConfig группы инструментов и шаблонов, которые сервер не предоставляет клиентам.
+// Отключенная группа не регистрируется вовсе, поэтому клиент не видит ее инструменты и шаблоны,
+// а без единого шаблона сервер не объявляет поддержку prompts
+type FeaturesConfig struct {
+	Disabled []string
+}
+
+// Enabled сообщает, включена ли группа
+func (f FeaturesConfig) Enabled(feature string) bool {
+	for _, disabled := range f.Disabled {
+		if strings.EqualFold(strings.TrimSpace(disabled), feature) {
+			return false
+		}
+	}
+	return true
+}
+
+// AuthConfig аутентификация клиентов SSE-транспорта. Ключ передается заголовком
+// Authorization: Bearer <ключ> или X-API-Key; запросы без известного ключа отклоняются
+type AuthConfig struct {
+	Keys []ClientKeyConfig
+}
+
+// ClientKeyConfig ключ доступа клиента
+type ClientKeyConfig struct {
+	Name  string   // Имя клиента в журнале
+	Key   string   // Статический API-ключ или bearer-токен
+	Tools []string // Разрешенные инструменты; пусто — все
+	// CallsPerMinute и DailyQuota переопределяют ограничения rateLimit для клиента; 0 — как в rateLimit
+	CallsPerMinute float64
+	DailyQuota     int
+}
+
+// RateLimitConfig ограничения вызовов инструментов одним клиентом (ключом SSE-транспорта или сессией stdio),
+// защищающие внешние API от зациклившейся модели; 0 — без ограничения
+type RateLimitConfig struct {
+	CallsPerMinute float64
+	Burst          int // Сколько вызовов можно сделать подряд; по умолчанию — CallsPerMinute
+	DailyQuota     int // Вызовов в сутки по московскому времени
+}
+
+// UniverseConfig именованный торговый универсум — набор тикеров, которым ограничиваются
+// скринеры и рейтинги. Универсум full зарезервирован и означает весь рынок.
+type UniverseConfig struct {
+	Description string
+	Tickers     []string
+}
+
+// DefaultUniverses возвращает универсумы, используемые, если в конфигурации они не заданы
+func DefaultUniverses() map[string]UniverseConfig {
+	return map[string]UniverseConfig{
+		"blue_chips": {
+			Description: "Голубые фишки — наиболее ликвидные акции MOEX",
+			Tickers: []string{
+				"SBER", "GAZP", "LKOH", "GMKN", "NVTK", "ROSN", "TATN", "PLZL",
+				"SNGS", "YDEX", "CHMF", "NLMK", "MGNT", "ALRS", "MTSS",
+			},
+		},
+		"second_tier": {
+			Description: "Второй эшелон — ликвидные акции вне индекса голубых фишек",
+			Tickers: []string{
+				"AFKS", "AFLT", "PIKK", "RUAL", "MOEX", "IRAO", "HYDR", "FEES",
+				"RTKM", "PHOR", "MAGN", "TRNFP", "SMLT", "VTBR", "CBOM", "POSI",
+			},
+		},
+	}
+}
+
 // LoadConfig загружает конфигурацию из файла или переменных окружения
 func LoadConfig(configPath string) (*Config, error) {
-	viper.SetConfigFile(configPath)
-	viper.AutomaticEnv()
+	if err := setupEnv(); err != nil {
+		return nil, fmt.Errorf("ошибка чтения переменных окружения: %w", err)
+	}
 
-	if err := viper.ReadInConfig(); err != nil {
-		return nil, fmt.Errorf("ошибка чтения конфигурации: %w", err)
+	// Файл конфигурации необязателен: в контейнере все параметры можно передать переменными окружения
+	if configPath != "" {
+		if _, err := os.Stat(configPath); err == nil {
+			viper.SetConfigFile(configPath)
+			if err := viper.ReadInConfig(); err != nil {
+				return nil, fmt.Errorf("ошибка чтения конфигурации: %w", err)
+			}
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("ошибка чтения конфигурации: %w", err)
+		}
 	}
 
 	var config Config
@@ -94,6 +567,10 @@ func setDefaults(config *Config) {
 		config.Server.Port = 8080
 	}
 
+	if config.Server.Transport == "" {
+		config.Server.Transport = TransportStdio
+	}
+
 	if config.Server.Host == "" {
 		config.Server.Host = "localhost"
 	}
@@ -102,27 +579,351 @@ func setDefaults(config *Config) {
 		config.Server.TimeoutSeconds = 30
 	}
 
+	if config.Server.FetchConcurrency == 0 {
+		config.Server.FetchConcurrency = 8
+	}
+
+	if config.Server.Name == "" {
+		config.Server.Name = "Stocks & News API"
+	}
+
+	if config.Server.Version == "" {
+		config.Server.Version = "1.0.0"
+	}
+
+	if config.Server.Language == "" {
+		config.Server.Language = "ru"
+	}
+
+	if config.Database.Driver == "" {
+		config.Database.Driver = DriverMongo
+	}
+
+	if config.Database.Timeout == 0 {
+		config.Database.Timeout = 5 * time.Second
+	}
+
+	if config.Database.Driver == DriverSQLite && config.Database.Path == "" {
+		config.Database.Path = "data/stocks.db"
+	}
+
 	if config.LogLevel == "" {
 		config.LogLevel = "info"
 	}
 
+	if config.Cache.Namespace == "" {
+		config.Cache.Namespace = config.Environment
+	}
+
 	if config.Cache.DefaultTTL == 0 {
 		config.Cache.DefaultTTL = 5 * time.Minute
 	}
 
+	if config.Cache.L1Size == 0 {
+		config.Cache.L1Size = 1000
+	}
+
+	if config.Cache.L1TTL == 0 {
+		config.Cache.L1TTL = 30 * time.Second
+	}
+
 	if config.Cache.StocksTTL == 0 {
 		config.Cache.StocksTTL = 15 * time.Minute
 	}
 
+	if config.Cache.StaleTTL == 0 {
+		config.Cache.StaleTTL = 10 * time.Minute
+	}
+
 	if config.Cache.NewsTTL == 0 {
 		config.Cache.NewsTTL = 30 * time.Minute
 	}
 
+	if config.Cache.ProfileTTL == 0 {
+		config.Cache.ProfileTTL = 7 * 24 * time.Hour
+	}
+
+	if config.Cache.OrderBookTTL == 0 {
+		config.Cache.OrderBookTTL = 10 * time.Second
+	}
+
+	if config.MOEX.BaseURL == "" {
+		config.MOEX.BaseURL = "https://iss.moex.com/iss"
+	}
+
+	if config.NewsAPI.BaseURL == "" {
+		config.NewsAPI.BaseURL = "https://newsapi.org/v2"
+	}
+
+	if config.Attribution.MOEX == "" {
+		config.Attribution.MOEX = "Данные: Московская Биржа, задержка 15 минут"
+	}
+
+	if config.Attribution.News == "" {
+		config.Attribution.News = "Новости: NewsAPI.org, права принадлежат изданиям-источникам"
+	}
+
+	if config.Attribution.Crypto == "" {
+		config.Attribution.Crypto = "Криптовалюты: CoinGecko"
+	}
+
+	if config.Attribution.Yahoo == "" {
+		config.Attribution.Yahoo = "Зарубежные котировки: Yahoo Finance"
+	}
+
+	if config.Attribution.CBR == "" {
+		config.Attribution.CBR = "Ставки и официальные курсы: Банк России"
+	}
+
+	if config.Enrichment.SummarizeMinLength == 0 {
+		config.Enrichment.SummarizeMinLength = 1000
+	}
+
+	if config.Summarizer.Method == "" {
+		config.Summarizer.Method = SummarizerTextRank
+	}
+
+	// Пересказ моделью клиента выполняет шаг обогащения summarize
+	if config.Summarizer.Method == SummarizerLLM {
+		config.Enrichment.Summarize = true
+	}
+
+	if config.Summarizer.Sentences == 0 {
+		config.Summarizer.Sentences = 3
+	}
+
+	if config.Summarizer.MinLength == 0 {
+		config.Summarizer.MinLength = 600
+	}
+
+	if config.Articles.UserAgent == "" {
+		config.Articles.UserAgent = "mcp-stocks-info-server/1.0 (+https://github.com/JkLondon/mcp-stocks-info-server)"
+	}
+
+	if config.Articles.Timeout == 0 {
+		config.Articles.Timeout = 15 * time.Second
+	}
+
+	if config.Articles.MaxBytes == 0 {
+		config.Articles.MaxBytes = 2 << 20
+	}
+
+	if config.Articles.CacheTTL == 0 {
+		config.Articles.CacheTTL = 7 * 24 * time.Hour
+	}
+
+	if config.Enrichment.MaxItems == 0 {
+		config.Enrichment.MaxItems = 5
+	}
+
+	if config.Enrichment.MaxTokens == 0 {
+		config.Enrichment.MaxTokens = 300
+	}
+
+	if config.Enrichment.Timeout == 0 {
+		config.Enrichment.Timeout = 60 * time.Second
+	}
+
+	if config.Enrichment.CacheTTL == 0 {
+		config.Enrichment.CacheTTL = 24 * time.Hour
+	}
+
+	if config.Watchlist.DefaultThresholdPerc == 0 {
+		config.Watchlist.DefaultThresholdPerc = 5
+	}
+
+	if config.Subscriptions.PollInterval == 0 {
+		config.Subscriptions.PollInterval = 30 * time.Second
+	}
+
+	if config.Subscriptions.MaxTickers == 0 {
+		config.Subscriptions.MaxTickers = 20
+	}
+
+	if config.Subscriptions.DefaultMinChangePerc == 0 {
+		config.Subscriptions.DefaultMinChangePerc = 0.5
+	}
+
+	if config.RawArchive.Dir == "" {
+		config.RawArchive.Dir = "data/raw"
+	}
+
+	if config.RawArchive.Retention == 0 {
+		config.RawArchive.Retention = 30 * 24 * time.Hour
+	}
+
+	if config.Export.InlineMaxBytes == 0 {
+		config.Export.InlineMaxBytes = 32 << 10
+	}
+
+	if config.Export.S3.Region == "" {
+		config.Export.S3.Region = "us-east-1"
+	}
+
+	if config.Export.S3.URLExpiry == 0 {
+		config.Export.S3.URLExpiry = 24 * time.Hour
+	}
+
+	if config.Listings.RefreshInterval == 0 {
+		config.Listings.RefreshInterval = 6 * time.Hour
+	}
+
+	if config.Securities.Path == "" {
+		config.Securities.Path = "data/securities.json"
+	}
+
+	if config.Securities.RefreshInterval == 0 {
+		config.Securities.RefreshInterval = 24 * time.Hour
+	}
+
+	if config.Securities.SyncAt == "" {
+		config.Securities.SyncAt = "03:00"
+	}
+
+	if config.Events.RefreshInterval == 0 {
+		config.Events.RefreshInterval = 12 * time.Hour
+	}
+
+	if config.Targets.RefreshInterval == 0 {
+		config.Targets.RefreshInterval = 12 * time.Hour
+	}
+
+	if config.Margin.RefreshInterval == 0 {
+		config.Margin.RefreshInterval = time.Hour
+	}
+
+	if config.Volume.Universe == "" {
+		config.Volume.Universe = "full"
+	}
+
+	if config.Volume.MinRatio == 0 {
+		config.Volume.MinRatio = 2
+	}
+
+	if config.Gaps.Universe == "" {
+		config.Gaps.Universe = "full"
+	}
+
+	if config.Gaps.MinGapPerc == 0 {
+		config.Gaps.MinGapPerc = 2
+	}
+
+	if config.Gaps.ScanAt == "" {
+		config.Gaps.ScanAt = "10:05"
+	}
+
+	if config.Commodities.UralsDiscountUSD == 0 {
+		config.Commodities.UralsDiscountUSD = 12
+	}
+
+	if config.OpenFIGI.BaseURL == "" {
+		config.OpenFIGI.BaseURL = "https://api.openfigi.com/v3"
+	}
+
+	if config.OpenFIGI.Timeout == 0 {
+		config.OpenFIGI.Timeout = 10 * time.Second
+	}
+
+	if config.OpenFIGI.CacheTTL == 0 {
+		config.OpenFIGI.CacheTTL = 30 * 24 * time.Hour
+	}
+
+	if config.Crypto.BaseURL == "" {
+		config.Crypto.BaseURL = "https://api.coingecko.com/api/v3"
+	}
+
+	if config.Crypto.Timeout == 0 {
+		config.Crypto.Timeout = 10 * time.Second
+	}
+
+	if config.Crypto.CacheTTL == 0 {
+		config.Crypto.CacheTTL = time.Minute
+	}
+
+	if len(config.Crypto.Coins) == 0 {
+		config.Crypto.Coins = map[string]string{
+			"BTC": "bitcoin",
+			"ETH": "ethereum",
+		}
+	}
+
+	if config.CBR.BaseURL == "" {
+		config.CBR.BaseURL = "https://www.cbr.ru/DailyInfoWebServ/DailyInfo.asmx"
+	}
+
+	if config.CBR.Timeout == 0 {
+		config.CBR.Timeout = 10 * time.Second
+	}
+
+	if config.CBR.CacheTTL == 0 {
+		config.CBR.CacheTTL = time.Hour
+	}
+
+	if len(config.Macro.Sources) == 0 {
+		config.Macro.Sources = []string{"cbr", "moex"}
+	}
+
+	if config.Macro.RefreshInterval == 0 {
+		config.Macro.RefreshInterval = 24 * time.Hour
+	}
+
+	if len(config.Indexes.Codes) == 0 {
+		config.Indexes.Codes = []string{"IMOEX", "RTSI"}
+	}
+
+	if config.Indexes.RefreshInterval == 0 {
+		config.Indexes.RefreshInterval = 24 * time.Hour
+	}
+
+	if len(config.Exchanges.Enabled) == 0 {
+		config.Exchanges.Enabled = []string{"MOEX"}
+	}
+
+	if config.Yahoo.BaseURL == "" {
+		config.Yahoo.BaseURL = "https://query1.finance.yahoo.com"
+	}
+
+	if config.Yahoo.Timeout == 0 {
+		config.Yahoo.Timeout = 10 * time.Second
+	}
+
+	if config.Yahoo.QuoteTTL == 0 {
+		config.Yahoo.QuoteTTL = 5 * time.Minute
+	}
+
+	if config.Yahoo.CandlesTTL == 0 {
+		config.Yahoo.CandlesTTL = time.Hour
+	}
+
+	if config.Yahoo.RequestsPerMinute == 0 {
+		config.Yahoo.RequestsPerMinute = 30
+	}
+
+	if len(config.Universes) == 0 {
+		config.Universes = DefaultUniverses()
+	}
+
 	if config.MOEX.Timeout == 0 {
 		config.MOEX.Timeout = 10 * time.Second
 	}
 
+	if config.MOEX.MaintenanceProbeInterval == 0 {
+		config.MOEX.MaintenanceProbeInterval = time.Minute
+	}
+
 	if config.NewsAPI.Timeout == 0 {
 		config.NewsAPI.Timeout = 10 * time.Second
 	}
+
+	if config.Telegram.BaseURL == "" {
+		config.Telegram.BaseURL = "https://api.telegram.org"
+	}
+
+	if config.Telegram.PollInterval == 0 {
+		config.Telegram.PollInterval = time.Minute
+	}
+
+	if config.Telegram.Timeout == 0 {
+		config.Telegram.Timeout = 10 * time.Second
+	}
 }
@@ -0,0 +1,93 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// EnvPrefix префикс переменных окружения: ключ cache.redisURI задается переменной MCP_STOCKS_CACHE_REDISURI
+const EnvPrefix = "MCP_STOCKS"
+
+// secretKeys ключи с секретами, которые можно передать файлом: переменная с суффиксом _FILE
+// (MCP_STOCKS_NEWSAPI_APIKEY_FILE=/run/secrets/newsapi_key) указывает путь к файлу со значением
+var secretKeys = []string{
+	"database.password",
+	"cache.redisPassword",
+	"cache.redisSentinelPassword",
+	"moex.apiKey",
+	"newsAPI.apiKey",
+	"telegram.botToken",
+	"apiKeys.moexKey",
+	"apiKeys.newsAPIKey",
+	"crypto.apiKey",
+	"openFIGI.apiKey",
+	"export.s3.secretAccessKey",
+}
+
+// setupEnv включает чтение переменных окружения с префиксом EnvPrefix. AutomaticEnv учитывается только
+// для ключей, уже известных viper, поэтому все поля конфигурации привязываются к переменным явно —
+// иначе без файла конфигурации вложенные ключи из окружения не попадут в Unmarshal
+func setupEnv() error {
+	viper.SetEnvPrefix(EnvPrefix)
+	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	viper.AutomaticEnv()
+
+	for _, key := range envKeys(reflect.TypeOf(Config{}), "") {
+		if err := viper.BindEnv(key); err != nil {
+			return err
+		}
+	}
+	return loadSecretFiles()
+}
+
+// envKeys возвращает ключи всех полей структуры конфигурации. Словари (universes, toolTimeouts и т.п.)
+// и списки структур (auth.keys) пропускаются: они задаются только в файле конфигурации
+func envKeys(t reflect.Type, prefix string) []string {
+	var keys []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		key := strings.ToLower(field.Name)
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		switch {
+		case field.Type.Kind() == reflect.Struct:
+			keys = append(keys, envKeys(field.Type, key)...)
+		case field.Type.Kind() == reflect.Map:
+		case field.Type.Kind() == reflect.Slice && field.Type.Elem().Kind() == reflect.Struct:
+		default:
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// loadSecretFiles подставляет секреты из файлов, заданных переменными *_FILE (Docker и Kubernetes secrets).
+// Значение самой переменной без суффикса имеет приоритет над файлом
+func loadSecretFiles() error {
+	for _, key := range secretKeys {
+		env := envName(key)
+		path := os.Getenv(env + "_FILE")
+		if path == "" || os.Getenv(env) != "" {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("ошибка чтения секрета %s: %w", env+"_FILE", err)
+		}
+		viper.Set(key, strings.TrimSpace(string(data)))
+	}
+	return nil
+}
+
+// envName возвращает имя переменной окружения для ключа конфигурации
+func envName(key string) string {
+	return EnvPrefix + "_" + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
@@ -0,0 +1,84 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	// DefaultNewsClusterTickers количество акций с новостями дня в шаблоне торговых идей по умолчанию
+	DefaultNewsClusterTickers = 10
+	// MaxNewsClusterTickers максимальное количество акций с новостями дня в шаблоне торговых идей
+	MaxNewsClusterTickers = 20
+	// MaxClusterNews максимальное количество новостей одной акции в кластере
+	MaxClusterNews = 5
+)
+
+// CandleShape описывает форму дневной свечи
+type CandleShape struct {
+	Pattern         string  `json:"pattern"`
+	BodyPerc        float64 `json:"body_perc"`         // Доля тела в дневном диапазоне, %
+	UpperShadowPerc float64 `json:"upper_shadow_perc"` // Доля верхней тени в дневном диапазоне, %
+	LowerShadowPerc float64 `json:"lower_shadow_perc"` // Доля нижней тени в дневном диапазоне, %
+	RangePerc       float64 `json:"range_perc"`        // Дневной диапазон относительно цены открытия, %
+}
+
+// PeerMove представляет изменение цены акции-аналога за день
+type PeerMove struct {
+	Ticker     string  `json:"ticker"`
+	ChangePerc float64 `json:"change_perc"`
+}
+
+// IntradayBar часовая свеча торговой сессии во внутридневном профиле движения
+type IntradayBar struct {
+	Time        time.Time `json:"time"` // Начало часа
+	Close       float64   `json:"close"`
+	ChangePerc  float64   `json:"change_perc"` // Изменение цены к закрытию предыдущего часа (первого часа — к закрытию предыдущей сессии), %
+	Volume      int64     `json:"volume"`
+	VolumeShare float64   `json:"volume_share"` // Доля объема сессии, %
+}
+
+// MoveExplanation собирает факторы, которые могли объяснить движение акции за день
+type MoveExplanation struct {
+	Ticker     string      `json:"ticker"`
+	Date       time.Time   `json:"date"`
+	Quote      StockQuote  `json:"quote"`
+	PrevClose  float64     `json:"prev_close"`
+	ChangePerc float64     `json:"change_perc"`
+	GapPerc    float64     `json:"gap_perc"`
+	Candle     CandleShape `json:"candle"`
+
+	// Объем относительно среднего за предыдущие сессии
+	AvgVolume      float64 `json:"avg_volume"`
+	VolumeRatio    float64 `json:"volume_ratio"`
+	VolumeSessions int     `json:"volume_sessions"`
+
+	// Часовой профиль сессии и час самого сильного изменения цены; пусты, если часовых свечей нет
+	Intraday []IntradayBar `json:"intraday,omitempty"`
+	MoveBar  *IntradayBar  `json:"move_bar,omitempty"`
+
+	// Новости по компании в окне между предыдущей сессией и концом дня и общие новости дня
+	TickerNews []News `json:"ticker_news"`
+	MarketNews []News `json:"market_news"`
+	// NewsBeforeMove сколько новостей по компании вышло до конца часа самого сильного движения
+	NewsBeforeMove int `json:"news_before_move"`
+
+	// Движение сектора и рынка в целом
+	Sector           string     `json:"sector,omitempty"`
+	SectorPeers      []PeerMove `json:"sector_peers,omitempty"`
+	SectorChangePerc float64    `json:"sector_change_perc"`
+	IndexTicker      string     `json:"index_ticker,omitempty"`
+	IndexChangePerc  float64    `json:"index_change_perc"`
+	HasIndex         bool       `json:"has_index"`
+
+	// Вероятные драйверы движения в порядке значимости
+	Drivers []string `json:"drivers"`
+}
+
+// NewsTickerCluster новости дня, в которых упомянута акция, вместе с ее текущей котировкой
+type NewsTickerCluster struct {
+	Ticker    string  `json:"ticker"`
+	Stock     *Stock  `json:"stock,omitempty"` // nil, если котировка недоступна
+	News      []News  `json:"news"`            // От новых к старым, не более MaxClusterNews
+	TotalNews int     `json:"total_news"`
+	Sentiment float64 `json:"sentiment"` // Средняя тональность новостей от -1 до 1
+}
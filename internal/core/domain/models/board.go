@@ -0,0 +1,112 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Основные режимы торгов MOEX
+const (
+	BoardShares        = "TQBR" // Акции, Т+
+	BoardETF           = "TQTF" // Биржевые фонды, Т+
+	BoardForeignShares = "FQBR" // Иностранные акции, Т+
+)
+
+// Рынки фондовой секции MOEX ISS
+const (
+	MarketShares        = "shares"
+	MarketForeignShares = "foreignshares"
+	MarketBonds         = "bonds"
+)
+
+// boardMarkets рынки известных режимов торгов; режимы, которых нет в списке, относятся к рынку акций
+var boardMarkets = map[string]string{
+	BoardShares:        MarketShares,
+	BoardETF:           MarketShares,
+	"TQTD":             MarketShares, // Биржевые фонды в долларах
+	"TQTE":             MarketShares, // Биржевые фонды в евро
+	"TQIF":             MarketShares, // Паевые фонды
+	"TQPI":             MarketShares, // Сектор ПИР
+	"SMAL":             MarketShares, // Неполные лоты
+	BoardForeignShares: MarketForeignShares,
+	"TQCB":             MarketBonds,
+	"TQOB":             MarketBonds,
+	"TQIR":             MarketBonds,
+}
+
+// Markets рынки, которые можно указать вместе с режимом торгов
+var Markets = []string{MarketShares, MarketForeignShares, MarketBonds}
+
+// boardPattern код режима торгов MOEX, например TQBR или TQTF
+var boardPattern = regexp.MustCompile(`^[A-Z0-9]{2,12}$`)
+
+// TradingBoard режим торгов MOEX и рынок, к которому он относится.
+// Пустое значение означает основной режим торгов бумаги
+type TradingBoard struct {
+	Board  string `json:"board,omitempty" bson:"board,omitempty"`
+	Market string `json:"market,omitempty" bson:"market,omitempty"`
+}
+
+// IsBoardCode сообщает, похожа ли строка на код режима торгов
+func IsBoardCode(board string) bool {
+	return boardPattern.MatchString(board)
+}
+
+// BoardMarket возвращает рынок режима торгов
+func BoardMarket(board string) string {
+	if market, ok := boardMarkets[strings.ToUpper(board)]; ok {
+		return market
+	}
+	return MarketShares
+}
+
+// ParseTradingBoard проверяет режим торгов и рынок из аргументов инструмента. Рынок без режима выбирает
+// основной режим бумаги на этом рынке, режим без рынка — рынок, к которому режим относится
+func ParseTradingBoard(board, market string) (TradingBoard, error) {
+	board = strings.ToUpper(strings.TrimSpace(board))
+	market = strings.ToLower(strings.TrimSpace(market))
+
+	if board != "" && !IsBoardCode(board) {
+		return TradingBoard{}, fmt.Errorf("некорректный код режима торгов %s: ожидаются латинские буквы и цифры, например TQBR", board)
+	}
+	if market != "" && !containsString(Markets, market) {
+		return TradingBoard{}, fmt.Errorf("неизвестный рынок %s, доступны: %s", market, strings.Join(Markets, ", "))
+	}
+	if board != "" && market == "" {
+		market = BoardMarket(board)
+	}
+
+	return TradingBoard{Board: board, Market: market}, nil
+}
+
+// IsZero сообщает, что режим не выбран и используется основной режим бумаги
+func (b TradingBoard) IsZero() bool {
+	return b.Board == "" && b.Market == ""
+}
+
+// MarketOrDefault возвращает рынок режима, по умолчанию — рынок акций
+func (b TradingBoard) MarketOrDefault() string {
+	if b.Market != "" {
+		return b.Market
+	}
+	return MarketShares
+}
+
+// String возвращает режим в виде market/BOARD, например shares/TQTF
+func (b TradingBoard) String() string {
+	if b.Board == "" {
+		return b.MarketOrDefault()
+	}
+	return b.MarketOrDefault() + "/" + b.Board
+}
+
+// containsString проверяет наличие строки в списке
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
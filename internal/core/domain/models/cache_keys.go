@@ -0,0 +1,60 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+// Ключи кэша данных моделей содержат версию схемы: после изменения модели значения в старом формате
+// не разбираются в новую структуру, а перестают читаться и вытесняются по истечении срока
+
+// StockCacheKey возвращает ключ кэша котировки акции
+func StockCacheKey(ticker string) string {
+	return fmt.Sprintf("stock:v%d:%s", StockSchemaVersion, ticker)
+}
+
+// BoardStockCacheKey возвращает ключ кэша котировки акции в выбранном режиме торгов;
+// для основного режима бумаги он совпадает с StockCacheKey
+func BoardStockCacheKey(ticker string, board TradingBoard) string {
+	if board.IsZero() {
+		return StockCacheKey(ticker)
+	}
+	return fmt.Sprintf("stock:v%d:%s:%s", StockSchemaVersion, ticker, board)
+}
+
+// AllStocksCacheKey возвращает ключ кэша списка всех акций
+func AllStocksCacheKey() string {
+	return fmt.Sprintf("stock:v%d:all", StockSchemaVersion)
+}
+
+// NewsCacheKey возвращает ключ кэша новости
+func NewsCacheKey(id string) string {
+	return fmt.Sprintf("news:v%d:%s", NewsSchemaVersion, id)
+}
+
+// NewsDateCacheKey возвращает ключ кэша новостей за день в формате YYYY-MM-DD
+func NewsDateCacheKey(day string) string {
+	return fmt.Sprintf("news:v%d:date:%s", NewsSchemaVersion, day)
+}
+
+// NewsKeywordCacheKey возвращает ключ кэша результатов поиска новостей по ключевому слову
+func NewsKeywordCacheKey(keyword string, filter NewsFilter) string {
+	return fmt.Sprintf("news:v%d:keyword:%s%s", NewsSchemaVersion, keyword, filter.CacheKey())
+}
+
+// NewsTickerCacheKey возвращает ключ кэша новостей по тикеру
+func NewsTickerCacheKey(ticker string) string {
+	return fmt.Sprintf("news:v%d:ticker:%s", NewsSchemaVersion, ticker)
+}
+
+// ArticleCacheKey возвращает ключ кэша текста статьи по адресу страницы
+func ArticleCacheKey(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return "article:" + hex.EncodeToString(sum[:16])
+}
+
+// RobotsCacheKey возвращает ключ кэша robots.txt сайта (схема и хост)
+func RobotsCacheKey(site string) string {
+	return "robots:" + site
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+)
+
+// KeyRateHistoryDays период, за который запрашивается история ключевой ставки, чтобы найти ее последнее изменение
+const KeyRateHistoryDays = 365
+
+// DefaultOfficialFXCurrencies валюты, официальные курсы которых возвращаются, если валюта не указана
+var DefaultOfficialFXCurrencies = []string{"USD", "EUR", "CNY"}
+
+// KeyRate значение ключевой ставки Банка России на дату
+type KeyRate struct {
+	Date time.Time `json:"date"`
+	Rate float64   `json:"rate"` // % годовых
+}
+
+// RUONIARate значение ставки RUONIA — средней ставки однодневных межбанковских кредитов
+type RUONIARate struct {
+	Date      time.Time `json:"date"`
+	Rate      float64   `json:"rate"`       // % годовых
+	VolumeBln float64   `json:"volume_bln"` // Объем сделок, млрд ₽
+}
+
+// KeyRateSummary текущая ключевая ставка, ее последнее изменение и ставка RUONIA
+type KeyRateSummary struct {
+	Current   KeyRate     `json:"current"`
+	Previous  *KeyRate    `json:"previous,omitempty"` // Ставка до последнего изменения; nil, если за период изменений не было
+	ChangedAt time.Time   `json:"changed_at"`         // Дата, с которой действует текущая ставка
+	RUONIA    *RUONIARate `json:"ruonia,omitempty"`   // Последнее значение RUONIA; nil, если недоступно
+}
+
+// OfficialFXRate официальный курс валюты, установленный Банком России
+type OfficialFXRate struct {
+	Code     string    `json:"code"` // Буквенный код валюты, например USD
+	Name     string    `json:"name"`
+	Nominal  int       `json:"nominal"`   // Количество единиц валюты, за которое установлен курс
+	Rate     float64   `json:"rate"`      // Курс за Nominal единиц, ₽
+	UnitRate float64   `json:"unit_rate"` // Курс за одну единицу, ₽
+	Date     time.Time `json:"date"`
+}
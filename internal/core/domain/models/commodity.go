@@ -0,0 +1,70 @@
+package models
+
+import (
+	"time"
+)
+
+// Коды сырьевых товаров
+const (
+	CommodityBrent  = "brent"
+	CommodityUrals  = "urals"
+	CommodityGold   = "gold"
+	CommoditySilver = "silver"
+	CommodityGas    = "gas"
+)
+
+// CommoditySpec сырьевой товар и фьючерс срочного рынка MOEX, по которому определяется его цена
+type CommoditySpec struct {
+	Code      string
+	Name      string
+	AssetCode string // Код базового актива фьючерса на срочном рынке MOEX
+	Unit      string // Единица, за которую котируется фьючерс в долларах
+}
+
+// Commodities поддерживаемые сырьевые товары. Фьючерса на Urals на MOEX нет,
+// поэтому его цена оценивается по Brent с дисконтом из конфигурации
+var Commodities = []CommoditySpec{
+	{Code: CommodityBrent, Name: "Нефть Brent", AssetCode: "BR", Unit: "баррель"},
+	{Code: CommodityUrals, Name: "Нефть Urals", AssetCode: "BR", Unit: "баррель"},
+	{Code: CommodityGold, Name: "Золото", AssetCode: "GOLD", Unit: "тройская унция"},
+	{Code: CommoditySilver, Name: "Серебро", AssetCode: "SILV", Unit: "тройская унция"},
+	{Code: CommodityGas, Name: "Природный газ", AssetCode: "NG", Unit: "MMBtu"},
+}
+
+// FindCommodity возвращает сырьевой товар по коду
+func FindCommodity(code string) (CommoditySpec, bool) {
+	for _, spec := range Commodities {
+		if spec.Code == code {
+			return spec, true
+		}
+	}
+	return CommoditySpec{}, false
+}
+
+// FuturesQuote котировка фьючерсного контракта срочного рынка MOEX
+type FuturesQuote struct {
+	SecID        string    `json:"secid"`
+	Name         string    `json:"name"`
+	AssetCode    string    `json:"asset_code"`
+	LastTradeDay time.Time `json:"last_trade_day"` // Последний день обращения; для вечных фьючерсов — нулевой
+	Price        float64   `json:"price"`          // Цена последней сделки, при ее отсутствии — расчетная цена
+	ChangePerc   float64   `json:"change_perc"`
+	OpenInterest int64     `json:"open_interest"` // Открытые позиции, контрактов
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// CommodityQuote цена сырьевого товара в долларах и рублях
+type CommodityQuote struct {
+	Code       string    `json:"code"`
+	Name       string    `json:"name"`
+	Unit       string    `json:"unit"`
+	Contract   string    `json:"contract"` // Фьючерс, по которому определена цена
+	Expiration time.Time `json:"expiration"`
+	PriceUSD   float64   `json:"price_usd"`
+	ChangePerc float64   `json:"change_perc"`
+	USDRUB     float64   `json:"usd_rub"`   // Курс пересчета; 0 — курс недоступен
+	PriceRUB   float64   `json:"price_rub"` // 0 — курс недоступен
+	Estimated  bool      `json:"estimated"` // Цена оценочная, а не биржевая
+	Note       string    `json:"note,omitempty"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
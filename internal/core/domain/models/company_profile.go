@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+)
+
+// CompanyProfile описание эмитента и его акций по данным MOEX ISS
+type CompanyProfile struct {
+	Ticker            string  `json:"ticker" bson:"ticker"`
+	Name              string  `json:"name" bson:"name"`           // Краткое название бумаги
+	FullName          string  `json:"full_name" bson:"full_name"` // Полное название эмитента
+	ISIN              string  `json:"isin" bson:"isin"`
+	Sector            string  `json:"sector" bson:"sector"`         // Сектор по отраслевым индексам MOEX; пустой, если бумага в них не входит
+	Industry          string  `json:"industry" bson:"industry"`     // Отрасль; известна не для всех бумаг
+	MarketCap         float64 `json:"market_cap" bson:"market_cap"` // Капитализация, ₽
+	SharesOutstanding int64   `json:"shares_outstanding" bson:"shares_outstanding"`
+	FreeFloatPerc     float64 `json:"free_float_perc" bson:"free_float_perc"` // 0 — данных нет
+	ListingLevel      int     `json:"listing_level" bson:"listing_level"`     // Уровень листинга: 1, 2 или 3
+
+	UpdatedAt time.Time `json:"updated_at" bson:"updated_at"`
+}
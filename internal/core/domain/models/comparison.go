@@ -0,0 +1,61 @@
+package models
+
+import (
+	"time"
+)
+
+// Ограничения на количество акций в сравнении
+const (
+	MinComparedStocks = 2
+	MaxComparedStocks = 5
+)
+
+const (
+	// CompareAnalysisMonths глубина нормированной истории в шаблоне compare_analysis, месяцев
+	CompareAnalysisMonths = 6
+	// MaxCompareAnalysisStocks максимальное количество акций в шаблоне compare_analysis
+	MaxCompareAnalysisStocks = 3
+	// NormalizedSeriesStep шаг прореживания нормированного ряда, сессий: при 5 ряд выводится понедельно
+	NormalizedSeriesStep = 5
+)
+
+// ComparedStock показатели акции для сравнения с другими
+type ComparedStock struct {
+	Stock
+	PE            float64 `json:"pe"`             // 0 — нет данных
+	DividendYield float64 `json:"dividend_yield"` // Дивидендная доходность, %; 0 — нет данных
+	Return1MPerc  float64 `json:"return_1m_perc"`
+	HasReturn1M   bool    `json:"has_return_1m"` // Истории котировок хватает для расчета доходности за месяц
+	Return3MPerc  float64 `json:"return_3m_perc"`
+	HasReturn3M   bool    `json:"has_return_3m"` // Истории котировок хватает для расчета доходности за 3 месяца
+}
+
+// StockComparison сравнение нескольких акций, в порядке запрошенных тикеров
+type StockComparison struct {
+	Stocks    []ComparedStock `json:"stocks"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+// NormalizedPoint значения нормированного ряда на дату сессии в порядке NormalizedReturns.Tickers
+type NormalizedPoint struct {
+	Date   time.Time `json:"date"`
+	Values []float64 `json:"values"`
+}
+
+// TickerPeriodStats доходность и максимальная просадка акции за период нормированного ряда
+type TickerPeriodStats struct {
+	Ticker          string  `json:"ticker"`
+	ReturnPerc      float64 `json:"return_perc"`
+	MaxDrawdownPerc float64 `json:"max_drawdown_perc"`
+}
+
+// NormalizedReturns цены закрытия нескольких акций, приведенные к 100 на первую общую сессию периода.
+// Ряд строится только по сессиям, в которые торговались все акции, и прореживается с шагом NormalizedSeriesStep
+type NormalizedReturns struct {
+	Tickers []string            `json:"tickers"` // Акции, вошедшие в ряд
+	Skipped []string            `json:"skipped"` // Акции без истории котировок за период
+	From    time.Time           `json:"from"`
+	To      time.Time           `json:"to"`
+	Points  []NormalizedPoint   `json:"points"`
+	Stats   []TickerPeriodStats `json:"stats"` // По всем общим сессиям, в порядке Tickers
+}
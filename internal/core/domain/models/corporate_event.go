@@ -0,0 +1,77 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	// DefaultTickerEventsHorizonDays горизонт событий эмитента по умолчанию, дней
+	DefaultTickerEventsHorizonDays = 90
+	// DefaultEventsCalendarDays горизонт календаря событий всех эмитентов по умолчанию, дней
+	DefaultEventsCalendarDays = 30
+	// MaxEventsRangeDays максимальная длина периода календаря корпоративных событий, дней
+	MaxEventsRangeDays = 366
+)
+
+// Типы корпоративных событий
+const (
+	// EventTypeEarnings публикация финансовой отчетности
+	EventTypeEarnings = "earnings"
+	// EventTypeAGM годовое или внеочередное собрание акционеров
+	EventTypeAGM = "agm"
+	// EventTypeBuyback программа обратного выкупа акций
+	EventTypeBuyback = "buyback"
+	// EventTypeDividend дата закрытия реестра под дивиденды
+	EventTypeDividend = "dividend"
+)
+
+// CorporateEventTypes поддерживаемые типы корпоративных событий
+var CorporateEventTypes = []string{EventTypeEarnings, EventTypeAGM, EventTypeBuyback, EventTypeDividend}
+
+// Источники сведений о корпоративных событиях
+const (
+	// EventSourceMOEX событие получено из MOEX ISS
+	EventSourceMOEX = "moex"
+	// EventSourceFeed событие описано в файле календаря из конфигурации
+	EventSourceFeed = "feed"
+)
+
+// CorporateEvent корпоративное событие эмитента, способное повлиять на цену акции.
+// Событие однозначно определяется тикером, типом, источником и идентификатором в источнике
+type CorporateEvent struct {
+	Ticker   string    `json:"ticker" bson:"ticker"`
+	Type     string    `json:"type" bson:"type"`
+	Date     time.Time `json:"date" bson:"date"`                             // День события; для программ выкупа — начало программы
+	EndDate  time.Time `json:"end_date,omitempty" bson:"end_date,omitempty"` // Окончание программы выкупа
+	Title    string    `json:"title" bson:"title"`
+	Details  string    `json:"details,omitempty" bson:"details,omitempty"`
+	Value    float64   `json:"value,omitempty" bson:"value,omitempty"` // Размер дивиденда на акцию
+	Currency string    `json:"currency,omitempty" bson:"currency,omitempty"`
+	Source   string    `json:"source" bson:"source"`
+	// SourceID идентификатор события в источнике: по нему перенесенное на другую дату событие
+	// заменяет прежнюю запись. Если источник не задает идентификатор, им служит дата события
+	SourceID string `json:"source_id" bson:"source_id"`
+
+	UpdatedAt time.Time `json:"updated_at" bson:"updated_at"`
+}
+
+// EventSourceID идентификатор события по дате для источников, которые не задают свой
+func EventSourceID(date time.Time) string {
+	return date.Format("2006-01-02")
+}
+
+// CorporateEventsFilter условия выборки корпоративных событий
+type CorporateEventsFilter struct {
+	Ticker string   // Пусто — события всех эмитентов
+	Types  []string // Пусто — события всех типов
+	From   time.Time
+	To     time.Time
+}
+
+// CorporateEventsIngestResult итог загрузки корпоративных событий из источников
+type CorporateEventsIngestResult struct {
+	Fetched int      `json:"fetched"`
+	Saved   int      `json:"saved"`
+	Removed int      `json:"removed,omitempty"` // Удалено событий, пропавших из источников-снимков
+	Failed  []string `json:"failed,omitempty"`  // Источники, загрузка из которых не удалась
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	// DefaultCorrelationWindowDays окно расчета корреляций и беты по умолчанию, дней
+	DefaultCorrelationWindowDays = 90
+	// MaxCorrelationWindowDays максимальное окно расчета корреляций и беты, дней
+	MaxCorrelationWindowDays = 730
+	// MaxCorrelationTickers максимальное количество акций в расчете корреляций
+	MaxCorrelationTickers = 10
+)
+
+// TickerBeta чувствительность акции к индексу за окно расчета
+type TickerBeta struct {
+	Ticker           string  `json:"ticker"`
+	Beta             float64 `json:"beta"`              // Наклон регрессии дневных доходностей акции на доходности индекса
+	IndexCorrelation float64 `json:"index_correlation"` // Корреляция дневных доходностей с индексом
+	VolatilityPerc   float64 `json:"volatility_perc"`   // Стандартное отклонение дневной доходности, %
+	Observations     int     `json:"observations"`      // Количество общих с индексом сессий; 0, если бета не определена
+}
+
+// PairCorrelation корреляция дневных доходностей двух акций. Пары с короткой общей историей
+// или постоянной ценой одной из акций в отчет не попадают: корреляция для них не определена
+type PairCorrelation struct {
+	TickerA      string  `json:"ticker_a"`
+	TickerB      string  `json:"ticker_b"`
+	Correlation  float64 `json:"correlation"`
+	Observations int     `json:"observations"` // Количество общих сессий
+}
+
+// CorrelationReport попарные корреляции и беты акций относительно индекса по сохраненной истории котировок
+type CorrelationReport struct {
+	Tickers     []string          `json:"tickers"` // Акции, вошедшие в расчет
+	Skipped     []string          `json:"skipped"` // Акции, для которых не хватило истории котировок
+	IndexTicker string            `json:"index_ticker"`
+	HasIndex    bool              `json:"has_index"`
+	WindowDays  int               `json:"window_days"`
+	From        time.Time         `json:"from"`
+	To          time.Time         `json:"to"`
+	Betas       []TickerBeta      `json:"betas"`
+	Pairs       []PairCorrelation `json:"pairs"`
+	// AvgPairCorrelation средняя попарная корреляция: чем она ниже, тем лучше акции диверсифицируют друг друга
+	AvgPairCorrelation float64 `json:"avg_pair_correlation"`
+}
@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+)
+
+// CryptoQuote котировка криптовалюты в долларах и рублях
+type CryptoQuote struct {
+	Symbol       string    `json:"symbol"`  // Тикер монеты, например BTC
+	CoinID       string    `json:"coin_id"` // Идентификатор монеты у источника котировок
+	PriceUSD     float64   `json:"price_usd"`
+	PriceRUB     float64   `json:"price_rub"`
+	ChangePerc   float64   `json:"change_perc"` // Изменение цены в долларах за 24 часа, %
+	MarketCapUSD float64   `json:"market_cap_usd"`
+	VolumeUSD    float64   `json:"volume_usd"` // Объем торгов за 24 часа, $
+	UpdatedAt    time.Time `json:"updated_at"`
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	// DividendHistoryDays период, за который суммируются выплаченные дивиденды для расчета доходности, дней
+	DividendHistoryDays = 365
+	// MaxDividendCandidates максимальное количество бумаг в плане дивидендного дохода
+	MaxDividendCandidates = 20
+)
+
+// DividendPayment дивиденд на одну акцию с датой закрытия реестра
+type DividendPayment struct {
+	RecordDate time.Time `json:"record_date"`
+	Value      float64   `json:"value"`
+	Currency   string    `json:"currency"`
+	Upcoming   bool      `json:"upcoming"` // Объявленный дивиденд с датой закрытия реестра в будущем
+}
+
+// DividendProfile дивидендная история бумаги за DividendHistoryDays и объявленные выплаты
+type DividendProfile struct {
+	Ticker string  `json:"ticker"`
+	Name   string  `json:"name"`
+	Price  float64 `json:"price"`
+	// TrailingDividend сумма рублевых дивидендов на акцию с закрытием реестра за последние DividendHistoryDays
+	TrailingDividend  float64           `json:"trailing_dividend"`
+	TrailingYieldPerc float64           `json:"trailing_yield_perc"`
+	Payments          []DividendPayment `json:"payments"`      // В хронологическом порядке
+	PayoutMonths      []int             `json:"payout_months"` // Месяцы закрытия реестра за последний год, по возрастанию
+}
@@ -0,0 +1,56 @@
+package models
+
+import "strings"
+
+// Коды бирж. Тикеры без указания биржи относятся к ExchangeMOEX
+const (
+	ExchangeMOEX     = "MOEX"
+	ExchangeNASDAQ   = "NASDAQ"
+	ExchangeNYSE     = "NYSE"
+	ExchangeXETRA    = "XETRA"
+	ExchangeEuronext = "EURONEXT" // Euronext Paris
+)
+
+// exchangeCurrencies валюта торгов на биржах
+var exchangeCurrencies = map[string]string{
+	ExchangeMOEX:     "RUB",
+	ExchangeNASDAQ:   "USD",
+	ExchangeNYSE:     "USD",
+	ExchangeXETRA:    "EUR",
+	ExchangeEuronext: "EUR",
+}
+
+// exchangeSeparator разделяет код биржи и тикер в полном обозначении бумаги (MOEX:SBER)
+const exchangeSeparator = ":"
+
+// ParseQualifiedTicker разбирает тикер вида "БИРЖА:ТИКЕР". Для тикера без биржи возвращается ExchangeMOEX
+func ParseQualifiedTicker(ticker string) (exchange, symbol string) {
+	ticker = strings.ToUpper(strings.TrimSpace(ticker))
+	if exchange, symbol, ok := strings.Cut(ticker, exchangeSeparator); ok {
+		return strings.TrimSpace(exchange), strings.TrimSpace(symbol)
+	}
+	return ExchangeMOEX, ticker
+}
+
+// QualifyTicker возвращает обозначение бумаги, под которым она хранится и показывается пользователю:
+// бумаги MOEX обозначаются тикером без префикса, бумаги остальных бирж — в виде "БИРЖА:ТИКЕР"
+func QualifyTicker(exchange, symbol string) string {
+	if exchange == "" || exchange == ExchangeMOEX {
+		return symbol
+	}
+	return exchange + exchangeSeparator + symbol
+}
+
+// NormalizeTicker приводит тикер к виду, в котором он хранится: MOEX:SBER и sber превращаются в SBER
+func NormalizeTicker(ticker string) string {
+	return QualifyTicker(ParseQualifiedTicker(ticker))
+}
+
+// TickerCurrency возвращает код валюты, в которой торгуется бумага, по бирже из ее тикера
+func TickerCurrency(ticker string) string {
+	exchange, _ := ParseQualifiedTicker(ticker)
+	if currency, ok := exchangeCurrencies[exchange]; ok {
+		return currency
+	}
+	return "RUB"
+}
@@ -0,0 +1,49 @@
+package models
+
+import "time"
+
+// Наборы данных выгрузки export_data
+const (
+	ExportHistory = "history" // Свечи котировок акции
+	ExportNews    = "news"    // Новости по запросу или тикеру
+)
+
+// Форматы выгрузки
+const (
+	ExportCSV  = "csv"
+	ExportJSON = "json"
+)
+
+// Куда помещается выгрузка
+const (
+	ExportAuto    = "auto"    // В ответ, если выгрузка не больше export.inlineMaxBytes, иначе в хранилище
+	ExportInline  = "inline"  // Только в ответ
+	ExportStorage = "storage" // В каталог или бакет S3
+)
+
+// MaxExportNews наибольшее число новостей в одной выгрузке
+const MaxExportNews = 1000
+
+// ExportRequest параметры выгрузки данных
+type ExportRequest struct {
+	Dataset     string
+	Format      string
+	Destination string
+	Ticker      string
+	Interval    string    // Интервал свечей истории
+	Query       string    // Ключевое слово поиска новостей
+	From        time.Time // Начало периода; нулевое значение — по умолчанию набора данных
+	To          time.Time // Конец периода (включительно); нулевое значение — по текущий момент
+}
+
+// ExportResult итог выгрузки: содержимое в ответе или адрес сохраненного файла
+type ExportResult struct {
+	Dataset     string `json:"dataset"`
+	Format      string `json:"format"`
+	Name        string `json:"name"` // Имя файла выгрузки
+	ContentType string `json:"content_type"`
+	Rows        int    `json:"rows"`
+	Bytes       int    `json:"bytes"`
+	Content     string `json:"content,omitempty"`  // Выгрузка в ответе
+	Location    string `json:"location,omitempty"` // Путь к файлу или ссылка на скачивание
+}
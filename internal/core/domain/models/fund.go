@@ -0,0 +1,65 @@
+package models
+
+import (
+	"time"
+)
+
+// Типы фондов
+const (
+	FundTypeETF    = "etf"    // Биржевой фонд: ETF или БПИФ
+	FundTypeMutual = "mutual" // Паевой инвестиционный фонд (ПИФ), паи которого обращаются на бирже
+)
+
+// FundTypes типы фондов в порядке вывода
+var FundTypes = []string{FundTypeETF, FundTypeMutual}
+
+// FundBoards режимы торгов MOEX, в которых обращаются паи фондов, и типы этих фондов
+var FundBoards = map[string]string{
+	BoardETF: FundTypeETF,
+	"TQIF":   FundTypeMutual,
+}
+
+// Сортировки результатов поиска фондов
+const (
+	FundSortValue  = "value"  // По обороту за день
+	FundSortFee    = "fee"    // По комиссии управляющего, от меньшей; фонды с неизвестной комиссией в конце
+	FundSortChange = "change" // По изменению цены за день, от большего
+)
+
+// FundMetadata сведения о фонде, которых нет в ISS: управляющая компания, комиссия и базовый индекс
+type FundMetadata struct {
+	Manager         string  `json:"manager,omitempty"`
+	ManagementFee   float64 `json:"management_fee,omitempty"`   // Комиссия управляющего, % годовых; 0 — неизвестна
+	UnderlyingIndex string  `json:"underlying_index,omitempty"` // Индекс, который повторяет фонд
+	INAVCode        string  `json:"inav_code,omitempty"`        // Код индикативной стоимости пая (iNAV) на индексном рынке MOEX
+}
+
+// Fund биржевой или паевой фонд: пай фонда с котировкой и сведениями о фонде
+type Fund struct {
+	Ticker     string    `json:"ticker"`
+	ShortName  string    `json:"short_name"`
+	Name       string    `json:"name"`
+	ISIN       string    `json:"isin"`
+	Type       string    `json:"type"` // FundType*
+	Board      string    `json:"board"`
+	Currency   string    `json:"currency"`
+	LotSize    int       `json:"lot_size"`
+	Price      float64   `json:"price"` // Цена последней сделки, вне торговой сессии — цена закрытия
+	ChangePerc float64   `json:"change_perc"`
+	Value      float64   `json:"value"` // Оборот за день в валюте торгов
+	UpdatedAt  time.Time `json:"updated_at"`
+	FundMetadata
+
+	NAV         float64 `json:"nav,omitempty"`          // Индикативная стоимость пая (iNAV); 0 — неизвестна
+	PremiumPerc float64 `json:"premium_perc,omitempty"` // Отклонение цены от iNAV, %: премия положительна, дисконт отрицателен
+}
+
+// FundFilter условия поиска фондов; пустые поля не ограничивают выборку
+type FundFilter struct {
+	Query    string  // Подстрока тикера, названия, управляющей компании или индекса
+	Type     string  // FundType*
+	Index    string  // Подстрока базового индекса
+	MaxFee   float64 // Наибольшая комиссия управляющего, %; фонды с неизвестной комиссией не подходят
+	Currency string
+	Sort     string // FundSort*; по умолчанию по обороту
+}
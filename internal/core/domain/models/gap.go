@@ -0,0 +1,58 @@
+package models
+
+import (
+	"time"
+)
+
+// Направления гэпа
+const (
+	GapDirectionUp   = "up"   // Открытие выше предыдущего закрытия
+	GapDirectionDown = "down" // Открытие ниже предыдущего закрытия
+)
+
+// GapDirections допустимые направления гэпа
+var GapDirections = []string{GapDirectionUp, GapDirectionDown}
+
+const (
+	// DefaultGappersLimit количество бумаг с гэпом в ответе по умолчанию
+	DefaultGappersLimit = 10
+	// MaxGappersLimit максимальное количество бумаг с гэпом в ответе
+	MaxGappersLimit = 50
+)
+
+// GappersTodayCacheKey ключ кэша гэпов текущей сессии; хранится до конца дня по Москве
+const GappersTodayCacheKey = "gappers:today"
+
+// Gap бумага, открывшаяся с разрывом к закрытию предыдущей сессии
+type Gap struct {
+	Ticker    string  `json:"ticker"`
+	Name      string  `json:"name"`
+	PrevClose float64 `json:"prev_close"` // Закрытие предыдущей сессии
+	Open      float64 `json:"open"`       // Открытие текущей сессии
+	GapPerc   float64 `json:"gap_perc"`   // Разрыв открытия к предыдущему закрытию, %
+	Price     float64 `json:"price"`      // Цена на момент расчета
+	// FromOpenPerc изменение цены от открытия на момент расчета, %
+	FromOpenPerc float64 `json:"from_open_perc"`
+	// Filled гэп закрыт: цена на момент расчета вернулась к предыдущему закрытию или прошла его
+	Filled bool `json:"filled"`
+}
+
+// Direction возвращает направление гэпа
+func (g Gap) Direction() string {
+	if g.GapPerc < 0 {
+		return GapDirectionDown
+	}
+	return GapDirectionUp
+}
+
+// GapReport гэпы открытия всех бумаг универсума за сессию, по убыванию величины разрыва
+type GapReport struct {
+	Universe string    `json:"universe"`
+	Session  time.Time `json:"session"` // День сессии по Москве
+	Gaps     []Gap     `json:"gaps"`
+	Scanned  int       `json:"scanned"` // Бумаги, для которых известны открытие и предыдущее закрытие
+	Skipped  int       `json:"skipped"` // Бумаги без свечей текущей или предыдущей сессии
+	// Matched число гэпов, прошедших отбор по величине и направлению; в Gaps тогда не более limit из них
+	Matched    int       `json:"matched,omitempty"`
+	ComputedAt time.Time `json:"computed_at"`
+}
@@ -0,0 +1,46 @@
+package models
+
+import "time"
+
+// Результаты проверок состояния
+const (
+	HealthOK   = "ok"
+	HealthFail = "fail"
+)
+
+// HealthCheck результат проверки одной зависимости сервера
+type HealthCheck struct {
+	Name string `json:"name"` // mongodb, redis, moex, newsapi, scheduler
+	// Critical обязательная зависимость: без нее сервер не готов обслуживать запросы
+	Critical bool          `json:"critical"`
+	Status   string        `json:"status"`
+	Latency  time.Duration `json:"latency"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// HealthReport итог проверки состояния сервера и его зависимостей
+type HealthReport struct {
+	CheckedAt time.Time     `json:"checked_at"`
+	Uptime    time.Duration `json:"uptime"`
+	Checks    []HealthCheck `json:"checks"`
+}
+
+// Ready сообщает, что все обязательные зависимости доступны
+func (r *HealthReport) Ready() bool {
+	for _, check := range r.Checks {
+		if check.Critical && check.Status != HealthOK {
+			return false
+		}
+	}
+	return true
+}
+
+// JobStatus состояние фоновой задачи
+type JobStatus struct {
+	Name      string        `json:"name"`
+	Interval  time.Duration `json:"interval"`
+	StartedAt time.Time     `json:"started_at"`
+	Running   bool          `json:"running"`
+	StoppedAt time.Time     `json:"stopped_at,omitempty"`
+	Error     string        `json:"error,omitempty"` // Причина аварийной остановки
+}
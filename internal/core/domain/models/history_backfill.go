@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// DefaultHistoryBackfillYears глубина загрузки истории дневных свечей по умолчанию, лет
+const DefaultHistoryBackfillYears = 5
+
+// MaxHistoryBackfillYears наибольшая глубина загрузки истории дневных свечей, лет
+const MaxHistoryBackfillYears = 20
+
+// HistoryProgress период истории дневных свечей тикера, уже загруженный с биржи.
+// По нему повторная загрузка продолжается с места остановки
+type HistoryProgress struct {
+	Ticker     string    `json:"ticker" bson:"_id"`
+	LoadedFrom time.Time `json:"loaded_from" bson:"loaded_from"`
+	LoadedTo   time.Time `json:"loaded_to" bson:"loaded_to"`
+	UpdatedAt  time.Time `json:"updated_at" bson:"updated_at"`
+}
+
+// TickerHistoryBackfill итог загрузки истории одного тикера
+type TickerHistoryBackfill struct {
+	Ticker   string `json:"ticker"`
+	Requests int    `json:"requests"` // Запросов свечей к бирже
+	Saved    int    `json:"saved"`    // Сохранено дневных свечей
+	Resumed  bool   `json:"resumed"`  // Часть периода была загружена раньше, загружены только недостающие дни
+	Error    string `json:"error,omitempty"`
+}
+
+// HistoryBackfill итог загрузки истории дневных свечей за период
+type HistoryBackfill struct {
+	From    time.Time               `json:"from"`
+	To      time.Time               `json:"to"` // Последний загруженный день (включительно)
+	Tickers []TickerHistoryBackfill `json:"tickers"`
+	Saved   int                     `json:"saved"`
+	Failed  int                     `json:"failed"` // Тикеров, загрузка которых прервалась с ошибкой
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+)
+
+// Индексы Московской биржи, состав и веса которых загружаются по умолчанию
+const (
+	IndexIMOEX = "IMOEX"
+	IndexRTSI  = "RTSI"
+)
+
+// IndexConstituent бумага в составе биржевого индекса с ее весом
+type IndexConstituent struct {
+	Index     string    `json:"index" bson:"index"`
+	Ticker    string    `json:"ticker" bson:"ticker"`
+	Name      string    `json:"name" bson:"name"`
+	Weight    float64   `json:"weight" bson:"weight"`         // Вес в индексе, %
+	TradeDate time.Time `json:"trade_date" bson:"trade_date"` // Дата, на которую биржа опубликовала веса
+	UpdatedAt time.Time `json:"updated_at" bson:"updated_at"`
+}
+
+// IndexConstituentQuote бумага индекса с текущей котировкой и вкладом в изменение индекса
+type IndexConstituentQuote struct {
+	IndexConstituent
+	Price      float64 `json:"price"`
+	ChangePerc float64 `json:"change_perc"`
+	// Contribution вклад бумаги в изменение индекса, процентных пунктов: вес, умноженный на изменение цены
+	Contribution float64 `json:"contribution"`
+	NoQuote      bool    `json:"no_quote,omitempty"`
+}
+
+// IndexComposition состав индекса с котировками, по убыванию веса
+type IndexComposition struct {
+	Index        string                  `json:"index"`
+	TradeDate    time.Time               `json:"trade_date"`
+	Constituents []IndexConstituentQuote `json:"constituents"`
+	TotalWeight  float64                 `json:"total_weight"` // Сумма весов бумаг с котировками, %
+	// WeightedChangePerc изменение индекса, оцененное по весам и изменениям цен бумаг
+	WeightedChangePerc float64 `json:"weighted_change_perc"`
+}
+
+// IndexIngestResult итог загрузки состава индексов
+type IndexIngestResult struct {
+	Fetched int      `json:"fetched"`
+	Saved   int      `json:"saved"`
+	Failed  []string `json:"failed,omitempty"` // Индексы, состав которых не удалось загрузить
+}
@@ -0,0 +1,71 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	// DefaultRecentListingsDays глубина поиска новых листингов по умолчанию, дней
+	DefaultRecentListingsDays = 30
+	// MaxRecentListingsDays максимальная глубина поиска новых листингов, дней
+	MaxRecentListingsDays = 365
+)
+
+// Статусы размещения
+const (
+	// ListingStatusUpcoming размещение объявлено, торги еще не начались
+	ListingStatusUpcoming = "upcoming"
+	// ListingStatusListed бумага торгуется на MOEX
+	ListingStatusListed = "listed"
+)
+
+// Виды размещения
+const (
+	// ListingKindIPO первичное публичное размещение
+	ListingKindIPO = "ipo"
+	// ListingKindSPO вторичное публичное размещение
+	ListingKindSPO = "spo"
+	// ListingKindDirect прямой листинг или перевод бумаги в основной режим торгов без размещения
+	ListingKindDirect = "listing"
+)
+
+// Источники сведений о размещении
+const (
+	// ListingSourceMOEX бумага обнаружена в списке торгуемых бумаг ISS
+	ListingSourceMOEX = "moex"
+	// ListingSourceFeed размещение описано в файле календаря из конфигурации
+	ListingSourceFeed = "feed"
+)
+
+// Listing размещение или начало торгов акцией на MOEX
+type Listing struct {
+	Ticker       string    `json:"ticker" bson:"ticker"`
+	Name         string    `json:"name" bson:"name"`
+	ISIN         string    `json:"isin,omitempty" bson:"isin,omitempty"`
+	Kind         string    `json:"kind" bson:"kind"`
+	Status       string    `json:"status" bson:"status"`
+	Source       string    `json:"source" bson:"source"`
+	ListingLevel int       `json:"listing_level,omitempty" bson:"listing_level,omitempty"` // Уровень листинга: 1, 2 или 3; 0 — неизвестен
+	ExpectedDate time.Time `json:"expected_date,omitempty" bson:"expected_date,omitempty"` // Ожидаемая дата начала торгов для объявленных размещений
+	PriceRange   string    `json:"price_range,omitempty" bson:"price_range,omitempty"`     // Ценовой диапазон размещения, как его объявил эмитент
+	Notes        string    `json:"notes,omitempty" bson:"notes,omitempty"`
+	ListedAt     time.Time `json:"listed_at,omitempty" bson:"listed_at,omitempty"` // Дата начала торгов; для обнаруженных в ISS — дата обнаружения
+
+	UpdatedAt time.Time `json:"updated_at" bson:"updated_at"`
+}
+
+// ListedSecurity акция, торгующаяся в основном режиме MOEX
+type ListedSecurity struct {
+	Ticker       string
+	Name         string
+	ISIN         string
+	ListingLevel int
+}
+
+// ListingRefreshResult итог сверки календаря размещений со списком бумаг MOEX и файлом календаря
+type ListingRefreshResult struct {
+	Securities int      `json:"securities"` // Бумаг в основном режиме торгов
+	Baseline   bool     `json:"baseline"`   // Первая сверка: список бумаг сохранен как исходный, новые листинги не определялись
+	NewListed  []string `json:"new_listed"` // Бумаги, начавшие торговаться с прошлой сверки
+	Upcoming   int      `json:"upcoming"`   // Объявленных размещений в календаре
+}
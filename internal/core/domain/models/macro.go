@@ -0,0 +1,94 @@
+package models
+
+import (
+	"time"
+)
+
+// DefaultMacroHistoryMonths период истории макропоказателя по умолчанию, месяцев
+const DefaultMacroHistoryMonths = 24
+
+// Коды макроэкономических показателей
+const (
+	MacroInflation    = "inflation"
+	MacroGDP          = "gdp"
+	MacroUnemployment = "unemployment"
+	MacroKeyRate      = "key_rate"
+	MacroUSDRUB       = "usd_rub"
+	MacroBrent        = "brent"
+)
+
+// Периодичность макроэкономических показателей
+const (
+	MacroFrequencyDaily     = "daily"
+	MacroFrequencyMonthly   = "monthly"
+	MacroFrequencyQuarterly = "quarterly"
+)
+
+// Источники макроэкономических показателей
+const (
+	// MacroSourceCBR показатель получен из веб-сервиса Банка России
+	MacroSourceCBR = "cbr"
+	// MacroSourceMOEX показатель рассчитан по котировкам MOEX
+	MacroSourceMOEX = "moex"
+	// MacroSourceRosstat показатель загружен из CSV-выгрузки Росстата
+	MacroSourceRosstat = "rosstat"
+)
+
+// MacroIndicatorSpec описание макроэкономического показателя
+type MacroIndicatorSpec struct {
+	Code      string
+	Name      string
+	Unit      string
+	Frequency string
+}
+
+// MacroIndicators поддерживаемые макроэкономические показатели
+var MacroIndicators = []MacroIndicatorSpec{
+	{Code: MacroInflation, Name: "Инфляция (ИПЦ)", Unit: "% г/г", Frequency: MacroFrequencyMonthly},
+	{Code: MacroGDP, Name: "ВВП", Unit: "% г/г", Frequency: MacroFrequencyQuarterly},
+	{Code: MacroUnemployment, Name: "Безработица", Unit: "%", Frequency: MacroFrequencyMonthly},
+	{Code: MacroKeyRate, Name: "Ключевая ставка", Unit: "% годовых", Frequency: MacroFrequencyDaily},
+	{Code: MacroUSDRUB, Name: "Официальный курс доллара", Unit: "₽", Frequency: MacroFrequencyDaily},
+	{Code: MacroBrent, Name: "Нефть Brent", Unit: "$ за баррель", Frequency: MacroFrequencyDaily},
+}
+
+// FindMacroIndicator возвращает описание макропоказателя по коду
+func FindMacroIndicator(code string) (MacroIndicatorSpec, bool) {
+	for _, spec := range MacroIndicators {
+		if spec.Code == code {
+			return spec, true
+		}
+	}
+	return MacroIndicatorSpec{}, false
+}
+
+// MacroIndicator значение макроэкономического показателя за период.
+// Значение однозначно определяется кодом показателя и началом периода
+type MacroIndicator struct {
+	Code   string    `json:"code" bson:"code"`
+	Period time.Time `json:"period" bson:"period"` // Начало периода: день, первый день месяца или квартала
+	Value  float64   `json:"value" bson:"value"`
+	Source string    `json:"source" bson:"source"`
+
+	UpdatedAt time.Time `json:"updated_at" bson:"updated_at"`
+}
+
+// MacroSeries история макропоказателя по возрастанию периода
+type MacroSeries struct {
+	Spec   MacroIndicatorSpec `json:"spec"`
+	Values []MacroIndicator   `json:"values"`
+}
+
+// MacroSnapshot последнее и предыдущее значения макропоказателя
+type MacroSnapshot struct {
+	Spec     MacroIndicatorSpec `json:"spec"`
+	Latest   MacroIndicator     `json:"latest"`
+	Previous *MacroIndicator    `json:"previous,omitempty"`
+}
+
+// MacroIngestResult итог загрузки макропоказателей из источников
+type MacroIngestResult struct {
+	Fetched int      `json:"fetched"`
+	Saved   int      `json:"saved"`
+	Failed  []string `json:"failed,omitempty"` // Источники, из которых не удалось загрузить показатели
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"time"
+)
+
+// Стороны маржинальной торговли
+const (
+	MarginSideLong  = "long"  // Покупка с плечом
+	MarginSideShort = "short" // Продажа без покрытия
+)
+
+// MarginSides допустимые стороны маржинальной торговли
+var MarginSides = []string{MarginSideLong, MarginSideShort}
+
+// MarginStatus условия маржинальной торговли бумагой у брокера
+type MarginStatus struct {
+	Ticker string `json:"ticker"`
+	Broker string `json:"broker"`
+	Long   bool   `json:"long"`  // Бумага доступна для покупки с плечом
+	Short  bool   `json:"short"` // Бумага доступна для продажи без покрытия
+	// RiskRateLong и RiskRateShort ставки риска брокера, %; 0 — ставка не указана
+	RiskRateLong  float64 `json:"risk_rate_long,omitempty"`
+	RiskRateShort float64 `json:"risk_rate_short,omitempty"`
+	// ShortFeePerc плата за перенос короткой позиции, % годовых; 0 — не указана
+	ShortFeePerc float64   `json:"short_fee_perc,omitempty"`
+	UpdatedAt    time.Time `json:"updated_at"` // Дата, на которую брокер опубликовал список
+}
+
+// Available сообщает, доступна ли бумага для маржинальных сделок на стороне side; пустая сторона — на любой
+func (m MarginStatus) Available(side string) bool {
+	switch side {
+	case MarginSideLong:
+		return m.Long
+	case MarginSideShort:
+		return m.Short
+	default:
+		return m.Long || m.Short
+	}
+}
+
+// RiskRate возвращает ставку риска для стороны side; для пустой стороны — ставку длинной позиции
+func (m MarginStatus) RiskRate(side string) float64 {
+	if side == MarginSideShort {
+		return m.RiskRateShort
+	}
+	return m.RiskRateLong
+}
+
+// Leverage возвращает максимальное плечо при ставке риска rate, %; 0 — ставка не указана
+func Leverage(rate float64) float64 {
+	if rate <= 0 {
+		return 0
+	}
+	return 100 / rate
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+)
+
+// DigestIndexCodes индексы Московской биржи в дайджесте рынка: индекс МосБиржи, индекс РТС и индекс гособлигаций
+var DigestIndexCodes = []string{"IMOEX", "RTSI", "RGBI"}
+
+// MarketIndex текущее значение биржевого индекса
+type MarketIndex struct {
+	Code       string    `json:"code"`
+	Name       string    `json:"name"`
+	Value      float64   `json:"value"`
+	Change     float64   `json:"change"`      // Изменение к закрытию предыдущего дня, пунктов
+	ChangePerc float64   `json:"change_perc"` // Изменение к закрытию предыдущего дня, %
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// Разделы дайджеста рынка
+const (
+	DigestSectionIndexes     = "indexes"
+	DigestSectionMovers      = "movers"
+	DigestSectionVolume      = "volume"
+	DigestSectionFX          = "fx"
+	DigestSectionCommodities = "commodities"
+	DigestSectionSectors     = "sectors"
+	DigestSectionNews        = "news"
+)
+
+// DigestSections разделы дайджеста рынка в порядке вывода
+var DigestSections = []string{
+	DigestSectionIndexes,
+	DigestSectionMovers,
+	DigestSectionVolume,
+	DigestSectionFX,
+	DigestSectionCommodities,
+	DigestSectionSectors,
+	DigestSectionNews,
+}
+
+const (
+	// DigestMoversLimit количество лидеров роста и падения в дайджесте
+	DigestMoversLimit = 5
+	// DefaultDigestNewsLimit количество новостей в дайджесте по умолчанию
+	DefaultDigestNewsLimit = 10
+	// MaxDigestNewsLimit максимальное количество новостей в дайджесте
+	MaxDigestNewsLimit = 30
+)
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	// DefaultMoodHistoryDays глубина истории индекса настроения рынка по умолчанию, дней
+	DefaultMoodHistoryDays = 30
+	// MaxMoodHistoryDays максимальная глубина истории индекса настроения рынка, дней
+	MaxMoodHistoryDays = 365
+)
+
+// MarketMood дневное значение составного индекса настроения рынка.
+// Каждая составляющая нормирована к диапазону от -1 (страх) до 1 (жадность),
+// итоговый индекс — взвешенное среднее доступных составляющих в шкале от 0 до 100
+type MarketMood struct {
+	Date  time.Time `json:"date" bson:"date"`
+	Score float64   `json:"score" bson:"score"`
+	Label string    `json:"label" bson:"label"`
+
+	// Ширина рынка: доля растущих акций за вычетом доли падающих
+	Advancers    int     `json:"advancers" bson:"advancers"`
+	Decliners    int     `json:"decliners" bson:"decliners"`
+	BreadthScore float64 `json:"breadth_score" bson:"breadth_score"`
+
+	// Волатильность: разброс дневных изменений акций; спокойный рынок повышает индекс
+	DispersionPerc  float64 `json:"dispersion_perc" bson:"dispersion_perc"`
+	VolatilityScore float64 `json:"volatility_score" bson:"volatility_score"`
+
+	// Тональность новостей дня по словарю позитивных и негативных слов
+	NewsCount int     `json:"news_count" bson:"news_count"`
+	NewsScore float64 `json:"news_score" bson:"news_score"`
+	HasNews   bool    `json:"has_news" bson:"has_news"`
+
+	// Курс рубля: укрепление повышает индекс, ослабление понижает
+	FXTicker     string  `json:"fx_ticker" bson:"fx_ticker"`
+	FXChangePerc float64 `json:"fx_change_perc" bson:"fx_change_perc"`
+	FXScore      float64 `json:"fx_score" bson:"fx_score"`
+	HasFX        bool    `json:"has_fx" bson:"has_fx"`
+
+	UpdatedAt time.Time `json:"updated_at" bson:"updated_at"`
+}
+
+// MarketMoodReport текущее значение индекса настроения и его история
+type MarketMoodReport struct {
+	Today   MarketMood   `json:"today"`
+	History []MarketMood `json:"history"` // От старых дней к новым, включая сегодняшний
+}
+
+// MoodLabel возвращает словесную оценку значения индекса настроения
+func MoodLabel(score float64) string {
+	switch {
+	case score < 20:
+		return "сильный страх"
+	case score < 40:
+		return "страх"
+	case score < 60:
+		return "нейтрально"
+	case score < 80:
+		return "оптимизм"
+	default:
+		return "эйфория"
+	}
+}
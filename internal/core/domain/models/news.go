@@ -1,9 +1,17 @@
 package models
 
 import (
+	"sort"
+	"strings"
 	"time"
 )
 
+// DefaultNewsLanguage язык новостей по умолчанию
+const DefaultNewsLanguage = "ru"
+
+// NewsSchemaVersion текущая версия схемы документов новостей в хранилище
+const NewsSchemaVersion = 1
+
 // News представляет собой финансовую новость
 type News struct {
 	ID          string    `json:"id" bson:"_id"`
@@ -16,4 +24,126 @@ type News struct {
 	CreatedAt   time.Time `json:"created_at" bson:"created_at"`
 	Tags        []string  `json:"tags" bson:"tags"`
 	RelatedTo   []string  `json:"related_to" bson:"related_to"` // Связанные тикеры акций
+	Language    string    `json:"language,omitempty" bson:"language,omitempty"`
+
+	// Результаты обогащения с помощью LLM (заполняются, если обогащение включено)
+	Summary     string `json:"summary,omitempty" bson:"summary,omitempty"`
+	Category    string `json:"category,omitempty" bson:"category,omitempty"`
+	Translation string `json:"translation,omitempty" bson:"translation,omitempty"`
+
+	SchemaVersion int `json:"-" bson:"schema_version"`
+}
+
+// NewsDetail подробность новостей в ответе: какие поля новости нужны клиенту
+type NewsDetail string
+
+const (
+	NewsDetailHeadline NewsDetail = "headline" // Заголовок, источник, дата публикации и ссылка
+	NewsDetailSummary  NewsDetail = "summary"  // Вдобавок описание и результаты обогащения
+	NewsDetailFull     NewsDetail = "full"     // Вдобавок полный текст новости
+)
+
+// CacheKey возвращает суффикс ключа кэша для подробности; для полных новостей — пустую строку,
+// чтобы ключи выборок без сокращения не менялись
+func (d NewsDetail) CacheKey() string {
+	if d == NewsDetailFull || d == "" {
+		return ""
+	}
+	return ":detail=" + string(d)
+}
+
+// Strip возвращает копию новостей без полей, которые не входят в подробность d
+func (d NewsDetail) Strip(news []News) []News {
+	if d == NewsDetailFull || d == "" {
+		return news
+	}
+
+	stripped := make([]News, len(news))
+	for i, item := range news {
+		item.Content = ""
+		if d == NewsDetailHeadline {
+			item.Description = ""
+			item.Summary, item.Category, item.Translation = "", "", ""
+		}
+		stripped[i] = item
+	}
+	return stripped
+}
+
+// NewsFilter дополнительные условия поиска новостей
+type NewsFilter struct {
+	From     time.Time // Начало периода публикации (включительно); нулевое значение — без ограничения
+	To       time.Time // Конец периода публикации (не включительно); нулевое значение — без ограничения
+	Sources  []string  // Издания; пустой список — все издания
+	Language string    // Язык новостей; пустая строка — DefaultNewsLanguage
+}
+
+// Lang возвращает язык фильтра или язык по умолчанию
+func (f NewsFilter) Lang() string {
+	if f.Language == "" {
+		return DefaultNewsLanguage
+	}
+	return strings.ToLower(f.Language)
+}
+
+// IsEmpty сообщает, что фильтр не задает ограничений сверх языка по умолчанию
+func (f NewsFilter) IsEmpty() bool {
+	return f.From.IsZero() && f.To.IsZero() && len(f.Sources) == 0 && f.Lang() == DefaultNewsLanguage
+}
+
+// CacheKey возвращает суффикс ключа кэша для фильтра; для пустого фильтра — пустую строку,
+// чтобы ключи поиска без фильтров не менялись
+func (f NewsFilter) CacheKey() string {
+	if f.IsEmpty() {
+		return ""
+	}
+
+	sources := make([]string, len(f.Sources))
+	for i, source := range f.Sources {
+		sources[i] = strings.ToLower(source)
+	}
+	sort.Strings(sources)
+
+	key := ""
+	if !f.From.IsZero() {
+		key += ":from=" + f.From.UTC().Format(time.RFC3339)
+	}
+	if !f.To.IsZero() {
+		key += ":to=" + f.To.UTC().Format(time.RFC3339)
+	}
+	if len(sources) > 0 {
+		key += ":sources=" + strings.Join(sources, ",")
+	}
+	return key + ":lang=" + f.Lang()
+}
+
+// MaxBackfillDays максимальная длина периода одной загрузки архива новостей
+const MaxBackfillDays = 31
+
+// NewsBackfill итог загрузки архива новостей за период
+type NewsBackfill struct {
+	From       time.Time         `json:"from"`
+	To         time.Time         `json:"to"` // Последний загруженный день (включительно)
+	Days       int               `json:"days"`
+	Requests   int               `json:"requests"`   // Число запросов к NewsAPI
+	Fetched    int               `json:"fetched"`    // Получено статей
+	Saved      int               `json:"saved"`      // Сохранено уникальных новостей
+	Duplicates int               `json:"duplicates"` // Пропущено повторов и статей без ID
+	Truncated  []string          `json:"truncated"`  // Дни, для которых NewsAPI отдал не все страницы
+	Failed     map[string]string `json:"failed"`     // Дни, загрузить которые не удалось, и причина
+}
+
+// Article полный текст статьи, извлеченный со страницы новости
+type Article struct {
+	URL   string `json:"url"`
+	Title string `json:"title"`
+	// Text абзацы статьи, разделенные пустой строкой
+	Text      string    `json:"text"`
+	FetchedAt time.Time `json:"fetched_at"`
+}
+
+// NewsFullText новость вместе с полным текстом статьи
+type NewsFullText struct {
+	News    News
+	Article Article
 }
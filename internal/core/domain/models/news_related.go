@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// RelatedNewsWindow период до и после публикации новости, в котором ищутся связанные с ней новости
+const RelatedNewsWindow = 30 * 24 * time.Hour
+
+// DefaultRelatedNews число связанных новостей по умолчанию
+const DefaultRelatedNews = 10
+
+// RelatedNews новость, связанная с исходной, и общее у них
+type RelatedNews struct {
+	News          News     `json:"news"`
+	Score         float64  `json:"score"` // Степень связи: общие тикеры весят больше общих тем и слов заголовка
+	SharedTickers []string `json:"shared_tickers"`
+	SharedTags    []string `json:"shared_tags"`
+}
+
+// NewsRelations новости, связанные с исходной, в хронологическом порядке
+type NewsRelations struct {
+	Origin  News          `json:"origin"`
+	Related []RelatedNews `json:"related"`
+}
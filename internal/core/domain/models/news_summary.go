@@ -0,0 +1,114 @@
+package models
+
+import (
+	"strings"
+	"time"
+	"unicode"
+)
+
+// DefaultSummaryHeadlines число заголовков на группу в сводке новостей по умолчанию
+const DefaultSummaryHeadlines = 3
+
+// OtherNewsTopic название группы сводки для новостей, не отнесенных ни к одной теме
+const OtherNewsTopic = "прочее"
+
+// shortStemLength длина основы, до которой она сравнивается со словом целиком, чтобы «газ» не находился в «газете»
+const shortStemLength = 3
+
+// NewsTopic тема (сектор) для группировки новостей. Название темы добавляется в теги новости
+type NewsTopic struct {
+	Name    string   // Название темы, оно же тег новости
+	Stems   []string // Основы слов, по которым новость относится к теме; основы до трех букв сравниваются со словом целиком
+	Tickers []string // Бумаги, упоминание которых относит новость к теме
+}
+
+// NewsTopics темы сводки новостей в порядке вывода
+var NewsTopics = []NewsTopic{
+	{
+		Name:    "банки",
+		Stems:   []string{"банк", "кредит", "ипотек", "вклад", "депозит", "заемщик"},
+		Tickers: []string{"SBER", "VTBR", "TCSG", "BSPB", "CBOM"},
+	},
+	{
+		Name:    "нефть и газ",
+		Stems:   []string{"нефт", "газ", "нпз", "бензин", "топлив", "опек", "brent", "брент", "спг"},
+		Tickers: []string{"GAZP", "LKOH", "ROSN", "NVTK", "SNGS", "TATN", "SIBN"},
+	},
+	{
+		Name:    "металлы",
+		Stems:   []string{"металл", "сталь", "стальн", "сталелит", "никел", "золот", "алюмин", "медь", "медн", "паллади", "платин", "руда", "руды", "рудн"},
+		Tickers: []string{"GMKN", "NLMK", "CHMF", "MAGN", "PLZL", "POLY", "RUAL", "ALRS", "UGLD"},
+	},
+	{
+		Name:  "макроэкономика",
+		Stems: []string{"ввп", "инфляц", "ставк", "цб", "центробанк", "минфин", "бюджет", "безработ", "экономик", "рецесс", "налог", "офз"},
+	},
+	{
+		Name:    "валюта",
+		Stems:   []string{"валют", "рубл", "доллар", "юан", "курс", "usd", "eur", "cny"},
+		Tickers: []string{"USD000UTSTOM", "CNYRUB_TOM", "EUR_RUB__TOM"},
+	},
+}
+
+// ClassifyNewsTopics возвращает названия тем, к которым относится текст новости с упомянутыми тикерами
+func ClassifyNewsTopics(text string, tickers []string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var topics []string
+	for _, topic := range NewsTopics {
+		if topic.matches(words, tickers) {
+			topics = append(topics, topic.Name)
+		}
+	}
+	return topics
+}
+
+// NewsTopicsFromTags возвращает названия тем среди тегов новости
+func NewsTopicsFromTags(tags []string) []string {
+	var topics []string
+	for _, topic := range NewsTopics {
+		for _, tag := range tags {
+			if strings.EqualFold(tag, topic.Name) {
+				topics = append(topics, topic.Name)
+				break
+			}
+		}
+	}
+	return topics
+}
+
+// matches проверяет, относится ли новость к теме по словам текста или тикерам
+func (t NewsTopic) matches(words, tickers []string) bool {
+	for _, ticker := range tickers {
+		for _, topicTicker := range t.Tickers {
+			if strings.EqualFold(ticker, topicTicker) {
+				return true
+			}
+		}
+	}
+
+	for _, word := range words {
+		for _, stem := range t.Stems {
+			if word == stem || len([]rune(stem)) > shortStemLength && strings.HasPrefix(word, stem) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// NewsTopicGroup группа новостей одной темы в сводке
+type NewsTopicGroup struct {
+	Topic     string `json:"topic"`
+	Count     int    `json:"count"`
+	Headlines []News `json:"headlines"` // Самые свежие новости темы
+}
+
+// NewsSummary сводка новостей за день по темам. Новость может входить в несколько групп
+type NewsSummary struct {
+	Date   time.Time        `json:"date"`
+	Total  int              `json:"total"`
+	Groups []NewsTopicGroup `json:"groups"` // По убыванию числа новостей, группа «прочее» последней
+}
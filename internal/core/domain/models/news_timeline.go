@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// DefaultNewsTimelineDays период хроники новостей по умолчанию, дней
+const DefaultNewsTimelineDays = 30
+
+// MaxNewsTimelineDays наибольший период хроники новостей, дней
+const MaxNewsTimelineDays = 366
+
+// DefaultTimelineHeadlines число заголовков за день в хронике новостей по умолчанию
+const DefaultTimelineHeadlines = 3
+
+// NewsDay число новостей тикера за день по московскому времени и самые свежие заголовки дня
+type NewsDay struct {
+	Date      time.Time `json:"date"`
+	Count     int       `json:"count"`
+	Headlines []News    `json:"headlines"`
+	Burst     bool      `json:"burst"` // Новостей заметно больше, чем в среднем за период
+}
+
+// NewsTimeline хроника новостей тикера по дням, включая дни без новостей
+type NewsTimeline struct {
+	Ticker string    `json:"ticker"`
+	From   time.Time `json:"from"`
+	To     time.Time `json:"to"`
+	Total  int       `json:"total"`
+	Days   []NewsDay `json:"days"`
+}
@@ -0,0 +1,91 @@
+package models
+
+import (
+	"math"
+)
+
+// Направления сделки
+const (
+	OrderSideBuy  = "buy"
+	OrderSideSell = "sell"
+)
+
+// OrderSides направления сделки, которые можно указать в estimate_order_cost
+var OrderSides = []string{OrderSideBuy, OrderSideSell}
+
+// TradingTerms условия торгов бумагой из справочника: размер лота и шаг цены; нули — условия неизвестны
+type TradingTerms struct {
+	LotSize int     `json:"lot_size,omitempty"`
+	MinStep float64 `json:"min_step,omitempty"`
+}
+
+// Known сообщает, что размер лота известен из справочника
+func (t TradingTerms) Known() bool {
+	return t.LotSize > 0
+}
+
+// Lots возвращает число лотов, необходимых для quantity бумаг, с округлением вверх.
+// Если размер лота неизвестен, лотом считается одна бумага
+func (t TradingTerms) Lots(quantity int64) int64 {
+	lot := int64(t.LotSize)
+	if lot <= 0 {
+		lot = 1
+	}
+	return (quantity + lot - 1) / lot
+}
+
+// OddLot возвращает число бумаг сверх целых лотов: их можно продать только в режиме неполных лотов
+func (t TradingTerms) OddLot(quantity int64) int64 {
+	if t.LotSize <= 1 {
+		return 0
+	}
+	return quantity % int64(t.LotSize)
+}
+
+// RoundPrice округляет цену до ближайшего шага цены; при неизвестном шаге цена не меняется
+func (t TradingTerms) RoundPrice(price float64) float64 {
+	if t.MinStep <= 0 {
+		return price
+	}
+	steps := math.Round(price / t.MinStep)
+	// Повторное округление убирает погрешность умножения, например 0.1*3
+	return math.Round(steps*t.MinStep*1e8) / 1e8
+}
+
+// CommissionTariff тариф брокера: процент от суммы сделки, но не меньше минимальной комиссии
+type CommissionTariff struct {
+	Perc float64 `json:"perc"`
+	Min  float64 `json:"min,omitempty"`
+}
+
+// IsZero сообщает, что тариф не задан и комиссия не учитывается
+func (c CommissionTariff) IsZero() bool {
+	return c.Perc == 0 && c.Min == 0
+}
+
+// For возвращает комиссию за сделку на сумму amount, округленную до копеек
+func (c CommissionTariff) For(amount float64) float64 {
+	if amount <= 0 || c.IsZero() {
+		return 0
+	}
+	return math.Round(math.Max(amount*c.Perc/100, c.Min)*100) / 100
+}
+
+// OrderCostEstimate оценка стоимости заявки с учетом лотов, шага цены и комиссии брокера
+type OrderCostEstimate struct {
+	Ticker string `json:"ticker"`
+	Side   string `json:"side"` // OrderSide*
+	TradingTerms
+	// Quantity запрошенное количество бумаг; Lots и LotQuantity — целые лоты, которые нужны для его исполнения
+	Quantity    int64 `json:"quantity"`
+	Lots        int64 `json:"lots"`
+	LotQuantity int64 `json:"lot_quantity"`
+	// Price цена заявки: указанная и округленная до шага цены либо текущая
+	Price      float64          `json:"price"`
+	LimitPrice bool             `json:"limit_price"` // Цена указана в заявке, а не взята текущая
+	Amount     float64          `json:"amount"`      // Сумма сделки без комиссии
+	Commission float64          `json:"commission"`
+	Tariff     CommissionTariff `json:"tariff"`
+	// Total списание со счета при покупке (сумма плюс комиссия) или зачисление при продаже (сумма минус комиссия)
+	Total float64 `json:"total"`
+}
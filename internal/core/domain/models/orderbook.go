@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	// DefaultOrderBookDepth количество уровней стакана с каждой стороны по умолчанию
+	DefaultOrderBookDepth = 10
+	// MaxOrderBookDepth максимальное количество уровней стакана с каждой стороны
+	MaxOrderBookDepth = 20
+)
+
+// OrderBookLevel ценовой уровень стакана
+type OrderBookLevel struct {
+	Price    float64 `json:"price"`
+	Quantity int64   `json:"quantity"` // Объем заявок на уровне, лотов
+}
+
+// OrderBook снимок стакана заявок по бумаге в основном режиме торгов.
+// Спред и дисбаланс рассчитываются по показанным уровням
+type OrderBook struct {
+	Ticker    string           `json:"ticker"`
+	Board     string           `json:"board,omitempty"` // Режим торгов
+	Bids      []OrderBookLevel `json:"bids"`            // Заявки на покупку, от лучшей (самой высокой) цены
+	Asks      []OrderBookLevel `json:"asks"`            // Заявки на продажу, от лучшей (самой низкой) цены
+	UpdatedAt time.Time        `json:"updated_at"`
+
+	BestBid    float64 `json:"best_bid"`
+	BestAsk    float64 `json:"best_ask"`
+	MidPrice   float64 `json:"mid_price"`
+	Spread     float64 `json:"spread"`      // Разница лучших цен продажи и покупки, ₽
+	SpreadPerc float64 `json:"spread_perc"` // Спред относительно средней цены, %
+	BidVolume  int64   `json:"bid_volume"`  // Суммарный объем заявок на покупку, лотов
+	AskVolume  int64   `json:"ask_volume"`  // Суммарный объем заявок на продажу, лотов
+	// Imbalance дисбаланс объемов от -1 (только продавцы) до 1 (только покупатели)
+	Imbalance float64 `json:"imbalance"`
+}
@@ -0,0 +1,44 @@
+package models
+
+// Ограничения постраничной выдачи списков
+const (
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
+)
+
+// Pagination параметры постраничной выдачи списков
+type Pagination struct {
+	Limit  int `json:"limit"` // 0 — без ограничения
+	Offset int `json:"offset"`
+}
+
+// WithDefaults подставляет размер страницы по умолчанию и ограничивает его максимумом
+func (p Pagination) WithDefaults() Pagination {
+	if p.Limit <= 0 {
+		p.Limit = DefaultPageLimit
+	}
+	if p.Limit > MaxPageLimit {
+		p.Limit = MaxPageLimit
+	}
+	if p.Offset < 0 {
+		p.Offset = 0
+	}
+	return p
+}
+
+// Bounds возвращает границы страницы [start, end) в списке из total элементов
+func (p Pagination) Bounds(total int) (int, int) {
+	start := p.Offset
+	if start < 0 {
+		start = 0
+	}
+	if start > total {
+		start = total
+	}
+
+	end := total
+	if p.Limit > 0 && start+p.Limit < total {
+		end = start + p.Limit
+	}
+	return start, end
+}
@@ -0,0 +1,134 @@
+package models
+
+import (
+	"time"
+)
+
+// DefaultPortfolio имя портфеля, используемого, если имя не указано
+const DefaultPortfolio = "default"
+
+const (
+	// DefaultRiskWindowDays окно расчета беты и просадок в обзоре рисков портфеля по умолчанию, дней
+	DefaultRiskWindowDays = 365
+	// MaxRiskWindowDays максимальное окно расчета беты и просадок в обзоре рисков портфеля, дней
+	MaxRiskWindowDays = 1095
+)
+
+// Position представляет позицию в портфеле
+type Position struct {
+	Portfolio string    `json:"portfolio" bson:"portfolio"`
+	Ticker    string    `json:"ticker" bson:"ticker"`
+	Quantity  int64     `json:"quantity" bson:"quantity"`
+	AvgPrice  float64   `json:"avg_price" bson:"avg_price"`
+	UpdatedAt time.Time `json:"updated_at" bson:"updated_at"`
+}
+
+// PositionChange изменение позиции портфеля. Before равен nil для новой позиции, After — для закрытой.
+// При DryRun изменение только рассчитано и не сохранено
+type PositionChange struct {
+	Before  *Position `json:"before"`
+	After   *Position `json:"after"`
+	DryRun  bool      `json:"dry_run"`
+	LotSize int       `json:"lot_size,omitempty"` // Размер лота из справочника; 0 — неизвестен
+	// Trade сделка, которой изменена позиция, в том виде, в каком она записана в историю
+	Trade *PortfolioTrade `json:"trade,omitempty"`
+}
+
+// PositionValue представляет оценку позиции по текущей цене
+type PositionValue struct {
+	Position
+	TradingTerms
+	Lots    int64   `json:"lots,omitempty"`    // Целых лотов в позиции
+	OddLot  int64   `json:"odd_lot,omitempty"` // Бумаг сверх целых лотов
+	Price   float64 `json:"price"`
+	Value   float64 `json:"value"`
+	PnL     float64 `json:"pnl"`
+	PnLPerc float64 `json:"pnl_perc"`
+	// Commission оценка комиссии брокера при продаже всей позиции, NetValue — стоимость за ее вычетом
+	Commission float64 `json:"commission,omitempty"`
+	NetValue   float64 `json:"net_value"`
+}
+
+// PortfolioSummary представляет оценку портфеля по текущим ценам
+type PortfolioSummary struct {
+	Name            string          `json:"name"`
+	Positions       []PositionValue `json:"positions"`
+	TotalValue      float64         `json:"total_value"`
+	TotalCost       float64         `json:"total_cost"`
+	TotalPnL        float64         `json:"total_pnl"`
+	TotalCommission float64         `json:"total_commission,omitempty"` // Комиссия при продаже всех позиций
+	NetValue        float64         `json:"net_value"`                  // Стоимость портфеля за вычетом комиссии
+}
+
+// StressScenario описывает исторический кризисный период для стресс-теста
+type StressScenario struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Start       time.Time `json:"start"`
+	End         time.Time `json:"end"`
+}
+
+// PositionStress представляет гипотетический результат позиции в стресс-сценарии
+type PositionStress struct {
+	Ticker          string  `json:"ticker"`
+	Value           float64 `json:"value"`
+	ReturnPerc      float64 `json:"return_perc"`
+	MaxDrawdownPerc float64 `json:"max_drawdown_perc"`
+	PnL             float64 `json:"pnl"`
+	Proxy           string  `json:"proxy,omitempty"` // Тикер, история которого использована вместо отсутствующей
+	NoData          bool    `json:"no_data"`
+}
+
+// StressTestResult представляет результат стресс-теста портфеля
+type StressTestResult struct {
+	Portfolio       string           `json:"portfolio"`
+	Scenario        StressScenario   `json:"scenario"`
+	Positions       []PositionStress `json:"positions"`
+	TotalValue      float64          `json:"total_value"`
+	ReturnPerc      float64          `json:"return_perc"`
+	MaxDrawdownPerc float64          `json:"max_drawdown_perc"` // Взвешенная по стоимости оценка
+	PnL             float64          `json:"pnl"`
+}
+
+// PositionRisk показатели риска позиции портфеля за окно расчета
+type PositionRisk struct {
+	Ticker          string  `json:"ticker"`
+	Sector          string  `json:"sector,omitempty"`
+	Value           float64 `json:"value"`
+	WeightPerc      float64 `json:"weight_perc"` // Доля в стоимости портфеля, %
+	Beta            float64 `json:"beta"`        // Бета относительно IMOEX по дневным доходностям
+	HasBeta         bool    `json:"has_beta"`    // Бета определена: есть общие с индексом сессии и цена менялась
+	VolatilityPerc  float64 `json:"volatility_perc"`
+	ReturnPerc      float64 `json:"return_perc"`
+	MaxDrawdownPerc float64 `json:"max_drawdown_perc"`
+	NoHistory       bool    `json:"no_history"` // Истории котировок за окно недостаточно для расчета показателей
+}
+
+// SectorExposure доля сектора в стоимости портфеля
+type SectorExposure struct {
+	Sector     string   `json:"sector"`
+	Value      float64  `json:"value"`
+	WeightPerc float64  `json:"weight_perc"`
+	Tickers    []string `json:"tickers"`
+}
+
+// PortfolioRiskReview концентрация, бета и просадки текущего состава портфеля по сохраненной истории котировок
+type PortfolioRiskReview struct {
+	Portfolio  string           `json:"portfolio"`
+	WindowDays int              `json:"window_days"`
+	From       time.Time        `json:"from"`
+	To         time.Time        `json:"to"`
+	TotalValue float64          `json:"total_value"`
+	Positions  []PositionRisk   `json:"positions"` // По убыванию доли
+	Sectors    []SectorExposure `json:"sectors"`   // По убыванию доли; пусто, если профили компаний недоступны
+	HasIndex   bool             `json:"has_index"` // Есть ли история IMOEX для расчета беты
+	// Beta бета портфеля, взвешенная по стоимости позиций с определенной бетой; HasBeta — есть ли такие позиции
+	Beta    float64 `json:"beta"`
+	HasBeta bool    `json:"has_beta"`
+	// HHI индекс Херфиндаля–Хиршмана по долям позиций, от 0 до 10000: выше 2500 — высокая концентрация
+	HHI float64 `json:"hhi"`
+	// ReturnPerc и MaxDrawdownPerc доходность и максимальная просадка текущего состава портфеля за окно
+	ReturnPerc      float64 `json:"return_perc"`
+	MaxDrawdownPerc float64 `json:"max_drawdown_perc"`
+}
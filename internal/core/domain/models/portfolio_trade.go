@@ -0,0 +1,93 @@
+package models
+
+import (
+	"time"
+)
+
+// CommissionByTariff значение комиссии сделки, при котором она рассчитывается по тарифу брокера из конфигурации
+const CommissionByTariff = -1
+
+// PortfolioTrade сделка с бумагами портфеля. Сделки сохраняются при изменении позиций и служат историей
+// для расчета реализованного результата и налогов
+type PortfolioTrade struct {
+	ID         string    `json:"id" bson:"_id,omitempty"`
+	Portfolio  string    `json:"portfolio" bson:"portfolio"`
+	Ticker     string    `json:"ticker" bson:"ticker"`
+	Side       string    `json:"side" bson:"side"` // OrderSide*
+	Quantity   int64     `json:"quantity" bson:"quantity"`
+	Price      float64   `json:"price" bson:"price"`
+	Commission float64   `json:"commission" bson:"commission"`
+	ExecutedAt time.Time `json:"executed_at" bson:"executed_at"`
+	// Opening начальный остаток позиции, открытой до ведения истории сделок: настоящая дата покупки неизвестна
+	Opening bool `json:"opening,omitempty" bson:"opening,omitempty"`
+}
+
+// RealizedLot часть продажи, сопоставленная с одной покупкой по методу FIFO
+type RealizedLot struct {
+	Ticker    string    `json:"ticker"`
+	Quantity  int64     `json:"quantity"`
+	BoughtAt  time.Time `json:"bought_at"`
+	SoldAt    time.Time `json:"sold_at"`
+	BuyPrice  float64   `json:"buy_price"`
+	SellPrice float64   `json:"sell_price"`
+	Cost      float64   `json:"cost"`     // Стоимость покупки с комиссией
+	Proceeds  float64   `json:"proceeds"` // Выручка от продажи за вычетом комиссии
+	Gain      float64   `json:"gain"`
+	Opening   bool      `json:"opening,omitempty"` // Покупка — начальный остаток с неизвестной датой
+	// Unmatched продано больше, чем куплено по истории сделок: стоимость покупки неизвестна и принята нулевой
+	Unmatched bool `json:"unmatched,omitempty"`
+}
+
+// HoldingYears возвращает число полных лет владения бумагами лота
+func (l RealizedLot) HoldingYears() int {
+	return fullYears(l.BoughtAt, l.SoldAt)
+}
+
+// OpenLot покупка, еще не сопоставленная с продажами
+type OpenLot struct {
+	Ticker   string    `json:"ticker"`
+	Quantity int64     `json:"quantity"`
+	BoughtAt time.Time `json:"bought_at"`
+	Price    float64   `json:"price"`
+	Opening  bool      `json:"opening,omitempty"`
+}
+
+// TickerPnL реализованный результат по бумаге за период
+type TickerPnL struct {
+	Ticker   string  `json:"ticker"`
+	Quantity int64   `json:"quantity"` // Продано бумаг
+	Proceeds float64 `json:"proceeds"`
+	Cost     float64 `json:"cost"`
+	Gain     float64 `json:"gain"`
+	GainPerc float64 `json:"gain_perc"` // Результат в процентах от стоимости покупки
+}
+
+// RealizedPnL реализованный результат портфеля за период: продажи периода, сопоставленные с покупками по FIFO
+type RealizedPnL struct {
+	Portfolio  string      `json:"portfolio"`
+	From       time.Time   `json:"from"`
+	To         time.Time   `json:"to"`
+	Tickers    []TickerPnL `json:"tickers"` // По убыванию результата
+	Proceeds   float64     `json:"proceeds"`
+	Cost       float64     `json:"cost"`
+	Gain       float64     `json:"gain"`
+	GainPerc   float64     `json:"gain_perc"`
+	Commission float64     `json:"commission"` // Комиссии всех сделок периода, включая покупки
+	Winners    int         `json:"winners"`    // Бумаг с прибылью
+	Losers     int         `json:"losers"`     // Бумаг с убытком
+	// HasUnmatched есть продажи без покупок в истории; HasOpening — продажи начальных остатков
+	HasUnmatched bool `json:"has_unmatched"`
+	HasOpening   bool `json:"has_opening"`
+}
+
+// fullYears возвращает число полных лет между from и to
+func fullYears(from, to time.Time) int {
+	if !to.After(from) {
+		return 0
+	}
+	years := to.Year() - from.Year()
+	if from.AddDate(years, 0, 0).After(to) {
+		years--
+	}
+	return years
+}
@@ -0,0 +1,93 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	// PriceTargetSourceUser целевая цена, заданная пользователем через set_price_target
+	PriceTargetSourceUser = "user"
+	// PriceTargetSourceFeed целевая цена аналитика, загруженная из файла оператора
+	PriceTargetSourceFeed = "feed"
+)
+
+const (
+	// PriceTargetRatingBuy рекомендация покупать
+	PriceTargetRatingBuy = "buy"
+	// PriceTargetRatingHold рекомендация держать
+	PriceTargetRatingHold = "hold"
+	// PriceTargetRatingSell рекомендация продавать
+	PriceTargetRatingSell = "sell"
+)
+
+// PriceTargetRatings допустимые рекомендации аналитиков
+var PriceTargetRatings = []string{PriceTargetRatingBuy, PriceTargetRatingHold, PriceTargetRatingSell}
+
+// PriceTargetMaxAgeDays срок актуальности целевой цены: более старые цели показываются, но не входят в консенсус
+const PriceTargetMaxAgeDays = 365
+
+// MaxPriceTargetTickers максимальное количество бумаг в одном сравнении целевых цен с рынком
+const MaxPriceTargetTickers = 20
+
+// PriceTarget целевая цена бумаги от одного автора. Цель определяется тикером, источником и аналитиком:
+// у пользователя может быть собственная цель и цели, переписанные из обзоров брокеров
+type PriceTarget struct {
+	Ticker  string  `json:"ticker" bson:"ticker"`
+	Source  string  `json:"source" bson:"source"`   // user или feed
+	Analyst string  `json:"analyst" bson:"analyst"` // Автор прогноза; пусто — собственная цель пользователя
+	Target  float64 `json:"target" bson:"target"`
+	Rating  string  `json:"rating,omitempty" bson:"rating,omitempty"` // buy, hold или sell; пусто — не указана
+	Note    string  `json:"note,omitempty" bson:"note,omitempty"`
+	// PriceAtSet цена бумаги на момент установки цели; 0 — неизвестна
+	PriceAtSet float64   `json:"price_at_set,omitempty" bson:"price_at_set,omitempty"`
+	SetAt      time.Time `json:"set_at" bson:"set_at"`
+}
+
+// Stale сообщает, что цель устарела на момент now и не входит в консенсус
+func (t PriceTarget) Stale(now time.Time) bool {
+	return now.Sub(t.SetAt) > PriceTargetMaxAgeDays*24*time.Hour
+}
+
+// PriceTargetChange изменение целевой цены. Before равен nil для новой цели, After — для удаленной.
+// При DryRun изменение только рассчитано и не сохранено
+type PriceTargetChange struct {
+	Before *PriceTarget `json:"before"`
+	After  *PriceTarget `json:"after"`
+	DryRun bool         `json:"dry_run"`
+}
+
+// PriceTargetComparison целевые цены бумаги и консенсус в сравнении с текущей котировкой
+type PriceTargetComparison struct {
+	Ticker  string        `json:"ticker"`
+	Name    string        `json:"name,omitempty"`
+	Price   float64       `json:"price"`
+	NoQuote bool          `json:"no_quote"` // Котировку получить не удалось, потенциал не рассчитан
+	Targets []PriceTarget `json:"targets"`  // От новых к старым
+
+	// Консенсус по актуальным целям; не заполняется, если актуальных целей нет
+	Consensus  float64 `json:"consensus"`
+	Median     float64 `json:"median"`
+	Low        float64 `json:"low"`
+	High       float64 `json:"high"`
+	Fresh      int     `json:"fresh"` // Количество актуальных целей
+	UpsidePerc float64 `json:"upside_perc"`
+
+	Buy  int `json:"buy"`
+	Hold int `json:"hold"`
+	Sell int `json:"sell"`
+}
+
+// UpsideTo возвращает потенциал роста (положительный) или снижения (отрицательный) до цены target, %
+func (c PriceTargetComparison) UpsideTo(target float64) float64 {
+	if c.Price <= 0 {
+		return 0
+	}
+	return (target - c.Price) / c.Price * 100
+}
+
+// PriceTargetsIngestResult итог загрузки целевых цен из источников
+type PriceTargetsIngestResult struct {
+	Fetched int      `json:"fetched"`
+	Saved   int      `json:"saved"`
+	Failed  []string `json:"failed,omitempty"` // Источники, загрузка из которых не удалась
+}
@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// RawReparse итог повторного разбора архива необработанных ответов внешних API
+type RawReparse struct {
+	From        time.Time `json:"from"`
+	To          time.Time `json:"to"`
+	Payloads    int       `json:"payloads"`    // Прочитано ответов из архива
+	Parsed      int       `json:"parsed"`      // Разобрано успешно
+	Unsupported int       `json:"unsupported"` // Ответы ресурсов без повторного разбора
+	News        int       `json:"news"`        // Сохранено уникальных новостей
+	Stocks      int       `json:"stocks"`      // Обновлено котировок
+	Errors      []string  `json:"errors"`      // Ошибки разбора отдельных ответов
+}
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"time"
+)
+
+// UnclassifiedSector сектор бумаг, не входящих ни в один отраслевой индекс
+const UnclassifiedSector = "Без сектора"
+
+// SectorPerformance сводная динамика акций одного сектора за день
+type SectorPerformance struct {
+	Sector        string  `json:"sector"`
+	Stocks        int     `json:"stocks"`
+	Advancers     int     `json:"advancers"`
+	Decliners     int     `json:"decliners"`
+	AvgChangePerc float64 `json:"avg_change_perc"` // Среднее изменение без учета капитализации
+	// CapWeightedChangePerc изменение, взвешенное по капитализации; совпадает со средним, если капитализация неизвестна
+	CapWeightedChangePerc float64 `json:"cap_weighted_change_perc"`
+	TotalVolume           int64   `json:"total_volume"`   // Суммарный объем торгов, акций
+	TurnoverRub           float64 `json:"turnover_rub"`   // Оценка оборота по текущей цене, ₽
+	MarketCapRub          float64 `json:"market_cap_rub"` // Суммарная капитализация, ₽
+	Leader                string  `json:"leader"`         // Тикер с наибольшим ростом
+	LeaderChangePerc      float64 `json:"leader_change_perc"`
+	Laggard               string  `json:"laggard"` // Тикер с наибольшим падением
+	LaggardChangePerc     float64 `json:"laggard_change_perc"`
+	// IndexWeightPerc доля сектора в индексе и IndexWeightedChangePerc изменение, взвешенное по весам
+	// бумаг в индексе; заполняются для универсума индекса
+	IndexWeightPerc         float64 `json:"index_weight_perc,omitempty"`
+	IndexWeightedChangePerc float64 `json:"index_weighted_change_perc,omitempty"`
+}
+
+// SectorReport динамика секторов универсума за день
+type SectorReport struct {
+	Universe  string              `json:"universe"`
+	Index     string              `json:"index,omitempty"` // Код индекса для универсума индекса
+	Sectors   []SectorPerformance `json:"sectors"`         // По убыванию взвешенного изменения
+	UpdatedAt time.Time           `json:"updated_at"`
+}
+
+// SectorStock акция сектора с данными профиля компании
+type SectorStock struct {
+	Stock
+	Industry     string  `json:"industry"`
+	MarketCapRub float64 `json:"market_cap_rub"`
+	IndexWeight  float64 `json:"index_weight,omitempty"` // Вес в индексе для универсума индекса, %
+}
+
+// SectorStocks акции сектора и их сводная динамика
+type SectorStocks struct {
+	Universe    string            `json:"universe"`
+	Index       string            `json:"index,omitempty"` // Код индекса для универсума индекса
+	Performance SectorPerformance `json:"performance"`
+	Stocks      []SectorStock     `json:"stocks"` // По убыванию изменения цены
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Security бумага из справочника ISS: акция, депозитарная расписка или паевой фонд фондового рынка MOEX
+type Security struct {
+	Ticker    string   `json:"ticker" bson:"_id"` // SECID
+	ShortName string   `json:"short_name" bson:"short_name"`
+	Name      string   `json:"name" bson:"name"`         // Полное название (SECNAME)
+	LatName   string   `json:"lat_name" bson:"lat_name"` // Название латиницей
+	ISIN      string   `json:"isin" bson:"isin"`
+	Board     string   `json:"board" bson:"board"`                       // Основной режим торгов
+	Boards    []string `json:"boards,omitempty" bson:"boards,omitempty"` // Все режимы торгов бумаги
+	LotSize   int      `json:"lot_size,omitempty" bson:"lot_size,omitempty"`
+	MinStep   float64  `json:"min_step,omitempty" bson:"min_step,omitempty"`     // Шаг цены в основном режиме торгов
+	Decimals  int      `json:"decimals,omitempty" bson:"decimals,omitempty"`     // Знаков после запятой в цене
+	ListLevel int      `json:"list_level,omitempty" bson:"list_level,omitempty"` // Уровень листинга: 1–3, 0 — неизвестен
+}
+
+// SecuritySnapshot сохраненный справочник бумаг
+type SecuritySnapshot struct {
+	UpdatedAt  time.Time  `json:"updated_at"`
+	Securities []Security `json:"securities"`
+}
+
+// MinSecuritySearchScore минимальная доля триграмм запроса, которые должны найтись в тикере или названии бумаги
+const MinSecuritySearchScore = 0.5
+
+// isinPattern ISIN: код страны, девять букв или цифр и контрольная цифра
+var isinPattern = regexp.MustCompile(`^[A-Z]{2}[A-Z0-9]{9}[0-9]$`)
+
+// IsISIN сообщает, похожа ли строка на ISIN, например RU0009029540
+func IsISIN(value string) bool {
+	return isinPattern.MatchString(strings.ToUpper(strings.TrimSpace(value)))
+}
+
+// figiPattern FIGI: префикс BBG, восемь согласных латинских букв или цифр и контрольная цифра
+var figiPattern = regexp.MustCompile(`^BBG[0-9BCDFGHJKLMNPQRSTVWXYZ]{8}[0-9]$`)
+
+// IsFIGI сообщает, похожа ли строка на FIGI, например BBG004730N88
+func IsFIGI(value string) bool {
+	return figiPattern.MatchString(strings.ToUpper(strings.TrimSpace(value)))
+}
+
+// FIGIMapping бумага, которой OpenFIGI сопоставляет FIGI
+type FIGIMapping struct {
+	FIGI     string `json:"figi"`
+	Ticker   string `json:"ticker"`
+	ExchCode string `json:"exch_code"` // Код биржи Bloomberg, например RX для MOEX
+	Name     string `json:"name"`
+}
+
+// moexExchCodes коды бирж Bloomberg для листингов MOEX
+var moexExchCodes = map[string]bool{"RX": true, "RM": true}
+
+// IsMOEXExchCode сообщает, относится ли код биржи Bloomberg к MOEX
+func IsMOEXExchCode(code string) bool {
+	return moexExchCodes[strings.ToUpper(strings.TrimSpace(code))]
+}
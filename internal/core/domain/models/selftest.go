@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// SourceCheck результат проверки одного внешнего источника данных
+type SourceCheck struct {
+	Source   string        `json:"source"`   // Источник: MOEX, NewsAPI
+	Request  string        `json:"request"`  // Описание контрольного запроса
+	Passed   bool          `json:"passed"`   // Запрос выполнен и разбор дал непустые поля
+	Duration time.Duration `json:"duration"` // Время выполнения запроса
+	Error    string        `json:"error,omitempty"`
+	Problems []string      `json:"problems,omitempty"` // Поля, оставшиеся пустыми после разбора ответа
+	Summary  string        `json:"summary,omitempty"`  // Краткая выжимка полученных данных
+}
+
+// SelfTestReport итог самопроверки источников данных
+type SelfTestReport struct {
+	StartedAt time.Time     `json:"started_at"`
+	Checks    []SourceCheck `json:"checks"`
+}
+
+// Passed сообщает, что все проверки пройдены
+func (r *SelfTestReport) Passed() bool {
+	for _, check := range r.Checks {
+		if !check.Passed {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// CacheStats статистика обращений к ключам кэша с общим префиксом
+type CacheStats struct {
+	Prefix     string        `json:"prefix"` // Префикс ключей: stock, news, moex и т.д.
+	Hits       int64         `json:"hits"`
+	Misses     int64         `json:"misses"`
+	Sets       int64         `json:"sets"`
+	Deletes    int64         `json:"deletes"`
+	Errors     int64         `json:"errors"`
+	Calls      int64         `json:"calls"`       // Число всех обращений
+	HitRate    float64       `json:"hit_rate"`    // Доля попаданий среди чтений, от 0 до 1
+	AvgLatency time.Duration `json:"avg_latency"` // Среднее время обращения
+}
+
+// ServerStats статистика работы сервера с момента запуска
+type ServerStats struct {
+	StartedAt  time.Time     `json:"started_at"`
+	Uptime     time.Duration `json:"uptime"`
+	Goroutines int           `json:"goroutines"`
+	HeapAlloc  uint64        `json:"heap_alloc"` // Занятая куча, байт
+	Cache      []CacheStats  `json:"cache"`
+}
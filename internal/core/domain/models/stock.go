@@ -4,6 +4,62 @@ import (
 	"time"
 )
 
+// Текущие версии схемы документов акций и котировок в хранилище.
+// Версия увеличивается при изменении формата, документы старых версий обновляются при чтении
+const (
+	StockSchemaVersion      = 1
+	StockQuoteSchemaVersion = 2
+)
+
+// Интервалы свечей котировок
+const (
+	IntervalMinute    = "1m"
+	IntervalTenMinute = "10m"
+	IntervalHour      = "1h"
+	IntervalDay       = "1d"
+)
+
+// QuoteInterval описывает интервал свечей и его ограничения
+type QuoteInterval struct {
+	Name     string
+	MOEXCode int           // Значение параметра interval запроса свечей MOEX ISS
+	MaxRange time.Duration // Максимальный период одного запроса истории, чтобы ответ оставался обозримым
+}
+
+// QuoteIntervals поддерживаемые интервалы свечей, от меньшего к большему
+var QuoteIntervals = []QuoteInterval{
+	{Name: IntervalMinute, MOEXCode: 1, MaxRange: 24 * time.Hour},
+	{Name: IntervalTenMinute, MOEXCode: 10, MaxRange: 7 * 24 * time.Hour},
+	{Name: IntervalHour, MOEXCode: 60, MaxRange: 31 * 24 * time.Hour},
+	{Name: IntervalDay, MOEXCode: 24, MaxRange: 5 * 365 * 24 * time.Hour},
+}
+
+// FindQuoteInterval возвращает описание интервала по названию; пустое название означает дневные свечи
+func FindQuoteInterval(name string) (QuoteInterval, bool) {
+	if name == "" {
+		name = IntervalDay
+	}
+	for _, interval := range QuoteIntervals {
+		if interval.Name == name {
+			return interval, true
+		}
+	}
+	return QuoteInterval{}, false
+}
+
+// IsIntraday проверяет, что интервал меньше торгового дня
+func IsIntraday(interval string) bool {
+	return interval != "" && interval != IntervalDay
+}
+
+// MoscowLocation часовой пояс Московской биржи, в котором ISS возвращает время
+var MoscowLocation = func() *time.Location {
+	if loc, err := time.LoadLocation("Europe/Moscow"); err == nil {
+		return loc
+	}
+	return time.FixedZone("MSK", 3*60*60)
+}()
+
 // Stock представляет собой информацию об акции
 type Stock struct {
 	Ticker     string    `json:"ticker" bson:"ticker"`
@@ -12,12 +68,17 @@ type Stock struct {
 	Change     float64   `json:"change" bson:"change"`
 	ChangePerc float64   `json:"change_perc" bson:"change_perc"`
 	Volume     int64     `json:"volume" bson:"volume"`
+	Board      string    `json:"board,omitempty" bson:"board,omitempty"` // Режим торгов, в котором получена котировка
 	UpdatedAt  time.Time `json:"updated_at" bson:"updated_at"`
+
+	SchemaVersion int `json:"-" bson:"schema_version"`
 }
 
-// StockQuote представляет котировки акции
+// StockQuote представляет котировки акции: дневную или внутридневную свечу.
+// Свеча идентифицируется тикером, интервалом и датой (для внутридневных — временем начала)
 type StockQuote struct {
 	Ticker         string    `json:"ticker" bson:"ticker"`
+	Interval       string    `json:"interval" bson:"interval"` // Интервал свечи; пустой у дневных котировок, сохраненных до появления поля
 	Open           float64   `json:"open" bson:"open"`
 	High           float64   `json:"high" bson:"high"`
 	Low            float64   `json:"low" bson:"low"`
@@ -29,4 +90,6 @@ type StockQuote struct {
 	DividendYield  float64   `json:"dividend_yield" bson:"dividend_yield"`
 	Sector         string    `json:"sector" bson:"sector"`
 	TradingSession string    `json:"trading_session" bson:"trading_session"`
+
+	SchemaVersion int `json:"-" bson:"schema_version"`
 }
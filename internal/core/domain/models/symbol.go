@@ -0,0 +1,27 @@
+package models
+
+// Способы, которыми введенная пользователем строка сопоставлена тикеру
+const (
+	SymbolMatchExact = "exact" // Тикер, с точностью до регистра
+	SymbolMatchAlias = "alias" // Название компании или известное сокращение
+	SymbolMatchFuzzy = "fuzzy" // Ближайшее по написанию название или тикер
+	SymbolMatchISIN  = "isin"  // ISIN из справочника бумаг
+	SymbolMatchFIGI  = "figi"  // FIGI, сопоставленный тикеру через OpenFIGI
+)
+
+// MaxSymbolCandidates максимальное количество вариантов, предлагаемых при неоднозначном совпадении
+const MaxSymbolCandidates = 5
+
+// SymbolResolution результат сопоставления введенной строки тикеру бумаги (SECID).
+// Если бумага не найдена, Ticker пуст; при неоднозначном совпадении в Candidates перечислены подходящие тикеры
+type SymbolResolution struct {
+	Input      string   `json:"input"`
+	Ticker     string   `json:"ticker,omitempty"`
+	Match      string   `json:"match,omitempty"`
+	Candidates []string `json:"candidates,omitempty"`
+}
+
+// Resolved сообщает, что строка однозначно сопоставлена тикеру
+func (r SymbolResolution) Resolved() bool {
+	return r.Ticker != ""
+}
@@ -0,0 +1,113 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	// NDFLRatePerc основная ставка НДФЛ для доходов от операций с ценными бумагами и дивидендов, %
+	NDFLRatePerc = 13
+	// NDFLHighRatePerc повышенная ставка НДФЛ с дохода сверх порога NDFLThreshold, %
+	NDFLHighRatePerc = 15
+
+	// LongTermHoldingYears срок владения, после которого доход от продажи бумаг освобождается от НДФЛ (ЛДВ)
+	LongTermHoldingYears = 3
+	// LongTermExemptionPerYear предел освобождаемого дохода за каждый полный год владения, ₽
+	LongTermExemptionPerYear = 3_000_000
+)
+
+// longTermSince льгота долгосрочного владения распространяется на бумаги, купленные с этой даты
+var longTermSince = time.Date(2014, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// NDFLThreshold возвращает порог годового дохода от инвестиций, сверх которого НДФЛ взимается по повышенной
+// ставке: 5 млн ₽ в 2021–2024 годах и 2,4 млн ₽ с 2025 года. До 2021 года шкала была плоской, порог 0
+func NDFLThreshold(year int) float64 {
+	switch {
+	case year < 2021:
+		return 0
+	case year < 2025:
+		return 5_000_000
+	default:
+		return 2_400_000
+	}
+}
+
+// NDFL рассчитывает налог на доход base, полученный в году year, по прогрессивной шкале
+func NDFL(base float64, year int) float64 {
+	if base <= 0 {
+		return 0
+	}
+
+	threshold := NDFLThreshold(year)
+	if threshold == 0 || base <= threshold {
+		return base * NDFLRatePerc / 100
+	}
+	return threshold*NDFLRatePerc/100 + (base-threshold)*NDFLHighRatePerc/100
+}
+
+// LongTermEligible сообщает, подпадает ли продажа лота под льготу долгосрочного владения:
+// бумаги куплены не раньше 2014 года и принадлежали продавцу больше LongTermHoldingYears лет
+func LongTermEligible(lot RealizedLot) bool {
+	if lot.Opening || lot.Unmatched || lot.BoughtAt.Before(longTermSince) {
+		return false
+	}
+	return lot.HoldingYears() >= LongTermHoldingYears
+}
+
+// LongTermFrom возвращает дату, с которой продажа открытого лота подпадает под льготу долгосрочного владения;
+// нулевое время, если лот не может получить льготу
+func LongTermFrom(lot OpenLot) time.Time {
+	if lot.Opening || lot.BoughtAt.Before(longTermSince) {
+		return time.Time{}
+	}
+	return lot.BoughtAt.AddDate(LongTermHoldingYears, 0, 0)
+}
+
+// DividendIncome дивиденды по бумаге, причитающиеся за акции, которыми владели на дату закрытия реестра
+type DividendIncome struct {
+	Ticker     string    `json:"ticker"`
+	RecordDate time.Time `json:"record_date"`
+	Quantity   int64     `json:"quantity"`
+	PerShare   float64   `json:"per_share"`
+	Amount     float64   `json:"amount"`
+}
+
+// LongTermLot открытый лот, продажа которого подпадает или будет подпадать под льготу долгосрочного владения
+type LongTermLot struct {
+	OpenLot
+	EligibleFrom time.Time `json:"eligible_from"`
+	Eligible     bool      `json:"eligible"` // Срок владения уже больше LongTermHoldingYears лет
+}
+
+// TaxEstimate оценка НДФЛ по портфелю за календарный год: с результата продаж по FIFO с учетом льготы
+// долгосрочного владения и с дивидендов. Налог с дивидендов удерживает налоговый агент, с продаж — брокер
+type TaxEstimate struct {
+	Portfolio string        `json:"portfolio"`
+	Year      int           `json:"year"`
+	Lots      []RealizedLot `json:"lots"` // Продажи года, сопоставленные с покупками
+
+	Proceeds float64 `json:"proceeds"`
+	Cost     float64 `json:"cost"`
+	Gain     float64 `json:"gain"` // Финансовый результат продаж: прибыль за вычетом убытков
+
+	// LongTermGain результат продаж бумаг, которыми владели дольше трех лет; LongTermLimit — предел льготы
+	// Кцб × 3 млн ₽, где Кцб — средневзвешенное по выручке число полных лет владения
+	LongTermGain      float64 `json:"long_term_gain"`
+	LongTermLimit     float64 `json:"long_term_limit"`
+	LongTermExemption float64 `json:"long_term_exemption"` // Освобожденный от налога доход
+
+	TradingBase float64 `json:"trading_base"`
+	TradingTax  float64 `json:"trading_tax"`
+
+	Dividends      []DividendIncome `json:"dividends"`
+	DividendIncome float64          `json:"dividend_income"`
+	DividendTax    float64          `json:"dividend_tax"`
+	// DividendsKnown календарь дивидендов доступен; без него дивиденды не учитываются
+	DividendsKnown bool `json:"dividends_known"`
+
+	TotalTax float64 `json:"total_tax"`
+
+	LongTermLots []LongTermLot `json:"long_term_lots"` // Открытые лоты, к которым применима льгота, по дате ее начала
+	HasUnmatched bool          `json:"has_unmatched"`  // Есть продажи без покупок в истории сделок
+	HasOpening   bool          `json:"has_opening"`    // Есть продажи начальных остатков с неизвестной датой покупки
+}
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	// TechnicalLookbackDays максимальная глубина истории для расчета индикаторов, дней;
+	// для внутридневных свечей глубина дополнительно ограничена QuoteInterval.MaxRange
+	TechnicalLookbackDays = 400
+	// TechnicalMinCandles минимальное количество свечей для расчета индикаторов: MACD(12, 26, 9) требует 34 свечи
+	TechnicalMinCandles = 34
+	// TechnicalRecentCandles количество последних свечей, передаваемых вместе с индикаторами
+	TechnicalRecentCandles = 10
+)
+
+// TechnicalIndicators технические индикаторы акции, рассчитанные по сохраненным свечам.
+// Нулевое значение скользящей средней означает, что свечей для ее расчета недостаточно
+type TechnicalIndicators struct {
+	Ticker   string    `json:"ticker"`
+	Interval string    `json:"interval"`
+	From     time.Time `json:"from"` // Время первой свечи
+	To       time.Time `json:"to"`   // Время последней свечи
+	Candles  int       `json:"candles"`
+
+	LastClose  float64 `json:"last_close"`
+	PeriodHigh float64 `json:"period_high"`
+	PeriodLow  float64 `json:"period_low"`
+
+	SMA20  float64 `json:"sma20"`
+	SMA50  float64 `json:"sma50"`
+	SMA200 float64 `json:"sma200"`
+	RSI14  float64 `json:"rsi14"`
+
+	MACD          float64 `json:"macd"`           // EMA12 − EMA26
+	MACDSignal    float64 `json:"macd_signal"`    // EMA9 от MACD
+	MACDHistogram float64 `json:"macd_histogram"` // MACD − сигнальная линия
+
+	Supports    []float64 `json:"supports"`    // Уровни поддержки ниже последней цены, от ближайшего
+	Resistances []float64 `json:"resistances"` // Уровни сопротивления выше последней цены, от ближайшего
+
+	RecentCandles []StockQuote `json:"recent_candles"` // Последние TechnicalRecentCandles свечей
+}
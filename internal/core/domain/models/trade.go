@@ -0,0 +1,62 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	// DefaultRecentTrades количество последних сделок по умолчанию
+	DefaultRecentTrades = 50
+	// MaxRecentTrades максимальное количество последних сделок в одном запросе
+	MaxRecentTrades = 5000
+	// TradesAggregationThreshold количество сделок, начиная с которого лента сворачивается в минутные интервалы
+	TradesAggregationThreshold = 200
+)
+
+const (
+	// TradeSideBuy сделка по инициативе покупателя
+	TradeSideBuy = "buy"
+	// TradeSideSell сделка по инициативе продавца
+	TradeSideSell = "sell"
+)
+
+// Trade сделка из ленты сделок основного режима торгов
+type Trade struct {
+	TradeNo  int64     `json:"trade_no"`
+	Time     time.Time `json:"time"`
+	Price    float64   `json:"price"`
+	Quantity int64     `json:"quantity"` // Объем сделки, лотов
+	Value    float64   `json:"value"`    // Объем сделки, ₽
+	Side     string    `json:"side"`     // Направление: buy или sell; пусто, если ISS его не отдал
+}
+
+// TradeMinute сделки одной минуты, свернутые в свечу с разбивкой объема по направлению
+type TradeMinute struct {
+	Minute       time.Time `json:"minute"`
+	Open         float64   `json:"open"`
+	High         float64   `json:"high"`
+	Low          float64   `json:"low"`
+	Close        float64   `json:"close"`
+	Trades       int       `json:"trades"`
+	Quantity     int64     `json:"quantity"`
+	BuyQuantity  int64     `json:"buy_quantity"`
+	SellQuantity int64     `json:"sell_quantity"`
+	Value        float64   `json:"value"`
+	VWAP         float64   `json:"vwap"`
+}
+
+// RecentTrades последние сделки по бумаге в хронологическом порядке.
+// При большом количестве сделок лента дополнительно сворачивается по минутам
+type RecentTrades struct {
+	Ticker     string        `json:"ticker"`
+	Trades     []Trade       `json:"trades"`
+	Minutes    []TradeMinute `json:"minutes,omitempty"`
+	Aggregated bool          `json:"aggregated"`
+	From       time.Time     `json:"from"`
+	To         time.Time     `json:"to"`
+
+	BuyQuantity  int64   `json:"buy_quantity"`
+	SellQuantity int64   `json:"sell_quantity"`
+	TotalValue   float64 `json:"total_value"`
+	VWAP         float64 `json:"vwap"` // Средневзвешенная по объему цена сделок
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+)
+
+// UniverseFull имя универсума, включающего весь рынок
+const UniverseFull = "full"
+
+// Universe представляет именованный торговый универсум
+type Universe struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Tickers     []string `json:"tickers"` // Пустой список означает весь рынок или состав индекса
+	// Index код индекса, состав которого образует универсум; тикеры тогда берутся из загруженного состава
+	Index string `json:"index,omitempty"`
+}
+
+// MarketBreadth представляет статистику ширины рынка по универсуму
+type MarketBreadth struct {
+	Universe      string  `json:"universe"`
+	Total         int     `json:"total"`
+	Advancers     int     `json:"advancers"`
+	Decliners     int     `json:"decliners"`
+	Unchanged     int     `json:"unchanged"`
+	AvgChangePerc float64 `json:"avg_change_perc"`
+	TotalVolume   int64   `json:"total_volume"`
+	// Index и WeightedChangePerc заполняются для универсума индекса: изменение, взвешенное по весам бумаг в индексе
+	Index              string    `json:"index,omitempty"`
+	WeightedChangePerc float64   `json:"weighted_change_perc,omitempty"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
+
+// Виды выборки, по которой строится обзор рынка
+const (
+	OverviewScopeUniverse = "universe" // Универсум из конфигурации
+	OverviewScopeSector   = "sector"   // Сектор всего рынка по профилям компаний
+	OverviewScopeTickers  = "tickers"  // Явный список тикеров, например список наблюдения
+)
+
+// DefaultOverviewLeaders количество лидеров роста и падения в обзоре рынка по умолчанию
+const DefaultOverviewLeaders = 5
+
+// MarketOverview представляет ширину рынка и лидеров движения по выборке акций
+type MarketOverview struct {
+	Scope   string        `json:"scope"`
+	Name    string        `json:"name"` // Имя универсума, сектора или списка
+	Breadth MarketBreadth `json:"breadth"`
+	Gainers []Stock       `json:"gainers"`
+	Losers  []Stock       `json:"losers"`
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	// VolumeLookbackSessions количество предыдущих торговых сессий, по которым считается средний объем
+	VolumeLookbackSessions = 30
+	// VolumeMinSessions наименьшее количество сессий истории, при котором средний объем считается надежным
+	VolumeMinSessions = 10
+	// DefaultVolumeAnomalyRatio порог аномалии по умолчанию: объем за день во столько раз выше среднего
+	DefaultVolumeAnomalyRatio = 2.0
+	// DefaultVolumeAnomalyLimit количество аномалий в ответе по умолчанию
+	DefaultVolumeAnomalyLimit = 10
+	// MaxVolumeAnomalyLimit максимальное количество аномалий в ответе
+	MaxVolumeAnomalyLimit = 50
+	// DigestVolumeLimit количество бумаг с аномальным объемом в дайджесте
+	DigestVolumeLimit = 5
+)
+
+// VolumeAnomaly бумага, объем торгов которой за день заметно выше среднего
+type VolumeAnomaly struct {
+	Ticker        string  `json:"ticker"`
+	Name          string  `json:"name"`
+	Price         float64 `json:"price"`
+	ChangePerc    float64 `json:"change_perc"`
+	Volume        int64   `json:"volume"`         // Объем за текущую (последнюю) сессию
+	AverageVolume float64 `json:"average_volume"` // Средний объем за предыдущие сессии
+	Sessions      int     `json:"sessions"`       // Количество сессий в среднем
+	Ratio         float64 `json:"ratio"`          // Отношение объема к среднему
+}
+
+// VolumeAnomalyReport результат поиска аномальных объемов по универсуму
+type VolumeAnomalyReport struct {
+	Universe  string          `json:"universe"`
+	MinRatio  float64         `json:"min_ratio"`
+	Scanned   int             `json:"scanned"`            // Бумаги с достаточной историей объемов
+	Skipped   int             `json:"skipped"`            // Бумаги без истории или с историей короче VolumeMinSessions сессий
+	Backfill  []string        `json:"backfill,omitempty"` // Пропущенные бумаги, историю которых нужно загрузить инструментом backfill_history
+	Total     int             `json:"total"`              // Все найденные аномалии; в Anomalies — не более limit с наибольшим отношением
+	Anomalies []VolumeAnomaly `json:"anomalies"`
+	CheckedAt time.Time       `json:"checked_at"`
+}
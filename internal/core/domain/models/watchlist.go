@@ -0,0 +1,76 @@
+package models
+
+import (
+	"time"
+)
+
+// DefaultWatchlist имя списка наблюдения, используемого, если имя не указано
+const DefaultWatchlist = "default"
+
+// WatchlistEntry представляет бумагу в списке наблюдения вместе с порогом уведомления
+type WatchlistEntry struct {
+	Watchlist string `json:"watchlist" bson:"watchlist"`
+	Ticker    string `json:"ticker" bson:"ticker"`
+	// ThresholdPerc порог изменения цены за день в процентах, при достижении которого
+	// отправляется уведомление; 0 — порог по умолчанию из конфигурации
+	ThresholdPerc float64   `json:"threshold_perc" bson:"threshold_perc"`
+	AddedAt       time.Time `json:"added_at" bson:"added_at"`
+	AddedPrice    float64   `json:"added_price" bson:"added_price"`
+	// LastAlertAt время последнего уведомления; уведомление отправляется не чаще раза в день
+	LastAlertAt time.Time `json:"last_alert_at,omitempty" bson:"last_alert_at,omitempty"`
+}
+
+// WatchlistChange изменение бумаги списка наблюдения. Before равен nil для новой бумаги, After — для удаленной.
+// При DryRun изменение только рассчитано и не сохранено
+type WatchlistChange struct {
+	Before *WatchlistEntry `json:"before"`
+	After  *WatchlistEntry `json:"after"`
+	DryRun bool            `json:"dry_run"`
+}
+
+// WatchlistItem представляет бумагу списка наблюдения с текущей котировкой
+type WatchlistItem struct {
+	WatchlistEntry
+	Price                  float64 `json:"price"`
+	ChangePerc             float64 `json:"change_perc"`
+	EffectiveThresholdPerc float64 `json:"effective_threshold_perc"` // Действующий порог с учетом значения по умолчанию
+}
+
+// WatchlistAlert уведомление о движении цены, превысившем порог бумаги из списка наблюдения
+type WatchlistAlert struct {
+	Watchlist     string    `json:"watchlist" bson:"watchlist"`
+	Ticker        string    `json:"ticker" bson:"ticker"`
+	Price         float64   `json:"price" bson:"price"`
+	ChangePerc    float64   `json:"change_perc" bson:"change_perc"`
+	ThresholdPerc float64   `json:"threshold_perc" bson:"threshold_perc"`
+	TriggeredAt   time.Time `json:"triggered_at" bson:"triggered_at"`
+}
+
+// PerformancePeriods периоды отчета о доходности списка наблюдения
+var PerformancePeriods = []string{"1w", "1m", "3m", "6m", "ytd", "1y"}
+
+// TickerPerformance доходность бумаги списка наблюдения за период
+type TickerPerformance struct {
+	Ticker          string  `json:"ticker"`
+	StartPrice      float64 `json:"start_price"`
+	EndPrice        float64 `json:"end_price"`
+	ReturnPerc      float64 `json:"return_perc"`
+	MaxDrawdownPerc float64 `json:"max_drawdown_perc"`
+	ExcessPerc      float64 `json:"excess_perc"` // Разница с доходностью бенчмарка, п.п.
+	NoData          bool    `json:"no_data"`
+}
+
+// WatchlistPerformance отчет о доходности списка наблюдения за период
+type WatchlistPerformance struct {
+	Watchlist        string              `json:"watchlist"`
+	Period           string              `json:"period"`
+	Start            time.Time           `json:"start"`
+	End              time.Time           `json:"end"`
+	Tickers          []TickerPerformance `json:"tickers"`
+	BasketReturnPerc float64             `json:"basket_return_perc"` // Равновзвешенная корзина бумаг с историей
+	BasketSize       int                 `json:"basket_size"`
+	Benchmark        string              `json:"benchmark"`
+	// BenchmarkReturnPerc доходность бенчмарка; не заполняется, если его истории нет
+	BenchmarkReturnPerc float64 `json:"benchmark_return_perc"`
+	BenchmarkNoData     bool    `json:"benchmark_no_data"`
+}
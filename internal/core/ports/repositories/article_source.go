@@ -0,0 +1,13 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// ArticleSource определяет источник полного текста статей по адресам страниц новостей
+type ArticleSource interface {
+	// FetchArticle загружает страницу и извлекает из нее заголовок и текст статьи
+	FetchArticle(ctx context.Context, url string) (*models.Article, error)
+}
@@ -0,0 +1,20 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// CBRSource определяет источник ставок и официальных курсов валют Банка России
+type CBRSource interface {
+	// GetKeyRates возвращает значения ключевой ставки за период в хронологическом порядке
+	GetKeyRates(ctx context.Context, from, till time.Time) ([]models.KeyRate, error)
+
+	// GetRUONIA возвращает значения ставки RUONIA за период в хронологическом порядке
+	GetRUONIA(ctx context.Context, from, till time.Time) ([]models.RUONIARate, error)
+
+	// GetOfficialFXRates возвращает официальные курсы всех валют на дату
+	GetOfficialFXRates(ctx context.Context, date time.Time) ([]models.OfficialFXRate, error)
+}
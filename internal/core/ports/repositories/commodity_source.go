@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// CommoditySource определяет источник котировок фьючерсов на сырьевые товары и курса доллара
+type CommoditySource interface {
+	// GetFrontFutures возвращает наиболее ликвидный из неистекших фьючерсов на базовый актив assetCode
+	GetFrontFutures(ctx context.Context, assetCode string) (*models.FuturesQuote, error)
+
+	// GetUSDRUB возвращает текущий курс доллара к рублю
+	GetUSDRUB(ctx context.Context) (float64, error)
+}
@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// CompanyProfileRepository определяет интерфейс для работы с профилями компаний
+type CompanyProfileRepository interface {
+	// GetCompanyProfile возвращает профиль компании по тикеру; устаревший профиль обновляется из MOEX
+	GetCompanyProfile(ctx context.Context, ticker string) (*models.CompanyProfile, error)
+
+	// GetCompanyProfiles возвращает профили нескольких компаний; недоступные профили пропускаются
+	GetCompanyProfiles(ctx context.Context, tickers []string) ([]models.CompanyProfile, error)
+}
@@ -0,0 +1,20 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// CorporateEventRepository определяет интерфейс для хранения корпоративных событий
+type CorporateEventRepository interface {
+	// SaveEvents сохраняет события, заменяя ранее сохраненные с теми же тикером, типом, источником
+	// и идентификатором в источнике
+	SaveEvents(ctx context.Context, events []models.CorporateEvent) (int, error)
+
+	// DeleteMissingEvents удаляет события источника source, которых нет среди events, и возвращает их число
+	DeleteMissingEvents(ctx context.Context, source string, events []models.CorporateEvent) (int, error)
+
+	// GetEvents возвращает события, подходящие под фильтр, по возрастанию даты
+	GetEvents(ctx context.Context, filter models.CorporateEventsFilter) ([]models.CorporateEvent, error)
+}
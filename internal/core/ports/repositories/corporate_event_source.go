@@ -0,0 +1,25 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// CorporateEventSource определяет источник корпоративных событий для загрузки в календарь
+type CorporateEventSource interface {
+	// Name возвращает название источника для журнала загрузки
+	Name() string
+
+	// GetCorporateEvents возвращает события эмитентов tickers; источник может вернуть и события других эмитентов
+	GetCorporateEvents(ctx context.Context, tickers []string) ([]models.CorporateEvent, error)
+}
+
+// SnapshotCorporateEventSource определяет источник, который каждый раз возвращает все свои события,
+// например файл календаря. События источника, пропавшие из очередной выгрузки, удаляются из календаря
+type SnapshotCorporateEventSource interface {
+	CorporateEventSource
+
+	// EventSource возвращает код источника, которым помечены его события (models.EventSource*)
+	EventSource() string
+}
@@ -0,0 +1,13 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// CryptoSource определяет источник котировок криптовалют
+type CryptoSource interface {
+	// GetCryptoQuotes возвращает котировки монет по их идентификаторам у источника; неизвестные монеты пропускаются
+	GetCryptoQuotes(ctx context.Context, coinIDs []string) (map[string]models.CryptoQuote, error)
+}
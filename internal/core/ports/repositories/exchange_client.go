@@ -0,0 +1,33 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// ExchangeClient определяет клиент биржи, поставляющий котировки и свечи бумаг.
+// Тикеры передаются без префикса биржи, в возвращаемых данных они указываются так же
+type ExchangeClient interface {
+	// Exchange возвращает код биржи, например MOEX
+	Exchange() string
+
+	// GetStock возвращает текущую котировку бумаги
+	GetStock(ctx context.Context, ticker string) (*models.Stock, error)
+
+	// GetStocks возвращает текущие котировки нескольких бумаг
+	GetStocks(ctx context.Context, tickers []string) ([]models.Stock, error)
+
+	// GetCandles возвращает свечи бумаги с указанным интервалом за период
+	GetCandles(ctx context.Context, ticker, interval string, from, till time.Time) ([]models.StockQuote, error)
+}
+
+// BoardExchangeClient определяет клиент биржи с несколькими режимами торгов, например MOEX,
+// у которого котировку можно запросить в выбранном режиме
+type BoardExchangeClient interface {
+	ExchangeClient
+
+	// GetBoardStock возвращает котировку бумаги в режиме торгов board; пустой режим — основной режим бумаги
+	GetBoardStock(ctx context.Context, ticker string, board models.TradingBoard) (*models.Stock, error)
+}
@@ -0,0 +1,14 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// ExchangeNewsSource определяет источник официальных сообщений биржи
+// (новости сайта, остановки торгов, изменения листинга)
+type ExchangeNewsSource interface {
+	// GetExchangeNews возвращает последние официальные сообщения биржи
+	GetExchangeNews(ctx context.Context) ([]models.News, error)
+}
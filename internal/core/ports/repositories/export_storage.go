@@ -0,0 +1,9 @@
+package repositories
+
+import "context"
+
+// ExportStorage хранилище файлов выгрузок, которые слишком велики для ответа инструмента
+type ExportStorage interface {
+	// Store сохраняет файл name и возвращает путь к нему или ссылку на скачивание
+	Store(ctx context.Context, name, contentType string, data []byte) (string, error)
+}
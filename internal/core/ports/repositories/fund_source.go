@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// FundSource определяет источник котировок паев биржевых и паевых фондов
+type FundSource interface {
+	// GetFunds возвращает паи фондов, обращающихся на бирже, с текущими котировками
+	GetFunds(ctx context.Context) ([]models.Fund, error)
+
+	// GetINAV возвращает текущую индикативную стоимость пая фонда по коду iNAV
+	GetINAV(ctx context.Context, code string) (float64, error)
+}
@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// IndexRepository определяет интерфейс для хранения состава биржевых индексов
+type IndexRepository interface {
+	// SaveConstituents заменяет состав индекса: бумаги, выбывшие из индекса, удаляются
+	SaveConstituents(ctx context.Context, index string, constituents []models.IndexConstituent) (int, error)
+
+	// GetConstituents возвращает состав индекса по убыванию веса; пустой список — состав не загружен
+	GetConstituents(ctx context.Context, index string) ([]models.IndexConstituent, error)
+}
@@ -0,0 +1,13 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// IndexConstituentSource определяет источник состава и весов биржевых индексов
+type IndexConstituentSource interface {
+	// GetIndexConstituents возвращает текущий состав индекса с весами
+	GetIndexConstituents(ctx context.Context, index string) ([]models.IndexConstituent, error)
+}
@@ -0,0 +1,29 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// ListingRepository определяет интерфейс для хранения календаря размещений
+type ListingRepository interface {
+	// GetListing возвращает размещение по тикеру; nil, если его нет
+	GetListing(ctx context.Context, ticker string) (*models.Listing, error)
+
+	// SaveListing сохраняет размещение, заменяя ранее сохраненное с тем же тикером
+	SaveListing(ctx context.Context, listing *models.Listing) error
+
+	// GetUpcomingListings возвращает объявленные размещения по ожидаемой дате начала торгов
+	GetUpcomingListings(ctx context.Context) ([]models.Listing, error)
+
+	// GetRecentListings возвращает бумаги, начавшие торговаться не раньше since, от новых к старым
+	GetRecentListings(ctx context.Context, since time.Time) ([]models.Listing, error)
+
+	// GetKnownSecurities возвращает тикеры бумаг, торговавшихся на момент прошлой сверки
+	GetKnownSecurities(ctx context.Context) ([]string, error)
+
+	// SaveKnownSecurities сохраняет тикеры бумаг, торгующихся на момент сверки
+	SaveKnownSecurities(ctx context.Context, tickers []string) error
+}
@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// ListingSource определяет источник списка бумаг, торгующихся на бирже
+type ListingSource interface {
+	// GetListedSecurities возвращает акции основного режима торгов
+	GetListedSecurities(ctx context.Context) ([]models.ListedSecurity, error)
+}
+
+// ListingFeed определяет источник объявленных размещений, которых еще нет в списке бумаг биржи
+type ListingFeed interface {
+	// GetPlannedListings возвращает объявленные размещения
+	GetPlannedListings(ctx context.Context) ([]models.Listing, error)
+}
@@ -0,0 +1,20 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// MacroRepository определяет интерфейс для хранения макроэкономических показателей
+type MacroRepository interface {
+	// SaveIndicators сохраняет значения, заменяя ранее сохраненные с тем же кодом и периодом
+	SaveIndicators(ctx context.Context, indicators []models.MacroIndicator) (int, error)
+
+	// GetIndicators возвращает значения показателя за период [from, to] по возрастанию периода
+	GetIndicators(ctx context.Context, code string, from, to time.Time) ([]models.MacroIndicator, error)
+
+	// GetLatestIndicators возвращает limit последних значений показателя, начиная с самого свежего
+	GetLatestIndicators(ctx context.Context, code string, limit int) ([]models.MacroIndicator, error)
+}
@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// MacroSource определяет источник макроэкономических показателей для загрузки в хранилище
+type MacroSource interface {
+	// Name возвращает название источника для журнала загрузки
+	Name() string
+
+	// GetMacroIndicators возвращает значения показателей, доступные в источнике
+	GetMacroIndicators(ctx context.Context) ([]models.MacroIndicator, error)
+}
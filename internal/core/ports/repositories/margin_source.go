@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// MarginSource определяет источник списков бумаг, доступных для маржинальной торговли
+type MarginSource interface {
+	// Name возвращает название источника для журнала загрузки
+	Name() string
+
+	// GetMarginList возвращает условия маржинальной торговли по всем бумагам списка
+	GetMarginList(ctx context.Context) ([]models.MarginStatus, error)
+}
@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// MarketDataSource определяет источник биржевых данных реального времени, которые не сохраняются в базу
+type MarketDataSource interface {
+	// GetOrderBook возвращает текущий стакан заявок по бумаге в режиме торгов board; пустой режим — основной режим бумаги
+	GetOrderBook(ctx context.Context, ticker string, board models.TradingBoard) (*models.OrderBook, error)
+	// GetRecentTrades возвращает последние limit сделок по бумаге в режиме торгов board в хронологическом порядке
+	GetRecentTrades(ctx context.Context, ticker string, board models.TradingBoard, limit int) ([]models.Trade, error)
+	// GetIndexes возвращает текущие значения биржевых индексов в порядке запроса
+	GetIndexes(ctx context.Context, codes []string) ([]models.MarketIndex, error)
+}
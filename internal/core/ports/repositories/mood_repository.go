@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// MoodRepository определяет интерфейс для хранения истории индекса настроения рынка
+type MoodRepository interface {
+	// SaveMood сохраняет значение индекса за день, заменяя ранее сохраненное
+	SaveMood(ctx context.Context, mood *models.MarketMood) error
+
+	// GetMoodHistory возвращает значения индекса за период [from, to] от старых дней к новым
+	GetMoodHistory(ctx context.Context, from, to time.Time) ([]models.MarketMood, error)
+}
@@ -15,18 +15,45 @@ type NewsRepository interface {
 	// GetNewsByDate возвращает новости за указанную дату
 	GetNewsByDate(ctx context.Context, date time.Time) ([]models.News, error)
 
-	// GetNewsForToday возвращает новости за сегодня
-	GetNewsForToday(ctx context.Context) ([]models.News, error)
+	// GetNewsForToday возвращает страницу новостей за сегодня (от новых к старым) и их общее количество
+	GetNewsForToday(ctx context.Context, page models.Pagination) ([]models.News, int, error)
 
-	// GetNewsByKeyword возвращает новости по ключевому слову
-	GetNewsByKeyword(ctx context.Context, keyword string) ([]models.News, error)
+	// GetNewsByKeyword возвращает страницу новостей по ключевому слову, удовлетворяющих фильтру, и их общее количество
+	GetNewsByKeyword(ctx context.Context, keyword string, filter models.NewsFilter, page models.Pagination) ([]models.News, int, error)
 
 	// GetNewsByTicker возвращает новости, связанные с указанным тикером
 	GetNewsByTicker(ctx context.Context, ticker string) ([]models.News, error)
 
+	// FindRelatedNews возвращает до limit новостей периода [from, to), у которых с news есть общие тикеры
+	// или теги, от новых к старым и без полного текста. Сама news в результат не входит
+	FindRelatedNews(ctx context.Context, news models.News, from, to time.Time, limit int) ([]models.News, error)
+
+	// GetNewsTimeline возвращает число новостей тикера по дням периода [from, to) по московскому времени
+	// и до headlines самых свежих заголовков каждого дня. Дни без новостей в результат не входят
+	GetNewsTimeline(ctx context.Context, ticker string, from, to time.Time, headlines int) ([]models.NewsDay, error)
+
 	// SaveNews сохраняет новость
 	SaveNews(ctx context.Context, news *models.News) error
 
 	// SaveNewsCollection сохраняет набор новостей
 	SaveNewsCollection(ctx context.Context, newsCollection []models.News) error
+
+	// BackfillNews загружает архив новостей за период [from, to] из внешнего источника и сохраняет его
+	BackfillNews(ctx context.Context, from, to time.Time) (*models.NewsBackfill, error)
+}
+
+type newsDetailKey struct{}
+
+// WithNewsDetail задает подробность, с которой выборки списков новостей (GetNewsByDate, GetNewsForToday,
+// GetNewsByKeyword, GetNewsByTicker) читают новости из хранилища: ненужные поля не загружаются из базы
+func WithNewsDetail(ctx context.Context, detail models.NewsDetail) context.Context {
+	return context.WithValue(ctx, newsDetailKey{}, detail)
+}
+
+// NewsDetailFromContext возвращает подробность выборки новостей; по умолчанию новости читаются полностью
+func NewsDetailFromContext(ctx context.Context) models.NewsDetail {
+	if detail, ok := ctx.Value(newsDetailKey{}).(models.NewsDetail); ok && detail != "" {
+		return detail
+	}
+	return models.NewsDetailFull
 }
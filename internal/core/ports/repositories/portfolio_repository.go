@@ -0,0 +1,28 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// PortfolioRepository определяет интерфейс для хранения позиций портфелей и истории сделок
+type PortfolioRepository interface {
+	// GetPositions возвращает все позиции портфеля
+	GetPositions(ctx context.Context, portfolio string) ([]models.Position, error)
+
+	// GetPosition возвращает позицию портфеля по тикеру или nil, если ее нет
+	GetPosition(ctx context.Context, portfolio, ticker string) (*models.Position, error)
+
+	// SavePosition сохраняет позицию
+	SavePosition(ctx context.Context, position *models.Position) error
+
+	// DeletePosition удаляет позицию
+	DeletePosition(ctx context.Context, portfolio, ticker string) error
+
+	// SaveTrade сохраняет сделку в историю портфеля; сделке без идентификатора он присваивается
+	SaveTrade(ctx context.Context, trade *models.PortfolioTrade) error
+
+	// GetTrades возвращает сделки портфеля по возрастанию времени исполнения; пустой тикер — сделки по всем бумагам
+	GetTrades(ctx context.Context, portfolio, ticker string) ([]models.PortfolioTrade, error)
+}
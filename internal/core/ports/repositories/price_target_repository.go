@@ -0,0 +1,23 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// PriceTargetRepository определяет интерфейс для хранения целевых цен бумаг
+type PriceTargetRepository interface {
+	// GetTargets возвращает целевые цены бумаги от новых к старым; пустой тикер — цели всех бумаг
+	GetTargets(ctx context.Context, ticker string) ([]models.PriceTarget, error)
+
+	// GetTarget возвращает цель бумаги от источника и аналитика или nil, если ее нет
+	GetTarget(ctx context.Context, ticker, source, analyst string) (*models.PriceTarget, error)
+
+	// SaveTargets сохраняет цели, заменяя прежние цели тех же бумаг, источников и аналитиков,
+	// и возвращает количество новых или измененных целей
+	SaveTargets(ctx context.Context, targets []models.PriceTarget) (int, error)
+
+	// DeleteTarget удаляет цель бумаги от источника и аналитика
+	DeleteTarget(ctx context.Context, ticker, source, analyst string) error
+}
@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// PriceTargetSource определяет источник целевых цен аналитиков для загрузки в хранилище
+type PriceTargetSource interface {
+	// Name возвращает название источника для журнала загрузки
+	Name() string
+
+	// GetPriceTargets возвращает все целевые цены источника
+	GetPriceTargets(ctx context.Context) ([]models.PriceTarget, error)
+}
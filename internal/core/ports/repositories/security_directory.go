@@ -0,0 +1,9 @@
+package repositories
+
+import "context"
+
+// SecurityDirectory справочник акций биржи
+type SecurityDirectory interface {
+	// SecurityNames возвращает названия компаний по тикерам акций; у привилегированных акций названий может не быть
+	SecurityNames(ctx context.Context) (map[string][]string, error)
+}
@@ -0,0 +1,28 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// SecuritySource источник справочника бумаг биржи
+type SecuritySource interface {
+	// GetSecurities возвращает все бумаги фондового рынка
+	GetSecurities(ctx context.Context) ([]models.Security, error)
+}
+
+// SecurityRepository хранилище загруженного справочника бумаг, которое позволяет искать бумаги без обращения к бирже
+type SecurityRepository interface {
+	// GetSecuritySnapshot возвращает сохраненный справочник; nil, если справочник еще не сохранялся
+	GetSecuritySnapshot(ctx context.Context) (*models.SecuritySnapshot, error)
+
+	// SaveSecuritySnapshot сохраняет справочник
+	SaveSecuritySnapshot(ctx context.Context, snapshot *models.SecuritySnapshot) error
+}
+
+// FIGIResolver сопоставляет идентификаторы FIGI биржевым тикерам
+type FIGIResolver interface {
+	// ResolveFIGI возвращает бумагу, которой присвоен FIGI; nil, если такого FIGI нет
+	ResolveFIGI(ctx context.Context, figi string) (*models.FIGIMapping, error)
+}
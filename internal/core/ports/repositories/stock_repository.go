@@ -12,14 +12,27 @@ type StockRepository interface {
 	// GetStock возвращает информацию об акции по тикеру
 	GetStock(ctx context.Context, ticker string) (*models.Stock, error)
 
+	// GetBoardStock возвращает котировку бумаги в режиме торгов board. Котировки основного режима хранятся в базе,
+	// котировки других режимов только кэшируются; пустой режим равнозначен GetStock
+	GetBoardStock(ctx context.Context, ticker string, board models.TradingBoard) (*models.Stock, error)
+
 	// GetStocks возвращает список акций по указанным тикерам
 	GetStocks(ctx context.Context, tickers []string) ([]models.Stock, error)
 
-	// GetStockQuote возвращает детальные котировки акции за указанную дату
+	// GetStockQuote возвращает дневную свечу акции за указанную дату; если ее нет в базе, свеча запрашивается у биржи
 	GetStockQuote(ctx context.Context, ticker string, date time.Time) (*models.StockQuote, error)
 
-	// GetStockHistory возвращает исторические данные по акции за период
-	GetStockHistory(ctx context.Context, ticker string, startDate, endDate time.Time) ([]models.StockQuote, error)
+	// GetStockHistory возвращает свечи акции с указанным интервалом (models.Interval*) за период
+	GetStockHistory(ctx context.Context, ticker, interval string, startDate, endDate time.Time) ([]models.StockQuote, error)
+
+	// GetStoredHistory возвращает сохраненные в базе свечи акции за период, не обращаясь к бирже.
+	// Подходит для сканеров универсума, которым нельзя делать запрос к бирже по каждой бумаге
+	GetStoredHistory(ctx context.Context, ticker, interval string, startDate, endDate time.Time) ([]models.StockQuote, error)
+
+	// BackfillHistory загружает с биржи дневные свечи тикера за период [from, to] (даты включительно) и сохраняет их.
+	// Загруженный период запоминается, поэтому прерванная загрузка продолжается с места остановки,
+	// а повторная загружает только недостающие дни
+	BackfillHistory(ctx context.Context, ticker string, from, to time.Time) (*models.TickerHistoryBackfill, error)
 
 	// SaveStock сохраняет информацию об акции
 	SaveStock(ctx context.Context, stock *models.Stock) error
@@ -0,0 +1,29 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// WatchlistRepository определяет интерфейс для хранения списков наблюдения и уведомлений по ним
+type WatchlistRepository interface {
+	// GetEntries возвращает бумаги списка наблюдения; пустое имя — бумаги всех списков
+	GetEntries(ctx context.Context, watchlist string) ([]models.WatchlistEntry, error)
+
+	// GetEntry возвращает бумагу списка наблюдения по тикеру или nil, если ее нет
+	GetEntry(ctx context.Context, watchlist, ticker string) (*models.WatchlistEntry, error)
+
+	// SaveEntry сохраняет бумагу списка наблюдения
+	SaveEntry(ctx context.Context, entry *models.WatchlistEntry) error
+
+	// DeleteEntry удаляет бумагу из списка наблюдения
+	DeleteEntry(ctx context.Context, watchlist, ticker string) error
+
+	// SaveAlert сохраняет сработавшее уведомление
+	SaveAlert(ctx context.Context, alert *models.WatchlistAlert) error
+
+	// GetAlerts возвращает уведомления списка наблюдения начиная с since (от новых к старым)
+	GetAlerts(ctx context.Context, watchlist string, since time.Time) ([]models.WatchlistAlert, error)
+}
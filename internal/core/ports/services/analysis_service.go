@@ -0,0 +1,20 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// AnalysisService определяет интерфейс сервиса аналитики, объединяющего котировки и новости
+type AnalysisService interface {
+	// ExplainMove собирает вероятные причины движения акции за указанную дату
+	ExplainMove(ctx context.Context, ticker string, date time.Time) (*models.MoveExplanation, error)
+
+	// GetCorrelation рассчитывает попарные корреляции дневных доходностей акций и их беты относительно IMOEX за windowDays дней
+	GetCorrelation(ctx context.Context, tickers []string, windowDays int) (*models.CorrelationReport, error)
+
+	// GetNewsTickerClusters группирует новости дня по упомянутым акциям и дополняет их текущими котировками
+	GetNewsTickerClusters(ctx context.Context, maxTickers int) ([]models.NewsTickerCluster, error)
+}
@@ -0,0 +1,9 @@
+package services
+
+import "context"
+
+// CacheService определяет интерфейс обслуживания кэша
+type CacheService interface {
+	// InvalidateCache удаляет ключи кэша, соответствующие glob-шаблону (например, stock:*)
+	InvalidateCache(ctx context.Context, pattern string) error
+}
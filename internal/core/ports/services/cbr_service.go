@@ -0,0 +1,18 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// CBRService определяет интерфейс сервиса ставок и официальных курсов валют Банка России
+type CBRService interface {
+	// GetKeyRate возвращает текущую ключевую ставку, ее последнее изменение и ставку RUONIA
+	GetKeyRate(ctx context.Context) (*models.KeyRateSummary, error)
+
+	// GetOfficialFXRates возвращает официальные курсы валют на дату; пустой список валют — основные валюты,
+	// нулевая дата — сегодня
+	GetOfficialFXRates(ctx context.Context, currencies []string, date time.Time) ([]models.OfficialFXRate, error)
+}
@@ -0,0 +1,16 @@
+package services
+
+import (
+	"context"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// CommodityService определяет интерфейс сервиса цен сырьевых товаров
+type CommodityService interface {
+	// GetCommodityPrice возвращает цену сырьевого товара в долларах и рублях
+	GetCommodityPrice(ctx context.Context, code string) (*models.CommodityQuote, error)
+
+	// GetCommodityPrices возвращает цены всех поддерживаемых сырьевых товаров; недоступные пропускаются
+	GetCommodityPrices(ctx context.Context) ([]models.CommodityQuote, error)
+}
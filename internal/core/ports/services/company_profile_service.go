@@ -0,0 +1,13 @@
+package services
+
+import (
+	"context"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// CompanyProfileService определяет интерфейс сервиса профилей компаний
+type CompanyProfileService interface {
+	// GetCompanyProfile возвращает профиль компании: сектор, отрасль, капитализацию, число акций, free float и уровень листинга
+	GetCompanyProfile(ctx context.Context, ticker string) (*models.CompanyProfile, error)
+}
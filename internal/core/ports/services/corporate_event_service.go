@@ -0,0 +1,24 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// CorporateEventService определяет интерфейс календаря корпоративных событий
+type CorporateEventService interface {
+	// GetEventsByTicker возвращает события эмитента за период [from, to]
+	GetEventsByTicker(ctx context.Context, ticker string, from, to time.Time) ([]models.CorporateEvent, error)
+
+	// GetEventsCalendar возвращает события всех эмитентов за период [from, to]; types ограничивает типы событий
+	GetEventsCalendar(ctx context.Context, from, to time.Time, types []string) ([]models.CorporateEvent, error)
+
+	// IngestEvents загружает события из всех источников и сохраняет их
+	IngestEvents(ctx context.Context) (*models.CorporateEventsIngestResult, error)
+
+	// GetDividendProfiles возвращает дивидендную доходность и график выплат бумаг по убыванию доходности.
+	// Без тикеров рассматриваются все бумаги, закрывавшие реестр под дивиденды за последний год
+	GetDividendProfiles(ctx context.Context, tickers []string) ([]models.DividendProfile, error)
+}
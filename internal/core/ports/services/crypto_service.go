@@ -0,0 +1,19 @@
+package services
+
+import (
+	"context"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// CryptoService определяет интерфейс сервиса котировок криптовалют
+type CryptoService interface {
+	// GetCryptoPrice возвращает котировку монеты по тикеру
+	GetCryptoPrice(ctx context.Context, symbol string) (*models.CryptoQuote, error)
+
+	// GetCryptoPrices возвращает котировки всех монет из конфигурации
+	GetCryptoPrices(ctx context.Context) ([]models.CryptoQuote, error)
+
+	// GetSymbols возвращает тикеры монет из конфигурации
+	GetSymbols() []string
+}
@@ -0,0 +1,17 @@
+package services
+
+import (
+	"context"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// EnrichmentService определяет интерфейс сервиса обогащения новостей с помощью LLM
+type EnrichmentService interface {
+	// Enabled сообщает, включен ли хотя бы один шаг обогащения и доступна ли модель
+	Enabled(ctx context.Context) bool
+
+	// EnrichNews дополняет новости результатами включенных шагов (резюме, категория, перевод).
+	// Ошибки отдельных шагов не прерывают обработку: новость возвращается без дополнений.
+	EnrichNews(ctx context.Context, news []models.News) []models.News
+}
@@ -0,0 +1,13 @@
+package services
+
+import (
+	"context"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// ExportService определяет интерфейс выгрузки истории котировок и новостей в CSV или JSON
+type ExportService interface {
+	// Export выгружает набор данных и возвращает выгрузку в ответе или адрес сохраненного файла
+	Export(ctx context.Context, request models.ExportRequest) (*models.ExportResult, error)
+}
@@ -0,0 +1,16 @@
+package services
+
+import (
+	"context"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// FundService определяет интерфейс сервиса биржевых и паевых фондов
+type FundService interface {
+	// GetFund возвращает фонд по тикеру пая с котировкой, сведениями о фонде и iNAV; nil — фонд не найден
+	GetFund(ctx context.Context, ticker string) (*models.Fund, error)
+
+	// SearchFunds ищет фонды по условиям filter и возвращает страницу результатов и их общее количество
+	SearchFunds(ctx context.Context, filter models.FundFilter, page models.Pagination) ([]models.Fund, int, error)
+}
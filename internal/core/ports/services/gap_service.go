@@ -0,0 +1,17 @@
+package services
+
+import (
+	"context"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// GapService определяет интерфейс сканера гэпов открытия
+type GapService interface {
+	// ScanGaps рассчитывает гэпы открытия текущей сессии и сохраняет их в кэш до конца дня
+	ScanGaps(ctx context.Context) (*models.GapReport, error)
+
+	// GetGappers возвращает гэпы текущей сессии не меньше minGapPerc по модулю в направлении direction
+	// (пустое — в обоих), не более limit. Если гэпы еще не рассчитаны, рассчитывает их
+	GetGappers(ctx context.Context, minGapPerc float64, direction string, limit int) (*models.GapReport, error)
+}
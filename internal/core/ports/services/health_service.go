@@ -0,0 +1,13 @@
+package services
+
+import (
+	"context"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// HealthService определяет интерфейс проверки состояния сервера
+type HealthService interface {
+	// CheckHealth проверяет базу данных, кэш, внешние API и фоновые задачи
+	CheckHealth(ctx context.Context) *models.HealthReport
+}
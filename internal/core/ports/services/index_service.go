@@ -0,0 +1,19 @@
+package services
+
+import (
+	"context"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// IndexService определяет интерфейс сервиса состава биржевых индексов
+type IndexService interface {
+	// GetIndexes возвращает коды индексов, состав которых загружается
+	GetIndexes() []string
+
+	// GetIndexConstituents возвращает состав индекса с весами, текущими котировками и вкладом бумаг в изменение индекса
+	GetIndexConstituents(ctx context.Context, index string) (*models.IndexComposition, error)
+
+	// IngestConstituents загружает состав и веса всех индексов и сохраняет их
+	IngestConstituents(ctx context.Context) (*models.IndexIngestResult, error)
+}
@@ -0,0 +1,19 @@
+package services
+
+import (
+	"context"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// ListingService определяет интерфейс календаря размещений и новых листингов MOEX
+type ListingService interface {
+	// GetUpcomingIPOs возвращает объявленные размещения, торги по которым еще не начались
+	GetUpcomingIPOs(ctx context.Context) ([]models.Listing, error)
+
+	// GetRecentListings возвращает бумаги, начавшие торговаться за последние days дней
+	GetRecentListings(ctx context.Context, days int) ([]models.Listing, error)
+
+	// RefreshListings сверяет список бумаг MOEX с сохраненным и обновляет календарь из файла объявленных размещений
+	RefreshListings(ctx context.Context) (*models.ListingRefreshResult, error)
+}
@@ -0,0 +1,20 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// MacroService определяет интерфейс сервиса макроэкономических показателей
+type MacroService interface {
+	// GetIndicator возвращает историю показателя за период [from, to]
+	GetIndicator(ctx context.Context, code string, from, to time.Time) (*models.MacroSeries, error)
+
+	// GetSnapshots возвращает последние значения всех показателей, по которым есть данные
+	GetSnapshots(ctx context.Context) ([]models.MacroSnapshot, error)
+
+	// IngestIndicators загружает показатели из всех источников и сохраняет их
+	IngestIndicators(ctx context.Context) (*models.MacroIngestResult, error)
+}
@@ -0,0 +1,20 @@
+package services
+
+import (
+	"context"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// MarginService определяет интерфейс сервиса маржинальных списков брокеров
+type MarginService interface {
+	// GetMarginStatus возвращает условия маржинальной торговли бумагой у брокеров; пустой список — бумаги нет в списках
+	GetMarginStatus(ctx context.Context, ticker string) ([]models.MarginStatus, error)
+
+	// GetMarginableStocks возвращает страницу бумаг, доступных для маржинальных сделок на стороне side
+	// (long, short; пусто — на любой) у брокера broker (пусто — у любого), и их общее количество
+	GetMarginableStocks(ctx context.Context, side, broker string, page models.Pagination) ([]models.MarginStatus, int, error)
+
+	// RefreshMarginLists перечитывает списки из источников и возвращает количество загруженных записей
+	RefreshMarginLists(ctx context.Context) (int, error)
+}
@@ -0,0 +1,17 @@
+package services
+
+import (
+	"context"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// MarketDataService определяет интерфейс сервиса биржевых данных реального времени
+type MarketDataService interface {
+	// GetOrderBook возвращает стакан заявок глубиной depth уровней с каждой стороны со спредом и дисбалансом
+	GetOrderBook(ctx context.Context, ticker string, board models.TradingBoard, depth int) (*models.OrderBook, error)
+	// GetRecentTrades возвращает последние limit сделок; при большом limit лента сворачивается по минутам
+	GetRecentTrades(ctx context.Context, ticker string, board models.TradingBoard, limit int) (*models.RecentTrades, error)
+	// GetIndexes возвращает текущие значения индексов; без кодов возвращаются индексы дайджеста рынка
+	GetIndexes(ctx context.Context, codes []string) ([]models.MarketIndex, error)
+}
@@ -0,0 +1,16 @@
+package services
+
+import (
+	"context"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// MoodService определяет интерфейс расчета составного индекса настроения рынка
+type MoodService interface {
+	// GetMarketMood рассчитывает индекс за сегодня и возвращает его вместе с историей за historyDays дней
+	GetMarketMood(ctx context.Context, historyDays int) (*models.MarketMoodReport, error)
+
+	// RecordMood рассчитывает и сохраняет индекс за сегодня
+	RecordMood(ctx context.Context) (*models.MarketMood, error)
+}
@@ -12,17 +12,24 @@ type NewsService interface {
 	// GetNewsById возвращает новость по ID
 	GetNewsById(ctx context.Context, id string) (*models.News, error)
 
+	// GetNewsFullText возвращает новость вместе с полным текстом статьи, загруженным с ее страницы
+	GetNewsFullText(ctx context.Context, id string) (*models.NewsFullText, error)
+
+	// GetRelatedNews возвращает до limit новостей, наиболее связанных с новостью id общими тикерами, темами
+	// и словами заголовка, в хронологическом порядке — из них складывается развитие сюжета
+	GetRelatedNews(ctx context.Context, id string, limit int) (*models.NewsRelations, error)
+
 	// GetNewsByDate возвращает новости за указанную дату
 	GetNewsByDate(ctx context.Context, date time.Time) ([]models.News, error)
 
-	// GetTodayNews возвращает новости за сегодняшний день
-	GetTodayNews(ctx context.Context) ([]models.News, error)
+	// GetTodayNews возвращает страницу новостей за сегодняшний день и их общее количество
+	GetTodayNews(ctx context.Context, page models.Pagination) ([]models.News, int, error)
 
 	// GetRecentNews возвращает последние новости
 	GetRecentNews(ctx context.Context, limit int) ([]models.News, error)
 
-	// SearchNewsByKeyword ищет новости по ключевому слову
-	SearchNewsByKeyword(ctx context.Context, keyword string) ([]models.News, error)
+	// SearchNewsByKeyword ищет новости по ключевому слову с учетом фильтра и возвращает страницу результатов и их общее количество
+	SearchNewsByKeyword(ctx context.Context, keyword string, filter models.NewsFilter, page models.Pagination) ([]models.News, int, error)
 
 	// GetNewsForTicker возвращает новости, связанные с указанным тикером
 	GetNewsForTicker(ctx context.Context, ticker string) ([]models.News, error)
@@ -30,6 +37,18 @@ type NewsService interface {
 	// GetNewsForMultipleTickers возвращает новости, связанные с несколькими тикерами
 	GetNewsForMultipleTickers(ctx context.Context, tickers []string) ([]models.News, error)
 
+	// GetNewsSummary группирует новости за сегодня по темам (банки, нефть и газ, металлы, макроэкономика, валюта)
+	// и возвращает по каждой теме число новостей и до headlines самых свежих заголовков
+	GetNewsSummary(ctx context.Context, headlines int) (*models.NewsSummary, error)
+
+	// GetNewsTimeline возвращает хронику новостей тикера по дням периода [from, to] (даты включительно):
+	// число новостей и до headlines свежих заголовков за каждый день, отмечая дни всплеска новостей
+	GetNewsTimeline(ctx context.Context, ticker string, from, to time.Time, headlines int) (*models.NewsTimeline, error)
+
+	// BackfillNews загружает архив новостей за период [from, to] (даты включительно),
+	// чтобы исторические выборки возвращали данные
+	BackfillNews(ctx context.Context, from, to time.Time) (*models.NewsBackfill, error)
+
 	// RefreshNews запускает обновление новостей
 	RefreshNews(ctx context.Context) error
 }
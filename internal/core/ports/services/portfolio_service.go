@@ -0,0 +1,48 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// PortfolioService определяет интерфейс сервиса для работы с портфелями
+type PortfolioService interface {
+	// GetPortfolio возвращает оценку портфеля по текущим ценам
+	GetPortfolio(ctx context.Context, portfolio string) (*models.PortfolioSummary, error)
+
+	// AddPosition добавляет бумаги в портфель; при нулевой цене используется текущая. Покупка сохраняется
+	// в историю сделок. При dryRun изменение только рассчитывается и не сохраняется
+	AddPosition(ctx context.Context, portfolio, ticker string, quantity int64, price float64, dryRun bool) (*models.PositionChange, error)
+
+	// RemovePosition уменьшает позицию; при нулевом количестве позиция удаляется полностью.
+	// Продажа по цене price, при нулевой цене — по текущей, сохраняется в историю сделок.
+	// При dryRun изменение только рассчитывается и не сохраняется
+	RemovePosition(ctx context.Context, portfolio, ticker string, quantity int64, price float64, dryRun bool) (*models.PositionChange, error)
+
+	// RecordTrade записывает в журнал сделку с указанными ценой, комиссией и датой и применяет ее к позиции.
+	// Комиссия CommissionByTariff рассчитывается по тарифу брокера, нулевая дата — текущий момент.
+	// При dryRun изменение только рассчитывается и не сохраняется
+	RecordTrade(ctx context.Context, trade models.PortfolioTrade, dryRun bool) (*models.PositionChange, error)
+
+	// GetTradeHistory возвращает сделки портфеля за период [from, to] от новых к старым и их общее количество;
+	// пустой тикер — сделки по всем бумагам, нулевое from — с начала истории
+	GetTradeHistory(ctx context.Context, portfolio, ticker string, from, to time.Time, page models.Pagination) ([]models.PortfolioTrade, int, error)
+
+	// GetRealizedPnL рассчитывает реализованный результат продаж портфеля за период [from, to] по FIFO
+	GetRealizedPnL(ctx context.Context, portfolio string, from, to time.Time) (*models.RealizedPnL, error)
+
+	// EstimateOrderCost оценивает стоимость заявки на quantity бумаг: число лотов, сумму и комиссию брокера.
+	// При нулевой цене используется текущая, указанная цена округляется до шага цены
+	EstimateOrderCost(ctx context.Context, ticker string, quantity int64, side string, price float64) (*models.OrderCostEstimate, error)
+
+	// StressScenarios возвращает доступные стресс-сценарии
+	StressScenarios() []models.StressScenario
+
+	// StressTest применяет исторический кризисный сценарий к текущим позициям портфеля
+	StressTest(ctx context.Context, portfolio, scenario string) (*models.StressTestResult, error)
+
+	// GetRiskReview рассчитывает концентрацию по секторам, бету и просадки текущих позиций портфеля за windowDays дней
+	GetRiskReview(ctx context.Context, portfolio string, windowDays int) (*models.PortfolioRiskReview, error)
+}
@@ -0,0 +1,21 @@
+package services
+
+import (
+	"context"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// PriceTargetService определяет интерфейс сервиса целевых цен и консенсус-прогнозов аналитиков
+type PriceTargetService interface {
+	// SetPriceTarget устанавливает целевую цену бумаги; нулевая цель удаляет прежнюю цель того же аналитика.
+	// При dryRun изменение только рассчитывается и не сохраняется
+	SetPriceTarget(ctx context.Context, target models.PriceTarget, dryRun bool) (*models.PriceTargetChange, error)
+
+	// GetPriceTargetVsMarket сравнивает целевые цены и консенсус с текущими котировками по убыванию потенциала роста;
+	// без тикеров — все бумаги, для которых заданы цели
+	GetPriceTargetVsMarket(ctx context.Context, tickers []string) ([]models.PriceTargetComparison, error)
+
+	// IngestTargets загружает целевые цены из всех источников и сохраняет их
+	IngestTargets(ctx context.Context) (*models.PriceTargetsIngestResult, error)
+}
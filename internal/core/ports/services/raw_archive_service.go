@@ -0,0 +1,14 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// RawArchiveService определяет интерфейс работы с архивом необработанных ответов внешних API
+type RawArchiveService interface {
+	// ReparseRaw повторно разбирает ответы, полученные в период [from, to], и сохраняет результат в репозитории
+	ReparseRaw(ctx context.Context, from, to time.Time) (*models.RawReparse, error)
+}
@@ -0,0 +1,18 @@
+package services
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrSamplingUnavailable возвращается, если подключенный клиент не поддерживает MCP sampling
+var ErrSamplingUnavailable = errors.New("клиент не поддерживает sampling")
+
+// Sampler запрашивает генерацию текста у модели подключенного MCP-клиента (MCP sampling)
+type Sampler interface {
+	// Available сообщает, поддерживает ли подключенный клиент sampling
+	Available(ctx context.Context) bool
+
+	// CreateMessage просит модель клиента ответить на текст userText с системной инструкцией systemPrompt
+	CreateMessage(ctx context.Context, systemPrompt, userText string, maxTokens int) (string, error)
+}
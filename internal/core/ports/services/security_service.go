@@ -0,0 +1,19 @@
+package services
+
+import (
+	"context"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// SecurityService определяет интерфейс сервиса поиска по справочнику бумаг биржи
+type SecurityService interface {
+	// SearchSecurities ищет бумаги по тикеру и названию с учетом опечаток и возвращает их в порядке убывания сходства
+	SearchSecurities(ctx context.Context, query string) ([]models.Security, error)
+
+	// LookupSecurity возвращает бумагу по тикеру или ISIN; nil, если в справочнике ее нет
+	LookupSecurity(ctx context.Context, id string) (*models.Security, error)
+
+	// SyncSecurities загружает справочник с биржи независимо от его возраста и возвращает число бумаг
+	SyncSecurities(ctx context.Context) (int, error)
+}
@@ -0,0 +1,14 @@
+package services
+
+import (
+	"context"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// SelfTestService определяет интерфейс самопроверки внешних источников данных
+type SelfTestService interface {
+	// RunSelfTest выполняет контрольный запрос к каждому источнику в обход кэша
+	// и проверяет, что разбор ответа дал непустые поля
+	RunSelfTest(ctx context.Context) *models.SelfTestReport
+}
@@ -0,0 +1,13 @@
+package services
+
+import (
+	"context"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// ServerStatsService определяет интерфейс статистики работы сервера
+type ServerStatsService interface {
+	// GetServerStats возвращает статистику с момента запуска: время работы, память, обращения к кэшу
+	GetServerStats(ctx context.Context) *models.ServerStats
+}
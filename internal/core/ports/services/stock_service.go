@@ -9,8 +9,8 @@ import (
 
 // StockService определяет интерфейс сервиса для работы с акциями
 type StockService interface {
-	// GetStockInfo возвращает информацию о котировке акции
-	GetStockInfo(ctx context.Context, ticker string) (*models.Stock, error)
+	// GetStockInfo возвращает информацию о котировке бумаги в режиме торгов board; пустой режим — основной режим бумаги
+	GetStockInfo(ctx context.Context, ticker string, board models.TradingBoard) (*models.Stock, error)
 
 	// GetMultipleStocks возвращает информацию о нескольких акциях
 	GetMultipleStocks(ctx context.Context, tickers []string) ([]models.Stock, error)
@@ -18,20 +18,57 @@ type StockService interface {
 	// GetStockQuote возвращает детальные данные по акции за указанную дату
 	GetStockQuote(ctx context.Context, ticker string, date time.Time) (*models.StockQuote, error)
 
-	// GetStockHistoricalData возвращает историю котировок акции за период
-	GetStockHistoricalData(ctx context.Context, ticker string, startDate, endDate time.Time) ([]models.StockQuote, error)
+	// GetStockHistoricalData возвращает историю котировок акции за период: дневные или внутридневные свечи (1m, 10m, 1h)
+	GetStockHistoricalData(ctx context.Context, ticker, interval string, startDate, endDate time.Time) ([]models.StockQuote, error)
 
-	// GetMOEXTopGainers возвращает топ растущих акций на MOEX
-	GetMOEXTopGainers(ctx context.Context, limit int) ([]models.Stock, error)
+	// GetMOEXTopGainers возвращает топ растущих акций универсума на MOEX
+	GetMOEXTopGainers(ctx context.Context, universe string, limit int) ([]models.Stock, error)
 
-	// GetMOEXTopLosers возвращает топ падающих акций на MOEX
-	GetMOEXTopLosers(ctx context.Context, limit int) ([]models.Stock, error)
+	// GetMOEXTopLosers возвращает топ падающих акций универсума на MOEX
+	GetMOEXTopLosers(ctx context.Context, universe string, limit int) ([]models.Stock, error)
 
-	// GetMOEXTopVolume возвращает акции с наибольшим объемом торгов на MOEX
-	GetMOEXTopVolume(ctx context.Context, limit int) ([]models.Stock, error)
+	// GetMOEXTopVolume возвращает акции универсума с наибольшим объемом торгов на MOEX
+	GetMOEXTopVolume(ctx context.Context, universe string, limit int) ([]models.Stock, error)
 
-	// SearchStocks ищет акции по названию или тикеру
-	SearchStocks(ctx context.Context, query string) ([]models.Stock, error)
+	// SearchStocks ищет акции универсума по названию или тикеру и возвращает страницу результатов и их общее количество
+	SearchStocks(ctx context.Context, universe, query string, page models.Pagination) ([]models.Stock, int, error)
+
+	// GetUniverses возвращает доступные торговые универсумы
+	GetUniverses() []models.Universe
+
+	// GetMarketBreadth возвращает статистику ширины рынка по универсуму
+	GetMarketBreadth(ctx context.Context, universe string) (*models.MarketBreadth, error)
+
+	// GetMarketOverview возвращает ширину рынка и limit лидеров роста и падения по универсуму или сектору;
+	// если tickers заданы, выборка состоит из них под именем universe
+	GetMarketOverview(ctx context.Context, universe string, tickers []string, limit int) (*models.MarketOverview, error)
+
+	// GetUnusualVolume сравнивает объем торгов бумаг универсума за день со средним за VolumeLookbackSessions
+	// предыдущих сессий и возвращает не более limit бумаг, у которых объем выше среднего хотя бы в minRatio раз
+	GetUnusualVolume(ctx context.Context, universe string, minRatio float64, limit int) (*models.VolumeAnomalyReport, error)
+
+	// GetOpeningGaps рассчитывает по сохраненным свечам разрывы открытия текущей сессии к закрытию предыдущей
+	// для всех бумаг универсума
+	GetOpeningGaps(ctx context.Context, universe string) (*models.GapReport, error)
+
+	// CompareStocks сравнивает от 2 до 5 акций по цене, объему, мультипликаторам и доходности за 1 и 3 месяца
+	CompareStocks(ctx context.Context, tickers []string) (*models.StockComparison, error)
+
+	// GetNormalizedReturns возвращает цены закрытия акций за months месяцев, приведенные к 100 на начало периода
+	GetNormalizedReturns(ctx context.Context, tickers []string, months int) (*models.NormalizedReturns, error)
+
+	// GetTechnicalIndicators рассчитывает SMA, RSI, MACD и уровни поддержки и сопротивления по сохраненным свечам интервала
+	GetTechnicalIndicators(ctx context.Context, ticker, interval string) (*models.TechnicalIndicators, error)
+
+	// GetSectorPerformance возвращает динамику секторов универсума по данным профилей компаний
+	GetSectorPerformance(ctx context.Context, universe string) (*models.SectorReport, error)
+
+	// GetStocksBySector возвращает акции универсума, относящиеся к сектору
+	GetStocksBySector(ctx context.Context, sector, universe string) (*models.SectorStocks, error)
+
+	// BackfillHistory загружает с биржи дневные свечи тикеров за последние years лет; без тикеров — всех тикеров
+	// универсумов из конфигурации. Прерванная загрузка при повторном вызове продолжается с места остановки
+	BackfillHistory(ctx context.Context, tickers []string, years int) (*models.HistoryBackfill, error)
 
 	// RefreshStockData запускает обновление данных по котировкам
 	RefreshStockData(ctx context.Context) error
@@ -0,0 +1,14 @@
+package services
+
+import (
+	"context"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// SymbolService определяет интерфейс сервиса распознавания тикеров
+type SymbolService interface {
+	// ResolveTicker сопоставляет введенный пользователем тикер или название компании («сбер», «Сбербанк», «sberp»)
+	// тикеру бумаги. Ошибка возвращается только при сбое; ненайденная бумага — результат без тикера
+	ResolveTicker(ctx context.Context, input string) (models.SymbolResolution, error)
+}
@@ -0,0 +1,14 @@
+package services
+
+import (
+	"context"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// TaxService определяет интерфейс оценки налогов частного инвестора по истории сделок портфеля
+type TaxService interface {
+	// EstimateTaxes оценивает НДФЛ по портфелю за календарный год: с результата продаж с учетом льготы
+	// долгосрочного владения и с дивидендов
+	EstimateTaxes(ctx context.Context, portfolio string, year int) (*models.TaxEstimate, error)
+}
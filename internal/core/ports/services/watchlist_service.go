@@ -0,0 +1,34 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/internal/core/domain/models"
+)
+
+// WatchlistService определяет интерфейс сервиса списков наблюдения
+type WatchlistService interface {
+	// GetWatchlist возвращает бумаги списка наблюдения с текущими котировками и действующими порогами
+	GetWatchlist(ctx context.Context, watchlist string) ([]models.WatchlistItem, error)
+
+	// AddToWatchlist добавляет бумагу в список наблюдения или меняет ее порог уведомления;
+	// нулевой порог означает порог по умолчанию, nil сохраняет порог бумаги, уже стоящей в списке.
+	// При dryRun изменение только рассчитывается и не сохраняется
+	AddToWatchlist(ctx context.Context, watchlist, ticker string, thresholdPerc *float64, dryRun bool) (*models.WatchlistChange, error)
+
+	// RemoveFromWatchlist удаляет бумагу из списка наблюдения.
+	// При dryRun изменение только рассчитывается и не сохраняется
+	RemoveFromWatchlist(ctx context.Context, watchlist, ticker string, dryRun bool) (*models.WatchlistChange, error)
+
+	// CheckThresholds проверяет пороги всех списков наблюдения по текущим котировкам
+	// и возвращает сработавшие уведомления
+	CheckThresholds(ctx context.Context) ([]models.WatchlistAlert, error)
+
+	// GetPerformance рассчитывает доходность бумаг списка наблюдения, равновзвешенной корзины
+	// и бенчмарка за период по архивным ценам закрытия
+	GetPerformance(ctx context.Context, watchlist, period string) (*models.WatchlistPerformance, error)
+
+	// GetAlerts возвращает уведомления списка наблюдения начиная с since
+	GetAlerts(ctx context.Context, watchlist string, since time.Time) ([]models.WatchlistAlert, error)
+}
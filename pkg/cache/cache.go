@@ -2,14 +2,20 @@ package cache
 
 import (
 	"context"
-	"encoding/json"
+	"crypto/tls"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/go-redis/redis/v8"
 )
 
+// ErrNotFound возвращается Get, если ключа нет в кэше или срок его хранения истек
+var ErrNotFound = errors.New("ключ не найден в кэше")
+
 // Cache представляет собой интерфейс для работы с кэшем
 type Cache interface {
+	// Get записывает значение ключа в dest; ErrNotFound, если ключа нет
 	Get(ctx context.Context, key string, dest interface{}) error
 	Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error
 	Delete(ctx context.Context, key string) error
@@ -17,51 +23,159 @@ type Cache interface {
 	Invalidate(ctx context.Context, pattern string) error
 }
 
+// Режимы подключения к Redis
+const (
+	RedisStandalone = "standalone"
+	RedisSentinel   = "sentinel"
+	RedisCluster    = "cluster"
+)
+
+// RedisOptions параметры подключения к Redis
+type RedisOptions struct {
+	Mode string // standalone (по умолчанию), sentinel или cluster
+	// Addrs адрес сервера (standalone), адреса Sentinel (sentinel) или узлов кластера (cluster)
+	Addrs      []string
+	MasterName string // Имя отслеживаемого Sentinel мастера
+	DB         int    // Номер базы; в кластере доступна только база 0
+	Username   string // Пользователь ACL Redis 6+
+	Password   string
+	// SentinelPassword пароль Sentinel, если он отличается от пароля Redis
+	SentinelPassword string
+	TLS              bool
+	TLSSkipVerify    bool // Не проверять сертификат сервера (только для отладки)
+	// Параметры пула соединений; 0 — значения go-redis по умолчанию
+	PoolSize     int
+	MinIdleConns int
+	DialTimeout  time.Duration
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+}
+
 // RedisCache реализация кэша на основе Redis
 type RedisCache struct {
-	client *redis.Client
+	client redis.UniversalClient
+	codec  *Codec
+	mode   string
 }
 
-// NewRedisCache создает новый экземпляр кэша Redis
-func NewRedisCache(redisURI string, db int) (*RedisCache, error) {
-	client := redis.NewClient(&redis.Options{
-		Addr: redisURI,
-		DB:   db,
-	})
+// NewRedisCache создает новый экземпляр кэша Redis, значения которого сериализует codec; nil — JSON.
+// Перед возвратом проверяет доступность всех узлов
+func NewRedisCache(opts RedisOptions, codec *Codec) (*RedisCache, error) {
+	if codec == nil {
+		codec = &Codec{format: FormatJSON}
+	}
+	if len(opts.Addrs) == 0 {
+		return nil, fmt.Errorf("не указан адрес Redis")
+	}
+
+	universal := &redis.UniversalOptions{
+		Addrs:            opts.Addrs,
+		DB:               opts.DB,
+		Username:         opts.Username,
+		Password:         opts.Password,
+		SentinelPassword: opts.SentinelPassword,
+		PoolSize:         opts.PoolSize,
+		MinIdleConns:     opts.MinIdleConns,
+		DialTimeout:      opts.DialTimeout,
+		ReadTimeout:      opts.ReadTimeout,
+		WriteTimeout:     opts.WriteTimeout,
+	}
+	if opts.TLS {
+		universal.TLSConfig = &tls.Config{
+			MinVersion:         tls.VersionTLS12,
+			InsecureSkipVerify: opts.TLSSkipVerify,
+		}
+	}
+
+	var client redis.UniversalClient
+	mode := opts.Mode
+	switch mode {
+	case "", RedisStandalone:
+		mode = RedisStandalone
+		client = redis.NewClient(universal.Simple())
+	case RedisSentinel:
+		if opts.MasterName == "" {
+			return nil, fmt.Errorf("для подключения через Sentinel нужно имя мастера")
+		}
+		universal.MasterName = opts.MasterName
+		client = redis.NewFailoverClient(universal.Failover())
+	case RedisCluster:
+		if opts.DB != 0 {
+			return nil, fmt.Errorf("в кластере Redis доступна только база 0")
+		}
+		client = redis.NewClusterClient(universal.Cluster())
+	default:
+		return nil, fmt.Errorf("неизвестный режим Redis %s, доступны: %s, %s, %s", mode, RedisStandalone, RedisSentinel, RedisCluster)
+	}
+
+	c := &RedisCache{
+		client: client,
+		codec:  codec,
+		mode:   mode,
+	}
 
 	// Проверяем соединение с Redis
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	if err := client.Ping(ctx).Err(); err != nil {
+	if err := c.HealthCheck(ctx); err != nil {
+		client.Close()
 		return nil, err
 	}
 
-	return &RedisCache{
-		client: client,
-	}, nil
+	return c, nil
+}
+
+// HealthCheck проверяет доступность Redis; в кластере — каждого узла
+func (c *RedisCache) HealthCheck(ctx context.Context) error {
+	if cluster, ok := c.client.(*redis.ClusterClient); ok {
+		return cluster.ForEachShard(ctx, func(ctx context.Context, node *redis.Client) error {
+			if err := node.Ping(ctx).Err(); err != nil {
+				return fmt.Errorf("узел кластера Redis %s недоступен: %w", node.Options().Addr, err)
+			}
+			return nil
+		})
+	}
+
+	return c.client.Ping(ctx).Err()
+}
+
+// Mode возвращает режим подключения к Redis
+func (c *RedisCache) Mode() string {
+	return c.mode
 }
 
 // Get получает значение из кэша
 func (c *RedisCache) Get(ctx context.Context, key string, dest interface{}) error {
-	val, err := c.client.Get(ctx, key).Result()
+	data, err := c.getBytes(ctx, key)
 	if err != nil {
-		if err == redis.Nil {
-			return nil
-		}
 		return err
 	}
 
-	return json.Unmarshal([]byte(val), dest)
+	return c.codec.Unmarshal(data, dest)
 }
 
 // Set сохраняет значение в кэш
 func (c *RedisCache) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
-	data, err := json.Marshal(value)
+	data, err := c.codec.Marshal(value)
 	if err != nil {
 		return err
 	}
 
+	return c.setBytes(ctx, key, data, ttl)
+}
+
+// getBytes получает сериализованное значение
+func (c *RedisCache) getBytes(ctx context.Context, key string) ([]byte, error) {
+	data, err := c.client.Get(ctx, key).Bytes()
+	if err == redis.Nil {
+		return nil, ErrNotFound
+	}
+	return data, err
+}
+
+// setBytes сохраняет сериализованное значение
+func (c *RedisCache) setBytes(ctx context.Context, key string, data []byte, ttl time.Duration) error {
 	return c.client.Set(ctx, key, data, ttl).Err()
 }
 
@@ -79,16 +193,48 @@ func (c *RedisCache) Exists(ctx context.Context, key string) (bool, error) {
 	return exists > 0, nil
 }
 
-// Invalidate удаляет все ключи соответствующие шаблону
+// Invalidate удаляет все ключи соответствующие шаблону. Ключи перебираются через SCAN,
+// чтобы не блокировать Redis на больших пространствах имен; в кластере — на каждом мастере
 func (c *RedisCache) Invalidate(ctx context.Context, pattern string) error {
-	keys, err := c.client.Keys(ctx, pattern).Result()
-	if err != nil {
+	if cluster, ok := c.client.(*redis.ClusterClient); ok {
+		return cluster.ForEachMaster(ctx, func(ctx context.Context, node *redis.Client) error {
+			return invalidateNode(ctx, node, pattern)
+		})
+	}
+
+	return invalidateNode(ctx, c.client, pattern)
+}
+
+// invalidateNode удаляет ключи узла, соответствующие шаблону. Ключи удаляются по одному в конвейере:
+// DEL нескольких ключей из разных слотов кластер отклоняет
+func invalidateNode(ctx context.Context, client redis.UniversalClient, pattern string) error {
+	iter := client.Scan(ctx, 0, pattern, 500).Iterator()
+
+	var keys []string
+	flush := func() error {
+		if len(keys) == 0 {
+			return nil
+		}
+		pipe := client.Pipeline()
+		for _, key := range keys {
+			pipe.Del(ctx, key)
+		}
+		keys = keys[:0]
+		_, err := pipe.Exec(ctx)
 		return err
 	}
 
-	if len(keys) > 0 {
-		return c.client.Del(ctx, keys...).Err()
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+		if len(keys) == 500 {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return err
 	}
 
-	return nil
+	return flush()
 }
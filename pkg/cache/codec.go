@@ -0,0 +1,157 @@
+package cache
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// Форматы сериализации значений кэша
+const (
+	FormatJSON    = "json"
+	FormatMsgpack = "msgpack"
+	FormatGob     = "gob"
+)
+
+// compressMinSize размер значения, начиная с которого оно сжимается: короткие значения gzip только увеличивает
+const compressMinSize = 1024
+
+// Заголовок сериализованного значения: нулевой байт (с него не начинается JSON), формат и флаги.
+// Значения без заголовка — JSON, записанный до появления форматов, поэтому смена формата в конфигурации
+// не делает прежние значения кэша нечитаемыми
+const (
+	headerMarker   = 0x00
+	headerSize     = 3
+	flagCompressed = 1 << 0
+)
+
+// formatIDs номера форматов в заголовке значения
+var formatIDs = map[string]byte{
+	FormatJSON:    1,
+	FormatMsgpack: 2,
+	FormatGob:     3,
+}
+
+// Codec сериализует значения кэша в выбранном формате и при необходимости сжимает их gzip.
+// Читаются значения в любом формате: формат и сжатие записываются в заголовок значения
+type Codec struct {
+	format   string
+	compress bool
+}
+
+// NewCodec создает сериализатор формата format (json, msgpack или gob); пустой формат — json
+func NewCodec(format string, compress bool) (*Codec, error) {
+	if format == "" {
+		format = FormatJSON
+	}
+	if _, ok := formatIDs[format]; !ok {
+		return nil, fmt.Errorf("неизвестный формат кэша %s, доступны: %s, %s, %s", format, FormatJSON, FormatMsgpack, FormatGob)
+	}
+	return &Codec{format: format, compress: compress}, nil
+}
+
+// Marshal сериализует значение
+func (c *Codec) Marshal(value interface{}) ([]byte, error) {
+	data, err := marshalFormat(c.format, value)
+	if err != nil {
+		return nil, err
+	}
+
+	compressed := c.compress && len(data) >= compressMinSize
+	// Несжатый JSON пишется без заголовка, чтобы его могли прочитать и прежние версии сервера
+	if c.format == FormatJSON && !compressed {
+		return data, nil
+	}
+
+	header := []byte{headerMarker, formatIDs[c.format], 0}
+	if !compressed {
+		return append(header, data...), nil
+	}
+
+	header[2] |= flagCompressed
+	buf := bytes.NewBuffer(header)
+	gz := gzip.NewWriter(buf)
+	if _, err := gz.Write(data); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Unmarshal разбирает значение, сериализованное в любом формате
+func (c *Codec) Unmarshal(data []byte, dest interface{}) error {
+	if len(data) == 0 || data[0] != headerMarker {
+		return json.Unmarshal(data, dest)
+	}
+	if len(data) < headerSize {
+		return fmt.Errorf("поврежденное значение кэша")
+	}
+
+	format := ""
+	for name, id := range formatIDs {
+		if id == data[1] {
+			format = name
+		}
+	}
+	if format == "" {
+		return fmt.Errorf("неизвестный формат значения кэша: %d", data[1])
+	}
+
+	payload := data[headerSize:]
+	if data[2]&flagCompressed != 0 {
+		gz, err := gzip.NewReader(bytes.NewReader(payload))
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		if payload, err = io.ReadAll(gz); err != nil {
+			return err
+		}
+	}
+
+	return unmarshalFormat(format, payload, dest)
+}
+
+// marshalFormat сериализует значение в формате format
+func marshalFormat(format string, value interface{}) ([]byte, error) {
+	switch format {
+	case FormatMsgpack:
+		var buf bytes.Buffer
+		enc := msgpack.NewEncoder(&buf)
+		// Имена и omitempty полей берутся из тегов json, как при сериализации в JSON
+		enc.SetCustomStructTag("json")
+		if err := enc.Encode(value); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case FormatGob:
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(value); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	default:
+		return json.Marshal(value)
+	}
+}
+
+// unmarshalFormat разбирает значение в формате format
+func unmarshalFormat(format string, data []byte, dest interface{}) error {
+	switch format {
+	case FormatMsgpack:
+		dec := msgpack.NewDecoder(bytes.NewReader(data))
+		dec.SetCustomStructTag("json")
+		return dec.Decode(dest)
+	case FormatGob:
+		return gob.NewDecoder(bytes.NewReader(data)).Decode(dest)
+	default:
+		return json.Unmarshal(data, dest)
+	}
+}
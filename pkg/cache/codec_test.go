@@ -0,0 +1,208 @@
+package cache
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// testQuote значение, похожее на котировки и новости, которые сервер хранит в кэше
+type testQuote struct {
+	Ticker    string    `json:"ticker"`
+	Name      string    `json:"name"`
+	Price     float64   `json:"price"`
+	Volume    int64     `json:"volume"`
+	Tags      []string  `json:"tags,omitempty"`
+	Note      string    `json:"note,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// testQuotes возвращает count котировок; сотни котировок превышают compressMinSize
+func testQuotes(count int) []testQuote {
+	updatedAt := time.Date(2024, 3, 15, 10, 30, 0, 0, time.UTC)
+	quotes := make([]testQuote, count)
+	for i := range quotes {
+		quotes[i] = testQuote{
+			Ticker:    fmt.Sprintf("T%03d", i),
+			Name:      fmt.Sprintf("Эмитент %d", i),
+			Price:     100 + float64(i)/4,
+			Volume:    int64(i) * 1000,
+			Tags:      []string{"moex", "tqbr"},
+			UpdatedAt: updatedAt.Add(time.Duration(i) * time.Minute),
+		}
+	}
+	return quotes
+}
+
+// equalQuotes сравнивает котировки; время сравнивается как момент, без учета часового пояса после разбора
+func equalQuotes(t *testing.T, got, want []testQuote) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("len = %d, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if !got[i].UpdatedAt.Equal(want[i].UpdatedAt) {
+			t.Fatalf("quote %d: UpdatedAt = %v, want %v", i, got[i].UpdatedAt, want[i].UpdatedAt)
+		}
+		g, w := got[i], want[i]
+		g.UpdatedAt, w.UpdatedAt = time.Time{}, time.Time{}
+		if !reflect.DeepEqual(g, w) {
+			t.Fatalf("quote %d = %+v, want %+v", i, g, w)
+		}
+	}
+}
+
+func TestCodecRoundTrip(t *testing.T) {
+	for _, format := range []string{FormatJSON, FormatMsgpack, FormatGob} {
+		for _, compress := range []bool{false, true} {
+			for _, count := range []int{1, 200} {
+				t.Run(fmt.Sprintf("%s/compress=%t/count=%d", format, compress, count), func(t *testing.T) {
+					codec, err := NewCodec(format, compress)
+					if err != nil {
+						t.Fatal(err)
+					}
+					want := testQuotes(count)
+
+					data, err := codec.Marshal(want)
+					if err != nil {
+						t.Fatal(err)
+					}
+
+					// Сжимаются только значения не короче compressMinSize; несжатый JSON пишется без заголовка
+					hasHeader := len(data) > 0 && data[0] == headerMarker
+					compressed := hasHeader && data[2]&flagCompressed != 0
+					if wantCompressed := compress && count > 1; compressed != wantCompressed {
+						t.Fatalf("compressed = %t, want %t", compressed, wantCompressed)
+					}
+					if wantHeader := format != FormatJSON || compressed; hasHeader != wantHeader {
+						t.Fatalf("header = %t, want %t", hasHeader, wantHeader)
+					}
+
+					var got []testQuote
+					if err := codec.Unmarshal(data, &got); err != nil {
+						t.Fatal(err)
+					}
+					equalQuotes(t, got, want)
+				})
+			}
+		}
+	}
+}
+
+func TestCodecReadsOtherFormats(t *testing.T) {
+	want := testQuotes(200)
+
+	// Значение, записанное в одном формате, читается сериализатором любого формата:
+	// смена формата в конфигурации не делает кэш нечитаемым
+	for _, writeFormat := range []string{FormatJSON, FormatMsgpack, FormatGob} {
+		writer, err := NewCodec(writeFormat, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := writer.Marshal(want)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, readFormat := range []string{FormatJSON, FormatMsgpack, FormatGob} {
+			reader, err := NewCodec(readFormat, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []testQuote
+			if err := reader.Unmarshal(data, &got); err != nil {
+				t.Fatalf("%s -> %s: %v", writeFormat, readFormat, err)
+			}
+			equalQuotes(t, got, want)
+		}
+	}
+}
+
+func TestCodecReadsLegacyJSON(t *testing.T) {
+	codec, err := NewCodec(FormatMsgpack, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got testQuote
+	if err := codec.Unmarshal([]byte(`{"ticker":"SBER","price":250.5}`), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Ticker != "SBER" || got.Price != 250.5 {
+		t.Fatalf("got %+v", got)
+	}
+}
+
+func TestCodecErrors(t *testing.T) {
+	if _, err := NewCodec("xml", false); err == nil {
+		t.Fatal("NewCodec(xml): want error")
+	}
+
+	codec, err := NewCodec(FormatJSON, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var dest testQuote
+	for _, data := range [][]byte{
+		{headerMarker},
+		{headerMarker, 99, 0, '{', '}'},
+		{headerMarker, formatIDs[FormatJSON], flagCompressed, 'x'},
+	} {
+		if err := codec.Unmarshal(data, &dest); err == nil {
+			t.Fatalf("Unmarshal(%v): want error", data)
+		}
+	}
+}
+
+// benchmarkCodecs запускает bench для каждого формата со сжатием и без
+func benchmarkCodecs(b *testing.B, bench func(b *testing.B, codec *Codec)) {
+	for _, format := range []string{FormatJSON, FormatMsgpack, FormatGob} {
+		for _, compress := range []bool{false, true} {
+			codec, err := NewCodec(format, compress)
+			if err != nil {
+				b.Fatal(err)
+			}
+			name := format
+			if compress {
+				name += "+gzip"
+			}
+			b.Run(name, func(b *testing.B) { bench(b, codec) })
+		}
+	}
+}
+
+func BenchmarkCodecMarshal(b *testing.B) {
+	quotes := testQuotes(200)
+	benchmarkCodecs(b, func(b *testing.B, codec *Codec) {
+		b.ReportAllocs()
+		var size int
+		for i := 0; i < b.N; i++ {
+			data, err := codec.Marshal(quotes)
+			if err != nil {
+				b.Fatal(err)
+			}
+			size = len(data)
+		}
+		b.ReportMetric(float64(size), "bytes")
+	})
+}
+
+func BenchmarkCodecUnmarshal(b *testing.B) {
+	quotes := testQuotes(200)
+	benchmarkCodecs(b, func(b *testing.B, codec *Codec) {
+		data, err := codec.Marshal(quotes)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			var got []testQuote
+			if err := codec.Unmarshal(data, &got); err != nil {
+				b.Fatal(err)
+			}
+		}
+		b.ReportMetric(float64(len(data)), "bytes")
+	})
+}
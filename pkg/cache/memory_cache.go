@@ -11,6 +11,8 @@ import (
 // InMemoryCache реализация кэша на основе go-cache (in-memory)
 type InMemoryCache struct {
 	client *cache.Cache
+	// maxItems наибольшее число ключей; 0 — без ограничения
+	maxItems int
 }
 
 // NewInMemoryCache создает новый экземпляр in-memory кэша
@@ -22,11 +24,19 @@ func NewInMemoryCache(defaultTTL time.Duration) *InMemoryCache {
 	}
 }
 
+// NewBoundedInMemoryCache создает in-memory кэш не больше чем на maxItems ключей. Когда кэш заполнен,
+// новый ключ вытесняет ключ, срок которого истекает раньше всех
+func NewBoundedInMemoryCache(defaultTTL time.Duration, maxItems int) *InMemoryCache {
+	c := NewInMemoryCache(defaultTTL)
+	c.maxItems = maxItems
+	return c
+}
+
 // Get получает значение из кэша
 func (c *InMemoryCache) Get(ctx context.Context, key string, dest interface{}) error {
 	val, found := c.client.Get(key)
 	if !found {
-		return nil
+		return ErrNotFound
 	}
 
 	data, err := json.Marshal(val)
@@ -39,10 +49,35 @@ func (c *InMemoryCache) Get(ctx context.Context, key string, dest interface{}) e
 
 // Set сохраняет значение в кэш
 func (c *InMemoryCache) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	if c.maxItems > 0 && c.client.ItemCount() >= c.maxItems {
+		if _, found := c.client.Get(key); !found {
+			c.evict()
+		}
+	}
 	c.client.Set(key, value, ttl)
 	return nil
 }
 
+// evict удаляет истекшие ключи, а если таких нет — ключ, срок которого истекает раньше всех
+func (c *InMemoryCache) evict() {
+	c.client.DeleteExpired()
+	if c.client.ItemCount() < c.maxItems {
+		return
+	}
+
+	var victim string
+	var earliest int64
+	for key, item := range c.client.Items() {
+		// Ключи без срока (Expiration == 0) вытесняются последними
+		if item.Expiration > 0 && (earliest == 0 || item.Expiration < earliest) {
+			victim, earliest = key, item.Expiration
+		} else if earliest == 0 && victim == "" {
+			victim = key
+		}
+	}
+	c.client.Delete(victim)
+}
+
 // Delete удаляет значение из кэша
 func (c *InMemoryCache) Delete(ctx context.Context, key string) error {
 	c.client.Delete(key)
@@ -55,15 +90,88 @@ func (c *InMemoryCache) Exists(ctx context.Context, key string) (bool, error) {
 	return found, nil
 }
 
-// Invalidate удаляет все ключи соответствующие шаблону
+// Invalidate удаляет все ключи соответствующие шаблону. Шаблон сравнивается так же, как в SCAN MATCH Redis:
+// шаблон без *, ?, [ задает ровно один ключ, поэтому оба кэша удаляют одни и те же ключи
 func (c *InMemoryCache) Invalidate(ctx context.Context, pattern string) error {
-	// Для простой реализации просто сравниваем начало ключа с шаблоном
-	// Более сложная реализация может использовать regexp
-	items := c.client.Items()
-	for k := range items {
-		if len(k) >= len(pattern) && k[:len(pattern)] == pattern {
+	for k := range c.client.Items() {
+		if matchGlob(pattern, k) {
 			c.client.Delete(k)
 		}
 	}
 	return nil
 }
+
+// matchGlob сопоставляет ключ с glob-шаблоном Redis: * — любая последовательность символов, ? — один символ,
+// [abc], [^abc] и [a-z] — класс символов, \ экранирует следующий символ
+func matchGlob(pattern, key string) bool {
+	p, k := []rune(pattern), []rune(key)
+	star, match := -1, 0
+	i, j := 0, 0
+	for j < len(k) {
+		if i < len(p) && p[i] == '*' {
+			star, match = i, j
+			i++
+			continue
+		}
+		if i < len(p) {
+			if next, ok := matchRune(p, i, k[j]); ok {
+				i = next
+				j++
+				continue
+			}
+		}
+		if star < 0 {
+			return false
+		}
+		i = star + 1
+		match++
+		j = match
+	}
+	for i < len(p) && p[i] == '*' {
+		i++
+	}
+	return i == len(p)
+}
+
+// matchRune сопоставляет символ r с элементом шаблона p[i]. Возвращает позицию следующего элемента
+func matchRune(p []rune, i int, r rune) (int, bool) {
+	switch p[i] {
+	case '?':
+		return i + 1, true
+	case '\\':
+		if i+1 < len(p) {
+			return i + 2, p[i+1] == r
+		}
+		return i + 1, r == '\\'
+	case '[':
+		i++
+		negate := i < len(p) && p[i] == '^'
+		if negate {
+			i++
+		}
+		matched := false
+		for ; i < len(p) && p[i] != ']'; i++ {
+			switch {
+			case p[i] == '\\' && i+1 < len(p):
+				i++
+				matched = matched || p[i] == r
+			case i+2 < len(p) && p[i+1] == '-' && p[i+2] != ']':
+				lo, hi := p[i], p[i+2]
+				if lo > hi {
+					lo, hi = hi, lo
+				}
+				matched = matched || (lo <= r && r <= hi)
+				i += 2
+			default:
+				matched = matched || p[i] == r
+			}
+		}
+		// Незакрытый класс, как и в Redis, заканчивается вместе с шаблоном
+		if i < len(p) {
+			i++
+		}
+		return i, matched != negate
+	default:
+		return i + 1, p[i] == r
+	}
+}
@@ -0,0 +1,200 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// PrefixStats статистика обращений к ключам с общим префиксом (stock, news, moex и т.д.)
+type PrefixStats struct {
+	Prefix  string
+	Hits    int64
+	Misses  int64
+	Sets    int64
+	Deletes int64 // Удаления ключей и сбросы по шаблону
+	Errors  int64
+	Calls   int64         // Число всех обращений, включая Exists
+	Latency time.Duration // Суммарное время обращений
+}
+
+// HitRate возвращает долю попаданий среди чтений или 0, если чтений не было
+func (s PrefixStats) HitRate() float64 {
+	if reads := s.Hits + s.Misses; reads > 0 {
+		return float64(s.Hits) / float64(reads)
+	}
+	return 0
+}
+
+// AvgLatency возвращает среднее время обращения
+func (s PrefixStats) AvgLatency() time.Duration {
+	if s.Calls == 0 {
+		return 0
+	}
+	return s.Latency / time.Duration(s.Calls)
+}
+
+// MeteredCache считает попадания, промахи, записи, ошибки и время обращений к кэшу по префиксам ключей.
+// Префикс — часть ключа до первого двоеточия, поэтому обертка ставится поверх пространства имен
+type MeteredCache struct {
+	cache Cache
+	mu    sync.Mutex
+	stats map[string]*PrefixStats
+}
+
+// NewMeteredCache оборачивает кэш учетом обращений
+func NewMeteredCache(c Cache) *MeteredCache {
+	return &MeteredCache{
+		cache: c,
+		stats: make(map[string]*PrefixStats),
+	}
+}
+
+// Get получает значение из кэша
+func (c *MeteredCache) Get(ctx context.Context, key string, dest interface{}) error {
+	start := time.Now()
+	err := c.cache.Get(ctx, key, dest)
+	c.record(keyPrefix(key), time.Since(start), func(s *PrefixStats) {
+		switch {
+		case err == nil:
+			s.Hits++
+		case errors.Is(err, ErrNotFound):
+			s.Misses++
+		default:
+			s.Errors++
+		}
+	})
+	return err
+}
+
+// Set сохраняет значение в кэш
+func (c *MeteredCache) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	start := time.Now()
+	err := c.cache.Set(ctx, key, value, ttl)
+	c.record(keyPrefix(key), time.Since(start), func(s *PrefixStats) {
+		if err != nil {
+			s.Errors++
+			return
+		}
+		s.Sets++
+	})
+	return err
+}
+
+// Delete удаляет значение из кэша
+func (c *MeteredCache) Delete(ctx context.Context, key string) error {
+	start := time.Now()
+	err := c.cache.Delete(ctx, key)
+	c.record(keyPrefix(key), time.Since(start), func(s *PrefixStats) {
+		if err != nil {
+			s.Errors++
+			return
+		}
+		s.Deletes++
+	})
+	return err
+}
+
+// Exists проверяет наличие ключа в кэше
+func (c *MeteredCache) Exists(ctx context.Context, key string) (bool, error) {
+	start := time.Now()
+	ok, err := c.cache.Exists(ctx, key)
+	c.record(keyPrefix(key), time.Since(start), func(s *PrefixStats) {
+		if err != nil {
+			s.Errors++
+		}
+	})
+	return ok, err
+}
+
+// Invalidate удаляет все ключи, соответствующие шаблону
+func (c *MeteredCache) Invalidate(ctx context.Context, pattern string) error {
+	start := time.Now()
+	err := c.cache.Invalidate(ctx, pattern)
+	c.record(keyPrefix(pattern), time.Since(start), func(s *PrefixStats) {
+		if err != nil {
+			s.Errors++
+			return
+		}
+		s.Deletes++
+	})
+	return err
+}
+
+// Stats возвращает снимок статистики по префиксам в алфавитном порядке
+func (c *MeteredCache) Stats() []PrefixStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := make([]PrefixStats, 0, len(c.stats))
+	for _, s := range c.stats {
+		stats = append(stats, *s)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].Prefix < stats[j].Prefix
+	})
+	return stats
+}
+
+// WriteMetrics записывает статистику в текстовом формате Prometheus
+func (c *MeteredCache) WriteMetrics(w io.Writer) error {
+	stats := c.Stats()
+
+	counters := []struct {
+		name, help string
+		value      func(PrefixStats) int64
+	}{
+		{"cache_hits_total", "Попадания в кэш", func(s PrefixStats) int64 { return s.Hits }},
+		{"cache_misses_total", "Промахи кэша", func(s PrefixStats) int64 { return s.Misses }},
+		{"cache_sets_total", "Записи в кэш", func(s PrefixStats) int64 { return s.Sets }},
+		{"cache_deletes_total", "Удаления ключей и сбросы по шаблону", func(s PrefixStats) int64 { return s.Deletes }},
+		{"cache_errors_total", "Ошибки обращений к кэшу", func(s PrefixStats) int64 { return s.Errors }},
+		{"cache_operations_total", "Число обращений к кэшу", func(s PrefixStats) int64 { return s.Calls }},
+	}
+
+	var b strings.Builder
+	for _, counter := range counters {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s counter\n", counter.name, counter.help, counter.name)
+		for _, s := range stats {
+			fmt.Fprintf(&b, "%s{prefix=%q} %d\n", counter.name, s.Prefix, counter.value(s))
+		}
+	}
+	b.WriteString("# HELP cache_operation_duration_seconds_total Суммарное время обращений к кэшу\n")
+	b.WriteString("# TYPE cache_operation_duration_seconds_total counter\n")
+	for _, s := range stats {
+		fmt.Fprintf(&b, "cache_operation_duration_seconds_total{prefix=%q} %g\n", s.Prefix, s.Latency.Seconds())
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// record учитывает обращение к ключам префикса
+func (c *MeteredCache) record(prefix string, latency time.Duration, update func(*PrefixStats)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s, ok := c.stats[prefix]
+	if !ok {
+		s = &PrefixStats{Prefix: prefix}
+		c.stats[prefix] = s
+	}
+	s.Calls++
+	s.Latency += latency
+	update(s)
+}
+
+// keyPrefix возвращает часть ключа до первого двоеточия. Шаблоны с подстановочными символами
+// в первом сегменте затрагивают несколько префиксов и учитываются под префиксом *
+func keyPrefix(key string) string {
+	prefix, _, _ := strings.Cut(key, ":")
+	if prefix == "" || strings.ContainsAny(prefix, "*?[") {
+		return "*"
+	}
+	return prefix
+}
@@ -0,0 +1,68 @@
+package cache
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// NamespacedCache изолирует ключи в пространстве имен: каждый ключ получает префикс "<namespace>:".
+// Так ключи окружений (staging, prod), работающих с одним Redis, не пересекаются.
+type NamespacedCache struct {
+	cache  Cache
+	prefix string
+}
+
+// NewNamespacedCache создает кэш с пространством имен поверх существующего кэша
+func NewNamespacedCache(c Cache, namespace string) *NamespacedCache {
+	return &NamespacedCache{
+		cache:  c,
+		prefix: namespaceSegment(namespace) + ":",
+	}
+}
+
+// Prefix возвращает префикс ключей пространства имен
+func (c *NamespacedCache) Prefix() string {
+	return c.prefix
+}
+
+// Get получает значение из кэша
+func (c *NamespacedCache) Get(ctx context.Context, key string, dest interface{}) error {
+	return c.cache.Get(ctx, c.prefix+key, dest)
+}
+
+// Set сохраняет значение в кэш
+func (c *NamespacedCache) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	return c.cache.Set(ctx, c.prefix+key, value, ttl)
+}
+
+// Delete удаляет значение из кэша
+func (c *NamespacedCache) Delete(ctx context.Context, key string) error {
+	return c.cache.Delete(ctx, c.prefix+key)
+}
+
+// Exists проверяет наличие ключа в кэше
+func (c *NamespacedCache) Exists(ctx context.Context, key string) (bool, error) {
+	return c.cache.Exists(ctx, c.prefix+key)
+}
+
+// Invalidate удаляет ключи пространства имен, соответствующие шаблону. Символы шаблона в префиксе
+// заменены namespaceSegment, поэтому шаблон не выходит за пределы пространства имен
+func (c *NamespacedCache) Invalidate(ctx context.Context, pattern string) error {
+	return c.cache.Invalidate(ctx, c.prefix+pattern)
+}
+
+// namespaceSegment заменяет символы, которые нарушили бы структуру ключа или шаблоны удаления
+func namespaceSegment(name string) string {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "default"
+	}
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ':', '*', '?', '[', ']', '\\':
+			return '_'
+		}
+		return r
+	}, name)
+}
@@ -0,0 +1,115 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// softLoadTimeout ограничение времени загрузки значения из источника
+const softLoadTimeout = 30 * time.Second
+
+// LoadFunc загружает значение из источника, когда его нет в кэше или оно устарело
+type LoadFunc func(ctx context.Context) (interface{}, error)
+
+// softEntry значение в кэше и время, до которого оно считается свежим
+type softEntry struct {
+	Value      json.RawMessage `json:"value"`
+	FreshUntil time.Time       `json:"fresh_until"`
+}
+
+// SoftCache кэш с мягким сроком жизни (stale-while-revalidate). Значение свежо до softTTL; после него и до
+// hardTTL оно по-прежнему отдается сразу, а из источника обновляется в фоне. Одновременные загрузки одного ключа
+// объединяются в одну, поэтому истечение популярного ключа не вызывает шквала запросов к базе данных и бирже
+type SoftCache struct {
+	cache Cache
+	group singleflight.Group
+}
+
+// NewSoftCache создает кэш с мягким сроком жизни поверх существующего кэша
+func NewSoftCache(c Cache) *SoftCache {
+	return &SoftCache{cache: c}
+}
+
+// Fetch записывает в dest значение ключа. Свежее значение берется из кэша; устаревшее берется из кэша
+// и обновляется в фоне; отсутствующее загружается load и сохраняется. Ошибки кэша не мешают загрузке
+func (c *SoftCache) Fetch(ctx context.Context, key string, dest interface{}, softTTL, hardTTL time.Duration, load LoadFunc) error {
+	// Значение в прежнем формате (без срока свежести) или неразбираемое загружается заново
+	var entry softEntry
+	switch err := c.cache.Get(ctx, key, &entry); {
+	case err == nil && len(entry.Value) > 0:
+		if err := json.Unmarshal(entry.Value, dest); err == nil {
+			if time.Now().After(entry.FreshUntil) {
+				c.refresh(key, softTTL, hardTTL, load)
+			}
+			return nil
+		}
+	case err != nil && !errors.Is(err, ErrNotFound):
+		log.Printf("Ошибка чтения кэша %s: %v", key, err)
+	}
+
+	// Загрузка общая для всех ожидающих ее запросов, поэтому отмена запроса, который ее начал, не должна
+	// прерывать ее для остальных: она выполняется в отвязанном контексте со своим ограничением времени
+	loaded := c.group.DoChan(key, func() (interface{}, error) {
+		loadCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), softLoadTimeout)
+		defer cancel()
+		return c.load(loadCtx, key, softTTL, hardTTL, load)
+	})
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case result := <-loaded:
+		if result.Err != nil {
+			return result.Err
+		}
+		return json.Unmarshal(result.Val.(json.RawMessage), dest)
+	}
+}
+
+// Store сохраняет значение, свежее в течение softTTL
+func (c *SoftCache) Store(ctx context.Context, key string, value interface{}, softTTL, hardTTL time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	return c.cache.Set(ctx, key, softEntry{Value: data, FreshUntil: time.Now().Add(softTTL)}, hardTTL)
+}
+
+// refresh обновляет значение в фоне, если оно еще не обновляется
+func (c *SoftCache) refresh(key string, softTTL, hardTTL time.Duration, load LoadFunc) {
+	// Фоновое обновление не должно прерываться вместе с запросом, который его запустил
+	c.group.DoChan(key, func() (interface{}, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), softLoadTimeout)
+		defer cancel()
+
+		value, err := c.load(ctx, key, softTTL, hardTTL, load)
+		if err != nil {
+			log.Printf("Не удалось обновить значение кэша %s: %v", key, err)
+		}
+		return value, err
+	})
+}
+
+// load загружает значение из источника и сохраняет его в кэш
+func (c *SoftCache) load(ctx context.Context, key string, softTTL, hardTTL time.Duration, load LoadFunc) (interface{}, error) {
+	value, err := load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ошибка записи в кэш не мешает вернуть загруженное значение
+	c.cache.Set(ctx, key, softEntry{Value: data, FreshUntil: time.Now().Add(softTTL)}, hardTTL)
+
+	return json.RawMessage(data), nil
+}
@@ -0,0 +1,76 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// TieredCache двухуровневый кэш: небольшой кэш в памяти процесса (L1) перед Redis (L2).
+// Запись идет в оба уровня, чтение — сначала из L1, поэтому популярные ключи не требуют обращения к Redis.
+// Значение в L1 живет не дольше l1TTL: изменения, записанные в Redis другими экземплярами сервера,
+// становятся видны не позже чем через l1TTL
+type TieredCache struct {
+	l1    *InMemoryCache
+	l2    *RedisCache
+	l1TTL time.Duration
+}
+
+// NewTieredCache создает двухуровневый кэш с L1 не больше чем на l1Size ключей
+func NewTieredCache(l2 *RedisCache, l1Size int, l1TTL time.Duration) *TieredCache {
+	return &TieredCache{
+		l1:    NewBoundedInMemoryCache(l1TTL, l1Size),
+		l2:    l2,
+		l1TTL: l1TTL,
+	}
+}
+
+// Get получает значение из L1, а при промахе — из Redis, запоминая его в L1.
+// В L1 хранится сериализованное значение, а не dest: иначе вызывающий код, изменив полученное значение, изменил бы кэш
+func (c *TieredCache) Get(ctx context.Context, key string, dest interface{}) error {
+	if cached, found := c.l1.client.Get(key); found {
+		return c.l2.codec.Unmarshal(cached.([]byte), dest)
+	}
+
+	data, err := c.l2.getBytes(ctx, key)
+	if err != nil {
+		return err
+	}
+	c.l1.Set(ctx, key, data, c.l1TTL)
+
+	return c.l2.codec.Unmarshal(data, dest)
+}
+
+// Set сохраняет значение в Redis и L1
+func (c *TieredCache) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	data, err := c.l2.codec.Marshal(value)
+	if err != nil {
+		return err
+	}
+	if err := c.l2.setBytes(ctx, key, data, ttl); err != nil {
+		c.l1.Delete(ctx, key)
+		return err
+	}
+
+	c.l1.Set(ctx, key, data, min(ttl, c.l1TTL))
+	return nil
+}
+
+// Delete удаляет значение из обоих уровней
+func (c *TieredCache) Delete(ctx context.Context, key string) error {
+	c.l1.Delete(ctx, key)
+	return c.l2.Delete(ctx, key)
+}
+
+// Exists проверяет наличие ключа в L1 или Redis
+func (c *TieredCache) Exists(ctx context.Context, key string) (bool, error) {
+	if found, _ := c.l1.Exists(ctx, key); found {
+		return true, nil
+	}
+	return c.l2.Exists(ctx, key)
+}
+
+// Invalidate удаляет ключи, соответствующие шаблону, из обоих уровней
+func (c *TieredCache) Invalidate(ctx context.Context, pattern string) error {
+	c.l1.Invalidate(ctx, pattern)
+	return c.l2.Invalidate(ctx, pattern)
+}
@@ -0,0 +1,48 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/timing"
+)
+
+// TimedCache учитывает время обращений к кэшу как этап cache отладочной разбивки
+type TimedCache struct {
+	cache Cache
+}
+
+// NewTimedCache оборачивает кэш замером времени обращений
+func NewTimedCache(c Cache) *TimedCache {
+	return &TimedCache{cache: c}
+}
+
+// Get получает значение из кэша
+func (c *TimedCache) Get(ctx context.Context, key string, dest interface{}) error {
+	defer timing.Track(ctx, timing.StageCache)()
+	return c.cache.Get(ctx, key, dest)
+}
+
+// Set сохраняет значение в кэш
+func (c *TimedCache) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	defer timing.Track(ctx, timing.StageCache)()
+	return c.cache.Set(ctx, key, value, ttl)
+}
+
+// Delete удаляет значение из кэша
+func (c *TimedCache) Delete(ctx context.Context, key string) error {
+	defer timing.Track(ctx, timing.StageCache)()
+	return c.cache.Delete(ctx, key)
+}
+
+// Exists проверяет наличие ключа в кэше
+func (c *TimedCache) Exists(ctx context.Context, key string) (bool, error) {
+	defer timing.Track(ctx, timing.StageCache)()
+	return c.cache.Exists(ctx, key)
+}
+
+// Invalidate удаляет все ключи, соответствующие шаблону
+func (c *TimedCache) Invalidate(ctx context.Context, pattern string) error {
+	defer timing.Track(ctx, timing.StageCache)()
+	return c.cache.Invalidate(ctx, pattern)
+}
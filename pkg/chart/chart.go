@@ -0,0 +1,285 @@
+// Package chart рисует графики котировок в PNG: свечи или линию цены закрытия с панелью объемов,
+// шкалой цен и подписями дат. Рисование выполняется стандартной библиотекой image, а подписи — шрифтом
+// Go Regular из golang.org/x/image, который содержит кириллицу и не зависит от системных шрифтов.
+// Для клиентов, которые показывают только текст, есть спарклайны из блочных символов
+package chart
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"strconv"
+	"time"
+)
+
+// Style вид графика
+type Style string
+
+const (
+	StyleCandles Style = "candles" // Японские свечи
+	StyleLine    Style = "line"    // Линия цены закрытия
+)
+
+// Размеры изображения по умолчанию
+const (
+	DefaultWidth  = 800
+	DefaultHeight = 480
+)
+
+const (
+	marginLeft   = 12
+	marginRight  = 76 // Место под шкалу цен
+	marginTop    = 32 // Место под заголовок
+	marginBottom = 26 // Место под подписи дат
+	panelGap     = 8  // Промежуток между ценами и объемами
+	volumeShare  = 0.22
+	priceTicks   = 6 // Примерное число линий сетки цен
+	timeLabels   = 6 // Примерное число подписей дат
+)
+
+var (
+	colorBackground = color.RGBA{255, 255, 255, 255}
+	colorGrid       = color.RGBA{230, 232, 236, 255}
+	colorAxis       = color.RGBA{160, 165, 175, 255}
+	colorText       = color.RGBA{60, 64, 72, 255}
+	colorUp         = color.RGBA{38, 166, 91, 255}
+	colorDown       = color.RGBA{232, 65, 66, 255}
+	colorLine       = color.RGBA{41, 98, 255, 255}
+	colorLast       = color.RGBA{41, 98, 255, 255}
+)
+
+// Bar свеча графика
+type Bar struct {
+	Time   time.Time
+	Open   float64
+	High   float64
+	Low    float64
+	Close  float64
+	Volume int64
+}
+
+// Options параметры графика
+type Options struct {
+	Title      string
+	Style      Style
+	Width      int
+	Height     int
+	TimeFormat string // Формат подписей дат; по умолчанию 02.01.06
+}
+
+// Render рисует график свечей bars (в порядке времени) и возвращает PNG
+func Render(bars []Bar, opts Options) ([]byte, error) {
+	if len(bars) == 0 {
+		return nil, errors.New("нет свечей для графика")
+	}
+	if opts.Width <= 0 {
+		opts.Width = DefaultWidth
+	}
+	if opts.Height <= 0 {
+		opts.Height = DefaultHeight
+	}
+	if opts.Style == "" {
+		opts.Style = StyleCandles
+	}
+	if opts.TimeFormat == "" {
+		opts.TimeFormat = "02.01.06"
+	}
+
+	plotWidth := opts.Width - marginLeft - marginRight
+	plotHeight := opts.Height - marginTop - marginBottom
+	if plotWidth < 100 || plotHeight < 100 {
+		return nil, fmt.Errorf("размер графика %d×%d слишком мал", opts.Width, opts.Height)
+	}
+
+	face, err := newFace()
+	if err != nil {
+		return nil, fmt.Errorf("не удалось загрузить шрифт подписей: %w", err)
+	}
+	defer face.Close()
+
+	img := image.NewRGBA(image.Rect(0, 0, opts.Width, opts.Height))
+	fillRect(img, 0, 0, opts.Width, opts.Height, colorBackground)
+	drawText(img, face, marginLeft, (marginTop-textHeight)/2, opts.Title, colorText)
+
+	volumeHeight := int(float64(plotHeight) * volumeShare)
+	price := panel{x: marginLeft, y: marginTop, width: plotWidth, height: plotHeight - volumeHeight - panelGap}
+	volume := panel{x: marginLeft, y: price.y + price.height + panelGap, width: plotWidth, height: volumeHeight}
+
+	// Шкала цен охватывает все тени свечей с небольшим запасом сверху и снизу
+	low, high := math.Inf(1), math.Inf(-1)
+	var maxVolume int64
+	for _, bar := range bars {
+		barLow, barHigh := bar.Low, bar.High
+		if opts.Style == StyleLine || barLow <= 0 || barHigh <= 0 {
+			barLow, barHigh = bar.Close, bar.Close
+		}
+		low, high = math.Min(low, barLow), math.Max(high, barHigh)
+		maxVolume = max(maxVolume, bar.Volume)
+	}
+	if high == low {
+		high, low = high+1, low-1
+	}
+	pad := (high - low) * 0.05
+	low, high = low-pad, high+pad
+	price.min, price.max = low, high
+
+	step := niceStep((high - low) / priceTicks)
+	decimals := max(0, -int(math.Floor(math.Log10(step))))
+	for tick := math.Ceil(low/step) * step; tick <= high; tick += step {
+		y := price.yOf(tick)
+		dashedLine(img, price.x, price.x+price.width, y, colorGrid)
+		drawText(img, face, price.x+price.width+6, y-textHeight/2, strconv.FormatFloat(tick, 'f', decimals, 64), colorAxis)
+	}
+
+	slot := float64(plotWidth) / float64(len(bars))
+	center := func(i int) int { return marginLeft + int(slot*(float64(i)+0.5)) }
+	bodyWidth := max(1, int(slot*0.7))
+
+	for i, bar := range bars {
+		x := center(i)
+		c := colorUp
+		if bar.Close < bar.Open {
+			c = colorDown
+		}
+
+		if opts.Style == StyleCandles {
+			vline(img, x, price.yOf(bar.High), price.yOf(bar.Low), c)
+			top, bottom := price.yOf(math.Max(bar.Open, bar.Close)), price.yOf(math.Min(bar.Open, bar.Close))
+			fillRect(img, x-bodyWidth/2, top, bodyWidth, max(1, bottom-top), c)
+		} else if i > 0 {
+			thickLine(img, center(i-1), price.yOf(bars[i-1].Close), x, price.yOf(bar.Close), colorLine)
+		}
+
+		if maxVolume > 0 {
+			h := int(float64(volume.height) * float64(bar.Volume) / float64(maxVolume))
+			fillRect(img, x-bodyWidth/2, volume.y+volume.height-h, bodyWidth, h, lighten(c))
+		}
+	}
+
+	// Последняя цена отмечается линией и подписью на шкале
+	last := bars[len(bars)-1].Close
+	lastY := price.yOf(last)
+	dashedLine(img, price.x, price.x+price.width, lastY, colorLast)
+	label := strconv.FormatFloat(last, 'f', max(decimals, 2), 64)
+	fillRect(img, price.x+price.width+2, lastY-textHeight/2-3, textWidth(face, label)+8, textHeight+6, colorLast)
+	drawText(img, face, price.x+price.width+6, lastY-textHeight/2, label, colorBackground)
+
+	// Оси и подписи дат под панелью объемов
+	hline(img, marginLeft, marginLeft+plotWidth, volume.y+volume.height, colorAxis)
+	vline(img, marginLeft+plotWidth, price.y, volume.y+volume.height, colorAxis)
+	every := max(1, len(bars)/timeLabels)
+	for i := 0; i < len(bars); i += every {
+		text := bars[i].Time.Format(opts.TimeFormat)
+		x := center(i) - textWidth(face, text)/2
+		if x < 0 || x+textWidth(face, text) > marginLeft+plotWidth {
+			continue
+		}
+		vline(img, center(i), volume.y+volume.height, volume.y+volume.height+4, colorAxis)
+		drawText(img, face, x, volume.y+volume.height+8, text, colorAxis)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("ошибка кодирования PNG: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// panel прямоугольная область графика с вертикальной шкалой [min, max]
+type panel struct {
+	x, y, width, height int
+	min, max            float64
+}
+
+// yOf возвращает координату значения на шкале панели
+func (p panel) yOf(value float64) int {
+	return p.y + int(float64(p.height)*(p.max-value)/(p.max-p.min))
+}
+
+// niceStep округляет шаг сетки до 1, 2, 2.5 или 5, умноженных на степень десяти
+func niceStep(raw float64) float64 {
+	if raw <= 0 {
+		return 1
+	}
+	magnitude := math.Pow(10, math.Floor(math.Log10(raw)))
+	for _, factor := range []float64{1, 2, 2.5, 5} {
+		if raw <= factor*magnitude {
+			return factor * magnitude
+		}
+	}
+	return 10 * magnitude
+}
+
+// lighten смешивает цвет с белым, чтобы объемы не спорили со свечами
+func lighten(c color.RGBA) color.RGBA {
+	return color.RGBA{uint8((int(c.R) + 255*2) / 3), uint8((int(c.G) + 255*2) / 3), uint8((int(c.B) + 255*2) / 3), 255}
+}
+
+func fillRect(img *image.RGBA, x, y, width, height int, c color.RGBA) {
+	rect := image.Rect(x, y, x+width, y+height).Intersect(img.Bounds())
+	for py := rect.Min.Y; py < rect.Max.Y; py++ {
+		for px := rect.Min.X; px < rect.Max.X; px++ {
+			img.SetRGBA(px, py, c)
+		}
+	}
+}
+
+func hline(img *image.RGBA, x1, x2, y int, c color.RGBA) {
+	fillRect(img, x1, y, x2-x1+1, 1, c)
+}
+
+func vline(img *image.RGBA, x, y1, y2 int, c color.RGBA) {
+	if y1 > y2 {
+		y1, y2 = y2, y1
+	}
+	fillRect(img, x, y1, 1, y2-y1+1, c)
+}
+
+func dashedLine(img *image.RGBA, x1, x2, y int, c color.RGBA) {
+	for x := x1; x <= x2; x += 6 {
+		fillRect(img, x, y, min(3, x2-x+1), 1, c)
+	}
+}
+
+// thickLine рисует отрезок толщиной 2 пикселя алгоритмом Брезенхема
+func thickLine(img *image.RGBA, x1, y1, x2, y2 int, c color.RGBA) {
+	dx, dy := abs(x2-x1), -abs(y2-y1)
+	sx, sy := sign(x2-x1), sign(y2-y1)
+	err := dx + dy
+	for {
+		fillRect(img, x1, y1, 2, 2, c)
+		if x1 == x2 && y1 == y2 {
+			return
+		}
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			x1 += sx
+		}
+		if e2 <= dx {
+			err += dx
+			y1 += sy
+		}
+	}
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func sign(v int) int {
+	switch {
+	case v > 0:
+		return 1
+	case v < 0:
+		return -1
+	}
+	return 0
+}
@@ -0,0 +1,51 @@
+package chart
+
+import (
+	"image"
+	"image/color"
+	"sync"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+const (
+	fontSize = 12 // Размер шрифта подписей в пикселях
+	// textHeight высота заглавных букв и цифр шрифта: по ней подписи выравниваются по вертикали.
+	// Выносные элементы строчных букв опускаются ниже еще на несколько пикселей
+	textHeight = 9
+)
+
+// parsedFont шрифт подписей Go Regular: он встроен в пакет golang.org/x/image и содержит кириллицу,
+// поэтому графики не зависят от системных шрифтов
+var parsedFont = sync.OnceValues(func() (*opentype.Font, error) {
+	return opentype.Parse(goregular.TTF)
+})
+
+// newFace создает начертание шрифта подписей. Начертание кэширует глифы и не допускает
+// одновременного использования, поэтому каждый график создает свое
+func newFace() (font.Face, error) {
+	f, err := parsedFont()
+	if err != nil {
+		return nil, err
+	}
+	return opentype.NewFace(f, &opentype.FaceOptions{Size: fontSize, DPI: 72, Hinting: font.HintingFull})
+}
+
+// textWidth ширина строки в пикселях
+func textWidth(face font.Face, text string) int {
+	return font.MeasureString(face, text).Ceil()
+}
+
+// drawText выводит строку так, что верх заглавных букв находится в точке (x, y)
+func drawText(img *image.RGBA, face font.Face, x, y int, text string, c color.RGBA) {
+	d := font.Drawer{
+		Dst:  img,
+		Src:  image.NewUniform(c),
+		Face: face,
+		Dot:  fixed.P(x, y+textHeight),
+	}
+	d.DrawString(text)
+}
@@ -0,0 +1,49 @@
+package chart
+
+import "math"
+
+// DefaultSparklineWidth длина спарклайна по умолчанию в символах
+const DefaultSparklineWidth = 40
+
+// sparkLevels символы уровней спарклайна от минимума к максимуму
+var sparkLevels = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline рисует ряд значений строкой из блочных символов восьми уровней: минимум ряда — ▁, максимум — █.
+// Если значений больше width, соседние значения усредняются, чтобы строка была не длиннее width символов
+func Sparkline(values []float64, width int) string {
+	if len(values) == 0 {
+		return ""
+	}
+	if width <= 0 {
+		width = DefaultSparklineWidth
+	}
+
+	if len(values) > width {
+		buckets := make([]float64, width)
+		for i := range buckets {
+			start, end := i*len(values)/width, (i+1)*len(values)/width
+			sum := 0.0
+			for _, value := range values[start:end] {
+				sum += value
+			}
+			buckets[i] = sum / float64(end-start)
+		}
+		values = buckets
+	}
+
+	low, high := math.Inf(1), math.Inf(-1)
+	for _, value := range values {
+		low, high = math.Min(low, value), math.Max(high, value)
+	}
+
+	line := make([]rune, len(values))
+	for i, value := range values {
+		// Ровный ряд рисуется средним уровнем
+		level := len(sparkLevels) / 2
+		if high > low {
+			level = int(math.Round((value - low) / (high - low) * float64(len(sparkLevels)-1)))
+		}
+		line[i] = sparkLevels[level]
+	}
+	return string(line)
+}
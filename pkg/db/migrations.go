@@ -0,0 +1,67 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+)
+
+// ApplyMigrations применяет SQL-миграции (*.sql из корня migrations) в лексикографическом порядке.
+// Примененные версии отмечаются в таблице schema_migrations, поэтому повторный запуск безопасен.
+func ApplyMigrations(ctx context.Context, conn *sql.DB, migrations fs.FS) error {
+	_, err := conn.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version TEXT PRIMARY KEY,
+		applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`)
+	if err != nil {
+		return fmt.Errorf("ошибка создания таблицы миграций: %w", err)
+	}
+
+	files, err := fs.Glob(migrations, "*.sql")
+	if err != nil {
+		return fmt.Errorf("ошибка чтения списка миграций: %w", err)
+	}
+	sort.Strings(files)
+
+	for _, file := range files {
+		version := strings.TrimSuffix(file, ".sql")
+
+		var applied int
+		err := conn.QueryRowContext(ctx,
+			`SELECT COUNT(*) FROM schema_migrations WHERE version = $1`, version,
+		).Scan(&applied)
+		if err != nil {
+			return fmt.Errorf("ошибка проверки миграции %s: %w", version, err)
+		}
+		if applied > 0 {
+			continue
+		}
+
+		script, err := fs.ReadFile(migrations, file)
+		if err != nil {
+			return fmt.Errorf("ошибка чтения миграции %s: %w", version, err)
+		}
+
+		// Каждая миграция применяется в отдельной транзакции
+		tx, err := conn.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, string(script)); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("ошибка применения миграции %s: %w", version, err)
+		}
+		if _, err := tx.ExecContext(ctx, `INSERT INTO schema_migrations (version) VALUES ($1)`, version); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("ошибка записи миграции %s: %w", version, err)
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("ошибка фиксации миграции %s: %w", version, err)
+		}
+	}
+
+	return nil
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"time"
 
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/timing"
+	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
@@ -20,7 +22,17 @@ func NewMongoDB(uri, database, collection string, timeout time.Duration) (*Mongo
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
-	clientOptions := options.Client().ApplyURI(uri)
+	// Монитор команд учитывает время запросов к базе в отладочной разбивке по этапам
+	monitor := &event.CommandMonitor{
+		Succeeded: func(ctx context.Context, evt *event.CommandSucceededEvent) {
+			timing.Add(ctx, timing.StageDB, evt.Duration)
+		},
+		Failed: func(ctx context.Context, evt *event.CommandFailedEvent) {
+			timing.Add(ctx, timing.StageDB, evt.Duration)
+		},
+	}
+
+	clientOptions := options.Client().ApplyURI(uri).SetMonitor(monitor)
 	client, err := mongo.Connect(ctx, clientOptions)
 	if err != nil {
 		return nil, err
@@ -42,6 +54,11 @@ func NewMongoDB(uri, database, collection string, timeout time.Duration) (*Mongo
 	}, nil
 }
 
+// Ping проверяет соединение с базой данных
+func (m *MongoDB) Ping(ctx context.Context) error {
+	return m.client.Ping(ctx, nil)
+}
+
 // Close закрывает соединение с базой данных
 func (m *MongoDB) Close(ctx context.Context) error {
 	return m.client.Disconnect(ctx)
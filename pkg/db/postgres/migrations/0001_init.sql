@@ -0,0 +1,41 @@
+CREATE TABLE IF NOT EXISTS stocks (
+    ticker      TEXT PRIMARY KEY,
+    name        TEXT NOT NULL DEFAULT '',
+    price       DOUBLE PRECISION NOT NULL DEFAULT 0,
+    change      DOUBLE PRECISION NOT NULL DEFAULT 0,
+    change_perc DOUBLE PRECISION NOT NULL DEFAULT 0,
+    volume      BIGINT NOT NULL DEFAULT 0,
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS stock_quotes (
+    ticker          TEXT NOT NULL,
+    date            DATE NOT NULL,
+    open            DOUBLE PRECISION NOT NULL DEFAULT 0,
+    high            DOUBLE PRECISION NOT NULL DEFAULT 0,
+    low             DOUBLE PRECISION NOT NULL DEFAULT 0,
+    close           DOUBLE PRECISION NOT NULL DEFAULT 0,
+    volume          BIGINT NOT NULL DEFAULT 0,
+    market_cap_bln  DOUBLE PRECISION NOT NULL DEFAULT 0,
+    pe              DOUBLE PRECISION NOT NULL DEFAULT 0,
+    dividend_yield  DOUBLE PRECISION NOT NULL DEFAULT 0,
+    sector          TEXT NOT NULL DEFAULT '',
+    trading_session TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (ticker, date)
+);
+
+CREATE TABLE IF NOT EXISTS news (
+    id           TEXT PRIMARY KEY,
+    title        TEXT NOT NULL DEFAULT '',
+    description  TEXT NOT NULL DEFAULT '',
+    content      TEXT NOT NULL DEFAULT '',
+    url          TEXT NOT NULL DEFAULT '',
+    source       TEXT NOT NULL DEFAULT '',
+    published_at TIMESTAMPTZ NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    tags         TEXT[] NOT NULL DEFAULT '{}',
+    related_to   TEXT[] NOT NULL DEFAULT '{}'
+);
+
+CREATE INDEX IF NOT EXISTS idx_news_published_at ON news (published_at);
+CREATE INDEX IF NOT EXISTS idx_news_related_to ON news USING GIN (related_to);
@@ -0,0 +1,2 @@
+-- Язык новости; ранее сохраненные новости загружались только на русском
+ALTER TABLE news ADD COLUMN language TEXT NOT NULL DEFAULT 'ru';
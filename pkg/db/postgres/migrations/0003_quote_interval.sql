@@ -0,0 +1,6 @@
+-- Интервал свечи: кроме дневных котировок хранятся внутридневные свечи (1m, 10m, 1h),
+-- поэтому date хранит время начала свечи и входит в ключ вместе с интервалом
+ALTER TABLE stock_quotes ADD COLUMN candle_interval TEXT NOT NULL DEFAULT '1d';
+ALTER TABLE stock_quotes ALTER COLUMN date TYPE TIMESTAMP;
+ALTER TABLE stock_quotes DROP CONSTRAINT stock_quotes_pkey;
+ALTER TABLE stock_quotes ADD PRIMARY KEY (ticker, candle_interval, date);
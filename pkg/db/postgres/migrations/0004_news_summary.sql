@@ -0,0 +1,2 @@
+-- Резюме статьи, составленное при загрузке новости (summarizer.method: textrank)
+ALTER TABLE news ADD COLUMN summary TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,62 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"io/fs"
+	"time"
+
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/db"
+
+	// Драйвер PostgreSQL для database/sql
+	_ "github.com/lib/pq"
+)
+
+//go:embed migrations/*.sql
+var migrationsFS embed.FS
+
+// Postgres представляет собой клиент для работы с PostgreSQL
+type Postgres struct {
+	db *sql.DB
+}
+
+// NewPostgres создает новый экземпляр клиента PostgreSQL
+func NewPostgres(uri string, timeout time.Duration) (*Postgres, error) {
+	conn, err := sql.Open("postgres", uri)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	// Проверяем соединение с базой данных
+	if err := conn.PingContext(ctx); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return &Postgres{
+		db: conn,
+	}, nil
+}
+
+// Migrate применяет к базе данных миграции, которые еще не были применены
+func (p *Postgres) Migrate(ctx context.Context) error {
+	migrations, err := fs.Sub(migrationsFS, "migrations")
+	if err != nil {
+		return err
+	}
+	return db.ApplyMigrations(ctx, p.db, migrations)
+}
+
+// Close закрывает соединение с базой данных
+func (p *Postgres) Close() error {
+	return p.db.Close()
+}
+
+// GetDB возвращает пул соединений с базой данных
+func (p *Postgres) GetDB() *sql.DB {
+	return p.db
+}
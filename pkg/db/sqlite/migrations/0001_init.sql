@@ -0,0 +1,41 @@
+CREATE TABLE IF NOT EXISTS stocks (
+    ticker      TEXT PRIMARY KEY,
+    name        TEXT NOT NULL DEFAULT '',
+    price       REAL NOT NULL DEFAULT 0,
+    change      REAL NOT NULL DEFAULT 0,
+    change_perc REAL NOT NULL DEFAULT 0,
+    volume      INTEGER NOT NULL DEFAULT 0,
+    updated_at  TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS stock_quotes (
+    ticker          TEXT NOT NULL,
+    date            DATE NOT NULL,
+    open            REAL NOT NULL DEFAULT 0,
+    high            REAL NOT NULL DEFAULT 0,
+    low             REAL NOT NULL DEFAULT 0,
+    close           REAL NOT NULL DEFAULT 0,
+    volume          INTEGER NOT NULL DEFAULT 0,
+    market_cap_bln  REAL NOT NULL DEFAULT 0,
+    pe              REAL NOT NULL DEFAULT 0,
+    dividend_yield  REAL NOT NULL DEFAULT 0,
+    sector          TEXT NOT NULL DEFAULT '',
+    trading_session TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (ticker, date)
+);
+
+-- tags и related_to хранятся как JSON-массивы строк
+CREATE TABLE IF NOT EXISTS news (
+    id           TEXT PRIMARY KEY,
+    title        TEXT NOT NULL DEFAULT '',
+    description  TEXT NOT NULL DEFAULT '',
+    content      TEXT NOT NULL DEFAULT '',
+    url          TEXT NOT NULL DEFAULT '',
+    source       TEXT NOT NULL DEFAULT '',
+    published_at TIMESTAMP NOT NULL,
+    created_at   TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    tags         TEXT NOT NULL DEFAULT '[]',
+    related_to   TEXT NOT NULL DEFAULT '[]'
+);
+
+CREATE INDEX IF NOT EXISTS idx_news_published_at ON news (published_at);
@@ -0,0 +1,25 @@
+-- Интервал свечи: кроме дневных котировок хранятся внутридневные свечи (1m, 10m, 1h),
+-- поэтому date хранит время начала свечи и входит в ключ вместе с интервалом.
+-- SQLite не позволяет изменить первичный ключ, поэтому таблица пересоздается
+CREATE TABLE stock_quotes_new (
+    ticker          TEXT NOT NULL,
+    candle_interval TEXT NOT NULL DEFAULT '1d',
+    date            TIMESTAMP NOT NULL,
+    open            REAL NOT NULL DEFAULT 0,
+    high            REAL NOT NULL DEFAULT 0,
+    low             REAL NOT NULL DEFAULT 0,
+    close           REAL NOT NULL DEFAULT 0,
+    volume          INTEGER NOT NULL DEFAULT 0,
+    market_cap_bln  REAL NOT NULL DEFAULT 0,
+    pe              REAL NOT NULL DEFAULT 0,
+    dividend_yield  REAL NOT NULL DEFAULT 0,
+    sector          TEXT NOT NULL DEFAULT '',
+    trading_session TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (ticker, candle_interval, date)
+);
+
+INSERT INTO stock_quotes_new (ticker, date, open, high, low, close, volume, market_cap_bln, pe, dividend_yield, sector, trading_session)
+SELECT ticker, date, open, high, low, close, volume, market_cap_bln, pe, dividend_yield, sector, trading_session FROM stock_quotes;
+
+DROP TABLE stock_quotes;
+ALTER TABLE stock_quotes_new RENAME TO stock_quotes;
@@ -0,0 +1,7 @@
+-- Ход загрузки истории дневных свечей с биржи: период, уже загруженный по каждому тикеру
+CREATE TABLE IF NOT EXISTS history_backfill (
+    ticker      TEXT PRIMARY KEY,
+    loaded_from DATE NOT NULL,
+    loaded_to   DATE NOT NULL,
+    updated_at  TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,71 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/JkLondon/mcp-stocks-info-server/pkg/db"
+
+	// Драйвер SQLite для database/sql (требует CGO)
+	_ "github.com/mattn/go-sqlite3"
+)
+
+//go:embed migrations/*.sql
+var migrationsFS embed.FS
+
+// SQLite представляет собой встроенную базу данных SQLite в файле
+type SQLite struct {
+	db *sql.DB
+}
+
+// NewSQLite открывает (или создает) файл базы данных SQLite по указанному пути
+func NewSQLite(path string) (*SQLite, error) {
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0o750); err != nil {
+			return nil, fmt.Errorf("не удалось создать каталог для базы данных: %w", err)
+		}
+	}
+
+	// WAL позволяет читать параллельно с записью, busy_timeout сглаживает конкурентные записи
+	dsn := fmt.Sprintf("file:%s?_journal_mode=WAL&_busy_timeout=5000&_foreign_keys=on", path)
+	conn, err := sql.Open("sqlite3", dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	// SQLite допускает только одного писателя, поэтому ограничиваем пул
+	conn.SetMaxOpenConns(1)
+
+	if err := conn.Ping(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return &SQLite{
+		db: conn,
+	}, nil
+}
+
+// Migrate применяет к базе данных миграции, которые еще не были применены
+func (s *SQLite) Migrate(ctx context.Context) error {
+	migrations, err := fs.Sub(migrationsFS, "migrations")
+	if err != nil {
+		return err
+	}
+	return db.ApplyMigrations(ctx, s.db, migrations)
+}
+
+// Close закрывает базу данных
+func (s *SQLite) Close() error {
+	return s.db.Close()
+}
+
+// GetDB возвращает соединение с базой данных
+func (s *SQLite) GetDB() *sql.DB {
+	return s.db
+}
@@ -0,0 +1,872 @@
+package i18n
+
+// english английский каталог сообщений. Ключ — исходное сообщение или строка формата на русском;
+// порядок глаголов формата в переводе должен совпадать с исходным
+var english = map[string]string{
+	// Проверка аргументов инструментов
+	"неизвестный параметр %s: инструмент не принимает параметров":   "unknown parameter %s: the tool takes no parameters",
+	"неизвестный параметр %s, допустимые параметры: %s":             "unknown parameter %s, allowed parameters: %s",
+	"параметр %s обязателен":                                        "parameter %s is required",
+	"параметр %s должен быть строкой":                               "parameter %s must be a string",
+	"параметр %s должен быть логическим значением (true или false)": "parameter %s must be a boolean (true or false)",
+	"параметр %s должен быть целым числом":                          "parameter %s must be an integer",
+	"параметр %s должен быть числом":                                "parameter %s must be a number",
+	"параметр %s должен быть списком строк":                         "parameter %s must be a list of strings",
+	"параметр %s должен быть одним из: %s":                          "parameter %s must be one of: %s",
+	"параметр %s должен быть %s %s":                                 "parameter %s must be %s %s",
+	"параметр %s должен содержать %s %s элементов":                  "parameter %s must contain %s %s items",
+	"не меньше": "at least",
+	"не больше": "at most",
+	"параметр %s должен быть в формате YYYY-MM-DD":                        "parameter %s must be in YYYY-MM-DD format",
+	"параметр from должен быть в формате YYYY-MM-DD или YYYY-MM-DD HH:MM": "parameter from must be in YYYY-MM-DD or YYYY-MM-DD HH:MM format",
+	"параметр to должен быть в формате YYYY-MM-DD или YYYY-MM-DD HH:MM":   "parameter to must be in YYYY-MM-DD or YYYY-MM-DD HH:MM format",
+
+	// Распознавание тикеров
+	"не удалось однозначно определить бумагу «%s», подходят: %s":                         "could not identify the security «%s» unambiguously, candidates: %s",
+	"не удалось найти бумагу «%s»: укажите тикер (например, SBER) или название компании": "could not find the security «%s»: specify a ticker (e.g. SBER) or a company name",
+	"«%s» распознано как %s": "«%s» recognized as %s",
+
+	// Ограничение времени выполнения
+	"инструмент %s не ответил за %d с: источник данных отвечает слишком долго, повторите запрос позже": "tool %s did not respond within %d s: the data source is responding too slowly, please retry later",
+
+	// Общие аргументы
+	"Язык ответа (по умолчанию %s)":                            "Response language (default %s)",
+	"Количество %s на странице (по умолчанию %d, максимум %d)": "Number of %s per page (default %d, maximum %d)",
+	"акций":    "stocks",
+	"новостей": "news items",
+	"Сколько первых результатов пропустить (по умолчанию 0)":                                                                                     "Number of leading results to skip (default 0)",
+	"Только показать, что изменится, ничего не сохраняя (по умолчанию false). Позволяет подтвердить изменение с пользователем перед применением": "Only show what would change without saving anything (default false). Lets you confirm the change with the user before applying it",
+	"Торговый универсум (по умолчанию full)":                                                                                                     "Trading universe (default full)",
+
+	// Инструкции сервера
+	"Сервер предоставляет данные о российском рынке акций (Московская биржа, MOEX) и финансовые новости на русском языке.\n": "The server provides data on the Russian stock market (Moscow Exchange, MOEX) and Russian-language financial news.\n",
+	"Данные обновляются с задержкой: %s.\n": "Data is delayed: %s.\n",
+	"котировки — до %s":                     "quotes by up to %s",
+	"новости — до %s":                       "news by up to %s",
+	"Цены указаны в рублях. Используй инструменты для получения актуальных данных и не выдумывай котировки.": "Prices are in rubles. Use the tools to get current data and never make up quotes.",
+
+	// Источники данных
+	"Данные: Московская Биржа, задержка 15 минут":                 "Data: Moscow Exchange, 15-minute delay",
+	"Новости: NewsAPI.org, права принадлежат изданиям-источникам": "News: NewsAPI.org, rights belong to the source publications",
+	"Криптовалюты: CoinGecko":                                     "Cryptocurrencies: CoinGecko",
+	"Зарубежные котировки: Yahoo Finance":                         "Foreign quotes: Yahoo Finance",
+	"Ставки и официальные курсы: Банк России":                     "Rates and official exchange rates: Bank of Russia",
+	"Банк России": "Bank of Russia",
+	"Внимание: %s на техническом обслуживании с %s. Показаны последние сохраненные данные, они могут быть устаревшими.": "Warning: %s has been under maintenance since %s. Showing the last saved data, which may be outdated.",
+
+	// Инструменты для акций
+	"Получить информацию о котировке акции на MOEX или включенной зарубежной бирже (NASDAQ, NYSE, XETRA, EURONEXT)":           "Get a stock quote from MOEX or an enabled foreign exchange (NASDAQ, NYSE, XETRA, EURONEXT)",
+	"Тикер акции (например, SBER, GAZP, LKOH); для бумаг других бирж — с префиксом биржи, например NASDAQ:AAPL или XETRA:SAP": "Stock ticker (e.g. SBER, GAZP, LKOH); for other exchanges prefix the exchange, e.g. NASDAQ:AAPL or XETRA:SAP",
+	"Тикер акции (например, SBER, GAZP, LKOH)": "Stock ticker (e.g. SBER, GAZP, LKOH)",
+	"Получить историю котировок акции свечами: дневными или внутридневными (1m, 10m, 1h) для анализа движения внутри торговой сессии":                               "Get stock price history as daily or intraday (1m, 10m, 1h) candles to analyze moves within a trading session",
+	"Интервал свечей: 1m, 10m, 1h или 1d (по умолчанию 1d). Период внутридневных свечей ограничен: 1m — сутки, 10m — неделя, 1h — месяц":                            "Candle interval: 1m, 10m, 1h or 1d (default 1d). Intraday ranges are limited: 1m — one day, 10m — one week, 1h — one month",
+	"Начало периода в формате YYYY-MM-DD или YYYY-MM-DD HH:MM по московскому времени (по умолчанию месяц назад для дневных свечей и сутки назад для внутридневных)": "Range start as YYYY-MM-DD or YYYY-MM-DD HH:MM Moscow time (default one month ago for daily candles and one day ago for intraday)",
+	"Конец периода в формате YYYY-MM-DD (включительно) или YYYY-MM-DD HH:MM по московскому времени (по умолчанию сейчас)":                                           "Range end as YYYY-MM-DD (inclusive) or YYYY-MM-DD HH:MM Moscow time (default now)",
+	"Сколько последних свечей вывести (по умолчанию %d); сводка считается по всему периоду":                                                                         "How many latest candles to show (default %d); the summary covers the whole range",
+	"Получить список топ растущих акций на MOEX":                                                                                   "Get the top gaining stocks on MOEX",
+	"Получить список топ падающих акций на MOEX":                                                                                   "Get the top losing stocks on MOEX",
+	"Количество акций в списке (по умолчанию 10)":                                                                                  "Number of stocks in the list (default 10)",
+	"Поиск акций по названию или тикеру среди всех бумаг MOEX с учетом опечаток":                                                   "Search all MOEX stocks by name or ticker, tolerating typos",
+	"Поисковый запрос: тикер, его начало или название компании, можно с опечатками":                                                "Search query: a ticker, its beginning or a company name, typos allowed",
+	"Получить ширину рынка: число растущих и падающих акций, среднее изменение и суммарный объем":                                  "Get market breadth: the number of advancing and declining stocks, the average change and total volume",
+	"Сравнить несколько акций бок о бок: цена, изменение за день, объем, P/E, дивидендная доходность и доходность за 1 и 3 месяца": "Compare several stocks side by side: price, daily change, volume, P/E, dividend yield and 1- and 3-month returns",
+	"Тикеры акций для сравнения, от %d до %d (например, [\"SBER\", \"VTBR\"])":                                                     "Stock tickers to compare, from %d to %d (e.g. [\"SBER\", \"VTBR\"])",
+
+	"не удалось получить информацию об акции: %v":   "failed to get stock info: %v",
+	"акция с тикером %s не найдена":                 "stock with ticker %s not found",
+	"не удалось получить список растущих акций: %v": "failed to get top gainers: %v",
+	"Не найдено растущих акций":                     "No gaining stocks found",
+	"не удалось получить список падающих акций: %v": "failed to get top losers: %v",
+	"Не найдено падающих акций":                     "No losing stocks found",
+	"не удалось выполнить поиск акций: %v":          "failed to search stocks: %v",
+	"По запросу не найдено акций":                   "No stocks match the query",
+	"не удалось получить ширину рынка: %v":          "failed to get market breadth: %v",
+	"Нет данных по акциям универсума %s":            "No stock data for universe %s",
+
+	// История котировок
+	"не удалось получить историю котировок: %v":         "failed to get price history: %v",
+	"Нет свечей %s с интервалом %s за указанный период": "No %s candles with interval %s in the requested range",
+	"История %s, свечи %s, %s – %s (%d шт.):\n":         "History of %s, %s candles, %s – %s (%d total):\n",
+	"Открытие: %.2f %s, закрытие: %.2f %s":              "Open: %.2f %s, close: %.2f %s",
+	"\nМаксимум: %.2f %s, минимум: %.2f %s\n":           "\nHigh: %.2f %s, low: %.2f %s\n",
+	"Суммарный объем: %d\n":                             "Total volume: %d\n",
+	"Последние %d свечей:\n":                            "Last %d candles:\n",
+
+	// Инструменты для новостей
+	"Получить финансовые новости за сегодня":                                                   "Get today's financial news",
+	"Поиск новостей по ключевому слову":                                                        "Search news by keyword",
+	"Ключевое слово для поиска":                                                                "Keyword to search for",
+	"Начало периода публикации в формате YYYY-MM-DD":                                           "Publication range start as YYYY-MM-DD",
+	"Конец периода публикации в формате YYYY-MM-DD (включительно)":                             "Publication range end as YYYY-MM-DD (inclusive)",
+	"Издания (идентификаторы NewsAPI, например rbc, kommersant); по умолчанию из конфигурации": "Publications (NewsAPI identifiers, e.g. rbc, kommersant); defaults to the configured ones",
+	"Язык новостей (по умолчанию ru)":                                                          "News language (default ru)",
+	"Получить новости, связанные с указанным тикером, включая официальные сообщения Московской Биржи (остановки торгов, изменения листинга)":                        "Get news related to a ticker, including official Moscow Exchange notices (trading halts, listing changes)",
+	"Получить сводку новостей за сегодня по темам (банки, нефть и газ, металлы, макроэкономика, валюта): число новостей и самые свежие заголовки по каждой теме":    "Get today's news summary by topic (banks, oil and gas, metals, macroeconomics, currency): the number of news items and the latest headlines per topic",
+	"Сколько заголовков показать по каждой теме (по умолчанию %d)":                                                                                                  "How many headlines to show per topic (default %d)",
+	"Загрузить из NewsAPI архив финансовых новостей за период (не длиннее %d дней) и сохранить его, чтобы поиск и выборки по прошедшим датам возвращали результаты": "Load an archive of financial news from NewsAPI for a range (at most %d days) and store it so searches over past dates return results",
+	"Начало периода в формате YYYY-MM-DD":               "Range start as YYYY-MM-DD",
+	"Конец периода в формате YYYY-MM-DD (включительно)": "Range end as YYYY-MM-DD (inclusive)",
+
+	"не удалось получить новости: %v":            "failed to get news: %v",
+	"На сегодня нет финансовых новостей":         "No financial news for today",
+	"не удалось выполнить поиск новостей: %v":    "failed to search news: %v",
+	"По запросу '%s' не найдено новостей":        "No news found for '%s'",
+	"не удалось загрузить архив новостей: %v":    "failed to load the news archive: %v",
+	"Не найдено новостей, связанных с акцией %s": "No news found related to %s",
+	"не удалось получить сводку новостей: %v":    "failed to get the news summary: %v",
+	"На сегодня новостей не найдено":             "No news found for today",
+
+	// Шаблоны результатов (internal/adapters/render/templates)
+	"На этой странице результатов нет (всего: %d)": "No results on this page (total: %d)",
+	"Показаны %d–%d из %d (total_count: %d)":       "Showing %d–%d of %d (total_count: %d)",
+	"Следующая страница: offset=%d":                "Next page: offset=%d",
+	"не удалось оформить результат: %v":            "failed to render the result: %v",
+	"Информация об акции %s (%s):":                 "Stock info %s (%s):",
+	"Цена: %.2f %s":                                  "Price: %.2f %s",
+	"Изменение: %.2f (%.2f%%)":                       "Change: %.2f (%.2f%%)",
+	"Объем торгов: %d":                               "Volume: %d",
+	"Дата обновления: %s":                            "Updated: %s",
+	"Топ %d растущих акций на MOEX:":                 "Top %d gaining stocks on MOEX:",
+	"Топ %d падающих акций на MOEX:":                 "Top %d losing stocks on MOEX:",
+	"Результаты поиска по запросу '%s':":             "Search results for '%s':",
+	"Ширина рынка (универсум %s):":                   "Market breadth (universe %s):",
+	"Акций: %d":                                      "Stocks: %d",
+	"Растут: %d, падают: %d, без изменений: %d":      "Advancing: %d, declining: %d, unchanged: %d",
+	"Среднее изменение: %.2f%%":                      "Average change: %.2f%%",
+	"Изменение с учетом весов индекса %s: %.2f%%":    "Change weighted by %s index weights: %.2f%%",
+	"Суммарный объем торгов: %d":                     "Total volume: %d",
+	"Финансовые новости за %s:":                      "Financial news for %s:",
+	"Результаты поиска новостей по запросу '%s':":    "News search results for '%s':",
+	"Новости, связанные с акцией %s:":                "News related to %s:",
+	"Источник: %s":                                   "Source: %s",
+	"Опубликовано: %s":                               "Published: %s",
+	"Кратко: %s":                                     "Summary: %s",
+	"Категория: %s":                                  "Category: %s",
+	"Перевод: %s":                                    "Translation: %s",
+	"Сводка новостей за %s (всего %d):":              "News summary for %s (%d total):",
+	"Загрузка архива новостей за %s – %s (дней: %d)": "News archive load for %s – %s (days: %d)",
+	"Запросов к NewsAPI: %d":                         "NewsAPI requests: %d",
+	"Получено статей: %d":                            "Articles fetched: %d",
+	"Сохранено уникальных новостей: %d":              "Unique news items saved: %d",
+	"Пропущено повторов: %d":                         "Duplicates skipped: %d",
+	"NewsAPI отдал не все страницы за дни: %s":       "NewsAPI did not return all pages for days: %s",
+	"Не удалось загрузить:":                          "Failed to load:",
+	"инструмент %s недоступен клиенту %s":            "tool %s is not available to client %s",
+	"quota_exceeded: исчерпана суточная квота клиента %s (%d вызовов), она обновится в 00:00 МСК":                                                                                                      "quota_exceeded: daily quota of client %s (%d calls) is exhausted, it resets at 00:00 MSK",
+	"quota_exceeded: превышена частота вызовов клиента %s (%g в минуту), повторите через %d с":                                                                                                         "quota_exceeded: client %s exceeded the call rate (%g per minute), retry in %d s",
+	"Еще новостей не поместилось в ответ: %d. Уточните запрос или запросите меньше новостей":                                                                                                           "More news did not fit into the response: %d. Refine the query or request fewer items",
+	"\n\n… результат сокращен до %d символов, опущено символов: %d. Уточните запрос или запросите меньше данных":                                                                                       "\n\n… result truncated to %d characters, %d characters omitted. Refine the query or request less data",
+	"Подробность новостей: headline — только заголовки, summary — с описанием (по умолчанию), full — с полным текстом":                                                                                 "News detail: headline — titles only, summary — with description (default), full — with full text",
+	"Получить полный текст статьи новости: страница загружается по ссылке новости (с учетом robots.txt сайта), из нее извлекается текст без меню и рекламы":                                            "Get the full text of a news article: the page is downloaded from the news link (respecting the site's robots.txt) and the text is extracted without menus and ads",
+	"ID новости из результатов инструментов новостей":                                                                                                                                                  "News ID from the results of the news tools",
+	"не удалось получить полный текст новости: %v":                                                                                                                                                     "failed to get the full text of the news: %v",
+	"Найти сохраненные новости, связанные с указанной общими тикерами, темами и словами заголовка (в пределах %d дней до и после нее), в хронологическом порядке — чтобы восстановить развитие сюжета": "Find stored news related to the given one by shared tickers, topics and title words (within %d days before and after it), in chronological order — to reconstruct how the story developed",
+	"Сколько связанных новостей вернуть (по умолчанию %d, максимум %d)":                                                                                                                                "How many related news items to return (default %d, maximum %d)",
+	"не удалось найти связанные новости: %v":                                                                                                                                                           "failed to find related news: %v",
+	"Не найдено новостей, связанных с «%s»":                                                                                                                                                            "No news related to “%s” found",
+	"Новости, связанные с «%s» от %s, в хронологическом порядке:":                                                                                                                                      "News related to “%s” of %s, in chronological order:",
+	"Общие тикеры: %s": "Shared tickers: %s",
+	"Общие темы: %s":   "Shared topics: %s",
+	"Получить хронику новостей по тикеру: число новостей и главные заголовки за каждый день периода с отметкой дней всплеска новостей — чтобы сопоставить движения цены с новостным фоном": "Get a news timeline for a ticker: the number of news items and top headlines for each day of the period, with news burst days marked — to correlate price moves with the news flow",
+	"Первый день периода в формате YYYY-MM-DD (по умолчанию %d дней назад)":                                       "First day of the period in YYYY-MM-DD format (default %d days ago)",
+	"Последний день периода в формате YYYY-MM-DD, включительно (по умолчанию сегодня); период не длиннее %d дней": "Last day of the period in YYYY-MM-DD format, inclusive (default today); the period may not exceed %d days",
+	"Сколько заголовков показать за каждый день (по умолчанию %d)":                                                "How many headlines to show per day (default %d)",
+	"не удалось получить хронику новостей: %v":                                                                    "failed to get the news timeline: %v",
+	"Не найдено новостей, связанных с акцией %s, за %s – %s":                                                      "No news related to %s found for %s – %s",
+	"Хроника новостей %s за %s – %s (всего %d):":                                                                  "News timeline of %s for %s – %s (%d total):",
+	"(всплеск новостей)": "(news burst)",
+	"Загрузить с Московской Биржи историю дневных свечей тикеров за несколько лет и сохранить ее, чтобы аналитика (корреляции, технические индикаторы, сравнение доходностей) работала по полной истории. Прерванная загрузка продолжается с места остановки, повторная загружает только новые дни": "Load several years of daily candles for tickers from the Moscow Exchange and store them so that analytics (correlations, technical indicators, return comparison) work on the full history. An interrupted load resumes where it stopped, a repeated one loads only new days",
+	"Тикеры акций (по умолчанию все тикеры универсумов из конфигурации)":                  "Stock tickers (default: all tickers of the configured universes)",
+	"Глубина истории в годах (по умолчанию %d, не более %d)":                              "History depth in years (default %d, maximum %d)",
+	"не удалось загрузить историю котировок: %v":                                          "failed to load the quote history: %v",
+	"Загрузка истории дневных свечей за %s – %s: тикеров %d, сохранено свечей %d\n\n":     "Daily candle history load for %s – %s: %d tickers, %d candles saved\n\n",
+	"- %s: ошибка после %d свечей: %s\n":                                                  "- %s: error after %d candles: %s\n",
+	"- %s: история уже загружена\n":                                                       "- %s: history already loaded\n",
+	"- %s: догружено свечей %d\n":                                                         "- %s: %d missing candles loaded\n",
+	"- %s: загружено свечей %d\n":                                                         "- %s: %d candles loaded\n",
+	"\nНе загружено тикеров: %d. Повторный запуск продолжит загрузку с места остановки\n": "\nTickers not loaded: %d. Running again resumes the load where it stopped\n",
+	"Выгрузить историю котировок акции или новости в CSV или JSON для анализа в таблицах и pandas. Небольшая выгрузка возвращается в ответе, крупная сохраняется в хранилище сервера, и в ответе указывается путь или ссылка на файл": "Export a stock's quote history or news as CSV or JSON for analysis in spreadsheets and pandas. A small export is returned in the response, a large one is saved to the server's storage and the response contains the file path or link",
+	"Набор данных: history — свечи котировок акции, news — новости тикера или поиска":                                                               "Dataset: history — stock quote candles, news — news for a ticker or a search",
+	"Формат файла: csv (по умолчанию) или json":                                                                                                     "File format: csv (default) or json",
+	"Тикер акции; обязателен для history, для news — новости тикера":                                                                                "Stock ticker; required for history, for news — news about the ticker",
+	"Интервал свечей для history: 1m, 10m, 1h или 1d (по умолчанию 1d)":                                                                             "Candle interval for history: 1m, 10m, 1h or 1d (default 1d)",
+	"Ключевое слово поиска новостей для news":                                                                                                       "News search keyword for news",
+	"Начало периода в формате YYYY-MM-DD или YYYY-MM-DD HH:MM по московскому времени":                                                               "Start of the period in YYYY-MM-DD or YYYY-MM-DD HH:MM format, Moscow time",
+	"Куда поместить выгрузку: auto (по умолчанию) — в ответ, если она небольшая, иначе в хранилище; inline — только в ответ; storage — в хранилище": "Where to put the export: auto (default) — in the response if it is small, otherwise to storage; inline — only in the response; storage — to storage",
+	"для выгрузки истории котировок укажите ticker":                                                                                                 "specify ticker to export the quote history",
+	"не удалось выгрузить данные: %v":                                                                                                               "failed to export data: %v",
+	"Выгрузка %s: строк %d, %d байт\n":                                                                                                              "Export %s: %d rows, %d bytes\n",
+	"Файл сохранен: %s\n": "File saved: %s\n",
+	"Построить график котировок акции за период в PNG: свечи или линию цены закрытия с объемами торгов. Клиенты, которые умеют показывать изображения, выводят его пользователю": "Draw a PNG chart of a stock's quotes for a period: candles or a closing price line with trading volumes. Clients that can display images show it to the user",
+	"Вид графика: candles — свечи (по умолчанию), line — линия цены закрытия": "Chart style: candles (default) or line — closing price line",
+	"Ширина изображения в пикселях (по умолчанию %d)":                         "Image width in pixels (default %d)",
+	"Высота изображения в пикселях (по умолчанию %d)":                         "Image height in pixels (default %d)",
+	"График котировок": "Quote chart",
+	"Свечной график акции в PNG за период по умолчанию: месяц для дневных свечей (interval 1d), сутки для внутридневных (1m, 10m, 1h)": "PNG candlestick chart of a stock for the default period: a month for daily candles (interval 1d), a day for intraday ones (1m, 10m, 1h)",
+	"не удалось построить график: %v":                                                                                   "failed to draw the chart: %v",
+	"нет свечей %s с интервалом %s за указанный период":                                                                 "no %s candles with interval %s for the specified period",
+	"График %s (%s) за %s – %s: свечей %d, закрытие %.2f, изменение за период %+.2f%%":                                  "Chart of %s (%s) for %s – %s: %d candles, close %.2f, change over the period %+.2f%%",
+	"Добавить спарклайн — строку из блочных символов ▁▂▃▄▅▆▇█, показывающую движение цены закрытия (по умолчанию true)": "Add a sparkline — a line of block characters ▁▂▃▄▅▆▇█ showing the closing price movement (default true)",
+	"Цена закрытия (торговых дней: %d): %s %.2f → %.2f":                                                                 "Closing price (%d trading days): %s %.2f → %.2f",
+	"Цена закрытия: %s\n": "Closing price: %s\n",
+	"Подписаться на изменения цен акций: сервер опрашивает котировки каждые %v и присылает уведомление (notifications/message, logger quotes), когда цена изменилась с прошлого уведомления на заданный процент. Подписка действует до unsubscribe_quotes или закрытия сессии": "Subscribe to stock price changes: the server polls quotes every %v and sends a notification (notifications/message, logger quotes) when the price has changed by the given percentage since the previous notification. The subscription lasts until unsubscribe_quotes or the end of the session",
+	"Тикеры акций (например, SBER, GAZP); в подписках сессии не больше %d бумаг":                                               "Stock tickers (e.g. SBER, GAZP); a session may subscribe to at most %d securities",
+	"Изменение цены с прошлого уведомления в процентах, о котором нужно сообщать (по умолчанию %.1f%%; 0 — о любом изменении)": "Price change since the previous notification, in percent, to report (default %.1f%%; 0 — any change)",
+	"Отменить подписку на изменения цен акций":                                                                                 "Cancel a subscription to stock price changes",
+	"Тикеры акций (по умолчанию все подписки сессии)":                                                                          "Stock tickers (default: all subscriptions of the session)",
+	"подписки доступны только в сессии MCP, которая принимает уведомления":                                                     "subscriptions are only available in an MCP session that accepts notifications",
+	"не удалось получить котировки: %v":                                                                                        "failed to get quotes: %v",
+	"не найдены котировки: %s":                                                                                                 "quotes not found: %s",
+	"в подписках сессии не может быть больше %d бумаг, сейчас %d: отмените часть подписок через unsubscribe_quotes":            "a session may subscribe to at most %d securities, currently %d: cancel some subscriptions with unsubscribe_quotes",
+	"Подписка оформлена: %s. Уведомление придет при изменении цены на %.2f%% и больше, котировки опрашиваются каждые %v\n\n":   "Subscribed: %s. A notification is sent when the price changes by %.2f%% or more, quotes are polled every %v\n\n",
+	"Подписок на котировки нет":                                                                                                "No quote subscriptions",
+	"Подписок на указанные бумаги нет\n\n":                                                                                     "No subscriptions to the specified securities\n\n",
+	"Подписка отменена: %s\n\n":                                                                                                "Unsubscribed: %s\n\n",
+	"%s: %.2f (%+.2f%% с прошлого уведомления, %+.2f%% за день)":                                                               "%s: %.2f (%+.2f%% since the previous notification, %+.2f%% for the day)",
+	"Подписок на котировки больше нет":                                                                                         "No quote subscriptions left",
+	"Подписки сессии (%d):\n":                                                                                                  "Session subscriptions (%d):\n",
+	"- %s: порог %.2f%%, цена отсчета %.2f\n":                                                                                  "- %s: threshold %.2f%%, reference price %.2f\n",
+	"Загружена история тикеров: %d из %d":                                                                                      "History loaded for tickers: %d of %d",
+	"История загружена: сохранено свечей %d":                                                                                   "History loaded: %d candles saved",
+	"Получено котировок: %d из %d":                                                                                             "Quotes received: %d of %d",
+	"Проверено объемов: %d из %d":                                                                                              "Volumes checked: %d of %d",
+	"Проверено гэпов: %d из %d":                                                                                                "Gaps checked: %d of %d",
+	"Доходности %s (%d из %d)":                                                                                                 "Returns of %s (%d of %d)",
+	"Доходности индекса %s":                                                                                                    "Returns of index %s",
+	"Загрузка данных для выгрузки":                                                                                             "Loading data for export",
+	"Формирование %s: строк %d":                                                                                                "Building %s: %d rows",
+	"Сохранение выгрузки: %d байт":                                                                                             "Saving export: %d bytes",
+	"Задать настройку сессии, которая подставляется в аргументы инструментов, если они не указаны в вызове: язык ответа (lang), режим торгов (board), размер списков (limit), портфель (portfolio) или список наблюдения (watchlist). Настройки действуют до закрытия сессии": "Set a session preference that is filled into tool arguments when the call omits them: response language (lang), trading board (board), list size (limit), portfolio (portfolio) or watchlist (watchlist). Preferences last until the session is closed",
+	"Название настройки": "Preference name",
+	"Значение: код языка (%s), код режима торгов MOEX (например, TQBR), число от 1 до %d или имя портфеля либо списка наблюдения. Пустое значение сбрасывает настройку": "Value: language code (%s), MOEX trading board code (e.g. TQBR), a number from 1 to %d, or a portfolio or watchlist name. An empty value resets the preference",
+	"Получить настройки текущей сессии":      "Get the preferences of the current session",
+	"настройки доступны только в сессии MCP": "preferences are only available in an MCP session",
+	"Настройка %s сброшена\n\n":              "Preference %s reset\n\n",
+	"неизвестный язык %s, доступны: %s":      "unknown language %s, available: %s",
+	"некорректный код режима торгов %s: ожидаются латинские буквы и цифры, например TQBR": "invalid trading board code %s: expected Latin letters and digits, e.g. TQBR",
+	"размер списков должен быть числом от 1 до %d":                                        "list size must be a number from 1 to %d",
+	"имя не может быть длиннее %d символов":                                               "name cannot be longer than %d characters",
+	"Настройка %s = %v\n\n":        "Preference %s = %v\n\n",
+	"язык из конфигурации сервера": "language from the server configuration",
+	"основной режим торгов бумаги": "primary board of the security",
+	"свой у каждого инструмента":   "tool-specific",
+	"Настройки сессии:\n":          "Session preferences:\n",
+	"- %s: %v\n":                   "- %s: %v\n",
+	"- %s: не задана (%s)\n":       "- %s: not set (%s)\n",
+	"Получить справочные данные бумаги MOEX по тикеру, ISIN или FIGI: название, ISIN, основной и остальные режимы торгов, размер лота и уровень листинга": "Get MOEX reference data for a security by ticker, ISIN or FIGI: name, ISIN, primary and other trading boards, lot size and listing level",
+	"Тикер (например, SBER), ISIN (например, RU0009029540) или FIGI (например, BBG004730N88)":                                                             "Ticker (e.g. SBER), ISIN (e.g. RU0009029540) or FIGI (e.g. BBG004730N88)",
+	"не удалось получить справочник бумаг: %v":                                                                                                            "failed to get the securities directory: %v",
+	"бумага с ISIN %s не торгуется на рынке акций MOEX":                                                                                                   "no security with ISIN %s trades on the MOEX equity market",
+	"бумага %s не найдена в справочнике MOEX: для поиска по названию используйте search_stocks":                                                           "security %s not found in the MOEX directory: use search_stocks to search by name",
+	"%s — %s\n":                   "%s — %s\n",
+	"Полное название: %s\n":       "Full name: %s\n",
+	"Название латиницей: %s\n":    "Latin name: %s\n",
+	"ISIN: %s\n":                  "ISIN: %s\n",
+	"Основной режим торгов: %s\n": "Primary board: %s\n",
+	"Режимы торгов: %s\n":         "Trading boards: %s\n",
+	"Размер лота: %d шт.\n":       "Lot size: %d shares\n",
+	"Уровень листинга: %d\n":      "Listing level: %d\n",
+	"Тикер акции (например, SBER, GAZP, LKOH), ее ISIN (RU0009029540) или FIGI (BBG004730N88); для бумаг других бирж — тикер с префиксом биржи, например NASDAQ:AAPL или XETRA:SAP": "Stock ticker (e.g. SBER, GAZP, LKOH), its ISIN (RU0009029540) or FIGI (BBG004730N88); for other exchanges, the ticker with an exchange prefix, e.g. NASDAQ:AAPL or XETRA:SAP",
+	"FIGI %s не соответствует бумаге, торгующейся на рынке акций MOEX":                                                                                                              "FIGI %s does not match a security traded on the MOEX equity market",
+	"Режим торгов MOEX: TQBR — акции, TQTF — биржевые фонды, FQBR — иностранные акции (по умолчанию основной режим бумаги)":                                                         "MOEX trading board: TQBR for shares, TQTF for ETFs, FQBR for foreign shares (defaults to the security's primary board)",
+	"Рынок MOEX: shares, foreignshares или bonds (по умолчанию определяется по режиму торгов)":                                                                                      "MOEX market: shares, foreignshares or bonds (derived from the trading board by default)",
+	"Режим торгов: %s": "Trading board: %s",
+	"Получить информацию о биржевом (ETF, БПИФ) или паевом фонде на MOEX: цена пая, индикативная стоимость пая (iNAV) и отклонение от нее, комиссия управляющего, базовый индекс и управляющая компания": "Get information about an exchange-traded (ETF, BPIF) or mutual fund on MOEX: unit price, indicative NAV (iNAV) and deviation from it, management fee, underlying index and management company",
+	"Тикер пая фонда (например, TMOS, SBMX, LQDT)": "Fund unit ticker (e.g. TMOS, SBMX, LQDT)",
+	"Найти биржевые и паевые фонды на MOEX по названию, управляющей компании, базовому индексу, типу и комиссии": "Find exchange-traded and mutual funds on MOEX by name, management company, underlying index, type and fee",
+	"Подстрока тикера, названия, управляющей компании или индекса (по умолчанию все фонды)":                      "Substring of the ticker, name, management company or index (all funds by default)",
+	"Тип фонда: etf — биржевые фонды (ETF, БПИФ), mutual — паевые фонды (ПИФ); по умолчанию все":                 "Fund type: etf for exchange-traded funds (ETF, BPIF), mutual for mutual funds (PIF); all by default",
+	"Подстрока базового индекса, например IMOEX или RGBITR":                                                      "Substring of the underlying index, e.g. IMOEX or RGBITR",
+	"Наибольшая комиссия управляющего, % годовых; фонды с неизвестной комиссией исключаются":                     "Maximum management fee, % per year; funds with an unknown fee are excluded",
+	"Валюта торгов пая, например RUB или USD":                                                                    "Trading currency of the unit, e.g. RUB or USD",
+	"Сортировка: value — по обороту за день (по умолчанию), fee — по комиссии, change — по изменению цены":       "Sort order: value by daily turnover (default), fee by management fee, change by price change",
+	"фондов": "funds",
+	"не удалось получить информацию о фонде: %v": "failed to get fund information: %v",
+	"фонд %s не найден в режимах торгов паями фондов MOEX: для акций используйте get_stock_info, для поиска фондов — search_etfs": "fund %s was not found on the MOEX fund boards: use get_stock_info for shares and search_etfs to find funds",
+	"не удалось выполнить поиск фондов: %v": "failed to search funds: %v",
+	"По запросу не найдено фондов":          "No funds found for the query",
+	"Фонд %s (%s):":       "Fund %s (%s):",
+	"Полное название: %s": "Full name: %s",
+	"Тип: паевой инвестиционный фонд (ПИФ)":                                "Type: mutual fund (PIF)",
+	"Тип: биржевой фонд (ETF/БПИФ)":                                        "Type: exchange-traded fund (ETF/BPIF)",
+	"Управляющая компания: %s":                                             "Management company: %s",
+	"Базовый индекс: %s":                                                   "Underlying index: %s",
+	"Комиссия управляющего: %.2f%% годовых":                                "Management fee: %.2f%% per year",
+	"Комиссия управляющего: нет данных":                                    "Management fee: no data",
+	"Цена пая: %.4f %s (%+.2f%% за день)":                                  "Unit price: %.4f %s (%+.2f%% today)",
+	"Индикативная стоимость пая (iNAV): %.4f %s, отклонение цены: %+.2f%%": "Indicative NAV (iNAV): %.4f %s, price deviation: %+.2f%%",
+	"Оборот за день: %.0f %s":                                              "Daily turnover: %.0f %s",
+	"Режим торгов: %s, лот: %d пай(ев)":                                    "Trading board: %s, lot: %d unit(s)",
+	"Фонды по запросу '%s':":                                               "Funds matching '%s':",
+	"Фонды на MOEX:":                                                       "Funds on MOEX:",
+	"индекс %s":                                                            "index %s",
+	"комиссия %.2f%%":                                                      "fee %.2f%%",
+	"оборот %.0f %s":                                                       "turnover %.0f %s",
+	"Шаг цены: %g\n":                                                       "Price step: %g\n",
+	"Маржинальные условия %s (список от %s):":                              "Margin terms at %s (list as of %s):",
+	"  Покупка с плечом: недоступна":                                       "  Leveraged long: not available",
+	"  Покупка с плечом: ставка риска %.1f%%":                              "  Leveraged long: risk rate %.1f%%",
+	"  Покупка с плечом: доступна":                                         "  Leveraged long: available",
+	"  Шорт: недоступен":                                                   "  Short: not available",
+	"  Шорт: ставка риска %.1f%%":                                          "  Short: risk rate %.1f%%",
+	"  Шорт: доступен":                                                     "  Short: available",
+	"  Плата за перенос шорта: %.2f%% годовых":                             "  Short carry fee: %.2f%% per year",
+	"Маржинальные списки: бумаги нет, шорт недоступен":                     "Margin lists: not listed, short selling not available",
+
+	// Аномальные объемы
+	"Найти акции с аномальным объемом торгов: объем за день в сравнении со средним за %d предыдущих сессий. Во время торгов объем дня еще не набран, поэтому к вечеру отношение растет": "Find stocks with unusual trading volume: the day's volume compared with the average of the previous %d sessions. During trading the day's volume is still building up, so the ratio grows towards the close",
+	"Во сколько раз объем должен превышать средний (по умолчанию %g)":                    "How many times the volume must exceed the average (default %g)",
+	"Количество акций с наибольшим превышением (по умолчанию %d, максимум %d)":           "Number of stocks with the largest excess (default %d, maximum %d)",
+	"не удалось найти аномальные объемы: %v":                                             "failed to find unusual volumes: %v",
+	"Аномальные объемы в универсуме %s (объем выше среднего в %g раза и более):\n":       "Unusual volumes in universe %s (volume at least %g times the average):\n",
+	"Аномальных объемов не найдено\n":                                                    "No unusual volumes found\n",
+	"%d. %s (%s): объем %d при среднем %.0f — в %.1f раза выше; цена %.2f ₽ (%+.2f%%)\n": "%d. %s (%s): volume %d vs average %.0f, %.1f times higher; price %.2f RUB (%+.2f%%)\n",
+	"Показано %d из %d\n":   "Showing %d of %d\n",
+	"\nПроверено бумаг: %d": "\nSecurities checked: %d",
+	", пропущено без истории объемов не менее %d сессий: %d": ", skipped without at least %d sessions of volume history: %d",
+	"Нужно загрузить историю (backfill_history): %s":         "History needs to be loaded (backfill_history): %s",
+	" и еще %d": " and %d more",
+
+	// Заголовок графика
+	"%s %s  %s – %s  закрытие %.2f (%+.2f%%)": "%s %s  %s – %s  close %.2f (%+.2f%%)",
+
+	// Стакан и лента сделок
+	"Получить стакан заявок по акции на MOEX: лучшие цены покупки и продажи с объемами, спред и дисбаланс спроса и предложения":                             "Get the order book for a MOEX stock: best bid and ask prices with volumes, the spread and the supply/demand imbalance",
+	"Количество уровней с каждой стороны (по умолчанию %d, не более %d)":                                                                                    "Number of levels on each side (default %d, at most %d)",
+	"Получить ленту последних сделок по акции на MOEX: время, цена, объем и направление. Если сделок больше %d, они дополнительно сворачиваются по минутам": "Get the latest trades for a MOEX stock: time, price, volume and side. If there are more than %d trades, they are also aggregated by minute",
+	"Количество последних сделок (по умолчанию %d, не более %d)":                                                                                            "Number of latest trades (default %d, at most %d)",
+	"не удалось получить стакан: %v":                          "failed to get the order book: %v",
+	"не удалось получить ленту сделок: %v":                    "failed to get the trade feed: %v",
+	"Стакан %s на %s:\n":                                      "Order book %s at %s:\n",
+	"Лучшая покупка: %.2f ₽, лучшая продажа: %.2f ₽\n":        "Best bid: %.2f ₽, best ask: %.2f ₽\n",
+	"Спред: %.2f ₽ (%.3f%% от средней цены %.2f ₽)\n":         "Spread: %.2f ₽ (%.3f%% of the mid price %.2f ₽)\n",
+	"Спред: нет данных, заявки есть только с одной стороны\n": "Spread: no data, orders are on one side only\n",
+	"Объем заявок: покупка %d лотов, продажа %d лотов\n":      "Order volume: bids %d lots, asks %d lots\n",
+	"Дисбаланс: %+.2f — перевес покупателей\n":                "Imbalance: %+.2f — buyers dominate\n",
+	"Дисбаланс: %+.2f — перевес продавцов\n":                  "Imbalance: %+.2f — sellers dominate\n",
+	"Дисбаланс: %+.2f — спрос и предложение сбалансированы\n": "Imbalance: %+.2f — supply and demand are balanced\n",
+	"\nПродажа:\n": "\nAsks:\n",
+	"Покупка:\n":   "Bids:\n",
+	"Последние %d сделок %s с %s по %s (МСК):\n":                                                          "Last %d trades of %s from %s to %s (MSK):\n",
+	"Средневзвешенная цена: %.2f ₽, оборот: %.0f ₽\n":                                                     "Volume-weighted price: %.2f ₽, turnover: %.0f ₽\n",
+	"Объем покупок: %d лотов, объем продаж: %d лотов\n":                                                   "Buy volume: %d lots, sell volume: %d lots\n",
+	"\nПо минутам (от последней):\n":                                                                      "\nBy minute (latest first):\n",
+	"| Минута | Открытие | Макс. | Мин. | Закрытие | Сделок | Покупки, лотов | Продажи, лотов | VWAP |\n": "| Minute | Open | High | Low | Close | Trades | Buys, lots | Sells, lots | VWAP |\n",
+	"\nСделки (от последней):\n":                                                                          "\nTrades (latest first):\n",
+	" покупка": " buy",
+	" продажа": " sell",
+
+	// Портфель и журнал сделок
+	"Имя портфеля (по умолчанию default)":                             "Portfolio name (default: default)",
+	"Получить позиции портфеля и их оценку по текущим ценам":          "Get portfolio positions valued at current prices",
+	"Добавить акции в портфель; средняя цена позиции пересчитывается": "Add shares to the portfolio; the position's average price is recalculated",
+	"Количество акций":                                                                            "Number of shares",
+	"Цена покупки (по умолчанию текущая цена)":                                                    "Purchase price (default: current price)",
+	"Уменьшить или закрыть позицию в портфеле":                                                    "Reduce or close a portfolio position",
+	"Количество акций (по умолчанию вся позиция)":                                                 "Number of shares (default: the whole position)",
+	"Цена продажи для истории сделок и расчета налогов (по умолчанию текущая цена)":               "Sale price for the trade history and tax calculation (default: current price)",
+	"Оценить стоимость заявки с учетом размера лота, шага цены и комиссии брокера":                "Estimate the cost of an order taking into account the lot size, price step and broker commission",
+	"Количество акций; заявка округляется вверх до целых лотов":                                   "Number of shares; the order is rounded up to whole lots",
+	"Направление сделки: buy — покупка, sell — продажа":                                           "Trade side: buy or sell",
+	"Цена лимитной заявки, округляется до шага цены (по умолчанию текущая цена)":                  "Limit order price, rounded to the price step (default: current price)",
+	"Применить исторический кризис к текущим позициям портфеля и оценить гипотетические просадки": "Apply a historical crisis to the current portfolio positions and estimate hypothetical drawdowns",
+	"Сценарий": "Scenario",
+	"не удалось получить портфель: %v":                       "failed to get the portfolio: %v",
+	"Портфель %s пуст":                                       "Portfolio %s is empty",
+	"Портфель %s:\n\n":                                       "Portfolio %s:\n\n",
+	"%d. %s: %d шт. по %.2f ₽ (средняя %.2f ₽)\n":            "%d. %s: %d shares at %.2f ₽ (average %.2f ₽)\n",
+	"   Лотов: %d по %d шт.":                                 "   Lots: %d of %d shares",
+	", неполный лот: %d шт.":                                 ", odd lot: %d shares",
+	"   Стоимость: %.2f ₽, результат: %+.2f ₽ (%+.2f%%)\n":   "   Value: %.2f ₽, P&L: %+.2f ₽ (%+.2f%%)\n",
+	"\nИтого: %.2f ₽, вложено: %.2f ₽, результат: %+.2f ₽\n": "\nTotal: %.2f ₽, invested: %.2f ₽, P&L: %+.2f ₽\n",
+	"Комиссия брокера при продаже всех позиций: %.2f ₽, стоимость за ее вычетом: %.2f ₽\n": "Broker commission for selling all positions: %.2f ₽, value net of it: %.2f ₽\n",
+	"не удалось добавить позицию: %v":  "failed to add the position: %v",
+	"не удалось изменить позицию: %v":  "failed to change the position: %v",
+	"Позиция %s в портфеле %s":         "Position %s in portfolio %s",
+	" будет открыта:\n":                " will be opened:\n",
+	" открыта:\n":                      " opened:\n",
+	"   Количество: %d шт.\n":          "   Quantity: %d shares\n",
+	"   Средняя цена: %.2f ₽\n":        "   Average price: %.2f ₽\n",
+	" будет закрыта:\n":                " will be closed:\n",
+	" закрыта:\n":                      " closed:\n",
+	"   Количество: %d → 0 шт.\n":      "   Quantity: %d → 0 shares\n",
+	"   Количество: %d → %d шт.\n":     "   Quantity: %d → %d shares\n",
+	"   Средняя цена: %.2f → %.2f ₽\n": "   Average price: %.2f → %.2f ₽\n",
+	"   Лотов: %d по %d шт.\n":         "   Lots: %d of %d shares\n",
+	"   Лотов: %d → %d по %d шт.\n":    "   Lots: %d → %d of %d shares\n",
+	"   Неполный лот: %d шт. можно продать только в режиме торгов неполными лотами\n": "   Odd lot: %d shares can only be sold on the odd-lot board\n",
+	"не удалось оценить заявку: %v": "failed to estimate the order: %v",
+	"Покупка":                           "Buy",
+	"Продажа":                           "Sell",
+	"текущая цена":                      "current price",
+	"цена заявки":                       "order price",
+	"%s %d шт. %s:\n":                   "%s %d shares of %s:\n",
+	"   Лотов: %d по %d шт. (%d шт.)\n": "   Lots: %d of %d shares (%d shares)\n",
+	"   Заявка исполняется только целыми лотами: %d шт. вместо %d\n":                    "   Orders are filled in whole lots only: %d shares instead of %d\n",
+	"   Размер лота неизвестен: бумаги нет в справочнике, заявка рассчитана поштучно\n": "   Lot size unknown: the security is not in the directory, the order is calculated per share\n",
+	"   Цена: %.2f ₽ (%s)":      "   Price: %.2f ₽ (%s)",
+	", шаг цены %g ₽":           ", price step %g ₽",
+	"   Сумма сделки: %.2f ₽\n": "   Trade amount: %.2f ₽\n",
+	"   Комиссия брокера не учитывается: тариф не задан в конфигурации (broker)\n": "   Broker commission not included: no tariff is configured (broker)\n",
+	"   Комиссия брокера: %.2f ₽ (%g%%":                                     "   Broker commission: %.2f ₽ (%g%%",
+	", не меньше %.2f ₽":                                                    ", at least %.2f ₽",
+	"Итого к зачислению: %.2f ₽\n":                                          "Total to be credited: %.2f ₽\n",
+	"Итого к списанию: %.2f ₽\n":                                            "Total to be debited: %.2f ₽\n",
+	"не удалось выполнить стресс-тест: %v":                                  "failed to run the stress test: %v",
+	"Стресс-тест портфеля %s: %s (%s — %s)\n":                               "Stress test of portfolio %s: %s (%s — %s)\n",
+	"Гипотетический результат по позициям:\n":                               "Hypothetical result by position:\n",
+	"%d. %s: нет исторических данных за период\n":                           "%d. %s: no historical data for the period\n",
+	"%d. %s: %+.2f%% за период, макс. просадка %.2f%%, %+.2f ₽ от %.2f ₽\n": "%d. %s: %+.2f%% over the period, max drawdown %.2f%%, %+.2f ₽ of %.2f ₽\n",
+	"   Истории бумаги нет, использована динамика %s\n":                     "   No history for the security, the performance of %s was used\n",
+	"\nПортфель: %.2f ₽ → %.2f ₽ (%+.2f%%)\n":                               "\nPortfolio: %.2f ₽ → %.2f ₽ (%+.2f%%)\n",
+	"Средневзвешенная максимальная просадка: %.2f%%\n":                      "Weighted average maximum drawdown: %.2f%%\n",
+	"\nДля %d позиций нет истории за период; загрузите исторические котировки, чтобы учесть их.\n": "\n%d positions have no history for the period; load historical quotes to include them.\n",
+	"параметр window_days должен быть числом":                 "parameter window_days must be a number",
+	"не удалось рассчитать риски портфеля: %w":                "failed to calculate portfolio risks: %w",
+	"Портфель %s: %.2f ₽, окно расчета %d дней (%s — %s)\n\n": "Portfolio %s: %.2f ₽, %d-day window (%s — %s)\n\n",
+	"Позиции:\n":            "Positions:\n",
+	": %.2f ₽, доля %.2f%%": ": %.2f ₽, weight %.2f%%",
+	", нет истории котировок за период\n":                                      ", no price history for the period\n",
+	", доходность %+.2f%%, макс. просадка %.2f%%, волатильность %.2f%% в день": ", return %+.2f%%, max drawdown %.2f%%, volatility %.2f%% per day",
+	", бета %.2f":                                               ", beta %.2f",
+	", бета нет данных":                                         ", beta: no data",
+	"\nКонцентрация по секторам:\n":                             "\nConcentration by sector:\n",
+	"\nИндекс концентрации HHI: %.0f\n":                         "\nHHI concentration index: %.0f\n",
+	"Бета портфеля к IMOEX: %.2f\n":                             "Portfolio beta to IMOEX: %.2f\n",
+	"Бета портфеля к IMOEX: нет данных\n":                       "Portfolio beta to IMOEX: no data\n",
+	"Бета не рассчитана: нет истории индекса IMOEX за период\n": "Beta not calculated: no IMOEX index history for the period\n",
+	"Доходность текущего состава за период: %+.2f%%, максимальная просадка: %.2f%%\n":                                                         "Return of the current holdings over the period: %+.2f%%, maximum drawdown: %.2f%%\n",
+	"Доходность и просадка текущего состава за период: нет данных\n":                                                                          "Return and drawdown of the current holdings over the period: no data\n",
+	"\nНет истории за период у позиций %s; загрузите ее инструментом backfill_history, чтобы учесть их.\n":                                    "\nPositions %s have no history for the period; load it with backfill_history to include them.\n",
+	"Записать совершенную сделку в журнал портфеля с ценой, комиссией и датой; позиция изменяется так же, как add_position и remove_position": "Record an executed trade in the portfolio journal with its price, commission and date; the position changes just as with add_position and remove_position",
+	"Цена сделки за акцию": "Trade price per share",
+	"Комиссия брокера за сделку, ₽ (по умолчанию по тарифу broker из конфигурации)":    "Broker commission for the trade, ₽ (default: per the broker tariff from the configuration)",
+	"Дата сделки в формате YYYY-MM-DD (по умолчанию сегодня)":                          "Trade date in YYYY-MM-DD format (default: today)",
+	"Получить сделки портфеля за период от новых к старым":                             "Get portfolio trades for a period, newest first",
+	"Тикер акции; по умолчанию сделки по всем бумагам":                                 "Stock ticker; trades in all securities by default",
+	"Первый день периода в формате YYYY-MM-DD (по умолчанию с начала истории)":         "First day of the period in YYYY-MM-DD format (default: from the start of the history)",
+	"Последний день периода в формате YYYY-MM-DD, включительно (по умолчанию сегодня)": "Last day of the period in YYYY-MM-DD format, inclusive (default: today)",
+	"сделок": "trades",
+	"Рассчитать реализованный результат продаж портфеля за период по методу FIFO с учетом комиссий": "Calculate the realized P&L of portfolio sales for a period using FIFO, including commissions",
+	"Первый день периода в формате YYYY-MM-DD (по умолчанию начало текущего года)":                  "First day of the period in YYYY-MM-DD format (default: start of the current year)",
+	"не удалось записать сделку: %v": "failed to record the trade: %v",
+	"\nСделка: ": "\nTrade: ",
+	"не удалось получить историю сделок: %v": "failed to get the trade history: %v",
+	"Сделок за период не найдено":            "No trades found for the period",
+	"История сделок портфеля %s:\n\n":        "Trade history of portfolio %s:\n\n",
+	"ошибка оформления результата: %v":       "failed to format the result: %v",
+	"не удалось рассчитать результат: %v":    "failed to calculate the result: %v",
+	"покупка": "buy",
+	"продажа": "sell",
+	"%s %s: %s %d шт. по %.2f ₽ = %.2f ₽": "%s %s: %s %d shares at %.2f ₽ = %.2f ₽",
+	", комиссия %.2f ₽":                   ", commission %.2f ₽",
+	" (начальный остаток позиции, открытой до ведения журнала)": " (opening balance of a position opened before the journal was kept)",
+	"до ": "until ",
+	"Реализованный результат портфеля %s за %s (FIFO):\n\n":                                               "Realized P&L of portfolio %s for %s (FIFO):\n\n",
+	"Продаж за период не было.\n":                                                                         "There were no sales in the period.\n",
+	"%d. %s: продано %d шт., выручка %.2f ₽, стоимость покупки %.2f ₽, результат %+.2f ₽ (%+.2f%%)\n":     "%d. %s: sold %d shares, proceeds %.2f ₽, cost %.2f ₽, P&L %+.2f ₽ (%+.2f%%)\n",
+	"\nИтого: выручка %.2f ₽, стоимость покупки %.2f ₽, результат %+.2f ₽ (%+.2f%%)\n":                    "\nTotal: proceeds %.2f ₽, cost %.2f ₽, P&L %+.2f ₽ (%+.2f%%)\n",
+	"Бумаг с прибылью: %d, с убытком: %d\n":                                                               "Securities with a gain: %d, with a loss: %d\n",
+	"Комиссии сделок за период: %.2f ₽\n":                                                                 "Trade commissions for the period: %.2f ₽\n",
+	"\nЧасть продаж сопоставлена с начальными остатками позиций по их средней цене.\n":                    "\nSome sales were matched against opening position balances at their average price.\n",
+	"\nДля части продаж покупки не найдены в журнале: их стоимость принята нулевой, результат завышен.\n": "\nFor some sales no purchases were found in the journal: their cost was taken as zero, so the result is overstated.\n",
+
+	// Предпросмотр изменений
+	"Предпросмотр (dry_run): изменения не сохранены. Повторите вызов без dry_run, чтобы применить их.\n\n": "Preview (dry_run): changes were not saved. Call again without dry_run to apply them.\n\n",
+
+	// Списки наблюдения, целевые цены и профили компаний
+	"Имя списка наблюдения (по умолчанию default)":                                    "Watchlist name (default: default)",
+	"Получить бумаги списка наблюдения с текущими котировками и порогами уведомлений": "Get the securities of a watchlist with current quotes and notification thresholds",
+	"Добавить акцию в список наблюдения или изменить ее порог уведомления. Уведомление приходит, когда изменение цены за день по модулю достигает порога":                                           "Add a stock to a watchlist or change its notification threshold. A notification is sent when the absolute daily price change reaches the threshold",
+	"Порог изменения цены за день в процентах, например 3 для ±3%%. Новая бумага без порога получает порог по умолчанию %.1f%% из конфигурации, у бумаги из списка порог без параметра не меняется": "Daily price change threshold in percent, e.g. 3 for ±3%%. A new security without a threshold gets the default %.1f%% from the configuration; for a security already in the list the threshold is kept when the parameter is omitted",
+	"Удалить акцию из списка наблюдения":                                                "Remove a stock from a watchlist",
+	"Получить уведомления о движениях цены, превысивших пороги бумаг списка наблюдения": "Get notifications about price moves that exceeded the thresholds of watchlist securities",
+	"За сколько последних дней показать уведомления (по умолчанию %d)":                  "How many recent days of notifications to show (default %d)",
+	"Рассчитать доходность бумаг списка наблюдения за период по архивным ценам закрытия, доходность равновзвешенной корзины и сравнение с индексом MOEX": "Calculate the returns of watchlist securities for a period from historical closing prices, the equal-weighted basket return and a comparison with the MOEX index",
+	"Период: 1w — неделя, 1m — месяц, 3m, 6m, ytd — с начала года, 1y — год":                                                                             "Period: 1w for a week, 1m for a month, 3m, 6m, ytd for year to date, 1y for a year",
+	"не удалось получить список наблюдения: %v":                                                                                                          "failed to get the watchlist: %v",
+	"Список наблюдения %s пуст":                                 "Watchlist %s is empty",
+	"Список наблюдения %s:\n\n":                                 "Watchlist %s:\n\n",
+	"%d. %s: %.2f ₽ (%+.2f%% за день)\n":                        "%d. %s: %.2f ₽ (%+.2f%% today)\n",
+	" (по умолчанию)":                                           " (default)",
+	"   Порог уведомления: %s\n":                                "   Notification threshold: %s\n",
+	"   Добавлена %s по %.2f ₽\n":                               "   Added on %s at %.2f ₽\n",
+	"   Последнее уведомление: %s\n":                            "   Last notification: %s\n",
+	"не удалось добавить бумагу в список наблюдения: %v":        "failed to add the security to the watchlist: %v",
+	"не удалось удалить бумагу из списка наблюдения: %v":        "failed to remove the security from the watchlist: %v",
+	"%s будет добавлена в список наблюдения %s":                 "%s will be added to watchlist %s",
+	"%s добавлена в список наблюдения %s":                       "%s added to watchlist %s",
+	" по %.2f ₽, порог уведомления %s\n":                        " at %.2f ₽, notification threshold %s\n",
+	"%s будет удалена из списка наблюдения %s\n":                "%s will be removed from watchlist %s\n",
+	"%s удалена из списка наблюдения %s\n":                      "%s removed from watchlist %s\n",
+	"%s в списке наблюдения %s, порог уведомления: %s → %s\n":   "%s in watchlist %s, notification threshold: %s → %s\n",
+	"±%.2f%% (по умолчанию)":                                    "±%.2f%% (default)",
+	"не удалось получить уведомления: %v":                       "failed to get the notifications: %v",
+	"За последние %d дн. уведомлений по списку %s не было":      "No notifications for watchlist %[2]s in the last %[1]d days",
+	"Уведомления списка наблюдения %s за последние %d дн.:\n\n": "Notifications of watchlist %s for the last %d days:\n\n",
+	"не удалось рассчитать доходность списка наблюдения: %v":    "failed to calculate the watchlist returns: %v",
+	"Доходность списка наблюдения %s за %s (%s — %s):\n\n":      "Returns of watchlist %s for %s (%s – %s):\n\n",
+	"%d. %s: %+.2f%% (%.2f → %.2f ₽), макс. просадка %.2f%%":    "%d. %s: %+.2f%% (%.2f → %.2f ₽), max drawdown %.2f%%",
+	", к индексу %+.2f п.п.":                                    ", vs index %+.2f pp",
+	"Равновзвешенная корзина (%d бумаг): %+.2f%%\n":             "Equal-weighted basket (%d securities): %+.2f%%\n",
+	"Бенчмарк %s: нет исторических данных за период\n":          "Benchmark %s: no historical data for the period\n",
+	"Бенчмарк %s: %+.2f%%\n":                                    "Benchmark %s: %+.2f%%\n",
+	"Корзина относительно бенчмарка: %+.2f п.п.\n":              "Basket vs benchmark: %+.2f pp\n",
+	"\nДля %d бумаг нет истории за период; загрузите исторические котировки, чтобы учесть их.\n":                      "\nNo history for the period for %d securities; load historical quotes to include them.\n",
+	"%s (%s): %+.2f%% за день, цена %.2f ₽, порог ±%.2f%%":                                                            "%s (%s): %+.2f%% today, price %.2f ₽, threshold ±%.2f%%",
+	"Задать целевую цену бумаги: собственную или из обзора брокера; нулевая цель удаляет прежнюю цель того же автора": "Set a price target for a security: your own or from a broker's review; a zero target removes the previous target of the same author",
+	"Целевая цена, ₽; 0 — удалить цель":                                                                               "Target price, ₽; 0 removes the target",
+	"Автор прогноза, например брокер; по умолчанию собственная цель пользователя":                                     "Author of the forecast, e.g. a broker; by default the user's own target",
+	"Рекомендация: buy — покупать, hold — держать, sell — продавать":                                                  "Rating: buy, hold or sell",
+	"Комментарий к цели, например горизонт или обоснование":                                                           "Comment on the target, e.g. the horizon or rationale",
+	"Сравнить целевые цены и консенсус аналитиков с текущими котировками: потенциал роста или снижения по каждой цели и по консенсусу. Цели старше %d дней в консенсус не входят": "Compare price targets and the analyst consensus with current quotes: upside or downside for each target and for the consensus. Targets older than %d days are excluded from the consensus",
+	"Тикеры акций, не более %d; по умолчанию все бумаги с заданными целями": "Stock tickers, at most %d; by default all securities with targets",
+	"не удалось задать целевую цену: %v":                                    "failed to set the price target: %v",
+	"не удалось сравнить целевые цены с рынком: %v":                         "failed to compare price targets with the market: %v",
+	"Целевые цены не заданы. Задайте их инструментом set_price_target":      "No price targets set. Set them with the set_price_target tool",
+	"Целевая цена %s %s": "Price target for %s %s",
+	" будет удалена":     " will be removed",
+	" удалена":           " removed",
+	" (была %.2f ₽)\n":   " (was %.2f ₽)\n",
+	" будет задана":      " will be set",
+	" задана":            " set",
+	" будет изменена":    " will be changed",
+	" изменена":          " changed",
+	"   Текущая цена: %.2f ₽, потенциал %+.2f%%\n":             "   Current price: %.2f ₽, potential %+.2f%%\n",
+	"   Рекомендация: %s\n":                                    "   Rating: %s\n",
+	"   Комментарий: %s\n":                                     "   Comment: %s\n",
+	"Целевые цены и текущие котировки:\n":                      "Price targets and current quotes:\n",
+	": котировка недоступна\n":                                 ": quote unavailable\n",
+	"   Целевые цены не заданы\n":                              "   No price targets set\n",
+	"   Консенсус: %.2f ₽":                                     "   Consensus: %.2f ₽",
+	", медиана %.2f ₽, диапазон %.2f–%.2f ₽, целей: %d\n":      ", median %.2f ₽, range %.2f–%.2f ₽, targets: %d\n",
+	"   Рекомендации: покупать %d, держать %d, продавать %d\n": "   Ratings: buy %d, hold %d, sell %d\n",
+	"   Актуальных целей нет: все старше %d дней\n":            "   No current targets: all are older than %d days\n",
+	", от %s":          ", from %s",
+	", устарела":       ", stale",
+	"пользователя":     "by the user",
+	"от ":              "by ",
+	"Собственная цель": "Own target",
+	"покупать":         "buy",
+	"держать":          "hold",
+	"продавать":        "sell",
+	"Получить профиль эмитента: сектор, отрасль, капитализацию, число акций в обращении, free float и уровень листинга на Московской Бирже":           "Get an issuer profile: sector, industry, market capitalization, shares outstanding, free float and Moscow Exchange listing level",
+	"Получить динамику секторов за день: среднее и взвешенное по капитализации изменение цены, объем и оборот, лидеров и аутсайдеров каждого сектора": "Get the daily sector performance: average and cap-weighted price change, volume and turnover, leaders and laggards of each sector",
+	"Получить акции сектора (например, «Нефть и газ», «Финансы», «Металлы и добыча») с изменением цены, объемом и капитализацией":                     "Get the stocks of a sector (e.g. «Нефть и газ», «Финансы», «Металлы и добыча») with price change, volume and market capitalization",
+	"Название сектора или его часть, без учета регистра":                            "Sector name or part of it, case-insensitive",
+	"не удалось получить профиль компании: %v":                                      "failed to get the company profile: %v",
+	"не удалось получить динамику секторов: %v":                                     "failed to get the sector performance: %v",
+	"не удалось получить акции сектора: %v":                                         "failed to get the sector stocks: %v",
+	"Динамика секторов (универсум %s):\n":                                           "Sector performance (universe %s):\n",
+	"Доли секторов и взвешенное изменение рассчитаны по весам бумаг в индексе %s\n": "Sector shares and the weighted change are based on security weights in index %s\n",
+	"Сектор %s (универсум %s):\n%s\n":                                               "Sector %s (universe %s):\n%s\n",
+	"%d. %s (%s): %.2f ₽ (%.2f%%), объем: %d":                                       "%d. %s (%s): %.2f ₽ (%.2f%%), volume: %d",
+	", капитализация: %.2f млрд ₽":                                                  ", market cap: %.2f bn ₽",
+	", вес в индексе %s: %.2f%%":                                                    ", weight in index %s: %.2f%%",
+	", отрасль: %s":                                                                 ", industry: %s",
+	"   Акций: %d (растут: %d, падают: %d)\n":                                       "   Stocks: %d (advancing: %d, declining: %d)\n",
+	"   Изменение: %.2f%% взвешенное по капитализации, %.2f%% среднее\n":            "   Change: %.2f%% cap-weighted, %.2f%% average\n",
+	"   Объем: %d, оборот: %.2f млн ₽\n":                                            "   Volume: %d, turnover: %.2f mln ₽\n",
+	"   Капитализация: %.2f млрд ₽\n":                                               "   Market cap: %.2f bn ₽\n",
+	"   Доля в индексе: %.2f%%, изменение с учетом весов индекса: %.2f%%\n":         "   Index weight: %.2f%%, index-weighted change: %.2f%%\n",
+	"   Лидер: %s (%.2f%%), аутсайдер: %s (%.2f%%)\n":                               "   Leader: %s (%.2f%%), laggard: %s (%.2f%%)\n",
+	"Профиль %s (%s):\n":                                                            "Profile of %s (%s):\n",
+	"Эмитент: %s\n":                                                                 "Issuer: %s\n",
+	"Сектор: %s\n":                                                                  "Sector: %s\n",
+	"Отрасль: %s\n":                                                                 "Industry: %s\n",
+	"Капитализация: %.2f млрд ₽\n":                                                  "Market cap: %.2f bn ₽\n",
+	"Капитализация: нет данных\n":                                                   "Market cap: no data\n",
+	"Акций в обращении: %d\n":                                                       "Shares outstanding: %d\n",
+	"Акций в обращении: нет данных\n":                                               "Shares outstanding: no data\n",
+	"Free float: нет данных\n":                                                      "Free float: no data\n",
+	"Уровень листинга: нет данных\n":                                                "Listing level: no data\n",
+	"Данные обновлены: %s\n":                                                        "Updated: %s\n",
+	"нет данных":                                                                    "no data",
+
+	// Корпоративные события, ставки Банка России, сырье, макропоказатели и размещения
+	"Получить корпоративные события эмитента за период: даты отчетности, собрания акционеров, программы обратного выкупа и закрытия реестра под дивиденды": "Get an issuer's corporate events for a period: reporting dates, shareholder meetings, buyback programs and dividend record dates",
+	"Первый день периода в формате YYYY-MM-DD (по умолчанию сегодня)":                            "First day of the period in YYYY-MM-DD format (default: today)",
+	"Последний день периода в формате YYYY-MM-DD, включительно (по умолчанию через %d дней)":     "Last day of the period in YYYY-MM-DD format, inclusive (default: %d days ahead)",
+	"Получить календарь корпоративных событий всех эмитентов за период, сгруппированный по дням": "Get the corporate events calendar of all issuers for a period, grouped by day",
+	"Типы событий; по умолчанию все":                                                             "Event types; all by default",
+	"не удалось получить события: %v":                                                            "failed to get the events: %v",
+	"События %s за %s – %s":                     "Events of %s for %s – %s",
+	"не удалось получить календарь событий: %v": "failed to get the events calendar: %v",
+	"Календарь событий за %s – %s":              "Events calendar for %s – %s",
+	": событий не найдено":                      ": no events found",
+	" (до %s)":                                  " (until %s)",
+	"Отчетность":                                "Earnings",
+	"Собрание акционеров":                       "Shareholder meeting",
+	"Обратный выкуп":                            "Buyback",
+	"Дивиденды":                                 "Dividends",
+	"Получить текущую ключевую ставку Банка России, дату и размер ее последнего изменения, а также ставку RUONIA": "Get the current Bank of Russia key rate, the date and size of its last change, and the RUONIA rate",
+	"Получить официальный курс валюты, установленный Банком России":                                               "Get the official exchange rate set by the Bank of Russia",
+	"Буквенный код валюты (например, USD); если не указан, возвращаются курсы %s":                                 "Alphabetic currency code (e.g. USD); if omitted, rates for %s are returned",
+	"Дата в формате YYYY-MM-DD (по умолчанию сегодня)":                                                            "Date in YYYY-MM-DD format (default: today)",
+	"не удалось получить ключевую ставку: %v":                                                                     "failed to get the key rate: %v",
+	"не удалось получить официальный курс: %v":                                                                    "failed to get the official exchange rate: %v",
+	"Официальные курсы Банка России на %s:\n":                                                                     "Bank of Russia official exchange rates for %s:\n",
+	"- %s (%s): %.4f ₽ за %d (%.4f ₽ за единицу)\n":                                                               "- %s (%s): %.4f ₽ per %d (%.4f ₽ per unit)\n",
+	"Ключевая ставка Банка России: %.2f%%":                                                                        "Bank of Russia key rate: %.2f%%",
+	" с %s (было %.2f%%, изменение %+.2f п.п.)\n":                                                                 " since %s (was %.2f%%, change %+.2f pp)\n",
+	", без изменений как минимум с %s\n":                                                                          ", unchanged since at least %s\n",
+	"RUONIA: %.2f%% на %s, объем сделок %.1f млрд ₽\n":                                                            "RUONIA: %.2f%% on %s, deal volume %.1f bn ₽\n",
+	"Получить цену сырьевого товара (нефть Brent и Urals, золото, серебро, природный газ) в долларах и рублях по ближайшему ликвидному фьючерсу срочного рынка MOEX": "Get a commodity price (Brent and Urals oil, gold, silver, natural gas) in dollars and rubles from the nearest liquid MOEX derivatives market futures contract",
+	"Код товара; если не указан, возвращаются цены всех товаров": "Commodity code; if omitted, prices of all commodities are returned",
+	"не удалось получить цены сырьевых товаров: %v":              "failed to get commodity prices: %v",
+	"не удалось получить цену сырьевого товара: %v":              "failed to get the commodity price: %v",
+	"Цены сырьевых товаров (фьючерсы MOEX):\n":                   "Commodity prices (MOEX futures):\n",
+	"- %s: $%.2f за %s (%+.2f%%)":        "- %s: $%.2f per %s (%+.2f%%)",
+	"  Контракт %s":                      "  Contract %s",
+	", исполнение %s":                    ", expiration %s",
+	"Курс пересчета: %.4f ₽ за доллар\n": "Conversion rate: %.4f ₽ per dollar\n",
+	"Курс доллара недоступен, рублевые цены не рассчитаны\n": "The dollar rate is unavailable, ruble prices were not calculated\n",
+	"Нефть Brent":    "Brent crude",
+	"Нефть Urals":    "Urals crude",
+	"Золото":         "Gold",
+	"Серебро":        "Silver",
+	"Природный газ":  "Natural gas",
+	"баррель":        "barrel",
+	"тройская унция": "troy ounce",
+	"Получить историю макроэкономического показателя: инфляции, ВВП, безработицы, ключевой ставки, курса доллара или цены нефти Brent": "Get the history of a macroeconomic indicator: inflation, GDP, unemployment, the key rate, the dollar rate or the Brent oil price",
+	"Код показателя": "Indicator code",
+	"Начало периода в формате YYYY-MM-DD (по умолчанию %d месяца назад)":          "Start of the period in YYYY-MM-DD format (default: %d months ago)",
+	"Окончание периода в формате YYYY-MM-DD, включительно (по умолчанию сегодня)": "End of the period in YYYY-MM-DD format, inclusive (default: today)",
+	"не удалось получить показатель: %v":                                          "failed to get the indicator: %v",
+	"Нет данных по показателю «%s» за %s – %s":                                    "No data for indicator «%s» for %s – %s",
+	"%s, %s (%d значений):\n":           "%s, %s (%d values):\n",
+	"Последние %d значений:\n":          "Last %d values:\n",
+	"Макроэкономические показатели:\n":  "Macroeconomic indicators:\n",
+	"- %s: %.2f %s за %s":               "- %s: %.2f %s for %s",
+	" (предыдущее значение %.2f за %s)": " (previous value %.2f for %s)",
+	"%d кв. %d":       "Q%d %d",
+	"Инфляция (ИПЦ)":  "Inflation (CPI)",
+	"ВВП":             "GDP",
+	"Безработица":     "Unemployment",
+	"Ключевая ставка": "Key rate",
+	"Официальный курс доллара": "Official dollar rate",
+	"% г/г":        "% y/y",
+	"% годовых":    "% per annum",
+	"$ за баррель": "$ per barrel",
+	"Получить объявленные IPO и SPO на Московской Бирже, торги по которым еще не начались: ожидаемая дата, ценовой диапазон и комментарии": "Get announced IPOs and SPOs on the Moscow Exchange that have not started trading yet: expected date, price range and comments",
+	"Получить акции, начавшие торговаться на Московской Бирже за последние дни: IPO, SPO и прямые листинги с уровнем листинга":             "Get stocks that started trading on the Moscow Exchange in recent days: IPOs, SPOs and direct listings with their listing level",
+	"Глубина поиска в днях (по умолчанию %d, не более %d)":                                                           "Search depth in days (default %d, at most %d)",
+	"не удалось получить календарь размещений: %v":                                                                   "failed to get the offerings calendar: %v",
+	"не удалось получить новые листинги: %v":                                                                         "failed to get new listings: %v",
+	"Объявленных размещений нет. Календарь пополняется из файла listings.feedPath, заданного в конфигурации сервера": "No announced offerings. The calendar is filled from the listings.feedPath file set in the server configuration",
+	"Объявленные размещения (%d):\n":                                                                                 "Announced offerings (%d):\n",
+	"   Дата начала торгов: не объявлена\n":                                                                          "   First trading date: not announced\n",
+	"   Дата начала торгов: %s — прошла, но бумага еще не появилась в списке торгуемых\n":                            "   First trading date: %s — passed, but the security has not appeared among traded securities yet\n",
+	"   Дата начала торгов: %s\n":                                                                                    "   First trading date: %s\n",
+	"   Ценовой диапазон: %s\n":                                                                                      "   Price range: %s\n",
+	"За последние %d дней новых акций в основном режиме торгов MOEX не появилось":                                    "No new stocks appeared in the MOEX main trading mode in the last %d days",
+	"Новые листинги за последние %d дней (%d):\n":                                                                    "New listings in the last %d days (%d):\n",
+	", уровень листинга %d":                                                                                          ", listing level %d",
+	"   Ценовой диапазон размещения: %s\n":                                                                           "   Offering price range: %s\n",
+	"листинг": "listing",
+
+	// Анализ движения, гэпы, маржинальные списки и налоги
+	"Собрать контекст движения акции за день, чтобы объяснить, что произошло: дневная свеча, часовой профиль цены и объема, новости по компании до и после самого сильного движения, движение сектора и индекса": "Collect the context of a stock's daily move to explain what happened: the daily candle, the hourly price and volume profile, company news before and after the strongest move, and the sector and index moves",
+	"Дата торгов в формате YYYY-MM-DD (по умолчанию сегодня)": "Trading date in YYYY-MM-DD format (default: today)",
+	"Рассчитать попарные корреляции дневных доходностей акций и их беты относительно индекса IMOEX по истории котировок; помогает оценить диверсификацию портфеля": "Calculate pairwise correlations of daily stock returns and their betas to the IMOEX index from quote history; helps assess portfolio diversification",
+	"Тикеры акций, не более %d (например, [\"SBER\", \"GAZP\", \"LKOH\"])":                    "Stock tickers, at most %d (e.g. [\"SBER\", \"GAZP\", \"LKOH\"])",
+	"Окно расчета в календарных днях (по умолчанию %d, не более %d)":                          "Calculation window in calendar days (default %d, at most %d)",
+	"не удалось проанализировать движение акции: %v":                                          "failed to analyze the stock move: %v",
+	"не удалось рассчитать корреляции: %v":                                                    "failed to calculate correlations: %v",
+	"Корреляции и беты за %d дней (%s – %s):\n\n":                                             "Correlations and betas for %d days (%s – %s):\n\n",
+	"Бета относительно %s:\n":                                                                 "Beta to %s:\n",
+	"- %s: бета нет данных, дневная волатильность %.2f%%\n":                                   "- %s: beta no data, daily volatility %.2f%%\n",
+	"- %s: бета %.2f, корреляция с индексом %.2f, дневная волатильность %.2f%% (%d сессий)\n": "- %s: beta %.2f, correlation with the index %.2f, daily volatility %.2f%% (%d sessions)\n",
+	"История индекса %s недоступна, беты не рассчитаны\n":                                     "History of index %s is unavailable, betas not calculated\n",
+	"\nМатрица корреляций дневных доходностей:\n\n|  |":                                       "\nCorrelation matrix of daily returns:\n\n|  |",
+	" нет данных |": " no data |",
+	"\nСредняя попарная корреляция: %.2f\n":                             "\nAverage pairwise correlation: %.2f\n",
+	"\nНедостаточно истории котировок, не вошли в расчет: %s\n":         "\nNot enough quote history, excluded from the calculation: %s\n",
+	"Движение %s за %s: %+.2f%%\n\n":                                    "Move of %s on %s: %+.2f%%\n\n",
+	"Вероятные драйверы:\n":                                             "Likely drivers:\n",
+	"\nСвеча:\n":                                                        "\nCandle:\n",
+	"   Закрытие предыдущей сессии: %.2f ₽, гэп на открытии: %+.2f%%\n": "   Previous session close: %.2f ₽, opening gap: %+.2f%%\n",
+	"   Форма: %s\n": "   Shape: %s\n",
+	"   Диапазон: %.2f%%, тело: %.0f%%, верхняя тень: %.0f%%, нижняя тень: %.0f%%\n": "   Range: %.2f%%, body: %.0f%%, upper shadow: %.0f%%, lower shadow: %.0f%%\n",
+	"\nОбъем:\n":       "\nVolume:\n",
+	"   За день: %d\n": "   For the day: %d\n",
+	"   Средний за %d сессий: %.0f (x%.2f)\n":                                 "   Average over %d sessions: %.0f (x%.2f)\n",
+	"\nПо часам (изменение к предыдущему часу, объем и его доля в сессии):\n": "\nHourly (change from the previous hour, volume and its share of the session):\n",
+	"   %s  %.2f ₽  %+.2f%%  объем %d (%.0f%%)":                               "   %s  %.2f ₽  %+.2f%%  volume %d (%.0f%%)",
+	"  ← самое сильное движение":                                              "  ← strongest move",
+	"\nРынок и сектор:\n":                                                     "\nMarket and sector:\n",
+	"   Индекс %s: %+.2f%%\n":                                                 "   Index %s: %+.2f%%\n",
+	"   Данные по индексу недоступны\n":                                       "   Index data unavailable\n",
+	"   Сектор «%s»: в среднем %+.2f%%\n":                                     "   Sector «%s»: %+.2f%% on average\n",
+	"   Аналоги из сектора «%s» не найдены\n":                                 "   No peers found in sector «%s»\n",
+	"\nНовости по компании:\n":                                                "\nCompany news:\n",
+	"   Не найдено\n":                                                         "   None found\n",
+	" (после движения)":                                                       " (after the move)",
+	" (до движения)":                                                          " (before the move)",
+	"   Источник: %s, URL: %s\n":                                              "   Source: %s, URL: %s\n",
+	"\nОбщие новости дня:\n":                                                  "\nGeneral news of the day:\n",
+	"без движения":                                                            "no movement",
+	"доджи — неопределенность, покупатели и продавцы в равновесии": "doji — indecision, buyers and sellers in balance",
+	"полнотелая растущая свеча — уверенные покупки весь день":      "full-bodied bullish candle — steady buying all day",
+	"полнотелая падающая свеча — уверенные продажи весь день":      "full-bodied bearish candle — steady selling all day",
+	"длинная верхняя тень — рост был продан":                       "long upper shadow — the rally was sold",
+	"длинная нижняя тень — снижение было выкуплено":                "long lower shadow — the dip was bought",
+	"растущая свеча": "bullish candle",
+	"падающая свеча": "bearish candle",
+	"Найти акции универсума %s, открывшие сессию с гэпом к закрытию предыдущей: величина разрыва, цена сейчас и закрыт ли гэп. Гэпы рассчитываются раз в день после открытия торгов": "Find stocks of universe %s that opened the session with a gap to the previous close: the gap size, the current price and whether the gap is filled. Gaps are calculated once a day after the open",
+	"Наименьший гэп по модулю, %% (по умолчанию %g)":                                              "Minimum absolute gap, %% (default %g)",
+	"Направление: up — открытие выше закрытия, down — ниже; по умолчанию оба":                     "Direction: up for an open above the close, down for below; both by default",
+	"Количество акций с наибольшим гэпом (по умолчанию %d, максимум %d)":                          "Number of stocks with the largest gap (default %d, maximum %d)",
+	"не удалось рассчитать гэпы открытия: %v":                                                     "failed to calculate opening gaps: %v",
+	"Гэпы открытия за %s не рассчитаны: торги еще не начались или сегодня нет сессии":             "Opening gaps for %s are not calculated: trading has not started yet or there is no session today",
+	"Гэпы открытия %s в универсуме %s (не меньше %g%%), расчет на %s:\n":                          "Opening gaps on %s in universe %s (at least %g%%), calculated at %s:\n",
+	"Акций с гэпом не найдено\n":                                                                  "No stocks with a gap found\n",
+	"%d. %s (%s): гэп %+.2f%% — закрытие %.2f → открытие %.2f; цена %.2f ₽ (%+.2f%% от открытия)": "%d. %s (%s): gap %+.2f%% — close %.2f → open %.2f; price %.2f ₽ (%+.2f%% from the open)",
+	", гэп закрыт": ", gap filled",
+	", пропущено без свечей сессии или предыдущего закрытия: %d": ", skipped without session candles or a previous close: %d",
+	"Получить бумаги из маржинальных списков брокеров: доступные для покупки с плечом или для шорта, со ставками риска и платой за перенос короткой позиции. Первыми идут бумаги с наибольшим плечом": "Get securities from brokers' margin lists: available for leveraged buying or shorting, with risk rates and short position carry fees. Securities with the highest leverage come first",
+	"Сторона: long — покупка с плечом, short — продажа без покрытия; по умолчанию бумаги, доступные на любой стороне":                                                                                 "Side: long for leveraged buying, short for short selling; by default securities available on either side",
+	"Брокер; по умолчанию списки всех брокеров":           "Broker; lists of all brokers by default",
+	"не удалось получить маржинальные списки: %v":         "failed to get margin lists: %v",
+	"Бумаг в маржинальных списках не найдено":             "No securities found in margin lists",
+	"Маржинальные бумаги":                                 "Marginable securities",
+	", доступные для покупки с плечом":                    " available for leveraged buying",
+	", доступные для шорта":                               " available for shorting",
+	" у брокера %s":                                       " at broker %s",
+	"Маржинальные списки брокеров:\n":                     "Brokers' margin lists:\n",
+	"- %s: нет в маржинальных списках, шорт недоступен\n": "- %s: not in margin lists, shorting unavailable\n",
+	"лонг": "long",
+	"лонг с плечом недоступен": "leveraged long unavailable",
+	"шорт":                                  "short",
+	", перенос %.2f%% годовых":              ", carry %.2f%% per annum",
+	"шорт недоступен":                       "short unavailable",
+	" (список от %s)":                       " (list of %s)",
+	": ставка риска %.1f%%, плечо до %.1fx": ": risk rate %.1f%%, leverage up to %.1fx",
+	"Оценить НДФЛ по портфелю за год: с результата продаж по FIFO с учетом льготы долгосрочного владения (ЛДВ) и с дивидендов": "Estimate personal income tax for a portfolio for a year: on sales results by FIFO, including the long-term holding exemption, and on dividends",
+	"Календарный год (по умолчанию текущий)":    "Calendar year (default: current)",
+	"не удалось оценить налоги: %v":             "failed to estimate taxes: %v",
+	"Оценка НДФЛ по портфелю %s за %d год\n\n":  "Personal income tax estimate for portfolio %s for %d\n\n",
+	"Продаж за год не было.\n":                  "There were no sales in the year.\n",
+	"Продажи (покупки сопоставлены по FIFO):\n": "Sales (purchases matched by FIFO):\n",
+	"%d. %s: %d шт., ": "%d. %s: %d shares, ",
+	"покупка не найдена в истории":    "purchase not found in the history",
+	"начальный остаток по %.2f ₽":     "opening balance at %.2f ₽",
+	"куплены %s по %.2f ₽":            "bought on %s at %.2f ₽",
+	", проданы %s по %.2f ₽: %+.2f ₽": ", sold on %s at %.2f ₽: %+.2f ₽",
+	" (ЛДВ, полных лет владения: %d)": " (long-term exemption, full years held: %d)",
+	"\nВыручка: %.2f ₽, расходы с комиссиями: %.2f ₽, финансовый результат: %+.2f ₽\n":                                                  "\nProceeds: %.2f ₽, costs including commissions: %.2f ₽, financial result: %+.2f ₽\n",
+	"Льгота долгосрочного владения: результат по бумагам, которыми владели больше %d лет, %+.2f ₽, предел %.2f ₽, освобождено %.2f ₽\n": "Long-term holding exemption: result on securities held for more than %d years %+.2f ₽, limit %.2f ₽, exempted %.2f ₽\n",
+	"Налоговая база по продажам: %.2f ₽\n":                                      "Tax base on sales: %.2f ₽\n",
+	"НДФЛ с продаж: %.2f ₽ (удерживает брокер)\n":                               "Tax on sales: %.2f ₽ (withheld by the broker)\n",
+	"Дивиденды не учтены: календарь корпоративных событий недоступен.\n":        "Dividends not included: the corporate events calendar is unavailable.\n",
+	"Дивидендов в рублях за год не было.\n":                                     "There were no ruble dividends in the year.\n",
+	"Дивиденды по бумагам, которые были в портфеле на дату закрытия реестра:\n": "Dividends on securities held in the portfolio on the record date:\n",
+	"%d. %s: реестр %s, %d шт. × %.2f ₽ = %.2f ₽\n":                             "%d. %s: record date %s, %d shares × %.2f ₽ = %.2f ₽\n",
+	"Дивиденды: %.2f ₽, НДФЛ с дивидендов: %.2f ₽ (удерживается при выплате)\n": "Dividends: %.2f ₽, tax on dividends: %.2f ₽ (withheld on payment)\n",
+	"\nИтого НДФЛ: %.2f ₽":                                    "\nTotal tax: %.2f ₽",
+	" (%d%% с дохода до %.0f ₽, %d%% сверх)":                  " (%d%% on income up to %.0f ₽, %d%% above)",
+	"\nЛьгота долгосрочного владения по открытым позициям:\n": "\nLong-term holding exemption on open positions:\n",
+	"- %s: %d шт., куплены %s по %.2f ₽ — ":                   "- %s: %d shares, bought on %s at %.2f ₽ — ",
+	"продажа уже освобождается от НДФЛ\n":                     "a sale is already tax-exempt\n",
+	"льгота с %s (через %d дн.)\n":                            "exempt from %s (in %d days)\n",
+	"\nЧасть продаж сопоставлена с начальными остатками позиций, открытых до ведения истории сделок: их дата покупки неизвестна, льгота к ним не применяется.\n": "\nSome sales were matched against opening balances of positions opened before the trade history was kept: their purchase date is unknown, so the exemption does not apply to them.\n",
+	"\nДля части продаж покупки не найдены в истории сделок: их стоимость принята нулевой, налог завышен.\n":                                                     "\nFor some sales no purchases were found in the trade history: their cost was taken as zero, so the tax is overstated.\n",
+	"\nОценка ориентировочная: не учитывает ИИС, налоговые вычеты, сальдирование с другими счетами и бумаги в иностранной валюте.\n":                             "\nThe estimate is approximate: it does not account for IIS accounts, tax deductions, netting with other accounts or foreign-currency securities.\n",
+
+	// Сравнение акций, криптовалюты, индексы, настроение рынка и диагностика
+	"не удалось сравнить акции: %v": "failed to compare the stocks: %v",
+	"| Показатель |":                "| Metric |",
+	"Название":                      "Name",
+	"Цена, ₽":                       "Price, ₽",
+	"Изменение за день":             "Daily change",
+	"Объем торгов":                  "Trading volume",
+	"Дивидендная доходность":        "Dividend yield",
+	"Доходность за 1 месяц":         "1-month return",
+	"Доходность за 3 месяца":        "3-month return",
+	"Сравнение акций:\n\n":          "Stock comparison:\n\n",
+	"\nДата обновления: %s":         "\nUpdated: %s",
+	"Получить котировку криптовалюты в долларах и рублях: изменение за 24 часа, капитализацию и объем торгов": "Get a cryptocurrency quote in dollars and rubles: 24-hour change, market capitalization and trading volume",
+	"Тикер монеты (%s); если не указан, возвращаются котировки всех монет":                                    "Coin ticker (%s); if omitted, quotes for all coins are returned",
+	"не удалось получить котировки криптовалют: %v":                                                           "failed to get cryptocurrency quotes: %v",
+	"не удалось получить котировку криптовалюты: %v":                                                          "failed to get the cryptocurrency quote: %v",
+	"Криптовалюты:\n":                                             "Cryptocurrencies:\n",
+	"- %s: $%.2f (%.0f ₽), за 24 часа %+.2f%%\n":                  "- %s: $%.2f (%.0f ₽), 24h %+.2f%%\n",
+	"  Капитализация: $%.1f млрд, объем за 24 часа: $%.1f млрд\n": "  Market cap: $%.1f bn, 24h volume: $%.1f bn\n",
+	"Получить состав индекса Московской биржи с весами бумаг, текущими котировками и вкладом каждой бумаги в изменение индекса за день": "Get the constituents of a Moscow Exchange index with security weights, current quotes and each security's contribution to the index's daily change",
+	"Код индекса": "Index code",
+	"Количество бумаг с наибольшим весом (по умолчанию все)": "Number of securities with the largest weight (default: all)",
+	"не удалось получить состав индекса: %v":                 "failed to get the index constituents: %v",
+	"Состав индекса %s": "Constituents of index %s",
+	" (веса на %s)":     " (weights as of %s)",
+	": %d бумаг\n":      ": %d securities\n",
+	"Изменение по весам бумаг: %+.2f%% (бумаги с котировками — %.2f%% индекса)\n": "Weighted change: %+.2f%% (securities with quotes make up %.2f%% of the index)\n",
+	": вес %.2f%%": ": weight %.2f%%",
+	", котировка недоступна\n":                       ", quote unavailable\n",
+	", %.2f ₽ (%+.2f%%), вклад %+.3f п.п.\n":         ", %.2f ₽ (%+.2f%%), contribution %+.3f pp\n",
+	"\nПоказаны %d бумаг с наибольшим весом из %d\n": "\nShowing %d securities with the largest weight out of %d\n",
+	"Получить составной индекс настроения рынка (0 — сильный страх, 100 — эйфория) по ширине рынка, волатильности, тональности новостей и курсу рубля, а также его историю по дням": "Get the composite market mood index (0 for extreme fear, 100 for euphoria) based on market breadth, volatility, news sentiment and the ruble rate, along with its daily history",
+	"За сколько последних дней показать историю индекса (по умолчанию %d, не более %d)":                                                                                             "How many recent days of index history to show (default %d, at most %d)",
+	"не удалось рассчитать индекс настроения рынка: %v":                                                                                                                             "failed to calculate the market mood index: %v",
+	"Индекс настроения рынка на %s: %.0f из 100 (%s)\n\n":                                                                                                                           "Market mood index on %s: %.0f out of 100 (%s)\n\n",
+	"Составляющие (от -1 — страх до +1 — жадность):\n":                                                                                                                              "Components (from -1 for fear to +1 for greed):\n",
+	"- Ширина рынка: %+.2f (растут %d, падают %d)\n":                                                                                                                                "- Market breadth: %+.2f (advancing %d, declining %d)\n",
+	"- Волатильность: %+.2f (разброс дневных изменений %.2f%%)\n":                                                                                                                   "- Volatility: %+.2f (dispersion of daily changes %.2f%%)\n",
+	"- Тональность новостей: %+.2f (новостей за день: %d)\n":                                                                                                                        "- News sentiment: %+.2f (news for the day: %d)\n",
+	"- Тональность новостей: нет данных, не учитывается\n":                                                                                                                          "- News sentiment: no data, not included\n",
+	"- Курс рубля: %+.2f (%s %+.2f%% за день)\n":                                                                                                                                    "- Ruble rate: %+.2f (%s %+.2f%% today)\n",
+	"- Курс рубля: нет данных, не учитывается\n":                                                                                                                                    "- Ruble rate: no data, not included\n",
+	"\nИстория:\n":                   "\nHistory:\n",
+	"Дата       | Индекс | Оценка\n": "Date       | Index  | Rating\n",
+	"сильный страх":                  "extreme fear",
+	"страх":                          "fear",
+	"нейтрально":                     "neutral",
+	"оптимизм":                       "optimism",
+	"эйфория":                        "euphoria",
+	"Проверить внешние источники данных: выполнить контрольный запрос к каждому (котировка SBER, поиск новостей) в обход кэша и убедиться, что разбор ответа дал непустые поля":   "Check external data sources: send a probe request to each (an SBER quote, a news search) bypassing the cache and make sure parsing the response produced non-empty fields",
+	"Повторно разобрать сохраненные в архиве ответы MOEX и NewsAPI за период и обновить новости и котировки, не обращаясь к API. Котировки обновляются последним снимком периода": "Re-parse archived MOEX and NewsAPI responses for a period and update news and quotes without calling the APIs. Quotes are updated with the last snapshot of the period",
+	"Первый день периода в формате YYYY-MM-DD (UTC)":                  "First day of the period in YYYY-MM-DD format (UTC)",
+	"Последний день периода в формате YYYY-MM-DD (UTC), включительно": "Last day of the period in YYYY-MM-DD format (UTC), inclusive",
+	"Удалить ключи кэша по шаблону, чтобы следующие запросы получили данные из базы и внешних API. Затрагивается только пространство имен кэша этого сервера":                                                   "Delete cache keys matching a pattern so that subsequent requests fetch data from the database and external APIs. Only this server's cache namespace is affected",
+	"Glob-шаблон ключей: * — любая последовательность символов, ? — один символ. Например, stock:* — котировки акций, news:* — новости":                                                                         "Glob pattern of keys: * matches any sequence of characters, ? a single character. For example, stock:* for stock quotes, news:* for news",
+	"Получить статистику сервера с момента запуска: время работы, память и обращения к кэшу по префиксам ключей (попадания, промахи, записи, ошибки, доля попаданий, среднее время)":                            "Get server statistics since startup: uptime, memory and cache accesses by key prefix (hits, misses, writes, errors, hit rate, average latency)",
+	"Получить число вызовов инструментов клиентом с момента запуска сервера: всего, за сегодня и отклоненных, ограничения частоты и суточную квоту, самые частые инструменты. Сам инструмент в квоту не входит": "Get the number of tool calls by the client since the server started: total, today and rejected, rate limits and the daily quota, and the most frequent tools. This tool itself does not count toward the quota",
+	"Проверить состояние сервера: соединение с базой данных и Redis, доступность MOEX и NewsAPI, работу фоновых задач. Помогает понять, почему данные устарели или запросы завершаются ошибкой":                 "Check the server's health: database and Redis connections, MOEX and NewsAPI availability, and background jobs. Helps understand why data is stale or requests fail",
+	"не удалось разобрать архив ответов: %v":         "failed to re-parse the response archive: %v",
+	"не удалось сбросить кэш: %v":                    "failed to invalidate the cache: %v",
+	"Ключи кэша по шаблону %s удалены":               "Cache keys matching %s deleted",
+	"Повторный разбор архива ответов за %s – %s\n\n": "Re-parse of the response archive for %s – %s\n\n",
+	"Ответов в архиве: %d\n":                         "Responses in the archive: %d\n",
+	"Разобрано: %d\n":                                "Parsed: %d\n",
+	"Пропущено ответов без повторного разбора: %d\n": "Skipped responses without re-parsing support: %d\n",
+	"Сохранено новостей: %d\n":                       "News saved: %d\n",
+	"Обновлено котировок: %d\n":                      "Quotes updated: %d\n",
+	"\nНе удалось разобрать ответов: %d\n":           "\nFailed to parse responses: %d\n",
+	"все источники работают":                         "all sources are working",
+	"есть проблемы":                                  "there are problems",
+	"Самопроверка источников данных (%s): %s\n\n":    "Data source self-test (%s): %s\n\n",
+	"[%s] %s — %s (%d мс)\n":                         "[%s] %s — %s (%d ms)\n",
+	"   Ошибка: %s\n":                                "   Error: %s\n",
+	"   Проблемы разбора: %s\n":                      "   Parsing problems: %s\n",
+	"   Данные: %s\n":                                "   Data: %s\n",
+	"Статистика сервера (запущен %s)\n\n":            "Server statistics (started %s)\n\n",
+	"Время работы: %s\n":                             "Uptime: %s\n",
+	"Горутин: %d\n":                                  "Goroutines: %d\n",
+	"Занято памяти: %.1f МБ\n\n":                     "Memory in use: %.1f MB\n\n",
+	"Обращений к кэшу еще не было\n":                 "No cache accesses yet\n",
+	"Кэш по префиксам ключей:\n":                     "Cache by key prefix:\n",
+	"| Префикс | Попадания | Промахи | Доля попаданий | Записи | Удаления | Ошибки | Среднее время |\n": "| Prefix | Hits | Misses | Hit rate | Writes | Deletes | Errors | Average latency |\n",
+	"\nВсего чтений: %d, доля попаданий: %.1f%%, ошибок: %d\n":                                          "\nTotal reads: %d, hit rate: %.1f%%, errors: %d\n",
+	"сервер готов к работе":                            "the server is ready",
+	"недоступны обязательные зависимости":              "required dependencies are unavailable",
+	"Состояние сервера (%s, работает %s): %s\n\n":      "Server health (%s, up %s): %s\n\n",
+	"необязательная":                                   "optional",
+	"обязательная":                                     "required",
+	"[%s] %s (%s) — %d мс\n":                           "[%s] %s (%s) — %d ms\n",
+	"Вызовов инструментов еще не было\n":               "No tool calls yet\n",
+	"Клиент %s\n":                                      "Client %s\n",
+	"Вызовов с момента запуска: %d, отклонено: %d\n":   "Calls since startup: %d, rejected: %d\n",
+	"Сегодня (%s): %d из %d, осталось %d\n":            "Today (%s): %d of %d, %d left\n",
+	"Сегодня (%s): %d, суточная квота не ограничена\n": "Today (%s): %d, no daily quota\n",
+	"Ограничение частоты: %g вызовов в минуту\n":       "Rate limit: %g calls per minute\n",
+	"Последний вызов: %s\n":                            "Last call: %s\n",
+}
@@ -0,0 +1,105 @@
+package i18n
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Language язык результатов и сообщений сервера
+type Language string
+
+const (
+	Russian Language = "ru"
+	English Language = "en"
+)
+
+// Default язык исходных сообщений; в каталогах других языков ключами служат сообщения на нем
+const Default = Russian
+
+// Languages перечисляет поддерживаемые языки
+var Languages = []Language{Russian, English}
+
+// catalogs переводы сообщений по языкам: ключ — исходное сообщение или строка формата на русском
+var catalogs = map[Language]map[string]string{
+	English: english,
+}
+
+// Parse разбирает код языка без учета регистра
+func Parse(code string) (Language, bool) {
+	lang := Language(strings.ToLower(strings.TrimSpace(code)))
+	for _, supported := range Languages {
+		if lang == supported {
+			return lang, true
+		}
+	}
+	return "", false
+}
+
+// Codes возвращает коды поддерживаемых языков
+func Codes() []string {
+	codes := make([]string, 0, len(Languages))
+	for _, lang := range Languages {
+		codes = append(codes, string(lang))
+	}
+	return codes
+}
+
+type languageKey struct{}
+
+// WithLanguage возвращает контекст с языком ответа
+func WithLanguage(ctx context.Context, lang Language) context.Context {
+	return context.WithValue(ctx, languageKey{}, lang)
+}
+
+// FromContext возвращает язык ответа из контекста или язык по умолчанию
+func FromContext(ctx context.Context) Language {
+	if ctx != nil {
+		if lang, ok := ctx.Value(languageKey{}).(Language); ok {
+			return lang
+		}
+	}
+	return Default
+}
+
+// Printer переводит сообщения на свой язык. Сообщения, которых нет в каталоге, возвращаются без перевода,
+// поэтому каталог можно пополнять постепенно
+type Printer struct {
+	lang Language
+}
+
+// NewPrinter создает переводчик для языка
+func NewPrinter(lang Language) Printer {
+	return Printer{lang: lang}
+}
+
+// PrinterFrom создает переводчик для языка ответа из контекста
+func PrinterFrom(ctx context.Context) Printer {
+	return NewPrinter(FromContext(ctx))
+}
+
+// Language возвращает язык переводчика
+func (p Printer) Language() Language {
+	if p.lang == "" {
+		return Default
+	}
+	return p.lang
+}
+
+// T переводит сообщение
+func (p Printer) T(message string) string {
+	if translated, ok := catalogs[p.Language()][message]; ok {
+		return translated
+	}
+	return message
+}
+
+// Sprintf переводит строку формата и подставляет в нее аргументы
+func (p Printer) Sprintf(format string, args ...interface{}) string {
+	return fmt.Sprintf(p.T(format), args...)
+}
+
+// Errorf переводит строку формата и возвращает ошибку с подставленными аргументами
+func (p Printer) Errorf(format string, args ...interface{}) error {
+	return fmt.Errorf(p.T(format), args...)
+}
@@ -0,0 +1,82 @@
+package listutil
+
+import (
+	"container/heap"
+	"sort"
+)
+
+// TopN возвращает n первых элементов items в порядке less, не изменяя items. less(a, b) сообщает,
+// что a должен идти раньше b; равные элементы сохраняют исходный порядок. n <= 0 или n больше длины — все элементы.
+//
+// Для малого n элементы отбираются кучей размера n за O(len·log n), иначе список сортируется целиком
+func TopN[T any](items []T, less func(a, b T) bool, n int) []T {
+	if n <= 0 || n > len(items) {
+		n = len(items)
+	}
+	if n == 0 {
+		return []T{}
+	}
+
+	if n*4 >= len(items) {
+		sorted := make([]T, len(items))
+		copy(sorted, items)
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return less(sorted[i], sorted[j])
+		})
+		return sorted[:n:n]
+	}
+
+	h := &boundedHeap[T]{less: less}
+	for i, item := range items {
+		entry := indexed[T]{item: item, index: i}
+		if h.Len() < n {
+			heap.Push(h, entry)
+			continue
+		}
+		// В вершине кучи худший из отобранных; новый элемент заменяет его, только если идет раньше
+		if h.before(entry, h.entries[0]) {
+			h.entries[0] = entry
+			heap.Fix(h, 0)
+		}
+	}
+
+	result := make([]T, h.Len())
+	for i := len(result) - 1; i >= 0; i-- {
+		result[i] = heap.Pop(h).(indexed[T]).item
+	}
+	return result
+}
+
+// indexed элемент и его позиция в исходном списке, по которой упорядочиваются равные элементы
+type indexed[T any] struct {
+	item  T
+	index int
+}
+
+// boundedHeap куча отобранных элементов, в вершине которой элемент, идущий последним
+type boundedHeap[T any] struct {
+	entries []indexed[T]
+	less    func(a, b T) bool
+}
+
+// before сообщает, что a идет раньше b
+func (h *boundedHeap[T]) before(a, b indexed[T]) bool {
+	if h.less(a.item, b.item) {
+		return true
+	}
+	if h.less(b.item, a.item) {
+		return false
+	}
+	return a.index < b.index
+}
+
+func (h *boundedHeap[T]) Len() int           { return len(h.entries) }
+func (h *boundedHeap[T]) Less(i, j int) bool { return h.before(h.entries[j], h.entries[i]) }
+func (h *boundedHeap[T]) Swap(i, j int)      { h.entries[i], h.entries[j] = h.entries[j], h.entries[i] }
+func (h *boundedHeap[T]) Push(x any)         { h.entries = append(h.entries, x.(indexed[T])) }
+
+func (h *boundedHeap[T]) Pop() any {
+	last := h.entries[len(h.entries)-1]
+	h.entries = h.entries[:len(h.entries)-1]
+	return last
+}
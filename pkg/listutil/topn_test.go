@@ -0,0 +1,103 @@
+package listutil
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// item элемент с ключом сортировки и позицией, по которой проверяется устойчивость
+type item struct {
+	key   int
+	index int
+}
+
+func byKey(a, b item) bool { return a.key < b.key }
+
+// randomItems возвращает size элементов с ключами из диапазона [0, keys): при малом keys много равных элементов
+func randomItems(rng *rand.Rand, size, keys int) []item {
+	items := make([]item, size)
+	for i := range items {
+		items[i] = item{key: rng.Intn(keys), index: i}
+	}
+	return items
+}
+
+// sortTopN эталон: устойчивая сортировка копии и первые n элементов
+func sortTopN(items []item, n int) []item {
+	if n <= 0 || n > len(items) {
+		n = len(items)
+	}
+	sorted := make([]item, len(items))
+	copy(sorted, items)
+	sort.SliceStable(sorted, func(i, j int) bool { return byKey(sorted[i], sorted[j]) })
+	return sorted[:n]
+}
+
+func TestTopN(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	for _, size := range []int{0, 1, 2, 7, 100, 1000} {
+		for _, keys := range []int{1, 5, 1 << 30} {
+			items := randomItems(rng, size, keys)
+			original := make([]item, len(items))
+			copy(original, items)
+
+			// n покрывает оба пути: кучу (n*4 < len) и полную сортировку, а также границы
+			for _, n := range []int{-1, 0, 1, 3, size / 8, size / 4, size / 2, size - 1, size, size + 1} {
+				got := TopN(items, byKey, n)
+				want := sortTopN(items, n)
+				if len(got) != len(want) || (len(want) > 0 && !reflect.DeepEqual(got, want)) {
+					t.Fatalf("size=%d keys=%d n=%d: got %v, want %v", size, keys, n, got, want)
+				}
+				if !reflect.DeepEqual(items, original) {
+					t.Fatalf("size=%d keys=%d n=%d: TopN изменил исходный список", size, keys, n)
+				}
+			}
+		}
+	}
+}
+
+func TestTopNEmpty(t *testing.T) {
+	got := TopN(nil, byKey, 5)
+	if got == nil || len(got) != 0 {
+		t.Fatalf("got %#v, want empty non-nil slice", got)
+	}
+}
+
+func TestTopNResultIsIndependent(t *testing.T) {
+	items := []item{{3, 0}, {1, 1}, {2, 2}}
+
+	got := TopN(items, byKey, 2)
+	// Емкость результата ограничена его длиной: append к нему не затирает отброшенные элементы
+	if cap(got) != len(got) {
+		t.Fatalf("cap = %d, want %d", cap(got), len(got))
+	}
+	got[0] = item{key: 100}
+	if items[1] != (item{1, 1}) {
+		t.Fatalf("изменение результата затронуло исходный список: %v", items)
+	}
+}
+
+// BenchmarkTopN сравнивает отбор кучей с сортировкой всего списка и срезом первых n элементов
+func BenchmarkTopN(b *testing.B) {
+	for _, size := range []int{100, 1000, 10000, 100000} {
+		items := randomItems(rand.New(rand.NewSource(1)), size, size)
+		for _, n := range []int{10, 50, size / 2} {
+			b.Run(fmt.Sprintf("size=%d/n=%d/topn", size, n), func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					TopN(items, byKey, n)
+				}
+			})
+			b.Run(fmt.Sprintf("size=%d/n=%d/sort", size, n), func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					sortTopN(items, n)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,45 @@
+// Package progress передает ход долгих операций из сервисов вызывающей стороне, например клиенту MCP
+// в уведомлениях notifications/progress. Сервисы сообщают о ходе работы через контекст и не зависят от транспорта
+package progress
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// Reporter получает ход операции: выполнено done шагов из total и описание текущего шага.
+// Описание передается форматом и аргументами, чтобы получатель мог перевести его
+type Reporter func(done, total int, format string, args ...any)
+
+type reporterKey struct{}
+
+// WithReporter возвращает контекст, ход операций в котором передается reporter
+func WithReporter(ctx context.Context, reporter Reporter) context.Context {
+	return context.WithValue(ctx, reporterKey{}, reporter)
+}
+
+// Report сообщает о ходе операции; без получателя в контексте ничего не делает
+func Report(ctx context.Context, done, total int, format string, args ...any) {
+	if reporter, ok := ctx.Value(reporterKey{}).(Reporter); ok {
+		reporter(done, total, format, args...)
+	}
+}
+
+// Counter считает завершенные шаги операции, которые выполняются параллельно
+type Counter struct {
+	ctx    context.Context
+	total  int
+	format string // Получает число выполненных шагов и их общее число
+	done   atomic.Int64
+}
+
+// NewCounter создает счетчик total шагов; format описывает ход и получает числа выполненных и всех шагов
+func NewCounter(ctx context.Context, total int, format string) *Counter {
+	return &Counter{ctx: ctx, total: total, format: format}
+}
+
+// Done отмечает завершение очередного шага
+func (c *Counter) Done() {
+	done := int(c.done.Add(1))
+	Report(c.ctx, done, c.total, c.format, done, c.total)
+}
@@ -0,0 +1,68 @@
+// Package ratelimit ограничивает частоту событий по алгоритму token bucket отдельно для каждого ключа
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// Limit параметры корзины: скорость пополнения и емкость
+type Limit struct {
+	PerMinute float64 // Маркеров в минуту; 0 — без ограничения
+	Burst     int     // Емкость корзины — сколько событий можно подряд; 0 — не меньше одного
+}
+
+// capacity возвращает емкость корзины
+func (l Limit) capacity() float64 {
+	if l.Burst > 0 {
+		return float64(l.Burst)
+	}
+	return math.Max(1, math.Floor(l.PerMinute))
+}
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// Limiter набор корзин маркеров по ключам. Корзина ключа создается полной при первом обращении
+type Limiter struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+// New создает пустой набор корзин
+func New() *Limiter {
+	return &Limiter{buckets: make(map[string]*bucket)}
+}
+
+// Allow забирает маркер из корзины ключа. Если маркеров нет, возвращает false и время,
+// через которое появится следующий
+func (l *Limiter) Allow(key string, limit Limit, now time.Time) (bool, time.Duration) {
+	if limit.PerMinute <= 0 {
+		return true, 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	capacity := limit.capacity()
+	perSecond := limit.PerMinute / 60
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: capacity, updated: now}
+		l.buckets[key] = b
+	}
+	if elapsed := now.Sub(b.updated).Seconds(); elapsed > 0 {
+		b.tokens = math.Min(capacity, b.tokens+elapsed*perSecond)
+		b.updated = now
+	}
+
+	if b.tokens < 1 {
+		wait := time.Duration((1 - b.tokens) / perSecond * float64(time.Second))
+		return false, wait
+	}
+	b.tokens--
+	return true, 0
+}
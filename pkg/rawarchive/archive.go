@@ -0,0 +1,214 @@
+package rawarchive
+
+import (
+	"compress/gzip"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// dayLayout формат имени каталога с ответами за день (UTC)
+	dayLayout = "2006-01-02"
+	// fileSuffix расширение файлов архива
+	fileSuffix = ".json.gz"
+)
+
+// Record сохраненный ответ внешнего API
+type Record struct {
+	URL       string    `json:"url"` // URL запроса без секретных параметров
+	FetchedAt time.Time `json:"fetched_at"`
+	Status    int       `json:"status"`
+	Body      []byte    `json:"body"`
+}
+
+// Archive неизменяемый архив необработанных ответов внешних API на диске.
+// Каждый ответ хранится в отдельном сжатом файле <dir>/<YYYY-MM-DD>/<время>_<хэш URL>.json.gz,
+// файлы только создаются и удаляются целыми днями по истечении срока хранения.
+type Archive struct {
+	dir       string
+	retention time.Duration
+}
+
+// New создает архив в каталоге dir; retention — срок хранения ответов, 0 — хранить бессрочно
+func New(dir string, retention time.Duration) *Archive {
+	return &Archive{
+		dir:       dir,
+		retention: retention,
+	}
+}
+
+// Store сохраняет ответ в архив
+func (a *Archive) Store(rec Record) error {
+	fetchedAt := rec.FetchedAt.UTC()
+	dayDir := filepath.Join(a.dir, fetchedAt.Format(dayLayout))
+	if err := os.MkdirAll(dayDir, 0o755); err != nil {
+		return fmt.Errorf("не удалось создать каталог архива: %w", err)
+	}
+
+	hash := sha1.Sum([]byte(rec.URL))
+	name := fmt.Sprintf("%s_%s%s", fetchedAt.Format("150405.000000000"), hex.EncodeToString(hash[:6]), fileSuffix)
+
+	// Пишем во временный файл и переименовываем, чтобы в архиве не появлялись неполные записи
+	tmp, err := os.CreateTemp(dayDir, ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("не удалось создать файл архива: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	gz := gzip.NewWriter(tmp)
+	if err := json.NewEncoder(gz).Encode(rec); err != nil {
+		tmp.Close()
+		return fmt.Errorf("ошибка записи в архив: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("ошибка записи в архив: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("ошибка записи в архив: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0o444); err != nil {
+		return fmt.Errorf("ошибка записи в архив: %w", err)
+	}
+
+	return os.Rename(tmp.Name(), filepath.Join(dayDir, name))
+}
+
+// Walk вызывает fn для каждого ответа, полученного в период [from, to], в порядке получения.
+// Ошибка fn прерывает обход и возвращается вызывающему
+func (a *Archive) Walk(from, to time.Time, fn func(Record) error) error {
+	days, err := a.days()
+	if err != nil {
+		return err
+	}
+
+	firstDay := from.UTC().Format(dayLayout)
+	lastDay := to.UTC().Format(dayLayout)
+	for _, day := range days {
+		if day < firstDay || day > lastDay {
+			continue
+		}
+
+		files, err := filepath.Glob(filepath.Join(a.dir, day, "*"+fileSuffix))
+		if err != nil {
+			return err
+		}
+		sort.Strings(files)
+
+		for _, file := range files {
+			rec, err := readRecord(file)
+			if err != nil {
+				return err
+			}
+			if rec.FetchedAt.Before(from) || rec.FetchedAt.After(to) {
+				continue
+			}
+			if err := fn(rec); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Prune удаляет ответы старше срока хранения и возвращает число удаленных дней
+func (a *Archive) Prune(now time.Time) (int, error) {
+	if a.retention <= 0 {
+		return 0, nil
+	}
+
+	days, err := a.days()
+	if err != nil {
+		return 0, err
+	}
+
+	// День удаляется целиком, когда срок хранения истек для последнего ответа этого дня
+	cutoff := now.UTC().Add(-a.retention).AddDate(0, 0, -1).Format(dayLayout)
+	removed := 0
+	for _, day := range days {
+		if day > cutoff {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(a.dir, day)); err != nil {
+			return removed, fmt.Errorf("не удалось удалить ответы за %s: %w", day, err)
+		}
+		removed++
+	}
+
+	return removed, nil
+}
+
+// days возвращает отсортированный список дней, за которые в архиве есть ответы
+func (a *Archive) days() ([]string, error) {
+	entries, err := os.ReadDir(a.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("не удалось прочитать каталог архива: %w", err)
+	}
+
+	var days []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		if _, err := time.Parse(dayLayout, entry.Name()); err == nil {
+			days = append(days, entry.Name())
+		}
+	}
+	sort.Strings(days)
+
+	return days, nil
+}
+
+// readRecord читает сохраненный ответ из файла
+func readRecord(path string) (Record, error) {
+	var rec Record
+
+	file, err := os.Open(path)
+	if err != nil {
+		return rec, err
+	}
+	defer file.Close()
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return rec, fmt.Errorf("поврежден файл архива %s: %w", filepath.Base(path), err)
+	}
+	defer gz.Close()
+
+	if err := json.NewDecoder(gz).Decode(&rec); err != nil {
+		return rec, fmt.Errorf("поврежден файл архива %s: %w", filepath.Base(path), err)
+	}
+
+	return rec, nil
+}
+
+// redactURL убирает из URL параметры с ключами доступа, чтобы они не попадали в архив
+func redactURL(rawURL string) string {
+	if i := strings.IndexByte(rawURL, '?'); i >= 0 {
+		base, query := rawURL[:i], rawURL[i+1:]
+		var kept []string
+		for _, param := range strings.Split(query, "&") {
+			name := strings.ToLower(strings.SplitN(param, "=", 2)[0])
+			if name == "apikey" || name == "api_key" || name == "token" {
+				continue
+			}
+			kept = append(kept, param)
+		}
+		if len(kept) == 0 {
+			return base
+		}
+		return base + "?" + strings.Join(kept, "&")
+	}
+	return rawURL
+}
@@ -0,0 +1,77 @@
+package rawarchive
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Transport записывает в архив тела успешных ответов на GET-запросы.
+// Ответ сохраняется при закрытии тела, если оно было прочитано полностью
+type Transport struct {
+	Base    http.RoundTripper
+	Archive *Archive
+}
+
+// RoundTrip выполняет запрос базовым транспортом и подключает запись ответа в архив
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	fetchedAt := time.Now()
+	resp, err := base.RoundTrip(req)
+	if err != nil || t.Archive == nil || req.Method != http.MethodGet ||
+		resp.StatusCode != http.StatusOK || resp.Body == nil {
+		return resp, err
+	}
+
+	resp.Body = &archivedBody{
+		ReadCloser: resp.Body,
+		archive:    t.Archive,
+		rec: Record{
+			URL:       redactURL(req.URL.String()),
+			FetchedAt: fetchedAt,
+			Status:    resp.StatusCode,
+		},
+	}
+	return resp, nil
+}
+
+// archivedBody копирует прочитанные данные и сохраняет их при закрытии тела ответа
+type archivedBody struct {
+	io.ReadCloser
+	archive *Archive
+	rec     Record
+	buf     bytes.Buffer
+	eof     bool
+	once    sync.Once
+}
+
+// Read читает тело ответа, сохраняя копию данных
+func (b *archivedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.buf.Write(p[:n])
+	if err == io.EOF {
+		b.eof = true
+	}
+	return n, err
+}
+
+// Close закрывает тело ответа и записывает полностью прочитанный ответ в архив
+func (b *archivedBody) Close() error {
+	b.once.Do(func() {
+		if !b.eof {
+			return
+		}
+		b.rec.Body = b.buf.Bytes()
+		if err := b.archive.Store(b.rec); err != nil {
+			log.Printf("Не удалось сохранить ответ %s в архив: %v", b.rec.URL, err)
+		}
+	})
+	return b.ReadCloser.Close()
+}
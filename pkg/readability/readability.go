@@ -0,0 +1,229 @@
+// Package readability извлекает из HTML-страницы статьи ее заголовок и читаемый текст, отбрасывая меню,
+// подвалы, скрипты, рекламу и блоки ссылок. Страница разбирается HTML-парсером golang.org/x/net/html,
+// поэтому незакрытые теги, вложенные блоки и атрибуты в любых кавычках обрабатываются так же, как в браузере.
+// Текст статьи ищется в абзацах внутри <article> или основного блока страницы
+package readability
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+const (
+	minParagraphLen  = 40  // Более короткие абзацы — подписи, даты, кнопки
+	maxLinkDensity   = 0.5 // Абзацы, текст которых больше чем наполовину состоит из ссылок, — навигация
+	minFallbackLine  = 80  // Строка без разметки абзацев считается текстом статьи, если она не короче
+	minArticleLength = 200 // Блок <article> короче этого считается карточкой анонса, а не статьей
+)
+
+// Article заголовок и текст статьи
+type Article struct {
+	Title string
+	// Text абзацы статьи, разделенные пустой строкой
+	Text string
+}
+
+// boilerplate элементы, которые никогда не содержат текста статьи
+var boilerplate = map[atom.Atom]bool{
+	atom.Script: true, atom.Style: true, atom.Noscript: true, atom.Svg: true, atom.Iframe: true,
+	atom.Form: true, atom.Nav: true, atom.Header: true, atom.Footer: true, atom.Aside: true,
+	atom.Figure: true, atom.Button: true, atom.Select: true, atom.Template: true,
+}
+
+// paragraphs элементы, текст которых образует абзац статьи
+var paragraphs = map[atom.Atom]bool{
+	atom.P: true, atom.Blockquote: true, atom.H2: true, atom.H3: true,
+}
+
+// blocks элементы, границы которых разделяют строки текста
+var blocks = map[atom.Atom]bool{
+	atom.Br: true, atom.Div: true, atom.P: true, atom.Li: true, atom.Tr: true, atom.Section: true,
+	atom.H1: true, atom.H2: true, atom.H3: true, atom.H4: true, atom.H5: true, atom.H6: true,
+	atom.Blockquote: true, atom.Article: true,
+}
+
+// Extract извлекает статью из HTML-документа. Если текст не найден, Text пуст
+func Extract(document string) Article {
+	root, err := html.Parse(strings.NewReader(document))
+	if err != nil {
+		// Парсер HTML5 восстанавливает любую разметку, ошибку возвращает только чтение
+		return Article{}
+	}
+	article := Article{Title: title(root)}
+
+	main := root
+	if body := find(root, atom.Body); body != nil {
+		main = body
+	}
+	// Из нескольких <article> (статья и анонсы других материалов) выбираем самый длинный по тексту
+	best := 0
+	walk(root, func(node *html.Node) bool {
+		if node.DataAtom != atom.Article {
+			return true
+		}
+		if length := utf8.RuneCountInString(cleanText(textOf(node, false))); length > best && length >= minArticleLength {
+			main, best = node, length
+		}
+		return false
+	})
+
+	lines := extractParagraphs(main)
+	if len(lines) == 0 {
+		lines = extractLines(main)
+	}
+	article.Text = strings.Join(lines, "\n\n")
+	return article
+}
+
+// title возвращает заголовок статьи: og:title, иначе <title>, иначе первый <h1>
+func title(root *html.Node) string {
+	var value string
+	walk(root, func(node *html.Node) bool {
+		if node.DataAtom == atom.Meta && attr(node, "property") == "og:title" {
+			value = cleanText(attr(node, "content"))
+		}
+		return value == ""
+	})
+	if value != "" {
+		return value
+	}
+
+	for _, tag := range []atom.Atom{atom.Title, atom.H1} {
+		if node := find(root, tag); node != nil {
+			if value := cleanText(textOf(node, true)); value != "" {
+				return value
+			}
+		}
+	}
+	return ""
+}
+
+// extractParagraphs возвращает содержательные абзацы блока: достаточно длинные и не состоящие из ссылок.
+// Цитата, в которой есть свои абзацы, разбирается по ним
+func extractParagraphs(block *html.Node) []string {
+	var result []string
+	seen := make(map[string]bool)
+	walk(block, func(node *html.Node) bool {
+		if !paragraphs[node.DataAtom] || (node.DataAtom == atom.Blockquote && find(node, atom.P) != nil) {
+			return true
+		}
+
+		text := cleanText(textOf(node, false))
+		length := utf8.RuneCountInString(text)
+		if length < minParagraphLen || seen[text] {
+			return false
+		}
+
+		linked := 0
+		walk(node, func(link *html.Node) bool {
+			if link.DataAtom != atom.A {
+				return true
+			}
+			linked += utf8.RuneCountInString(cleanText(textOf(link, false)))
+			return false
+		})
+		if float64(linked)/float64(length) > maxLinkDensity {
+			return false
+		}
+
+		result = append(result, text)
+		seen[text] = true
+		return false
+	})
+	return result
+}
+
+// extractLines разбирает блок без абзацев <p>: текст делится по блочным элементам и переводам строк,
+// и остаются только длинные строки
+func extractLines(block *html.Node) []string {
+	var lines []string
+	for _, line := range strings.Split(textOf(block, true), "\n") {
+		if text := cleanText(line); utf8.RuneCountInString(text) >= minFallbackLine {
+			lines = append(lines, text)
+		}
+	}
+	return lines
+}
+
+// textOf собирает текст элемента без служебных блоков. При lineBreaks границы блочных элементов
+// становятся переводами строк, иначе пробелами
+func textOf(node *html.Node, lineBreaks bool) string {
+	separator := " "
+	if lineBreaks {
+		separator = "\n"
+	}
+
+	var b strings.Builder
+	var collect func(*html.Node)
+	collect = func(node *html.Node) {
+		switch {
+		case node.Type == html.TextNode:
+			b.WriteString(node.Data)
+			return
+		case node.Type == html.ElementNode && boilerplate[node.DataAtom]:
+			return
+		}
+
+		block := node.Type == html.ElementNode && blocks[node.DataAtom]
+		if block {
+			b.WriteString(separator)
+		}
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			collect(child)
+		}
+		if block {
+			b.WriteString(separator)
+		}
+	}
+	collect(node)
+	return b.String()
+}
+
+// walk обходит дерево в глубину, пропуская служебные блоки. Если visit возвращает false,
+// потомки элемента не обходятся
+func walk(node *html.Node, visit func(*html.Node) bool) {
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type != html.ElementNode {
+			walk(child, visit)
+			continue
+		}
+		if boilerplate[child.DataAtom] {
+			continue
+		}
+		if visit(child) {
+			walk(child, visit)
+		}
+	}
+}
+
+// find возвращает первый элемент tag в дереве или nil
+func find(root *html.Node, tag atom.Atom) *html.Node {
+	var found *html.Node
+	walk(root, func(node *html.Node) bool {
+		if found == nil && node.DataAtom == tag {
+			found = node
+		}
+		return found == nil
+	})
+	return found
+}
+
+// attr возвращает значение атрибута элемента или пустую строку
+func attr(node *html.Node, name string) string {
+	for _, a := range node.Attr {
+		if strings.EqualFold(a.Key, name) {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+// cleanText схлопывает пробелы; сущности HTML парсер уже раскрыл
+func cleanText(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	// Пробелы, оставшиеся на месте блочных элементов перед знаками препинания
+	return strings.NewReplacer(" ,", ",", " .", ".", " :", ":", " ;", ";", " !", "!", " ?", "?").Replace(text)
+}
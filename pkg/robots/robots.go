@@ -0,0 +1,121 @@
+// Package robots разбирает robots.txt и проверяет, разрешено ли агенту загружать страницу (RFC 9309)
+package robots
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+)
+
+// Rules правила robots.txt, действующие для одного агента
+type Rules struct {
+	rules []rule
+}
+
+type rule struct {
+	allow   bool
+	pattern string
+}
+
+// AllowAll правила, разрешающие все страницы: так трактуется отсутствующий robots.txt
+var AllowAll = &Rules{}
+
+// Parse выбирает из robots.txt группы правил агента agent (по совпадению имени продукта без учета регистра),
+// а если таких нет — группы для «*»
+func Parse(data []byte, agent string) *Rules {
+	agent = strings.ToLower(agent)
+	if product, _, ok := strings.Cut(agent, "/"); ok {
+		agent = product
+	}
+
+	var own, common []rule
+	var groupAgents []string
+	hasOwn := false  // Группа агента без правил тоже отменяет группу «*»
+	inRules := false // Строки User-agent после правил начинают новую группу
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "user-agent":
+			if inRules {
+				groupAgents, inRules = nil, false
+			}
+			name := strings.ToLower(value)
+			groupAgents = append(groupAgents, name)
+			if name != "" && name != "*" && strings.Contains(agent, name) {
+				hasOwn = true
+			}
+		case "allow", "disallow":
+			inRules = true
+			// Пустой Disallow ничего не запрещает
+			if value == "" {
+				continue
+			}
+			r := rule{allow: key == "allow", pattern: value}
+			for _, name := range groupAgents {
+				switch {
+				case name == "*":
+					common = append(common, r)
+				case name != "" && strings.Contains(agent, name):
+					own = append(own, r)
+				}
+			}
+		}
+	}
+
+	if hasOwn {
+		return &Rules{rules: own}
+	}
+	return &Rules{rules: common}
+}
+
+// Allowed сообщает, разрешено ли загружать путь path (вместе со строкой запроса). Действует правило
+// с самым длинным шаблоном, при равной длине — разрешающее
+func (r *Rules) Allowed(path string) bool {
+	if path == "" {
+		path = "/"
+	}
+
+	allowed, longest := true, -1
+	for _, rule := range r.rules {
+		if !match(rule.pattern, path) {
+			continue
+		}
+		if length := len(rule.pattern); length > longest || (length == longest && rule.allow) {
+			allowed, longest = rule.allow, length
+		}
+	}
+	return allowed
+}
+
+// match сопоставляет путь с шаблоном: * — любая последовательность символов, $ в конце — конец пути
+func match(pattern, path string) bool {
+	anchored := strings.HasSuffix(pattern, "$")
+	pattern = strings.TrimSuffix(pattern, "$")
+
+	parts := strings.Split(pattern, "*")
+	if !strings.HasPrefix(path, parts[0]) {
+		return false
+	}
+	rest := path[len(parts[0]):]
+	for i, part := range parts[1:] {
+		// Последний фрагмент шаблона с $ должен совпасть с концом пути
+		if anchored && i == len(parts)-2 {
+			return strings.HasSuffix(rest, part)
+		}
+		idx := strings.Index(rest, part)
+		if idx < 0 {
+			return false
+		}
+		rest = rest[idx+len(part):]
+	}
+	return !anchored || rest == ""
+}
@@ -0,0 +1,221 @@
+// Package s3 загружает объекты в S3-совместимое хранилище (AWS S3, MinIO, Yandex Object Storage) и выдает
+// на них подписанные ссылки. Запросы подписываются AWS Signature Version 4 без внешних SDK: серверу нужны
+// только PutObject и ссылка на скачивание
+package s3
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	service   = "s3"
+	algorithm = "AWS4-HMAC-SHA256"
+	// maxPresignExpiry наибольший срок действия подписанной ссылки, допускаемый SigV4
+	maxPresignExpiry = 7 * 24 * time.Hour
+)
+
+// Client клиент одного бакета
+type Client struct {
+	Endpoint  string // Адрес хранилища, например https://s3.eu-central-1.amazonaws.com
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	// PathStyle адресует бакет путем (endpoint/bucket/key) вместо поддомена (bucket.endpoint/key); нужен MinIO
+	PathStyle bool
+
+	HTTPClient *http.Client
+}
+
+// PutObject загружает объект key с типом содержимого contentType
+func (c *Client) PutObject(ctx context.Context, key, contentType string, data []byte) error {
+	u, err := c.objectURL(key)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, u.String(), bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("не удалось создать запрос: %w", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	payloadHash := hashHex(data)
+	t := time.Now().UTC()
+	req.Header.Set("X-Amz-Date", t.Format("20060102T150405Z"))
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	signedHeaders, canonicalHeaders := canonicalHeaders(req, u.Host)
+	canonicalRequest := strings.Join([]string{
+		http.MethodPut,
+		u.EscapedPath(),
+		"",
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	scope := c.scope(t)
+	signature := c.sign(t, stringToSign(t, scope, canonicalRequest))
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		algorithm, c.AccessKey, scope, signedHeaders, signature))
+
+	client := c.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("ошибка загрузки объекта %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4<<10))
+		return fmt.Errorf("хранилище вернуло ошибку %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// PresignGet возвращает ссылку на скачивание объекта key, действующую expires (не больше 7 дней)
+func (c *Client) PresignGet(key string, expires time.Duration) (string, error) {
+	u, err := c.objectURL(key)
+	if err != nil {
+		return "", err
+	}
+	if expires <= 0 || expires > maxPresignExpiry {
+		expires = maxPresignExpiry
+	}
+
+	t := time.Now().UTC()
+	scope := c.scope(t)
+	query := url.Values{}
+	query.Set("X-Amz-Algorithm", algorithm)
+	query.Set("X-Amz-Credential", c.AccessKey+"/"+scope)
+	query.Set("X-Amz-Date", t.Format("20060102T150405Z"))
+	query.Set("X-Amz-Expires", fmt.Sprint(int(expires.Seconds())))
+	query.Set("X-Amz-SignedHeaders", "host")
+
+	canonicalRequest := strings.Join([]string{
+		http.MethodGet,
+		u.EscapedPath(),
+		canonicalQuery(query),
+		"host:" + u.Host + "\n",
+		"host",
+		"UNSIGNED-PAYLOAD",
+	}, "\n")
+	query.Set("X-Amz-Signature", c.sign(t, stringToSign(t, scope, canonicalRequest)))
+
+	u.RawQuery = canonicalQuery(query)
+	return u.String(), nil
+}
+
+// objectURL возвращает адрес объекта с учетом способа адресации бакета
+func (c *Client) objectURL(key string) (*url.URL, error) {
+	u, err := url.Parse(c.Endpoint)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("некорректный адрес хранилища %q", c.Endpoint)
+	}
+	key = strings.TrimPrefix(key, "/")
+	if c.PathStyle {
+		u.Path = strings.TrimSuffix(u.Path, "/") + "/" + c.Bucket + "/" + key
+	} else {
+		u.Host = c.Bucket + "." + u.Host
+		u.Path = strings.TrimSuffix(u.Path, "/") + "/" + key
+	}
+	// SigV4 требует кодировать каждый сегмент пути, кроме разделителей
+	segments := strings.Split(u.Path, "/")
+	for i, segment := range segments {
+		segments[i] = escape(segment)
+	}
+	u.RawPath = strings.Join(segments, "/")
+	return u, nil
+}
+
+// scope область действия ключа подписи: дата/регион/сервис/aws4_request
+func (c *Client) scope(t time.Time) string {
+	return strings.Join([]string{t.Format("20060102"), c.Region, service, "aws4_request"}, "/")
+}
+
+// sign вычисляет подпись строки ключом, производным от секретного ключа, даты и региона
+func (c *Client) sign(t time.Time, value string) string {
+	key := hmacSHA256([]byte("AWS4"+c.SecretKey), t.Format("20060102"))
+	key = hmacSHA256(key, c.Region)
+	key = hmacSHA256(key, service)
+	key = hmacSHA256(key, "aws4_request")
+	return hex.EncodeToString(hmacSHA256(key, value))
+}
+
+func stringToSign(t time.Time, scope, canonicalRequest string) string {
+	return strings.Join([]string{algorithm, t.Format("20060102T150405Z"), scope, hashHex([]byte(canonicalRequest))}, "\n")
+}
+
+// canonicalHeaders возвращает список подписываемых заголовков и их каноническую запись
+func canonicalHeaders(req *http.Request, host string) (string, string) {
+	headers := map[string]string{"host": host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonical strings.Builder
+	for _, name := range names {
+		canonical.WriteString(name + ":" + headers[name] + "\n")
+	}
+	return strings.Join(names, ";"), canonical.String()
+}
+
+// canonicalQuery кодирует параметры запроса по правилам SigV4: сортировка по имени и %20 вместо +
+func canonicalQuery(values url.Values) string {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pairs := make([]string, 0, len(names))
+	for _, name := range names {
+		for _, value := range values[name] {
+			pairs = append(pairs, escape(name)+"="+escape(value))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// escape кодирует строку по RFC 3986: без изменений остаются только буквы, цифры и -._~
+func escape(s string) string {
+	var b strings.Builder
+	for _, c := range []byte(s) {
+		if ('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z') || ('0' <= c && c <= '9') || strings.IndexByte("-._~", c) >= 0 {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func hashHex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, value string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(value))
+	return mac.Sum(nil)
+}
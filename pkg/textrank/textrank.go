@@ -0,0 +1,197 @@
+// Package textrank составляет извлекающее резюме текста алгоритмом TextRank: предложения связываются
+// по общим словам, ранжируются как страницы в PageRank, и в резюме попадают самые «центральные» из них
+package textrank
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	damping    = 0.85 // Коэффициент затухания PageRank
+	iterations = 50   // Предел итераций; обычно ранги сходятся раньше
+	tolerance  = 1e-4 // Изменение рангов, при котором итерации прекращаются
+	minWordLen = 3    // Более короткие слова (предлоги, союзы) не учитываются при сравнении предложений
+)
+
+// stopWords частые слова, которые связывают почти любые предложения и поэтому не говорят об их сходстве
+var stopWords = map[string]bool{
+	"это": true, "что": true, "как": true, "для": true, "при": true, "его": true, "она": true, "они": true,
+	"так": true, "также": true, "уже": true, "еще": true, "или": true, "был": true, "была": true, "были": true,
+	"будет": true, "может": true, "этом": true, "этого": true, "который": true, "которые": true, "году": true,
+	"the": true, "and": true, "for": true, "that": true, "with": true, "this": true, "from": true, "are": true,
+	"was": true, "were": true, "has": true, "have": true, "will": true, "its": true,
+}
+
+// Summarize возвращает не больше n самых значимых предложений текста в исходном порядке.
+// Если предложений не больше n, текст возвращается целиком (без лишних пробелов)
+func Summarize(text string, n int) string {
+	sentences := Sentences(text)
+	if n <= 0 || len(sentences) <= n {
+		return strings.Join(sentences, " ")
+	}
+
+	words := make([]map[string]bool, len(sentences))
+	for i, sentence := range sentences {
+		words[i] = sentenceWords(sentence)
+	}
+
+	// Вес связи двух предложений — число общих слов, нормированное на их длину, чтобы длинные
+	// предложения не выигрывали только за счет размера
+	weights := make([][]float64, len(sentences))
+	totals := make([]float64, len(sentences))
+	for i := range sentences {
+		weights[i] = make([]float64, len(sentences))
+	}
+	for i := range sentences {
+		for j := i + 1; j < len(sentences); j++ {
+			w := similarity(words[i], words[j])
+			weights[i][j], weights[j][i] = w, w
+			totals[i] += w
+			totals[j] += w
+		}
+	}
+
+	ranks := rank(weights, totals)
+
+	order := make([]int, len(sentences))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return ranks[order[a]] > ranks[order[b]]
+	})
+	chosen := order[:n]
+	sort.Ints(chosen)
+
+	summary := make([]string, 0, n)
+	for _, i := range chosen {
+		summary = append(summary, sentences[i])
+	}
+	return strings.Join(summary, " ")
+}
+
+// rank вычисляет ранги предложений по взвешенному графу сходства
+func rank(weights [][]float64, totals []float64) []float64 {
+	count := len(weights)
+	ranks := make([]float64, count)
+	for i := range ranks {
+		ranks[i] = 1
+	}
+
+	next := make([]float64, count)
+	for iter := 0; iter < iterations; iter++ {
+		delta := 0.0
+		for i := range next {
+			sum := 0.0
+			for j := range weights {
+				if weights[j][i] > 0 && totals[j] > 0 {
+					sum += weights[j][i] / totals[j] * ranks[j]
+				}
+			}
+			next[i] = 1 - damping + damping*sum
+			delta = math.Max(delta, math.Abs(next[i]-ranks[i]))
+		}
+		ranks, next = next, ranks
+		if delta < tolerance {
+			break
+		}
+	}
+	return ranks
+}
+
+// similarity сходство предложений по формуле TextRank: общие слова / (ln|a| + ln|b|)
+func similarity(a, b map[string]bool) float64 {
+	if len(a) < 2 || len(b) < 2 {
+		return 0
+	}
+	common := 0
+	for word := range a {
+		if b[word] {
+			common++
+		}
+	}
+	if common == 0 {
+		return 0
+	}
+	return float64(common) / (math.Log(float64(len(a))) + math.Log(float64(len(b))))
+}
+
+// sentenceWords возвращает значимые слова предложения в нижнем регистре. Слова обрезаются до шести букв —
+// грубая замена стемминга, чтобы «акции» и «акций» считались одним словом
+func sentenceWords(sentence string) map[string]bool {
+	words := make(map[string]bool)
+	for _, word := range strings.FieldsFunc(strings.ToLower(sentence), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if utf8.RuneCountInString(word) < minWordLen || stopWords[word] {
+			continue
+		}
+		if runes := []rune(word); len(runes) > 6 {
+			word = string(runes[:6])
+		}
+		words[word] = true
+	}
+	return words
+}
+
+// Sentences разбивает текст на предложения. Предложение заканчивается знаком . ! ? или …, за которым
+// следуют пробел и заглавная буква или цифра, либо переводом строки. Точка после одиночной буквы
+// (инициалы, «т.е.») концом предложения не считается
+func Sentences(text string) []string {
+	runes := []rune(text)
+	var sentences []string
+	start := 0
+	flush := func(end int) {
+		if sentence := strings.Join(strings.Fields(string(runes[start:end])), " "); sentence != "" {
+			sentences = append(sentences, sentence)
+		}
+		start = end
+	}
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if r == '\n' {
+			flush(i + 1)
+			continue
+		}
+		if !strings.ContainsRune(".!?…", r) {
+			continue
+		}
+
+		// Несколько знаков подряд («?!», «...») и закрывающие кавычки и скобки относятся к предложению
+		end := i + 1
+		for end < len(runes) && strings.ContainsRune(".!?…»\")", runes[end]) {
+			end++
+		}
+		if end < len(runes) && !unicode.IsSpace(runes[end]) {
+			i = end - 1
+			continue
+		}
+		next := end
+		for next < len(runes) && runes[next] == ' ' {
+			next++
+		}
+		if next < len(runes) && runes[next] != '\n' && !unicode.IsUpper(runes[next]) && !unicode.IsDigit(runes[next]) &&
+			!strings.ContainsRune("«\"(—–-", runes[next]) {
+			i = end - 1
+			continue
+		}
+		if r == '.' && initialBefore(runes, i) {
+			i = end - 1
+			continue
+		}
+		flush(end)
+		i = end - 1
+	}
+	flush(len(runes))
+	return sentences
+}
+
+// initialBefore сообщает, что перед точкой в позиции i стоит одиночная буква — инициал или сокращение
+func initialBefore(runes []rune, i int) bool {
+	return i >= 1 && unicode.IsLetter(runes[i-1]) && (i == 1 || !unicode.IsLetter(runes[i-2]))
+}
@@ -0,0 +1,166 @@
+package timing
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Stage обозначает этап обработки запроса, время которого учитывается отдельно
+type Stage string
+
+const (
+	StageCache      Stage = "cache"
+	StageDB         Stage = "db"
+	StageUpstream   Stage = "upstream"
+	StageFormatting Stage = "formatting"
+)
+
+// Stages перечисляет этапы в порядке вывода
+var Stages = []Stage{StageCache, StageDB, StageUpstream, StageFormatting}
+
+type recorderKey struct{}
+
+// Recorder накапливает время и число обращений по этапам в рамках одного вызова
+type Recorder struct {
+	mu        sync.Mutex
+	durations map[Stage]time.Duration
+	counts    map[Stage]int
+}
+
+// WithRecorder возвращает контекст с новым накопителем времени
+func WithRecorder(ctx context.Context) (context.Context, *Recorder) {
+	rec := &Recorder{
+		durations: make(map[Stage]time.Duration),
+		counts:    make(map[Stage]int),
+	}
+	return context.WithValue(ctx, recorderKey{}, rec), rec
+}
+
+// FromContext возвращает накопитель из контекста или nil, если отладка выключена
+func FromContext(ctx context.Context) *Recorder {
+	if ctx == nil {
+		return nil
+	}
+	rec, _ := ctx.Value(recorderKey{}).(*Recorder)
+	return rec
+}
+
+// Add учитывает длительность этапа; без накопителя в контексте ничего не делает
+func Add(ctx context.Context, stage Stage, d time.Duration) {
+	if rec := FromContext(ctx); rec != nil {
+		rec.Add(stage, d)
+	}
+}
+
+// Track начинает замер этапа и возвращает функцию его завершения
+func Track(ctx context.Context, stage Stage) func() {
+	rec := FromContext(ctx)
+	if rec == nil {
+		return func() {}
+	}
+	start := time.Now()
+	return func() {
+		rec.Add(stage, time.Since(start))
+	}
+}
+
+// Add учитывает длительность этапа
+func (r *Recorder) Add(stage Stage, d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.durations[stage] += d
+	r.counts[stage]++
+}
+
+// Duration возвращает суммарное время этапа
+func (r *Recorder) Duration(stage Stage) time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.durations[stage]
+}
+
+// Breakdown возвращает разбивку в миллисекундах вместе с числом обращений к каждому этапу.
+// Этап formatting вычисляется как остаток от total за вычетом кэша, БД и внешних API:
+// при параллельных запросах сумма этапов может превышать total, тогда остаток равен нулю.
+func (r *Recorder) Breakdown(total time.Duration) map[string]interface{} {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var measured time.Duration
+	for stage, d := range r.durations {
+		if stage != StageFormatting {
+			measured += d
+		}
+	}
+	formatting := r.durations[StageFormatting]
+	if formatting == 0 && total > measured {
+		formatting = total - measured
+	}
+
+	result := map[string]interface{}{
+		"total_ms": milliseconds(total),
+	}
+	for _, stage := range Stages {
+		d := r.durations[stage]
+		if stage == StageFormatting {
+			d = formatting
+		}
+		result[string(stage)+"_ms"] = milliseconds(d)
+		if stage != StageFormatting {
+			result[string(stage)+"_calls"] = r.counts[stage]
+		}
+	}
+	return result
+}
+
+// milliseconds переводит длительность в миллисекунды с точностью до сотых
+func milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()/10) / 100
+}
+
+// Transport учитывает время HTTP-запросов к внешним API как этап upstream.
+// Замер длится до закрытия тела ответа, чтобы включить чтение данных
+type Transport struct {
+	Base http.RoundTripper
+}
+
+// RoundTrip выполняет запрос базовым транспортом и замеряет его длительность
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	rec := FromContext(req.Context())
+	if rec == nil {
+		return base.RoundTrip(req)
+	}
+
+	start := time.Now()
+	resp, err := base.RoundTrip(req)
+	if err != nil || resp.Body == nil {
+		rec.Add(StageUpstream, time.Since(start))
+		return resp, err
+	}
+	resp.Body = &timedBody{ReadCloser: resp.Body, rec: rec, start: start}
+	return resp, nil
+}
+
+// timedBody фиксирует длительность запроса при закрытии тела ответа
+type timedBody struct {
+	io.ReadCloser
+	rec   *Recorder
+	start time.Time
+	once  sync.Once
+}
+
+// Close закрывает тело ответа и учитывает время запроса
+func (b *timedBody) Close() error {
+	b.once.Do(func() {
+		b.rec.Add(StageUpstream, time.Since(b.start))
+	})
+	return b.ReadCloser.Close()
+}
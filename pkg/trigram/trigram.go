@@ -0,0 +1,101 @@
+package trigram
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Index индекс триграмм для нечеткого поиска по коротким текстам (названиям, тикерам).
+// Текст разбивается на слова, каждое слово дополняется пробелами по краям («  сбер »), как в pg_trgm,
+// поэтому совпадение начала слова весит больше, чем совпадение середины
+type Index struct {
+	postings map[string][]int // Триграмма → номера текстов, в которых она встречается
+	sizes    []int            // Число различных триграмм каждого текста
+}
+
+// Match найденный текст и степень его сходства с запросом
+type Match struct {
+	Doc int // Номер текста в порядке добавления
+	// Score доля триграмм запроса, найденных в тексте, от 0 до 1
+	Score float64
+	// Similarity сходство запроса и текста целиком (коэффициент Жаккара), чтобы при равном Score
+	// короткий текст, близкий к запросу, шел раньше длинного
+	Similarity float64
+}
+
+// New строит индекс по текстам; номер текста в результатах поиска — его индекс в texts
+func New(texts []string) *Index {
+	idx := &Index{
+		postings: make(map[string][]int),
+		sizes:    make([]int, len(texts)),
+	}
+	for doc, text := range texts {
+		grams := Trigrams(text)
+		idx.sizes[doc] = len(grams)
+		for gram := range grams {
+			idx.postings[gram] = append(idx.postings[gram], doc)
+		}
+	}
+	return idx
+}
+
+// Search возвращает тексты, содержащие не меньше minScore триграмм запроса, по убыванию Score и Similarity
+func (idx *Index) Search(query string, minScore float64) []Match {
+	grams := Trigrams(query)
+	if len(grams) == 0 {
+		return nil
+	}
+
+	common := make(map[int]int)
+	for gram := range grams {
+		for _, doc := range idx.postings[gram] {
+			common[doc]++
+		}
+	}
+
+	matches := make([]Match, 0, len(common))
+	for doc, count := range common {
+		score := float64(count) / float64(len(grams))
+		if score < minScore {
+			continue
+		}
+		matches = append(matches, Match{
+			Doc:        doc,
+			Score:      score,
+			Similarity: float64(count) / float64(len(grams)+idx.sizes[doc]-count),
+		})
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		if matches[i].Similarity != matches[j].Similarity {
+			return matches[i].Similarity > matches[j].Similarity
+		}
+		return matches[i].Doc < matches[j].Doc
+	})
+
+	return matches
+}
+
+// Trigrams возвращает множество триграмм текста: слова приводятся к нижнему регистру, «ё» — к «е»
+func Trigrams(text string) map[string]bool {
+	grams := make(map[string]bool)
+	for _, word := range Words(text) {
+		runes := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(runes); i++ {
+			grams[string(runes[i:i+3])] = true
+		}
+	}
+	return grams
+}
+
+// Words разбивает текст на слова в нижнем регистре без знаков препинания; «ё» заменяется на «е»
+func Words(text string) []string {
+	text = strings.ReplaceAll(strings.ToLower(text), "ё", "е")
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
@@ -0,0 +1,75 @@
+package workpool
+
+import (
+	"context"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// Pool ограничивает число одновременных запросов к источникам данных. Один пул разделяется
+// всеми репозиториями и клиентами API, поэтому параллельные пакетные запросы вместе
+// не превышают лимит. Nil-пул выполняет задачи последовательно
+type Pool struct {
+	sem chan struct{}
+}
+
+// New создает пул не более чем на limit одновременных задач; limit меньше 1 считается равным 1
+func New(limit int) *Pool {
+	return &Pool{sem: make(chan struct{}, max(limit, 1))}
+}
+
+// Limit возвращает число одновременных задач пула
+func (p *Pool) Limit() int {
+	if p == nil {
+		return 1
+	}
+	return cap(p.sem)
+}
+
+// Run выполняет fn для индексов от 0 до n-1 параллельно в пределах лимита пула.
+// Первая ошибка отменяет контекст остальных задач и возвращается вызывающему.
+// fn не должна запускать задачи в том же пуле: вложенное ожидание слота может заблокировать пул
+func (p *Pool) Run(ctx context.Context, n int, fn func(ctx context.Context, i int) error) error {
+	if p == nil || n <= 1 {
+		for i := 0; i < n; i++ {
+			if err := fn(ctx, i); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(cap(p.sem))
+	for i := 0; i < n; i++ {
+		g.Go(func() error {
+			select {
+			case p.sem <- struct{}{}:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			defer func() { <-p.sem }()
+
+			return fn(ctx, i)
+		})
+	}
+	return g.Wait()
+}
+
+// Map применяет fn к каждому элементу items в пуле и возвращает результаты в порядке items.
+// При ошибке возвращается первая из них
+func Map[T, R any](ctx context.Context, p *Pool, items []T, fn func(ctx context.Context, item T) (R, error)) ([]R, error) {
+	results := make([]R, len(items))
+	err := p.Run(ctx, len(items), func(ctx context.Context, i int) error {
+		result, err := fn(ctx, items[i])
+		if err != nil {
+			return err
+		}
+		results[i] = result
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}